@@ -494,6 +494,21 @@ func (mr *MockDCGMMockRecorder) NewDefaultGroup(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewDefaultGroup", reflect.TypeOf((*MockDCGM)(nil).NewDefaultGroup), arg0)
 }
 
+// RunDiag mocks base method.
+func (m *MockDCGM) RunDiag(diagType dcgm.DiagType, groupID dcgm.GroupHandle) (dcgm.DiagResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunDiag", diagType, groupID)
+	ret0, _ := ret[0].(dcgm.DiagResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunDiag indicates an expected call of RunDiag.
+func (mr *MockDCGMMockRecorder) RunDiag(diagType, groupID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunDiag", reflect.TypeOf((*MockDCGM)(nil).RunDiag), diagType, groupID)
+}
+
 // UnwatchFields mocks base method.
 func (m *MockDCGM) UnwatchFields(arg0 dcgm.FieldHandle, arg1 dcgm.GroupHandle) error {
 	m.ctrl.T.Helper()
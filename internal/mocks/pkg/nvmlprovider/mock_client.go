@@ -24,6 +24,7 @@
 package nvmlprovider
 
 import (
+	context "context"
 	reflect "reflect"
 
 	nvmlprovider "github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
@@ -125,3 +126,166 @@ func (mr *MockNVMLMockRecorder) GetMIGDeviceInfoByID(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMIGDeviceInfoByID", reflect.TypeOf((*MockNVML)(nil).GetMIGDeviceInfoByID), arg0)
 }
+
+// GetProcessUtilization mocks base method.
+func (m *MockNVML) GetProcessUtilization(gpuUUID string, lastSeenTimeStamp uint64) ([]nvmlprovider.ProcessUtilization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProcessUtilization", gpuUUID, lastSeenTimeStamp)
+	ret0, _ := ret[0].([]nvmlprovider.ProcessUtilization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProcessUtilization indicates an expected call of GetProcessUtilization.
+func (mr *MockNVMLMockRecorder) GetProcessUtilization(gpuUUID, lastSeenTimeStamp any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProcessUtilization", reflect.TypeOf((*MockNVML)(nil).GetProcessUtilization), gpuUUID, lastSeenTimeStamp)
+}
+
+// EnableAccountingMode mocks base method.
+func (m *MockNVML) EnableAccountingMode(gpuUUID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableAccountingMode", gpuUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableAccountingMode indicates an expected call of EnableAccountingMode.
+func (mr *MockNVMLMockRecorder) EnableAccountingMode(gpuUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableAccountingMode", reflect.TypeOf((*MockNVML)(nil).EnableAccountingMode), gpuUUID)
+}
+
+// GetAccountingStats mocks base method.
+func (m *MockNVML) GetAccountingStats(gpuUUID string) ([]nvmlprovider.AccountingStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountingStats", gpuUUID)
+	ret0, _ := ret[0].([]nvmlprovider.AccountingStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountingStats indicates an expected call of GetAccountingStats.
+func (mr *MockNVMLMockRecorder) GetAccountingStats(gpuUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountingStats", reflect.TypeOf((*MockNVML)(nil).GetAccountingStats), gpuUUID)
+}
+
+// GetNvLinkRemote mocks base method.
+func (m *MockNVML) GetNvLinkRemote(gpuUUID string, link uint) (nvmlprovider.NvLinkRemote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNvLinkRemote", gpuUUID, link)
+	ret0, _ := ret[0].(nvmlprovider.NvLinkRemote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNvLinkRemote indicates an expected call of GetNvLinkRemote.
+func (mr *MockNVMLMockRecorder) GetNvLinkRemote(gpuUUID, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNvLinkRemote", reflect.TypeOf((*MockNVML)(nil).GetNvLinkRemote), gpuUUID, link)
+}
+
+// GetDevices mocks base method.
+func (m *MockNVML) GetDevices() ([]nvmlprovider.GPUDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDevices")
+	ret0, _ := ret[0].([]nvmlprovider.GPUDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDevices indicates an expected call of GetDevices.
+func (mr *MockNVMLMockRecorder) GetDevices() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDevices", reflect.TypeOf((*MockNVML)(nil).GetDevices))
+}
+
+// GetDeviceStatus mocks base method.
+func (m *MockNVML) GetDeviceStatus(gpuUUID string) (nvmlprovider.DeviceStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeviceStatus", gpuUUID)
+	ret0, _ := ret[0].(nvmlprovider.DeviceStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeviceStatus indicates an expected call of GetDeviceStatus.
+func (mr *MockNVMLMockRecorder) GetDeviceStatus(gpuUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeviceStatus", reflect.TypeOf((*MockNVML)(nil).GetDeviceStatus), gpuUUID)
+}
+
+// GetMPSClients mocks base method.
+func (m *MockNVML) GetMPSClients(gpuUUID string) ([]uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMPSClients", gpuUUID)
+	ret0, _ := ret[0].([]uint32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMPSClients indicates an expected call of GetMPSClients.
+func (mr *MockNVMLMockRecorder) GetMPSClients(gpuUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMPSClients", reflect.TypeOf((*MockNVML)(nil).GetMPSClients), gpuUUID)
+}
+
+// GetComputeMode mocks base method.
+func (m *MockNVML) GetComputeMode(gpuUUID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComputeMode", gpuUUID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComputeMode indicates an expected call of GetComputeMode.
+func (mr *MockNVMLMockRecorder) GetComputeMode(gpuUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComputeMode", reflect.TypeOf((*MockNVML)(nil).GetComputeMode), gpuUUID)
+}
+
+// GetMIGCapacity mocks base method.
+func (m *MockNVML) GetMIGCapacity(gpuUUID string) (nvmlprovider.MIGCapacity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMIGCapacity", gpuUUID)
+	ret0, _ := ret[0].(nvmlprovider.MIGCapacity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMIGCapacity indicates an expected call of GetMIGCapacity.
+func (mr *MockNVMLMockRecorder) GetMIGCapacity(gpuUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMIGCapacity", reflect.TypeOf((*MockNVML)(nil).GetMIGCapacity), gpuUUID)
+}
+
+// GetMIGLayout mocks base method.
+func (m *MockNVML) GetMIGLayout(gpuUUID string) (nvmlprovider.MIGLayout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMIGLayout", gpuUUID)
+	ret0, _ := ret[0].(nvmlprovider.MIGLayout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMIGLayout indicates an expected call of GetMIGLayout.
+func (mr *MockNVMLMockRecorder) GetMIGLayout(gpuUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMIGLayout", reflect.TypeOf((*MockNVML)(nil).GetMIGLayout), gpuUUID)
+}
+
+// WatchXIDEvents mocks base method.
+func (m *MockNVML) WatchXIDEvents(ctx context.Context, onEvent func(nvmlprovider.XIDEvent)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchXIDEvents", ctx, onEvent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchXIDEvents indicates an expected call of WatchXIDEvents.
+func (mr *MockNVMLMockRecorder) WatchXIDEvents(ctx, onEvent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchXIDEvents", reflect.TypeOf((*MockNVML)(nil).WatchXIDEvents), ctx, onEvent)
+}
@@ -20,7 +20,37 @@ const (
 	GPUUID     KubernetesGPUIDType = "uid"
 	DeviceName KubernetesGPUIDType = "device-name"
 
+	GPUInstanceIDFormatIndex GPUInstanceIDFormat = "index-gi" // <gpu index>-<gpu instance id>
+	GPUInstanceIDFormatUUID  GPUInstanceIDFormat = "uuid/gi"  // <gpu uuid>/<gpu instance id>
+
+	IPFamilyAny  IPFamily = "any"  // Whatever the address resolves to; dual-stack for a wildcard address
+	IPFamilyIPv4 IPFamily = "ipv4" // IPv4 only
+	IPFamilyIPv6 IPFamily = "ipv6" // IPv6 only
+	IPFamilyDual IPFamily = "dual" // Separate IPv4 and IPv6 sockets, both are required
+
+	DCGMModuleProfiling DCGMModule = "profiling" // DCP metrics (DCGM_FI_PROF_*)
+	DCGMModuleHealth    DCGMModule = "health"    // DCGM_EXP_GPU_HEALTH_STATUS
+	DCGMModulePolicy    DCGMModule = "policy"    // Never used by the exporter
+	DCGMModuleNvSwitch  DCGMModule = "nvswitch"  // NvSwitch and NvLink entities
+
+	OTLPProtocolGRPC OTLPProtocol = "grpc"          // OTLP/gRPC
+	OTLPProtocolHTTP OTLPProtocol = "http/protobuf" // OTLP/HTTP with protobuf payloads
+
+	DerivedCounterModeDelta DerivedCounterMode = "delta" // Increase over the last collect interval
+	DerivedCounterModeRate  DerivedCounterMode = "rate"  // Per-second increase over the last collect interval
+
+	NodeLabelModeLabels NodeLabelMode = "labels" // Added to every metric
+	NodeLabelModeInfo   NodeLabelMode = "info"   // Exported by the dcgm_exporter_node_info gauge
+
+	FieldIDModeLabel FieldIDMode = "label" // Added to every metric
+	FieldIDModeInfo  FieldIDMode = "info"  // Exported by the dcgm_exporter_field_info gauge
+
+	DuplicatePolicyDRA     DuplicatePolicy = "dra"     // The series of the DRA mapper is kept
+	DuplicatePolicySharing DuplicatePolicy = "sharing" // The series of the sharing mapper is kept
+	DuplicatePolicyMerge   DuplicatePolicy = "merge"   // The attributes of both series are merged, the DRA ones first
+
 	NvidiaResourceName      = "nvidia.com/gpu"
 	NvidiaMigResourcePrefix = "nvidia.com/mig-"
-	MIG_UUID_PREFIX         = "MIG-"
+
+	redactedValue = "REDACTED"
 )
@@ -17,6 +17,8 @@
 package appconfig
 
 import (
+	"net/url"
+	"slices"
 	"time"
 
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
@@ -24,10 +26,77 @@ import (
 
 type KubernetesGPUIDType string
 
+// DCGMModule is a DCGM module, which the exporter loads on its first use
+type DCGMModule string
+
+// DCGMModules lists the DCGM modules, which loading can be controlled by the exporter
+var DCGMModules = []DCGMModule{DCGMModuleProfiling, DCGMModuleHealth, DCGMModulePolicy, DCGMModuleNvSwitch}
+
+// IPFamily restricts the IP family the HTTP server listens on
+type IPFamily string
+
+// IPFamilies lists the supported values of the IP family the HTTP server listens on
+var IPFamilies = []IPFamily{IPFamilyAny, IPFamilyIPv4, IPFamilyIPv6, IPFamilyDual}
+
+// NodeLabelMode is how the labels of the Kubernetes node are exported
+type NodeLabelMode string
+
+// NodeLabelModes lists the supported ways of exporting the labels of the Kubernetes node
+var NodeLabelModes = []NodeLabelMode{NodeLabelModeLabels, NodeLabelModeInfo}
+
+// FieldIDMode is how the DCGM field IDs of the families are exported
+type FieldIDMode string
+
+// FieldIDModes lists the supported ways of exporting the DCGM field IDs of the families
+var FieldIDModes = []FieldIDMode{FieldIDModeLabel, FieldIDModeInfo}
+
+// DuplicatePolicy is how the series the sharing and DRA pod mappers both emit for a container are resolved
+type DuplicatePolicy string
+
+// DuplicatePolicies lists the supported ways of resolving the series mapped to a container by both pod mappers
+var DuplicatePolicies = []DuplicatePolicy{DuplicatePolicyDRA, DuplicatePolicySharing, DuplicatePolicyMerge}
+
+// OTLPProtocol is the protocol metrics are pushed with to an OTLP collector
+type OTLPProtocol string
+
+// OTLPProtocols lists the supported protocols of the OTLP export
+var OTLPProtocols = []OTLPProtocol{OTLPProtocolGRPC, OTLPProtocolHTTP}
+
+// RemoteWriteEndpoint is a Prometheus remote write endpoint metrics are pushed to
+type RemoteWriteEndpoint struct {
+	URL        string
+	Namespaces []string // Only the series of the pods in these namespaces are pushed; empty pushes all the series
+}
+
+// MetricNameMigration renames a metric family. During the transition window, the family is also exported
+// under its old name, so that dashboards and alerts can be migrated.
+type MetricNameMigration struct {
+	OldName string
+	NewName string
+	Until   time.Time // End of the transition window; zero exports both names until the migration is removed
+}
+
+// DerivedCounterMode is the value derived from a field between two collect intervals
+type DerivedCounterMode string
+
+// DerivedCounter derives a <FIELD>_DELTA or <FIELD>_RATE gauge from a cumulative field
+type DerivedCounter struct {
+	FieldName string
+	Mode      DerivedCounterMode
+}
+
+// GPUInstanceIDFormat defines how a GPU instance (MIG device) is identified when metrics are joined with pods
+type GPUInstanceIDFormat string
+
+// GPUInstanceIDFormats lists the supported GPU instance identifier formats
+var GPUInstanceIDFormats = []GPUInstanceIDFormat{GPUInstanceIDFormatIndex, GPUInstanceIDFormatUUID}
+
 type DeviceOptions struct {
 	Flex       bool  // If true, then monitor all GPUs if MIG mode is disabled or all GPU instances if MIG is enabled.
 	MajorRange []int // The indices of each GPU/NvSwitch to monitor, or -1 to monitor all
 	MinorRange []int // The indices of each GPUInstance/NvLink to monitor, or -1 to monitor all
+	// If true, then also monitor the compute instances of each monitored GPU instance.
+	ComputeInstances bool
 }
 
 // DumpConfig controls file-based debugging dumps
@@ -45,13 +114,18 @@ type Config struct {
 	Kubernetes                       bool
 	KubernetesEnablePodLabels        bool
 	KubernetesEnablePodUID           bool
+	KubernetesWorkloadLabels         bool // Add the kind and the name of the workload owning the pods
 	KubernetesGPUIdType              KubernetesGPUIDType
 	KubernetesPodLabelAllowlistRegex []string // Regex patterns for filtering pod labels
 	KubernetesPodLabelCacheSize      int      // Maximum number of label keys to cache (<=0 means default size)
+	KubernetesPodAnnotations         []string // Regex patterns of the pod annotations added as labels; empty adds none
 	CollectDCP                       bool
 	UseOldNamespace                  bool
 	UseRemoteHE                      bool
-	RemoteHEInfo                     string
+	RemoteHEInfo                     string        // Remote hostengine connected to, one of RemoteHEEndpoints
+	RemoteHEEndpoints                []string      // Remote hostengines in their order of preference for the failover
+	RemoteHECheckInterval            time.Duration // Interval between the checks of the connection to the remote hostengine
+	ProbeEnabled                     bool          // Serve the metrics of remote hostengines on /probe?target=<host>:<port>
 	GPUDeviceOptions                 DeviceOptions
 	SwitchDeviceOptions              DeviceOptions
 	CPUDeviceOptions                 DeviceOptions
@@ -62,18 +136,115 @@ type Config struct {
 	WebSystemdSocket                 bool
 	WebConfigFile                    string
 	XIDCountWindowSize               int
+	XIDCorrelation                   bool // Attach the clock events, temperature and power to the XID errors
 	ReplaceBlanksInModelName         bool
 	Debug                            bool
 	ClockEventsCountWindowSize       int
+	NVLinkErrorsCountWindowSize      int
 	EnableDCGMLog                    bool
 	DCGMLogLevel                     string
 	PodResourcesKubeletSocket        string
+	KubeletCheckpointFile            string // Kubelet device plugin checkpoint read when the pod-resources socket fails; empty disables it
 	HPCJobMappingDir                 string
 	NvidiaResourceNames              []string
 	KubernetesVirtualGPUs            bool
 	DumpConfig                       DumpConfig // Configuration for file-based dumps
 	KubernetesEnableDRA              bool
+	KubernetesDuplicatePolicy        DuplicatePolicy // How the series mapped to a container by both the sharing and DRA mappers are resolved
 	DisableStartupValidate           bool
 	EnableGPUBindUnbindWatch         bool          // Enable GPU bind/unbind event monitoring
 	GPUBindUnbindPollInterval        time.Duration // Poll interval for GPU bind/unbind events
+	EnableMIGWatch                   bool          // Enable MIG configuration change monitoring
+	MIGWatchPollInterval             time.Duration // Poll interval for MIG configuration changes
+	CPUOnly                          bool          // Collect the CPU and CPU core metrics only, on nodes without GPUs
+	NVMLOnly                         bool          // Serve the basic GPU metrics from NVML, without DCGM
+	GPUInstanceIDFormat              GPUInstanceIDFormat
+	EnableCounterDeltas              bool // Derive <FIELD>_DELTA gauges from counter fields
+	IPFamily                         IPFamily
+	DCGMModules                      []DCGMModule  // DCGM modules the exporter may load; nil means all
+	SplitMIGMetrics                  bool          // Export MIG instance metrics as <FIELD>_MIG families
+	MIGInstanceMemory                bool          // Export the framebuffer and BAR1 usage of the GPU instances, even if the counters don't list them
+	KubernetesSkipInactivePods       bool          // Don't map devices to terminating, terminated or unschedulable pods
+	PodNamespaceAllowlist            []string      // Namespaces, or glob patterns, of the pods devices are mapped to; nil means all
+	PodNamespaceDenylist             []string      // Namespaces, or glob patterns, of the pods devices are never mapped to
+	NodeLabels                       []string      // Labels of the Kubernetes node exported; empty disables the node informer
+	NodeLabelsMode                   NodeLabelMode // Whether the node labels are added to the metrics or to an info gauge
+	InstanceFQDNLabel                bool          // Add the instance_fqdn label with the FQDN of the host
+	FieldIDMode                      FieldIDMode   // Whether the DCGM field IDs are added to the metrics or to an info gauge; empty disables them
+	GPUSlotsFile                     string        // File persisting the UUID to stable slot map used as gpu label
+	CollectorInventoryMetric         bool          // Export the dcgm_exporter_collectors inventory metric
+	ExpositionHashMetric             bool          // Export the dcgm_exporter_exposition_hash shape hash metric
+	FamilySamplesMetric              bool          // Export the dcgm_exporter_samples_per_family metric of the last gather
+	EventLogSize                     int           // Number of events kept in the log of /api/v1/events; 0 disables it
+	JournalEvents                    bool          // Write the error events to the systemd journal
+	TelemetryMetrics                 bool          // Export the dcgm_exporter_* metrics about the exporter itself
+	DeviceLabelsInfo                 bool          // Export the label counters once per GPU in dcgm_exp_device_labels_info
+	CollectorTimeout                 time.Duration // Longest collection of a collector on a scrape; 0 waits indefinitely
+	CollectorBreakerThreshold        int           // Consecutive failed collections skipping a collector; 0 disables it
+	CollectorBreakerCycles           int           // Scrapes skipping a collector once its circuit breaker opened
+	MetricsCacheInterval             time.Duration // Interval of the background gather served by /metrics; 0 gathers on scrape
+	MaxStaleness                     time.Duration // Age of the background gather beyond which /metrics returns 503
+	OpenMetrics                      bool          // Serve /metrics in the OpenMetrics format when negotiated
+	DevicePauseAPI                   bool          // Serve the endpoints pausing the collection of a GPU
+	MetricsCompression               bool          // Compress the /metrics responses as negotiated with the client
+	MaintenanceAPI                   bool          // Serve the endpoint starting maintenance windows of the node
+	MaintenanceMaxDuration           time.Duration // Maximum duration of a maintenance window; 0 means unbounded
+	MaintenanceDropFamilies          []string      // Families dropped during a maintenance window
+	StreamingRender                  bool          // Stream the metrics of an entity type once its collectors are done
+	MIGFragmentationMetrics          bool          // Derive the free slices and fragmentation of the MIG enabled GPUs from NVML
+	OTLPEndpoint                     string        // URL of the OTLP collector metrics are pushed to; empty disables the push
+	OTLPProtocol                     OTLPProtocol
+	OTLPInterval                     time.Duration
+	SuppressIdleMetrics              []string // Families dropped for GPUs without processes and with zero utilization
+	CanaryCollectorsFile             string   // Candidate counters file compared with CollectorsFile by /canary
+	CollectOnScrape                  bool     // Update the DCGM fields on every scrape
+	CollectOnScrapeMinInterval       time.Duration
+	DiagLevel                        int                   // Level of the DCGM diagnostics, 0 disables them
+	DiagInterval                     time.Duration         // Interval of the DCGM diagnostics runs, 0 runs them on demand only
+	RemoteWriteEndpoints             []RemoteWriteEndpoint // Prometheus remote write endpoints metrics are pushed to
+	RemoteWriteInterval              time.Duration
+	MetricNameMigrations             []MetricNameMigration
+	DualNamespace                    bool     // Export the GPU series in both the old and the current namespace
+	DualNamespaceFamilies            []string // Families exported in both namespaces; nil means all
+	MemoryWatermark                  uint64   // RSS in bytes above which optional features are shed, 0 disables it
+	PushQueueSize                    int      // Items queued per push endpoint before the oldest are dropped
+	PushBatchSize                    int      // Maximal number of items of a push request
+	PushFlushInterval                time.Duration
+	DerivedCounters                  []DerivedCounter
+	ExtraLabels                      map[string]string // Static labels added to every metric, e.g. cluster or region
+	RelabelConfigFile                string            // YAML file of the relabel rules; empty disables relabeling
+	ParquetDirectory                 string            // Directory of the Parquet files; empty disables the sink
+	ParquetInterval                  time.Duration
+	ParquetRotationInterval          time.Duration
+	ParquetRetention                 int // Number of completed Parquet files kept, 0 keeps all of them
+}
+
+// IsDCGMModuleEnabled reports whether the exporter may use the DCGM module
+func (c *Config) IsDCGMModuleEnabled(module DCGMModule) bool {
+	return c.DCGMModules == nil || slices.Contains(c.DCGMModules, module)
+}
+
+// Redacted returns a copy of the configuration that is safe to log and expose:
+// the credentials of the URLs are masked.
+func (c *Config) Redacted() Config {
+	redacted := *c
+	redacted.OTLPEndpoint = RedactURL(c.OTLPEndpoint)
+	if c.RemoteWriteEndpoints != nil {
+		redacted.RemoteWriteEndpoints = make([]RemoteWriteEndpoint, len(c.RemoteWriteEndpoints))
+		for i, e := range c.RemoteWriteEndpoints {
+			e.URL = RedactURL(e.URL)
+			redacted.RemoteWriteEndpoints[i] = e
+		}
+	}
+	return redacted
+}
+
+// RedactURL masks the credentials of a URL
+func RedactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	u.User = url.User(redactedValue)
+	return u.String()
 }
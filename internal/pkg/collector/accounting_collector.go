@@ -0,0 +1,174 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/memguard"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+)
+
+// accountingRetention is how long a terminated process is still exported, so that it is scraped at least once
+// even with long scrape intervals
+const accountingRetention = 5 * time.Minute
+
+// accountingValues maps the accounting counters to their value in the NVML accounting statistics
+var accountingValues = map[string]func(nvmlprovider.AccountingStats) int{
+	counters.DCGMExpAccountingGPUUtil:   func(s nvmlprovider.AccountingStats) int { return int(s.GPUUtil) },
+	counters.DCGMExpAccountingMemUtil:   func(s nvmlprovider.AccountingStats) int { return int(s.MemUtil) },
+	counters.DCGMExpAccountingMaxMemory: func(s nvmlprovider.AccountingStats) int { return int(s.MaxMemoryUsage) },
+}
+
+// IsDCGMExpAccountingEnabled checks if any of the DCGM_EXP_ACCOUNTING_* counters exists
+func IsDCGMExpAccountingEnabled(counterList counters.CounterList) bool {
+	return slices.ContainsFunc(counterList, func(c counters.Counter) bool {
+		_, exists := accountingValues[c.FieldName]
+		return exists
+	})
+}
+
+// accountingCollector exports the statistics NVML accumulates per process while the accounting mode is enabled.
+// Unlike the utilization samples, the statistics outlive the processes, so the processes that start and exit
+// between two scrapes are still accounted for: a terminated process is exported for accountingRetention after
+// it exited.
+type accountingCollector struct {
+	baseExpCollector
+	counters []counters.Counter
+
+	now func() time.Time
+}
+
+func NewAccountingCollector(
+	counterList counters.CounterList,
+	hostname string,
+	config *appconfig.Config,
+	deviceWatchList devicewatchlistmanager.WatchList,
+) (Collector, error) {
+	if !IsDCGMExpAccountingEnabled(counterList) {
+		slog.Error(accountingCollectorName + " collector is disabled")
+		return nil, errors.New(accountingCollectorName + " collector is disabled")
+	}
+
+	// NVML is only initialized in Kubernetes mode, the statistics aren't available through DCGM
+	if err := nvmlprovider.Initialize(); err != nil {
+		return nil, fmt.Errorf("failed to initialize NVML: %w", err)
+	}
+
+	var accountingCounters []counters.Counter
+	for _, c := range counterList {
+		if _, exists := accountingValues[c.FieldName]; exists {
+			accountingCounters = append(accountingCounters, c)
+		}
+	}
+
+	// Enabling the accounting mode requires root, without it the statistics are only available
+	// on the GPUs where the administrator enabled it
+	for _, mi := range devicemonitoring.GetMonitoredEntities(deviceWatchList.DeviceInfo()) {
+		if mi.InstanceInfo != nil {
+			continue
+		}
+		if err := nvmlprovider.Client().EnableAccountingMode(mi.DeviceInfo.UUID); err != nil {
+			slog.Warn("Failed to enable accounting mode",
+				slog.String("gpuUUID", mi.DeviceInfo.UUID),
+				slog.String("error", err.Error()))
+		}
+	}
+
+	return &accountingCollector{
+		baseExpCollector: baseExpCollector{
+			labelsCounters:  counterList.LabelCounters(),
+			hostname:        hostname,
+			config:          config,
+			deviceWatchList: deviceWatchList,
+		},
+		counters: accountingCounters,
+		now:      time.Now,
+	}, nil
+}
+
+func (c *accountingCollector) GetMetrics() (MetricsByCounter, error) {
+	if memguard.IsShed(memguard.FeatureProcessMetrics) {
+		return MetricsByCounter{}, nil
+	}
+
+	uuid := "UUID"
+	if c.config.UseOldNamespace {
+		uuid = "uuid"
+	}
+
+	metrics := MetricsByCounter{}
+	labels := map[string]string{}
+	now := c.now()
+
+	for _, mi := range devicemonitoring.GetMonitoredEntities(c.deviceWatchList.DeviceInfo()) {
+		// NVML accounts the processes of the physical GPUs only
+		if mi.InstanceInfo != nil {
+			continue
+		}
+
+		gpuUUID := mi.DeviceInfo.UUID
+		stats, err := nvmlprovider.Client().GetAccountingStats(gpuUUID)
+		if err != nil {
+			slog.Debug("Failed to get accounting stats", "gpuUUID", gpuUUID, "error", err)
+			continue
+		}
+		if len(stats) == 0 {
+			continue
+		}
+
+		if len(c.labelsCounters) > 0 && len(c.deviceWatchList.LabelDeviceFields()) > 0 {
+			err := c.getLabelsFromCounters(mi, labels)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, s := range stats {
+			if !s.IsRunning && now.Sub(accountingEndTime(s)) > accountingRetention {
+				continue
+			}
+
+			metricValueLabels := maps.Clone(labels)
+			metricValueLabels[pidLabel] = fmt.Sprint(s.PID)
+			metricValueLabels[runningLabel] = strconv.FormatBool(s.IsRunning)
+
+			for _, counter := range c.counters {
+				m := c.createMetric(metricValueLabels, mi, uuid, accountingValues[counter.FieldName](s))
+				m.Counter = counter
+				metrics[counter] = append(metrics[counter], m)
+			}
+		}
+	}
+
+	return metrics, nil
+}
+
+// accountingEndTime returns when a terminated process exited
+func accountingEndTime(s nvmlprovider.AccountingStats) time.Time {
+	return time.UnixMicro(int64(s.StartTime)).Add(time.Duration(s.Time) * time.Millisecond)
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocknvml "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/nvmlprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/testutils"
+)
+
+func TestIsDCGMExpAccountingEnabled(t *testing.T) {
+	assert.False(t, IsDCGMExpAccountingEnabled(counters.CounterList{
+		{FieldName: counters.DCGMExpProcessSMUtil},
+	}))
+	assert.True(t, IsDCGMExpAccountingEnabled(counters.CounterList{
+		{FieldName: counters.DCGMExpProcessSMUtil},
+		{FieldName: counters.DCGMExpAccountingMaxMemory},
+	}))
+}
+
+func Test_accountingCollector_GetMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockNVML := mocknvml.NewMockNVML(ctrl)
+
+	realNVML := nvmlprovider.Client()
+	defer func() {
+		nvmlprovider.SetClient(realNVML)
+	}()
+	nvmlprovider.SetClient(mockNVML)
+
+	gpuUtil := counters.Counter{FieldName: counters.DCGMExpAccountingGPUUtil, PromType: "gauge"}
+	maxMemory := counters.Counter{FieldName: counters.DCGMExpAccountingMaxMemory, PromType: "gauge"}
+
+	mockGPUDeviceInfo := testutils.MockGPUDeviceInfo(ctrl, 1, nil)
+	mockGPUDeviceInfo.EXPECT().GOpts().Return(appconfig.DeviceOptions{Flex: true}).AnyTimes()
+
+	// Enabling the accounting mode requires root, the collector works without it
+	mockNVML.EXPECT().EnableAccountingMode("").Return(errors.New("Insufficient Permissions"))
+
+	deviceWatchList := devicewatchlistmanager.NewWatchList(mockGPUDeviceInfo, nil, nil, nil, 1)
+	collector, err := NewAccountingCollector(counters.CounterList{gpuUtil, maxMemory}, "localhost",
+		&appconfig.Config{}, *deviceWatchList)
+	require.NoError(t, err)
+
+	now := time.Now()
+	collector.(*accountingCollector).now = func() time.Time { return now }
+
+	mockNVML.EXPECT().GetAccountingStats("").Return([]nvmlprovider.AccountingStats{
+		{PID: 1001, GPUUtil: 80, MaxMemoryUsage: 1 << 30, IsRunning: true},
+		// Exited a minute ago
+		{
+			PID: 1002, GPUUtil: 40, MaxMemoryUsage: 1 << 20,
+			StartTime: uint64(now.Add(-2 * time.Minute).UnixMicro()), Time: uint64(time.Minute.Milliseconds()),
+		},
+		// Exited an hour ago
+		{
+			PID: 1003, GPUUtil: 10, MaxMemoryUsage: 1 << 10,
+			StartTime: uint64(now.Add(-2 * time.Hour).UnixMicro()), Time: uint64(time.Hour.Milliseconds()),
+		},
+	}, nil)
+
+	metrics, err := collector.GetMetrics()
+	require.NoError(t, err)
+	require.Len(t, metrics, 2, "Only the enabled counters should be exported")
+
+	got := map[string]string{}
+	for counter, metricList := range metrics {
+		for _, m := range metricList {
+			assert.Equal(t, counter, m.Counter)
+			assert.Equal(t, "0", m.GPU)
+			got[counter.FieldName+"/"+m.Labels[pidLabel]+"/"+m.Labels[runningLabel]] = m.Value
+		}
+	}
+	assert.Equal(t, map[string]string{
+		counters.DCGMExpAccountingGPUUtil + "/1001/true":    "80",
+		counters.DCGMExpAccountingGPUUtil + "/1002/false":   "40",
+		counters.DCGMExpAccountingMaxMemory + "/1001/true":  "1073741824",
+		counters.DCGMExpAccountingMaxMemory + "/1002/false": "1048576",
+	}, got)
+}
@@ -18,6 +18,7 @@ package collector
 
 import (
 	"fmt"
+	"maps"
 
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
@@ -52,11 +53,17 @@ func (c *baseExpCollector) createMetric(
 		Hostname:     c.hostname,
 
 		Labels:     labels,
-		Attributes: map[string]string{},
+		Attributes: maps.Clone(c.counter.StaticLabels()),
+	}
+	if m.Attributes == nil {
+		m.Attributes = map[string]string{}
 	}
 	if mi.InstanceInfo != nil {
 		m.MigProfile = mi.InstanceInfo.ProfileName
 		m.GPUInstanceID = fmt.Sprintf("%d", mi.InstanceInfo.Info.NvmlInstanceId)
+		if ci := mi.ComputeInstance(); ci != nil {
+			m.ComputeInstanceID = fmt.Sprintf("%d", ci.InstanceInfo.NvmlComputeInstanceId)
+		}
 	} else {
 		m.MigProfile = ""
 		m.GPUInstanceID = ""
@@ -19,6 +19,7 @@ package collector
 import (
 	"fmt"
 	"log/slog"
+	"maps"
 	"slices"
 
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
@@ -82,6 +83,23 @@ func (enm clockEventBitmask) String() string {
 	return clockEventToString[enm]
 }
 
+// ClockEventReasons returns the names of the clock event reasons set in a DCGM_FI_DEV_CLOCKS_EVENT_REASONS bitmask
+func ClockEventReasons(value int64) []string {
+	var reasons []string
+	for reason, name := range clockEventToString {
+		if clockEventBitmask(value)&reason != 0 {
+			reasons = append(reasons, name)
+		}
+	}
+	slices.Sort(reasons)
+	return reasons
+}
+
+// ClockEventReasonNames returns the names of all the clock event reasons
+func ClockEventReasonNames() []string {
+	return slices.Sorted(maps.Values(clockEventToString))
+}
+
 func (c *clockEventsCollector) GetMetrics() (MetricsByCounter, error) {
 	return c.expCollector.getMetrics()
 }
@@ -64,6 +64,10 @@ func (cf *collectorFactory) NewCollectors() []EntityCollectorTuple {
 		dcgm.FE_LINK,
 		dcgm.FE_CPU,
 		dcgm.FE_CPU_CORE,
+		dcgm.FE_VGPU,
+	}
+	if cf.config.CPUOnly {
+		entityTypes = []dcgm.Field_Entity_Group{dcgm.FE_CPU, dcgm.FE_CPU_CORE}
 	}
 
 	for _, entityType := range entityTypes {
@@ -85,11 +89,18 @@ func (cf *collectorFactory) NewCollectors() []EntityCollectorTuple {
 				entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
 					entity:    entityType,
 					collector: dcgmCollector,
+					name:      DCGMCollectorName,
 				})
 			}
 		}
 	}
 
+	// The exporter collectors read GPU fields
+	if cf.config.CPUOnly {
+		slog.Info("The exporter collectors are skipped in CPU-only mode")
+		return entityCollectorTuples
+	}
+
 	if IsDCGMExpClockEventsCountEnabled(cf.counterSet.ExporterCounters) {
 		if newCollector, err := cf.enableExpCollector(counters.DCGMExpClockEventsCount); err != nil {
 			slog.Error(fmt.Sprintf("collector '%s' cannot be initialized; err: %v", counters.DCGMExpClockEventsCount, err))
@@ -98,6 +109,7 @@ func (cf *collectorFactory) NewCollectors() []EntityCollectorTuple {
 			entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
 				entity:    dcgm.FE_GPU,
 				collector: newCollector,
+				name:      counters.DCGMExpClockEventsCount,
 			})
 		}
 	}
@@ -110,11 +122,16 @@ func (cf *collectorFactory) NewCollectors() []EntityCollectorTuple {
 			entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
 				entity:    dcgm.FE_GPU,
 				collector: newCollector,
+				name:      counters.DCGMExpXIDErrorsCount,
 			})
 		}
 	}
 
-	if IsDCGMExpGPUHealthStatusEnabled(cf.counterSet.ExporterCounters) {
+	if IsDCGMExpGPUHealthStatusEnabled(cf.counterSet.ExporterCounters) &&
+		!cf.config.IsDCGMModuleEnabled(appconfig.DCGMModuleHealth) {
+		slog.Warn(fmt.Sprintf("collector '%s' is skipped; DCGM health module is disabled",
+			counters.DCGMExpGPUHealthStatus))
+	} else if IsDCGMExpGPUHealthStatusEnabled(cf.counterSet.ExporterCounters) {
 		if newCollector, err := cf.enableExpCollector(counters.DCGMExpGPUHealthStatus); err != nil {
 			slog.Error(fmt.Sprintf("collector '%s' cannot be initialized; err: %v", counters.DCGMExpGPUHealthStatus, err))
 			os.Exit(1)
@@ -122,6 +139,124 @@ func (cf *collectorFactory) NewCollectors() []EntityCollectorTuple {
 			entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
 				entity:    dcgm.FE_GPU,
 				collector: newCollector,
+				name:      counters.DCGMExpGPUHealthStatus,
+			})
+		}
+	}
+
+	if IsDCGMExpHealthStatusEnabled(cf.counterSet.ExporterCounters) &&
+		!cf.config.IsDCGMModuleEnabled(appconfig.DCGMModuleHealth) {
+		slog.Warn(fmt.Sprintf("collector '%s' is skipped; DCGM health module is disabled",
+			counters.DCGMExpHealthStatus))
+	} else if IsDCGMExpHealthStatusEnabled(cf.counterSet.ExporterCounters) {
+		if newCollector, err := cf.enableExpCollector(counters.DCGMExpHealthStatus); err != nil {
+			slog.Warn(fmt.Sprintf("collector '%s' is skipped; err: %v", counters.DCGMExpHealthStatus, err))
+		} else {
+			entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
+				entity:    dcgm.FE_GPU,
+				collector: newCollector,
+				name:      counters.DCGMExpHealthStatus,
+			})
+		}
+	}
+
+	if IsDCGMExpNVLinkErrorsCountEnabled(cf.counterSet.ExporterCounters) {
+		if newCollector, err := cf.enableExpCollector(counters.DCGMExpNVLinkErrorsCount); err != nil {
+			slog.Warn(fmt.Sprintf("collector '%s' is skipped; err: %v", counters.DCGMExpNVLinkErrorsCount, err))
+		} else {
+			entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
+				entity:    dcgm.FE_GPU,
+				collector: newCollector,
+				name:      counters.DCGMExpNVLinkErrorsCount,
+			})
+		}
+	}
+
+	if IsDCGMExpXIDErrorsTotalEnabled(cf.counterSet.ExporterCounters) {
+		if newCollector, err := cf.enableExpCollector(counters.DCGMExpXIDErrorsTotal); err != nil {
+			slog.Warn(fmt.Sprintf("collector '%s' is skipped; err: %v", counters.DCGMExpXIDErrorsTotal, err))
+		} else {
+			entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
+				entity:    dcgm.FE_GPU,
+				collector: newCollector,
+				name:      counters.DCGMExpXIDErrorsTotal,
+			})
+		}
+	}
+
+	if IsDCGMExpGPUDeviceInfoEnabled(cf.counterSet.ExporterCounters) {
+		if newCollector, err := cf.enableExpCollector(counters.DCGMExpGPUDeviceInfo); err != nil {
+			slog.Warn(fmt.Sprintf("collector '%s' is skipped; err: %v", counters.DCGMExpGPUDeviceInfo, err))
+		} else {
+			entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
+				entity:    dcgm.FE_GPU,
+				collector: newCollector,
+				name:      counters.DCGMExpGPUDeviceInfo,
+			})
+		}
+	}
+
+	if IsDCGMExpProcessUtilEnabled(cf.counterSet.ExporterCounters) {
+		if newCollector, err := cf.enableExpCollector(processUtilCollectorName); err != nil {
+			slog.Warn(fmt.Sprintf("collector '%s' is skipped; err: %v", processUtilCollectorName, err))
+		} else {
+			entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
+				entity:    dcgm.FE_GPU,
+				collector: newCollector,
+				name:      processUtilCollectorName,
+			})
+		}
+	}
+
+	if IsDCGMExpAccountingEnabled(cf.counterSet.ExporterCounters) {
+		if newCollector, err := cf.enableExpCollector(accountingCollectorName); err != nil {
+			// The statistics are optional, NVML may be unavailable, e.g. on a hot reload
+			slog.Warn(fmt.Sprintf("collector '%s' is skipped; err: %v", accountingCollectorName, err))
+		} else {
+			entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
+				entity:    dcgm.FE_GPU,
+				collector: newCollector,
+				name:      accountingCollectorName,
+			})
+		}
+	}
+
+	if IsDCGMExpMPSEnabled(cf.counterSet.ExporterCounters) {
+		if newCollector, err := cf.enableExpCollector(mpsCollectorName); err != nil {
+			slog.Warn(fmt.Sprintf("collector '%s' is skipped; err: %v", mpsCollectorName, err))
+		} else {
+			entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
+				entity:    dcgm.FE_GPU,
+				collector: newCollector,
+				name:      mpsCollectorName,
+			})
+		}
+	}
+
+	if IsDCGMExpGPUNeedsResetEnabled(cf.counterSet.ExporterCounters) {
+		if newCollector, err := cf.enableExpCollector(counters.DCGMExpGPUNeedsReset); err != nil {
+			slog.Warn(fmt.Sprintf("collector '%s' is skipped; err: %v", counters.DCGMExpGPUNeedsReset, err))
+		} else {
+			entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
+				entity:    dcgm.FE_GPU,
+				collector: newCollector,
+				name:      counters.DCGMExpGPUNeedsReset,
+			})
+		}
+	}
+
+	if IsDCGMExpNVSwitchPortStatusEnabled(cf.counterSet.ExporterCounters) &&
+		!cf.config.IsDCGMModuleEnabled(appconfig.DCGMModuleNvSwitch) {
+		slog.Warn(fmt.Sprintf("collector '%s' is skipped; DCGM nvswitch module is disabled",
+			counters.DCGMExpNVSwitchPortStatus))
+	} else if IsDCGMExpNVSwitchPortStatusEnabled(cf.counterSet.ExporterCounters) {
+		if newCollector, err := cf.enableExpCollector(counters.DCGMExpNVSwitchPortStatus); err != nil {
+			slog.Warn(fmt.Sprintf("collector '%s' is skipped; err: %v", counters.DCGMExpNVSwitchPortStatus, err))
+		} else {
+			entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
+				entity:    dcgm.FE_LINK,
+				collector: newCollector,
+				name:      counters.DCGMExpNVSwitchPortStatus,
 			})
 		}
 	}
@@ -137,6 +272,7 @@ func (cf *collectorFactory) NewCollectors() []EntityCollectorTuple {
 		entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
 			entity:    dcgm.FE_GPU,
 			collector: newCollector,
+			name:      counters.DCGMExpP2PStatus,
 		})
 	}
 
@@ -151,11 +287,27 @@ func (cf *collectorFactory) enableDCGMCollector(entityWatchList devicewatchlistm
 		return nil, err
 	}
 
+	if counter, ok := findFieldStalenessCounter(cf.counterSet.ExporterCounters); ok {
+		newCollector.fieldStaleness = &counter
+	}
+
+	if counter, ok := findFieldStatusCounter(cf.counterSet.ExporterCounters); ok {
+		newCollector.fieldStatus = &counter
+	}
+
+	if counter, ok := findEnergyTotalCounter(cf.counterSet.ExporterCounters); ok {
+		newCollector.energyTotal = &counter
+	}
+
 	return newCollector, nil
 }
 
 func (cf *collectorFactory) enableExpCollector(expCollectorName string) (Collector, error) {
 	entityType := dcgm.FE_GPU
+	// The NVSwitch ports are rendered with the NvLinks of the switches
+	if expCollectorName == counters.DCGMExpNVSwitchPortStatus {
+		entityType = dcgm.FE_LINK
+	}
 
 	item, exists := cf.deviceWatchListManager.EntityWatchList(entityType)
 	if !exists {
@@ -171,12 +323,39 @@ func (cf *collectorFactory) enableExpCollector(expCollectorName string) (Collect
 	case counters.DCGMExpXIDErrorsCount:
 		newCollector, err = NewXIDCollector(cf.counterSet.ExporterCounters, cf.hostname, cf.config,
 			item)
+	case counters.DCGMExpXIDErrorsTotal:
+		newCollector, err = NewXIDTotalCollector(cf.counterSet.ExporterCounters, cf.hostname, cf.config,
+			item)
+	case counters.DCGMExpNVLinkErrorsCount:
+		newCollector, err = NewNVLinkErrorsCollector(cf.counterSet.ExporterCounters, cf.hostname, cf.config,
+			item)
 	case counters.DCGMExpGPUHealthStatus:
 		newCollector, err = NewGPUHealthStatusCollector(cf.counterSet.ExporterCounters,
 			cf.hostname,
 			cf.config,
 			item,
 		)
+	case counters.DCGMExpHealthStatus:
+		newCollector, err = NewHealthWatchCollector(cf.counterSet.ExporterCounters, cf.hostname, cf.config,
+			item)
+	case counters.DCGMExpGPUDeviceInfo:
+		newCollector, err = NewGPUDeviceInfoCollector(cf.counterSet.ExporterCounters, cf.hostname, cf.config,
+			item)
+	case processUtilCollectorName:
+		newCollector, err = NewProcessUtilCollector(cf.counterSet.ExporterCounters, cf.hostname, cf.config,
+			item)
+	case accountingCollectorName:
+		newCollector, err = NewAccountingCollector(cf.counterSet.ExporterCounters, cf.hostname, cf.config,
+			item)
+	case mpsCollectorName:
+		newCollector, err = NewMPSCollector(cf.counterSet.ExporterCounters, cf.hostname, cf.config,
+			item)
+	case counters.DCGMExpGPUNeedsReset:
+		newCollector, err = NewGPUNeedsResetCollector(cf.counterSet.ExporterCounters, cf.hostname, cf.config,
+			item)
+	case counters.DCGMExpNVSwitchPortStatus:
+		newCollector, err = NewNVSwitchPortCollector(cf.counterSet.ExporterCounters, cf.hostname, cf.config,
+			item)
 	case counters.DCGMExpP2PStatus:
 		newCollector, err = NewP2PStatusCollector(cf.counterSet.ExporterCounters,
 			cf.hostname,
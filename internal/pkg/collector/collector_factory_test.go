@@ -519,6 +519,204 @@ func Test_collectorFactory_Register(t *testing.T) {
 			},
 			wantsPanic: true,
 		},
+		{
+			name: "DCGM_EXP_ACCOUNTING collector is skipped when it can not be initialized",
+			cs: &counters.CounterSet{
+				DCGMCounters: []counters.Counter{},
+				ExporterCounters: []counters.Counter{
+					{
+						FieldName: counters.DCGMExpAccountingGPUUtil,
+					},
+				},
+			},
+			getDeviceWatchListManager: func() devicewatchlistmanager.Manager {
+				mockDeviceWatchListManager := mockdevicewatchlistmanager.NewMockManager(ctrl)
+				mockDeviceWatchListManager.EXPECT().EntityWatchList(gomock.Any()).Return(devicewatchlistmanager.
+					WatchList{}, false).AnyTimes()
+				return mockDeviceWatchListManager
+			},
+			hostname: "testhost",
+			config:   &appconfig.Config{},
+			assert: func(t *testing.T, entityCollectorTuples []EntityCollectorTuple) {
+				require.Len(t, entityCollectorTuples, 0)
+			},
+		},
+		{
+			name: "DCGM_EXP_PROCESS_UTIL collector is skipped when it can not be initialized",
+			cs: &counters.CounterSet{
+				DCGMCounters: []counters.Counter{},
+				ExporterCounters: []counters.Counter{
+					{
+						FieldName: counters.DCGMExpProcessSMUtil,
+					},
+				},
+			},
+			getDeviceWatchListManager: func() devicewatchlistmanager.Manager {
+				mockDeviceWatchListManager := mockdevicewatchlistmanager.NewMockManager(ctrl)
+				mockDeviceWatchListManager.EXPECT().EntityWatchList(gomock.Any()).Return(devicewatchlistmanager.
+					WatchList{}, false).AnyTimes()
+				return mockDeviceWatchListManager
+			},
+			hostname: "testhost",
+			config:   &appconfig.Config{},
+			assert: func(t *testing.T, entityCollectorTuples []EntityCollectorTuple) {
+				require.Len(t, entityCollectorTuples, 0)
+			},
+		},
+		{
+			name: "DCGM_EXP_MPS collector is skipped when it can not be initialized",
+			cs: &counters.CounterSet{
+				DCGMCounters: []counters.Counter{},
+				ExporterCounters: []counters.Counter{
+					{
+						FieldName: counters.DCGMExpMPSDaemonUp,
+					},
+				},
+			},
+			getDeviceWatchListManager: func() devicewatchlistmanager.Manager {
+				mockDeviceWatchListManager := mockdevicewatchlistmanager.NewMockManager(ctrl)
+				mockDeviceWatchListManager.EXPECT().EntityWatchList(gomock.Any()).Return(devicewatchlistmanager.
+					WatchList{}, false).AnyTimes()
+				return mockDeviceWatchListManager
+			},
+			hostname: "testhost",
+			config:   &appconfig.Config{},
+			assert: func(t *testing.T, entityCollectorTuples []EntityCollectorTuple) {
+				require.Len(t, entityCollectorTuples, 0)
+			},
+		},
+		{
+			name: "DCGM_EXP_NVLINK_ERRORS_COUNT collector is skipped when it can not be initialized",
+			cs: &counters.CounterSet{
+				DCGMCounters: []counters.Counter{},
+				ExporterCounters: []counters.Counter{
+					{
+						FieldName: counters.DCGMExpNVLinkErrorsCount,
+					},
+				},
+			},
+			getDeviceWatchListManager: func() devicewatchlistmanager.Manager {
+				mockDeviceWatchListManager := mockdevicewatchlistmanager.NewMockManager(ctrl)
+				mockDeviceWatchListManager.EXPECT().EntityWatchList(gomock.Any()).Return(devicewatchlistmanager.
+					WatchList{}, false).AnyTimes()
+				return mockDeviceWatchListManager
+			},
+			hostname: "testhost",
+			config:   &appconfig.Config{},
+			assert: func(t *testing.T, entityCollectorTuples []EntityCollectorTuple) {
+				require.Len(t, entityCollectorTuples, 0)
+			},
+		},
+		{
+			name: "DCGM_EXP_HEALTH_STATUS collector is skipped when it can not be initialized",
+			cs: &counters.CounterSet{
+				DCGMCounters: []counters.Counter{},
+				ExporterCounters: []counters.Counter{
+					{
+						FieldName: counters.DCGMExpHealthStatus,
+					},
+				},
+			},
+			getDeviceWatchListManager: func() devicewatchlistmanager.Manager {
+				mockDeviceWatchListManager := mockdevicewatchlistmanager.NewMockManager(ctrl)
+				mockDeviceWatchListManager.EXPECT().EntityWatchList(gomock.Any()).Return(devicewatchlistmanager.
+					WatchList{}, false).AnyTimes()
+				return mockDeviceWatchListManager
+			},
+			hostname: "testhost",
+			config:   &appconfig.Config{},
+			assert: func(t *testing.T, entityCollectorTuples []EntityCollectorTuple) {
+				require.Len(t, entityCollectorTuples, 0)
+			},
+		},
+		{
+			name: "DCGM_EXP_GPU_DEVICE_INFO collector is skipped when it can not be initialized",
+			cs: &counters.CounterSet{
+				DCGMCounters: []counters.Counter{},
+				ExporterCounters: []counters.Counter{
+					{
+						FieldName: counters.DCGMExpGPUDeviceInfo,
+					},
+				},
+			},
+			getDeviceWatchListManager: func() devicewatchlistmanager.Manager {
+				mockDeviceWatchListManager := mockdevicewatchlistmanager.NewMockManager(ctrl)
+				mockDeviceWatchListManager.EXPECT().EntityWatchList(gomock.Any()).Return(devicewatchlistmanager.
+					WatchList{}, false).AnyTimes()
+				return mockDeviceWatchListManager
+			},
+			hostname: "testhost",
+			config:   &appconfig.Config{},
+			assert: func(t *testing.T, entityCollectorTuples []EntityCollectorTuple) {
+				require.Len(t, entityCollectorTuples, 0)
+			},
+		},
+		{
+			name: "DCGM_EXP_XID_ERRORS_TOTAL collector is skipped when it can not be initialized",
+			cs: &counters.CounterSet{
+				DCGMCounters: []counters.Counter{},
+				ExporterCounters: []counters.Counter{
+					{
+						FieldName: counters.DCGMExpXIDErrorsTotal,
+					},
+				},
+			},
+			getDeviceWatchListManager: func() devicewatchlistmanager.Manager {
+				mockDeviceWatchListManager := mockdevicewatchlistmanager.NewMockManager(ctrl)
+				mockDeviceWatchListManager.EXPECT().EntityWatchList(gomock.Any()).Return(devicewatchlistmanager.
+					WatchList{}, false).AnyTimes()
+				return mockDeviceWatchListManager
+			},
+			hostname: "testhost",
+			config:   &appconfig.Config{},
+			assert: func(t *testing.T, entityCollectorTuples []EntityCollectorTuple) {
+				require.Len(t, entityCollectorTuples, 0)
+			},
+		},
+		{
+			name: "DCGM_EXP_NVSWITCH_PORT_STATUS collector is skipped when it can not be initialized",
+			cs: &counters.CounterSet{
+				DCGMCounters: []counters.Counter{},
+				ExporterCounters: []counters.Counter{
+					{
+						FieldName: counters.DCGMExpNVSwitchPortStatus,
+					},
+				},
+			},
+			getDeviceWatchListManager: func() devicewatchlistmanager.Manager {
+				mockDeviceWatchListManager := mockdevicewatchlistmanager.NewMockManager(ctrl)
+				mockDeviceWatchListManager.EXPECT().EntityWatchList(gomock.Any()).Return(devicewatchlistmanager.
+					WatchList{}, false).AnyTimes()
+				return mockDeviceWatchListManager
+			},
+			hostname: "testhost",
+			config:   &appconfig.Config{},
+			assert: func(t *testing.T, entityCollectorTuples []EntityCollectorTuple) {
+				require.Len(t, entityCollectorTuples, 0)
+			},
+		},
+		{
+			name: "DCGM_EXP_GPU_NEEDS_RESET collector is skipped when it can not be initialized",
+			cs: &counters.CounterSet{
+				DCGMCounters: []counters.Counter{},
+				ExporterCounters: []counters.Counter{
+					{
+						FieldName: counters.DCGMExpGPUNeedsReset,
+					},
+				},
+			},
+			getDeviceWatchListManager: func() devicewatchlistmanager.Manager {
+				mockDeviceWatchListManager := mockdevicewatchlistmanager.NewMockManager(ctrl)
+				mockDeviceWatchListManager.EXPECT().EntityWatchList(gomock.Any()).Return(devicewatchlistmanager.
+					WatchList{}, false).AnyTimes()
+				return mockDeviceWatchListManager
+			},
+			hostname: "testhost",
+			config:   &appconfig.Config{},
+			assert: func(t *testing.T, entityCollectorTuples []EntityCollectorTuple) {
+				require.Len(t, entityCollectorTuples, 0)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
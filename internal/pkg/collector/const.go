@@ -19,6 +19,32 @@ package collector
 const (
 	windowSizeInMSLabel = "window_size_in_ms"
 
+	xidLabel = "xid"
+
+	// Attributes of the XID errors when XID correlation is enabled
+	xidClockEventsAttribute = "clock_events"
+	xidGPUTempAttribute     = "gpu_temp"
+	xidPowerUsageAttribute  = "power_usage"
+
+	linkIDLabel          = "link_id"
+	nvlinkErrorTypeLabel = "error_type"
+	peerTypeLabel        = "peer_type"
+	peerIDLabel          = "peer_id"
+
+	healthWatchLabel        = "health_watch"
+	healthErrorCodeLabel    = "health_error_code"
+	healthErrorMessageLabel = "health_error_message"
+
+	minorNumberLabel = "minor_number"
+	deviceNodeLabel  = "device_node"
+
+	pidLabel     = "pid"
+	runningLabel = "running"
+
+	vgpuUUIDLabel = "vgpu_uuid"
+	vmIDLabel     = "vm_id"
+	vmNameLabel   = "vm_name"
+
 	skipDCGMValue   = "SKIPPING DCGM VALUE"
 	FailedToConvert = "ERROR - FAILED TO CONVERT TO STRING"
 
@@ -32,4 +58,19 @@ const (
 
 	PeerGPULabel    = "peer_gpu"
 	LinkStatusLabel = "link_status"
+
+	// DCGMCollectorName is the name of the collector of the DCGM fields of an entity type
+	DCGMCollectorName = "DCGM"
+
+	// NVMLCollectorName is the name of the collector of the GPU fields served from NVML in the NVML-only mode
+	NVMLCollectorName = "NVML"
+
+	// processUtilCollectorName is the name of the collector of the DCGM_EXP_PROCESS_*_UTIL counters
+	processUtilCollectorName = "DCGM_EXP_PROCESS_UTIL"
+
+	// accountingCollectorName is the name of the collector of the DCGM_EXP_ACCOUNTING_* counters
+	accountingCollectorName = "DCGM_EXP_ACCOUNTING"
+
+	// mpsCollectorName is the name of the collector of the DCGM_EXP_MPS_* counters
+	mpsCollectorName = "DCGM_EXP_MPS"
 )
@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+)
+
+// energyTotalTTL is how long the energy of a GPU is remembered after it was last seen
+const energyTotalTTL = 10 * time.Minute
+
+// energyTotals keeps the energy of the GPUs across the reloads, which rebuild the collectors
+var energyTotals = newEnergyIntegrator()
+
+func IsDCGMExpEnergyTotalEnabled(counterList counters.CounterList) bool {
+	_, ok := findEnergyTotalCounter(counterList)
+	return ok
+}
+
+func findEnergyTotalCounter(counterList counters.CounterList) (counters.Counter, bool) {
+	idx := slices.IndexFunc(counterList, func(c counters.Counter) bool {
+		return c.FieldName == counters.DCGMExpEnergyTotal
+	})
+	if idx < 0 {
+		return counters.Counter{}, false
+	}
+	return counterList[idx], true
+}
+
+// energyIntegrator derives DCGM_EXP_ENERGY_JOULES_TOTAL by integrating DCGM_FI_DEV_POWER_USAGE over the
+// DCGM timestamps of the samples, with the trapezoidal rule. The GPUs without power samples fall back to
+// the increase of DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION, where the driver supports it. Only new samples add
+// energy, so the counter doesn't depend on how often it is scraped, and rate() gives the average power draw.
+type energyIntegrator struct {
+	mtx    sync.Mutex
+	series map[string]*energyState
+}
+
+type energyState struct {
+	power    float64   // Last power sample (in W)
+	powerTS  int64     // DCGM timestamp of the last power sample (in µs), zero if not sampled
+	energy   float64   // Last DCGM total energy sample (in mJ)
+	energyTS int64     // DCGM timestamp of the last total energy sample (in µs), zero if not sampled
+	total    float64   // Cumulative energy (in J)
+	seenAt   time.Time // Time the series was last observed, to forget the removed GPUs
+}
+
+func newEnergyIntegrator() *energyIntegrator {
+	return &energyIntegrator{series: map[string]*energyState{}}
+}
+
+// observe adds the energy consumed between the last samples and the current ones to the total of the series.
+// It returns false if the series has never had a power or total energy sample.
+func (e *energyIntegrator) observe(key string, values []dcgm.FieldValue_v1, now time.Time) (float64, bool) {
+	e.mtx.Lock()
+	defer e.mtx.Unlock()
+
+	for k, st := range e.series {
+		if now.Sub(st.seenAt) > energyTotalTTL {
+			delete(e.series, k)
+		}
+	}
+
+	power, powerTS := energySample(values, dcgm.DCGM_FI_DEV_POWER_USAGE)
+	energy, energyTS := energySample(values, dcgm.DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION)
+
+	st, exists := e.series[key]
+	if !exists {
+		if powerTS == 0 && energyTS == 0 {
+			return 0, false
+		}
+		st = &energyState{}
+		e.series[key] = st
+	}
+
+	switch {
+	case powerTS != 0:
+		if st.powerTS != 0 && powerTS > st.powerTS {
+			st.total += (st.power + power) / 2 * float64(powerTS-st.powerTS) / 1e6
+		}
+	case energyTS != 0:
+		// The total energy is reset by a driver reload, the interval is skipped then
+		if st.energyTS != 0 && energyTS > st.energyTS && energy >= st.energy {
+			st.total += (energy - st.energy) / 1000
+		}
+	}
+
+	st.power, st.powerTS = power, powerTS
+	st.energy, st.energyTS = energy, energyTS
+	st.seenAt = now
+
+	return st.total, true
+}
+
+// energySample returns the value and the DCGM timestamp of a field, the timestamp is zero if the field
+// has no valid sample.
+func energySample(values []dcgm.FieldValue_v1, fieldID dcgm.Short) (float64, int64) {
+	for _, val := range values {
+		if val.FieldID != fieldID || val.TS <= 0 {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(toString(val), 64)
+		if err != nil || v < 0 {
+			return 0, 0
+		}
+		return v, val.TS
+	}
+	return 0, 0
+}
+
+// energySeriesKey identifies the energy of an entity across the reloads, which may renumber the GPUs
+func energySeriesKey(mi devicemonitoring.Info) string {
+	return fmt.Sprintf("%s|%d|%d", mi.DeviceInfo.UUID, mi.Entity.EntityGroupId, mi.Entity.EntityId)
+}
+
+// appendEnergyTotal adds the energy metric of one entity. The entity may not export a field the metric
+// could copy its labels from, so convert builds the metric of the entity from its label values and a
+// placeholder value of the energy counter.
+func appendEnergyTotal(
+	metrics MetricsByCounter, values []dcgm.FieldValue_v1, c []counters.Counter, energyCounter counters.Counter,
+	total float64, convert func(MetricsByCounter, []dcgm.FieldValue_v1, []counters.Counter),
+) {
+	// The placeholder is looked up by the field ID of the power draw, which is never a label
+	placeholderCounter := energyCounter
+	placeholderCounter.FieldID = dcgm.DCGM_FI_DEV_POWER_USAGE
+
+	entityCounters := []counters.Counter{placeholderCounter}
+	placeholders := []dcgm.FieldValue_v1{}
+	for _, val := range values {
+		counter, err := findCounterField(c, val.FieldID)
+		if err != nil || !counter.IsLabel() {
+			continue
+		}
+		entityCounters = append(entityCounters, counter)
+		placeholders = append(placeholders, val)
+	}
+	placeholders = append(placeholders, dcgm.FieldValue_v1{
+		FieldID:   dcgm.DCGM_FI_DEV_POWER_USAGE,
+		FieldType: dcgm.DCGM_FT_INT64,
+		Value:     [4096]byte{1},
+	})
+
+	entityMetrics := make(MetricsByCounter)
+	convert(entityMetrics, placeholders, entityCounters)
+
+	for _, m := range entityMetrics[placeholderCounter] {
+		energyMetric := m
+		energyMetric.Counter = energyCounter
+		energyMetric.Value = strconv.FormatFloat(total, 'f', -1, 64)
+
+		metrics[energyCounter] = append(metrics[energyCounter], energyMetric)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+)
+
+func float64FieldValue(fieldID dcgm.Short, v float64) dcgm.FieldValue_v1 {
+	val := dcgm.FieldValue_v1{FieldID: fieldID, FieldType: dcgm.DCGM_FT_DOUBLE}
+	binary.LittleEndian.PutUint64(val.Value[:], math.Float64bits(v))
+	return val
+}
+
+// energyValues returns the power and total energy samples, a negative value is a missing sample
+func energyValues(power float64, powerTS time.Time, energy int64, energyTS time.Time) []dcgm.FieldValue_v1 {
+	var values []dcgm.FieldValue_v1
+	if power >= 0 {
+		val := float64FieldValue(dcgm.DCGM_FI_DEV_POWER_USAGE, power)
+		val.TS = powerTS.UnixMicro()
+		values = append(values, val)
+	} else {
+		values = append(values, float64FieldValue(dcgm.DCGM_FI_DEV_POWER_USAGE, dcgm.DCGM_FT_FP64_BLANK))
+	}
+	if energy >= 0 {
+		val := int64FieldValue(dcgm.DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION, energy)
+		val.TS = energyTS.UnixMicro()
+		values = append(values, val)
+	}
+	return values
+}
+
+func TestIsDCGMExpEnergyTotalEnabled(t *testing.T) {
+	assert.False(t, IsDCGMExpEnergyTotalEnabled(counters.CounterList{
+		{FieldName: counters.DCGMExpClockThrottleDuration},
+	}))
+	assert.True(t, IsDCGMExpEnergyTotalEnabled(counters.CounterList{
+		{FieldName: counters.DCGMExpClockThrottleDuration},
+		{FieldName: counters.DCGMExpEnergyTotal},
+	}))
+}
+
+func TestEnergyIntegrator_Observe(t *testing.T) {
+	integrator := newEnergyIntegrator()
+	start := time.Unix(1000, 0)
+	now := start
+
+	// GPU 0 reports its power draw, GPU 1 only reports its total energy
+	observe := func(gpu string, values []dcgm.FieldValue_v1) float64 {
+		total, ok := integrator.observe(gpu, values, now)
+		require.True(t, ok)
+		return total
+	}
+
+	assert.Equal(t, 0.0, observe("0", energyValues(100, start, -1, time.Time{})))
+	assert.Equal(t, 0.0, observe("1", energyValues(-1, time.Time{}, 5000000, start)))
+
+	// The samples are integrated over their DCGM timestamps, not the time between the observations
+	now = now.Add(time.Minute)
+	// (100 W + 200 W) / 2 * 10 s and (5002000 mJ - 5000000 mJ)
+	assert.Equal(t, 1500.0, observe("0", energyValues(200, start.Add(10*time.Second), -1, time.Time{})))
+	assert.Equal(t, 2.0, observe("1", energyValues(-1, time.Time{}, 5002000, start.Add(10*time.Second))))
+
+	// The same samples read again, e.g. by two scrapes within the collect interval, add no energy
+	now = now.Add(time.Second)
+	assert.Equal(t, 1500.0, observe("0", energyValues(200, start.Add(10*time.Second), -1, time.Time{})))
+
+	// The total energy was reset by a driver reload, the interval is skipped
+	assert.Equal(t, 2.0, observe("1", energyValues(-1, time.Time{}, 1000, start.Add(20*time.Second))))
+	assert.Equal(t, 3.0, observe("1", energyValues(-1, time.Time{}, 2000, start.Add(30*time.Second))))
+
+	// The power draw is integrated when both fields are collected
+	assert.Equal(t, 1500.0+200*5,
+		observe("0", energyValues(200, start.Add(15*time.Second), 999000, start.Add(15*time.Second))))
+}
+
+func TestEnergyIntegrator_ObserveWithoutSamples(t *testing.T) {
+	integrator := newEnergyIntegrator()
+	now := time.Unix(1000, 0)
+
+	_, ok := integrator.observe("0", energyValues(-1, time.Time{}, -1, time.Time{}), now)
+	assert.False(t, ok, "A GPU without power or energy samples has no energy metric")
+
+	_, ok = integrator.observe("1", energyValues(100, now, -1, time.Time{}), now)
+	require.True(t, ok)
+
+	// The series of a GPU that is no longer seen is forgotten
+	now = now.Add(energyTotalTTL + time.Second)
+	_, ok = integrator.observe("0", energyValues(-1, time.Time{}, -1, time.Time{}), now)
+	assert.False(t, ok)
+	assert.NotContains(t, integrator.series, "1")
+}
+
+func TestAppendEnergyTotal(t *testing.T) {
+	energyCounter := counters.Counter{
+		FieldID:   dcgm.Short(counters.DCGMEnergyTotal),
+		FieldName: counters.DCGMExpEnergyTotal,
+		PromType:  "counter",
+	}
+	driverCounter := counters.Counter{FieldID: dcgm.DCGM_FI_DRIVER_VERSION, FieldName: "DCGM_FI_DRIVER_VERSION", PromType: "label"}
+	tempCounter := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_GPU_TEMP, FieldName: "DCGM_FI_DEV_GPU_TEMP", PromType: "gauge"}
+	counterList := []counters.Counter{driverCounter, tempCounter}
+
+	driverVersion := dcgm.FieldValue_v1{FieldID: dcgm.DCGM_FI_DRIVER_VERSION, FieldType: dcgm.DCGM_FT_STRING}
+	copy(driverVersion.Value[:], "550.54.15")
+	values := []dcgm.FieldValue_v1{
+		driverVersion,
+		int64FieldValue(dcgm.DCGM_FI_DEV_GPU_TEMP, 42),
+		float64FieldValue(dcgm.DCGM_FI_DEV_POWER_USAGE, 150.5),
+	}
+
+	mi := devicemonitoring.Info{}
+	mi.DeviceInfo.GPU = 3
+	mi.DeviceInfo.UUID = "GPU-00000000-0000-0000-0000-000000000003"
+
+	metrics := MetricsByCounter{}
+	appendEnergyTotal(metrics, values, counterList, energyCounter, 1500.25,
+		func(entityMetrics MetricsByCounter, values []dcgm.FieldValue_v1, c []counters.Counter) {
+			toMetric(entityMetrics, values, c, mi, false, "testhost", false)
+		})
+
+	require.Len(t, metrics, 1)
+	require.Len(t, metrics[energyCounter], 1)
+
+	m := metrics[energyCounter][0]
+	assert.Equal(t, energyCounter, m.Counter)
+	assert.Equal(t, "1500.25", m.Value)
+	assert.Equal(t, "3", m.GPU)
+	assert.Equal(t, "GPU-00000000-0000-0000-0000-000000000003", m.GPUUUID)
+	assert.Equal(t, "550.54.15", m.Labels["DCGM_FI_DRIVER_VERSION"])
+}
@@ -22,6 +22,8 @@ import (
 	"maps"
 	"time"
 
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
@@ -37,35 +39,54 @@ type expCollector struct {
 }
 
 func (c *expCollector) getMetrics() (MetricsByCounter, error) {
-	err := dcgmprovider.Client().UpdateAllFields()
+	values, err := c.readValues()
 	if err != nil {
 		return nil, err
 	}
 
-	mapEntityIDToValues := map[uint]map[int64]int{}
+	return c.toMetrics(values, nil)
+}
+
+// readValues reads the values of the watched fields within the window
+func (c *expCollector) readValues() ([]dcgm.FieldValue_v2, error) {
+	err := dcgmprovider.Client().UpdateAllFields()
+	if err != nil {
+		return nil, err
+	}
 
 	window := time.Now().Add(-time.Duration(c.windowSize) * time.Millisecond)
 
+	var samples []dcgm.FieldValue_v2
 	for _, group := range c.deviceWatchList.DeviceGroups() {
 		values, _, err := dcgmprovider.Client().GetValuesSince(group, c.deviceWatchList.DeviceFieldGroup(), window)
 		if err != nil {
 			return nil, err
 		}
+		samples = append(samples, values...)
+	}
+	return samples, nil
+}
 
-		for _, val := range values {
-			if val.Status == 0 {
-				// Check if the value is a DCGM blank/sentinel value and skip it
-				if isBlankValue(val) {
-					continue
-				}
+// toMetrics counts the values per entity and parsed value. The attributes function, when not nil, returns
+// the attributes of the metric of an entity and parsed value.
+func (c *expCollector) toMetrics(
+	values []dcgm.FieldValue_v2, attributes func(entityID uint, entityValue int64) map[string]string,
+) (MetricsByCounter, error) {
+	mapEntityIDToValues := map[uint]map[int64]int{}
 
-				if _, exists := mapEntityIDToValues[val.EntityID]; !exists {
-					mapEntityIDToValues[val.EntityID] = map[int64]int{}
-				}
+	for _, val := range values {
+		if val.Status == 0 {
+			// Check if the value is a DCGM blank/sentinel value and skip it
+			if isBlankValue(val) {
+				continue
+			}
 
-				for _, v := range c.fieldValueParser(val.Int64()) {
-					mapEntityIDToValues[val.EntityID][v] += 1
-				}
+			if _, exists := mapEntityIDToValues[val.EntityID]; !exists {
+				mapEntityIDToValues[val.EntityID] = map[int64]int{}
+			}
+
+			for _, v := range c.fieldValueParser(val.Int64()) {
+				mapEntityIDToValues[val.EntityID][v] += 1
 			}
 		}
 	}
@@ -95,6 +116,9 @@ func (c *expCollector) getMetrics() (MetricsByCounter, error) {
 				c.labelFiller(metricValueLabels, entityValue)
 
 				m := c.createMetric(metricValueLabels, mi, uuid, val)
+				if attributes != nil {
+					maps.Copy(m.Attributes, attributes(mi.DeviceInfo.GPU, entityValue))
+				}
 
 				metrics[c.counter] = append(metrics[c.counter], m)
 			}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+const fieldNameAttribute = "field_name"
+
+func IsDCGMExpFieldStalenessEnabled(counterList counters.CounterList) bool {
+	_, ok := findFieldStalenessCounter(counterList)
+	return ok
+}
+
+func findFieldStalenessCounter(counterList counters.CounterList) (counters.Counter, bool) {
+	idx := slices.IndexFunc(counterList, func(c counters.Counter) bool {
+		return c.FieldName == counters.DCGMExpFieldStaleness
+	})
+	if idx < 0 {
+		return counters.Counter{}, false
+	}
+	return counterList[idx], true
+}
+
+// appendFieldStaleness adds a staleness metric for every metric of one entity: the age of the
+// DCGM sample it was built from. DCGM keeps serving the last sample of a field that is no longer
+// updated, so the age is the only way to tell that the value is stale.
+func appendFieldStaleness(
+	metrics, entityMetrics MetricsByCounter, values []dcgm.FieldValue_v1, stalenessCounter counters.Counter,
+	now time.Time,
+) {
+	timestamps := make(map[dcgm.Short]int64, len(values))
+	for _, val := range values {
+		timestamps[val.FieldID] = val.TS
+	}
+
+	for counter, metricList := range entityMetrics {
+		ts, exists := timestamps[counter.FieldID]
+		if !exists || ts <= 0 {
+			continue
+		}
+
+		age := max(now.Sub(time.UnixMicro(ts)).Seconds(), 0)
+
+		for _, m := range metricList {
+			stalenessMetric := m
+			stalenessMetric.Counter = stalenessCounter
+			stalenessMetric.Value = strconv.FormatFloat(age, 'f', 3, 64)
+			stalenessMetric.Attributes = make(map[string]string, len(m.Attributes)+1)
+			for k, v := range m.Attributes {
+				stalenessMetric.Attributes[k] = v
+			}
+			stalenessMetric.Attributes[fieldNameAttribute] = counter.FieldName
+
+			metrics[stalenessCounter] = append(metrics[stalenessCounter], stalenessMetric)
+		}
+	}
+}
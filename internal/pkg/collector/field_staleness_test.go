@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+func TestIsDCGMExpFieldStalenessEnabled(t *testing.T) {
+	assert.False(t, IsDCGMExpFieldStalenessEnabled(counters.CounterList{
+		{FieldName: counters.DCGMExpXIDErrorsCount},
+	}))
+	assert.True(t, IsDCGMExpFieldStalenessEnabled(counters.CounterList{
+		{FieldName: counters.DCGMExpXIDErrorsCount},
+		{FieldName: counters.DCGMExpFieldStaleness},
+	}))
+}
+
+func TestAppendFieldStaleness(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	tempCounter := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_GPU_TEMP, FieldName: "DCGM_FI_DEV_GPU_TEMP", PromType: "gauge"}
+	powerCounter := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_POWER_USAGE, FieldName: "DCGM_FI_DEV_POWER_USAGE", PromType: "gauge"}
+	stalenessCounter := counters.Counter{
+		FieldID:   dcgm.Short(counters.DCGMFieldStaleness),
+		FieldName: counters.DCGMExpFieldStaleness,
+		PromType:  "gauge",
+	}
+
+	entityMetrics := MetricsByCounter{
+		tempCounter: {{
+			Counter:    tempCounter,
+			GPU:        "0",
+			Value:      "42",
+			Attributes: map[string]string{"container": "main"},
+		}},
+		powerCounter: {{
+			Counter: powerCounter,
+			GPU:     "0",
+			Value:   "100",
+		}},
+	}
+
+	values := []dcgm.FieldValue_v1{
+		{FieldID: dcgm.DCGM_FI_DEV_GPU_TEMP, TS: now.Add(-90 * time.Second).UnixMicro()},
+		{FieldID: dcgm.DCGM_FI_DEV_POWER_USAGE, TS: now.Add(-1500 * time.Millisecond).UnixMicro()},
+	}
+
+	metrics := MetricsByCounter{}
+	appendFieldStaleness(metrics, entityMetrics, values, stalenessCounter, now)
+
+	require.Len(t, metrics[stalenessCounter], 2)
+
+	got := map[string]Metric{}
+	for _, m := range metrics[stalenessCounter] {
+		got[m.Attributes[fieldNameAttribute]] = m
+	}
+
+	assert.Equal(t, "90.000", got["DCGM_FI_DEV_GPU_TEMP"].Value)
+	assert.Equal(t, "main", got["DCGM_FI_DEV_GPU_TEMP"].Attributes["container"])
+	assert.Equal(t, "0", got["DCGM_FI_DEV_GPU_TEMP"].GPU)
+	assert.Equal(t, "1.500", got["DCGM_FI_DEV_POWER_USAGE"].Value)
+
+	// Source metrics are not modified
+	assert.NotContains(t, entityMetrics[tempCounter][0].Attributes, fieldNameAttribute)
+}
@@ -0,0 +1,169 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+const fieldStatusAttribute = "status"
+
+// Status of a field exported by dcgm_exp_field_status
+const (
+	fieldStatusOK           = "OK"
+	fieldStatusNotSupported = "NOT_SUPPORTED"
+	fieldStatusNoData       = "NO_DATA"
+	fieldStatusPermission   = "PERMISSION"
+	fieldStatusNotWatched   = "NOT_WATCHED"
+	fieldStatusError        = "ERROR"
+)
+
+// Source of the const values: https://github.com/NVIDIA/DCGM/blob/master/dcgmlib/dcgm_structs.h
+const (
+	dcgmStNotSupported = -6
+	dcgmStNoData       = -14
+	dcgmStStaleData    = -15
+	dcgmStNotWatched   = -16
+	dcgmStNoPermission = -17
+)
+
+func IsDCGMExpFieldStatusEnabled(counterList counters.CounterList) bool {
+	_, ok := findFieldStatusCounter(counterList)
+	return ok
+}
+
+func findFieldStatusCounter(counterList counters.CounterList) (counters.Counter, bool) {
+	idx := slices.IndexFunc(counterList, func(c counters.Counter) bool {
+		return c.FieldName == counters.DCGMExpFieldStatus
+	})
+	if idx < 0 {
+		return counters.Counter{}, false
+	}
+	return counterList[idx], true
+}
+
+// appendFieldStatus adds a status metric for every field of one entity, whether or not it has a value.
+// The fields without a value have no metric to copy the labels of the entity from, so convert builds
+// the metrics of the entity from placeholder values of all the fields, in place of the values read.
+func appendFieldStatus(
+	metrics MetricsByCounter, values []dcgm.FieldValue_v1, c []counters.Counter, statusCounter counters.Counter,
+	convert func(MetricsByCounter, []dcgm.FieldValue_v1),
+) {
+	statuses := make(map[dcgm.Short]string, len(values))
+	placeholders := make([]dcgm.FieldValue_v1, 0, len(values))
+	for _, val := range values {
+		counter, err := findCounterField(c, val.FieldID)
+		if err != nil {
+			continue
+		}
+		// The labels keep their values, they are part of the identity of the entity
+		if counter.IsLabel() {
+			placeholders = append(placeholders, val)
+			continue
+		}
+
+		statuses[val.FieldID] = fieldStatus(val)
+		placeholders = append(placeholders, dcgm.FieldValue_v1{
+			FieldID:   val.FieldID,
+			FieldType: dcgm.DCGM_FT_INT64,
+			Value:     [4096]byte{1},
+		})
+	}
+
+	entityMetrics := make(MetricsByCounter)
+	convert(entityMetrics, placeholders)
+
+	for counter, metricList := range entityMetrics {
+		status, exists := statuses[counter.FieldID]
+		if !exists {
+			continue
+		}
+
+		for _, m := range metricList {
+			statusMetric := m
+			statusMetric.Counter = statusCounter
+			statusMetric.Value = "1"
+			statusMetric.Attributes = maps.Clone(m.Attributes)
+			if statusMetric.Attributes == nil {
+				statusMetric.Attributes = map[string]string{}
+			}
+			// The XID attributes are derived from the placeholder value
+			delete(statusMetric.Attributes, "err_code")
+			delete(statusMetric.Attributes, "err_msg")
+			statusMetric.Attributes[fieldNameAttribute] = counter.FieldName
+			statusMetric.Attributes[fieldStatusAttribute] = status
+
+			metrics[statusCounter] = append(metrics[statusCounter], statusMetric)
+		}
+	}
+}
+
+// fieldStatus returns the status of a value: the error DCGM returned for the field, or the blank value
+// DCGM stored in place of a sample.
+func fieldStatus(val dcgm.FieldValue_v1) string {
+	switch val.Status {
+	case 0:
+	case dcgmStNotSupported:
+		return fieldStatusNotSupported
+	case dcgmStNoData, dcgmStStaleData:
+		return fieldStatusNoData
+	case dcgmStNotWatched:
+		return fieldStatusNotWatched
+	case dcgmStNoPermission:
+		return fieldStatusPermission
+	default:
+		return fieldStatusError
+	}
+
+	switch val.FieldType {
+	case dcgm.DCGM_FT_INT64:
+		switch val.Int64() {
+		case dcgm.DCGM_FT_INT32_NOT_SUPPORTED, dcgm.DCGM_FT_INT64_NOT_SUPPORTED:
+			return fieldStatusNotSupported
+		case dcgm.DCGM_FT_INT32_NOT_PERMISSIONED, dcgm.DCGM_FT_INT64_NOT_PERMISSIONED:
+			return fieldStatusPermission
+		case dcgm.DCGM_FT_INT32_BLANK, dcgm.DCGM_FT_INT32_NOT_FOUND,
+			dcgm.DCGM_FT_INT64_BLANK, dcgm.DCGM_FT_INT64_NOT_FOUND:
+			return fieldStatusNoData
+		}
+	case dcgm.DCGM_FT_DOUBLE:
+		switch val.Float64() {
+		case dcgm.DCGM_FT_FP64_NOT_SUPPORTED:
+			return fieldStatusNotSupported
+		case dcgm.DCGM_FT_FP64_NOT_PERMISSIONED:
+			return fieldStatusPermission
+		case dcgm.DCGM_FT_FP64_BLANK, dcgm.DCGM_FT_FP64_NOT_FOUND:
+			return fieldStatusNoData
+		}
+	case dcgm.DCGM_FT_STRING:
+		switch val.String() {
+		case dcgm.DCGM_FT_STR_NOT_SUPPORTED:
+			return fieldStatusNotSupported
+		case dcgm.DCGM_FT_STR_NOT_PERMISSIONED:
+			return fieldStatusPermission
+		case dcgm.DCGM_FT_STR_BLANK, dcgm.DCGM_FT_STR_NOT_FOUND:
+			return fieldStatusNoData
+		}
+	}
+
+	return fieldStatusOK
+}
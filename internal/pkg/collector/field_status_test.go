@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+)
+
+func int64FieldValue(fieldID dcgm.Short, v int64) dcgm.FieldValue_v1 {
+	val := dcgm.FieldValue_v1{FieldID: fieldID, FieldType: dcgm.DCGM_FT_INT64}
+	binary.LittleEndian.PutUint64(val.Value[:], uint64(v))
+	return val
+}
+
+func TestIsDCGMExpFieldStatusEnabled(t *testing.T) {
+	assert.False(t, IsDCGMExpFieldStatusEnabled(counters.CounterList{
+		{FieldName: counters.DCGMExpFieldStaleness},
+	}))
+	assert.True(t, IsDCGMExpFieldStatusEnabled(counters.CounterList{
+		{FieldName: counters.DCGMExpFieldStaleness},
+		{FieldName: counters.DCGMExpFieldStatus},
+	}))
+}
+
+func Test_fieldStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		val  dcgm.FieldValue_v1
+		want string
+	}{
+		{name: "value", val: int64FieldValue(dcgm.DCGM_FI_DEV_GPU_TEMP, 42), want: fieldStatusOK},
+		{name: "not supported", val: dcgm.FieldValue_v1{Status: dcgmStNotSupported}, want: fieldStatusNotSupported},
+		{name: "no permission", val: dcgm.FieldValue_v1{Status: dcgmStNoPermission}, want: fieldStatusPermission},
+		{name: "stale", val: dcgm.FieldValue_v1{Status: dcgmStStaleData}, want: fieldStatusNoData},
+		{name: "not watched", val: dcgm.FieldValue_v1{Status: dcgmStNotWatched}, want: fieldStatusNotWatched},
+		{name: "other error", val: dcgm.FieldValue_v1{Status: -3}, want: fieldStatusError},
+		{
+			name: "blank",
+			val:  int64FieldValue(dcgm.DCGM_FI_DEV_GPU_TEMP, dcgm.DCGM_FT_INT64_BLANK),
+			want: fieldStatusNoData,
+		},
+		{
+			name: "not supported value",
+			val:  int64FieldValue(dcgm.DCGM_FI_DEV_GPU_TEMP, dcgm.DCGM_FT_INT64_NOT_SUPPORTED),
+			want: fieldStatusNotSupported,
+		},
+		{
+			name: "not permissioned value",
+			val:  int64FieldValue(dcgm.DCGM_FI_DEV_GPU_TEMP, dcgm.DCGM_FT_INT64_NOT_PERMISSIONED),
+			want: fieldStatusPermission,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fieldStatus(tt.val))
+		})
+	}
+}
+
+func TestAppendFieldStatus(t *testing.T) {
+	tempCounter := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_GPU_TEMP, FieldName: "DCGM_FI_DEV_GPU_TEMP", PromType: "gauge"}
+	powerCounter := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_POWER_USAGE, FieldName: "DCGM_FI_DEV_POWER_USAGE", PromType: "gauge"}
+	statusCounter := counters.Counter{
+		FieldID:   dcgm.Short(counters.DCGMFieldStatus),
+		FieldName: counters.DCGMExpFieldStatus,
+		PromType:  "gauge",
+	}
+	counterList := []counters.Counter{tempCounter, powerCounter}
+
+	values := []dcgm.FieldValue_v1{
+		int64FieldValue(dcgm.DCGM_FI_DEV_GPU_TEMP, 42),
+		{FieldID: dcgm.DCGM_FI_DEV_POWER_USAGE, FieldType: dcgm.DCGM_FT_DOUBLE, Status: dcgmStNotSupported},
+	}
+
+	mi := devicemonitoring.Info{}
+	mi.DeviceInfo.GPU = 3
+	mi.DeviceInfo.UUID = "GPU-00000000-0000-0000-0000-000000000003"
+
+	metrics := MetricsByCounter{}
+	appendFieldStatus(metrics, values, counterList, statusCounter,
+		func(entityMetrics MetricsByCounter, values []dcgm.FieldValue_v1) {
+			toMetric(entityMetrics, values, counterList, mi, false, "testhost", false)
+		})
+
+	require.Len(t, metrics[statusCounter], 2)
+
+	got := map[string]Metric{}
+	for _, m := range metrics[statusCounter] {
+		got[m.Attributes[fieldNameAttribute]] = m
+	}
+
+	assert.Equal(t, fieldStatusOK, got["DCGM_FI_DEV_GPU_TEMP"].Attributes[fieldStatusAttribute])
+	// The field without a value has the labels of the entity
+	assert.Equal(t, fieldStatusNotSupported, got["DCGM_FI_DEV_POWER_USAGE"].Attributes[fieldStatusAttribute])
+	assert.Equal(t, "3", got["DCGM_FI_DEV_POWER_USAGE"].GPU)
+	assert.Equal(t, "GPU-00000000-0000-0000-0000-000000000003", got["DCGM_FI_DEV_POWER_USAGE"].GPUUUID)
+	assert.Equal(t, "1", got["DCGM_FI_DEV_POWER_USAGE"].Value)
+}
@@ -20,16 +20,20 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
 
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
 )
 
 const unknownErr = "Unknown Error"
@@ -41,6 +45,11 @@ type DCGMCollector struct {
 	deviceWatchList          devicewatchlistmanager.WatchList
 	hostname                 string
 	replaceBlanksInModelName bool
+	fieldStaleness           *counters.Counter // Set when dcgm_exp_field_staleness_seconds is enabled
+	fieldStatus              *counters.Counter // Set when dcgm_exp_field_status is enabled
+	energyTotal              *counters.Counter // Set when DCGM_EXP_ENERGY_JOULES_TOTAL is enabled
+	collectOnScrape          bool              // Update the fields on every scrape
+	minScrapeUpdateInterval  time.Duration     // Minimal interval between the updates triggered by scrapes
 }
 
 func NewDCGMCollector(
@@ -66,6 +75,8 @@ func NewDCGMCollector(
 
 	collector.useOldNamespace = config.UseOldNamespace
 	collector.replaceBlanksInModelName = config.ReplaceBlanksInModelName
+	collector.collectOnScrape = config.CollectOnScrape
+	collector.minScrapeUpdateInterval = config.CollectOnScrapeMinInterval
 
 	cleanups, err := deviceWatchList.Watch()
 	if err != nil {
@@ -84,9 +95,17 @@ func (c *DCGMCollector) Cleanup() {
 }
 
 func (c *DCGMCollector) GetMetrics() (MetricsByCounter, error) {
+	if c.collectOnScrape {
+		err := fieldUpdater.update(c.minScrapeUpdateInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update the fields on scrape: %w", err)
+		}
+	}
+
 	monitoringInfo := devicemonitoring.GetMonitoredEntities(c.deviceWatchList.DeviceInfo())
 
 	metrics := make(MetricsByCounter)
+	now := time.Now()
 
 	for _, mi := range monitoringInfo {
 		var vals []dcgm.FieldValue_v1
@@ -100,41 +119,98 @@ func (c *DCGMCollector) GetMetrics() (MetricsByCounter, error) {
 		}
 
 		if err != nil {
-			if derr, ok := err.(*dcgm.Error); ok {
-				if derr.Code == dcgm.DCGM_ST_CONNECTION_NOT_VALID {
-					slog.Error("Could not retrieve metrics: " + err.Error())
-					os.Exit(1)
-				}
+			// The registry is rebuilt once the exporter reconnects to DCGM
+			var derr *dcgm.Error
+			if errors.As(err, &derr) && derr.Code == dcgm.DCGM_ST_CONNECTION_NOT_VALID {
+				slog.Error("Lost the connection to DCGM: " + err.Error())
+				dcgmprovider.ReportConnectionLost()
 			}
 			return nil, err
 		}
 
-		// InstanceInfo will be nil for GPUs
-		switch c.deviceWatchList.DeviceInfo().InfoType() {
-		case dcgm.FE_LINK:
-			if mi.ParentType == dcgm.FE_SWITCH {
-				toSwitchMetric(metrics, vals, c.counters, mi, c.useOldNamespace, c.hostname)
-			} else {
-				toGPUNvLinkMetric(metrics, vals, c.counters, mi, c.hostname)
+		// Metrics of a single entity are needed to derive their staleness
+		entityMetrics := metrics
+		if c.fieldStaleness != nil {
+			entityMetrics = make(MetricsByCounter)
+		}
+
+		c.toEntityMetrics(entityMetrics, vals, mi)
+
+		if c.fieldStatus != nil {
+			appendFieldStatus(metrics, vals, c.counters, *c.fieldStatus,
+				func(statusMetrics MetricsByCounter, values []dcgm.FieldValue_v1) {
+					c.toEntityMetrics(statusMetrics, values, mi)
+				})
+		}
+
+		if c.energyTotal != nil && c.deviceWatchList.DeviceInfo().InfoType() == dcgm.FE_GPU {
+			if total, ok := energyTotals.observe(energySeriesKey(mi), vals, now); ok {
+				appendEnergyTotal(metrics, vals, c.counters, *c.energyTotal, total,
+					func(energyMetrics MetricsByCounter, values []dcgm.FieldValue_v1, energyCounters []counters.Counter) {
+						toMetric(energyMetrics, values, energyCounters, mi, c.useOldNamespace, c.hostname,
+							c.replaceBlanksInModelName)
+					})
+			}
+		}
+
+		if c.fieldStaleness != nil {
+			appendFieldStaleness(metrics, entityMetrics, vals, *c.fieldStaleness, now)
+			for counter, metricList := range entityMetrics {
+				metrics[counter] = append(metrics[counter], metricList...)
 			}
-		case dcgm.FE_SWITCH:
-			toSwitchMetric(metrics, vals, c.counters, mi, c.useOldNamespace, c.hostname)
-		case dcgm.FE_CPU, dcgm.FE_CPU_CORE:
-			toCPUMetric(metrics, vals, c.counters, mi, c.useOldNamespace, c.hostname)
-		default:
-			toMetric(metrics,
-				vals,
-				c.counters,
-				mi,
-				c.useOldNamespace,
-				c.hostname,
-				c.replaceBlanksInModelName)
 		}
 	}
 
 	return metrics, nil
 }
 
+// toEntityMetrics converts the values of an entity to metrics
+func (c *DCGMCollector) toEntityMetrics(
+	metrics MetricsByCounter, vals []dcgm.FieldValue_v1, mi devicemonitoring.Info,
+) {
+	// InstanceInfo will be nil for GPUs
+	switch c.deviceWatchList.DeviceInfo().InfoType() {
+	case dcgm.FE_LINK:
+		if mi.ParentType == dcgm.FE_SWITCH {
+			toSwitchMetric(metrics, vals, c.counters, mi, c.useOldNamespace, c.hostname)
+		} else {
+			peer := nvLinkPeer(c.deviceWatchList.DeviceInfo(), mi)
+			toGPUNvLinkMetric(metrics, vals, c.counters, mi, peer, c.hostname)
+		}
+	case dcgm.FE_SWITCH:
+		toSwitchMetric(metrics, vals, c.counters, mi, c.useOldNamespace, c.hostname)
+	case dcgm.FE_CPU, dcgm.FE_CPU_CORE:
+		toCPUMetric(metrics, vals, c.counters, mi, c.useOldNamespace, c.hostname)
+	case dcgm.FE_VGPU:
+		toVGPUMetric(metrics, vals, c.counters, mi, c.hostname)
+	default:
+		toMetric(metrics,
+			vals,
+			c.counters,
+			mi,
+			c.useOldNamespace,
+			c.hostname,
+			c.replaceBlanksInModelName)
+	}
+}
+
+// migInstanceCounters are the counters of the devicewatcher.MIGInstanceFields, for the GPU instances of the MIG
+// instance memory expansion
+var migInstanceCounters = map[dcgm.Short]counters.Counter{
+	dcgm.DCGM_FI_DEV_FB_USED: {
+		FieldID: dcgm.DCGM_FI_DEV_FB_USED, FieldName: "DCGM_FI_DEV_FB_USED", PromType: "gauge",
+		Help: "Framebuffer memory used (in MiB).",
+	},
+	dcgm.DCGM_FI_DEV_FB_FREE: {
+		FieldID: dcgm.DCGM_FI_DEV_FB_FREE, FieldName: "DCGM_FI_DEV_FB_FREE", PromType: "gauge",
+		Help: "Framebuffer memory free (in MiB).",
+	},
+	dcgm.DCGM_FI_DEV_BAR1_USED: {
+		FieldID: dcgm.DCGM_FI_DEV_BAR1_USED, FieldName: "DCGM_FI_DEV_BAR1_USED", PromType: "gauge",
+		Help: "Used BAR1 memory (in MiB).",
+	},
+}
+
 func findCounterField(c []counters.Counter, fieldID dcgm.Short) (counters.Counter, error) {
 	for i := 0; i < len(c); i++ {
 		if c[i].FieldID == fieldID {
@@ -236,11 +312,81 @@ func toCPUMetric(
 	}
 }
 
+// vgpuIdentityLabels maps the vGPU identity fields to the labels set on every metric of the vGPU
+var vgpuIdentityLabels = map[dcgm.Short]string{
+	dcgm.DCGM_FI_DEV_VGPU_UUID:    vgpuUUIDLabel,
+	dcgm.DCGM_FI_DEV_VGPU_VM_ID:   vmIDLabel,
+	dcgm.DCGM_FI_DEV_VGPU_VM_NAME: vmNameLabel,
+}
+
+func toVGPUMetric(
+	metrics MetricsByCounter,
+	values []dcgm.FieldValue_v1, c []counters.Counter, mi devicemonitoring.Info, hostname string,
+) {
+	labels := map[string]string{}
+
+	// The identity fields are watched without being counters, so they are collected first to label all the metrics
+	for _, val := range values {
+		label, exists := vgpuIdentityLabels[val.FieldID]
+		if !exists {
+			continue
+		}
+
+		if v := toString(val); v != skipDCGMValue {
+			labels[label] = v
+		}
+	}
+
+	for _, val := range values {
+		v := toString(val)
+		// Filter out counters with no value and ignored fields for this entity
+		if v == skipDCGMValue {
+			continue
+		}
+
+		counter, err := findCounterField(c, val.FieldID)
+		if err != nil {
+			continue
+		}
+
+		if counter.IsLabel() {
+			labels[counter.FieldName] = v
+			continue
+		}
+
+		m := Metric{
+			Counter:    counter,
+			Value:      v,
+			GPU:        fmt.Sprintf("%d", mi.Entity.EntityId),
+			Hostname:   hostname,
+			Labels:     labels,
+			Attributes: nil,
+			ParentType: mi.ParentType,
+		}
+
+		metrics[m.Counter] = append(metrics[m.Counter], m)
+	}
+}
+
+// nvLinkPeer returns the device at the other end of the NVLink of a GPU
+func nvLinkPeer(deviceInfo deviceinfo.Provider, mi devicemonitoring.Info) deviceinfo.NvLinkPeer {
+	for _, gpu := range deviceInfo.GPUs() {
+		if gpu.DeviceInfo.GPU == mi.ParentId {
+			if peer, exists := gpu.NvLinkPeers[mi.Entity.EntityId]; exists {
+				return peer
+			}
+			break
+		}
+	}
+	return deviceinfo.NvLinkPeer{LinkID: mi.Entity.EntityId, Type: nvmlprovider.NvLinkDeviceUnknown}
+}
+
 func toGPUNvLinkMetric(
 	metrics MetricsByCounter,
 	values []dcgm.FieldValue_v1,
 	c []counters.Counter,
 	mi devicemonitoring.Info,
+	peer deviceinfo.NvLinkPeer,
 	hostname string,
 ) {
 	labels := map[string]string{}
@@ -263,7 +409,11 @@ func toGPUNvLinkMetric(
 		if v == skipDCGMValue {
 			continue
 		} else {
-			attrs := map[string]string{}
+			attrs := map[string]string{
+				linkIDLabel:   fmt.Sprintf("%d", peer.LinkID),
+				peerTypeLabel: peer.Type,
+				peerIDLabel:   peer.ID,
+			}
 
 			m = Metric{
 				Counter:      counter,
@@ -306,7 +456,13 @@ func toMetric(
 
 		counter, err := findCounterField(c, val.FieldID)
 		if err != nil {
-			continue
+			// The memory fields of the MIG instance memory expansion are watched without a counter, they are
+			// only exported for the GPU instances
+			instanceCounter, exists := migInstanceCounters[val.FieldID]
+			if !exists || mi.InstanceInfo == nil {
+				continue
+			}
+			counter = instanceCounter
 		}
 
 		if counter.IsLabel() {
@@ -330,6 +486,8 @@ func toMetric(
 				attrs["err_msg"] = unknownErr
 			}
 		}
+		// The static labels of the counter go to the attributes, the labels are shared by the entity metrics
+		maps.Copy(attrs, counter.StaticLabels())
 
 		m := Metric{
 			Counter: counter,
@@ -350,6 +508,9 @@ func toMetric(
 		if mi.InstanceInfo != nil {
 			m.MigProfile = mi.InstanceInfo.ProfileName
 			m.GPUInstanceID = fmt.Sprintf("%d", mi.InstanceInfo.Info.NvmlInstanceId)
+			if ci := mi.ComputeInstance(); ci != nil {
+				m.ComputeInstanceID = fmt.Sprintf("%d", ci.InstanceInfo.NvmlComputeInstanceId)
+			}
 		} else {
 			m.MigProfile = ""
 			m.GPUInstanceID = ""
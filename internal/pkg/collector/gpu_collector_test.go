@@ -23,9 +23,14 @@ import (
 
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 
+	mockdeviceinfo "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/deviceinfo"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
 )
 
 func TestToMetric(t *testing.T) {
@@ -91,6 +96,36 @@ func TestToMetric(t *testing.T) {
 	}
 }
 
+func TestToMetricWithMIGInstanceFields(t *testing.T) {
+	fieldValue := [4096]byte{}
+	fieldValue[0] = 42
+	values := []dcgm.FieldValue_v1{
+		{
+			FieldID:   dcgm.DCGM_FI_DEV_FB_USED,
+			FieldType: dcgm.DCGM_FT_INT64,
+			Value:     fieldValue,
+		},
+	}
+	gpu := devicemonitoring.Info{
+		DeviceInfo: dcgm.Device{UUID: "fake0"},
+	}
+	instance := devicemonitoring.Info{
+		DeviceInfo:   dcgm.Device{UUID: "fake0"},
+		InstanceInfo: &deviceinfo.GPUInstanceInfo{},
+	}
+
+	metrics := make(map[counters.Counter][]Metric)
+	toMetric(metrics, values, nil, gpu, false, "", false)
+	assert.Empty(t, metrics, "The instance fields without a counter are not exported for the GPUs")
+
+	toMetric(metrics, values, nil, instance, false, "", false)
+	require.Len(t, metrics, 1)
+	metricValues := metrics[migInstanceCounters[dcgm.DCGM_FI_DEV_FB_USED]]
+	require.Len(t, metricValues, 1)
+	assert.Equal(t, "42", metricValues[0].Value)
+	assert.Equal(t, "DCGM_FI_DEV_FB_USED", metricValues[0].Counter.FieldName)
+}
+
 func TestToMetricWhenDCGM_FI_DEV_XID_ERRORSField(t *testing.T) {
 	c := []counters.Counter{
 		{
@@ -103,13 +138,13 @@ func TestToMetricWhenDCGM_FI_DEV_XID_ERRORSField(t *testing.T) {
 
 	mi := devicemonitoring.Info{
 		DeviceInfo: dcgm.Device{
-		UUID: "fake0",
-		Identifiers: dcgm.DeviceIdentifiers{
-			Model: "NVIDIA T400 4GB",
-		},
-		PCI: dcgm.PCIInfo{
-			BusID: "00000000:0000:0000.0",
-		},
+			UUID: "fake0",
+			Identifiers: dcgm.DeviceIdentifiers{
+				Model: "NVIDIA T400 4GB",
+			},
+			PCI: dcgm.PCIInfo{
+				BusID: "00000000:0000:0000.0",
+			},
 		},
 	}
 
@@ -165,3 +200,57 @@ func TestToMetricWhenDCGM_FI_DEV_XID_ERRORSField(t *testing.T) {
 		})
 	}
 }
+
+func TestToGPUNvLinkMetric_PeerLabels(t *testing.T) {
+	fieldValue := [4096]byte{}
+	fieldValue[0] = 7
+	values := []dcgm.FieldValue_v1{
+		{
+			FieldID:   dcgm.DCGM_FI_DEV_NVLINK_CRC_FLIT_ERROR_COUNT_TOTAL,
+			FieldType: dcgm.DCGM_FT_INT64,
+			Value:     fieldValue,
+		},
+	}
+	c := []counters.Counter{
+		{
+			FieldID:   dcgm.DCGM_FI_DEV_NVLINK_CRC_FLIT_ERROR_COUNT_TOTAL,
+			FieldName: "DCGM_FI_DEV_NVLINK_CRC_FLIT_ERROR_COUNT_TOTAL",
+			PromType:  "counter",
+		},
+	}
+	mi := devicemonitoring.Info{
+		Entity:     dcgm.GroupEntityPair{EntityGroupId: dcgm.FE_LINK, EntityId: 3},
+		DeviceInfo: dcgm.Device{GPU: 0, UUID: "fake0"},
+		ParentId:   0,
+		ParentType: dcgm.FE_GPU,
+	}
+
+	ctrl := gomock.NewController(t)
+	mockDeviceInfo := mockdeviceinfo.NewMockProvider(ctrl)
+	mockDeviceInfo.EXPECT().GPUs().Return([]deviceinfo.GPUInfo{
+		{
+			DeviceInfo: dcgm.Device{GPU: 0},
+			NvLinkPeers: map[uint]deviceinfo.NvLinkPeer{
+				3: {LinkID: 2, Type: nvmlprovider.NvLinkDeviceGPU, ID: "1"},
+			},
+		},
+	}).AnyTimes()
+
+	metrics := MetricsByCounter{}
+	toGPUNvLinkMetric(metrics, values, c, mi, nvLinkPeer(mockDeviceInfo, mi), "testhost")
+
+	metricValues := metrics[c[0]]
+	assert.Len(t, metricValues, 1)
+	assert.Equal(t, "7", metricValues[0].Value)
+	assert.Equal(t, "3", metricValues[0].NvLink)
+	assert.Equal(t, map[string]string{
+		linkIDLabel:   "2",
+		peerTypeLabel: nvmlprovider.NvLinkDeviceGPU,
+		peerIDLabel:   "1",
+	}, metricValues[0].Attributes)
+
+	// A link without resolved peer is labeled with an unknown peer
+	mi.Entity.EntityId = 4
+	assert.Equal(t, deviceinfo.NvLinkPeer{LinkID: 4, Type: nvmlprovider.NvLinkDeviceUnknown},
+		nvLinkPeer(mockDeviceInfo, mi))
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+)
+
+// deviceNodePrefix is the path of the device nodes of the GPUs without the minor number
+const deviceNodePrefix = "/dev/nvidia"
+
+// IsDCGMExpGPUDeviceInfoEnabled checks if the DCGM_EXP_GPU_DEVICE_INFO counter exists
+func IsDCGMExpGPUDeviceInfoEnabled(counterList counters.CounterList) bool {
+	return slices.ContainsFunc(counterList, func(c counters.Counter) bool {
+		return c.FieldName == counters.DCGMExpGPUDeviceInfo
+	})
+}
+
+// gpuDeviceInfoCollector exports the minor number and the device node of the GPUs as an info metric,
+// container runtimes and some schedulers reference the GPUs by minor number rather than by index or UUID.
+type gpuDeviceInfoCollector struct {
+	baseExpCollector
+	minorNumberField dcgm.Short
+}
+
+func NewGPUDeviceInfoCollector(
+	counterList counters.CounterList,
+	hostname string,
+	config *appconfig.Config,
+	deviceWatchList devicewatchlistmanager.WatchList,
+) (Collector, error) {
+	if !IsDCGMExpGPUDeviceInfoEnabled(counterList) {
+		slog.Error(counters.DCGMExpGPUDeviceInfo + " collector is disabled")
+		return nil, errors.New(counters.DCGMExpGPUDeviceInfo + " collector is disabled")
+	}
+
+	minorNumberField, ok := dcgm.GetFieldID("DCGM_FI_DEV_MINOR_NUMBER")
+	if !ok {
+		return nil, errors.New("the DCGM_FI_DEV_MINOR_NUMBER field is not available")
+	}
+
+	deviceWatchList.SetDeviceFields([]dcgm.Short{minorNumberField})
+
+	expCollector, err := newExpCollector(
+		counterList.LabelCounters(),
+		hostname,
+		config,
+		deviceWatchList,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	collector := gpuDeviceInfoCollector{
+		baseExpCollector: expCollector.baseExpCollector,
+		minorNumberField: minorNumberField,
+	}
+
+	collector.counter = counterList[slices.IndexFunc(counterList, func(c counters.Counter) bool {
+		return c.FieldName == counters.DCGMExpGPUDeviceInfo
+	})]
+
+	return &collector, nil
+}
+
+func (c *gpuDeviceInfoCollector) GetMetrics() (MetricsByCounter, error) {
+	uuid := "UUID"
+	if c.config.UseOldNamespace {
+		uuid = "uuid"
+	}
+
+	// The minor number is static, it is read once per GPU for its MIG instances
+	minorNumbers := map[uint]string{}
+
+	metrics := MetricsByCounter{}
+	labels := map[string]string{}
+
+	for _, mi := range devicemonitoring.GetMonitoredEntities(c.deviceWatchList.DeviceInfo()) {
+		if len(c.labelsCounters) > 0 && len(c.deviceWatchList.LabelDeviceFields()) > 0 {
+			err := c.getLabelsFromCounters(mi, labels)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		minorNumber, exists := minorNumbers[mi.DeviceInfo.GPU]
+		if !exists {
+			values, err := dcgmprovider.Client().EntityGetLatestValues(dcgm.FE_GPU, mi.DeviceInfo.GPU,
+				[]dcgm.Short{c.minorNumberField})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the minor number of GPU %d: %w", mi.DeviceInfo.GPU, err)
+			}
+			if len(values) > 0 && values[0].Status == 0 {
+				minorNumber = toString(values[0])
+			}
+			minorNumbers[mi.DeviceInfo.GPU] = minorNumber
+		}
+
+		if minorNumber == "" || minorNumber == skipDCGMValue || minorNumber == FailedToConvert {
+			slog.Debug("The minor number of the GPU is not available", slog.Uint64("gpu", uint64(mi.DeviceInfo.GPU)))
+			continue
+		}
+
+		metricValueLabels := maps.Clone(labels)
+		metricValueLabels[minorNumberLabel] = minorNumber
+		metricValueLabels[deviceNodeLabel] = deviceNodePrefix + minorNumber
+
+		metrics[c.counter] = append(metrics[c.counter], c.createMetric(metricValueLabels, mi, uuid, 1))
+	}
+
+	return metrics, nil
+}
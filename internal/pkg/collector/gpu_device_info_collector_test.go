@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdcgm "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/dcgmprovider"
+	mockdevicewatcher "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/devicewatcher"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/testutils"
+)
+
+func Test_gpuDeviceInfoCollector_GetMetrics(t *testing.T) {
+	minorNumberField, ok := dcgm.GetFieldID("DCGM_FI_DEV_MINOR_NUMBER")
+	require.True(t, ok)
+
+	ctrl := gomock.NewController(t)
+	mockDCGM := mockdcgm.NewMockDCGM(ctrl)
+	mockDeviceWatcher := mockdevicewatcher.NewMockWatcher(ctrl)
+
+	realDCGM := dcgmprovider.Client()
+	defer func() {
+		dcgmprovider.SetClient(realDCGM)
+	}()
+	dcgmprovider.SetClient(mockDCGM)
+
+	counter := counters.Counter{
+		FieldID:   dcgm.Short(counters.DCGMGPUDeviceInfo),
+		FieldName: counters.DCGMExpGPUDeviceInfo,
+		PromType:  "gauge",
+	}
+
+	mockGPUDeviceInfo := testutils.MockGPUDeviceInfo(ctrl, 2, nil)
+	mockGPUDeviceInfo.EXPECT().GOpts().Return(appconfig.DeviceOptions{Flex: true}).AnyTimes()
+
+	mockDeviceWatcher.EXPECT().WatchDeviceFields([]dcgm.Short{minorNumberField}, gomock.Any(), gomock.Any()).
+		Return([]dcgm.GroupHandle{{}}, dcgm.FieldHandle{}, nil, nil)
+
+	deviceWatchList := devicewatchlistmanager.NewWatchList(mockGPUDeviceInfo, nil, nil, mockDeviceWatcher, 1)
+	collector, err := NewGPUDeviceInfoCollector(counters.CounterList{counter}, "localhost",
+		&appconfig.Config{}, *deviceWatchList)
+	require.NoError(t, err)
+
+	minorNumber := func(value byte) []dcgm.FieldValue_v1 {
+		return []dcgm.FieldValue_v1{{FieldID: minorNumberField, FieldType: dcgm.DCGM_FT_INT64, Value: [4096]byte{value}}}
+	}
+	mockDCGM.EXPECT().EntityGetLatestValues(dcgm.FE_GPU, uint(0), []dcgm.Short{minorNumberField}).Return(minorNumber(3), nil)
+	// The minor number of GPU 1 isn't known
+	mockDCGM.EXPECT().EntityGetLatestValues(dcgm.FE_GPU, uint(1), []dcgm.Short{minorNumberField}).
+		Return([]dcgm.FieldValue_v1{{FieldID: minorNumberField, FieldType: dcgm.DCGM_FT_INT64, Status: 1}}, nil)
+
+	metrics, err := collector.GetMetrics()
+	require.NoError(t, err)
+	require.Len(t, metrics[counter], 1)
+
+	m := metrics[counter][0]
+	assert.Equal(t, "0", m.GPU)
+	assert.Equal(t, "1", m.Value)
+	assert.Equal(t, "3", m.Labels[minorNumberLabel])
+	assert.Equal(t, "/dev/nvidia3", m.Labels[deviceNodeLabel])
+}
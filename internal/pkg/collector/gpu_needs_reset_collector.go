@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+)
+
+// needsResetFieldNames are the fields that are set when the GPU must be reset to complete the repair of
+// its memory: the rows pending remapping, and the pages pending retirement on GPUs before Ampere.
+var needsResetFieldNames = []string{
+	"DCGM_FI_DEV_ROW_REMAP_PENDING",
+	"DCGM_FI_DEV_RETIRED_PENDING",
+}
+
+// IsDCGMExpGPUNeedsResetEnabled checks if the DCGM_EXP_GPU_NEEDS_RESET counter exists
+func IsDCGMExpGPUNeedsResetEnabled(counterList counters.CounterList) bool {
+	return slices.ContainsFunc(counterList, func(c counters.Counter) bool {
+		return c.FieldName == counters.DCGMExpGPUNeedsReset
+	})
+}
+
+// gpuNeedsResetCollector exports whether a GPU must be reset, the remapped rows and the retired pages
+// only take effect after a reset, the GPU is drained and reset or sent for RMA based on this gauge.
+type gpuNeedsResetCollector struct {
+	baseExpCollector
+	fields []dcgm.Short
+}
+
+func NewGPUNeedsResetCollector(
+	counterList counters.CounterList,
+	hostname string,
+	config *appconfig.Config,
+	deviceWatchList devicewatchlistmanager.WatchList,
+) (Collector, error) {
+	if !IsDCGMExpGPUNeedsResetEnabled(counterList) {
+		slog.Error(counters.DCGMExpGPUNeedsReset + " collector is disabled")
+		return nil, errors.New(counters.DCGMExpGPUNeedsReset + " collector is disabled")
+	}
+
+	var fields []dcgm.Short
+	for _, name := range needsResetFieldNames {
+		fieldID, ok := dcgm.GetFieldID(name)
+		if !ok {
+			return nil, fmt.Errorf("the %s field is not available", name)
+		}
+		fields = append(fields, fieldID)
+	}
+
+	deviceWatchList.SetDeviceFields(fields)
+
+	expCollector, err := newExpCollector(
+		counterList.LabelCounters(),
+		hostname,
+		config,
+		deviceWatchList,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	collector := gpuNeedsResetCollector{
+		baseExpCollector: expCollector.baseExpCollector,
+		fields:           fields,
+	}
+
+	collector.counter = counterList[slices.IndexFunc(counterList, func(c counters.Counter) bool {
+		return c.FieldName == counters.DCGMExpGPUNeedsReset
+	})]
+
+	return &collector, nil
+}
+
+func (c *gpuNeedsResetCollector) GetMetrics() (MetricsByCounter, error) {
+	uuid := "UUID"
+	if c.config.UseOldNamespace {
+		uuid = "uuid"
+	}
+
+	// A reset applies to the physical GPU, it is read once per GPU for its MIG instances
+	needsReset := map[uint]int{}
+
+	metrics := MetricsByCounter{}
+	labels := map[string]string{}
+
+	for _, mi := range devicemonitoring.GetMonitoredEntities(c.deviceWatchList.DeviceInfo()) {
+		if len(c.labelsCounters) > 0 && len(c.deviceWatchList.LabelDeviceFields()) > 0 {
+			err := c.getLabelsFromCounters(mi, labels)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		value, exists := needsReset[mi.DeviceInfo.GPU]
+		if !exists {
+			values, err := dcgmprovider.Client().EntityGetLatestValues(dcgm.FE_GPU, mi.DeviceInfo.GPU, c.fields)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the pending repairs of GPU %d: %w", mi.DeviceInfo.GPU, err)
+			}
+			value = pendingRepair(values)
+			needsReset[mi.DeviceInfo.GPU] = value
+		}
+
+		metrics[c.counter] = append(metrics[c.counter], c.createMetric(maps.Clone(labels), mi, uuid, value))
+	}
+
+	return metrics, nil
+}
+
+// pendingRepair returns 1 when any of the values is set, the values that are blank or not supported by
+// the GPU are ignored.
+func pendingRepair(values []dcgm.FieldValue_v1) int {
+	for _, value := range values {
+		if value.Status != 0 || value.FieldType != dcgm.DCGM_FT_INT64 {
+			continue
+		}
+		if v := value.Int64(); !isInt64Blank(v) && v > 0 {
+			return 1
+		}
+	}
+	return 0
+}
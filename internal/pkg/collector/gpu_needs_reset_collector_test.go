@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdcgm "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/dcgmprovider"
+	mockdevicewatcher "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/devicewatcher"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/testutils"
+)
+
+func Test_gpuNeedsResetCollector_GetMetrics(t *testing.T) {
+	remapPendingField, ok := dcgm.GetFieldID("DCGM_FI_DEV_ROW_REMAP_PENDING")
+	require.True(t, ok)
+	retiredPendingField, ok := dcgm.GetFieldID("DCGM_FI_DEV_RETIRED_PENDING")
+	require.True(t, ok)
+	fields := []dcgm.Short{remapPendingField, retiredPendingField}
+
+	ctrl := gomock.NewController(t)
+	mockDCGM := mockdcgm.NewMockDCGM(ctrl)
+	mockDeviceWatcher := mockdevicewatcher.NewMockWatcher(ctrl)
+
+	realDCGM := dcgmprovider.Client()
+	defer func() {
+		dcgmprovider.SetClient(realDCGM)
+	}()
+	dcgmprovider.SetClient(mockDCGM)
+
+	counter := counters.Counter{
+		FieldID:   dcgm.Short(counters.DCGMGPUNeedsReset),
+		FieldName: counters.DCGMExpGPUNeedsReset,
+		PromType:  "gauge",
+	}
+
+	mockGPUDeviceInfo := testutils.MockGPUDeviceInfo(ctrl, 3, nil)
+	mockGPUDeviceInfo.EXPECT().GOpts().Return(appconfig.DeviceOptions{Flex: true}).AnyTimes()
+
+	mockDeviceWatcher.EXPECT().WatchDeviceFields(fields, gomock.Any(), gomock.Any()).
+		Return([]dcgm.GroupHandle{{}}, dcgm.FieldHandle{}, nil, nil)
+
+	deviceWatchList := devicewatchlistmanager.NewWatchList(mockGPUDeviceInfo, nil, nil, mockDeviceWatcher, 1)
+	collector, err := NewGPUNeedsResetCollector(counters.CounterList{counter}, "localhost",
+		&appconfig.Config{}, *deviceWatchList)
+	require.NoError(t, err)
+
+	value := func(fieldID dcgm.Short, v byte) dcgm.FieldValue_v1 {
+		return dcgm.FieldValue_v1{FieldID: fieldID, FieldType: dcgm.DCGM_FT_INT64, Value: [4096]byte{v}}
+	}
+	// GPU 0 has rows pending remapping
+	mockDCGM.EXPECT().EntityGetLatestValues(dcgm.FE_GPU, uint(0), fields).
+		Return([]dcgm.FieldValue_v1{value(remapPendingField, 1), {FieldID: retiredPendingField, Status: 1}}, nil)
+	// GPU 1 has pages pending retirement
+	mockDCGM.EXPECT().EntityGetLatestValues(dcgm.FE_GPU, uint(1), fields).
+		Return([]dcgm.FieldValue_v1{{FieldID: remapPendingField, Status: 1}, value(retiredPendingField, 2)}, nil)
+	// GPU 2 is healthy
+	mockDCGM.EXPECT().EntityGetLatestValues(dcgm.FE_GPU, uint(2), fields).
+		Return([]dcgm.FieldValue_v1{value(remapPendingField, 0), value(retiredPendingField, 0)}, nil)
+
+	metrics, err := collector.GetMetrics()
+	require.NoError(t, err)
+	require.Len(t, metrics[counter], 3)
+
+	got := map[string]string{}
+	for _, m := range metrics[counter] {
+		got[m.GPU] = m.Value
+	}
+	assert.Equal(t, map[string]string{"0": "1", "1": "1", "2": "0"}, got)
+}
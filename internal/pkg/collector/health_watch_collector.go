@@ -0,0 +1,236 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/utils"
+)
+
+// healthWatchSystems are the subsystems watched by the DCGM_EXP_HEALTH_STATUS collector
+var healthWatchSystems = []dcgm.HealthSystem{
+	dcgm.DCGM_HEALTH_WATCH_PCIE,
+	dcgm.DCGM_HEALTH_WATCH_NVLINK,
+	dcgm.DCGM_HEALTH_WATCH_MEM,
+	dcgm.DCGM_HEALTH_WATCH_THERMAL,
+	dcgm.DCGM_HEALTH_WATCH_POWER,
+	dcgm.DCGM_HEALTH_WATCH_INFOROM,
+}
+
+// healthWatchCollector exports the result of the DCGM health watches of every monitored GPU per subsystem,
+// the same as `dcgmi health --check` reports. Unlike DCGM_EXP_GPU_HEALTH_STATUS, which watches all
+// the subsystems of all the supported GPUs, it follows the GPU device options and keeps the incident messages.
+type healthWatchCollector struct {
+	baseExpCollector
+	groupID dcgm.GroupHandle
+}
+
+func NewHealthWatchCollector(
+	counterList counters.CounterList,
+	hostname string,
+	config *appconfig.Config,
+	deviceWatchList devicewatchlistmanager.WatchList,
+) (Collector, error) {
+	if !IsDCGMExpHealthStatusEnabled(counterList) {
+		slog.Error(counters.DCGMExpHealthStatus + " collector is disabled")
+		return nil, fmt.Errorf(counters.DCGMExpHealthStatus + " collector is disabled")
+	}
+
+	gpus := monitoredGPUs(devicemonitoring.GetMonitoredEntities(deviceWatchList.DeviceInfo()))
+	if len(gpus) == 0 {
+		return nil, errors.New("no monitored GPU devices found")
+	}
+
+	groupNumber, err := utils.RandUint64()
+	if err != nil {
+		return nil, err
+	}
+
+	groupID, err := dcgmprovider.Client().CreateGroup(fmt.Sprintf("gpu_health_watch_%d", groupNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+
+	cleanups := []func(){func() {
+		destroyErr := dcgmprovider.Client().DestroyGroup(groupID)
+		if destroyErr != nil {
+			slog.Warn("Cannot destroy group", slog.Any("groupID", groupID), slog.String("error", destroyErr.Error()))
+		}
+	}}
+
+	cleanup := func() {
+		for _, c := range cleanups {
+			c()
+		}
+	}
+
+	for _, gpu := range gpus {
+		err = dcgmprovider.Client().AddEntityToGroup(groupID, dcgm.FE_GPU, gpu)
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("failed to add GPU %d to group: %w", gpu, err)
+		}
+	}
+
+	var systems dcgm.HealthSystem
+	for _, system := range healthWatchSystems {
+		systems |= system
+	}
+
+	err = dcgmprovider.Client().HealthSet(groupID, systems)
+	if err != nil {
+		cleanup()
+		return nil, fmt.Errorf("failed to set health watches: %w", err)
+	}
+
+	if !deviceWatchList.IsEmpty() {
+		watchListCleanups, err := deviceWatchList.Watch()
+		if err != nil {
+			cleanup()
+			return nil, err
+		}
+		cleanups = append(cleanups, watchListCleanups...)
+	}
+
+	return &healthWatchCollector{
+		baseExpCollector: baseExpCollector{
+			counter: counterList[slices.IndexFunc(counterList, func(c counters.Counter) bool {
+				return c.FieldName == counters.DCGMExpHealthStatus
+			})],
+			labelsCounters:  counterList.LabelCounters(),
+			hostname:        hostname,
+			config:          config,
+			cleanups:        cleanups,
+			deviceWatchList: deviceWatchList,
+		},
+		groupID: groupID,
+	}, nil
+}
+
+// monitoredGPUs returns the GPUs of the monitored entities, the parent GPUs for MIG instances
+func monitoredGPUs(monitoringInfo []devicemonitoring.Info) []uint {
+	var gpus []uint
+	for _, mi := range monitoringInfo {
+		if !slices.Contains(gpus, mi.DeviceInfo.GPU) {
+			gpus = append(gpus, mi.DeviceInfo.GPU)
+		}
+	}
+	return gpus
+}
+
+func (c *healthWatchCollector) GetMetrics() (MetricsByCounter, error) {
+	response, err := dcgmprovider.Client().HealthCheck(c.groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	// GPU -> subsystem -> incidents; a subsystem may report several incidents of a GPU
+	incidents := map[uint]map[dcgm.HealthSystem][]dcgm.Incident{}
+	for _, incident := range response.Incidents {
+		if incident.EntityInfo.EntityGroupId != dcgm.FE_GPU {
+			continue
+		}
+		gpu := incident.EntityInfo.EntityId
+		if _, exists := incidents[gpu]; !exists {
+			incidents[gpu] = map[dcgm.HealthSystem][]dcgm.Incident{}
+		}
+		incidents[gpu][incident.System] = append(incidents[gpu][incident.System], incident)
+	}
+
+	uuid := "UUID"
+	if c.config.UseOldNamespace {
+		uuid = "uuid"
+	}
+
+	metrics := MetricsByCounter{}
+	labels := map[string]string{}
+
+	for _, mi := range devicemonitoring.GetMonitoredEntities(c.deviceWatchList.DeviceInfo()) {
+		if len(c.labelsCounters) > 0 && len(c.deviceWatchList.LabelDeviceFields()) > 0 {
+			err := c.getLabelsFromCounters(mi, labels)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, system := range healthWatchSystems {
+			systemIncidents := incidents[mi.DeviceInfo.GPU][system]
+
+			metricValueLabels := maps.Clone(labels)
+			metricValueLabels[healthWatchLabel] = healthSystemWatchToString(system)
+
+			m := c.createMetric(metricValueLabels, mi, uuid, worstHealth(systemIncidents))
+			m.Attributes = incidentAttributes(systemIncidents)
+			metrics[c.counter] = append(metrics[c.counter], m)
+		}
+	}
+
+	return metrics, nil
+}
+
+// worstHealth returns the most severe result of the incidents, PASS without incidents
+func worstHealth(incidents []dcgm.Incident) int {
+	health := int(dcgm.DCGM_HEALTH_RESULT_PASS)
+	for _, incident := range incidents {
+		health = max(health, int(incident.Health))
+	}
+	return health
+}
+
+// incidentAttributes describes the incidents of a subsystem with their error codes and messages
+func incidentAttributes(incidents []dcgm.Incident) map[string]string {
+	codes := make([]string, 0, len(incidents))
+	messages := make([]string, 0, len(incidents))
+	for _, incident := range incidents {
+		codes = append(codes, healthCheckErrorToString(incident.Error.Code))
+		messages = append(messages, sanitizeIncidentMessage(incident.Error.Message))
+	}
+
+	if len(codes) == 0 {
+		codes = append(codes, healthCheckErrorToString(dcgm.DCGM_FR_OK))
+	}
+
+	return map[string]string{
+		healthErrorCodeLabel:    strings.Join(codes, ","),
+		healthErrorMessageLabel: strings.Join(messages, "; "),
+	}
+}
+
+// sanitizeIncidentMessage makes a DCGM incident message safe to use as a label value
+func sanitizeIncidentMessage(message string) string {
+	message = strings.NewReplacer(`"`, "'", `\`, "/", "\n", " ").Replace(message)
+	return strings.TrimSpace(message)
+}
+
+func IsDCGMExpHealthStatusEnabled(counterList counters.CounterList) bool {
+	return slices.ContainsFunc(counterList, func(c counters.Counter) bool {
+		return c.FieldName == counters.DCGMExpHealthStatus
+	})
+}
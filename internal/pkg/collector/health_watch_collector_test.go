@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdcgm "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/testutils"
+)
+
+func TestHealthWatchCollector_GetMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDCGM := mockdcgm.NewMockDCGM(ctrl)
+
+	realDCGM := dcgmprovider.Client()
+	defer func() {
+		dcgmprovider.SetClient(realDCGM)
+	}()
+	dcgmprovider.SetClient(mockDCGM)
+
+	counter := counters.Counter{
+		FieldID:   dcgm.Short(counters.DCGMHealthStatus),
+		FieldName: counters.DCGMExpHealthStatus,
+		PromType:  "gauge",
+	}
+
+	mockGPUDeviceInfo := testutils.MockGPUDeviceInfo(ctrl, 2, nil)
+	mockGPUDeviceInfo.EXPECT().GOpts().Return(appconfig.DeviceOptions{Flex: true}).AnyTimes()
+
+	groupHandle := dcgm.GroupHandle{}
+	groupHandle.SetHandle(uintptr(1))
+
+	mockDCGM.EXPECT().CreateGroup(gomock.Any()).Return(groupHandle, nil)
+	mockDCGM.EXPECT().AddEntityToGroup(groupHandle, dcgm.FE_GPU, uint(0)).Return(nil)
+	mockDCGM.EXPECT().AddEntityToGroup(groupHandle, dcgm.FE_GPU, uint(1)).Return(nil)
+	mockDCGM.EXPECT().HealthSet(groupHandle, dcgm.DCGM_HEALTH_WATCH_PCIE|dcgm.DCGM_HEALTH_WATCH_NVLINK|
+		dcgm.DCGM_HEALTH_WATCH_MEM|dcgm.DCGM_HEALTH_WATCH_THERMAL|dcgm.DCGM_HEALTH_WATCH_POWER|
+		dcgm.DCGM_HEALTH_WATCH_INFOROM).Return(nil)
+	mockDCGM.EXPECT().DestroyGroup(groupHandle).Return(nil)
+
+	deviceWatchList := devicewatchlistmanager.NewWatchList(mockGPUDeviceInfo, nil, nil, deviceWatcher, 1)
+	collector, err := NewHealthWatchCollector(counters.CounterList{counter}, "localhost",
+		&appconfig.Config{}, *deviceWatchList)
+	require.NoError(t, err)
+	defer collector.Cleanup()
+
+	gpu1 := dcgm.GroupEntityPair{EntityGroupId: dcgm.FE_GPU, EntityId: 1}
+	mockDCGM.EXPECT().HealthCheck(groupHandle).Return(dcgm.HealthResponse{
+		OverallHealth: dcgm.DCGM_HEALTH_RESULT_FAIL,
+		Incidents: []dcgm.Incident{
+			{
+				System:     dcgm.DCGM_HEALTH_WATCH_PCIE,
+				Health:     dcgm.DCGM_HEALTH_RESULT_WARN,
+				Error:      dcgm.DiagErrorDetail{Message: "Detected more than 8 PCIe replays", Code: dcgm.DCGM_FR_PCI_REPLAY_RATE},
+				EntityInfo: gpu1,
+			},
+			{
+				System:     dcgm.DCGM_HEALTH_WATCH_MEM,
+				Health:     dcgm.DCGM_HEALTH_RESULT_FAIL,
+				Error:      dcgm.DiagErrorDetail{Message: "Volatile \"DBE\" detected", Code: dcgm.DCGM_FR_VOLATILE_DBE_DETECTED},
+				EntityInfo: gpu1,
+			},
+		},
+	}, nil)
+
+	metrics, err := collector.GetMetrics()
+	require.NoError(t, err)
+	require.Len(t, metrics[counter], 2*len(healthWatchSystems))
+
+	got := map[string]Metric{}
+	for _, m := range metrics[counter] {
+		got[m.GPU+"/"+m.Labels[healthWatchLabel]] = m
+	}
+
+	assert.Equal(t, "0", got["0/PCIE"].Value)
+	assert.Equal(t, "DCGM_FR_OK", got["0/PCIE"].Attributes[healthErrorCodeLabel])
+	assert.Equal(t, "10", got["1/PCIE"].Value)
+	assert.Equal(t, "DCGM_FR_PCI_REPLAY_RATE", got["1/PCIE"].Attributes[healthErrorCodeLabel])
+	assert.Equal(t, "Detected more than 8 PCIe replays", got["1/PCIE"].Attributes[healthErrorMessageLabel])
+	assert.Equal(t, "20", got["1/MEM"].Value)
+	assert.Equal(t, "Volatile 'DBE' detected", got["1/MEM"].Attributes[healthErrorMessageLabel])
+	assert.Equal(t, "0", got["1/THERMAL"].Value)
+}
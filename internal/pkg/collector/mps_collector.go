@@ -0,0 +1,365 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	stdos "os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+)
+
+const (
+	mpsControlBinary = "nvidia-cuda-mps-control"
+	mpsServerBinary  = "nvidia-cuda-mps-server"
+
+	// mpsDefaultPipeDirectory is the pipe directory of the control daemons started without CUDA_MPS_PIPE_DIRECTORY
+	mpsDefaultPipeDirectory = "/tmp/nvidia-mps"
+
+	// mpsQueryTimeout bounds the query of a control daemon, so that a hung daemon doesn't stall the scrape
+	mpsQueryTimeout = 2 * time.Second
+)
+
+var mpsCounters = []string{
+	counters.DCGMExpMPSDaemonUp,
+	counters.DCGMExpMPSServerUp,
+	counters.DCGMExpMPSActiveClients,
+	counters.DCGMExpMPSThreadPercentage,
+	counters.DCGMExpMPSClientThreadPct,
+	counters.DCGMExpComputeMode,
+}
+
+// IsDCGMExpMPSEnabled checks if any of the DCGM_EXP_MPS_* or DCGM_EXP_COMPUTE_MODE counters exists
+func IsDCGMExpMPSEnabled(counterList counters.CounterList) bool {
+	return slices.ContainsFunc(counterList, func(c counters.Counter) bool {
+		return slices.Contains(mpsCounters, c.FieldName)
+	})
+}
+
+// mpsProcess is a running MPS control daemon or server
+type mpsProcess struct {
+	PID int
+	// Devices are the CUDA_VISIBLE_DEVICES of the process, as indices or UUIDs; empty when the process serves
+	// all the GPUs
+	Devices       []string
+	PipeDirectory string
+}
+
+// servesGPU returns true if the process serves the GPU with the index and UUID
+func (d mpsProcess) servesGPU(index uint, uuid string) bool {
+	if len(d.Devices) == 0 {
+		return true
+	}
+	for _, device := range d.Devices {
+		if device == strconv.FormatUint(uint64(index), 10) || (uuid != "" && strings.HasPrefix(uuid, device)) {
+			return true
+		}
+	}
+	return false
+}
+
+// mpsCollector exports the status of MPS on the GPUs: the control daemons and servers, found in the process
+// table, which requires the host PID namespace in a container, the clients and the compute mode, from NVML, and
+// the active thread percentages, from the control daemons and the environment of the clients.
+type mpsCollector struct {
+	baseExpCollector
+	counters []counters.Counter
+
+	procRoot              string
+	queryThreadPercentage func(d mpsProcess, procRoot string) (float64, error)
+}
+
+func NewMPSCollector(
+	counterList counters.CounterList,
+	hostname string,
+	config *appconfig.Config,
+	deviceWatchList devicewatchlistmanager.WatchList,
+) (Collector, error) {
+	if !IsDCGMExpMPSEnabled(counterList) {
+		slog.Error(mpsCollectorName + " collector is disabled")
+		return nil, errors.New(mpsCollectorName + " collector is disabled")
+	}
+
+	// NVML is only initialized in Kubernetes mode, the MPS clients aren't available through DCGM
+	if err := nvmlprovider.Initialize(); err != nil {
+		return nil, fmt.Errorf("failed to initialize NVML: %w", err)
+	}
+
+	var mpsCounterList []counters.Counter
+	for _, c := range counterList {
+		if slices.Contains(mpsCounters, c.FieldName) {
+			mpsCounterList = append(mpsCounterList, c)
+		}
+	}
+
+	return &mpsCollector{
+		baseExpCollector: baseExpCollector{
+			labelsCounters:  counterList.LabelCounters(),
+			hostname:        hostname,
+			config:          config,
+			deviceWatchList: deviceWatchList,
+		},
+		counters:              mpsCounterList,
+		procRoot:              "/proc",
+		queryThreadPercentage: queryMPSThreadPercentage,
+	}, nil
+}
+
+func (c *mpsCollector) GetMetrics() (MetricsByCounter, error) {
+	uuid := "UUID"
+	if c.config.UseOldNamespace {
+		uuid = "uuid"
+	}
+
+	daemons, err := findMPSProcesses(c.procRoot, mpsControlBinary)
+	if err != nil {
+		return nil, err
+	}
+	servers, err := findMPSProcesses(c.procRoot, mpsServerBinary)
+	if err != nil {
+		return nil, err
+	}
+
+	// The control daemons are queried once per scrape, whatever the number of GPUs they serve
+	percentages := map[int]*float64{}
+	defaultPercentage := func(d mpsProcess) *float64 {
+		if _, exists := percentages[d.PID]; !exists {
+			percentages[d.PID] = nil
+			if percentage, err := c.queryThreadPercentage(d, c.procRoot); err != nil {
+				slog.Debug("Failed to query the MPS control daemon", "pid", d.PID, "error", err)
+			} else {
+				percentages[d.PID] = &percentage
+			}
+		}
+		return percentages[d.PID]
+	}
+
+	metrics := MetricsByCounter{}
+	labels := map[string]string{}
+
+	for _, mi := range devicemonitoring.GetMonitoredEntities(c.deviceWatchList.DeviceInfo()) {
+		// MPS runs on the physical GPUs, the MIG instances are reported through their parent GPU
+		if mi.InstanceInfo != nil {
+			continue
+		}
+
+		gpuUUID := mi.DeviceInfo.UUID
+		servesGPU := func(d mpsProcess) bool {
+			return d.servesGPU(mi.DeviceInfo.GPU, gpuUUID)
+		}
+		daemonIdx := slices.IndexFunc(daemons, servesGPU)
+		serverUp := slices.ContainsFunc(servers, servesGPU)
+
+		// The clients are only listed when a control daemon serves the GPU
+		var clients []uint32
+		clientsListed := false
+		if daemonIdx >= 0 {
+			clients, err = nvmlprovider.Client().GetMPSClients(gpuUUID)
+			if err != nil {
+				slog.Debug("Failed to get MPS clients", "gpuUUID", gpuUUID, "error", err)
+			} else {
+				clientsListed = true
+			}
+		}
+
+		if len(c.labelsCounters) > 0 && len(c.deviceWatchList.LabelDeviceFields()) > 0 {
+			err := c.getLabelsFromCounters(mi, labels)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, counter := range c.counters {
+			switch counter.FieldName {
+			case counters.DCGMExpMPSDaemonUp:
+				m := c.createMetric(labels, mi, uuid, boolValue(daemonIdx >= 0))
+				m.Counter = counter
+				metrics[counter] = append(metrics[counter], m)
+			case counters.DCGMExpMPSServerUp:
+				m := c.createMetric(labels, mi, uuid, boolValue(serverUp))
+				m.Counter = counter
+				metrics[counter] = append(metrics[counter], m)
+			case counters.DCGMExpMPSActiveClients:
+				if !clientsListed {
+					continue
+				}
+				m := c.createMetric(labels, mi, uuid, len(clients))
+				m.Counter = counter
+				metrics[counter] = append(metrics[counter], m)
+			case counters.DCGMExpMPSThreadPercentage:
+				if daemonIdx < 0 {
+					continue
+				}
+				percentage := defaultPercentage(daemons[daemonIdx])
+				if percentage == nil {
+					continue
+				}
+				m := c.createMetric(labels, mi, uuid, 0)
+				m.Value = strconv.FormatFloat(*percentage, 'f', -1, 64)
+				m.Counter = counter
+				metrics[counter] = append(metrics[counter], m)
+			case counters.DCGMExpMPSClientThreadPct:
+				for _, pid := range clients {
+					// A client without CUDA_MPS_ACTIVE_THREAD_PERCENTAGE is limited by the default of the daemon
+					percentage := clientThreadPercentage(c.procRoot, pid)
+					if percentage == nil {
+						percentage = defaultPercentage(daemons[daemonIdx])
+					}
+					if percentage == nil {
+						continue
+					}
+
+					metricValueLabels := maps.Clone(labels)
+					metricValueLabels[pidLabel] = fmt.Sprint(pid)
+					m := c.createMetric(metricValueLabels, mi, uuid, 0)
+					m.Value = strconv.FormatFloat(*percentage, 'f', -1, 64)
+					m.Counter = counter
+					metrics[counter] = append(metrics[counter], m)
+				}
+			case counters.DCGMExpComputeMode:
+				mode, err := nvmlprovider.Client().GetComputeMode(gpuUUID)
+				if err != nil {
+					slog.Debug("Failed to get compute mode", "gpuUUID", gpuUUID, "error", err)
+					continue
+				}
+				m := c.createMetric(labels, mi, uuid, mode)
+				m.Counter = counter
+				metrics[counter] = append(metrics[counter], m)
+			}
+		}
+	}
+
+	return metrics, nil
+}
+
+// boolValue returns the value of a status metric
+func boolValue(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// readEnviron returns the environment of the process, nil when it can't be read
+func readEnviron(procRoot string, pid int) map[string]string {
+	environ, err := stdos.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "environ"))
+	if err != nil {
+		slog.Debug("Failed to read the environment of the process", "pid", pid, "error", err)
+		return nil
+	}
+
+	variables := map[string]string{}
+	for _, variable := range bytes.Split(environ, []byte{0}) {
+		if name, value, found := strings.Cut(string(variable), "="); found {
+			variables[name] = value
+		}
+	}
+	return variables
+}
+
+// clientThreadPercentage returns the CUDA_MPS_ACTIVE_THREAD_PERCENTAGE the client was started with, nil when it
+// wasn't set
+func clientThreadPercentage(procRoot string, pid uint32) *float64 {
+	value, exists := readEnviron(procRoot, int(pid))["CUDA_MPS_ACTIVE_THREAD_PERCENTAGE"]
+	if !exists {
+		return nil
+	}
+	percentage, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		slog.Debug("Invalid CUDA_MPS_ACTIVE_THREAD_PERCENTAGE", "pid", pid, "value", value)
+		return nil
+	}
+	return &percentage
+}
+
+// findMPSProcesses returns the processes of the MPS binary in the process table
+func findMPSProcesses(procRoot, binary string) ([]mpsProcess, error) {
+	entries, err := stdos.ReadDir(procRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", procRoot, err)
+	}
+
+	var processes []mpsProcess
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		// The comm of the process is truncated to 15 characters, the command line isn't
+		cmdline, err := stdos.ReadFile(filepath.Join(procRoot, entry.Name(), "cmdline"))
+		if err != nil {
+			continue
+		}
+		argv0, _, _ := bytes.Cut(cmdline, []byte{0})
+		if filepath.Base(string(argv0)) != binary {
+			continue
+		}
+
+		d := mpsProcess{PID: pid, PipeDirectory: mpsDefaultPipeDirectory}
+		environ := readEnviron(procRoot, pid)
+		for _, device := range strings.Split(environ["CUDA_VISIBLE_DEVICES"], ",") {
+			if device = strings.TrimSpace(device); device != "" {
+				d.Devices = append(d.Devices, device)
+			}
+		}
+		if pipeDirectory := environ["CUDA_MPS_PIPE_DIRECTORY"]; pipeDirectory != "" {
+			d.PipeDirectory = pipeDirectory
+		}
+		processes = append(processes, d)
+	}
+
+	return processes, nil
+}
+
+// queryMPSThreadPercentage asks the control daemon for its default active thread percentage. The pipe directory
+// is reached through the root of the daemon, so that the daemons of other mount namespaces are queried too.
+func queryMPSThreadPercentage(d mpsProcess, procRoot string) (float64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), mpsQueryTimeout)
+	defer cancel()
+
+	pipeDirectory := filepath.Join(procRoot, strconv.Itoa(d.PID), "root", d.PipeDirectory)
+	cmd := exec.CommandContext(ctx, mpsControlBinary)
+	cmd.Env = append(stdos.Environ(), "CUDA_MPS_PIPE_DIRECTORY="+pipeDirectory)
+	cmd.Stdin = strings.NewReader("get_default_active_thread_percentage\n")
+
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to run %s: %w", mpsControlBinary, err)
+	}
+
+	percentage, err := strconv.ParseFloat(strings.TrimSpace(string(out)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the active thread percentage %q: %w", strings.TrimSpace(string(out)), err)
+	}
+	return percentage, nil
+}
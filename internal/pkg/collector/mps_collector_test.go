@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	stdos "os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocknvml "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/nvmlprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/testutils"
+)
+
+// writeProc creates the cmdline and environ of a process in a fake /proc
+func writeProc(t *testing.T, procRoot, pid, cmdline, environ string) {
+	t.Helper()
+	dir := filepath.Join(procRoot, pid)
+	require.NoError(t, stdos.MkdirAll(dir, 0o755))
+	require.NoError(t, stdos.WriteFile(filepath.Join(dir, "cmdline"), []byte(cmdline), 0o644))
+	require.NoError(t, stdos.WriteFile(filepath.Join(dir, "environ"), []byte(environ), 0o644))
+}
+
+func TestIsDCGMExpMPSEnabled(t *testing.T) {
+	assert.False(t, IsDCGMExpMPSEnabled(counters.CounterList{
+		{FieldName: counters.DCGMExpProcessSMUtil},
+	}))
+	assert.True(t, IsDCGMExpMPSEnabled(counters.CounterList{
+		{FieldName: counters.DCGMExpProcessSMUtil},
+		{FieldName: counters.DCGMExpMPSDaemonUp},
+	}))
+}
+
+func Test_findMPSProcesses(t *testing.T) {
+	procRoot := t.TempDir()
+	writeProc(t, procRoot, "100", "/usr/bin/nvidia-cuda-mps-control\x00-d\x00",
+		"PATH=/usr/bin\x00CUDA_VISIBLE_DEVICES=0, GPU-1234\x00CUDA_MPS_PIPE_DIRECTORY=/var/run/mps\x00")
+	writeProc(t, procRoot, "101", "nvidia-cuda-mps-control\x00-d\x00", "")
+	writeProc(t, procRoot, "102", "nvidia-cuda-mps-server\x00", "CUDA_VISIBLE_DEVICES=0\x00")
+	writeProc(t, procRoot, "self", "nvidia-cuda-mps-control\x00", "")
+
+	daemons, err := findMPSProcesses(procRoot, mpsControlBinary)
+	require.NoError(t, err)
+	assert.Equal(t, []mpsProcess{
+		{PID: 100, Devices: []string{"0", "GPU-1234"}, PipeDirectory: "/var/run/mps"},
+		{PID: 101, PipeDirectory: mpsDefaultPipeDirectory},
+	}, daemons)
+
+	servers, err := findMPSProcesses(procRoot, mpsServerBinary)
+	require.NoError(t, err)
+	assert.Equal(t, []mpsProcess{
+		{PID: 102, Devices: []string{"0"}, PipeDirectory: mpsDefaultPipeDirectory},
+	}, servers)
+
+	_, err = findMPSProcesses(filepath.Join(procRoot, "missing"), mpsControlBinary)
+	assert.Error(t, err)
+}
+
+func Test_mpsProcess_servesGPU(t *testing.T) {
+	assert.True(t, mpsProcess{}.servesGPU(3, "GPU-abcd"))
+	assert.True(t, mpsProcess{Devices: []string{"1", "3"}}.servesGPU(3, "GPU-abcd"))
+	assert.True(t, mpsProcess{Devices: []string{"GPU-ab"}}.servesGPU(3, "GPU-abcd"))
+	assert.False(t, mpsProcess{Devices: []string{"0", "GPU-ef"}}.servesGPU(3, "GPU-abcd"))
+}
+
+func Test_mpsCollector_GetMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockNVML := mocknvml.NewMockNVML(ctrl)
+
+	realNVML := nvmlprovider.Client()
+	defer func() {
+		nvmlprovider.SetClient(realNVML)
+	}()
+	nvmlprovider.SetClient(mockNVML)
+
+	daemonUp := counters.Counter{FieldName: counters.DCGMExpMPSDaemonUp, PromType: "gauge"}
+	serverUp := counters.Counter{FieldName: counters.DCGMExpMPSServerUp, PromType: "gauge"}
+	clients := counters.Counter{FieldName: counters.DCGMExpMPSActiveClients, PromType: "gauge"}
+	percentage := counters.Counter{FieldName: counters.DCGMExpMPSThreadPercentage, PromType: "gauge"}
+	clientPercentage := counters.Counter{FieldName: counters.DCGMExpMPSClientThreadPct, PromType: "gauge"}
+	computeMode := counters.Counter{FieldName: counters.DCGMExpComputeMode, PromType: "gauge"}
+
+	mockGPUDeviceInfo := testutils.MockGPUDeviceInfo(ctrl, 2, nil)
+	mockGPUDeviceInfo.EXPECT().GOpts().Return(appconfig.DeviceOptions{Flex: true}).AnyTimes()
+
+	deviceWatchList := devicewatchlistmanager.NewWatchList(mockGPUDeviceInfo, nil, nil, nil, 1)
+	collector, err := NewMPSCollector(
+		counters.CounterList{daemonUp, serverUp, clients, percentage, clientPercentage, computeMode},
+		"localhost", &appconfig.Config{}, *deviceWatchList)
+	require.NoError(t, err)
+
+	// The daemon only serves the GPU 1, the client 201 is limited to 25% of the threads
+	procRoot := t.TempDir()
+	writeProc(t, procRoot, "100", "nvidia-cuda-mps-control\x00-d\x00", "CUDA_VISIBLE_DEVICES=1\x00")
+	writeProc(t, procRoot, "101", "nvidia-cuda-mps-server\x00", "CUDA_VISIBLE_DEVICES=1\x00")
+	writeProc(t, procRoot, "200", "python\x00", "")
+	writeProc(t, procRoot, "201", "python\x00", "CUDA_MPS_ACTIVE_THREAD_PERCENTAGE=25\x00")
+
+	queries := 0
+	c := collector.(*mpsCollector)
+	c.procRoot = procRoot
+	c.queryThreadPercentage = func(d mpsProcess, root string) (float64, error) {
+		queries++
+		assert.Equal(t, 100, d.PID)
+		assert.Equal(t, procRoot, root)
+		return 37.5, nil
+	}
+
+	mockNVML.EXPECT().GetMPSClients("").Return([]uint32{200, 201}, nil)
+	mockNVML.EXPECT().GetComputeMode("").Return(0, nil)
+	mockNVML.EXPECT().GetComputeMode("").Return(3, nil)
+
+	metrics, err := collector.GetMetrics()
+	require.NoError(t, err)
+	assert.Equal(t, 1, queries, "The daemon is queried once per scrape")
+
+	values := func(counter counters.Counter) map[string]string {
+		result := map[string]string{}
+		for _, m := range metrics[counter] {
+			assert.Equal(t, counter, m.Counter)
+			result[m.GPU+"/"+m.Labels[pidLabel]] = m.Value
+		}
+		return result
+	}
+
+	assert.Equal(t, map[string]string{"0/": "0", "1/": "1"}, values(daemonUp))
+	assert.Equal(t, map[string]string{"0/": "0", "1/": "1"}, values(serverUp))
+	assert.Equal(t, map[string]string{"1/": "2"}, values(clients))
+	assert.Equal(t, map[string]string{"1/": "37.5"}, values(percentage))
+	assert.Equal(t, map[string]string{"1/200": "37.5", "1/201": "25"}, values(clientPercentage))
+	assert.Equal(t, map[string]string{"0/": "0", "1/": "3"}, values(computeMode))
+}
@@ -0,0 +1,218 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+)
+
+// maxNVLinks is the number of links covered by the per-link NVLink error fields (L0 - L17)
+const maxNVLinks = 18
+
+// nvlinkErrorTypes maps the error_type label value to the name prefix of its per-link DCGM fields
+var nvlinkErrorTypes = []struct {
+	name        string
+	fieldPrefix string
+}{
+	{name: "crc_flit", fieldPrefix: "DCGM_FI_DEV_NVLINK_CRC_FLIT_ERROR_COUNT_L"},
+	{name: "crc_data", fieldPrefix: "DCGM_FI_DEV_NVLINK_CRC_DATA_ERROR_COUNT_L"},
+	{name: "replay", fieldPrefix: "DCGM_FI_DEV_NVLINK_REPLAY_ERROR_COUNT_L"},
+	{name: "recovery", fieldPrefix: "DCGM_FI_DEV_NVLINK_RECOVERY_ERROR_COUNT_L"},
+}
+
+type nvlinkErrorField struct {
+	errorType string
+	link      uint
+}
+
+// nvlinkErrorFields returns the per-link NVLink error fields known to DCGM
+func nvlinkErrorFields() map[dcgm.Short]nvlinkErrorField {
+	fields := map[dcgm.Short]nvlinkErrorField{}
+	for _, errorType := range nvlinkErrorTypes {
+		for link := uint(0); link < maxNVLinks; link++ {
+			fieldID, ok := dcgm.GetFieldID(fmt.Sprintf("%s%d", errorType.fieldPrefix, link))
+			if !ok {
+				continue
+			}
+			fields[fieldID] = nvlinkErrorField{errorType: errorType.name, link: link}
+		}
+	}
+	return fields
+}
+
+type nvlinkErrorsCollector struct {
+	baseExpCollector
+	fields     map[dcgm.Short]nvlinkErrorField
+	windowSize int
+}
+
+func NewNVLinkErrorsCollector(
+	counterList counters.CounterList,
+	hostname string,
+	config *appconfig.Config,
+	deviceWatchList devicewatchlistmanager.WatchList,
+) (Collector, error) {
+	if !IsDCGMExpNVLinkErrorsCountEnabled(counterList) {
+		slog.Error(counters.DCGMExpNVLinkErrorsCount + " collector is disabled")
+		return nil, fmt.Errorf(counters.DCGMExpNVLinkErrorsCount + " collector is disabled")
+	}
+
+	fields := nvlinkErrorFields()
+	if len(fields) == 0 {
+		return nil, errors.New("no per-link NVLink error fields are available")
+	}
+
+	deviceFields := slices.Collect(maps.Keys(fields))
+	slices.Sort(deviceFields)
+	deviceWatchList.SetDeviceFields(deviceFields)
+
+	expCollector, err := newExpCollector(
+		counterList.LabelCounters(),
+		hostname,
+		config,
+		deviceWatchList,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	collector := nvlinkErrorsCollector{
+		baseExpCollector: expCollector.baseExpCollector,
+		fields:           fields,
+		windowSize:       config.NVLinkErrorsCountWindowSize,
+	}
+
+	collector.counter = counterList[slices.IndexFunc(counterList, func(c counters.Counter) bool {
+		return c.FieldName == counters.DCGMExpNVLinkErrorsCount
+	})]
+
+	return &collector, nil
+}
+
+func (c *nvlinkErrorsCollector) GetMetrics() (MetricsByCounter, error) {
+	err := dcgmprovider.Client().UpdateAllFields()
+	if err != nil {
+		return nil, err
+	}
+
+	// Samples of every GPU and field within the window
+	mapEntityIDToSamples := map[uint]map[dcgm.Short][]dcgm.FieldValue_v2{}
+
+	window := time.Now().Add(-time.Duration(c.windowSize) * time.Millisecond)
+
+	for _, group := range c.deviceWatchList.DeviceGroups() {
+		values, _, err := dcgmprovider.Client().GetValuesSince(group, c.deviceWatchList.DeviceFieldGroup(), window)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, val := range values {
+			if val.Status != 0 || isBlankValue(val) {
+				continue
+			}
+
+			if _, exists := c.fields[val.FieldID]; !exists {
+				continue
+			}
+
+			if _, exists := mapEntityIDToSamples[val.EntityID]; !exists {
+				mapEntityIDToSamples[val.EntityID] = map[dcgm.Short][]dcgm.FieldValue_v2{}
+			}
+			mapEntityIDToSamples[val.EntityID][val.FieldID] = append(mapEntityIDToSamples[val.EntityID][val.FieldID], val)
+		}
+	}
+
+	labels := map[string]string{}
+	labels[windowSizeInMSLabel] = fmt.Sprint(c.windowSize)
+
+	monitoringInfo := devicemonitoring.GetMonitoredEntities(c.deviceWatchList.DeviceInfo())
+	metrics := make(MetricsByCounter)
+	uuid := "UUID"
+	if c.config.UseOldNamespace {
+		uuid = "uuid"
+	}
+	for _, mi := range monitoringInfo {
+		if len(c.labelsCounters) > 0 && len(c.deviceWatchList.LabelDeviceFields()) > 0 {
+			err := c.getLabelsFromCounters(mi, labels)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		hasErrors := false
+		for fieldID, samples := range mapEntityIDToSamples[mi.DeviceInfo.GPU] {
+			errorCount := countCounterIncrease(samples)
+			if errorCount == 0 {
+				continue
+			}
+
+			field := c.fields[fieldID]
+			metricValueLabels := maps.Clone(labels)
+			metricValueLabels[linkIDLabel] = fmt.Sprint(field.link)
+			metricValueLabels[nvlinkErrorTypeLabel] = field.errorType
+
+			metrics[c.counter] = append(metrics[c.counter], c.createMetric(metricValueLabels, mi, uuid, errorCount))
+			hasErrors = true
+		}
+
+		if !hasErrors {
+			// Create metric with Zero value if the GPU had no NVLink errors within the window
+			metrics[c.counter] = append(metrics[c.counter], c.createMetric(labels, mi, uuid, 0))
+		}
+	}
+
+	return metrics, nil
+}
+
+// countCounterIncrease returns how much a counter increased over its samples.
+// A decrease is treated as a counter reset, after which the counter started from zero.
+func countCounterIncrease(samples []dcgm.FieldValue_v2) int {
+	slices.SortFunc(samples, func(a, b dcgm.FieldValue_v2) int {
+		return cmp.Compare(a.TS, b.TS)
+	})
+
+	increase := int64(0)
+	for i := 1; i < len(samples); i++ {
+		previous, current := samples[i-1].Int64(), samples[i].Int64()
+		if current >= previous {
+			increase += current - previous
+		} else {
+			increase += current
+		}
+	}
+	return int(increase)
+}
+
+func IsDCGMExpNVLinkErrorsCountEnabled(counterList counters.CounterList) bool {
+	return slices.ContainsFunc(counterList, func(c counters.Counter) bool {
+		return c.FieldName == counters.DCGMExpNVLinkErrorsCount
+	})
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdcgm "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/dcgmprovider"
+	mockdevicewatcher "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/devicewatcher"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/testutils"
+)
+
+func TestIsDCGMExpNVLinkErrorsCountEnabled(t *testing.T) {
+	assert.False(t, IsDCGMExpNVLinkErrorsCountEnabled(counters.CounterList{
+		{FieldName: counters.DCGMExpXIDErrorsCount},
+	}))
+	assert.True(t, IsDCGMExpNVLinkErrorsCountEnabled(counters.CounterList{
+		{FieldName: counters.DCGMExpXIDErrorsCount},
+		{FieldName: counters.DCGMExpNVLinkErrorsCount},
+	}))
+}
+
+func nvlinkErrorSample(gpu uint, fieldID dcgm.Short, value byte, ts int64) dcgm.FieldValue_v2 {
+	return dcgm.FieldValue_v2{
+		EntityID:  gpu,
+		FieldID:   fieldID,
+		FieldType: dcgm.DCGM_FT_INT64,
+		TS:        ts,
+		Value:     [4096]byte{value},
+	}
+}
+
+func TestCountCounterIncrease(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []byte
+		want   int
+	}{
+		{name: "single sample", values: []byte{5}, want: 0},
+		{name: "no errors", values: []byte{5, 5, 5}, want: 0},
+		{name: "burst", values: []byte{5, 6, 9, 9}, want: 4},
+		{name: "counter reset", values: []byte{5, 8, 2, 3}, want: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var samples []dcgm.FieldValue_v2
+			// Samples are passed in reverse order, they are sorted by timestamp
+			for i := len(tt.values) - 1; i >= 0; i-- {
+				samples = append(samples, nvlinkErrorSample(0, 1, tt.values[i], int64(i)))
+			}
+			assert.Equal(t, tt.want, countCounterIncrease(samples))
+		})
+	}
+}
+
+func Test_nvlinkErrorsCollector_GetMetrics(t *testing.T) {
+	crcFlitL0, ok := dcgm.GetFieldID("DCGM_FI_DEV_NVLINK_CRC_FLIT_ERROR_COUNT_L0")
+	require.True(t, ok)
+	replayL3, ok := dcgm.GetFieldID("DCGM_FI_DEV_NVLINK_REPLAY_ERROR_COUNT_L3")
+	require.True(t, ok)
+
+	ctrl := gomock.NewController(t)
+	mockDCGM := mockdcgm.NewMockDCGM(ctrl)
+	mockDeviceWatcher := mockdevicewatcher.NewMockWatcher(ctrl)
+
+	realDCGM := dcgmprovider.Client()
+	defer func() {
+		dcgmprovider.SetClient(realDCGM)
+	}()
+	dcgmprovider.SetClient(mockDCGM)
+
+	counter := counters.Counter{
+		FieldID:   dcgm.Short(counters.DCGMNVLinkErrorsCount),
+		FieldName: counters.DCGMExpNVLinkErrorsCount,
+		PromType:  "counter",
+	}
+
+	mockGPUDeviceInfo := testutils.MockGPUDeviceInfo(ctrl, 2, nil)
+	mockGPUDeviceInfo.EXPECT().GOpts().Return(appconfig.DeviceOptions{Flex: true}).AnyTimes()
+
+	groupHandle := dcgm.GroupHandle{}
+	groupHandle.SetHandle(uintptr(1))
+
+	fieldGroupHandle := dcgm.FieldHandle{}
+	fieldGroupHandle.SetHandle(uintptr(1))
+
+	mockDeviceWatcher.EXPECT().WatchDeviceFields(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return([]dcgm.GroupHandle{groupHandle}, fieldGroupHandle, nil, nil)
+
+	deviceWatchList := devicewatchlistmanager.NewWatchList(mockGPUDeviceInfo, nil, nil, mockDeviceWatcher, 1)
+	collector, err := NewNVLinkErrorsCollector(counters.CounterList{counter}, "localhost",
+		&appconfig.Config{NVLinkErrorsCountWindowSize: 60000}, *deviceWatchList)
+	require.NoError(t, err)
+
+	mockDCGM.EXPECT().UpdateAllFields().Return(nil)
+	mockDCGM.EXPECT().GetValuesSince(groupHandle, fieldGroupHandle, gomock.AssignableToTypeOf(time.Time{})).
+		Return([]dcgm.FieldValue_v2{
+			// GPU 0 link 0 has a CRC burst, link 3 has replays
+			nvlinkErrorSample(0, crcFlitL0, 10, 1),
+			nvlinkErrorSample(0, crcFlitL0, 15, 2),
+			nvlinkErrorSample(0, replayL3, 1, 1),
+			nvlinkErrorSample(0, replayL3, 2, 2),
+			// GPU 1 link 0 is healthy
+			nvlinkErrorSample(1, crcFlitL0, 7, 1),
+			nvlinkErrorSample(1, crcFlitL0, 7, 2),
+		}, time.Time{}, nil)
+
+	metrics, err := collector.GetMetrics()
+	require.NoError(t, err)
+	require.Len(t, metrics[counter], 3)
+
+	got := map[string]Metric{}
+	for _, m := range metrics[counter] {
+		got[m.GPU+"/"+m.Labels[linkIDLabel]+"/"+m.Labels[nvlinkErrorTypeLabel]] = m
+	}
+
+	assert.Equal(t, "5", got["0/0/crc_flit"].Value)
+	assert.Equal(t, "1", got["0/3/replay"].Value)
+	assert.Equal(t, "0", got["1//"].Value)
+	assert.Equal(t, "60000", got["0/0/crc_flit"].Labels[windowSizeInMSLabel])
+}
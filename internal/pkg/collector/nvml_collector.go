@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strconv"
+	"sync"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+)
+
+// nvmlFieldValues maps the DCGM fields served in the NVML-only mode to their value in the NVML device status,
+// formatted as DCGM formats them
+var nvmlFieldValues = map[dcgm.Short]func(nvmlprovider.DeviceStatus) string{
+	dcgm.DCGM_FI_DEV_GPU_UTIL:      func(s nvmlprovider.DeviceStatus) string { return fmt.Sprintf("%d", s.GPUUtil) },
+	dcgm.DCGM_FI_DEV_MEM_COPY_UTIL: func(s nvmlprovider.DeviceStatus) string { return fmt.Sprintf("%d", s.MemCopyUtil) },
+	dcgm.DCGM_FI_DEV_FB_USED:       func(s nvmlprovider.DeviceStatus) string { return fmt.Sprintf("%d", s.MemUsed>>20) },
+	dcgm.DCGM_FI_DEV_FB_FREE:       func(s nvmlprovider.DeviceStatus) string { return fmt.Sprintf("%d", s.MemFree>>20) },
+	dcgm.DCGM_FI_DEV_GPU_TEMP:      func(s nvmlprovider.DeviceStatus) string { return fmt.Sprintf("%d", s.Temperature) },
+	dcgm.DCGM_FI_DEV_POWER_USAGE:   func(s nvmlprovider.DeviceStatus) string { return fmt.Sprintf("%f", s.PowerUsage) },
+}
+
+// nvmlCollector serves the DCGM fields of the GPUs from NVML, for the NVML-only mode where DCGM isn't running.
+// The XID errors are received from the NVML events, DCGM_FI_DEV_XID_ERRORS is the last XID of the GPU as
+// reported by DCGM.
+type nvmlCollector struct {
+	baseExpCollector
+	counters   []counters.Counter
+	xidCounter *counters.Counter
+
+	mtx     sync.Mutex
+	lastXID map[string]uint64 // GPU UUID -> last XID
+}
+
+func NewNVMLCollector(
+	counterList counters.CounterList,
+	hostname string,
+	config *appconfig.Config,
+	deviceWatchList devicewatchlistmanager.WatchList,
+) (Collector, error) {
+	collector := &nvmlCollector{
+		baseExpCollector: baseExpCollector{
+			hostname:        hostname,
+			config:          config,
+			deviceWatchList: deviceWatchList,
+		},
+		lastXID: map[string]uint64{},
+	}
+
+	for _, c := range counterList {
+		switch {
+		case c.IsLabel():
+			continue
+		case c.FieldID == dcgm.DCGM_FI_DEV_XID_ERRORS:
+			collector.xidCounter = &c
+		case nvmlFieldValues[c.FieldID] != nil:
+			collector.counters = append(collector.counters, c)
+		default:
+			slog.Warn("The counter is not supported in the NVML-only mode, skipping",
+				slog.String("counter", c.FieldName))
+		}
+	}
+	if len(collector.counters) == 0 && collector.xidCounter == nil {
+		return nil, errors.New("no counter is supported in the NVML-only mode")
+	}
+
+	if collector.xidCounter != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			if err := nvmlprovider.Client().WatchXIDEvents(ctx, collector.onXIDEvent); err != nil {
+				slog.Warn("Failed to watch the XID events, "+collector.xidCounter.FieldName+" is not updated",
+					slog.String("error", err.Error()))
+			}
+		}()
+		collector.cleanups = append(collector.cleanups, func() {
+			cancel()
+			<-done
+		})
+	}
+
+	return collector, nil
+}
+
+// NewNVMLCollectors returns the collectors of the NVML-only mode: the NVML collector of the DCGM fields and,
+// when enabled, the per-process utilization collector, which reads NVML only as well
+func NewNVMLCollectors(
+	counterSet *counters.CounterSet,
+	hostname string,
+	config *appconfig.Config,
+	deviceWatchList devicewatchlistmanager.WatchList,
+) ([]EntityCollectorTuple, error) {
+	fieldsCollector, err := NewNVMLCollector(counterSet.DCGMCounters, hostname, config, deviceWatchList)
+	if err != nil {
+		return nil, err
+	}
+	entityCollectorTuples := []EntityCollectorTuple{{
+		entity:    dcgm.FE_GPU,
+		collector: fieldsCollector,
+		name:      NVMLCollectorName,
+	}}
+
+	if IsDCGMExpProcessUtilEnabled(counterSet.ExporterCounters) {
+		processUtilCollector, err := NewProcessUtilCollector(counterSet.ExporterCounters, hostname, config,
+			deviceWatchList)
+		if err != nil {
+			fieldsCollector.Cleanup()
+			return nil, fmt.Errorf("collector '%s' cannot be initialized: %w", processUtilCollectorName, err)
+		}
+		entityCollectorTuples = append(entityCollectorTuples, EntityCollectorTuple{
+			entity:    dcgm.FE_GPU,
+			collector: processUtilCollector,
+			name:      processUtilCollectorName,
+		})
+	}
+
+	return entityCollectorTuples, nil
+}
+
+func (c *nvmlCollector) onXIDEvent(event nvmlprovider.XIDEvent) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.lastXID[event.GPUUUID] = event.XID
+}
+
+func (c *nvmlCollector) GetMetrics() (MetricsByCounter, error) {
+	uuid := "UUID"
+	if c.config.UseOldNamespace {
+		uuid = "uuid"
+	}
+
+	metrics := MetricsByCounter{}
+
+	for _, mi := range devicemonitoring.GetMonitoredEntities(c.deviceWatchList.DeviceInfo()) {
+		if mi.InstanceInfo != nil {
+			continue
+		}
+
+		gpuUUID := mi.DeviceInfo.UUID
+		if len(c.counters) > 0 {
+			status, err := nvmlprovider.Client().GetDeviceStatus(gpuUUID)
+			if err != nil {
+				slog.Debug("Failed to get device status", "gpuUUID", gpuUUID, "error", err)
+			} else {
+				for _, counter := range c.counters {
+					m := c.newMetric(mi, uuid, counter, nvmlFieldValues[counter.FieldID](status))
+					metrics[counter] = append(metrics[counter], m)
+				}
+			}
+		}
+
+		if c.xidCounter != nil {
+			c.mtx.Lock()
+			xid := c.lastXID[gpuUUID]
+			c.mtx.Unlock()
+
+			m := c.newMetric(mi, uuid, *c.xidCounter, strconv.FormatUint(xid, 10))
+			m.Attributes["err_code"] = strconv.FormatUint(xid, 10)
+			m.Attributes["err_msg"] = unknownErr
+			if xid < uint64(len(xidErrCodeToText)) {
+				m.Attributes["err_msg"] = xidErrCodeToText[xid]
+			}
+			metrics[m.Counter] = append(metrics[m.Counter], m)
+		}
+	}
+
+	return metrics, nil
+}
+
+// newMetric returns the metric of the counter for the GPU, with the static labels of the counter as attributes
+func (c *nvmlCollector) newMetric(
+	mi devicemonitoring.Info, uuid string, counter counters.Counter, value string,
+) Metric {
+	m := c.createMetric(map[string]string{}, mi, uuid, 0)
+	m.Counter = counter
+	m.Value = value
+	m.Attributes = maps.Clone(counter.StaticLabels())
+	if m.Attributes == nil {
+		m.Attributes = map[string]string{}
+	}
+	return m
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocknvml "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/nvmlprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/testutils"
+)
+
+func Test_nvmlCollector_GetMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockNVML := mocknvml.NewMockNVML(ctrl)
+
+	realNVML := nvmlprovider.Client()
+	defer func() {
+		nvmlprovider.SetClient(realNVML)
+	}()
+	nvmlprovider.SetClient(mockNVML)
+
+	gpuUtil := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_GPU_UTIL, FieldName: "DCGM_FI_DEV_GPU_UTIL", PromType: "gauge"}
+	fbUsed := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_FB_USED, FieldName: "DCGM_FI_DEV_FB_USED", PromType: "gauge"}
+	power := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_POWER_USAGE, FieldName: "DCGM_FI_DEV_POWER_USAGE", PromType: "gauge"}
+	xid := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_XID_ERRORS, FieldName: "DCGM_FI_DEV_XID_ERRORS", PromType: "gauge"}
+	smActive := counters.Counter{FieldID: dcgm.DCGM_FI_PROF_SM_ACTIVE, FieldName: "DCGM_FI_PROF_SM_ACTIVE", PromType: "gauge"}
+
+	mockGPUDeviceInfo := testutils.MockGPUDeviceInfo(ctrl, 1, nil)
+	mockGPUDeviceInfo.EXPECT().GOpts().Return(appconfig.DeviceOptions{Flex: true}).AnyTimes()
+
+	xidReceived := make(chan struct{})
+	mockNVML.EXPECT().WatchXIDEvents(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, onEvent func(nvmlprovider.XIDEvent)) error {
+			onEvent(nvmlprovider.XIDEvent{XID: 79})
+			close(xidReceived)
+			<-ctx.Done()
+			return nil
+		})
+	mockNVML.EXPECT().GetDeviceStatus("").Return(nvmlprovider.DeviceStatus{
+		GPUUtil:    42,
+		MemUsed:    3 << 20,
+		PowerUsage: 7.5,
+	}, nil)
+
+	deviceWatchList := devicewatchlistmanager.NewWatchList(mockGPUDeviceInfo, nil, nil, nil, 1)
+	collector, err := NewNVMLCollector(counters.CounterList{gpuUtil, fbUsed, power, xid, smActive}, "localhost",
+		&appconfig.Config{}, *deviceWatchList)
+	require.NoError(t, err)
+	defer collector.Cleanup()
+
+	<-xidReceived
+	metrics, err := collector.GetMetrics()
+	require.NoError(t, err)
+	require.Len(t, metrics, 4, "The counters not supported by NVML shouldn't be exported")
+
+	got := map[string]string{}
+	for counter, metricList := range metrics {
+		require.Len(t, metricList, 1)
+		assert.Equal(t, counter, metricList[0].Counter)
+		assert.Equal(t, "0", metricList[0].GPU)
+		got[counter.FieldName] = metricList[0].Value
+	}
+	assert.Equal(t, map[string]string{
+		"DCGM_FI_DEV_GPU_UTIL":    "42",
+		"DCGM_FI_DEV_FB_USED":     "3",
+		"DCGM_FI_DEV_POWER_USAGE": "7.500000",
+		"DCGM_FI_DEV_XID_ERRORS":  "79",
+	}, got)
+	assert.Equal(t, "79", metrics[xid][0].Attributes["err_code"])
+	assert.Equal(t, xidErrCodeToText[79], metrics[xid][0].Attributes["err_msg"])
+}
+
+func TestNewNVMLCollector_NoSupportedCounter(t *testing.T) {
+	smActive := counters.Counter{FieldID: dcgm.DCGM_FI_PROF_SM_ACTIVE, FieldName: "DCGM_FI_PROF_SM_ACTIVE", PromType: "gauge"}
+
+	_, err := NewNVMLCollector(counters.CounterList{smActive}, "localhost", &appconfig.Config{},
+		devicewatchlistmanager.WatchList{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+)
+
+// IsDCGMExpNVSwitchPortStatusEnabled checks if the DCGM_EXP_NVSWITCH_PORT_STATUS counter exists
+func IsDCGMExpNVSwitchPortStatusEnabled(counterList counters.CounterList) bool {
+	return slices.ContainsFunc(counterList, func(c counters.Counter) bool {
+		return c.FieldName == counters.DCGMExpNVSwitchPortStatus
+	})
+}
+
+// nvswitchPortCollector exports the state of every port of the watched NVSwitches. Unlike the NvLink entities,
+// which are the active ports only, all the ports are exported, so that a port going down is visible.
+type nvswitchPortCollector struct {
+	baseExpCollector
+}
+
+func NewNVSwitchPortCollector(
+	counterList counters.CounterList,
+	hostname string,
+	config *appconfig.Config,
+	deviceWatchList devicewatchlistmanager.WatchList,
+) (Collector, error) {
+	if !IsDCGMExpNVSwitchPortStatusEnabled(counterList) {
+		slog.Error(counters.DCGMExpNVSwitchPortStatus + " collector is disabled")
+		return nil, errors.New(counters.DCGMExpNVSwitchPortStatus + " collector is disabled")
+	}
+
+	return &nvswitchPortCollector{
+		baseExpCollector: baseExpCollector{
+			counter: counterList[slices.IndexFunc(counterList, func(c counters.Counter) bool {
+				return c.FieldName == counters.DCGMExpNVSwitchPortStatus
+			})],
+			hostname:        hostname,
+			config:          config,
+			deviceWatchList: deviceWatchList,
+		},
+	}, nil
+}
+
+func (c *nvswitchPortCollector) GetMetrics() (MetricsByCounter, error) {
+	// The state is read on every scrape, the device info only has the state of the ports at the last reload
+	links, err := dcgmprovider.Client().GetNvLinkLinkStatus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NvLink status: %w", err)
+	}
+
+	deviceInfo := c.deviceWatchList.DeviceInfo()
+	metrics := MetricsByCounter{}
+
+	for _, link := range links {
+		if link.ParentType != dcgm.FE_SWITCH || !deviceInfo.IsSwitchWatched(link.ParentId) ||
+			!deviceInfo.IsLinkWatched(link.Index, link.ParentId) {
+			continue
+		}
+
+		m := Metric{
+			Counter:    c.counter,
+			Value:      fmt.Sprint(int(link.State)),
+			NvLink:     fmt.Sprintf("%d", link.Index),
+			NvSwitch:   fmt.Sprintf("nvswitch%d", link.ParentId),
+			Hostname:   c.hostname,
+			Labels:     map[string]string{},
+			Attributes: map[string]string{},
+			ParentType: dcgm.FE_SWITCH,
+		}
+		metrics[c.counter] = append(metrics[c.counter], m)
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdcgm "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/dcgmprovider"
+	mockdeviceinfo "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/deviceinfo"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+)
+
+func TestNewNVSwitchPortCollector_Disabled(t *testing.T) {
+	_, err := NewNVSwitchPortCollector(counters.CounterList{{FieldName: "random"}}, "testhost",
+		&appconfig.Config{}, devicewatchlistmanager.WatchList{})
+	assert.Error(t, err)
+}
+
+func TestNVSwitchPortCollector_GetMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDCGM := mockdcgm.NewMockDCGM(ctrl)
+	realDCGM := dcgmprovider.Client()
+	defer func() {
+		dcgmprovider.SetClient(realDCGM)
+	}()
+	dcgmprovider.SetClient(mockDCGM)
+
+	mockDeviceInfo := mockdeviceinfo.NewMockProvider(ctrl)
+	mockDeviceInfo.EXPECT().IsSwitchWatched(uint(1)).Return(true).AnyTimes()
+	mockDeviceInfo.EXPECT().IsSwitchWatched(uint(2)).Return(false).AnyTimes()
+	mockDeviceInfo.EXPECT().IsLinkWatched(gomock.Any(), uint(1)).Return(true).AnyTimes()
+
+	counter := counters.Counter{FieldName: counters.DCGMExpNVSwitchPortStatus, PromType: "gauge"}
+	watchList := devicewatchlistmanager.NewWatchList(mockDeviceInfo, nil, nil, nil, 1)
+	c, err := NewNVSwitchPortCollector(counters.CounterList{counter}, "testhost", &appconfig.Config{}, *watchList)
+	require.NoError(t, err)
+
+	mockDCGM.EXPECT().GetNvLinkLinkStatus().Return([]dcgm.NvLinkStatus{
+		{ParentId: 1, ParentType: dcgm.FE_SWITCH, State: dcgm.LS_UP, Index: 0},
+		{ParentId: 1, ParentType: dcgm.FE_SWITCH, State: dcgm.LS_DOWN, Index: 1},
+		{ParentId: 2, ParentType: dcgm.FE_SWITCH, State: dcgm.LS_UP, Index: 0},
+		{ParentId: 0, ParentType: dcgm.FE_GPU, State: dcgm.LS_UP, Index: 0},
+	}, nil)
+
+	metrics, err := c.GetMetrics()
+	require.NoError(t, err)
+	require.Len(t, metrics[counter], 2, "Only the ports of the watched switches are exported")
+
+	assert.Equal(t, "nvswitch1", metrics[counter][0].NvSwitch)
+	assert.Equal(t, "0", metrics[counter][0].NvLink)
+	assert.Equal(t, "3", metrics[counter][0].Value)
+	assert.Equal(t, "1", metrics[counter][1].NvLink)
+	assert.Equal(t, "2", metrics[counter][1].Value)
+
+	mockDCGM.EXPECT().GetNvLinkLinkStatus().Return(nil, errors.New("boom"))
+	_, err = c.GetMetrics()
+	assert.Error(t, err)
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/memguard"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+)
+
+// processUtilValues maps the per-process utilization counters to their value in an NVML sample
+var processUtilValues = map[string]func(nvmlprovider.ProcessUtilization) uint32{
+	counters.DCGMExpProcessSMUtil:  func(s nvmlprovider.ProcessUtilization) uint32 { return s.SMUtil },
+	counters.DCGMExpProcessMemUtil: func(s nvmlprovider.ProcessUtilization) uint32 { return s.MemUtil },
+	counters.DCGMExpProcessEncUtil: func(s nvmlprovider.ProcessUtilization) uint32 { return s.EncUtil },
+	counters.DCGMExpProcessDecUtil: func(s nvmlprovider.ProcessUtilization) uint32 { return s.DecUtil },
+}
+
+// IsDCGMExpProcessUtilEnabled checks if any of the DCGM_EXP_PROCESS_*_UTIL counters exists
+func IsDCGMExpProcessUtilEnabled(counterList counters.CounterList) bool {
+	return slices.ContainsFunc(counterList, func(c counters.Counter) bool {
+		_, exists := processUtilValues[c.FieldName]
+		return exists
+	})
+}
+
+// processUtilCollector exports the utilization of every process running on the GPUs, as sampled by NVML.
+// NVML only returns the samples more recent than a timestamp, the collector keeps the timestamp of the
+// last sample of every GPU, so that a process is only reported while it is active.
+type processUtilCollector struct {
+	baseExpCollector
+	counters []counters.Counter
+
+	mtx      sync.Mutex
+	lastSeen map[string]uint64 // GPU UUID -> timestamp of the last sample in microseconds
+}
+
+func NewProcessUtilCollector(
+	counterList counters.CounterList,
+	hostname string,
+	config *appconfig.Config,
+	deviceWatchList devicewatchlistmanager.WatchList,
+) (Collector, error) {
+	if !IsDCGMExpProcessUtilEnabled(counterList) {
+		slog.Error(processUtilCollectorName + " collector is disabled")
+		return nil, errors.New(processUtilCollectorName + " collector is disabled")
+	}
+
+	// NVML is only initialized in Kubernetes mode, the samples aren't available through DCGM
+	if err := nvmlprovider.Initialize(); err != nil {
+		return nil, fmt.Errorf("failed to initialize NVML: %w", err)
+	}
+
+	var processCounters []counters.Counter
+	for _, c := range counterList {
+		if _, exists := processUtilValues[c.FieldName]; exists {
+			processCounters = append(processCounters, c)
+		}
+	}
+
+	return &processUtilCollector{
+		baseExpCollector: baseExpCollector{
+			labelsCounters:  counterList.LabelCounters(),
+			hostname:        hostname,
+			config:          config,
+			deviceWatchList: deviceWatchList,
+		},
+		counters: processCounters,
+		lastSeen: map[string]uint64{},
+	}, nil
+}
+
+func (c *processUtilCollector) GetMetrics() (MetricsByCounter, error) {
+	if memguard.IsShed(memguard.FeatureProcessMetrics) {
+		return MetricsByCounter{}, nil
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	uuid := "UUID"
+	if c.config.UseOldNamespace {
+		uuid = "uuid"
+	}
+
+	metrics := MetricsByCounter{}
+	labels := map[string]string{}
+
+	for _, mi := range devicemonitoring.GetMonitoredEntities(c.deviceWatchList.DeviceInfo()) {
+		// NVML doesn't sample the processes of MIG instances
+		if mi.InstanceInfo != nil {
+			continue
+		}
+
+		gpuUUID := mi.DeviceInfo.UUID
+		samples, err := nvmlprovider.Client().GetProcessUtilization(gpuUUID, c.lastSeen[gpuUUID])
+		if err != nil {
+			slog.Debug("Failed to get process utilization", "gpuUUID", gpuUUID, "error", err)
+			continue
+		}
+		if len(samples) == 0 {
+			continue
+		}
+
+		if len(c.labelsCounters) > 0 && len(c.deviceWatchList.LabelDeviceFields()) > 0 {
+			err := c.getLabelsFromCounters(mi, labels)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		for _, sample := range samples {
+			c.lastSeen[gpuUUID] = max(c.lastSeen[gpuUUID], sample.TimeStamp)
+
+			metricValueLabels := maps.Clone(labels)
+			metricValueLabels[pidLabel] = fmt.Sprint(sample.PID)
+
+			for _, counter := range c.counters {
+				m := c.createMetric(metricValueLabels, mi, uuid, int(processUtilValues[counter.FieldName](sample)))
+				m.Counter = counter
+				metrics[counter] = append(metrics[counter], m)
+			}
+		}
+	}
+
+	return metrics, nil
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mocknvml "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/nvmlprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/testutils"
+)
+
+func TestIsDCGMExpProcessUtilEnabled(t *testing.T) {
+	assert.False(t, IsDCGMExpProcessUtilEnabled(counters.CounterList{
+		{FieldName: counters.DCGMExpXIDErrorsCount},
+	}))
+	assert.True(t, IsDCGMExpProcessUtilEnabled(counters.CounterList{
+		{FieldName: counters.DCGMExpXIDErrorsCount},
+		{FieldName: counters.DCGMExpProcessDecUtil},
+	}))
+}
+
+func Test_processUtilCollector_GetMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockNVML := mocknvml.NewMockNVML(ctrl)
+
+	realNVML := nvmlprovider.Client()
+	defer func() {
+		nvmlprovider.SetClient(realNVML)
+	}()
+	nvmlprovider.SetClient(mockNVML)
+
+	smUtil := counters.Counter{FieldName: counters.DCGMExpProcessSMUtil, PromType: "gauge"}
+	encUtil := counters.Counter{FieldName: counters.DCGMExpProcessEncUtil, PromType: "gauge"}
+
+	mockGPUDeviceInfo := testutils.MockGPUDeviceInfo(ctrl, 1, nil)
+	mockGPUDeviceInfo.EXPECT().GOpts().Return(appconfig.DeviceOptions{Flex: true}).AnyTimes()
+
+	deviceWatchList := devicewatchlistmanager.NewWatchList(mockGPUDeviceInfo, nil, nil, nil, 1)
+	collector, err := NewProcessUtilCollector(counters.CounterList{smUtil, encUtil}, "localhost",
+		&appconfig.Config{}, *deviceWatchList)
+	require.NoError(t, err)
+
+	gomock.InOrder(
+		mockNVML.EXPECT().GetProcessUtilization("", uint64(0)).Return([]nvmlprovider.ProcessUtilization{
+			{PID: 1001, TimeStamp: 100, SMUtil: 80, MemUtil: 30, EncUtil: 0},
+			{PID: 1002, TimeStamp: 200, SMUtil: 5, MemUtil: 1, EncUtil: 40},
+		}, nil),
+		// The second collection only asks for the samples after the last one
+		mockNVML.EXPECT().GetProcessUtilization("", uint64(200)).Return(nil, nil),
+	)
+
+	metrics, err := collector.GetMetrics()
+	require.NoError(t, err)
+	require.Len(t, metrics, 2, "Only the enabled counters should be exported")
+	require.Len(t, metrics[smUtil], 2)
+	require.Len(t, metrics[encUtil], 2)
+
+	got := map[string]string{}
+	for counter, metricList := range metrics {
+		for _, m := range metricList {
+			assert.Equal(t, counter, m.Counter)
+			assert.Equal(t, "0", m.GPU)
+			got[counter.FieldName+"/"+m.Labels[pidLabel]] = m.Value
+		}
+	}
+	assert.Equal(t, map[string]string{
+		counters.DCGMExpProcessSMUtil + "/1001":  "80",
+		counters.DCGMExpProcessSMUtil + "/1002":  "5",
+		counters.DCGMExpProcessEncUtil + "/1001": "0",
+		counters.DCGMExpProcessEncUtil + "/1002": "40",
+	}, got)
+
+	metrics, err = collector.GetMetrics()
+	require.NoError(t, err)
+	assert.Empty(t, metrics, "Idle processes shouldn't be reported")
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+)
+
+// scrapeFieldUpdater triggers the update of the watched fields at scrape time, instead of waiting
+// for the next update of the collect interval. It is shared by the collectors of all the entity types,
+// so that a scrape updates the fields once, and updates closer than minInterval are skipped.
+type scrapeFieldUpdater struct {
+	mtx        sync.Mutex
+	lastUpdate time.Time
+	now        func() time.Time
+}
+
+var fieldUpdater = &scrapeFieldUpdater{now: time.Now}
+
+func (u *scrapeFieldUpdater) update(minInterval time.Duration) error {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+
+	now := u.now()
+	if !u.lastUpdate.IsZero() && now.Sub(u.lastUpdate) < minInterval {
+		return nil
+	}
+
+	err := dcgmprovider.Client().UpdateAllFields()
+	if err != nil {
+		return err
+	}
+
+	u.lastUpdate = now
+	return nil
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mockdcgm "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+)
+
+func TestScrapeFieldUpdater_Update(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDCGM := mockdcgm.NewMockDCGM(ctrl)
+
+	realDCGM := dcgmprovider.Client()
+	defer func() {
+		dcgmprovider.SetClient(realDCGM)
+	}()
+	dcgmprovider.SetClient(mockDCGM)
+
+	now := time.Now()
+	updater := &scrapeFieldUpdater{now: func() time.Time { return now }}
+
+	mockDCGM.EXPECT().UpdateAllFields().Return(nil)
+	assert.NoError(t, updater.update(5*time.Second))
+
+	// Too soon after the previous update
+	now = now.Add(2 * time.Second)
+	assert.NoError(t, updater.update(5*time.Second))
+
+	// A failed update is retried on the next scrape
+	now = now.Add(5 * time.Second)
+	mockDCGM.EXPECT().UpdateAllFields().Return(errors.New("update failed"))
+	assert.Error(t, updater.update(5*time.Second))
+
+	mockDCGM.EXPECT().UpdateAllFields().Return(nil)
+	assert.NoError(t, updater.update(5*time.Second))
+}
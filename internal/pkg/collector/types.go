@@ -26,6 +26,7 @@ import (
 
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
 )
 
 //go:generate go run -v go.uber.org/mock/mockgen  -destination=../../mocks/pkg/collector/mock_collector.go -package=collector -copyright_file=../../../hack/header.txt . Collector
@@ -39,6 +40,7 @@ type Collector interface {
 type EntityCollectorTuple struct {
 	entity    dcgm.Field_Entity_Group
 	collector Collector
+	name      string
 }
 
 func (e *EntityCollectorTuple) SetEntity(entity dcgm.Field_Entity_Group) {
@@ -57,29 +59,45 @@ func (e *EntityCollectorTuple) Collector() Collector {
 	return e.collector
 }
 
+func (e *EntityCollectorTuple) SetName(name string) {
+	e.name = name
+}
+
+// Name returns the name of the collector, e.g. DCGM or the name of an exporter counter
+func (e *EntityCollectorTuple) Name() string {
+	return e.name
+}
+
 type Metric struct {
-	Counter       counters.Counter        `json:"counter"`
-	Value         string                  `json:"value"`
-	GPU           string                  `json:"gpu,omitempty"`
-	GPUUUID       string                  `json:"gpu_uuid,omitempty"`
-	GPUDevice     string                  `json:"gpu_device,omitempty"`
-	GPUModelName  string                  `json:"gpu_model,omitempty"`
-	GPUPCIBusID   string                  `json:"pci_bus_id,omitempty"`
-	UUID          string                  `json:"uuid,omitempty"`
-	MigProfile    string                  `json:"mig_profile,omitempty"`
-	NvSwitch      string                  `json:"nv_switch,omitempty"`
-	NvLink        string                  `json:"nv_link,omitempty"`
-	GPUInstanceID string                  `json:"gpu_instance_id,omitempty"`
-	Hostname      string                  `json:"hostname"`
-	Labels        map[string]string       `json:"labels"`
-	Attributes    map[string]string       `json:"attributes"`
-	ParentType    dcgm.Field_Entity_Group `json:"parent_type"`
+	Counter           counters.Counter        `json:"counter"`
+	Value             string                  `json:"value"`
+	GPU               string                  `json:"gpu,omitempty"`
+	GPUUUID           string                  `json:"gpu_uuid,omitempty"`
+	GPUDevice         string                  `json:"gpu_device,omitempty"`
+	GPUModelName      string                  `json:"gpu_model,omitempty"`
+	GPUPCIBusID       string                  `json:"pci_bus_id,omitempty"`
+	UUID              string                  `json:"uuid,omitempty"`
+	MigProfile        string                  `json:"mig_profile,omitempty"`
+	NvSwitch          string                  `json:"nv_switch,omitempty"`
+	NvLink            string                  `json:"nv_link,omitempty"`
+	GPUInstanceID     string                  `json:"gpu_instance_id,omitempty"`
+	ComputeInstanceID string                  `json:"compute_instance_id,omitempty"`
+	Hostname          string                  `json:"hostname"`
+	Labels            map[string]string       `json:"labels"`
+	Attributes        map[string]string       `json:"attributes"`
+	ParentType        dcgm.Field_Entity_Group `json:"parent_type"`
 }
 
-func (m Metric) GetIDOfType(idType appconfig.KubernetesGPUIDType) (string, error) {
-	// For MIG devices, return the MIG profile instead of
+func (m Metric) GetIDOfType(
+	idType appconfig.KubernetesGPUIDType, giFormat appconfig.GPUInstanceIDFormat,
+) (string, error) {
+	// For MIG devices, return the compute or GPU instance identifier instead of the GPU ID
+	if m.MigProfile != "" && m.ComputeInstanceID != "" {
+		return deviceinfo.FormatComputeInstanceIdentifier(giFormat, m.GPU, m.GPUUUID, m.GPUInstanceID,
+			m.ComputeInstanceID), nil
+	}
 	if m.MigProfile != "" {
-		return fmt.Sprintf("%s-%s", m.GPU, m.GPUInstanceID), nil
+		return deviceinfo.FormatGPUInstanceIdentifier(giFormat, m.GPU, m.GPUUUID, m.GPUInstanceID), nil
 	}
 	switch idType {
 	case appconfig.GPUUID:
@@ -67,7 +67,7 @@ func TestMetricsByCounter_GoString(t *testing.T) {
 					},
 				},
 			},
-			expected: `MetricsByCounter{"DCGM_FI_DEV_GPU_TEMP": []collector.Metric{collector.Metric{Counter:counters.Counter{FieldID:0x96, FieldName:"DCGM_FI_DEV_GPU_TEMP", PromType:"gauge", Help:"Temperature Help info"}, Value:"42", GPU:"0", GPUUUID:"GPU-00000000-0000-0000-0000-000000000000", GPUDevice:"nvidia0", GPUModelName:"NVIDIA T400 4GB", GPUPCIBusID:"", UUID:"UUID", MigProfile:"", NvSwitch:"", NvLink:"", GPUInstanceID:"", Hostname:"testhost", Labels:map[string]string{}, Attributes:map[string]string{}, ParentType:0x0}}}`,
+			expected: `MetricsByCounter{"DCGM_FI_DEV_GPU_TEMP": []collector.Metric{collector.Metric{Counter:counters.Counter{FieldID:0x96, FieldName:"DCGM_FI_DEV_GPU_TEMP", PromType:"gauge", Help:"Temperature Help info", Labels:"", UpdateInterval:0}, Value:"42", GPU:"0", GPUUUID:"GPU-00000000-0000-0000-0000-000000000000", GPUDevice:"nvidia0", GPUModelName:"NVIDIA T400 4GB", GPUPCIBusID:"", UUID:"UUID", MigProfile:"", NvSwitch:"", NvLink:"", GPUInstanceID:"", ComputeInstanceID:"", Hostname:"testhost", Labels:map[string]string{}, Attributes:map[string]string{}, ParentType:0x0}}}`,
 		},
 	}
 
@@ -134,8 +134,8 @@ func TestMetricsByCounter_GoString_MultipleCounters(t *testing.T) {
 	result := metrics.GoString()
 
 	// Since Go maps don't guarantee order, we need to check that both counters are present
-	require.Contains(t, result, `"DCGM_FI_DEV_GPU_TEMP": []collector.Metric{collector.Metric{Counter:counters.Counter{FieldID:0x96, FieldName:"DCGM_FI_DEV_GPU_TEMP", PromType:"gauge", Help:"Temperature Help info"}, Value:"42", GPU:"0", GPUUUID:"GPU-00000000-0000-0000-0000-000000000000", GPUDevice:"nvidia0", GPUModelName:"NVIDIA T400 4GB", GPUPCIBusID:"", UUID:"UUID", MigProfile:"", NvSwitch:"", NvLink:"", GPUInstanceID:"", Hostname:"testhost", Labels:map[string]string{}, Attributes:map[string]string{}, ParentType:0x0}}`)
-	require.Contains(t, result, `"DCGM_FI_DEV_POWER_USAGE": []collector.Metric{collector.Metric{Counter:counters.Counter{FieldID:0x9b, FieldName:"DCGM_FI_DEV_POWER_USAGE", PromType:"gauge", Help:"Power usage info"}, Value:"150", GPU:"0", GPUUUID:"GPU-00000000-0000-0000-0000-000000000000", GPUDevice:"nvidia0", GPUModelName:"NVIDIA T400 4GB", GPUPCIBusID:"", UUID:"UUID", MigProfile:"", NvSwitch:"", NvLink:"", GPUInstanceID:"", Hostname:"testhost", Labels:map[string]string{}, Attributes:map[string]string{}, ParentType:0x0}}`)
+	require.Contains(t, result, `"DCGM_FI_DEV_GPU_TEMP": []collector.Metric{collector.Metric{Counter:counters.Counter{FieldID:0x96, FieldName:"DCGM_FI_DEV_GPU_TEMP", PromType:"gauge", Help:"Temperature Help info", Labels:"", UpdateInterval:0}, Value:"42", GPU:"0", GPUUUID:"GPU-00000000-0000-0000-0000-000000000000", GPUDevice:"nvidia0", GPUModelName:"NVIDIA T400 4GB", GPUPCIBusID:"", UUID:"UUID", MigProfile:"", NvSwitch:"", NvLink:"", GPUInstanceID:"", ComputeInstanceID:"", Hostname:"testhost", Labels:map[string]string{}, Attributes:map[string]string{}, ParentType:0x0}}`)
+	require.Contains(t, result, `"DCGM_FI_DEV_POWER_USAGE": []collector.Metric{collector.Metric{Counter:counters.Counter{FieldID:0x9b, FieldName:"DCGM_FI_DEV_POWER_USAGE", PromType:"gauge", Help:"Power usage info", Labels:"", UpdateInterval:0}, Value:"150", GPU:"0", GPUUUID:"GPU-00000000-0000-0000-0000-000000000000", GPUDevice:"nvidia0", GPUModelName:"NVIDIA T400 4GB", GPUPCIBusID:"", UUID:"UUID", MigProfile:"", NvSwitch:"", NvLink:"", GPUInstanceID:"", ComputeInstanceID:"", Hostname:"testhost", Labels:map[string]string{}, Attributes:map[string]string{}, ParentType:0x0}}`)
 	require.Contains(t, result, "MetricsByCounter{")
 	require.Contains(t, result, "}")
 
@@ -301,6 +301,7 @@ func TestMetric_GetIDOfType(t *testing.T) {
 		name     string
 		metric   Metric
 		idType   appconfig.KubernetesGPUIDType
+		giFormat appconfig.GPUInstanceIDFormat
 		expected string
 		hasError bool
 	}{
@@ -333,6 +334,45 @@ func TestMetric_GetIDOfType(t *testing.T) {
 			expected: "0-1",
 			hasError: false,
 		},
+		{
+			name: "MIG device with uuid/gi format",
+			metric: Metric{
+				GPU:           "0",
+				GPUUUID:       "GPU-00000000-0000-0000-0000-000000000000",
+				GPUInstanceID: "1",
+				MigProfile:    "1g.5gb",
+			},
+			idType:   appconfig.DeviceName,
+			giFormat: appconfig.GPUInstanceIDFormatUUID,
+			expected: "GPU-00000000-0000-0000-0000-000000000000/1",
+			hasError: false,
+		},
+		{
+			name: "MIG compute instance",
+			metric: Metric{
+				GPU:               "0",
+				GPUInstanceID:     "1",
+				ComputeInstanceID: "2",
+				MigProfile:        "1g.5gb",
+			},
+			idType:   appconfig.GPUUID,
+			expected: "0-1-2",
+			hasError: false,
+		},
+		{
+			name: "MIG compute instance with uuid/gi format",
+			metric: Metric{
+				GPU:               "0",
+				GPUUUID:           "GPU-00000000-0000-0000-0000-000000000000",
+				GPUInstanceID:     "1",
+				ComputeInstanceID: "2",
+				MigProfile:        "1g.5gb",
+			},
+			idType:   appconfig.GPUUID,
+			giFormat: appconfig.GPUInstanceIDFormatUUID,
+			expected: "GPU-00000000-0000-0000-0000-000000000000/1/2",
+			hasError: false,
+		},
 		{
 			name: "Unsupported ID type",
 			metric: Metric{
@@ -346,7 +386,7 @@ func TestMetric_GetIDOfType(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := tt.metric.GetIDOfType(tt.idType)
+			result, err := tt.metric.GetIDOfType(tt.idType, tt.giFormat)
 			if tt.hasError {
 				assert.Error(t, err)
 				assert.Empty(t, result)
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"log/slog"
 	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
 
@@ -28,12 +30,98 @@ import (
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
 )
 
+// xidContextFields are the fields snapshotted with the XID errors when XID correlation is enabled
+var xidContextFields = []dcgm.Short{
+	dcgm.DCGM_FI_DEV_CLOCKS_EVENT_REASONS,
+	dcgm.DCGM_FI_DEV_GPU_TEMP,
+	dcgm.DCGM_FI_DEV_POWER_USAGE,
+}
+
 type xidCollector struct {
 	expCollector
+	correlate bool // Attach the clock events, temperature and power at the last occurrence of every XID
 }
 
 func (c *xidCollector) GetMetrics() (MetricsByCounter, error) {
-	return c.expCollector.getMetrics()
+	if !c.correlate {
+		return c.expCollector.getMetrics()
+	}
+
+	values, err := c.readValues()
+	if err != nil {
+		return nil, err
+	}
+
+	var xids, contextValues []dcgm.FieldValue_v2
+	for _, val := range values {
+		if val.FieldID == dcgm.DCGM_FI_DEV_XID_ERRORS {
+			xids = append(xids, val)
+		} else {
+			contextValues = append(contextValues, val)
+		}
+	}
+
+	snapshots := xidSnapshots(xids, contextValues)
+	return c.toMetrics(xids, func(entityID uint, xid int64) map[string]string {
+		return snapshots[entityID][xid]
+	})
+}
+
+// xidSnapshots returns, per entity and XID, the attributes of the context values the closest in time to the
+// last occurrence of the XID.
+func xidSnapshots(xids, contextValues []dcgm.FieldValue_v2) map[uint]map[int64]map[string]string {
+	last := map[uint]map[int64]int64{} // entity -> XID -> timestamp of its last occurrence
+	for _, val := range xids {
+		if val.Status != 0 || isBlankValue(val) {
+			continue
+		}
+		if _, exists := last[val.EntityID]; !exists {
+			last[val.EntityID] = map[int64]int64{}
+		}
+		if ts, exists := last[val.EntityID][val.Int64()]; !exists || val.TS > ts {
+			last[val.EntityID][val.Int64()] = val.TS
+		}
+	}
+
+	snapshots := map[uint]map[int64]map[string]string{}
+	for entityID, xidTimestamps := range last {
+		snapshots[entityID] = map[int64]map[string]string{}
+		for xid, ts := range xidTimestamps {
+			closest := map[dcgm.Short]dcgm.FieldValue_v2{}
+			for _, val := range contextValues {
+				if val.EntityID != entityID || val.Status != 0 || isBlankValue(val) {
+					continue
+				}
+				if current, exists := closest[val.FieldID]; !exists || absDiff(val.TS, ts) < absDiff(current.TS, ts) {
+					closest[val.FieldID] = val
+				}
+			}
+			snapshots[entityID][xid] = xidContextAttributes(closest)
+		}
+	}
+	return snapshots
+}
+
+// xidContextAttributes converts the context values of an XID into metric attributes
+func xidContextAttributes(values map[dcgm.Short]dcgm.FieldValue_v2) map[string]string {
+	attributes := map[string]string{}
+	if val, exists := values[dcgm.DCGM_FI_DEV_CLOCKS_EVENT_REASONS]; exists {
+		attributes[xidClockEventsAttribute] = strings.Join(ClockEventReasons(val.Int64()), ",")
+	}
+	if val, exists := values[dcgm.DCGM_FI_DEV_GPU_TEMP]; exists {
+		attributes[xidGPUTempAttribute] = fmt.Sprint(val.Int64())
+	}
+	if val, exists := values[dcgm.DCGM_FI_DEV_POWER_USAGE]; exists {
+		attributes[xidPowerUsageAttribute] = strconv.FormatFloat(val.Float64(), 'f', 1, 64)
+	}
+	return attributes
+}
+
+func absDiff(a, b int64) int64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
 }
 
 func NewXIDCollector(
@@ -47,9 +135,13 @@ func NewXIDCollector(
 		return nil, fmt.Errorf(counters.DCGMExpXIDErrorsCount + " collector is disabled")
 	}
 
-	collector := xidCollector{}
+	collector := xidCollector{correlate: config.XIDCorrelation}
 	var err error
-	deviceWatchList.SetDeviceFields([]dcgm.Short{dcgm.DCGM_FI_DEV_XID_ERRORS})
+	deviceFields := []dcgm.Short{dcgm.DCGM_FI_DEV_XID_ERRORS}
+	if collector.correlate {
+		deviceFields = append(deviceFields, xidContextFields...)
+	}
+	deviceWatchList.SetDeviceFields(deviceFields)
 
 	collector.expCollector, err = newExpCollector(
 		counterList.LabelCounters(),
@@ -66,7 +158,7 @@ func NewXIDCollector(
 	})]
 
 	collector.labelFiller = func(metricValueLabels map[string]string, entityValue int64) {
-		metricValueLabels["xid"] = fmt.Sprint(entityValue)
+		metricValueLabels[xidLabel] = fmt.Sprint(entityValue)
 	}
 
 	collector.windowSize = config.XIDCountWindowSize
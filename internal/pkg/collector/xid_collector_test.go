@@ -192,7 +192,7 @@ func TestNewXIDCollector(t *testing.T) {
 			) Collector {
 				deviceWatchList.SetDeviceFields([]dcgm.Short{dcgm.DCGM_FI_DEV_XID_ERRORS})
 				return &xidCollector{
-					expCollector{
+					expCollector: expCollector{
 						baseExpCollector: baseExpCollector{
 							deviceWatchList: deviceWatchList,
 							counter:         sampleDCGMExpXIDCounter,
@@ -231,7 +231,7 @@ func TestNewXIDCollector(t *testing.T) {
 			) Collector {
 				deviceWatchList.SetDeviceFields([]dcgm.Short{dcgm.DCGM_FI_DEV_XID_ERRORS})
 				return &xidCollector{
-					expCollector{
+					expCollector: expCollector{
 						baseExpCollector: baseExpCollector{
 							deviceWatchList: deviceWatchList,
 							counter:         sampleDCGMExpXIDCounter,
@@ -601,3 +601,40 @@ func Test_xidCollector_GetMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestXIDSnapshots(t *testing.T) {
+	int64Value := func(gpu uint, fieldID dcgm.Short, value int64, ts int64) dcgm.FieldValue_v2 {
+		return dcgm.FieldValue_v2{
+			EntityID: gpu, FieldID: fieldID, FieldType: dcgm.DCGM_FT_INT64, TS: ts, Value: createInt64ByteArray(value),
+		}
+	}
+	powerValue := func(gpu uint, value float64, ts int64) dcgm.FieldValue_v2 {
+		return dcgm.FieldValue_v2{
+			EntityID: gpu, FieldID: dcgm.DCGM_FI_DEV_POWER_USAGE, FieldType: dcgm.DCGM_FT_DOUBLE, TS: ts,
+			Value: createFloat64ByteArray(value),
+		}
+	}
+
+	xids := []dcgm.FieldValue_v2{
+		int64Value(0, dcgm.DCGM_FI_DEV_XID_ERRORS, 79, 1000),
+		int64Value(0, dcgm.DCGM_FI_DEV_XID_ERRORS, 79, 3000),
+		int64Value(1, dcgm.DCGM_FI_DEV_XID_ERRORS, 48, 2000),
+	}
+	contextValues := []dcgm.FieldValue_v2{
+		int64Value(0, dcgm.DCGM_FI_DEV_GPU_TEMP, 60, 900),
+		int64Value(0, dcgm.DCGM_FI_DEV_GPU_TEMP, 91, 2900),
+		int64Value(0, dcgm.DCGM_FI_DEV_CLOCKS_EVENT_REASONS,
+			int64(DCGM_CLOCKS_THROTTLE_REASON_SW_THERMAL|DCGM_CLOCKS_THROTTLE_REASON_HW_THERMAL), 3100),
+		powerValue(0, 301.3, 3000),
+		int64Value(1, dcgm.DCGM_FI_DEV_GPU_TEMP, 45, 2000),
+	}
+
+	assert.Equal(t, map[uint]map[int64]map[string]string{
+		0: {79: {
+			xidClockEventsAttribute: "hw_thermal,sw_thermal",
+			xidGPUTempAttribute:     "91",
+			xidPowerUsageAttribute:  "301.3",
+		}},
+		1: {48: {xidGPUTempAttribute: "45"}},
+	}, xidSnapshots(xids, contextValues))
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+)
+
+// seededXIDs are exported with a zero value before they occur, so that increase() and rate()
+// see their first occurrence: 48 (double bit ECC error), 63 (row remapping) and 79 (fallen off the bus).
+var seededXIDs = []int64{48, 63, 79}
+
+// IsDCGMExpXIDErrorsTotalEnabled checks if the DCGM_EXP_XID_ERRORS_TOTAL counter exists
+func IsDCGMExpXIDErrorsTotalEnabled(counterList counters.CounterList) bool {
+	return slices.ContainsFunc(counterList, func(c counters.Counter) bool {
+		return c.FieldName == counters.DCGMExpXIDErrorsTotal
+	})
+}
+
+// xidTotalCollector counts the XID errors of every GPU per XID code since the start of the exporter.
+// Unlike DCGM_EXP_XID_ERRORS_COUNT, which counts the errors within the window, the counters never decrease;
+// the window is how far back the XID errors are read, so it must exceed the interval between two scrapes.
+type xidTotalCollector struct {
+	baseExpCollector
+	windowSize int
+
+	mtx    sync.Mutex
+	totals map[uint]map[int64]int // GPU -> XID -> number of errors
+	lastTS map[uint]int64         // GPU -> timestamp of the last counted error
+}
+
+func NewXIDTotalCollector(
+	counterList counters.CounterList,
+	hostname string,
+	config *appconfig.Config,
+	deviceWatchList devicewatchlistmanager.WatchList,
+) (Collector, error) {
+	if !IsDCGMExpXIDErrorsTotalEnabled(counterList) {
+		slog.Error(counters.DCGMExpXIDErrorsTotal + " collector is disabled")
+		return nil, errors.New(counters.DCGMExpXIDErrorsTotal + " collector is disabled")
+	}
+
+	deviceWatchList.SetDeviceFields([]dcgm.Short{dcgm.DCGM_FI_DEV_XID_ERRORS})
+
+	expCollector, err := newExpCollector(
+		counterList.LabelCounters(),
+		hostname,
+		config,
+		deviceWatchList,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	collector := xidTotalCollector{
+		baseExpCollector: expCollector.baseExpCollector,
+		windowSize:       config.XIDCountWindowSize,
+		totals:           map[uint]map[int64]int{},
+		lastTS:           map[uint]int64{},
+	}
+
+	collector.counter = counterList[slices.IndexFunc(counterList, func(c counters.Counter) bool {
+		return c.FieldName == counters.DCGMExpXIDErrorsTotal
+	})]
+
+	return &collector, nil
+}
+
+func (c *xidTotalCollector) GetMetrics() (MetricsByCounter, error) {
+	err := dcgmprovider.Client().UpdateAllFields()
+	if err != nil {
+		return nil, err
+	}
+
+	window := time.Now().Add(-time.Duration(c.windowSize) * time.Millisecond)
+
+	var samples []dcgm.FieldValue_v2
+	for _, group := range c.deviceWatchList.DeviceGroups() {
+		values, _, err := dcgmprovider.Client().GetValuesSince(group, c.deviceWatchList.DeviceFieldGroup(), window)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, values...)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.count(samples)
+
+	uuid := "UUID"
+	if c.config.UseOldNamespace {
+		uuid = "uuid"
+	}
+
+	metrics := MetricsByCounter{}
+	labels := map[string]string{}
+
+	for _, mi := range devicemonitoring.GetMonitoredEntities(c.deviceWatchList.DeviceInfo()) {
+		if len(c.labelsCounters) > 0 && len(c.deviceWatchList.LabelDeviceFields()) > 0 {
+			err := c.getLabelsFromCounters(mi, labels)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		totals := maps.Clone(c.totals[mi.DeviceInfo.GPU])
+		if totals == nil {
+			totals = map[int64]int{}
+		}
+		for _, xid := range seededXIDs {
+			if _, exists := totals[xid]; !exists {
+				totals[xid] = 0
+			}
+		}
+
+		for _, xid := range slices.Sorted(maps.Keys(totals)) {
+			metricValueLabels := maps.Clone(labels)
+			metricValueLabels[xidLabel] = fmt.Sprint(xid)
+			metrics[c.counter] = append(metrics[c.counter], c.createMetric(metricValueLabels, mi, uuid, totals[xid]))
+		}
+	}
+
+	return metrics, nil
+}
+
+// count adds the XID errors more recent than the last counted error of their GPU to the totals
+func (c *xidTotalCollector) count(samples []dcgm.FieldValue_v2) {
+	lastTS := maps.Clone(c.lastTS)
+
+	for _, val := range samples {
+		if val.Status != 0 || val.FieldID != dcgm.DCGM_FI_DEV_XID_ERRORS || isBlankValue(val) {
+			continue
+		}
+
+		if val.TS <= c.lastTS[val.EntityID] {
+			continue
+		}
+
+		if _, exists := c.totals[val.EntityID]; !exists {
+			c.totals[val.EntityID] = map[int64]int{}
+		}
+		c.totals[val.EntityID][val.Int64()]++
+
+		lastTS[val.EntityID] = max(lastTS[val.EntityID], val.TS)
+	}
+
+	c.lastTS = lastTS
+}
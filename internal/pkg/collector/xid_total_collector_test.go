@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdcgm "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/dcgmprovider"
+	mockdevicewatcher "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/devicewatcher"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/testutils"
+)
+
+func Test_xidTotalCollector_GetMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDCGM := mockdcgm.NewMockDCGM(ctrl)
+	mockDeviceWatcher := mockdevicewatcher.NewMockWatcher(ctrl)
+
+	realDCGM := dcgmprovider.Client()
+	defer func() {
+		dcgmprovider.SetClient(realDCGM)
+	}()
+	dcgmprovider.SetClient(mockDCGM)
+
+	counter := counters.Counter{
+		FieldID:   dcgm.Short(counters.DCGMXIDErrorsTotal),
+		FieldName: counters.DCGMExpXIDErrorsTotal,
+		PromType:  "counter",
+	}
+
+	mockGPUDeviceInfo := testutils.MockGPUDeviceInfo(ctrl, 2, nil)
+	mockGPUDeviceInfo.EXPECT().GOpts().Return(appconfig.DeviceOptions{Flex: true}).AnyTimes()
+
+	groupHandle := dcgm.GroupHandle{}
+	groupHandle.SetHandle(uintptr(1))
+
+	fieldGroupHandle := dcgm.FieldHandle{}
+	fieldGroupHandle.SetHandle(uintptr(1))
+
+	mockDeviceWatcher.EXPECT().WatchDeviceFields([]dcgm.Short{dcgm.DCGM_FI_DEV_XID_ERRORS}, gomock.Any(), gomock.Any()).
+		Return([]dcgm.GroupHandle{groupHandle}, fieldGroupHandle, nil, nil)
+
+	deviceWatchList := devicewatchlistmanager.NewWatchList(mockGPUDeviceInfo, nil, nil, mockDeviceWatcher, 1)
+	collector, err := NewXIDTotalCollector(counters.CounterList{counter}, "localhost",
+		&appconfig.Config{XIDCountWindowSize: 60000}, *deviceWatchList)
+	require.NoError(t, err)
+
+	xid := func(gpu uint, code byte, ts int64) dcgm.FieldValue_v2 {
+		return dcgm.FieldValue_v2{
+			EntityID:  gpu,
+			FieldID:   dcgm.DCGM_FI_DEV_XID_ERRORS,
+			FieldType: dcgm.DCGM_FT_INT64,
+			TS:        ts,
+			Value:     [4096]byte{code},
+		}
+	}
+
+	getTotals := func(samples ...dcgm.FieldValue_v2) map[string]string {
+		mockDCGM.EXPECT().UpdateAllFields().Return(nil)
+		mockDCGM.EXPECT().GetValuesSince(groupHandle, fieldGroupHandle, gomock.AssignableToTypeOf(time.Time{})).
+			Return(samples, time.Time{}, nil)
+
+		metrics, err := collector.GetMetrics()
+		require.NoError(t, err)
+
+		totals := map[string]string{}
+		for _, m := range metrics[counter] {
+			totals[m.GPU+"/"+m.Labels[xidLabel]] = m.Value
+		}
+		return totals
+	}
+
+	assert.Equal(t, map[string]string{
+		"0/48": "0", "0/63": "0", "0/79": "2", "0/13": "1",
+		"1/48": "0", "1/63": "0", "1/79": "0",
+	}, getTotals(xid(0, 79, 1), xid(0, 79, 2), xid(0, 13, 2)))
+
+	// The errors still within the window are counted once
+	assert.Equal(t, map[string]string{
+		"0/48": "0", "0/63": "0", "0/79": "3", "0/13": "1",
+		"1/48": "1", "1/63": "0", "1/79": "0",
+	}, getTotals(xid(0, 79, 1), xid(0, 79, 2), xid(0, 13, 2), xid(0, 79, 3), xid(1, 48, 1)))
+
+	// The counters don't decrease when the errors leave the window
+	assert.Equal(t, "3", getTotals()["0/79"])
+}
@@ -16,15 +16,50 @@
 
 package counters
 
+import "time"
+
 const (
 	undefinedConfigMapData = "none"
 
+	// counterLabelsSeparator separates the labels in the labels column of the counters file
+	counterLabelsSeparator = ";"
+
+	// minCounterUpdateInterval is the lowest update interval a counter may set
+	minCounterUpdateInterval = 100 * time.Millisecond
+
 	cpuFieldsStart = 1100
 	dcpFieldsStart = 1000
 
-	DCGMExpClockEventsCount = "DCGM_EXP_CLOCK_EVENTS_COUNT"
-	DCGMExpXIDErrorsCount   = "DCGM_EXP_XID_ERRORS_COUNT"
-	DCGMExpGPUHealthStatus  = "DCGM_EXP_GPU_HEALTH_STATUS"
-	DCGMExpP2PStatus        = "DCGM_EXP_P2P_STATUS"
-	DCGMExpWeightedGPUUtil  = "DCGM_FI_DEV_WEIGHTED_GPU_UTIL"
+	DCGMExpClockEventsCount      = "DCGM_EXP_CLOCK_EVENTS_COUNT"
+	DCGMExpXIDErrorsCount        = "DCGM_EXP_XID_ERRORS_COUNT"
+	DCGMExpGPUHealthStatus       = "DCGM_EXP_GPU_HEALTH_STATUS"
+	DCGMExpP2PStatus             = "DCGM_EXP_P2P_STATUS"
+	DCGMExpWeightedGPUUtil       = "DCGM_FI_DEV_WEIGHTED_GPU_UTIL"
+	DCGMExpFieldStaleness        = "dcgm_exp_field_staleness_seconds"
+	DCGMExpNVLinkErrorsCount     = "DCGM_EXP_NVLINK_ERRORS_COUNT"
+	DCGMExpHealthStatus          = "DCGM_EXP_HEALTH_STATUS"
+	DCGMExpGPUDeviceInfo         = "DCGM_EXP_GPU_DEVICE_INFO"
+	DCGMExpXIDErrorsTotal        = "DCGM_EXP_XID_ERRORS_TOTAL"
+	DCGMExpClockThrottleDuration = "DCGM_EXP_CLOCK_THROTTLE_DURATION_SECONDS"
+	DCGMExpProcessSMUtil         = "DCGM_EXP_PROCESS_SM_UTIL"
+	DCGMExpProcessMemUtil        = "DCGM_EXP_PROCESS_MEM_UTIL"
+	DCGMExpProcessEncUtil        = "DCGM_EXP_PROCESS_ENC_UTIL"
+	DCGMExpProcessDecUtil        = "DCGM_EXP_PROCESS_DEC_UTIL"
+	DCGMExpAccountingGPUUtil     = "DCGM_EXP_ACCOUNTING_GPU_UTIL"
+	DCGMExpAccountingMemUtil     = "DCGM_EXP_ACCOUNTING_MEM_UTIL"
+	DCGMExpAccountingMaxMemory   = "DCGM_EXP_ACCOUNTING_MAX_MEMORY_BYTES"
+	DCGMExpNVSwitchPortStatus    = "DCGM_EXP_NVSWITCH_PORT_STATUS"
+	DCGMExpGPUNeedsReset         = "DCGM_EXP_GPU_NEEDS_RESET"
+	DCGMExpEnergyTotal           = "DCGM_EXP_ENERGY_JOULES_TOTAL"
+	DCGMExpFieldStatus           = "dcgm_exp_field_status"
+	DCGMExpMIGFreeSlices         = "DCGM_EXP_MIG_FREE_SLICES"
+	DCGMExpMIGLargestFreeProfile = "DCGM_EXP_MIG_LARGEST_FREE_PROFILE_SLICES"
+	DCGMExpMIGFragmentation      = "DCGM_EXP_MIG_FRAGMENTATION_RATIO"
+	DCGMExpMPSDaemonUp           = "DCGM_EXP_MPS_DAEMON_UP"
+	DCGMExpMPSActiveClients      = "DCGM_EXP_MPS_ACTIVE_CLIENTS"
+	DCGMExpMPSThreadPercentage   = "DCGM_EXP_MPS_ACTIVE_THREAD_PERCENTAGE"
+	DCGMExpMPSServerUp           = "DCGM_EXP_MPS_SERVER_UP"
+	DCGMExpMPSClientThreadPct    = "DCGM_EXP_MPS_CLIENT_ACTIVE_THREAD_PERCENTAGE"
+	DCGMExpComputeMode           = "DCGM_EXP_COMPUTE_MODE"
+	DCGMExpDeviceLabelsInfo      = "dcgm_exp_device_labels_info"
 )
@@ -21,7 +21,11 @@ import (
 	"encoding/csv"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
 	corev1 "k8s.io/api/core/v1"
@@ -44,13 +48,11 @@ func GetCounterSet(ctx context.Context, c *appconfig.Config) (*CounterSet, error
 		var client kubernetes.Interface
 		client, err = kubeclient.GetKubeClient()
 		if err != nil {
-			slog.Error(err.Error())
-			os.Exit(1)
+			return res, fmt.Errorf("failed to get kube client: %w", err)
 		}
 		records, err = readConfigMap(ctx, client, c)
 		if err != nil {
-			slog.Error(err.Error())
-			os.Exit(1)
+			return res, err
 		}
 	} else {
 		err = fmt.Errorf("no configmap data specified")
@@ -84,6 +86,7 @@ func ReadCSVFile(filename string) ([][]string, error) {
 
 	r := csv.NewReader(file)
 	r.Comment = '#'
+	r.FieldsPerRecord = -1 // The labels and update interval columns are optional
 	records, err := r.ReadAll()
 
 	return records, err
@@ -101,12 +104,29 @@ func ExtractCounters(records [][]string, c *appconfig.Config) (*CounterSet, erro
 			record[j] = strings.Trim(r, " ")
 		}
 
-		if len(record) != 3 {
+		if len(record) < 3 || len(record) > 5 {
 			return nil, fmt.Errorf("malformed CSV record; err: failed to parse line %d (`%v`), "+
-				"expected 3 fields", i,
+				"expected 3 to 5 fields", i,
 				record)
 		}
 
+		var labels string
+		if len(record) >= 4 {
+			var err error
+			if labels, err = parseCounterLabels(record[3]); err != nil {
+				return nil, fmt.Errorf("malformed CSV record; err: failed to parse the labels of line %d: %w", i, err)
+			}
+		}
+
+		var updateInterval time.Duration
+		if len(record) == 5 {
+			var err error
+			if updateInterval, err = parseCounterUpdateInterval(record[4]); err != nil {
+				return nil, fmt.Errorf("malformed CSV record; err: failed to parse the update interval of line %d: %w",
+					i, err)
+			}
+		}
+
 		fieldID, ok := dcgm.GetFieldID(record[0])
 		isLegacyField := dcgm.IsLegacyField(record[0])
 
@@ -122,11 +142,13 @@ func ExtractCounters(records [][]string, c *appconfig.Config) (*CounterSet, erro
 						FieldName: record[0],
 						PromType:  record[1],
 						Help:      record[2],
+						Labels:    labels,
 					})
 				continue
 			}
 		}
 
+		// Profiling fields are skipped along with their update interval unless DCP is collected and supported
 		if !fieldIsSupported(uint(fieldID), c) {
 			slog.Warn(fmt.Sprintf("Skipping line %d ('%s'): metric not enabled", i, record[0]))
 			continue
@@ -137,12 +159,71 @@ func ExtractCounters(records [][]string, c *appconfig.Config) (*CounterSet, erro
 		}
 
 		res.DCGMCounters = append(res.DCGMCounters,
-			Counter{FieldID: fieldID, FieldName: record[0], PromType: record[1], Help: record[2]})
+			Counter{
+				FieldID:        fieldID,
+				FieldName:      record[0],
+				PromType:       record[1],
+				Help:           record[2],
+				Labels:         labels,
+				UpdateInterval: updateInterval,
+			})
 	}
 
 	return &res, nil
 }
 
+// parseCounterLabels parses the static labels of a counter in the format <name>=<value>;<name>=<value>,
+// the values may be quoted. It returns them in the canonical format of Counter.Labels.
+func parseCounterLabels(value string) (string, error) {
+	labels := map[string]string{}
+	for _, label := range strings.Split(value, counterLabelsSeparator) {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+
+		name, labelValue, found := strings.Cut(label, "=")
+		name, labelValue = strings.TrimSpace(name), strings.TrimSpace(labelValue)
+		if unquoted, err := strconv.Unquote(labelValue); err == nil {
+			labelValue = unquoted
+		}
+
+		if !found || !labelNameRegex.MatchString(name) || strings.HasPrefix(name, "__") {
+			return "", fmt.Errorf("invalid label '%s'", label)
+		}
+		if strings.ContainsAny(labelValue, "\"\\\n") || strings.Contains(labelValue, counterLabelsSeparator) {
+			return "", fmt.Errorf("invalid value of label '%s'", name)
+		}
+		if _, exists := labels[name]; exists {
+			return "", fmt.Errorf("label '%s' is set twice", name)
+		}
+		labels[name] = labelValue
+	}
+
+	pairs := make([]string, 0, len(labels))
+	for _, name := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, name+"="+labels[name])
+	}
+	return strings.Join(pairs, counterLabelsSeparator), nil
+}
+
+// parseCounterUpdateInterval parses the update interval of a counter, e.g. 1s or 5m, an empty value keeps the
+// collect interval.
+func parseCounterUpdateInterval(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if interval < minCounterUpdateInterval {
+		return 0, fmt.Errorf("update interval '%s' is lower than %s", value, minCounterUpdateInterval)
+	}
+	return interval.Truncate(time.Millisecond), nil
+}
+
 func fieldIsSupported(fieldID uint, c *appconfig.Config) bool {
 	if fieldID < dcpFieldsStart || fieldID >= cpuFieldsStart {
 		return true
@@ -181,6 +262,7 @@ func readConfigMap(ctx context.Context, kubeClient kubernetes.Interface, c *appc
 
 	r := csv.NewReader(strings.NewReader(cm.Data["metrics"]))
 	r.Comment = '#'
+	r.FieldsPerRecord = -1 // The labels and update interval columns are optional
 	records, err := r.ReadAll()
 
 	if len(records) == 0 {
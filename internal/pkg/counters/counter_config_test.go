@@ -20,7 +20,9 @@ import (
 	"context"
 	stdos "os"
 	"testing"
+	"time"
 
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
@@ -125,6 +127,26 @@ func TestExtractCounters(t *testing.T) {
 			field: "DCGM_FI_DEV_GPU_TEMP, gauge, temperature\n",
 			valid: true,
 		},
+		{
+			name:  "Valid Input with labels",
+			field: "DCGM_FI_DEV_GPU_TEMP, gauge, temperature, tier=hw;team=infra\n",
+			valid: true,
+		},
+		{
+			name:  "Valid Input with update interval",
+			field: "DCGM_FI_DEV_GPU_TEMP, gauge, temperature, , 5m\n",
+			valid: true,
+		},
+		{
+			name:  "Invalid update interval",
+			field: "DCGM_FI_DEV_GPU_TEMP, gauge, temperature, , 10ms\n",
+			valid: false,
+		},
+		{
+			name:  "Invalid labels",
+			field: "DCGM_FI_DEV_GPU_TEMP, gauge, temperature, tier-name=hw\n",
+			valid: false,
+		},
 		{
 			name:  "Invalid Input DCGM_EXP_XID_ERRORS_COUNTXXX",
 			field: "DCGM_EXP_XID_ERRORS_COUNTXXX, gauge, temperature\n",
@@ -171,3 +193,68 @@ func extractCountersHelper(t *testing.T, input string, valid bool) {
 		assert.Nil(t, cc, "Expected no counters.")
 	}
 }
+
+func TestExtractCounters_Labels(t *testing.T) {
+	records := [][]string{
+		{"DCGM_FI_DEV_GPU_TEMP", "gauge", "temperature"},
+		{"DCGM_FI_DEV_SM_CLOCK", "gauge", "SM clock", ` team = ml ; tier = "hw" `},
+	}
+
+	cs, err := ExtractCounters(records, &appconfig.Config{})
+	require.NoError(t, err)
+	require.Len(t, cs.DCGMCounters, 2)
+
+	assert.Empty(t, cs.DCGMCounters[0].Labels)
+	assert.Nil(t, cs.DCGMCounters[0].StaticLabels())
+	assert.Equal(t, "team=ml;tier=hw", cs.DCGMCounters[1].Labels)
+	assert.Equal(t, map[string]string{"team": "ml", "tier": "hw"}, cs.DCGMCounters[1].StaticLabels())
+}
+
+func TestParseCounterLabels_Invalid(t *testing.T) {
+	for _, value := range []string{"tier", "__name__=x", "tier=a;tier=b", `tier="a\"b"`} {
+		_, err := parseCounterLabels(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestExtractCounters_UpdateInterval(t *testing.T) {
+	records := [][]string{
+		{"DCGM_FI_DEV_GPU_TEMP", "gauge", "temperature"},
+		{"DCGM_FI_PROF_GR_ENGINE_ACTIVE", "gauge", "graphics engine active", "", "1s"},
+		{"DCGM_FI_DEV_SM_CLOCK", "gauge", "SM clock", "tier=hw", "5m"},
+	}
+
+	c := &appconfig.Config{
+		CollectDCP:   true,
+		MetricGroups: []dcgm.MetricGroup{{FieldIds: []uint{uint(dcgm.DCGM_FI_PROF_GR_ENGINE_ACTIVE)}}},
+	}
+
+	cs, err := ExtractCounters(records, c)
+	require.NoError(t, err)
+	require.Len(t, cs.DCGMCounters, 3)
+
+	assert.Zero(t, cs.DCGMCounters[0].UpdateInterval)
+	assert.Equal(t, time.Second, cs.DCGMCounters[1].UpdateInterval)
+	assert.Empty(t, cs.DCGMCounters[1].Labels)
+	assert.Equal(t, 5*time.Minute, cs.DCGMCounters[2].UpdateInterval)
+	assert.Equal(t, "tier=hw", cs.DCGMCounters[2].Labels)
+}
+
+func TestExtractCounters_UpdateInterval_ProfilingFieldNotEnabled(t *testing.T) {
+	records := [][]string{
+		{"DCGM_FI_PROF_GR_ENGINE_ACTIVE", "gauge", "graphics engine active", "", "1s"},
+		{"DCGM_FI_DEV_SM_CLOCK", "gauge", "SM clock", "", "5m"},
+	}
+
+	// The profiling field is skipped with its update interval when DCP is not collected
+	cs, err := ExtractCounters(records, &appconfig.Config{})
+	require.NoError(t, err)
+	require.Len(t, cs.DCGMCounters, 1)
+	assert.Equal(t, "DCGM_FI_DEV_SM_CLOCK", cs.DCGMCounters[0].FieldName)
+	assert.Equal(t, 5*time.Minute, cs.DCGMCounters[0].UpdateInterval)
+
+	// The update interval of a skipped profiling field is still validated
+	records[0][4] = "10ms"
+	_, err = ExtractCounters(records, &appconfig.Config{})
+	assert.Error(t, err)
+}
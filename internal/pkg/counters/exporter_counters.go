@@ -21,12 +21,38 @@ import "fmt"
 type ExporterCounter uint16
 
 const (
-	DCGMFIUnknown        ExporterCounter = 0
-	DCGMXIDErrorsCount   ExporterCounter = iota + 9000
-	DCGMClockEventsCount ExporterCounter = iota + 9000
-	DCGMGPUHealthStatus  ExporterCounter = iota + 9000
-	DCGMP2PStatus        ExporterCounter = iota + 9000
-	DCGMWeightedGPUUtil  ExporterCounter = iota + 9000
+	DCGMFIUnknown             ExporterCounter = 0
+	DCGMXIDErrorsCount        ExporterCounter = iota + 9000
+	DCGMClockEventsCount      ExporterCounter = iota + 9000
+	DCGMGPUHealthStatus       ExporterCounter = iota + 9000
+	DCGMP2PStatus             ExporterCounter = iota + 9000
+	DCGMWeightedGPUUtil       ExporterCounter = iota + 9000
+	DCGMFieldStaleness        ExporterCounter = iota + 9000
+	DCGMNVLinkErrorsCount     ExporterCounter = iota + 9000
+	DCGMHealthStatus          ExporterCounter = iota + 9000
+	DCGMGPUDeviceInfo         ExporterCounter = iota + 9000
+	DCGMXIDErrorsTotal        ExporterCounter = iota + 9000
+	DCGMClockThrottleDuration ExporterCounter = iota + 9000
+	DCGMProcessSMUtil         ExporterCounter = iota + 9000
+	DCGMProcessMemUtil        ExporterCounter = iota + 9000
+	DCGMProcessEncUtil        ExporterCounter = iota + 9000
+	DCGMProcessDecUtil        ExporterCounter = iota + 9000
+	DCGMAccountingGPUUtil     ExporterCounter = iota + 9000
+	DCGMAccountingMemUtil     ExporterCounter = iota + 9000
+	DCGMAccountingMaxMemory   ExporterCounter = iota + 9000
+	DCGMNVSwitchPortStatus    ExporterCounter = iota + 9000
+	DCGMGPUNeedsReset         ExporterCounter = iota + 9000
+	DCGMEnergyTotal           ExporterCounter = iota + 9000
+	DCGMFieldStatus           ExporterCounter = iota + 9000
+	DCGMMIGFreeSlices         ExporterCounter = iota + 9000
+	DCGMMIGLargestFreeProfile ExporterCounter = iota + 9000
+	DCGMMIGFragmentation      ExporterCounter = iota + 9000
+	DCGMMPSDaemonUp           ExporterCounter = iota + 9000
+	DCGMMPSActiveClients      ExporterCounter = iota + 9000
+	DCGMMPSThreadPercentage   ExporterCounter = iota + 9000
+	DCGMMPSServerUp           ExporterCounter = iota + 9000
+	DCGMMPSClientThreadPct    ExporterCounter = iota + 9000
+	DCGMComputeMode           ExporterCounter = iota + 9000
 )
 
 // String method to convert the enum value to a string
@@ -42,6 +68,58 @@ func (enm ExporterCounter) String() string {
 		return DCGMExpP2PStatus
 	case DCGMWeightedGPUUtil:
 		return DCGMExpWeightedGPUUtil
+	case DCGMFieldStaleness:
+		return DCGMExpFieldStaleness
+	case DCGMNVLinkErrorsCount:
+		return DCGMExpNVLinkErrorsCount
+	case DCGMHealthStatus:
+		return DCGMExpHealthStatus
+	case DCGMGPUDeviceInfo:
+		return DCGMExpGPUDeviceInfo
+	case DCGMXIDErrorsTotal:
+		return DCGMExpXIDErrorsTotal
+	case DCGMClockThrottleDuration:
+		return DCGMExpClockThrottleDuration
+	case DCGMProcessSMUtil:
+		return DCGMExpProcessSMUtil
+	case DCGMProcessMemUtil:
+		return DCGMExpProcessMemUtil
+	case DCGMProcessEncUtil:
+		return DCGMExpProcessEncUtil
+	case DCGMProcessDecUtil:
+		return DCGMExpProcessDecUtil
+	case DCGMAccountingGPUUtil:
+		return DCGMExpAccountingGPUUtil
+	case DCGMAccountingMemUtil:
+		return DCGMExpAccountingMemUtil
+	case DCGMAccountingMaxMemory:
+		return DCGMExpAccountingMaxMemory
+	case DCGMNVSwitchPortStatus:
+		return DCGMExpNVSwitchPortStatus
+	case DCGMGPUNeedsReset:
+		return DCGMExpGPUNeedsReset
+	case DCGMEnergyTotal:
+		return DCGMExpEnergyTotal
+	case DCGMFieldStatus:
+		return DCGMExpFieldStatus
+	case DCGMMIGFreeSlices:
+		return DCGMExpMIGFreeSlices
+	case DCGMMIGLargestFreeProfile:
+		return DCGMExpMIGLargestFreeProfile
+	case DCGMMIGFragmentation:
+		return DCGMExpMIGFragmentation
+	case DCGMMPSDaemonUp:
+		return DCGMExpMPSDaemonUp
+	case DCGMMPSActiveClients:
+		return DCGMExpMPSActiveClients
+	case DCGMMPSThreadPercentage:
+		return DCGMExpMPSThreadPercentage
+	case DCGMMPSServerUp:
+		return DCGMExpMPSServerUp
+	case DCGMMPSClientThreadPct:
+		return DCGMExpMPSClientThreadPct
+	case DCGMComputeMode:
+		return DCGMExpComputeMode
 	default:
 		return "DCGM_FI_UNKNOWN"
 	}
@@ -49,12 +127,38 @@ func (enm ExporterCounter) String() string {
 
 // DCGMFields maps DCGMExporterMetric String to enum
 var DCGMFields = map[string]ExporterCounter{
-	DCGMXIDErrorsCount.String():   DCGMXIDErrorsCount,
-	DCGMClockEventsCount.String(): DCGMClockEventsCount,
-	DCGMGPUHealthStatus.String():  DCGMGPUHealthStatus,
-	DCGMP2PStatus.String():        DCGMP2PStatus,
-	DCGMWeightedGPUUtil.String():  DCGMWeightedGPUUtil,
-	DCGMFIUnknown.String():        DCGMFIUnknown,
+	DCGMXIDErrorsCount.String():        DCGMXIDErrorsCount,
+	DCGMClockEventsCount.String():      DCGMClockEventsCount,
+	DCGMGPUHealthStatus.String():       DCGMGPUHealthStatus,
+	DCGMP2PStatus.String():             DCGMP2PStatus,
+	DCGMWeightedGPUUtil.String():       DCGMWeightedGPUUtil,
+	DCGMFieldStaleness.String():        DCGMFieldStaleness,
+	DCGMNVLinkErrorsCount.String():     DCGMNVLinkErrorsCount,
+	DCGMHealthStatus.String():          DCGMHealthStatus,
+	DCGMGPUDeviceInfo.String():         DCGMGPUDeviceInfo,
+	DCGMXIDErrorsTotal.String():        DCGMXIDErrorsTotal,
+	DCGMClockThrottleDuration.String(): DCGMClockThrottleDuration,
+	DCGMProcessSMUtil.String():         DCGMProcessSMUtil,
+	DCGMProcessMemUtil.String():        DCGMProcessMemUtil,
+	DCGMProcessEncUtil.String():        DCGMProcessEncUtil,
+	DCGMProcessDecUtil.String():        DCGMProcessDecUtil,
+	DCGMAccountingGPUUtil.String():     DCGMAccountingGPUUtil,
+	DCGMAccountingMemUtil.String():     DCGMAccountingMemUtil,
+	DCGMAccountingMaxMemory.String():   DCGMAccountingMaxMemory,
+	DCGMNVSwitchPortStatus.String():    DCGMNVSwitchPortStatus,
+	DCGMGPUNeedsReset.String():         DCGMGPUNeedsReset,
+	DCGMEnergyTotal.String():           DCGMEnergyTotal,
+	DCGMFieldStatus.String():           DCGMFieldStatus,
+	DCGMMIGFreeSlices.String():         DCGMMIGFreeSlices,
+	DCGMMIGLargestFreeProfile.String(): DCGMMIGLargestFreeProfile,
+	DCGMMIGFragmentation.String():      DCGMMIGFragmentation,
+	DCGMMPSDaemonUp.String():           DCGMMPSDaemonUp,
+	DCGMMPSActiveClients.String():      DCGMMPSActiveClients,
+	DCGMMPSThreadPercentage.String():   DCGMMPSThreadPercentage,
+	DCGMMPSServerUp.String():           DCGMMPSServerUp,
+	DCGMMPSClientThreadPct.String():    DCGMMPSClientThreadPct,
+	DCGMComputeMode.String():           DCGMComputeMode,
+	DCGMFIUnknown.String():             DCGMFIUnknown,
 }
 
 func IdentifyMetricType(s string) (ExporterCounter, error) {
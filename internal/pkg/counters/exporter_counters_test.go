@@ -35,6 +35,126 @@ func TestIdentifyMetricType(t *testing.T) {
 			output: DCGMXIDErrorsCount,
 			valid:  true,
 		},
+		{
+			name:   "Valid Input dcgm_exp_field_staleness_seconds",
+			field:  "dcgm_exp_field_staleness_seconds",
+			output: DCGMFieldStaleness,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_NVLINK_ERRORS_COUNT",
+			field:  "DCGM_EXP_NVLINK_ERRORS_COUNT",
+			output: DCGMNVLinkErrorsCount,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_HEALTH_STATUS",
+			field:  "DCGM_EXP_HEALTH_STATUS",
+			output: DCGMHealthStatus,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_GPU_DEVICE_INFO",
+			field:  "DCGM_EXP_GPU_DEVICE_INFO",
+			output: DCGMGPUDeviceInfo,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_XID_ERRORS_TOTAL",
+			field:  "DCGM_EXP_XID_ERRORS_TOTAL",
+			output: DCGMXIDErrorsTotal,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_CLOCK_THROTTLE_DURATION_SECONDS",
+			field:  "DCGM_EXP_CLOCK_THROTTLE_DURATION_SECONDS",
+			output: DCGMClockThrottleDuration,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_PROCESS_SM_UTIL",
+			field:  "DCGM_EXP_PROCESS_SM_UTIL",
+			output: DCGMProcessSMUtil,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_PROCESS_MEM_UTIL",
+			field:  "DCGM_EXP_PROCESS_MEM_UTIL",
+			output: DCGMProcessMemUtil,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_PROCESS_ENC_UTIL",
+			field:  "DCGM_EXP_PROCESS_ENC_UTIL",
+			output: DCGMProcessEncUtil,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_PROCESS_DEC_UTIL",
+			field:  "DCGM_EXP_PROCESS_DEC_UTIL",
+			output: DCGMProcessDecUtil,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_ACCOUNTING_GPU_UTIL",
+			field:  "DCGM_EXP_ACCOUNTING_GPU_UTIL",
+			output: DCGMAccountingGPUUtil,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_ACCOUNTING_MEM_UTIL",
+			field:  "DCGM_EXP_ACCOUNTING_MEM_UTIL",
+			output: DCGMAccountingMemUtil,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_ACCOUNTING_MAX_MEMORY_BYTES",
+			field:  "DCGM_EXP_ACCOUNTING_MAX_MEMORY_BYTES",
+			output: DCGMAccountingMaxMemory,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_NVSWITCH_PORT_STATUS",
+			field:  "DCGM_EXP_NVSWITCH_PORT_STATUS",
+			output: DCGMNVSwitchPortStatus,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_GPU_NEEDS_RESET",
+			field:  "DCGM_EXP_GPU_NEEDS_RESET",
+			output: DCGMGPUNeedsReset,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_ENERGY_JOULES_TOTAL",
+			field:  "DCGM_EXP_ENERGY_JOULES_TOTAL",
+			output: DCGMEnergyTotal,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input dcgm_exp_field_status",
+			field:  "dcgm_exp_field_status",
+			output: DCGMFieldStatus,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_MIG_FRAGMENTATION_RATIO",
+			field:  "DCGM_EXP_MIG_FRAGMENTATION_RATIO",
+			output: DCGMMIGFragmentation,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_MPS_ACTIVE_THREAD_PERCENTAGE",
+			field:  "DCGM_EXP_MPS_ACTIVE_THREAD_PERCENTAGE",
+			output: DCGMMPSThreadPercentage,
+			valid:  true,
+		},
+		{
+			name:   "Valid Input DCGM_EXP_COMPUTE_MODE",
+			field:  "DCGM_EXP_COMPUTE_MODE",
+			output: DCGMComputeMode,
+			valid:  true,
+		},
 		{
 			name:   "Valid Input DCGM_FI_UNKNOWN",
 			field:  "DCGM_FI_UNKNOWN",
@@ -18,6 +18,7 @@ package counters
 
 import (
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
 )
@@ -27,6 +28,25 @@ type Counter struct {
 	FieldName string     `json:"field_name"`
 	PromType  string     `json:"prom_type"`
 	Help      string     `json:"help"`
+	// Labels are the static labels of the counter, as <name>=<value>;<name>=<value> sorted by name,
+	// which keeps the counter comparable
+	Labels string `json:"labels,omitempty"`
+	// UpdateInterval is the interval at which DCGM updates the field, 0 to use the collect interval
+	UpdateInterval time.Duration `json:"update_interval,omitempty"`
+}
+
+// StaticLabels returns the static labels of the counter, nil if it has none
+func (c Counter) StaticLabels() map[string]string {
+	if c.Labels == "" {
+		return nil
+	}
+
+	labels := map[string]string{}
+	for _, label := range strings.Split(c.Labels, counterLabelsSeparator) {
+		name, value, _ := strings.Cut(label, "=")
+		labels[name] = value
+	}
+	return labels
 }
 
 func (c Counter) IsLabel() bool {
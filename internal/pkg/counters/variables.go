@@ -16,10 +16,17 @@
 
 package counters
 
-import osinterface "github.com/NVIDIA/dcgm-exporter/internal/pkg/os"
+import (
+	"regexp"
+
+	osinterface "github.com/NVIDIA/dcgm-exporter/internal/pkg/os"
+)
 
 var os osinterface.OS = osinterface.RealOS{}
 
+// labelNameRegex matches the valid Prometheus label names
+var labelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
 var promMetricType = map[string]bool{
 	"gauge":     true,
 	"counter":   true,
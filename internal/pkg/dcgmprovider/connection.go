@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dcgmprovider
+
+import "sync/atomic"
+
+var (
+	// connected is whether DCGM is initialized and no collector reported the connection as lost since
+	connected atomic.Bool
+	// connectionLost holds a pending report of a lost connection, until the reconnection picks it up
+	connectionLost = make(chan struct{}, 1)
+)
+
+// IsConnected returns whether the exporter is connected to DCGM.
+func IsConnected() bool {
+	return connected.Load()
+}
+
+func setConnected(value bool) {
+	connected.Store(value)
+}
+
+// ReportConnectionLost reports that a DCGM call failed because the connection to the hostengine is no
+// longer valid. It doesn't block: the reports are coalesced until the reconnection picks them up.
+func ReportConnectionLost() {
+	setConnected(false)
+	select {
+	case connectionLost <- struct{}{}:
+	default:
+	}
+}
+
+// ConnectionLost returns the channel receiving the reports of ReportConnectionLost.
+func ConnectionLost() <-chan struct{} {
+	return connectionLost
+}
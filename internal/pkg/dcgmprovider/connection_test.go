@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dcgmprovider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportConnectionLost(t *testing.T) {
+	setConnected(true)
+	defer setConnected(false)
+
+	// The reports are coalesced, reporting doesn't block when nobody reconnects
+	ReportConnectionLost()
+	ReportConnectionLost()
+	assert.False(t, IsConnected())
+
+	select {
+	case <-ConnectionLost():
+	default:
+		t.Fatal("The lost connection should be reported")
+	}
+	select {
+	case <-ConnectionLost():
+		t.Fatal("The reports should be coalesced")
+	default:
+	}
+}
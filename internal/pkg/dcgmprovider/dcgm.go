@@ -17,6 +17,7 @@
 package dcgmprovider
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -30,13 +31,29 @@ import (
 var dcgmInterface DCGM
 
 // Initialize sets up the Singleton DCGM interface using the provided configuration.
+// The exporter exits when DCGM can't be initialized.
 func Initialize(config *appconfig.Config) {
-	dcgmInterface = newDCGMProvider(config)
+	if err := TryInitialize(config); err != nil {
+		slog.Error(err.Error())
+		os.Exit(1)
+	}
+}
+
+// TryInitialize is the same as Initialize, but returns an error when DCGM can't be initialized, so that
+// the caller can retry, e.g. while a remote hostengine restarts.
+func TryInitialize(config *appconfig.Config) error {
+	client, err := newDCGMProvider(config)
+	if err != nil {
+		return err
+	}
+	SetClient(client)
+	return nil
 }
 
 // reset clears the current DCGM interface instance.
 func reset() {
 	dcgmInterface = nil
+	setConnected(false)
 }
 
 // Client retrieves the current DCGM interface instance.
@@ -47,6 +64,7 @@ func Client() DCGM {
 // SetClient sets the current DCGM interface instance to the provided one.
 func SetClient(d DCGM) {
 	dcgmInterface = d
+	setConnected(d != nil)
 }
 
 // dcgmProvider implements DCGM Interface
@@ -56,23 +74,20 @@ type dcgmProvider struct {
 }
 
 // newDCGMProvider initializes a new DCGM provider based on the provided configuration
-func newDCGMProvider(config *appconfig.Config) DCGM {
+func newDCGMProvider(config *appconfig.Config) (DCGM, error) {
 	// Check if a DCGM client already exists and return it if so.
 	if Client() != nil {
 		slog.Info("DCGM already initialized")
-		return Client()
+		return Client(), nil
 	}
 
 	client := dcgmProvider{}
 
 	// Connect to a remote DCGM host engine if configured.
 	if config.UseRemoteHE {
-		slog.Info("Attempting to connect to remote hostengine at " + config.RemoteHEInfo)
-		cleanup, err := dcgm.Init(dcgm.Standalone, config.RemoteHEInfo, "0")
+		cleanup, err := connectRemoteHostengine(config)
 		if err != nil {
-			// Don't call cleanup on error - initialization failed, nothing to clean up
-			slog.Error(err.Error())
-			os.Exit(1)
+			return nil, err
 		}
 		client.shutdown = cleanup
 	} else {
@@ -85,21 +100,47 @@ func newDCGMProvider(config *appconfig.Config) DCGM {
 		slog.Info("Attempting to initialize DCGM.")
 		cleanup, err := dcgm.Init(dcgm.Embedded)
 		if err != nil {
-			slog.Error(err.Error())
-			os.Exit(1)
+			return nil, err
 		}
 		client.shutdown = cleanup
 	}
 
 	// Initialize the DcgmFields module
 	if val := dcgm.FieldsInit(); val < 0 {
-		slog.Error(fmt.Sprintf("Failed to initialize DCGM Fields module; err: %d", val))
-		os.Exit(1)
-	} else {
-		slog.Info("Initialized DCGM Fields module.")
+		client.shutdown()
+		return nil, fmt.Errorf("failed to initialize DCGM Fields module; err: %d", val)
+	}
+	slog.Info("Initialized DCGM Fields module.")
+
+	return client, nil
+}
+
+// connectRemoteHostengine connects to the first reachable hostengine of config.RemoteHEEndpoints, in their order
+// of preference, and sets config.RemoteHEInfo to it.
+func connectRemoteHostengine(config *appconfig.Config) (func(), error) {
+	endpoints := config.RemoteHEEndpoints
+	if len(endpoints) == 0 {
+		endpoints = []string{config.RemoteHEInfo}
+	}
+
+	var errs []error
+	for _, endpoint := range endpoints {
+		slog.Info("Attempting to connect to remote hostengine at " + endpoint)
+		cleanup, err := dcgm.Init(dcgm.Standalone, endpoint, "0")
+		if err != nil {
+			// Don't call cleanup on error - initialization failed, nothing to clean up
+			slog.Warn("Failed to connect to remote hostengine",
+				slog.String("endpoint", endpoint),
+				slog.String("error", err.Error()))
+			errs = append(errs, fmt.Errorf("%s: %w", endpoint, err))
+			continue
+		}
+
+		config.RemoteHEInfo = endpoint
+		return cleanup, nil
 	}
 
-	return client
+	return nil, fmt.Errorf("failed to connect to a remote hostengine: %w", errors.Join(errs...))
 }
 
 func (d dcgmProvider) AddEntityToGroup(
@@ -147,12 +188,15 @@ func (d dcgmProvider) FieldGetByID(fieldID dcgm.Short) dcgm.FieldMeta {
 	return dcgm.FieldGetByID(fieldID)
 }
 
+// FieldGroupCreate returns a field group watching the fields. Watchers of the same fields share a field group,
+// the hostengine supports a limited number of them.
 func (d dcgmProvider) FieldGroupCreate(fieldsGroupName string, fields []dcgm.Short) (dcgm.FieldHandle, error) {
-	return dcgm.FieldGroupCreate(fieldsGroupName, fields)
+	return fieldGroups.Create(fieldsGroupName, fields)
 }
 
+// FieldGroupDestroy releases a field group created by FieldGroupCreate
 func (d dcgmProvider) FieldGroupDestroy(fieldsGroup dcgm.FieldHandle) error {
-	return dcgm.FieldGroupDestroy(fieldsGroup)
+	return fieldGroups.Destroy(fieldsGroup)
 }
 
 func (d dcgmProvider) GetAllDeviceCount() (uint, error) {
@@ -240,6 +284,7 @@ func (d dcgmProvider) Cleanup() {
 	slog.Info("Attempting to terminate DCGM.")
 	d.shutdown()
 
+	fieldGroups.Reset()
 	reset()
 }
 
@@ -262,3 +307,7 @@ func (d dcgmProvider) GetGroupInfo(groupID dcgm.GroupHandle) (*dcgm.GroupInfo, e
 func (d dcgmProvider) GetNvLinkP2PStatus() (dcgm.NvLinkP2PStatus, error) {
 	return dcgm.GetNvLinkP2PStatus()
 }
+
+func (d dcgmProvider) RunDiag(diagType dcgm.DiagType, groupID dcgm.GroupHandle) (dcgm.DiagResults, error) {
+	return dcgm.RunDiag(diagType, groupID)
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dcgmprovider
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+)
+
+const (
+	// MaxFieldGroups is the number of field groups the hostengine supports (DCGM_MAX_NUM_FIELD_GROUPS)
+	MaxFieldGroups = 64
+	// fieldGroupsWarningThreshold is the number of field groups from which the exporter warns about the limit
+	fieldGroupsWarningThreshold = MaxFieldGroups * 8 / 10
+)
+
+// FieldGroupStats is the usage of the DCGM field groups by the exporter
+type FieldGroupStats struct {
+	Active    int    // field groups currently created
+	Created   uint64 // field groups created since the start
+	Destroyed uint64 // field groups destroyed since the start
+	Reused    uint64 // field group creations served by an existing field group with the same fields
+}
+
+// NearLimit reports whether the active field groups approach the hostengine limit
+func (s FieldGroupStats) NearLimit() bool {
+	return s.Active >= fieldGroupsWarningThreshold
+}
+
+type trackedFieldGroup struct {
+	key    string
+	handle dcgm.FieldHandle
+	refs   int
+}
+
+// fieldGroupTracker counts the field groups and shares a field group between the watchers of the same fields,
+// so that reloads don't exhaust the field groups of the hostengine.
+type fieldGroupTracker struct {
+	mtx      sync.Mutex
+	create   func(string, []dcgm.Short) (dcgm.FieldHandle, error)
+	destroy  func(dcgm.FieldHandle) error
+	byKey    map[string]*trackedFieldGroup
+	byHandle map[dcgm.FieldHandle]*trackedFieldGroup
+	stats    FieldGroupStats
+}
+
+var fieldGroups = newFieldGroupTracker(dcgm.FieldGroupCreate, dcgm.FieldGroupDestroy)
+
+func newFieldGroupTracker(
+	create func(string, []dcgm.Short) (dcgm.FieldHandle, error), destroy func(dcgm.FieldHandle) error,
+) *fieldGroupTracker {
+	return &fieldGroupTracker{
+		create:   create,
+		destroy:  destroy,
+		byKey:    map[string]*trackedFieldGroup{},
+		byHandle: map[dcgm.FieldHandle]*trackedFieldGroup{},
+	}
+}
+
+// GetFieldGroupStats returns the usage of the DCGM field groups
+func GetFieldGroupStats() FieldGroupStats {
+	return fieldGroups.Stats()
+}
+
+func fieldGroupKey(fields []dcgm.Short) string {
+	sorted := slices.Clone(fields)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	var sb strings.Builder
+	for i, field := range sorted {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		fmt.Fprint(&sb, field)
+	}
+	return sb.String()
+}
+
+// Create returns the field group watching the fields, the existing one if any
+func (t *fieldGroupTracker) Create(name string, fields []dcgm.Short) (dcgm.FieldHandle, error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	key := fieldGroupKey(fields)
+	if fieldGroup, exists := t.byKey[key]; exists {
+		fieldGroup.refs++
+		t.stats.Reused++
+		return fieldGroup.handle, nil
+	}
+
+	if len(t.byKey) >= MaxFieldGroups {
+		return dcgm.FieldHandle{}, fmt.Errorf("cannot create field group %q: the %d field groups of the hostengine are in use",
+			name, MaxFieldGroups)
+	}
+
+	handle, err := t.create(name, fields)
+	if err != nil {
+		return dcgm.FieldHandle{}, err
+	}
+
+	fieldGroup := &trackedFieldGroup{key: key, handle: handle, refs: 1}
+	t.byKey[key] = fieldGroup
+	t.byHandle[handle] = fieldGroup
+	t.stats.Created++
+	t.stats.Active = len(t.byKey)
+
+	if t.stats.Active == fieldGroupsWarningThreshold {
+		slog.Warn("The number of DCGM field groups approaches the hostengine limit",
+			slog.Int("field_groups", t.stats.Active),
+			slog.Int("limit", MaxFieldGroups))
+	}
+
+	return handle, nil
+}
+
+// Destroy releases the field group, which is destroyed once no watcher uses it anymore
+func (t *fieldGroupTracker) Destroy(handle dcgm.FieldHandle) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	fieldGroup, exists := t.byHandle[handle]
+	if !exists {
+		return t.destroy(handle)
+	}
+
+	fieldGroup.refs--
+	if fieldGroup.refs > 0 {
+		return nil
+	}
+
+	delete(t.byKey, fieldGroup.key)
+	delete(t.byHandle, handle)
+	t.stats.Destroyed++
+	t.stats.Active = len(t.byKey)
+
+	return t.destroy(handle)
+}
+
+// Reset forgets the field groups, which are gone with the hostengine connection
+func (t *fieldGroupTracker) Reset() {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	clear(t.byKey)
+	clear(t.byHandle)
+	t.stats.Active = 0
+}
+
+func (t *fieldGroupTracker) Stats() FieldGroupStats {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	return t.stats
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dcgmprovider
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFieldGroupTracker(destroyed *[]dcgm.FieldHandle) *fieldGroupTracker {
+	next := uintptr(0)
+	return newFieldGroupTracker(
+		func(string, []dcgm.Short) (dcgm.FieldHandle, error) {
+			next++
+			handle := dcgm.FieldHandle{}
+			handle.SetHandle(next)
+			return handle, nil
+		},
+		func(handle dcgm.FieldHandle) error {
+			*destroyed = append(*destroyed, handle)
+			return nil
+		},
+	)
+}
+
+func TestFieldGroupTracker(t *testing.T) {
+	var destroyed []dcgm.FieldHandle
+	tracker := newTestFieldGroupTracker(&destroyed)
+
+	first, err := tracker.Create("a", []dcgm.Short{dcgm.DCGM_FI_DEV_GPU_TEMP, dcgm.DCGM_FI_DEV_POWER_USAGE})
+	require.NoError(t, err)
+
+	// The same fields in another order share the field group
+	second, err := tracker.Create("b", []dcgm.Short{dcgm.DCGM_FI_DEV_POWER_USAGE, dcgm.DCGM_FI_DEV_GPU_TEMP})
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+
+	other, err := tracker.Create("c", []dcgm.Short{dcgm.DCGM_FI_DEV_GPU_TEMP})
+	require.NoError(t, err)
+	assert.NotEqual(t, first, other)
+
+	assert.Equal(t, FieldGroupStats{Active: 2, Created: 2, Reused: 1}, tracker.Stats())
+
+	// The shared field group is destroyed with its last user
+	require.NoError(t, tracker.Destroy(first))
+	assert.Empty(t, destroyed)
+	require.NoError(t, tracker.Destroy(second))
+	assert.Equal(t, []dcgm.FieldHandle{first}, destroyed)
+
+	assert.Equal(t, FieldGroupStats{Active: 1, Created: 2, Destroyed: 1, Reused: 1}, tracker.Stats())
+
+	tracker.Reset()
+	assert.Equal(t, 0, tracker.Stats().Active)
+}
+
+func TestFieldGroupTrackerLimit(t *testing.T) {
+	var destroyed []dcgm.FieldHandle
+	tracker := newTestFieldGroupTracker(&destroyed)
+
+	for i := 0; i < MaxFieldGroups; i++ {
+		_, err := tracker.Create("group", []dcgm.Short{dcgm.Short(i + 1)})
+		require.NoError(t, err)
+	}
+	assert.True(t, tracker.Stats().NearLimit())
+
+	_, err := tracker.Create("group", []dcgm.Short{dcgm.Short(MaxFieldGroups + 1)})
+	assert.ErrorContains(t, err, "field groups of the hostengine are in use")
+
+	// Fields with a field group are still served
+	_, err = tracker.Create("group", []dcgm.Short{1})
+	assert.NoError(t, err)
+}
@@ -60,4 +60,5 @@ type DCGM interface {
 	HealthCheck(groupID dcgm.GroupHandle) (dcgm.HealthResponse, error)
 	GetGroupInfo(groupID dcgm.GroupHandle) (*dcgm.GroupInfo, error)
 	GetNvLinkP2PStatus() (dcgm.NvLinkP2PStatus, error)
+	RunDiag(diagType dcgm.DiagType, groupID dcgm.GroupHandle) (dcgm.DiagResults, error)
 }
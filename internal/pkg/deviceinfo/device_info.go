@@ -19,6 +19,7 @@ package deviceinfo
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"slices"
@@ -28,6 +29,8 @@ import (
 
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/identity"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
 )
 
 const deviceInitMessage = "System entities of type %s initialized"
@@ -37,6 +40,7 @@ type Info struct {
 	gpus     [dcgm.MAX_NUM_DEVICES]GPUInfo
 	switches []SwitchInfo
 	cpus     []CPUInfo
+	vgpus    []VGPUInfo
 	gOpt     appconfig.DeviceOptions
 	sOpt     appconfig.DeviceOptions
 	cOpt     appconfig.DeviceOptions
@@ -71,6 +75,10 @@ func (s *Info) CPU(i uint) CPUInfo {
 	return s.cpus[i]
 }
 
+func (s *Info) VGPUs() []VGPUInfo {
+	return s.vgpus
+}
+
 func (s *Info) GOpts() appconfig.DeviceOptions {
 	return s.gOpt
 }
@@ -105,6 +113,8 @@ func Initialize(
 		err = deviceInfo.initializeGPUInfo(gOpt, useFakeGPUs)
 		if err != nil {
 			slog.Warn("Failed to initialize GPU/NvLink info", "error", err)
+		} else if !useFakeGPUs {
+			deviceInfo.resolveNvLinkPeers()
 		}
 		err = nil
 	case dcgm.FE_SWITCH:
@@ -119,6 +129,9 @@ func Initialize(
 	case dcgm.FE_CPU_CORE:
 		deviceInfo.infoType = dcgm.FE_CPU_CORE
 		err = deviceInfo.initializeCPUInfo(cOpt)
+	case dcgm.FE_VGPU:
+		deviceInfo.infoType = dcgm.FE_VGPU
+		err = deviceInfo.initializeVGPUInfo()
 	default:
 		err = fmt.Errorf("invalid entity type '%d'", entityType)
 	}
@@ -126,6 +139,74 @@ func Initialize(
 	return deviceInfo, err
 }
 
+// InitializeFromNVML enumerates the GPUs with NVML instead of DCGM, for the NVML-only mode.
+// The GPUs have no MIG instances nor NvLinks.
+func InitializeFromNVML(gOpt appconfig.DeviceOptions) (*Info, error) {
+	slog.Info("Initializing GPUs from NVML")
+
+	devices, err := nvmlprovider.Client().GetDevices()
+	if err != nil {
+		return nil, err
+	}
+	if len(devices) > int(dcgm.MAX_NUM_DEVICES) {
+		return nil, fmt.Errorf("found %d GPUs, at most %d are supported", len(devices), dcgm.MAX_NUM_DEVICES)
+	}
+
+	deviceInfo := &Info{infoType: dcgm.FE_GPU}
+	for i, device := range devices {
+		deviceInfo.gpus[i].DeviceInfo.GPU = device.Index
+		deviceInfo.gpus[i].DeviceInfo.UUID = device.UUID
+		deviceInfo.gpus[i].DeviceInfo.Identifiers.Model = device.Name
+		deviceInfo.gpus[i].DeviceInfo.PCI.BusID = device.PCIBusID
+	}
+	deviceInfo.gpuCount = uint(len(devices))
+	deviceInfo.gOpt = gOpt
+
+	if err := deviceInfo.verifyDevicePresence(); err != nil {
+		return nil, err
+	}
+	slog.Debug(fmt.Sprintf(deviceInitMessage, deviceInfo.infoType))
+	return deviceInfo, nil
+}
+
+// ValidateDeviceOptions checks the device options against the entities enumerated by DCGM and
+// reports every requested ID that doesn't exist. Options that monitor all entities are not checked.
+func ValidateDeviceOptions(
+	gOpt appconfig.DeviceOptions, sOpt appconfig.DeviceOptions, cOpt appconfig.DeviceOptions, useFakeGPUs bool,
+) error {
+	var errs []error
+
+	for _, entity := range []struct {
+		entityType dcgm.Field_Entity_Group
+		opt        appconfig.DeviceOptions
+	}{
+		{entityType: dcgm.FE_GPU, opt: gOpt},
+		{entityType: dcgm.FE_SWITCH, opt: sOpt},
+		{entityType: dcgm.FE_CPU, opt: cOpt},
+	} {
+		if !requestsDevices(entity.opt) {
+			continue
+		}
+
+		_, err := Initialize(gOpt, sOpt, cOpt, useFakeGPUs, entity.entityType)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entity.entityType.String(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// requestsDevices returns whether the device options request specific devices
+func requestsDevices(opt appconfig.DeviceOptions) bool {
+	if opt.Flex {
+		return false
+	}
+	return slices.ContainsFunc([][]int{opt.MajorRange, opt.MinorRange}, func(r []int) bool {
+		return len(r) > 0 && r[0] != -1
+	})
+}
+
 func (s *Info) initializeGPUInfo(gOpt appconfig.DeviceOptions, useFakeGPUs bool) error {
 	gpuCount, err := dcgmprovider.Client().GetAllDeviceCount()
 	if err != nil {
@@ -157,8 +238,11 @@ func (s *Info) initializeGPUInfo(gOpt appconfig.DeviceOptions, useFakeGPUs bool)
 			if gOpt.Flex || s.shouldMonitor(gOpt.MajorRange, s.gpus[i].DeviceInfo.GPU) {
 				var matchingLinks []dcgm.NvLinkStatus
 				var linkCount uint = 1
+				peers := map[uint]NvLinkPeer{}
 				for _, link := range links {
 					if link.ParentType == dcgm.FE_GPU && link.ParentId == s.gpus[i].DeviceInfo.GPU {
+						// The peer is resolved for the links of the FE_LINK entities only
+						peers[linkCount] = NvLinkPeer{LinkID: link.Index, Type: nvmlprovider.NvLinkDeviceUnknown}
 						link.Index = linkCount
 						matchingLinks = append(matchingLinks, link)
 						linkCount++
@@ -166,6 +250,9 @@ func (s *Info) initializeGPUInfo(gOpt appconfig.DeviceOptions, useFakeGPUs bool)
 				}
 
 				s.gpus[i].NvLinks = matchingLinks
+				if len(matchingLinks) > 0 {
+					s.gpus[i].NvLinkPeers = peers
+				}
 			}
 		}
 	}
@@ -314,6 +401,78 @@ func (s *Info) initializeNvSwitchInfo(sOpt appconfig.DeviceOptions) error {
 	return err
 }
 
+// resolveNvLinkPeers resolves the devices at the other end of the active NVLinks of the GPUs with NVML.
+// The peers stay unknown when NVML isn't available.
+func (s *Info) resolveNvLinkPeers() {
+	hasActiveLinks := slices.ContainsFunc(s.gpus[:s.gpuCount], func(gpu GPUInfo) bool {
+		return slices.ContainsFunc(gpu.NvLinks, func(link dcgm.NvLinkStatus) bool { return link.State == dcgm.LS_UP })
+	})
+	if !hasActiveLinks {
+		return
+	}
+
+	if err := nvmlprovider.Initialize(); err != nil {
+		slog.Warn("NVML not available, NvLink peers are unknown", "error", err)
+		return
+	}
+
+	for i := uint(0); i < s.gpuCount; i++ {
+		gpu := &s.gpus[i]
+		for _, link := range gpu.NvLinks {
+			if link.State != dcgm.LS_UP {
+				continue
+			}
+
+			peer := gpu.NvLinkPeers[link.Index]
+			remote, err := nvmlprovider.Client().GetNvLinkRemote(gpu.DeviceInfo.UUID, peer.LinkID)
+			if err != nil {
+				slog.Debug("Failed to resolve NvLink peer",
+					"gpu", gpu.DeviceInfo.GPU, "link", peer.LinkID, "error", err)
+				continue
+			}
+
+			peer.Type = remote.DeviceType
+			peer.ID = remote.PCIBusID
+			if remote.DeviceType == nvmlprovider.NvLinkDeviceGPU {
+				if peerGPU, found := s.gpuByPCIBusID(remote.PCIBusID); found {
+					peer.ID = fmt.Sprint(peerGPU)
+				}
+			}
+			gpu.NvLinkPeers[link.Index] = peer
+		}
+	}
+}
+
+// gpuByPCIBusID returns the index of the GPU with the PCI bus ID
+func (s *Info) gpuByPCIBusID(busID string) (uint, bool) {
+	for i := uint(0); i < s.gpuCount; i++ {
+		if identity.EqualPCIBusIDs(s.gpus[i].DeviceInfo.PCI.BusID, busID) {
+			return s.gpus[i].DeviceInfo.GPU, true
+		}
+	}
+	return 0, false
+}
+
+// initializeVGPUInfo discovers the vGPU instances, which only exist on hypervisors running vGPU VMs.
+// All the vGPU instances are monitored, the GPU device options apply to the physical GPUs.
+func (s *Info) initializeVGPUInfo() error {
+	vgpus, err := dcgmprovider.Client().GetEntityGroupEntities(dcgm.FE_VGPU)
+	if err != nil {
+		return err
+	}
+
+	if len(vgpus) == 0 {
+		return fmt.Errorf("no vgpus to monitor")
+	}
+
+	for _, vgpu := range vgpus {
+		s.vgpus = append(s.vgpus, VGPUInfo{EntityId: vgpu})
+	}
+
+	slog.Debug(fmt.Sprintf(deviceInitMessage, s.infoType))
+	return nil
+}
+
 func (s *Info) setGPUInstanceProfileName(entityID uint, profileName string) bool {
 	for i := uint(0); i < s.gpuCount; i++ {
 		for j := range s.gpus[i].GPUInstances {
@@ -428,24 +587,10 @@ func (s *Info) verifyDevicePresence() error {
 		return nil
 	}
 
-	if len(s.gOpt.MajorRange) > 0 && s.gOpt.MajorRange[0] != -1 {
-		// Verify we can find all the specified gpus
-		for _, gpuID := range s.gOpt.MajorRange {
-			if !s.gpuIDExists(gpuID) {
-				return fmt.Errorf("couldn't find requested GPU ID '%d'", gpuID)
-			}
-		}
-	}
-
-	if len(s.gOpt.MinorRange) > 0 && s.gOpt.MinorRange[0] != -1 {
-		for _, gpuInstanceID := range s.gOpt.MinorRange {
-			if !s.gpuInstanceIDExists(gpuInstanceID) {
-				return fmt.Errorf("couldn't find requested GPU instance ID '%d'", gpuInstanceID)
-			}
-		}
-	}
-
-	return nil
+	return errors.Join(
+		missingDevicesError("GPU ID", s.gOpt.MajorRange, s.gpuIDExists),
+		missingDevicesError("GPU instance ID", s.gOpt.MinorRange, s.gpuInstanceIDExists),
+	)
 }
 
 func (s *Info) verifyCPUDevicePresence() error {
@@ -453,24 +598,29 @@ func (s *Info) verifyCPUDevicePresence() error {
 		return nil
 	}
 
-	if len(s.cOpt.MajorRange) > 0 && s.cOpt.MajorRange[0] != -1 {
-		// Verify we can find all the specified CPUs
-		for _, cpuID := range s.cOpt.MajorRange {
-			if !s.cpuIDExists(cpuID) {
-				return fmt.Errorf("couldn't find requested CPU ID '%d'", cpuID)
-			}
-		}
+	return errors.Join(
+		missingDevicesError("CPU ID", s.cOpt.MajorRange, s.cpuIDExists),
+		missingDevicesError("CPU core", s.cOpt.MinorRange, s.cpuCoreIDExists),
+	)
+}
+
+// missingDevicesError returns a MissingDevicesError with the IDs of the monitoring range that don't exist,
+// or nil if all of them exist.
+func missingDevicesError(kind string, monitoringRange []int, exists func(int) bool) error {
+	if len(monitoringRange) == 0 || monitoringRange[0] == -1 {
+		return nil
 	}
 
-	if len(s.cOpt.MinorRange) > 0 && s.cOpt.MinorRange[0] != -1 {
-		for _, coreID := range s.cOpt.MinorRange {
-			if !s.cpuCoreIDExists(coreID) {
-				return fmt.Errorf("couldn't find requested CPU core '%d'", coreID)
-			}
+	var missing []int
+	for _, id := range monitoringRange {
+		if !exists(id) {
+			missing = append(missing, id)
 		}
 	}
-
-	return nil
+	if len(missing) == 0 {
+		return nil
+	}
+	return &MissingDevicesError{Kind: kind, IDs: missing}
 }
 
 func (s *Info) shouldMonitor(monitoringRange []int, val uint) bool {
@@ -490,24 +640,10 @@ func (s *Info) verifySwitchDevicePresence() error {
 		return nil
 	}
 
-	if len(s.sOpt.MajorRange) > 0 && s.sOpt.MajorRange[0] != -1 {
-		// Verify we can find all the specified switches
-		for _, swID := range s.sOpt.MajorRange {
-			if !s.switchIDExists(swID) {
-				return fmt.Errorf("couldn't find requested NvSwitch ID '%d'", swID)
-			}
-		}
-	}
-
-	if len(s.sOpt.MinorRange) > 0 && s.sOpt.MinorRange[0] != -1 {
-		for _, linkID := range s.sOpt.MinorRange {
-			if !s.linkIDExists(linkID) {
-				return fmt.Errorf("couldn't find requested NvLink '%d'", linkID)
-			}
-		}
-	}
-
-	return nil
+	return errors.Join(
+		missingDevicesError("NvSwitch ID", s.sOpt.MajorRange, s.switchIDExists),
+		missingDevicesError("NvLink", s.sOpt.MinorRange, s.linkIDExists),
+	)
 }
 
 func (s *Info) IsCPUWatched(cpuID uint) bool {
@@ -615,11 +751,63 @@ func getCoreArray(bitmask []uint64) []uint {
 
 // Helper Functions
 
-func GetGPUInstanceIdentifier(deviceInfo Provider, gpuuuid string, gpuInstanceID uint) string {
+// FormatGPUInstanceIdentifier builds the identifier of a GPU instance in the given format.
+// It must be used for both metrics and pod mapping keys, otherwise they never match.
+func FormatGPUInstanceIdentifier(format appconfig.GPUInstanceIDFormat, gpuIndex, gpuUUID, gpuInstanceID string) string {
+	if format == appconfig.GPUInstanceIDFormatUUID {
+		return fmt.Sprintf("%s/%s", gpuUUID, gpuInstanceID)
+	}
+
+	return fmt.Sprintf("%s-%s", gpuIndex, gpuInstanceID)
+}
+
+// FormatComputeInstanceIdentifier builds the identifier of a compute instance of a GPU instance in the given format.
+func FormatComputeInstanceIdentifier(
+	format appconfig.GPUInstanceIDFormat, gpuIndex, gpuUUID, gpuInstanceID, computeInstanceID string,
+) string {
+	if format == appconfig.GPUInstanceIDFormatUUID {
+		return fmt.Sprintf("%s/%s/%s", gpuUUID, gpuInstanceID, computeInstanceID)
+	}
+
+	return fmt.Sprintf("%s-%s-%s", gpuIndex, gpuInstanceID, computeInstanceID)
+}
+
+// GetComputeInstanceIdentifier is the same as GetGPUInstanceIdentifier for a compute instance of the GPU instance.
+func GetComputeInstanceIdentifier(
+	deviceInfo Provider, format appconfig.GPUInstanceIDFormat, gpuuuid string, gpuInstanceID, computeInstanceID uint,
+) string {
 	for i := uint(0); i < deviceInfo.GPUCount(); i++ {
 		if deviceInfo.GPU(i).DeviceInfo.UUID == gpuuuid {
-			identifier := fmt.Sprintf("%d-%d", deviceInfo.GPU(i).DeviceInfo.GPU, gpuInstanceID)
-			return identifier
+			return FormatComputeInstanceIdentifier(format, fmt.Sprint(deviceInfo.GPU(i).DeviceInfo.GPU), gpuuuid,
+				fmt.Sprint(gpuInstanceID), fmt.Sprint(computeInstanceID))
+		}
+	}
+
+	return ""
+}
+
+func GetGPUInstanceIdentifier(
+	deviceInfo Provider, format appconfig.GPUInstanceIDFormat, gpuuuid string, gpuInstanceID uint,
+) string {
+	for i := uint(0); i < deviceInfo.GPUCount(); i++ {
+		if deviceInfo.GPU(i).DeviceInfo.UUID == gpuuuid {
+			return FormatGPUInstanceIdentifier(format, fmt.Sprint(deviceInfo.GPU(i).DeviceInfo.GPU), gpuuuid,
+				fmt.Sprint(gpuInstanceID))
+		}
+	}
+
+	return ""
+}
+
+// GetGPUInstanceIdentifierByIndex is the same as GetGPUInstanceIdentifier, for device plugins that
+// reference GPUs by index (e.g. GKE).
+func GetGPUInstanceIdentifierByIndex(
+	deviceInfo Provider, format appconfig.GPUInstanceIDFormat, gpuIndex uint, gpuInstanceID uint,
+) string {
+	for i := uint(0); i < deviceInfo.GPUCount(); i++ {
+		if deviceInfo.GPU(i).DeviceInfo.GPU == gpuIndex {
+			return FormatGPUInstanceIdentifier(format, fmt.Sprint(gpuIndex), deviceInfo.GPU(i).DeviceInfo.UUID,
+				fmt.Sprint(gpuInstanceID))
 		}
 	}
 
@@ -653,6 +841,7 @@ func (s *Info) MarshalJSON() ([]byte, error) {
 		"gpus":           s.GPUs(),
 		"switches":       s.Switches(),
 		"cpus":           s.CPUs(),
+		"vgpus":          s.VGPUs(),
 		"gpu_options":    s.GOpts(),
 		"switch_options": s.SOpts(),
 		"cpu_options":    s.COpts(),
@@ -668,6 +857,7 @@ func (s *Info) UnmarshalJSON(data []byte) error {
 		GPUs          []GPUInfo               `json:"gpus"`
 		Switches      []SwitchInfo            `json:"switches"`
 		CPUs          []CPUInfo               `json:"cpus"`
+		VGPUs         []VGPUInfo              `json:"vgpus"`
 		GPUOptions    appconfig.DeviceOptions `json:"gpu_options"`
 		SwitchOptions appconfig.DeviceOptions `json:"switch_options"`
 		CPUOptions    appconfig.DeviceOptions `json:"cpu_options"`
@@ -703,6 +893,7 @@ func (s *Info) UnmarshalJSON(data []byte) error {
 	// Copy other slices
 	s.switches = result.Switches
 	s.cpus = result.CPUs
+	s.vgpus = result.VGPUs
 
 	return nil
 }
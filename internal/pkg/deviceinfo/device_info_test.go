@@ -27,8 +27,10 @@ import (
 	"go.uber.org/mock/gomock"
 
 	mockdcgm "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/dcgmprovider"
+	mocknvml "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/nvmlprovider"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
 )
 
 var fakeProfileName = "2fake.4gb"
@@ -425,7 +427,7 @@ func TestInitialize(t *testing.T) {
 		{
 			name:       "Initialize Invalid type error",
 			cOpts:      appconfig.DeviceOptions{Flex: true},
-			entityType: dcgm.FE_VGPU,
+			entityType: dcgm.FE_NONE,
 			mockCalls:  func() {},
 			wantErr:    true,
 		},
@@ -2126,6 +2128,32 @@ func TestVerifyDevicePresence(t *testing.T) {
 	require.Equal(t, err, nil, "Expected to have no error, but found %s", err)
 }
 
+func TestVerifyDevicePresence_ReportsAllMissingIDs(t *testing.T) {
+	deviceInfo := SpoofGPUDeviceInfo()
+	deviceInfo.gOpt = appconfig.DeviceOptions{
+		MajorRange: []int{0, 5, 7},
+		MinorRange: []int{10},
+	}
+
+	err := deviceInfo.verifyDevicePresence()
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "couldn't find requested GPU IDs [5 7]")
+	assert.ErrorContains(t, err, "couldn't find requested GPU instance ID '10'")
+
+	var missingDevicesErr *MissingDevicesError
+	require.ErrorAs(t, err, &missingDevicesErr)
+	assert.Equal(t, "GPU ID", missingDevicesErr.Kind)
+	assert.Equal(t, []int{5, 7}, missingDevicesErr.IDs)
+}
+
+func TestRequestsDevices(t *testing.T) {
+	assert.False(t, requestsDevices(appconfig.DeviceOptions{Flex: true, MajorRange: []int{0}}))
+	assert.False(t, requestsDevices(appconfig.DeviceOptions{MajorRange: []int{-1}, MinorRange: []int{-1}}))
+	assert.False(t, requestsDevices(appconfig.DeviceOptions{}))
+	assert.True(t, requestsDevices(appconfig.DeviceOptions{MajorRange: []int{0, 5}}))
+	assert.True(t, requestsDevices(appconfig.DeviceOptions{MajorRange: []int{-1}, MinorRange: []int{3}}))
+}
+
 func TestIsSwitchWatched(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -2675,6 +2703,7 @@ func TestGetGPUInstanceIdentifier(t *testing.T) {
 
 	type args struct {
 		deviceInfo    Provider
+		format        appconfig.GPUInstanceIDFormat
 		gpuuuid       string
 		gpuInstanceID uint
 	}
@@ -2702,6 +2731,26 @@ func TestGetGPUInstanceIdentifier(t *testing.T) {
 			},
 			expectedOutput: fmt.Sprintf("%d-%d", fakeDevices[1].GPU, gpuInstanceID),
 		},
+		{
+			name: "GPU UUID found, uuid/gi format",
+			args: args{
+				deviceInfo: &Info{
+					gpuCount: 2,
+					gpus: [dcgm.MAX_NUM_DEVICES]GPUInfo{
+						{
+							DeviceInfo: fakeDevices[0],
+						},
+						{
+							DeviceInfo: fakeDevices[1],
+						},
+					},
+				},
+				format:        appconfig.GPUInstanceIDFormatUUID,
+				gpuuuid:       fakeDevices[1].UUID,
+				gpuInstanceID: uint(gpuInstanceID),
+			},
+			expectedOutput: fmt.Sprintf("%s/%d", fakeDevices[1].UUID, gpuInstanceID),
+		},
 		{
 			name: "GPU UUID not found",
 			args: args{
@@ -2723,8 +2772,36 @@ func TestGetGPUInstanceIdentifier(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equalf(t, tt.expectedOutput, GetGPUInstanceIdentifier(tt.args.deviceInfo, tt.args.gpuuuid,
-				tt.args.gpuInstanceID), "GPU Instance Identifier mismatch")
+			assert.Equalf(t, tt.expectedOutput, GetGPUInstanceIdentifier(tt.args.deviceInfo, tt.args.format,
+				tt.args.gpuuuid, tt.args.gpuInstanceID), "GPU Instance Identifier mismatch")
 		})
 	}
 }
+
+func TestInitializeFromNVML(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockNVML := mocknvml.NewMockNVML(ctrl)
+
+	realNVML := nvmlprovider.Client()
+	defer func() {
+		nvmlprovider.SetClient(realNVML)
+	}()
+	nvmlprovider.SetClient(mockNVML)
+
+	mockNVML.EXPECT().GetDevices().Return([]nvmlprovider.GPUDevice{
+		{Index: 0, UUID: "GPU-0", Name: "Orin", PCIBusID: "00000000:00:00.0"},
+		{Index: 1, UUID: "GPU-1", Name: "Orin", PCIBusID: "00000000:01:00.0"},
+	}, nil).Times(2)
+
+	deviceInfo, err := InitializeFromNVML(appconfig.DeviceOptions{Flex: true})
+	require.NoError(t, err)
+	assert.Equal(t, dcgm.FE_GPU, deviceInfo.InfoType())
+	require.Equal(t, uint(2), deviceInfo.GPUCount())
+	assert.Equal(t, uint(1), deviceInfo.GPU(1).DeviceInfo.GPU)
+	assert.Equal(t, "GPU-1", deviceInfo.GPU(1).DeviceInfo.UUID)
+	assert.Equal(t, "Orin", deviceInfo.GPU(1).DeviceInfo.Identifiers.Model)
+	assert.Equal(t, "00000000:01:00.0", deviceInfo.GPU(1).DeviceInfo.PCI.BusID)
+
+	_, err = InitializeFromNVML(appconfig.DeviceOptions{MajorRange: []int{2}})
+	assert.Error(t, err, "a missing GPU must be reported")
+}
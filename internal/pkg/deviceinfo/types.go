@@ -19,6 +19,8 @@
 package deviceinfo
 
 import (
+	"fmt"
+
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
 
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
@@ -40,6 +42,7 @@ type Provider interface {
 	IsCoreWatched(coreID uint, cpuID uint) bool
 	IsSwitchWatched(switchID uint) bool
 	IsLinkWatched(linkIndex uint, switchID uint) bool
+	VGPUs() []VGPUInfo
 }
 
 type GPUInfo struct {
@@ -47,6 +50,14 @@ type GPUInfo struct {
 	GPUInstances []GPUInstanceInfo
 	MigEnabled   bool
 	NvLinks      []dcgm.NvLinkStatus
+	NvLinkPeers  map[uint]NvLinkPeer // NvLinks index -> device at the other end of the link
+}
+
+// NvLinkPeer is the device at the other end of an NVLink of a GPU
+type NvLinkPeer struct {
+	LinkID uint   // number of the link on the GPU, as in the per-link DCGM fields (L0 - L17)
+	Type   string // one of the nvmlprovider.NvLinkDevice* types
+	ID     string // index of a peer GPU, PCI bus ID of the other devices
 }
 
 type GPUInstanceInfo struct {
@@ -67,7 +78,25 @@ type CPUInfo struct {
 	Cores    []uint
 }
 
+// VGPUInfo is a vGPU instance running on the GPUs of a hypervisor
+type VGPUInfo struct {
+	EntityId uint
+}
+
 type SwitchInfo struct {
 	EntityId uint
 	NvLinks  []dcgm.NvLinkStatus
 }
+
+// MissingDevicesError reports the devices requested by the device options that don't exist
+type MissingDevicesError struct {
+	Kind string // e.g. "GPU ID" or "GPU instance ID"
+	IDs  []int
+}
+
+func (e *MissingDevicesError) Error() string {
+	if len(e.IDs) == 1 {
+		return fmt.Sprintf("couldn't find requested %s '%d'", e.Kind, e.IDs[0])
+	}
+	return fmt.Sprintf("couldn't find requested %ss %v", e.Kind, e.IDs)
+}
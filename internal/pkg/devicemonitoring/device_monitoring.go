@@ -37,15 +37,21 @@ func GetMonitoredEntities(deviceInfo deviceinfo.Provider) []Info {
 		monitoring = monitorAllCPUs(deviceInfo)
 	case dcgm.FE_CPU_CORE:
 		monitoring = monitorAllCPUCores(deviceInfo)
+	case dcgm.FE_VGPU:
+		monitoring = monitorAllVGPUs(deviceInfo)
 	default:
 		if deviceInfo.GOpts().Flex {
 			monitoring = monitorAllGPUInstances(deviceInfo, true)
 		} else {
 			monitoring = handleGPUOptions(deviceInfo)
 		}
+
+		if deviceInfo.GOpts().ComputeInstances {
+			monitoring = append(monitoring, monitorComputeInstances(monitoring)...)
+		}
 	}
 
-	return monitoring
+	return withoutPassthroughGPUs(withoutPausedGPUs(monitoring))
 }
 
 func handleGPUOptions(deviceInfo deviceinfo.Provider) []Info {
@@ -128,6 +134,29 @@ func monitorAllGPUInstances(deviceInfo deviceinfo.Provider, addFlexibly bool) []
 	return monitoring
 }
 
+// monitorComputeInstances returns the compute instances of the monitored GPU instances.
+func monitorComputeInstances(monitoring []Info) []Info {
+	var computeInstances []Info
+
+	for _, mi := range monitoring {
+		if mi.Entity.EntityGroupId != dcgm.FE_GPU_I || mi.InstanceInfo == nil {
+			continue
+		}
+
+		for _, ci := range mi.InstanceInfo.ComputeInstances {
+			computeInstances = append(computeInstances, Info{
+				dcgm.GroupEntityPair{EntityGroupId: dcgm.FE_GPU_CI, EntityId: ci.EntityId},
+				mi.DeviceInfo,
+				mi.InstanceInfo,
+				mi.Entity.EntityId,
+				dcgm.FE_GPU_I,
+			})
+		}
+	}
+
+	return computeInstances
+}
+
 func monitorAllCPUs(deviceInfo deviceinfo.Provider) []Info {
 	var monitoring []Info
 
@@ -149,6 +178,23 @@ func monitorAllCPUs(deviceInfo deviceinfo.Provider) []Info {
 	return monitoring
 }
 
+func monitorAllVGPUs(deviceInfo deviceinfo.Provider) []Info {
+	var monitoring []Info
+
+	for _, vgpu := range deviceInfo.VGPUs() {
+		mi := Info{
+			dcgm.GroupEntityPair{EntityGroupId: dcgm.FE_VGPU, EntityId: vgpu.EntityId},
+			dcgm.Device{},
+			nil,
+			PARENT_ID_IGNORED,
+			dcgm.FE_NONE,
+		}
+		monitoring = append(monitoring, mi)
+	}
+
+	return monitoring
+}
+
 func monitorAllCPUCores(deviceInfo deviceinfo.Provider) []Info {
 	var monitoring []Info
 
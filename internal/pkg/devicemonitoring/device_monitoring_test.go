@@ -21,6 +21,7 @@ import (
 
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	mockdeviceinfo "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/deviceinfo"
@@ -1278,6 +1279,50 @@ func Test_monitorAllCPUs(t *testing.T) {
 	}
 }
 
+func Test_monitorAllVGPUs(t *testing.T) {
+	tests := []struct {
+		name  string
+		vgpus []deviceinfo.VGPUInfo
+		want  []Info
+	}{
+		{
+			name:  "vGPU Count 0",
+			vgpus: nil,
+			want:  nil,
+		},
+		{
+			name:  "vGPU Count 2",
+			vgpus: []deviceinfo.VGPUInfo{{EntityId: 3}, {EntityId: 7}},
+			want: []Info{
+				{
+					Entity:       dcgm.GroupEntityPair{EntityGroupId: dcgm.FE_VGPU, EntityId: uint(3)},
+					DeviceInfo:   dcgm.Device{},
+					InstanceInfo: nil,
+					ParentId:     PARENT_ID_IGNORED,
+					ParentType:   dcgm.FE_NONE,
+				},
+				{
+					Entity:       dcgm.GroupEntityPair{EntityGroupId: dcgm.FE_VGPU, EntityId: uint(7)},
+					DeviceInfo:   dcgm.Device{},
+					InstanceInfo: nil,
+					ParentId:     PARENT_ID_IGNORED,
+					ParentType:   dcgm.FE_NONE,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			deviceInfo := mockdeviceinfo.NewMockProvider(ctrl)
+			deviceInfo.EXPECT().VGPUs().Return(tt.vgpus).AnyTimes()
+
+			got := monitorAllVGPUs(deviceInfo)
+			assert.Equalf(t, tt.want, got, "Unexpected Output")
+		})
+	}
+}
+
 func Test_monitorAllCPUCores(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1657,3 +1702,35 @@ func Test_monitorGPUInstance(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMonitoredEntities_ComputeInstances(t *testing.T) {
+	gpuInstance := deviceinfo.GPUInstanceInfo{
+		Info:        dcgm.MigEntityInfo{GpuUuid: "fake", NvmlInstanceId: 1, NvmlProfileSlices: 3},
+		ProfileName: "3g.20gb",
+		EntityId:    14,
+		ComputeInstances: []deviceinfo.ComputeInstanceInfo{
+			{InstanceInfo: dcgm.MigEntityInfo{NvmlInstanceId: 1, NvmlComputeInstanceId: 0}, EntityId: 20},
+			{InstanceInfo: dcgm.MigEntityInfo{NvmlInstanceId: 1, NvmlComputeInstanceId: 1}, EntityId: 21},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	mockGPUDeviceInfo := testutils.MockGPUDeviceInfo(ctrl, 1,
+		map[int][]deviceinfo.GPUInstanceInfo{0: {gpuInstance}})
+	mockGPUDeviceInfo.EXPECT().GOpts().Return(appconfig.DeviceOptions{Flex: true, ComputeInstances: true}).AnyTimes()
+
+	got := GetMonitoredEntities(mockGPUDeviceInfo)
+	require.Len(t, got, 3)
+
+	assert.Equal(t, dcgm.FE_GPU_I, got[0].Entity.EntityGroupId)
+	assert.Nil(t, got[0].ComputeInstance())
+
+	for i, ci := range gpuInstance.ComputeInstances {
+		mi := got[i+1]
+		assert.Equal(t, dcgm.GroupEntityPair{EntityGroupId: dcgm.FE_GPU_CI, EntityId: ci.EntityId}, mi.Entity)
+		assert.Equal(t, gpuInstance.EntityId, mi.ParentId)
+		assert.Equal(t, dcgm.FE_GPU_I, mi.ParentType)
+		require.NotNil(t, mi.ComputeInstance())
+		assert.Equal(t, ci, *mi.ComputeInstance())
+	}
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devicemonitoring
+
+import (
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/passthrough"
+)
+
+// withoutPassthroughGPUs removes the entities of the GPUs bound to vfio-pci for VM passthrough from monitoring:
+// DCGM can't watch them
+func withoutPassthroughGPUs(monitoring []Info) []Info {
+	kept := monitoring[:0]
+	for _, mi := range monitoring {
+		if passthrough.IsPassthrough(mi.DeviceInfo.PCI.BusID) {
+			continue
+		}
+		kept = append(kept, mi)
+	}
+	return kept
+}
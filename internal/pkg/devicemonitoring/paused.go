@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devicemonitoring
+
+import (
+	"maps"
+	"sync"
+	"time"
+)
+
+// pausedGPUs are the GPUs whose collection is paused, e.g. during a firmware update
+var pausedGPUs = struct {
+	sync.RWMutex
+	since map[string]time.Time // UUID -> time the collection was paused
+}{since: map[string]time.Time{}}
+
+// PauseGPU pauses the collection of a GPU, its GPU instances and its NvLinks: they are left out of the
+// monitored entities, so the watches of the next registry and the metrics skip them. It returns false
+// when the GPU was already paused.
+func PauseGPU(uuid string) bool {
+	pausedGPUs.Lock()
+	defer pausedGPUs.Unlock()
+
+	if _, exists := pausedGPUs.since[uuid]; exists {
+		return false
+	}
+	pausedGPUs.since[uuid] = time.Now()
+	return true
+}
+
+// ResumeGPU resumes the collection of a paused GPU. It returns false when the GPU wasn't paused.
+func ResumeGPU(uuid string) bool {
+	pausedGPUs.Lock()
+	defer pausedGPUs.Unlock()
+
+	if _, exists := pausedGPUs.since[uuid]; !exists {
+		return false
+	}
+	delete(pausedGPUs.since, uuid)
+	return true
+}
+
+// PausedGPUs returns the UUIDs of the paused GPUs and the time their collection was paused
+func PausedGPUs() map[string]time.Time {
+	pausedGPUs.RLock()
+	defer pausedGPUs.RUnlock()
+	return maps.Clone(pausedGPUs.since)
+}
+
+// IsGPUPaused returns whether the collection of a GPU is paused
+func IsGPUPaused(uuid string) bool {
+	pausedGPUs.RLock()
+	defer pausedGPUs.RUnlock()
+	_, exists := pausedGPUs.since[uuid]
+	return exists
+}
+
+// withoutPausedGPUs removes the entities of the paused GPUs from monitoring
+func withoutPausedGPUs(monitoring []Info) []Info {
+	pausedGPUs.RLock()
+	defer pausedGPUs.RUnlock()
+
+	if len(pausedGPUs.since) == 0 {
+		return monitoring
+	}
+
+	kept := monitoring[:0]
+	for _, mi := range monitoring {
+		if _, paused := pausedGPUs.since[mi.DeviceInfo.UUID]; paused && mi.DeviceInfo.UUID != "" {
+			continue
+		}
+		kept = append(kept, mi)
+	}
+	return kept
+}
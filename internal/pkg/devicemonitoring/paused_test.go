@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devicemonitoring
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPauseGPU(t *testing.T) {
+	gpu0 := Info{
+		Entity:     dcgm.GroupEntityPair{EntityGroupId: dcgm.FE_GPU, EntityId: 0},
+		DeviceInfo: dcgm.Device{GPU: 0, UUID: "GPU-0"},
+	}
+	gpu1 := Info{
+		Entity:     dcgm.GroupEntityPair{EntityGroupId: dcgm.FE_GPU, EntityId: 1},
+		DeviceInfo: dcgm.Device{GPU: 1, UUID: "GPU-1"},
+	}
+	gpu0Link := Info{
+		Entity:     dcgm.GroupEntityPair{EntityGroupId: dcgm.FE_LINK, EntityId: 0},
+		DeviceInfo: dcgm.Device{GPU: 0, UUID: "GPU-0"},
+		ParentType: dcgm.FE_GPU,
+	}
+	cpu := Info{Entity: dcgm.GroupEntityPair{EntityGroupId: dcgm.FE_CPU, EntityId: 0}}
+
+	assert.True(t, PauseGPU("GPU-0"))
+	t.Cleanup(func() { ResumeGPU("GPU-0") })
+	assert.False(t, PauseGPU("GPU-0"), "A paused GPU should not be paused twice")
+	assert.True(t, IsGPUPaused("GPU-0"))
+	assert.Contains(t, PausedGPUs(), "GPU-0")
+
+	assert.Equal(t, []Info{gpu1, cpu}, withoutPausedGPUs([]Info{gpu0, gpu1, gpu0Link, cpu}))
+
+	assert.True(t, ResumeGPU("GPU-0"))
+	assert.False(t, ResumeGPU("GPU-0"), "A resumed GPU should not be resumed twice")
+	assert.False(t, IsGPUPaused("GPU-0"))
+	assert.Equal(t, []Info{gpu0, gpu1, gpu0Link, cpu}, withoutPausedGPUs([]Info{gpu0, gpu1, gpu0Link, cpu}))
+}
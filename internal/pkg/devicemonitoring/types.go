@@ -29,3 +29,18 @@ type Info struct {
 	ParentId     uint
 	ParentType   dcgm.Field_Entity_Group
 }
+
+// ComputeInstance returns the compute instance of a FE_GPU_CI entity, or nil for other entities.
+func (i Info) ComputeInstance() *deviceinfo.ComputeInstanceInfo {
+	if i.Entity.EntityGroupId != dcgm.FE_GPU_CI || i.InstanceInfo == nil {
+		return nil
+	}
+
+	for j := range i.InstanceInfo.ComputeInstances {
+		if i.InstanceInfo.ComputeInstances[j].EntityId == i.Entity.EntityId {
+			return &i.InstanceInfo.ComputeInstances[j]
+		}
+	}
+
+	return nil
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
 
@@ -32,7 +34,10 @@ import (
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/utils"
 )
 
-type DeviceWatcher struct{}
+type DeviceWatcher struct {
+	// MIGInstanceMemory adds the MIGInstanceFields to the GPU fields, for the metrics of the GPU instances
+	MIGInstanceMemory bool
+}
 
 // WatchResources holds all DCGM resources that need cleanup
 type WatchResources struct {
@@ -94,12 +99,24 @@ func NewDeviceWatcher() *DeviceWatcher {
 
 func (d *DeviceWatcher) GetDeviceFields(counters []counters.Counter, entityType dcgm.Field_Entity_Group) []dcgm.Short {
 	var deviceFields []dcgm.Short
+	hasMetricCounters := false
 	for _, counter := range counters {
 		fieldMeta := dcgmprovider.Client().FieldGetByID(counter.FieldID)
 
-		if shouldIncludeField(entityType, fieldMeta.EntityLevel) {
+		if shouldIncludeField(entityType, fieldMeta.EntityLevel) ||
+			(entityType == dcgm.FE_LINK && slices.Contains(perLinkFields, counter.FieldID)) {
 			deviceFields = append(deviceFields, counter.FieldID)
 		}
+		hasMetricCounters = hasMetricCounters || !counter.IsLabel()
+	}
+
+	// The instance fields are metrics, they are not added to the fields of the label counters
+	if d.MIGInstanceMemory && entityType == dcgm.FE_GPU && hasMetricCounters {
+		for _, field := range MIGInstanceFields {
+			if !slices.Contains(deviceFields, field) {
+				deviceFields = append(deviceFields, field)
+			}
+		}
 	}
 
 	return deviceFields
@@ -160,9 +177,19 @@ func (d *DeviceWatcher) WatchDeviceFields(
 		}
 	}
 	resources.hasWatch = true
+	recordWatch(resources, watchRecord{
+		entity:         deviceInfo.InfoType(),
+		fields:         deviceFields,
+		groups:         len(resources.groups),
+		entities:       watchedEntityCount(deviceInfo),
+		updateInterval: time.Duration(updateFreqInUsec) * time.Microsecond,
+	})
 
 	// Return single cleanup function
-	cleanup := func() { resources.Cleanup() }
+	cleanup := func() {
+		forgetWatch(resources)
+		resources.Cleanup()
+	}
 	return resources.groups, resources.fieldGroup, []func(){cleanup}, nil
 }
 
@@ -1974,3 +1974,65 @@ func TestDeviceWatcher_GetDeviceFields(t *testing.T) {
 		})
 	}
 }
+
+func TestDeviceWatcher_GetDeviceFields_PerLinkFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDCGM := mockdcgm.NewMockDCGM(ctrl)
+
+	realDCGM := dcgmprovider.Client()
+	defer func() {
+		dcgmprovider.SetClient(realDCGM)
+	}()
+	dcgmprovider.SetClient(mockDCGM)
+
+	counterList := []counters.Counter{
+		{FieldID: dcgm.DCGM_FI_DEV_NVLINK_CRC_FLIT_ERROR_COUNT_TOTAL, FieldName: "DCGM_FI_DEV_NVLINK_CRC_FLIT_ERROR_COUNT_TOTAL"},
+		{FieldID: dcgm.DCGM_FI_PROF_NVLINK_TX_BYTES, FieldName: "DCGM_FI_PROF_NVLINK_TX_BYTES"},
+		testutils.SampleGPUTempCounter,
+	}
+	for _, counter := range counterList {
+		mockDCGM.EXPECT().FieldGetByID(counter.FieldID).
+			Return(dcgm.FieldMeta{FieldID: counter.FieldID, EntityLevel: dcgm.FE_GPU}).AnyTimes()
+	}
+
+	d := &DeviceWatcher{}
+	assert.Equal(t, []dcgm.Short{dcgm.DCGM_FI_DEV_NVLINK_CRC_FLIT_ERROR_COUNT_TOTAL, dcgm.DCGM_FI_PROF_NVLINK_TX_BYTES},
+		d.GetDeviceFields(counterList, dcgm.FE_LINK), "The per-link fields are watched on the links")
+	assert.Len(t, d.GetDeviceFields(counterList, dcgm.FE_GPU), 3, "The per-link fields are still watched on the GPUs")
+}
+
+func TestDeviceWatcher_GetDeviceFields_MIGInstanceMemory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDCGM := mockdcgm.NewMockDCGM(ctrl)
+
+	realDCGM := dcgmprovider.Client()
+	defer func() {
+		dcgmprovider.SetClient(realDCGM)
+	}()
+	dcgmprovider.SetClient(mockDCGM)
+
+	counterList := []counters.Counter{
+		testutils.SampleGPUTempCounter,
+		{FieldID: dcgm.DCGM_FI_DEV_FB_USED, FieldName: "DCGM_FI_DEV_FB_USED", PromType: "gauge"},
+		testutils.SampleDriverVersionCounter,
+	}
+	for _, counter := range counterList {
+		mockDCGM.EXPECT().FieldGetByID(counter.FieldID).
+			Return(dcgm.FieldMeta{FieldID: counter.FieldID, EntityLevel: dcgm.FE_GPU}).AnyTimes()
+	}
+
+	d := &DeviceWatcher{MIGInstanceMemory: true}
+	assert.Equal(t, []dcgm.Short{
+		testutils.SampleGPUTempCounter.FieldID,
+		dcgm.DCGM_FI_DEV_FB_USED,
+		testutils.SampleDriverVersionCounter.FieldID,
+		dcgm.DCGM_FI_DEV_FB_FREE,
+		dcgm.DCGM_FI_DEV_BAR1_USED,
+	}, d.GetDeviceFields(counterList, dcgm.FE_GPU), "The missing instance fields are added once")
+	assert.Equal(t, []dcgm.Short{testutils.SampleDriverVersionCounter.FieldID},
+		d.GetDeviceFields(counterList[2:], dcgm.FE_GPU), "The label counters are not expanded")
+	assert.Empty(t, d.GetDeviceFields(counterList, dcgm.FE_LINK), "The links are not expanded")
+
+	d = &DeviceWatcher{}
+	assert.Len(t, d.GetDeviceFields(counterList, dcgm.FE_GPU), 3, "The fields are not expanded when disabled")
+}
@@ -16,6 +16,27 @@
 
 package devicewatcher
 
+import "github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+// perLinkFields are GPU fields DCGM also reports for a single NVLink, they are watched on the links in addition
+// to the GPUs so that the throughput and the errors are exported per link
+var perLinkFields = []dcgm.Short{
+	dcgm.DCGM_FI_PROF_NVLINK_TX_BYTES,
+	dcgm.DCGM_FI_PROF_NVLINK_RX_BYTES,
+	dcgm.DCGM_FI_DEV_NVLINK_CRC_FLIT_ERROR_COUNT_TOTAL,
+	dcgm.DCGM_FI_DEV_NVLINK_CRC_DATA_ERROR_COUNT_TOTAL,
+	dcgm.DCGM_FI_DEV_NVLINK_REPLAY_ERROR_COUNT_TOTAL,
+	dcgm.DCGM_FI_DEV_NVLINK_RECOVERY_ERROR_COUNT_TOTAL,
+}
+
+// MIGInstanceFields are the memory fields DCGM reports per GPU instance, watched on the GPUs with the
+// MIG instance memory expansion even when no counter lists them, so that the memory is exported per instance
+var MIGInstanceFields = []dcgm.Short{
+	dcgm.DCGM_FI_DEV_FB_USED,
+	dcgm.DCGM_FI_DEV_FB_FREE,
+	dcgm.DCGM_FI_DEV_BAR1_USED,
+}
+
 var doNothing = func() {
 	// This function is intentionally left blank
 }
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devicewatcher
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+)
+
+// WatchStats is the DCGM watch configuration of an entity type, summed over its watches
+type WatchStats struct {
+	Entity         dcgm.Field_Entity_Group
+	Fields         int           // distinct watched fields
+	Groups         int           // DCGM entity groups watching fields
+	Entities       int           // monitored entities
+	Watches        int           // field groups watched on the entity groups
+	UpdateInterval time.Duration // shortest update interval of the watches
+}
+
+// watchRecord is a watch alive until its cleanup
+type watchRecord struct {
+	entity         dcgm.Field_Entity_Group
+	fields         []dcgm.Short
+	groups         int
+	entities       int
+	updateInterval time.Duration
+}
+
+// activeWatches are the watches of the collectors of the registries, until they are cleaned up
+var activeWatches = struct {
+	sync.Mutex
+	records map[*WatchResources]watchRecord
+}{records: map[*WatchResources]watchRecord{}}
+
+func recordWatch(resources *WatchResources, record watchRecord) {
+	activeWatches.Lock()
+	defer activeWatches.Unlock()
+	activeWatches.records[resources] = record
+}
+
+func forgetWatch(resources *WatchResources) {
+	activeWatches.Lock()
+	defer activeWatches.Unlock()
+	delete(activeWatches.records, resources)
+}
+
+// GetWatchStats returns the watch configuration of the watched entity types, sorted by entity type. During a
+// reload, the watches of the previous registry count until they are cleaned up.
+func GetWatchStats() []WatchStats {
+	activeWatches.Lock()
+	defer activeWatches.Unlock()
+
+	statsByEntity := map[dcgm.Field_Entity_Group]*WatchStats{}
+	fieldsByEntity := map[dcgm.Field_Entity_Group]map[dcgm.Short]struct{}{}
+	for _, record := range activeWatches.records {
+		stats, exists := statsByEntity[record.entity]
+		if !exists {
+			stats = &WatchStats{Entity: record.entity, UpdateInterval: record.updateInterval}
+			statsByEntity[record.entity] = stats
+			fieldsByEntity[record.entity] = map[dcgm.Short]struct{}{}
+		}
+
+		for _, field := range record.fields {
+			fieldsByEntity[record.entity][field] = struct{}{}
+		}
+		stats.Groups += record.groups
+		stats.Watches++
+		// The watches of an entity type share its entities
+		stats.Entities = max(stats.Entities, record.entities)
+		stats.UpdateInterval = min(stats.UpdateInterval, record.updateInterval)
+	}
+
+	watchStats := make([]WatchStats, 0, len(statsByEntity))
+	for _, entity := range slices.Sorted(maps.Keys(statsByEntity)) {
+		stats := *statsByEntity[entity]
+		stats.Fields = len(fieldsByEntity[entity])
+		watchStats = append(watchStats, stats)
+	}
+	return watchStats
+}
+
+// watchedEntityCount returns the number of entities added to the groups of a watch, like the group creations do
+func watchedEntityCount(deviceInfo deviceinfo.Provider) int {
+	var count int
+	switch deviceInfo.InfoType() {
+	case dcgm.FE_LINK:
+		for _, gpu := range deviceInfo.GPUs() {
+			count += len(gpu.NvLinks)
+		}
+		for _, sw := range deviceInfo.Switches() {
+			if !deviceInfo.IsSwitchWatched(sw.EntityId) {
+				continue
+			}
+			for _, link := range sw.NvLinks {
+				if link.State == dcgm.LS_UP && deviceInfo.IsLinkWatched(link.Index, sw.EntityId) {
+					count++
+				}
+			}
+		}
+	case dcgm.FE_CPU_CORE:
+		for _, cpu := range deviceInfo.CPUs() {
+			if !deviceInfo.IsCPUWatched(cpu.EntityId) {
+				continue
+			}
+			for _, core := range cpu.Cores {
+				if deviceInfo.IsCoreWatched(core, cpu.EntityId) {
+					count++
+				}
+			}
+		}
+	default:
+		count = len(devicemonitoring.GetMonitoredEntities(deviceInfo))
+	}
+	return count
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package devicewatcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWatchStats(t *testing.T) {
+	collectInterval, p2pInterval := &WatchResources{}, &WatchResources{}
+	switches := &WatchResources{}
+	recordWatch(collectInterval, watchRecord{
+		entity: dcgm.FE_GPU, fields: []dcgm.Short{150, 155}, groups: 1, entities: 8, updateInterval: 30 * time.Second,
+	})
+	recordWatch(p2pInterval, watchRecord{
+		entity: dcgm.FE_GPU, fields: []dcgm.Short{155, 1002}, groups: 1, entities: 8, updateInterval: time.Second,
+	})
+	recordWatch(switches, watchRecord{
+		entity: dcgm.FE_SWITCH, fields: []dcgm.Short{856}, groups: 1, entities: 4, updateInterval: 30 * time.Second,
+	})
+	t.Cleanup(func() {
+		forgetWatch(collectInterval)
+		forgetWatch(p2pInterval)
+		forgetWatch(switches)
+	})
+
+	assert.Equal(t, []WatchStats{
+		{Entity: dcgm.FE_GPU, Fields: 3, Groups: 2, Entities: 8, Watches: 2, UpdateInterval: time.Second},
+		{Entity: dcgm.FE_SWITCH, Fields: 1, Groups: 1, Entities: 4, Watches: 1, UpdateInterval: 30 * time.Second},
+	}, GetWatchStats())
+
+	// The watches cleaned up are not counted anymore
+	forgetWatch(p2pInterval)
+	forgetWatch(switches)
+	assert.Equal(t, []WatchStats{
+		{Entity: dcgm.FE_GPU, Fields: 2, Groups: 1, Entities: 8, Watches: 1, UpdateInterval: 30 * time.Second},
+	}, GetWatchStats())
+}
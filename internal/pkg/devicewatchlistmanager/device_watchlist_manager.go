@@ -17,6 +17,10 @@
 package devicewatchlistmanager
 
 import (
+	"fmt"
+	"maps"
+	"slices"
+
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
 
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
@@ -32,6 +36,21 @@ var DeviceTypesToWatch = []dcgm.Field_Entity_Group{
 	dcgm.FE_LINK,
 	dcgm.FE_CPU,
 	dcgm.FE_CPU_CORE,
+	dcgm.FE_VGPU,
+}
+
+// CPUDeviceTypesToWatch are the entity group types watched in the CPU-only mode
+var CPUDeviceTypesToWatch = []dcgm.Field_Entity_Group{
+	dcgm.FE_CPU,
+	dcgm.FE_CPU_CORE,
+}
+
+// VGPUIdentityFields are the fields identifying a vGPU and its VM, always watched along with the vGPU fields
+// so that the vGPU metrics are labeled with them
+var VGPUIdentityFields = []dcgm.Short{
+	dcgm.DCGM_FI_DEV_VGPU_UUID,
+	dcgm.DCGM_FI_DEV_VGPU_VM_ID,
+	dcgm.DCGM_FI_DEV_VGPU_VM_NAME,
 }
 
 type WatchList struct {
@@ -42,6 +61,8 @@ type WatchList struct {
 	labelDeviceFields []dcgm.Short
 	watcher           devicewatcher.Watcher
 	collectInterval   int64
+	// fieldIntervals are the update intervals in milliseconds of the fields that do not use the collect interval
+	fieldIntervals map[dcgm.Short]int64
 }
 
 func NewWatchList(
@@ -73,17 +94,59 @@ func (d *WatchList) LabelDeviceFields() []dcgm.Short {
 	return d.labelDeviceFields
 }
 
+// SetFieldIntervals sets the update intervals in milliseconds of the fields that do not use the collect interval
+func (d *WatchList) SetFieldIntervals(fieldIntervals map[dcgm.Short]int64) {
+	d.fieldIntervals = fieldIntervals
+}
+
 func (d *WatchList) IsEmpty() bool {
 	return len(d.deviceFields) == 0
 }
 
+// Watch watches the device fields, with one field group per update interval. The device groups and field group
+// of the watch list are the ones of the collect interval, or of the first interval when every field sets its own.
 func (d *WatchList) Watch() ([]func(), error) {
+	if len(d.fieldIntervals) == 0 {
+		var cleanups []func()
+		var err error
+
+		d.deviceGroups, d.deviceFieldGroup, cleanups, err = d.watcher.WatchDeviceFields(d.deviceFields, d.deviceInfo,
+			d.collectInterval*1000)
+		return cleanups, err
+	}
+
+	fieldsByInterval := map[int64][]dcgm.Short{}
+	for _, field := range d.deviceFields {
+		interval, ok := d.fieldIntervals[field]
+		if !ok {
+			interval = d.collectInterval
+		}
+		fieldsByInterval[interval] = append(fieldsByInterval[interval], field)
+	}
+
+	intervals := slices.Sorted(maps.Keys(fieldsByInterval))
+	if i := slices.Index(intervals, d.collectInterval); i > 0 {
+		intervals = append(append([]int64{d.collectInterval}, intervals[:i]...), intervals[i+1:]...)
+	}
+
 	var cleanups []func()
-	var err error
+	for i, interval := range intervals {
+		groups, fieldGroup, intervalCleanups, err := d.watcher.WatchDeviceFields(fieldsByInterval[interval],
+			d.deviceInfo, interval*1000)
+		if err != nil {
+			for _, cleanup := range cleanups {
+				cleanup()
+			}
+			return nil, fmt.Errorf("failed to watch the fields updated every %dms: %w", interval, err)
+		}
 
-	d.deviceGroups, d.deviceFieldGroup, cleanups, err = d.watcher.WatchDeviceFields(d.deviceFields, d.deviceInfo,
-		d.collectInterval*1000)
-	return cleanups, err
+		if i == 0 {
+			d.deviceGroups, d.deviceFieldGroup = groups, fieldGroup
+		}
+		cleanups = append(cleanups, intervalCleanups...)
+	}
+
+	return cleanups, nil
 }
 
 func (d *WatchList) DeviceGroups() []dcgm.GroupHandle {
@@ -128,21 +191,64 @@ func (e *WatchListManager) CreateEntityWatchList(
 
 	labelDeviceFields := watcher.GetDeviceFields(e.counters.LabelCounters(), entityType)
 
+	if entityType == dcgm.FE_VGPU && len(deviceFields) > 0 {
+		for _, field := range VGPUIdentityFields {
+			if !slices.Contains(deviceFields, field) {
+				deviceFields = append(deviceFields, field)
+			}
+		}
+	}
+
 	deviceInfo, err := deviceinfo.Initialize(e.gOpts, e.sOpts, e.cOpts, e.useFakeGPUs, entityType)
 	if err != nil {
 		return err
 	}
 
-	e.entityWatchLists[entityType] = *NewWatchList(
+	watchList := NewWatchList(
 		deviceInfo,
 		deviceFields,
 		labelDeviceFields,
 		watcher,
 		collectInterval)
+	if fieldIntervals := e.fieldIntervals(deviceFields); len(fieldIntervals) > 0 {
+		watchList.SetFieldIntervals(fieldIntervals)
+	}
+
+	e.entityWatchLists[entityType] = *watchList
 
 	return err
 }
 
+// CreateNVMLWatchList loads the GPUs from NVML into the GPU WatchList, for the NVML-only mode where DCGM
+// watches no field.
+func (e *WatchListManager) CreateNVMLWatchList() error {
+	deviceInfo, err := deviceinfo.InitializeFromNVML(e.gOpts)
+	if err != nil {
+		return err
+	}
+
+	e.entityWatchLists[dcgm.FE_GPU] = *NewWatchList(deviceInfo, nil, nil, nil, 0)
+
+	return nil
+}
+
+// fieldIntervals returns the update intervals in milliseconds of the fields whose counter sets one, the lowest
+// one when several counters share a field
+func (e *WatchListManager) fieldIntervals(deviceFields []dcgm.Short) map[dcgm.Short]int64 {
+	fieldIntervals := map[dcgm.Short]int64{}
+	for _, counter := range e.counters {
+		if counter.UpdateInterval <= 0 || !slices.Contains(deviceFields, counter.FieldID) {
+			continue
+		}
+
+		interval := counter.UpdateInterval.Milliseconds()
+		if current, ok := fieldIntervals[counter.FieldID]; !ok || interval < current {
+			fieldIntervals[counter.FieldID] = interval
+		}
+	}
+	return fieldIntervals
+}
+
 // EntityWatchList returns a given entity's WatchList and true if such WatchList exists otherwise
 // an empty WatchList and false.
 func (e *WatchListManager) EntityWatchList(deviceType dcgm.Field_Entity_Group) (WatchList, bool) {
@@ -167,6 +167,59 @@ func TestNewWatchList(t *testing.T) {
 	}
 }
 
+func TestWatchList_WatchFieldIntervals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	deviceInfo := mockDeviceInfoFunc(ctrl)
+	mockDeviceWatcher := mockdevicewatcher.NewMockWatcher(ctrl)
+
+	defaultGroup := dcgm.FieldHandle{}
+	defaultGroup.SetHandle(uintptr(1))
+	slowGroup := dcgm.FieldHandle{}
+	slowGroup.SetHandle(uintptr(2))
+
+	var cleaned []string
+	gomock.InOrder(
+		mockDeviceWatcher.EXPECT().WatchDeviceFields([]dcgm.Short{1, 3}, deviceInfo, int64(30000*1000)).
+			Return([]dcgm.GroupHandle{}, defaultGroup, []func(){func() { cleaned = append(cleaned, "default") }}, nil),
+		mockDeviceWatcher.EXPECT().WatchDeviceFields([]dcgm.Short{2, 4}, deviceInfo, int64(300000*1000)).
+			Return([]dcgm.GroupHandle{}, slowGroup, []func(){func() { cleaned = append(cleaned, "slow") }}, nil),
+	)
+
+	watchList := NewWatchList(deviceInfo, []dcgm.Short{1, 2, 3, 4}, nil, mockDeviceWatcher, 30000)
+	watchList.SetFieldIntervals(map[dcgm.Short]int64{2: 300000, 4: 300000})
+
+	cleanups, err := watchList.Watch()
+	assert.NoError(t, err)
+	assert.Equal(t, defaultGroup, watchList.DeviceFieldGroup(), "expected the field group of the collect interval")
+
+	for _, cleanup := range cleanups {
+		cleanup()
+	}
+	assert.Equal(t, []string{"default", "slow"}, cleaned)
+}
+
+func TestWatchList_WatchFieldIntervalsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	deviceInfo := mockDeviceInfoFunc(ctrl)
+	mockDeviceWatcher := mockdevicewatcher.NewMockWatcher(ctrl)
+
+	cleaned := false
+	gomock.InOrder(
+		mockDeviceWatcher.EXPECT().WatchDeviceFields([]dcgm.Short{1}, deviceInfo, int64(1000*1000)).
+			Return([]dcgm.GroupHandle{}, dcgm.FieldHandle{}, []func(){func() { cleaned = true }}, nil),
+		mockDeviceWatcher.EXPECT().WatchDeviceFields([]dcgm.Short{2}, deviceInfo, int64(5000*1000)).
+			Return(nil, dcgm.FieldHandle{}, nil, fmt.Errorf("some error")),
+	)
+
+	watchList := NewWatchList(deviceInfo, []dcgm.Short{1, 2}, nil, mockDeviceWatcher, 1000)
+	watchList.SetFieldIntervals(map[dcgm.Short]int64{2: 5000})
+
+	cleanups, err := watchList.Watch()
+	assert.Error(t, err)
+	assert.Nil(t, cleanups)
+	assert.True(t, cleaned, "expected the watched intervals to be cleaned up")
+}
+
 func TestNewWatchListManager(t *testing.T) {
 	type args struct {
 		counters counters.CounterList
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package diag
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+// NewRunner creates a runner of the diagnostics of the given level. A zero interval disables the periodic runs,
+// the diagnostics then run only on demand.
+func NewRunner(level int, interval time.Duration) (*Runner, error) {
+	if !slices.Contains(Levels, level) {
+		return nil, fmt.Errorf("unsupported diagnostic level: %d", level)
+	}
+	if interval < 0 {
+		return nil, fmt.Errorf("invalid diagnostic interval: %s", interval)
+	}
+
+	return &Runner{
+		diagType: dcgm.DiagType(level),
+		interval: interval,
+	}, nil
+}
+
+// Run runs the diagnostics every interval until the context is done
+func (r *Runner) Run(ctx context.Context) {
+	if r.interval == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.Start() {
+				slog.Warn("Skipping the periodic DCGM diagnostics, a run is in progress")
+			}
+		}
+	}
+}
+
+// Start starts a diagnostics run in the background and returns false if a run is already in progress
+func (r *Runner) Start() bool {
+	if !r.running.CompareAndSwap(false, true) {
+		return false
+	}
+
+	go func() {
+		defer r.running.Store(false)
+		r.run()
+	}()
+
+	return true
+}
+
+// InProgress reports whether the diagnostics are running
+func (r *Runner) InProgress() bool {
+	return r.running.Load()
+}
+
+// LastReport returns the report of the last run, or nil before the first run completes
+func (r *Runner) LastReport() *Report {
+	return r.report.Load()
+}
+
+// Level returns the diagnostic level of the runs
+func (r *Runner) Level() int {
+	return int(r.diagType)
+}
+
+func (r *Runner) run() {
+	slog.Info("Running the DCGM diagnostics", slog.Int("level", r.Level()))
+
+	report := &Report{
+		Level:   r.Level(),
+		Time:    time.Now(),
+		Results: []TestResult{},
+	}
+
+	results, err := dcgmprovider.Client().RunDiag(r.diagType, dcgmprovider.Client().GroupAllGPUs())
+	report.Duration = time.Since(report.Time)
+	if err != nil {
+		slog.Error("Failed to run the DCGM diagnostics", slog.String(logging.ErrorKey, err.Error()))
+		report.Error = err.Error()
+		r.report.Store(report)
+		return
+	}
+
+	for _, result := range results.Software {
+		report.Results = append(report.Results, TestResult{
+			Test:    result.TestName,
+			GPU:     strconv.FormatUint(uint64(result.EntityID), 10),
+			Status:  result.Status,
+			Message: result.ErrorMessage,
+		})
+	}
+
+	failed := slices.DeleteFunc(slices.Clone(report.Results), func(result TestResult) bool {
+		return !result.Ran() || result.Passed()
+	})
+	slog.Info("DCGM diagnostics completed",
+		slog.Int("level", r.Level()),
+		slog.Duration("duration", report.Duration),
+		slog.Int("tests", len(report.Results)),
+		slog.Int("failed", len(failed)))
+
+	r.report.Store(report)
+}
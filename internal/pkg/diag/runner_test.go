@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package diag
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdcgm "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+)
+
+func TestNewRunner(t *testing.T) {
+	_, err := NewRunner(3, time.Hour)
+	assert.Error(t, err, "Long diagnostics are not supported")
+
+	_, err = NewRunner(1, -time.Hour)
+	assert.Error(t, err)
+
+	runner, err := NewRunner(2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, runner.Level())
+	assert.Nil(t, runner.LastReport())
+}
+
+func TestRunner_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDCGM := mockdcgm.NewMockDCGM(ctrl)
+
+	realDCGM := dcgmprovider.Client()
+	defer func() {
+		dcgmprovider.SetClient(realDCGM)
+	}()
+	dcgmprovider.SetClient(mockDCGM)
+
+	group := dcgm.GroupHandle{}
+	group.SetHandle(uintptr(1))
+	mockDCGM.EXPECT().GroupAllGPUs().Return(group).AnyTimes()
+
+	runner, err := NewRunner(1, 0)
+	require.NoError(t, err)
+
+	t.Run("Diagnostics complete", func(t *testing.T) {
+		release := make(chan struct{})
+		mockDCGM.EXPECT().RunDiag(dcgm.DiagType(1), group).DoAndReturn(
+			func(dcgm.DiagType, dcgm.GroupHandle) (dcgm.DiagResults, error) {
+				<-release
+				return dcgm.DiagResults{Software: []dcgm.DiagResult{
+					{TestName: "software", EntityID: 0, Status: StatusPass},
+					{TestName: "pcie", EntityID: 1, Status: StatusFail, ErrorMessage: "PCIe replays"},
+				}}, nil
+			})
+
+		require.True(t, runner.Start())
+		assert.True(t, runner.InProgress())
+		assert.False(t, runner.Start(), "Only one run at a time")
+		close(release)
+
+		require.Eventually(t, func() bool { return !runner.InProgress() }, time.Second, 10*time.Millisecond)
+
+		report := runner.LastReport()
+		require.NotNil(t, report)
+		assert.Empty(t, report.Error)
+		assert.Equal(t, []TestResult{
+			{Test: "software", GPU: "0", Status: StatusPass},
+			{Test: "pcie", GPU: "1", Status: StatusFail, Message: "PCIe replays"},
+		}, report.Results)
+	})
+
+	t.Run("Diagnostics fail to run", func(t *testing.T) {
+		mockDCGM.EXPECT().RunDiag(dcgm.DiagType(1), group).Return(dcgm.DiagResults{}, errors.New("diag module not loaded"))
+
+		require.True(t, runner.Start())
+		require.Eventually(t, func() bool { return !runner.InProgress() }, time.Second, 10*time.Millisecond)
+
+		report := runner.LastReport()
+		require.NotNil(t, report)
+		assert.Equal(t, "diag module not loaded", report.Error)
+		assert.Empty(t, report.Results)
+	})
+}
+
+func TestTestResult(t *testing.T) {
+	assert.True(t, TestResult{Status: StatusWarn}.Passed())
+	assert.True(t, TestResult{Status: StatusWarn}.Ran())
+	assert.False(t, TestResult{Status: StatusFail}.Passed())
+	assert.False(t, TestResult{Status: "skipped"}.Ran())
+}
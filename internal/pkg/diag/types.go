@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package diag
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+)
+
+// Runner runs the DCGM diagnostics periodically and on demand, and keeps the report of the last run
+type Runner struct {
+	diagType dcgm.DiagType
+	interval time.Duration
+
+	running atomic.Bool
+	report  atomic.Pointer[Report]
+}
+
+// Report is the outcome of a diagnostics run
+type Report struct {
+	Level    int           `json:"level"`
+	Time     time.Time     `json:"time"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"` // why the diagnostics couldn't run
+	Results  []TestResult  `json:"results"`
+}
+
+// TestResult is the result of a diagnostic test on a GPU
+type TestResult struct {
+	Test    string `json:"test"`
+	GPU     string `json:"gpu"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Passed reports whether the test passed, warnings don't fail a test
+func (r TestResult) Passed() bool {
+	return r.Status == StatusPass || r.Status == StatusWarn
+}
+
+// Ran reports whether the test ran on the GPU, i.e. wasn't skipped
+func (r TestResult) Ran() bool {
+	return r.Status == StatusPass || r.Status == StatusWarn || r.Status == StatusFail
+}
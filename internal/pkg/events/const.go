@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+// Severities of the events
+const (
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Types of the events
+const (
+	TypeXID            Type = "xid"             // an XID error was reported by a GPU
+	TypeThrottle       Type = "throttle"        // the clocks of a GPU started or stopped being throttled
+	TypeHealth         Type = "health"          // a DCGM health watch of a GPU changed its result
+	TypeReload         Type = "reload"          // the registry was rebuilt
+	TypeTopologyChange Type = "topology_change" // a reload changed the number of monitored entities
+	TypeConnection     Type = "connection"      // the connection to DCGM was lost or restored
+	TypeDevicePause    Type = "device_pause"    // the collection of a GPU was paused or resumed
+	TypeMaintenance    Type = "maintenance"     // a maintenance window of the node started or ended
+	TypeECC            Type = "ecc"             // double-bit ECC errors were reported by a GPU
+	TypeReset          Type = "reset"           // a GPU started or stopped needing a reset
+)
+
+// DefaultCapacity is the number of events kept in the log, the oldest ones are dropped first
+const DefaultCapacity = 1000
+
+// journalIdentifier is the SYSLOG_IDENTIFIER of the events written to the systemd journal
+const journalIdentifier = "dcgm-exporter"
+
+// journalMessageIDs are the MESSAGE_ID of the events written to the systemd journal, so that the journal-based
+// alerting can match them with journalctl MESSAGE_ID=<ID>
+var journalMessageIDs = map[Type]string{
+	TypeXID:    "5268e593edac4e54a0cb5149136f6985",
+	TypeECC:    "b4eb8b6b4c7e41a69e0c67cfca8c7bd2",
+	TypeReset:  "9f39e4185a304c1bad4ef315020162ca",
+	TypeHealth: "f2b4155257cf41a3ab4d1d1a9f29f600",
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/coreos/go-systemd/v22/journal"
+)
+
+// JournalSink writes the events of severity error, such as the XID errors, the double-bit ECC errors and the
+// GPUs needing a reset, to the systemd journal of the host, so that the journal-based alerting catches the GPU
+// faults even when the metrics are not scraped.
+type JournalSink struct {
+	send func(message string, priority journal.Priority, vars map[string]string) error
+}
+
+// NewJournalSink returns a sink writing to the systemd journal, or an error when the journal socket is not
+// reachable, e.g. on a host without systemd or a container without /run/systemd/journal mounted.
+func NewJournalSink() (*JournalSink, error) {
+	if !journal.Enabled() {
+		return nil, errors.New("the systemd journal socket is not available")
+	}
+	return &JournalSink{send: journal.Send}, nil
+}
+
+// Write writes the event to the journal when it is an error, with its type, GPU and attributes as fields.
+func (s *JournalSink) Write(event Event) {
+	if event.Severity != SeverityError {
+		return
+	}
+
+	vars := map[string]string{
+		"SYSLOG_IDENTIFIER": journalIdentifier,
+		"DCGM_EVENT_TYPE":   string(event.Type),
+	}
+	if id, exists := journalMessageIDs[event.Type]; exists {
+		vars["MESSAGE_ID"] = id
+	}
+	message := event.Message
+	if event.GPU != "" {
+		vars["GPU"] = event.GPU
+		message = fmt.Sprintf("GPU %s: %s", event.GPU, event.Message)
+	}
+	if event.GPUUUID != "" {
+		vars["GPU_UUID"] = event.GPUUUID
+	}
+	for name, value := range event.Attributes {
+		vars[journalFieldName(name)] = value
+	}
+
+	if err := s.send(message, journal.PriErr, vars); err != nil {
+		slog.Warn("Failed to write the event to the systemd journal", "type", event.Type, "error", err)
+	}
+}
+
+// journalFieldName returns the journal field of an event attribute, e.g. DCGM_ERR_CODE for err_code. The
+// journal only accepts upper case letters, digits and underscores, and the prefix keeps the attributes apart
+// from the fields of the journal.
+func journalFieldName(name string) string {
+	return "DCGM_" + strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"testing"
+
+	"github.com/coreos/go-systemd/v22/journal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalSink_Write(t *testing.T) {
+	type entry struct {
+		message  string
+		priority journal.Priority
+		vars     map[string]string
+	}
+	var written []entry
+	sink := &JournalSink{send: func(message string, priority journal.Priority, vars map[string]string) error {
+		written = append(written, entry{message: message, priority: priority, vars: vars})
+		return nil
+	}}
+
+	sink.Write(Event{Type: TypeThrottle, Severity: SeverityWarning, GPU: "0", Message: "Clocks throttled"})
+	assert.Empty(t, written, "Only the errors should be written")
+
+	sink.Write(Event{
+		Type:       TypeXID,
+		Severity:   SeverityError,
+		GPU:        "1",
+		GPUUUID:    "GPU-1",
+		Message:    "XID 79",
+		Attributes: map[string]string{"err_code": "79", "gpu_instance_id": "2"},
+	})
+	require.Len(t, written, 1)
+	assert.Equal(t, "GPU 1: XID 79", written[0].message)
+	assert.Equal(t, journal.PriErr, written[0].priority)
+	assert.Equal(t, map[string]string{
+		"SYSLOG_IDENTIFIER":    "dcgm-exporter",
+		"MESSAGE_ID":           journalMessageIDs[TypeXID],
+		"DCGM_EVENT_TYPE":      "xid",
+		"GPU":                  "1",
+		"GPU_UUID":             "GPU-1",
+		"DCGM_ERR_CODE":        "79",
+		"DCGM_GPU_INSTANCE_ID": "2",
+	}, written[0].vars)
+}
+
+func TestAddSink(t *testing.T) {
+	defer reset()
+	SetCapacity(0)
+
+	var received []Event
+	AddSink(func(event Event) {
+		received = append(received, event)
+	})
+
+	Record(Event{Type: TypeReset, Message: "GPU needs a reset"})
+	assert.Empty(t, Events(nil))
+	if assert.Len(t, received, 1, "The sinks should receive the events even when the log is disabled") {
+		assert.Equal(t, TypeReset, received[0].Type)
+		assert.False(t, received[0].Time.IsZero())
+	}
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// log is the event log of the exporter, shared by the collectors, the transformations and the reloads
+var log = struct {
+	sync.Mutex
+	capacity int
+	events   []Event
+	sinks    []func(Event)
+}{capacity: DefaultCapacity}
+
+// SetCapacity sets the number of events kept in the log, dropping the oldest ones beyond it.
+// A capacity of 0 disables the log.
+func SetCapacity(capacity int) {
+	log.Lock()
+	defer log.Unlock()
+
+	log.capacity = max(capacity, 0)
+	if len(log.events) > log.capacity {
+		log.events = slices.Delete(log.events, 0, len(log.events)-log.capacity)
+	}
+}
+
+// Enabled returns whether the events are recorded.
+func Enabled() bool {
+	log.Lock()
+	defer log.Unlock()
+
+	return log.capacity > 0
+}
+
+// AddSink registers a function called with every recorded event, even when the log is disabled.
+func AddSink(sink func(Event)) {
+	log.Lock()
+	defer log.Unlock()
+
+	log.sinks = append(log.sinks, sink)
+}
+
+// Record adds an event to the log, at the current time unless the event has one, dropping the oldest
+// event once the log is full, and passes it to the sinks.
+func Record(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	log.Lock()
+	sinks := log.sinks
+	if log.capacity > 0 {
+		if len(log.events) == log.capacity {
+			log.events = slices.Delete(log.events, 0, 1)
+		}
+		log.events = append(log.events, event)
+	}
+	log.Unlock()
+
+	// The sinks may be slow, e.g. writing to a socket, they are called without holding the log
+	for _, sink := range sinks {
+		sink(event)
+	}
+}
+
+// Events returns the events of the log accepted by match, the most recent first. A nil match accepts
+// all the events.
+func Events(match func(Event) bool) []Event {
+	log.Lock()
+	defer log.Unlock()
+
+	events := make([]Event, 0, len(log.events))
+	for i := len(log.events) - 1; i >= 0; i-- {
+		if match == nil || match(log.events[i]) {
+			events = append(events, log.events[i])
+		}
+	}
+	return events
+}
+
+// reset empties the log, removes the sinks and restores its default capacity.
+func reset() {
+	log.Lock()
+	defer log.Unlock()
+
+	log.capacity = DefaultCapacity
+	log.events = nil
+	log.sinks = nil
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecord(t *testing.T) {
+	defer reset()
+	SetCapacity(2)
+
+	Record(Event{Type: TypeXID, Message: "first"})
+	Record(Event{Type: TypeReload, Message: "second"})
+	Record(Event{Type: TypeXID, Message: "third"})
+
+	events := Events(nil)
+	if assert.Len(t, events, 2, "The oldest event should be dropped") {
+		assert.Equal(t, "third", events[0].Message, "The most recent event should be first")
+		assert.Equal(t, "second", events[1].Message)
+		assert.False(t, events[0].Time.IsZero())
+	}
+
+	xids := Events(func(e Event) bool { return e.Type == TypeXID })
+	if assert.Len(t, xids, 1) {
+		assert.Equal(t, "third", xids[0].Message)
+	}
+
+	SetCapacity(1)
+	assert.Len(t, Events(nil), 1)
+}
+
+func TestRecordKeepsTime(t *testing.T) {
+	defer reset()
+
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	Record(Event{Time: at, Type: TypeReload})
+	assert.Equal(t, at, Events(nil)[0].Time)
+}
+
+func TestDisabled(t *testing.T) {
+	defer reset()
+	SetCapacity(0)
+
+	Record(Event{Type: TypeXID})
+	assert.False(t, Enabled())
+	assert.Empty(t, Events(nil))
+}
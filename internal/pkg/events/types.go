@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import "time"
+
+type Severity string
+
+type Type string
+
+// Event is an entry of the event log served by /api/v1/events
+type Event struct {
+	Time       time.Time         `json:"time"`
+	Type       Type              `json:"type"`
+	Severity   Severity          `json:"severity"`
+	GPU        string            `json:"gpu,omitempty"`
+	GPUUUID    string            `json:"gpu_uuid,omitempty"`
+	Message    string            `json:"message"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
@@ -18,6 +18,7 @@ package hostname
 
 import (
 	"net"
+	"strings"
 
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
 	osinterface "github.com/NVIDIA/dcgm-exporter/internal/pkg/os"
@@ -25,6 +26,11 @@ import (
 
 var os osinterface.OS = osinterface.RealOS{}
 
+var (
+	lookupAddr  = net.LookupAddr
+	lookupCNAME = net.LookupCNAME
+)
+
 // GetHostname return a hostname where metric was collected.
 func GetHostname(config *appconfig.Config) (string, error) {
 	if config.Kubernetes {
@@ -59,3 +65,33 @@ func getLocalHostname() (string, error) {
 	}
 	return hostname, nil
 }
+
+// GetFQDN returns the fully qualified domain name of the host where metric was collected.
+// IP addresses, e.g. of a remote hostengine, are resolved by a reverse lookup.
+// If the name can't be resolved, the hostname is returned as is.
+func GetFQDN(config *appconfig.Config) (string, error) {
+	hostname, err := GetHostname(config)
+	if err != nil {
+		return "", err
+	}
+	return resolveFQDN(hostname), nil
+}
+
+func resolveFQDN(hostname string) string {
+	// IPv6 addresses may be enclosed in brackets
+	host := strings.TrimSuffix(strings.TrimPrefix(hostname, "["), "]")
+
+	if ip := net.ParseIP(host); ip != nil {
+		names, err := lookupAddr(ip.String())
+		if err != nil || len(names) == 0 {
+			return ip.String()
+		}
+		return strings.TrimSuffix(names[0], ".")
+	}
+
+	cname, err := lookupCNAME(host)
+	if err != nil || cname == "" {
+		return host
+	}
+	return strings.TrimSuffix(cname, ".")
+}
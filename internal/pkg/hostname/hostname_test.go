@@ -19,6 +19,7 @@ package hostname
 import (
 	"errors"
 	"fmt"
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -155,3 +156,44 @@ func TestGetHostname(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveFQDN(t *testing.T) {
+	defer func() {
+		lookupAddr = net.LookupAddr
+		lookupCNAME = net.LookupCNAME
+	}()
+
+	lookupAddr = func(addr string) ([]string, error) {
+		switch addr {
+		case "10.0.0.1":
+			return []string{"node1.example.com."}, nil
+		case "2001:db8::1":
+			return []string{"node2.example.com."}, nil
+		}
+		return nil, errors.New("not found")
+	}
+	lookupCNAME = func(host string) (string, error) {
+		if host == "node1" {
+			return "node1.example.com.", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	tests := []struct {
+		hostname string
+		want     string
+	}{
+		{hostname: "node1", want: "node1.example.com"},
+		{hostname: "unknown", want: "unknown"},
+		{hostname: "10.0.0.1", want: "node1.example.com"},
+		{hostname: "2001:db8::1", want: "node2.example.com"},
+		{hostname: "[2001:db8::1]", want: "node2.example.com"},
+		{hostname: "10.0.0.2", want: "10.0.0.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hostname, func(t *testing.T) {
+			assert.Equal(t, tt.want, resolveFQDN(tt.hostname))
+		})
+	}
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package identity
+
+const (
+	// GPUPrefix starts the UUIDs of the GPUs, e.g. GPU-5a5a7118-e550-79a1-597e-7631e126c57a
+	GPUPrefix = "GPU-"
+	// MIGPrefix starts the UUIDs of the MIG devices, MIG-<UUID> since R470, MIG-<GPU UUID>/<GI>/<CI> before
+	MIGPrefix = "MIG-"
+
+	// replicaSeparator separates a device ID from its replica with the NVIDIA device plugin time-slicing and MPS
+	replicaSeparator = "::"
+	// gkeReplicaSeparator separates a device ID from its replica with the GKE device plugin GPU sharing
+	gkeReplicaSeparator = "/vgpu"
+)
+
+// Kind is the format of a device ID reported by a device plugin
+type Kind int
+
+const (
+	KindUnknown Kind = iota
+	KindGPU          // GPU-<UUID>, or <GPU UUID>-<N> with the HAMi and Volcano device plugins
+	KindMIG          // MIG-<UUID>, or MIG-<GPU UUID>/<GI>/<CI> with the drivers before R470
+	KindGKEGPU       // nvidia<index> with the GKE device plugin
+	KindGKEMIG       // nvidia<index>/gi<GI> with the GKE device plugin
+)
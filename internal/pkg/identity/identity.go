@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package identity parses the device IDs the device plugins, NVML and DCGM report the GPUs and the MIG devices
+// with, so that the metrics and the pods are joined on the same device whatever the format of its ID.
+package identity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parse splits a device ID reported by a device plugin into the device and its replica. It recognizes:
+//   - the GPU and MIG UUIDs, with the ::<N> replica of the NVIDIA device plugin time-slicing and MPS
+//   - the <GPU UUID>-<N> replicas of the HAMi and Volcano device plugins
+//   - the nvidia<index> and nvidia<index>/gi<GI> devices of the GKE device plugin, with their /vgpu<N> replica
+//
+// The other device IDs are of KindUnknown, still split from their ::<N> replica.
+func Parse(deviceID string) DeviceID {
+	id := DeviceID{Device: deviceID}
+	if device, replica, found := strings.Cut(deviceID, gkeReplicaSeparator); found {
+		id.Device, id.Replica = device, replica
+	} else if device, replica, found := strings.Cut(deviceID, replicaSeparator); found {
+		id.Device, id.Replica = device, replica
+	} else if matches := splitGPUDeviceIDRegex.FindStringSubmatch(deviceID); matches != nil {
+		id.Device, id.Replica = matches[1], matches[2]
+	}
+
+	switch {
+	case strings.HasPrefix(id.Device, MIGPrefix):
+		id.Kind = KindMIG
+	case strings.HasPrefix(id.Device, GPUPrefix):
+		id.Kind = KindGPU
+	default:
+		if matches := gkeMIGDeviceIDRegex.FindStringSubmatch(id.Device); matches != nil {
+			id.Kind, id.GPUIndex, id.GPUInstanceID = KindGKEMIG, matches[1], matches[2]
+		} else if matches := gkeGPUDeviceIDRegex.FindStringSubmatch(id.Device); matches != nil {
+			id.Kind, id.GPUIndex = KindGKEGPU, matches[1]
+		}
+	}
+
+	return id
+}
+
+// ParseMIGUUID returns the GPU and compute instance of a MIG UUID of the drivers before R470 (e.g. R450 and R460),
+// which enumerate each MIG device by its CI and parent GI: MIG-<GPU UUID>/<GPU instance ID>/<Compute instance ID>.
+// The MIG UUIDs of the later drivers don't tell their GPU instance, they are resolved with NVML.
+func ParseMIGUUID(uuid string) (MIGDevice, error) {
+	tokens := strings.SplitN(uuid, "-", 2)
+	if len(tokens) != 2 || tokens[0]+"-" != MIGPrefix {
+		return MIGDevice{}, fmt.Errorf("unable to parse '%s' as MIG device UUID", uuid)
+	}
+
+	gpuTokens := strings.SplitN(tokens[1], "/", 3)
+	if len(gpuTokens) != 3 || !strings.HasPrefix(gpuTokens[0], GPUPrefix) {
+		return MIGDevice{}, fmt.Errorf("invalid MIG device UUID '%s'", uuid)
+	}
+
+	gi, err := strconv.Atoi(gpuTokens[1])
+	if err != nil {
+		return MIGDevice{}, fmt.Errorf("invalid GPU instance ID '%s' for MIG device '%s'", gpuTokens[1], uuid)
+	}
+
+	ci, err := strconv.Atoi(gpuTokens[2])
+	if err != nil {
+		return MIGDevice{}, fmt.Errorf("invalid Compute instance ID '%s' for MIG device '%s'", gpuTokens[2], uuid)
+	}
+
+	return MIGDevice{
+		ParentUUID:        gpuTokens[0],
+		GPUInstanceID:     gi,
+		ComputeInstanceID: ci,
+	}, nil
+}
+
+// NormalizePCIBusID returns a PCI bus ID in the sysfs format: lowercase, with a 4-digit domain.
+// DCGM and NVML use an 8-digit domain, e.g. 00000000:3B:00.0.
+func NormalizePCIBusID(busID string) string {
+	busID = strings.ToLower(strings.TrimSpace(busID))
+	domain, rest, found := strings.Cut(busID, ":")
+	if !found {
+		return busID
+	}
+	if len(domain) > 4 {
+		domain = domain[len(domain)-4:]
+	}
+	return domain + ":" + rest
+}
+
+// EqualPCIBusIDs returns whether two PCI bus IDs, in the sysfs, DCGM or NVML format, are of the same device
+func EqualPCIBusIDs(a, b string) bool {
+	return NormalizePCIBusID(a) == NormalizePCIBusID(b)
+}
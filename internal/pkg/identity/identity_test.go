@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package identity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		deviceID string
+		want     DeviceID
+	}{
+		{
+			name:     "GPU UUID",
+			deviceID: "GPU-5a5a7118-e550-79a1-597e-7631e126c57a",
+			want:     DeviceID{Kind: KindGPU, Device: "GPU-5a5a7118-e550-79a1-597e-7631e126c57a"},
+		},
+		{
+			name:     "GPU UUID shared by the NVIDIA device plugin",
+			deviceID: "GPU-5a5a7118-e550-79a1-597e-7631e126c57a::3",
+			want:     DeviceID{Kind: KindGPU, Device: "GPU-5a5a7118-e550-79a1-597e-7631e126c57a", Replica: "3"},
+		},
+		{
+			name:     "GPU UUID shared by the HAMi or Volcano device plugin",
+			deviceID: "GPU-5a5a7118-e550-79a1-597e-7631e126c57a-7",
+			want:     DeviceID{Kind: KindGPU, Device: "GPU-5a5a7118-e550-79a1-597e-7631e126c57a", Replica: "7"},
+		},
+		{
+			name:     "GPU UUID with an uppercase UUID shared by the HAMi or Volcano device plugin",
+			deviceID: "GPU-5A5A7118-E550-79A1-597E-7631E126C57A-12",
+			want:     DeviceID{Kind: KindGPU, Device: "GPU-5A5A7118-E550-79A1-597E-7631E126C57A", Replica: "12"},
+		},
+		{
+			name:     "GPU UUID with a trailing number that isn't a HAMi replica",
+			deviceID: "GPU-5a5a7118-e550-79a1-597e-7631e126c57a1-7",
+			want:     DeviceID{Kind: KindGPU, Device: "GPU-5a5a7118-e550-79a1-597e-7631e126c57a1-7"},
+		},
+		{
+			name:     "MIG UUID",
+			deviceID: "MIG-42f0f413-f7b0-58cc-aced-c1d1fb54db26",
+			want:     DeviceID{Kind: KindMIG, Device: "MIG-42f0f413-f7b0-58cc-aced-c1d1fb54db26"},
+		},
+		{
+			name:     "MIG UUID shared by the NVIDIA device plugin",
+			deviceID: "MIG-42f0f413-f7b0-58cc-aced-c1d1fb54db26::0",
+			want:     DeviceID{Kind: KindMIG, Device: "MIG-42f0f413-f7b0-58cc-aced-c1d1fb54db26", Replica: "0"},
+		},
+		{
+			name:     "MIG UUID with an empty replica",
+			deviceID: "MIG-2ce7a541-c516-5dbc-a76e-26cc100d9b55::",
+			want:     DeviceID{Kind: KindMIG, Device: "MIG-2ce7a541-c516-5dbc-a76e-26cc100d9b55"},
+		},
+		{
+			name:     "MIG UUID of a driver before R470",
+			deviceID: "MIG-GPU-b8ea3855-276c-c9cb-b366-c6fa655957c5/1/5",
+			want:     DeviceID{Kind: KindMIG, Device: "MIG-GPU-b8ea3855-276c-c9cb-b366-c6fa655957c5/1/5"},
+		},
+		{
+			name:     "GKE GPU",
+			deviceID: "nvidia0",
+			want:     DeviceID{Kind: KindGKEGPU, Device: "nvidia0", GPUIndex: "0"},
+		},
+		{
+			name:     "GKE GPU shared",
+			deviceID: "nvidia1/vgpu0",
+			want:     DeviceID{Kind: KindGKEGPU, Device: "nvidia1", Replica: "0", GPUIndex: "1"},
+		},
+		{
+			name:     "GKE MIG device",
+			deviceID: "nvidia0/gi0",
+			want:     DeviceID{Kind: KindGKEMIG, Device: "nvidia0/gi0", GPUIndex: "0", GPUInstanceID: "0"},
+		},
+		{
+			name:     "GKE MIG device shared",
+			deviceID: "nvidia2/gi13/vgpu1",
+			want:     DeviceID{Kind: KindGKEMIG, Device: "nvidia2/gi13", Replica: "1", GPUIndex: "2", GPUInstanceID: "13"},
+		},
+		{
+			name:     "UUID without prefix shared by the NVIDIA device plugin",
+			deviceID: "b8ea3855-276c-c9cb-b366-c6fa655957c5::2",
+			want:     DeviceID{Kind: KindUnknown, Device: "b8ea3855-276c-c9cb-b366-c6fa655957c5", Replica: "2"},
+		},
+		{
+			name:     "Unknown device",
+			deviceID: "nvidia-gpu-0",
+			want:     DeviceID{Kind: KindUnknown, Device: "nvidia-gpu-0"},
+		},
+		{
+			name:     "Empty device ID",
+			deviceID: "",
+			want:     DeviceID{Kind: KindUnknown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Parse(tt.deviceID))
+		})
+	}
+}
+
+func TestParseMIGUUID(t *testing.T) {
+	tests := []struct {
+		name    string
+		uuid    string
+		want    MIGDevice
+		wantErr bool
+	}{
+		{
+			name: "Successful Parsing",
+			uuid: "MIG-GPU-b8ea3855-276c-c9cb-b366-c6fa655957c5/1/5",
+			want: MIGDevice{
+				ParentUUID:        "GPU-b8ea3855-276c-c9cb-b366-c6fa655957c5",
+				GPUInstanceID:     1,
+				ComputeInstanceID: 5,
+			},
+		},
+		{
+			name:    "Fail, Missing MIG at the beginning of UUID",
+			uuid:    "GPU-b8ea3855-276c-c9cb-b366-c6fa655957c5/1/5",
+			wantErr: true,
+		},
+		{
+			name:    "Fail, Missing GPU at the beginning of GPU UUID",
+			uuid:    "MIG-b8ea3855-276c-c9cb-b366-c6fa655957c5/1/5",
+			wantErr: true,
+		},
+		{
+			name:    "Fail, MIG UUID of a driver from R470",
+			uuid:    "MIG-42f0f413-f7b0-58cc-aced-c1d1fb54db26",
+			wantErr: true,
+		},
+		{
+			name:    "Fail, GI not parsable",
+			uuid:    "MIG-GPU-b8ea3855-276c-c9cb-b366-c6fa655957c5/xx/5",
+			wantErr: true,
+		},
+		{
+			name:    "Fail, CI not parsable",
+			uuid:    "MIG-GPU-b8ea3855-276c-c9cb-b366-c6fa655957c5/1/xx",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMIGUUID(tt.uuid)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizePCIBusID(t *testing.T) {
+	assert.Equal(t, "0000:3b:00.0", NormalizePCIBusID("00000000:3B:00.0"))
+	assert.Equal(t, "0001:3b:00.0", NormalizePCIBusID("0001:3b:00.0"))
+	assert.Equal(t, "3b:00.0", NormalizePCIBusID("3B:00.0"))
+	assert.Equal(t, "0000:3b:00.0", NormalizePCIBusID(" 0000:3b:00.0\n"))
+}
+
+func TestEqualPCIBusIDs(t *testing.T) {
+	assert.True(t, EqualPCIBusIDs("00000000:3B:00.0", "0000:3b:00.0"), "The NVML and sysfs formats should match")
+	assert.True(t, EqualPCIBusIDs("00000000:3B:00.0", "00000000:3b:00.0"))
+	assert.False(t, EqualPCIBusIDs("00000000:3B:00.0", "00000000:86:00.0"))
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package identity
+
+import "sync"
+
+// resolver is the Resolver of the exporter, it looks the MIG devices up and keeps them in its cache
+type resolver struct {
+	lookup MIGLookup
+	cache  Cache
+}
+
+// NewResolver returns a Resolver looking the MIG devices up with lookup. The MIG devices are kept in the
+// cache when it isn't nil, the failed lookups are retried.
+func NewResolver(lookup MIGLookup, cache Cache) Resolver {
+	return &resolver{
+		lookup: lookup,
+		cache:  cache,
+	}
+}
+
+func (r *resolver) Parse(deviceID string) DeviceID {
+	return Parse(deviceID)
+}
+
+func (r *resolver) ResolveMIG(migUUID string) (MIGDevice, error) {
+	if r.cache != nil {
+		if device, exists := r.cache.Get(migUUID); exists {
+			return device, nil
+		}
+	}
+
+	device, err := r.lookup(migUUID)
+	if err != nil {
+		return MIGDevice{}, err
+	}
+
+	if r.cache != nil {
+		r.cache.Set(migUUID, device)
+	}
+	return device, nil
+}
+
+// mapCache is a Cache of unbounded size, a node has a few dozen MIG devices at most and every MIG device
+// gets a new UUID when its GPU instance is recreated
+type mapCache struct {
+	mu      sync.RWMutex
+	devices map[string]MIGDevice
+}
+
+// NewCache returns an empty Cache safe for concurrent use
+func NewCache() Cache {
+	return &mapCache{devices: make(map[string]MIGDevice)}
+}
+
+func (c *mapCache) Get(migUUID string) (MIGDevice, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	device, exists := c.devices[migUUID]
+	return device, exists
+}
+
+func (c *mapCache) Set(migUUID string, device MIGDevice) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.devices[migUUID] = device
+}
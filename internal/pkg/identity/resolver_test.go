@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package identity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver_ResolveMIG(t *testing.T) {
+	migDevice := MIGDevice{ParentUUID: "GPU-parent", GPUInstanceID: 1, ComputeInstanceID: 0}
+
+	tests := []struct {
+		name        string
+		cache       Cache
+		wantLookups int
+	}{
+		{
+			name:        "With a cache",
+			cache:       NewCache(),
+			wantLookups: 2, // the failed lookup and the first successful one
+		},
+		{
+			name:        "Without a cache",
+			wantLookups: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookups := 0
+			r := NewResolver(func(migUUID string) (MIGDevice, error) {
+				lookups++
+				assert.Equal(t, "MIG-42f0f413-f7b0-58cc-aced-c1d1fb54db26", migUUID)
+				if lookups == 1 {
+					return MIGDevice{}, errors.New("NVML library not initialized")
+				}
+				return migDevice, nil
+			}, tt.cache)
+
+			_, err := r.ResolveMIG("MIG-42f0f413-f7b0-58cc-aced-c1d1fb54db26")
+			assert.Error(t, err, "The lookup errors are returned")
+
+			for range 2 {
+				got, err := r.ResolveMIG("MIG-42f0f413-f7b0-58cc-aced-c1d1fb54db26")
+				require.NoError(t, err)
+				assert.Equal(t, migDevice, got)
+			}
+			assert.Equal(t, tt.wantLookups, lookups)
+		})
+	}
+}
+
+func TestResolver_Parse(t *testing.T) {
+	r := NewResolver(nil, nil)
+	assert.Equal(t, Parse("MIG-42f0f413-f7b0-58cc-aced-c1d1fb54db26::0"),
+		r.Parse("MIG-42f0f413-f7b0-58cc-aced-c1d1fb54db26::0"))
+}
@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package identity
+
+// DeviceID is a device ID reported by a device plugin, split into the device and its replica
+type DeviceID struct {
+	Kind Kind
+	// Device is the device ID without its replica: the GPU or MIG UUID, or the GKE device name
+	Device string
+	// Replica is the replica of a shared device, "" when the device isn't shared
+	Replica string
+	// GPUIndex is the index of the GPU of the GKE device IDs
+	GPUIndex string
+	// GPUInstanceID is the GPU instance ID of the GKE MIG device IDs
+	GPUInstanceID string
+}
+
+// MIGDevice is the GPU and compute instance of a MIG device
+type MIGDevice struct {
+	ParentUUID        string
+	GPUInstanceID     int
+	ComputeInstanceID int
+}
+
+// MIGLookup reads the GPU and compute instance of a MIG device, e.g. from NVML
+type MIGLookup func(migUUID string) (MIGDevice, error)
+
+// Cache keeps the MIG devices the Resolver looked up by MIG UUID
+type Cache interface {
+	Get(migUUID string) (MIGDevice, bool)
+	Set(migUUID string, device MIGDevice)
+}
+
+// Resolver resolves the device IDs reported by the device plugins to the devices the metrics are joined with
+type Resolver interface {
+	// Parse splits the device ID into the device and its replica
+	Parse(deviceID string) DeviceID
+	// ResolveMIG returns the GPU and compute instance of the MIG UUID
+	ResolveMIG(migUUID string) (MIGDevice, error)
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memguard
+
+import "time"
+
+// Features that can be shed, in the order they are shed
+const (
+	FeatureProcessMetrics Feature = "process_metrics" // per-process metrics and their pod mapping
+	FeaturePodLabels      Feature = "pod_labels"      // mapping of the metrics to the Kubernetes pods
+	FeatureDCP            Feature = "dcp"             // profiling metrics (DCGM_FI_PROF_*)
+)
+
+// Features are the features shed when the RSS exceeds the watermark, the first one first.
+// They are restored in the reverse order.
+var Features = []Feature{FeatureProcessMetrics, FeaturePodLabels, FeatureDCP}
+
+const (
+	// DefaultCheckInterval is the interval between two reads of the RSS, a single feature is shed or restored
+	// per check so that the effect of a change is measured before the next one
+	DefaultCheckInterval = 5 * time.Second
+
+	// recoveryRatio is the ratio of the watermark the RSS must drop below to restore a feature,
+	// so that the features aren't restored and shed again at every check
+	recoveryRatio = 0.8
+
+	statmPath = "/proc/self/statm"
+)
@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memguard
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+// shed is the number of features of Features being shed, the state is process-wide as the features
+// are checked by the collectors and the transformations, which are recreated on every reload
+var shed atomic.Int32
+
+// IsShed reports whether the feature is shed
+func IsShed(feature Feature) bool {
+	n := int(shed.Load())
+	for i := 0; i < n && i < len(Features); i++ {
+		if Features[i] == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// States returns whether each of the features is shed
+func States() map[Feature]bool {
+	states := make(map[Feature]bool, len(Features))
+	for _, feature := range Features {
+		states[feature] = IsShed(feature)
+	}
+	return states
+}
+
+func NewGuard(watermark uint64, interval time.Duration) *Guard {
+	if interval <= 0 {
+		interval = DefaultCheckInterval
+	}
+
+	return &Guard{
+		watermark: watermark,
+		interval:  interval,
+		readRSS:   readRSS,
+	}
+}
+
+// Run checks the RSS periodically until the context is canceled, the features shed are then restored
+func (g *Guard) Run(ctx context.Context) {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+	defer shed.Store(0)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := g.check(); err != nil {
+				slog.Warn("Failed to read the RSS, memory watermark disabled",
+					slog.String(logging.ErrorKey, err.Error()))
+				return
+			}
+		}
+	}
+}
+
+// check sheds the next feature if the RSS exceeds the watermark, or restores the last feature shed
+// if the RSS dropped below the recovery threshold
+func (g *Guard) check() error {
+	rss, err := g.readRSS()
+	if err != nil {
+		return err
+	}
+
+	n := int(shed.Load())
+	switch {
+	case rss > g.watermark && n < len(Features):
+		shed.Store(int32(n + 1))
+		slog.Warn("Memory watermark exceeded, shedding feature",
+			slog.String("feature", string(Features[n])),
+			slog.Uint64("rss", rss),
+			slog.Uint64("watermark", g.watermark))
+		// Return the memory of the feature to the OS now rather than at the next GC cycle
+		debug.FreeOSMemory()
+	case float64(rss) < float64(g.watermark)*recoveryRatio && n > 0:
+		shed.Store(int32(n - 1))
+		slog.Info("Memory recovered, restoring feature",
+			slog.String("feature", string(Features[n-1])),
+			slog.Uint64("rss", rss),
+			slog.Uint64("watermark", g.watermark))
+	}
+	return nil
+}
+
+// ReadRSS reads the resident set size of the process in bytes
+func ReadRSS() (uint64, error) {
+	return readRSS()
+}
+
+// readRSS reads the resident set size of the process in bytes
+func readRSS() (uint64, error) {
+	data, err := os.ReadFile(statmPath)
+	if err != nil {
+		return 0, err
+	}
+
+	// statm fields are in pages: size resident shared text lib data dt
+	fields := strings.Fields(string(data))
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("unexpected format of %s: %q", statmPath, string(data))
+	}
+
+	pages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected format of %s: %w", statmPath, err)
+	}
+	return pages * uint64(os.Getpagesize()), nil
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memguard
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuard_check(t *testing.T) {
+	t.Cleanup(func() { shed.Store(0) })
+
+	var rss uint64
+	g := NewGuard(1000, 0)
+	g.readRSS = func() (uint64, error) { return rss, nil }
+
+	// Below the watermark, nothing is shed
+	rss = 900
+	require.NoError(t, g.check())
+	assert.False(t, IsShed(FeatureProcessMetrics))
+
+	// Above the watermark, the features are shed one per check in order
+	rss = 1500
+	require.NoError(t, g.check())
+	assert.Equal(t, map[Feature]bool{
+		FeatureProcessMetrics: true,
+		FeaturePodLabels:      false,
+		FeatureDCP:            false,
+	}, States())
+
+	require.NoError(t, g.check())
+	require.NoError(t, g.check())
+	require.NoError(t, g.check())
+	assert.Equal(t, map[Feature]bool{
+		FeatureProcessMetrics: true,
+		FeaturePodLabels:      true,
+		FeatureDCP:            true,
+	}, States())
+
+	// Between the recovery threshold and the watermark, nothing changes
+	rss = 900
+	require.NoError(t, g.check())
+	assert.True(t, IsShed(FeatureDCP))
+
+	// Below the recovery threshold, the features are restored in the reverse order
+	rss = 500
+	require.NoError(t, g.check())
+	assert.False(t, IsShed(FeatureDCP))
+	assert.True(t, IsShed(FeaturePodLabels))
+
+	require.NoError(t, g.check())
+	require.NoError(t, g.check())
+	assert.Equal(t, map[Feature]bool{
+		FeatureProcessMetrics: false,
+		FeaturePodLabels:      false,
+		FeatureDCP:            false,
+	}, States())
+}
+
+func TestGuard_check_ReadError(t *testing.T) {
+	t.Cleanup(func() { shed.Store(0) })
+
+	g := NewGuard(1000, 0)
+	g.readRSS = func() (uint64, error) { return 0, errors.New("boom") }
+
+	assert.Error(t, g.check())
+	assert.False(t, IsShed(FeatureProcessMetrics))
+}
@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package memguard
+
+import "time"
+
+// Feature is an optional feature of the exporter shed under memory pressure
+type Feature string
+
+// Guard sheds the optional features of the exporter when its RSS exceeds a watermark,
+// rather than letting the exporter be OOM-killed, and restores them when the memory recovers.
+type Guard struct {
+	watermark uint64 // in bytes
+	interval  time.Duration
+	readRSS   func() (uint64, error)
+}
@@ -17,41 +17,162 @@
 package nvmlprovider
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
-	"strconv"
+	"maps"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/NVIDIA/go-nvml/pkg/nvml"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/identity"
 )
 
-type MIGDeviceInfo struct {
-	ParentUUID        string
-	GPUInstanceID     int
-	ComputeInstanceID int
+// MIGDeviceInfo is the GPU and compute instance of a MIG device
+type MIGDeviceInfo = identity.MIGDevice
+
+// ProcessUtilization is a utilization sample of a process running on a GPU, the utilizations are in percent
+type ProcessUtilization struct {
+	PID       uint32
+	TimeStamp uint64 // CPU timestamp of the sample in microseconds
+	SMUtil    uint32
+	MemUtil   uint32
+	EncUtil   uint32
+	DecUtil   uint32
+}
+
+// AccountingStats are the statistics NVML accumulated over the lifetime of a process while the accounting mode
+// of the GPU was enabled
+type AccountingStats struct {
+	PID            uint32
+	GPUUtil        uint32 // average SM utilization over the lifetime of the process, in percent
+	MemUtil        uint32 // average memory utilization over the lifetime of the process, in percent
+	MaxMemoryUsage uint64 // maximum memory used by the process, in bytes
+	StartTime      uint64 // CPU timestamp of the start of the process in microseconds
+	Time           uint64 // run time of the process in milliseconds, so far if it is running
+	IsRunning      bool
+}
+
+// Types of the device at the other end of an NVLink
+const (
+	NvLinkDeviceGPU     = "gpu"
+	NvLinkDeviceSwitch  = "nvswitch"
+	NvLinkDeviceIBMNPU  = "ibmnpu"
+	NvLinkDeviceUnknown = "unknown"
+)
+
+// NvLinkRemote is the device at the other end of an NVLink
+type NvLinkRemote struct {
+	DeviceType string // one of the NvLinkDevice* types
+	PCIBusID   string
+}
+
+// GPUDevice identifies a GPU as enumerated by NVML
+type GPUDevice struct {
+	Index    uint
+	UUID     string
+	Name     string
+	PCIBusID string
+}
+
+// MIGLayout is the MIG configuration of a GPU as read from NVML
+type MIGLayout struct {
+	Enabled   bool          // whether the current MIG mode of the GPU is enabled
+	Instances []MIGInstance // the compute instances of the GPU, sorted by GPU instance then compute instance
+}
+
+// MIGInstance identifies a compute instance within its GPU instance
+type MIGInstance struct {
+	GPUInstanceID     uint
+	ComputeInstanceID uint
+}
+
+// DeviceStatus is the state of a GPU as read from NVML
+type DeviceStatus struct {
+	GPUUtil     uint32  // SM utilization over the last sample period, in percent
+	MemCopyUtil uint32  // memory controller utilization over the last sample period, in percent
+	MemUsed     uint64  // used framebuffer memory, in bytes
+	MemFree     uint64  // free framebuffer memory, in bytes
+	Temperature uint32  // GPU temperature, in degrees C
+	PowerUsage  float64 // power draw, in watts
+}
+
+// MIGCapacity is the free capacity of a MIG enabled GPU as read from NVML
+type MIGCapacity struct {
+	TotalSlices              uint // compute slices of the GPU
+	FreeSlices               uint // compute slices not used by a GPU instance
+	LargestFreeProfileSlices uint // compute slices of the largest GPU instance profile that can still be created
 }
 
-var nvmlInterface NVML
+// XIDEvent is an XID error reported by the NVML events of a GPU
+type XIDEvent struct {
+	GPUUUID string
+	XID     uint64
+}
+
+// xidEventWaitTimeout bounds a wait for the NVML events, so that WatchXIDEvents returns soon after ctx is done
+const xidEventWaitTimeout = 1000 // ms
+
+var (
+	nvmlInterface NVML
+	clientMtx     sync.RWMutex // guards nvmlInterface, read by the scrapes while NVML initializes
+	initMtx       sync.Mutex   // serializes the initializations
+)
 
 // Initialize sets up the Singleton NVML interface.
+// It is safe to call concurrently, NVML is only initialized once.
 func Initialize() error {
-	var err error
-	nvmlInterface, err = newNVMLProvider()
+	initMtx.Lock()
+	defer initMtx.Unlock()
+
+	provider, err := newNVMLProvider()
 	if err != nil {
 		return err
 	}
+	SetClient(provider)
 	return nil
 }
 
+// InitializeWithRetry initializes NVML, retrying with an exponential backoff from retryInterval up to
+// maxRetryInterval until it succeeds or ctx is done. Until then, Client returns a non-initialized provider
+// whose methods return errors, so that the callers, e.g. the MIG device parsing, degrade gracefully.
+func InitializeWithRetry(ctx context.Context, retryInterval, maxRetryInterval time.Duration) error {
+	for attempt := 1; ; attempt++ {
+		err := Initialize()
+		if err == nil {
+			return nil
+		}
+
+		slog.Warn("Failed to initialize NVML - retrying",
+			slog.Int("attempt", attempt),
+			slog.Duration("retry_in", retryInterval),
+			slog.String("error", err.Error()))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("NVML not initialized after %d attempts: %w", attempt, err)
+		case <-time.After(retryInterval):
+		}
+		retryInterval = min(2*retryInterval, maxRetryInterval)
+	}
+}
+
 // reset clears the current NVML interface instance.
 func reset() {
-	nvmlInterface = nil
+	SetClient(nil)
 }
 
 // Client retrieves the current NVML interface instance.
 // Returns a non-initialized provider if NVML was never initialized.
 func Client() NVML {
+	clientMtx.RLock()
+	defer clientMtx.RUnlock()
+
 	if nvmlInterface == nil {
 		// Return a non-initialized provider that will safely return errors
 		return nvmlProvider{initialized: false}
@@ -61,6 +182,9 @@ func Client() NVML {
 
 // SetClient sets the current NVML interface instance to the provided one.
 func SetClient(n NVML) {
+	clientMtx.Lock()
+	defer clientMtx.Unlock()
+
 	nvmlInterface = n
 }
 
@@ -71,7 +195,7 @@ type nvmlProvider struct {
 
 func newNVMLProvider() (NVML, error) {
 	// Check if a NVML client already exists and return it if so.
-	if Client() != nil && Client().(nvmlProvider).initialized {
+	if provider, ok := Client().(nvmlProvider); !ok || provider.initialized {
 		slog.Info("NVML already initialized.")
 		return Client(), nil
 	}
@@ -140,34 +264,13 @@ func getMIGDeviceInfoForNewDriver(device nvml.Device) (*MIGDeviceInfo, error) {
 }
 
 // getMIGDeviceInfoForOldDriver identifies MIG Device Information for drivers < R470 (e.g. R450 and R460),
-// each MIG device is enumerated by specifying the CI and the corresponding parent GI. The format follows this
-// convention: MIG-<GPU-UUID>/<GPU instance ID>/<Compute instance ID>.
+// each MIG device is enumerated by specifying the CI and the corresponding parent GI, see identity.ParseMIGUUID.
 func getMIGDeviceInfoForOldDriver(uuid string) (*MIGDeviceInfo, error) {
-	tokens := strings.SplitN(uuid, "-", 2)
-	if len(tokens) != 2 || tokens[0] != "MIG" {
-		return nil, fmt.Errorf("unable to parse '%s' as MIG device UUID", uuid)
-	}
-
-	gpuTokens := strings.SplitN(tokens[1], "/", 3)
-	if len(gpuTokens) != 3 || !strings.HasPrefix(gpuTokens[0], "GPU-") {
-		return nil, fmt.Errorf("invalid MIG device UUID '%s'", uuid)
-	}
-
-	gi, err := strconv.Atoi(gpuTokens[1])
+	device, err := identity.ParseMIGUUID(uuid)
 	if err != nil {
-		return nil, fmt.Errorf("invalid GPU instance ID '%s' for MIG device '%s'", gpuTokens[1], uuid)
+		return nil, err
 	}
-
-	ci, err := strconv.Atoi(gpuTokens[2])
-	if err != nil {
-		return nil, fmt.Errorf("invalid Compute instance ID '%s' for MIG device '%s'", gpuTokens[2], uuid)
-	}
-
-	return &MIGDeviceInfo{
-		ParentUUID:        gpuTokens[0],
-		GPUInstanceID:     gi,
-		ComputeInstanceID: ci,
-	}, nil
+	return &device, nil
 }
 
 // GetDeviceProcessMemory returns memory usage for compute processes running on the GPU
@@ -194,8 +297,66 @@ func (n nvmlProvider) GetDeviceProcessMemory(gpuUUID string) (map[uint32]uint64,
 	return result, nil
 }
 
+// GetMPSClients returns the PIDs of the MPS client processes running on the GPU
+func (n nvmlProvider) GetMPSClients(gpuUUID string) ([]uint32, error) {
+	if err := n.preCheck(); err != nil {
+		return nil, fmt.Errorf("failed to get MPS clients: %w", err)
+	}
+
+	device, ret := nvml.DeviceGetHandleByUUID(gpuUUID)
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get device handle for UUID %s: %s", gpuUUID, nvml.ErrorString(ret))
+	}
+
+	processes, ret := device.GetMPSComputeRunningProcesses()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get MPS compute running processes: %s", nvml.ErrorString(ret))
+	}
+
+	pids := make([]uint32, 0, len(processes))
+	for _, p := range processes {
+		pids = append(pids, p.Pid)
+	}
+	return pids, nil
+}
+
+// GetComputeMode returns the compute mode of the GPU
+func (n nvmlProvider) GetComputeMode(gpuUUID string) (int, error) {
+	if err := n.preCheck(); err != nil {
+		return 0, fmt.Errorf("failed to get compute mode: %w", err)
+	}
+
+	device, ret := nvml.DeviceGetHandleByUUID(gpuUUID)
+	if ret != nvml.SUCCESS {
+		return 0, fmt.Errorf("failed to get device handle for UUID %s: %s", gpuUUID, nvml.ErrorString(ret))
+	}
+
+	mode, ret := device.GetComputeMode()
+	if ret != nvml.SUCCESS {
+		return 0, fmt.Errorf("failed to get compute mode: %s", nvml.ErrorString(ret))
+	}
+	return int(mode), nil
+}
+
 // GetDeviceProcessUtilization returns SM utilization for processes running on the GPU
 func (n nvmlProvider) GetDeviceProcessUtilization(gpuUUID string) (map[uint32]uint32, error) {
+	samples, err := n.GetProcessUtilization(gpuUUID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint32]uint32, len(samples))
+	for _, s := range samples {
+		result[s.PID] = s.SMUtil
+	}
+
+	return result, nil
+}
+
+// GetProcessUtilization returns the latest utilization sample of every process running on the GPU, among
+// the samples more recent than lastSeenTimeStamp. NVML keeps a buffer of samples per process, so a process
+// without a sample since lastSeenTimeStamp was idle.
+func (n nvmlProvider) GetProcessUtilization(gpuUUID string, lastSeenTimeStamp uint64) ([]ProcessUtilization, error) {
 	if err := n.preCheck(); err != nil {
 		return nil, fmt.Errorf("failed to get device process utilization: %w", err)
 	}
@@ -205,22 +366,289 @@ func (n nvmlProvider) GetDeviceProcessUtilization(gpuUUID string) (map[uint32]ui
 		return nil, fmt.Errorf("failed to get device handle for UUID %s: %s", gpuUUID, nvml.ErrorString(ret))
 	}
 
-	samples, ret := device.GetProcessUtilization(0)
+	samples, ret := device.GetProcessUtilization(lastSeenTimeStamp)
 	if ret != nvml.SUCCESS {
-		if ret == nvml.ERROR_NOT_SUPPORTED {
+		// NOT_FOUND means there is no sample since lastSeenTimeStamp
+		if ret == nvml.ERROR_NOT_SUPPORTED || ret == nvml.ERROR_NOT_FOUND {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get process utilization: %s", nvml.ErrorString(ret))
 	}
 
-	result := make(map[uint32]uint32, len(samples))
+	utilizations := make([]ProcessUtilization, 0, len(samples))
+	for _, s := range samples {
+		utilizations = append(utilizations, ProcessUtilization{
+			PID:       s.Pid,
+			TimeStamp: s.TimeStamp,
+			SMUtil:    s.SmUtil,
+			MemUtil:   s.MemUtil,
+			EncUtil:   s.EncUtil,
+			DecUtil:   s.DecUtil,
+		})
+	}
+
+	return latestProcessUtilization(utilizations), nil
+}
+
+// latestProcessUtilization keeps the latest sample of every process, sorted by PID
+func latestProcessUtilization(samples []ProcessUtilization) []ProcessUtilization {
+	latest := make(map[uint32]ProcessUtilization, len(samples))
 	for _, s := range samples {
-		result[s.Pid] = s.SmUtil
+		if current, exists := latest[s.PID]; !exists || s.TimeStamp > current.TimeStamp {
+			latest[s.PID] = s
+		}
+	}
+
+	result := slices.Collect(maps.Values(latest))
+	slices.SortFunc(result, func(a, b ProcessUtilization) int {
+		return cmp.Compare(a.PID, b.PID)
+	})
+	return result
+}
+
+// EnableAccountingMode enables the accounting mode of the GPU if it is disabled
+func (n nvmlProvider) EnableAccountingMode(gpuUUID string) error {
+	if err := n.preCheck(); err != nil {
+		return fmt.Errorf("failed to enable accounting mode: %w", err)
+	}
+
+	device, ret := nvml.DeviceGetHandleByUUID(gpuUUID)
+	if ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to get device handle for UUID %s: %s", gpuUUID, nvml.ErrorString(ret))
+	}
+
+	mode, ret := device.GetAccountingMode()
+	if ret == nvml.SUCCESS && mode == nvml.FEATURE_ENABLED {
+		return nil
+	}
+
+	ret = device.SetAccountingMode(nvml.FEATURE_ENABLED)
+	if ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to enable accounting mode: %s", nvml.ErrorString(ret))
+	}
+
+	return nil
+}
+
+// GetAccountingStats returns the accounting statistics of the processes in the accounting buffer of the GPU.
+// The buffer keeps the terminated processes until it wraps around, so short-lived processes are still
+// accounted for after they exit.
+func (n nvmlProvider) GetAccountingStats(gpuUUID string) ([]AccountingStats, error) {
+	if err := n.preCheck(); err != nil {
+		return nil, fmt.Errorf("failed to get accounting stats: %w", err)
+	}
+
+	device, ret := nvml.DeviceGetHandleByUUID(gpuUUID)
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get device handle for UUID %s: %s", gpuUUID, nvml.ErrorString(ret))
+	}
+
+	pids, ret := device.GetAccountingPids()
+	if ret != nvml.SUCCESS {
+		if ret == nvml.ERROR_NOT_SUPPORTED {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get accounting pids: %s", nvml.ErrorString(ret))
+	}
+
+	result := make([]AccountingStats, 0, len(pids))
+	for _, pid := range pids {
+		stats, ret := device.GetAccountingStats(uint32(pid))
+		if ret != nvml.SUCCESS {
+			// NOT_FOUND means the process left the accounting buffer since the pids were listed
+			if ret == nvml.ERROR_NOT_FOUND {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get accounting stats of pid %d: %s", pid, nvml.ErrorString(ret))
+		}
+
+		result = append(result, AccountingStats{
+			PID:            uint32(pid),
+			GPUUtil:        stats.GpuUtilization,
+			MemUtil:        stats.MemoryUtilization,
+			MaxMemoryUsage: stats.MaxMemoryUsage,
+			StartTime:      stats.StartTime,
+			Time:           stats.Time,
+			IsRunning:      stats.IsRunning != 0,
+		})
 	}
 
 	return result, nil
 }
 
+// GetNvLinkRemote returns the type and the PCI bus ID of the device at the other end of the NVLink of the GPU
+func (n nvmlProvider) GetNvLinkRemote(gpuUUID string, link uint) (NvLinkRemote, error) {
+	if err := n.preCheck(); err != nil {
+		return NvLinkRemote{}, fmt.Errorf("failed to get NVLink remote device: %w", err)
+	}
+
+	device, ret := nvml.DeviceGetHandleByUUID(gpuUUID)
+	if ret != nvml.SUCCESS {
+		return NvLinkRemote{}, fmt.Errorf("failed to get device handle for UUID %s: %s", gpuUUID, nvml.ErrorString(ret))
+	}
+
+	pciInfo, ret := device.GetNvLinkRemotePciInfo(int(link))
+	if ret != nvml.SUCCESS {
+		return NvLinkRemote{}, fmt.Errorf("failed to get remote PCI info of NVLink %d: %s", link, nvml.ErrorString(ret))
+	}
+
+	remote := NvLinkRemote{DeviceType: NvLinkDeviceUnknown, PCIBusID: pciBusID(pciInfo)}
+
+	// Drivers older than the remote device type API only connect GPUs to GPUs or NVSwitches,
+	// the type is then unknown rather than an error
+	deviceType, ret := device.GetNvLinkRemoteDeviceType(int(link))
+	if ret == nvml.SUCCESS {
+		switch deviceType {
+		case nvml.NVLINK_DEVICE_TYPE_GPU:
+			remote.DeviceType = NvLinkDeviceGPU
+		case nvml.NVLINK_DEVICE_TYPE_SWITCH:
+			remote.DeviceType = NvLinkDeviceSwitch
+		case nvml.NVLINK_DEVICE_TYPE_IBMNPU:
+			remote.DeviceType = NvLinkDeviceIBMNPU
+		}
+	}
+
+	return remote, nil
+}
+
+// pciBusID returns the PCI bus ID of the PCI info, a NUL-terminated string
+func pciBusID(pciInfo nvml.PciInfo) string {
+	var busID strings.Builder
+	for _, c := range pciInfo.BusId {
+		if c == 0 {
+			break
+		}
+		busID.WriteByte(byte(c))
+	}
+	return busID.String()
+}
+
+// GetDevices returns the GPUs of the node, in the order of their NVML index
+func (n nvmlProvider) GetDevices() ([]GPUDevice, error) {
+	if err := n.preCheck(); err != nil {
+		return nil, fmt.Errorf("failed to get devices: %w", err)
+	}
+
+	count, ret := nvml.DeviceGetCount()
+	if ret != nvml.SUCCESS {
+		return nil, fmt.Errorf("failed to get device count: %s", nvml.ErrorString(ret))
+	}
+
+	devices := make([]GPUDevice, 0, count)
+	for i := 0; i < count; i++ {
+		device, ret := nvml.DeviceGetHandleByIndex(i)
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get device handle for index %d: %s", i, nvml.ErrorString(ret))
+		}
+
+		uuid, ret := device.GetUUID()
+		if ret != nvml.SUCCESS {
+			return nil, fmt.Errorf("failed to get UUID of device %d: %s", i, nvml.ErrorString(ret))
+		}
+
+		gpu := GPUDevice{Index: uint(i), UUID: uuid}
+		if name, ret := device.GetName(); ret == nvml.SUCCESS {
+			gpu.Name = name
+		}
+		if pciInfo, ret := device.GetPciInfo(); ret == nvml.SUCCESS {
+			gpu.PCIBusID = pciBusID(pciInfo)
+		}
+		devices = append(devices, gpu)
+	}
+
+	return devices, nil
+}
+
+// GetDeviceStatus returns the utilization, memory, temperature and power usage of the GPU.
+// The values that are not supported by the GPU, e.g. the power usage of some Jetson modules, are left to 0.
+func (n nvmlProvider) GetDeviceStatus(gpuUUID string) (DeviceStatus, error) {
+	if err := n.preCheck(); err != nil {
+		return DeviceStatus{}, fmt.Errorf("failed to get device status: %w", err)
+	}
+
+	device, ret := nvml.DeviceGetHandleByUUID(gpuUUID)
+	if ret != nvml.SUCCESS {
+		return DeviceStatus{}, fmt.Errorf("failed to get device handle for UUID %s: %s", gpuUUID, nvml.ErrorString(ret))
+	}
+
+	var status DeviceStatus
+	if utilization, ret := device.GetUtilizationRates(); ret == nvml.SUCCESS {
+		status.GPUUtil = utilization.Gpu
+		status.MemCopyUtil = utilization.Memory
+	}
+	if memory, ret := device.GetMemoryInfo(); ret == nvml.SUCCESS {
+		status.MemUsed = memory.Used
+		status.MemFree = memory.Free
+	}
+	if temperature, ret := device.GetTemperature(nvml.TEMPERATURE_GPU); ret == nvml.SUCCESS {
+		status.Temperature = temperature
+	}
+	if power, ret := device.GetPowerUsage(); ret == nvml.SUCCESS {
+		status.PowerUsage = float64(power) / 1000
+	}
+
+	return status, nil
+}
+
+// WatchXIDEvents registers the XID critical errors of every GPU supporting them and calls onEvent for every
+// error until ctx is done
+func (n nvmlProvider) WatchXIDEvents(ctx context.Context, onEvent func(XIDEvent)) error {
+	if err := n.preCheck(); err != nil {
+		return fmt.Errorf("failed to watch XID events: %w", err)
+	}
+
+	eventSet, ret := nvml.EventSetCreate()
+	if ret != nvml.SUCCESS {
+		return fmt.Errorf("failed to create event set: %s", nvml.ErrorString(ret))
+	}
+	defer eventSet.Free()
+
+	devices, err := n.GetDevices()
+	if err != nil {
+		return err
+	}
+
+	registered := 0
+	for _, gpu := range devices {
+		device, ret := nvml.DeviceGetHandleByUUID(gpu.UUID)
+		if ret != nvml.SUCCESS {
+			continue
+		}
+		ret = device.RegisterEvents(nvml.EventTypeXidCriticalError, eventSet)
+		if ret != nvml.SUCCESS {
+			slog.Warn("XID events are not supported by the GPU",
+				slog.String("uuid", gpu.UUID),
+				slog.String("error", nvml.ErrorString(ret)))
+			continue
+		}
+		registered++
+	}
+	if registered == 0 {
+		return errors.New("no GPU supports the XID events")
+	}
+
+	for ctx.Err() == nil {
+		data, ret := eventSet.Wait(xidEventWaitTimeout)
+		if ret == nvml.ERROR_TIMEOUT {
+			continue
+		}
+		if ret != nvml.SUCCESS {
+			return fmt.Errorf("failed to wait for events: %s", nvml.ErrorString(ret))
+		}
+		if data.EventType != nvml.EventTypeXidCriticalError {
+			continue
+		}
+
+		uuid, ret := data.Device.GetUUID()
+		if ret != nvml.SUCCESS {
+			continue
+		}
+		onEvent(XIDEvent{GPUUUID: uuid, XID: data.EventData})
+	}
+
+	return nil
+}
+
 // GetAllMIGDevicesProcessMemory returns per-process memory usage for all MIG instances on a GPU.
 // Returns map[gpuInstanceID (MIG instance)]map[PID]memoryBytes.
 func (n nvmlProvider) GetAllMIGDevicesProcessMemory(parentGPUUUID string) (map[uint]map[uint32]uint64, error) {
@@ -272,6 +700,113 @@ func (n nvmlProvider) GetAllMIGDevicesProcessMemory(parentGPUUUID string) (map[u
 	return result, nil
 }
 
+// GetMIGLayout returns the MIG mode and the compute instances of the GPU.
+// The GPUs that don't support MIG are reported with MIG disabled.
+func (n nvmlProvider) GetMIGLayout(gpuUUID string) (MIGLayout, error) {
+	if err := n.preCheck(); err != nil {
+		return MIGLayout{}, fmt.Errorf("failed to get MIG layout: %w", err)
+	}
+
+	device, ret := nvml.DeviceGetHandleByUUID(gpuUUID)
+	if ret != nvml.SUCCESS {
+		return MIGLayout{}, fmt.Errorf("failed to get device handle for UUID %s: %s", gpuUUID, nvml.ErrorString(ret))
+	}
+
+	mode, _, ret := device.GetMigMode()
+	if ret == nvml.ERROR_NOT_SUPPORTED {
+		return MIGLayout{}, nil
+	}
+	if ret != nvml.SUCCESS {
+		return MIGLayout{}, fmt.Errorf("failed to get MIG mode for UUID %s: %s", gpuUUID, nvml.ErrorString(ret))
+	}
+	if mode != nvml.DEVICE_MIG_ENABLE {
+		return MIGLayout{}, nil
+	}
+
+	migCount, ret := device.GetMaxMigDeviceCount()
+	if ret != nvml.SUCCESS {
+		return MIGLayout{}, fmt.Errorf("failed to get MIG device count for UUID %s: %s", gpuUUID, nvml.ErrorString(ret))
+	}
+
+	layout := MIGLayout{Enabled: true}
+	for i := 0; i < migCount; i++ {
+		migDevice, ret := device.GetMigDeviceHandleByIndex(i)
+		if ret == nvml.ERROR_NOT_FOUND || ret == nvml.ERROR_INVALID_ARGUMENT {
+			continue
+		}
+		if ret != nvml.SUCCESS {
+			return MIGLayout{}, fmt.Errorf("failed to get MIG device %d for UUID %s: %s", i, gpuUUID, nvml.ErrorString(ret))
+		}
+
+		giID, ret := migDevice.GetGpuInstanceId()
+		if ret != nvml.SUCCESS {
+			return MIGLayout{}, fmt.Errorf("failed to get GPU instance ID of MIG device %d: %s", i, nvml.ErrorString(ret))
+		}
+		ciID, ret := migDevice.GetComputeInstanceId()
+		if ret != nvml.SUCCESS {
+			return MIGLayout{}, fmt.Errorf("failed to get compute instance ID of MIG device %d: %s", i, nvml.ErrorString(ret))
+		}
+		layout.Instances = append(layout.Instances, MIGInstance{GPUInstanceID: uint(giID), ComputeInstanceID: uint(ciID)})
+	}
+
+	slices.SortFunc(layout.Instances, func(a, b MIGInstance) int {
+		return cmp.Or(cmp.Compare(a.GPUInstanceID, b.GPUInstanceID), cmp.Compare(a.ComputeInstanceID, b.ComputeInstanceID))
+	})
+
+	return layout, nil
+}
+
+func (n nvmlProvider) GetMIGCapacity(gpuUUID string) (MIGCapacity, error) {
+	if err := n.preCheck(); err != nil {
+		return MIGCapacity{}, fmt.Errorf("failed to get MIG capacity: %w", err)
+	}
+
+	device, ret := nvml.DeviceGetHandleByUUID(gpuUUID)
+	if ret != nvml.SUCCESS {
+		return MIGCapacity{}, fmt.Errorf("failed to get device handle for UUID %s: %s", gpuUUID, nvml.ErrorString(ret))
+	}
+
+	var capacity MIGCapacity
+	var usedSlices uint
+	for profile := 0; profile < nvml.GPU_INSTANCE_PROFILE_COUNT; profile++ {
+		info, ret := device.GetGpuInstanceProfileInfo(profile)
+		// The GPU doesn't support every profile, e.g. the 8 slice profile only exists on the 8 slice GPUs
+		if ret == nvml.ERROR_NOT_SUPPORTED || ret == nvml.ERROR_INVALID_ARGUMENT {
+			continue
+		}
+		if ret != nvml.SUCCESS {
+			return MIGCapacity{}, fmt.Errorf("failed to get GPU instance profile %d for UUID %s: %s", profile, gpuUUID,
+				nvml.ErrorString(ret))
+		}
+
+		profileSlices := uint(info.SliceCount)
+		capacity.TotalSlices = max(capacity.TotalSlices, profileSlices)
+
+		instances, ret := device.GetGpuInstances(&info)
+		if ret != nvml.SUCCESS {
+			return MIGCapacity{}, fmt.Errorf("failed to get GPU instances of profile %d for UUID %s: %s", profile,
+				gpuUUID, nvml.ErrorString(ret))
+		}
+		usedSlices += uint(len(instances)) * profileSlices
+
+		// The remaining capacity accounts for the placement of the existing GPU instances
+		remaining, ret := device.GetGpuInstanceRemainingCapacity(&info)
+		if ret != nvml.SUCCESS {
+			return MIGCapacity{}, fmt.Errorf("failed to get remaining capacity of profile %d for UUID %s: %s", profile,
+				gpuUUID, nvml.ErrorString(ret))
+		}
+		if remaining > 0 {
+			capacity.LargestFreeProfileSlices = max(capacity.LargestFreeProfileSlices, profileSlices)
+		}
+	}
+
+	if usedSlices < capacity.TotalSlices {
+		capacity.FreeSlices = capacity.TotalSlices - usedSlices
+	}
+
+	return capacity, nil
+}
+
 // Cleanup performs cleanup operations for the NVML provider
 func (n nvmlProvider) Cleanup() {
 	if !n.initialized {
@@ -17,7 +17,10 @@
 package nvmlprovider
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -47,6 +50,52 @@ func TestGetDeviceProcessUtilization_When_NVML_Not_Initialized(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to get device process utilization")
 }
 
+func TestGetProcessUtilization_When_NVML_Not_Initialized(t *testing.T) {
+	provider := nvmlProvider{}
+	result, err := provider.GetProcessUtilization("GPU-test-uuid", 0)
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to get device process utilization")
+}
+
+func TestEnableAccountingMode_When_NVML_Not_Initialized(t *testing.T) {
+	provider := nvmlProvider{}
+	err := provider.EnableAccountingMode("GPU-test-uuid")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to enable accounting mode")
+}
+
+func TestGetAccountingStats_When_NVML_Not_Initialized(t *testing.T) {
+	provider := nvmlProvider{}
+	result, err := provider.GetAccountingStats("GPU-test-uuid")
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to get accounting stats")
+}
+
+func TestGetNvLinkRemote_When_NVML_Not_Initialized(t *testing.T) {
+	provider := nvmlProvider{}
+	result, err := provider.GetNvLinkRemote("GPU-test-uuid", 0)
+	assert.Error(t, err)
+	assert.Equal(t, NvLinkRemote{}, result)
+	assert.Contains(t, err.Error(), "failed to get NVLink remote device")
+}
+
+func Test_latestProcessUtilization(t *testing.T) {
+	// NVML returns several samples per process from its buffer
+	samples := []ProcessUtilization{
+		{PID: 2001, TimeStamp: 100, SMUtil: 10},
+		{PID: 1001, TimeStamp: 300, SMUtil: 80, MemUtil: 20},
+		{PID: 2001, TimeStamp: 200, SMUtil: 30, EncUtil: 5},
+		{PID: 1001, TimeStamp: 100, SMUtil: 40},
+	}
+
+	assert.Equal(t, []ProcessUtilization{
+		{PID: 1001, TimeStamp: 300, SMUtil: 80, MemUtil: 20},
+		{PID: 2001, TimeStamp: 200, SMUtil: 30, EncUtil: 5},
+	}, latestProcessUtilization(samples))
+}
+
 func TestGetAllMIGDevicesProcessMemory_When_NVML_Not_Initialized(t *testing.T) {
 	provider := nvmlProvider{}
 	result, err := provider.GetAllMIGDevicesProcessMemory("GPU-test-uuid")
@@ -144,6 +193,37 @@ func Test_newNVMLProvider(t *testing.T) {
 	}
 }
 
+func TestInitializeWithRetry_When_Already_Initialized(t *testing.T) {
+	SetClient(nvmlProvider{initialized: true})
+	defer reset()
+
+	// NVML is initialized at the first attempt, the context is never waited on
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	require.NoError(t, InitializeWithRetry(ctx, time.Millisecond, time.Millisecond))
+	assert.Equal(t, nvmlProvider{initialized: true}, Client())
+}
+
+func TestClient_ConcurrentInitialize(t *testing.T) {
+	SetClient(nvmlProvider{initialized: true})
+	defer reset()
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, Initialize())
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NotNil(t, Client())
+		}()
+	}
+	wg.Wait()
+}
+
 // TestClient_WhenNil tests that Client() returns a safe non-nil provider when not initialized
 func TestClient_WhenNil(t *testing.T) {
 	// Reset to ensure nvmlInterface is nil
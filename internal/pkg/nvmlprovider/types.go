@@ -18,6 +18,8 @@
 
 package nvmlprovider
 
+import "context"
+
 type NVML interface {
 	GetMIGDeviceInfoByID(string) (*MIGDeviceInfo, error)
 	// GetDeviceProcessMemory returns memory usage for processes running on the GPU.
@@ -26,8 +28,33 @@ type NVML interface {
 	// GetDeviceProcessUtilization returns SM utilization for processes running on the GPU.
 	// Returns a map from PID to SM utilization percentage.
 	GetDeviceProcessUtilization(gpuUUID string) (map[uint32]uint32, error)
+	// GetMPSClients returns the PIDs of the MPS client processes running on the GPU.
+	GetMPSClients(gpuUUID string) ([]uint32, error)
+	// GetComputeMode returns the compute mode of the GPU, e.g. 3 for the exclusive process mode MPS requires.
+	GetComputeMode(gpuUUID string) (int, error)
+	// GetProcessUtilization returns the latest utilization sample of every process running on the GPU,
+	// among the samples more recent than lastSeenTimeStamp (in microseconds, 0 for all the samples).
+	GetProcessUtilization(gpuUUID string, lastSeenTimeStamp uint64) ([]ProcessUtilization, error)
 	// GetAllMIGDevicesProcessMemory returns per-process memory usage for all MIG instances on a GPU.
 	// Returns map[gpuInstanceID (MIG instance)]map[PID]memoryBytes.
 	GetAllMIGDevicesProcessMemory(parentGPUUUID string) (map[uint]map[uint32]uint64, error)
+	// EnableAccountingMode enables the accounting mode of the GPU if it is disabled, which requires root.
+	EnableAccountingMode(gpuUUID string) error
+	// GetAccountingStats returns the accounting statistics of the running and the terminated processes
+	// in the accounting buffer of the GPU.
+	GetAccountingStats(gpuUUID string) ([]AccountingStats, error)
+	// GetNvLinkRemote returns the device at the other end of the NVLink of the GPU.
+	GetNvLinkRemote(gpuUUID string, link uint) (NvLinkRemote, error)
+	// GetDevices returns the GPUs of the node, in the order of their NVML index.
+	GetDevices() ([]GPUDevice, error)
+	// GetDeviceStatus returns the utilization, memory, temperature and power usage of the GPU.
+	GetDeviceStatus(gpuUUID string) (DeviceStatus, error)
+	// GetMIGLayout returns the MIG mode and the compute instances of the GPU.
+	GetMIGLayout(gpuUUID string) (MIGLayout, error)
+	// GetMIGCapacity returns the free compute slices of the MIG enabled GPU and the largest GPU instance
+	// profile that can still be placed on them.
+	GetMIGCapacity(gpuUUID string) (MIGCapacity, error)
+	// WatchXIDEvents calls onEvent for every XID error of the GPUs until ctx is done.
+	WatchXIDEvents(ctx context.Context, onEvent func(XIDEvent)) error
 	Cleanup()
 }
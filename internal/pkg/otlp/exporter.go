@@ -0,0 +1,267 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/pushqueue"
+)
+
+// NewExporter creates an exporter pushing the metrics of source to the OTLP collector of the configuration.
+// The metrics carry the same labels as the ones served for Prometheus, since they are produced by the same
+// collectors and transformations.
+func NewExporter(
+	ctx context.Context, config *appconfig.Config, hostname string, source MetricsSource,
+) (*Exporter, error) {
+	if config.OTLPInterval <= 0 {
+		return nil, fmt.Errorf("invalid OTLP interval: %s", config.OTLPInterval)
+	}
+
+	exporter, err := newMetricExporter(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	return &Exporter{
+		exporter: exporter,
+		source:   source,
+		interval: config.OTLPInterval,
+		resource: resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+			attribute.String("host.name", hostname),
+		),
+		startTime: time.Now(),
+		queue: pushqueue.New[metricdata.Metrics](sinkName, appconfig.RedactURL(config.OTLPEndpoint), pushqueue.Config{
+			Capacity:      config.PushQueueSize,
+			BatchSize:     config.PushBatchSize,
+			FlushInterval: config.PushFlushInterval,
+		}),
+	}, nil
+}
+
+func newMetricExporter(ctx context.Context, config *appconfig.Config) (metricExporter, error) {
+	switch config.OTLPProtocol {
+	case appconfig.OTLPProtocolHTTP:
+		return otlpmetrichttp.New(ctx, otlpmetrichttp.WithEndpointURL(config.OTLPEndpoint))
+	default:
+		return otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithEndpointURL(config.OTLPEndpoint))
+	}
+}
+
+// Run queues the metrics every interval until the context is canceled, then shuts the exporter down. The
+// queue is pushed in the background, in batches of metric families.
+func (e *Exporter) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	defer e.shutdown()
+	defer wg.Wait()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		e.queue.Run(ctx, e.send)
+	}()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.enqueue(); err != nil {
+				slog.Warn("Failed to export metrics over OTLP", slog.String(logging.ErrorKey, err.Error()))
+			}
+		}
+	}
+}
+
+func (e *Exporter) shutdown() {
+	ctx, cancel := context.WithTimeout(context.Background(), e.interval)
+	defer cancel()
+
+	if err := e.exporter.Shutdown(ctx); err != nil {
+		slog.Warn("Failed to shut down the OTLP exporter", slog.String(logging.ErrorKey, err.Error()))
+	}
+}
+
+// enqueue adds the current metrics of the source to the queue.
+func (e *Exporter) enqueue() error {
+	metrics, err := e.collect()
+	if err != nil {
+		return err
+	}
+
+	e.queue.Push(metrics...)
+	return nil
+}
+
+// Export pushes the current metrics of the source, bypassing the queue.
+func (e *Exporter) Export(ctx context.Context) error {
+	metrics, err := e.collect()
+	if err != nil {
+		return err
+	}
+
+	return e.send(ctx, metrics)
+}
+
+// send pushes a batch of metrics within the interval
+func (e *Exporter) send(ctx context.Context, metrics []metricdata.Metrics) error {
+	exportCtx, cancel := context.WithTimeout(ctx, e.interval)
+	defer cancel()
+
+	return e.exporter.Export(exportCtx, e.toResourceMetrics(metrics))
+}
+
+// collect returns the current metrics of the source
+func (e *Exporter) collect() ([]metricdata.Metrics, error) {
+	var buf bytes.Buffer
+	if err := e.source(&buf); err != nil {
+		return nil, err
+	}
+
+	return e.toMetricsList(&buf, time.Now())
+}
+
+// toMetricsList converts metrics in the Prometheus text format into OTLP metrics, one per metric family.
+func (e *Exporter) toMetricsList(r io.Reader, now time.Time) ([]metricdata.Metrics, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+
+	metrics := make([]metricdata.Metrics, 0, len(families))
+	for _, name := range slices.Sorted(maps.Keys(families)) {
+		if m, ok := toMetrics(families[name], e.startTime, now); ok {
+			metrics = append(metrics, m)
+		}
+	}
+	return metrics, nil
+}
+
+// toResourceMetrics wraps metrics into the resource of the exporter.
+func (e *Exporter) toResourceMetrics(metrics []metricdata.Metrics) *metricdata.ResourceMetrics {
+	return &metricdata.ResourceMetrics{
+		Resource: e.resource,
+		ScopeMetrics: []metricdata.ScopeMetrics{
+			{
+				Scope:   instrumentation.Scope{Name: scopeName},
+				Metrics: metrics,
+			},
+		},
+	}
+}
+
+// toMetrics converts a Prometheus metric family. Counters become cumulative sums, gauges and untyped
+// metrics become gauges. Histograms aren't produced by the exporter and are skipped.
+func toMetrics(mf *dto.MetricFamily, startTime, now time.Time) (metricdata.Metrics, bool) {
+	m := metricdata.Metrics{
+		Name:        mf.GetName(),
+		Description: mf.GetHelp(),
+	}
+
+	switch mf.GetType() {
+	case dto.MetricType_COUNTER:
+		m.Data = metricdata.Sum[float64]{
+			DataPoints: toDataPoints(mf, startTime, now, func(pm *dto.Metric) float64 {
+				return pm.GetCounter().GetValue()
+			}),
+			Temporality: metricdata.CumulativeTemporality,
+			IsMonotonic: true,
+		}
+	case dto.MetricType_GAUGE:
+		m.Data = metricdata.Gauge[float64]{
+			DataPoints: toDataPoints(mf, startTime, now, func(pm *dto.Metric) float64 {
+				return pm.GetGauge().GetValue()
+			}),
+		}
+	case dto.MetricType_UNTYPED:
+		m.Data = metricdata.Gauge[float64]{
+			DataPoints: toDataPoints(mf, startTime, now, func(pm *dto.Metric) float64 {
+				return pm.GetUntyped().GetValue()
+			}),
+		}
+	case dto.MetricType_SUMMARY:
+		dataPoints := make([]metricdata.SummaryDataPoint, 0, len(mf.GetMetric()))
+		for _, pm := range mf.GetMetric() {
+			dataPoint := metricdata.SummaryDataPoint{
+				Attributes: toAttributes(pm),
+				StartTime:  startTime,
+				Time:       now,
+				Count:      pm.GetSummary().GetSampleCount(),
+				Sum:        pm.GetSummary().GetSampleSum(),
+			}
+			for _, q := range pm.GetSummary().GetQuantile() {
+				dataPoint.QuantileValues = append(dataPoint.QuantileValues, metricdata.QuantileValue{
+					Quantile: q.GetQuantile(),
+					Value:    q.GetValue(),
+				})
+			}
+			dataPoints = append(dataPoints, dataPoint)
+		}
+		m.Data = metricdata.Summary{DataPoints: dataPoints}
+	default:
+		return m, false
+	}
+
+	return m, true
+}
+
+func toDataPoints(
+	mf *dto.MetricFamily, startTime, now time.Time, value func(*dto.Metric) float64,
+) []metricdata.DataPoint[float64] {
+	dataPoints := make([]metricdata.DataPoint[float64], 0, len(mf.GetMetric()))
+	for _, pm := range mf.GetMetric() {
+		dataPoints = append(dataPoints, metricdata.DataPoint[float64]{
+			Attributes: toAttributes(pm),
+			StartTime:  startTime,
+			Time:       now,
+			Value:      value(pm),
+		})
+	}
+	return dataPoints
+}
+
+func toAttributes(pm *dto.Metric) attribute.Set {
+	kvs := make([]attribute.KeyValue, 0, len(pm.GetLabel()))
+	for _, label := range pm.GetLabel() {
+		kvs = append(kvs, attribute.String(label.GetName(), label.GetValue()))
+	}
+	return attribute.NewSet(kvs...)
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package otlp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/pushqueue"
+)
+
+const testMetrics = `# HELP DCGM_FI_DEV_GPU_TEMP GPU temperature (in C).
+# TYPE DCGM_FI_DEV_GPU_TEMP gauge
+DCGM_FI_DEV_GPU_TEMP{gpu="0",UUID="GPU-0",pod="train-0",namespace="ml",container="main"} 42
+# HELP DCGM_FI_DEV_XID_ERRORS_TOTAL Number of XID errors.
+# TYPE DCGM_FI_DEV_XID_ERRORS_TOTAL counter
+DCGM_FI_DEV_XID_ERRORS_TOTAL{gpu="0",UUID="GPU-0"} 3
+# HELP dcgm_exporter_dra_mapping_duration_seconds Time spent mapping DRA devices to pods.
+# TYPE dcgm_exporter_dra_mapping_duration_seconds summary
+dcgm_exporter_dra_mapping_duration_seconds_sum 0.5
+dcgm_exporter_dra_mapping_duration_seconds_count 2
+`
+
+type fakeMetricExporter struct {
+	exported []*metricdata.ResourceMetrics
+	shutdown bool
+}
+
+func (f *fakeMetricExporter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	f.exported = append(f.exported, rm)
+	return nil
+}
+
+func (f *fakeMetricExporter) Shutdown(context.Context) error {
+	f.shutdown = true
+	return nil
+}
+
+func newTestExporter(source MetricsSource) (*Exporter, *fakeMetricExporter) {
+	fake := &fakeMetricExporter{}
+	return &Exporter{
+		exporter:  fake,
+		source:    source,
+		interval:  time.Second,
+		resource:  resource.Empty(),
+		startTime: time.Unix(100, 0),
+		queue:     pushqueue.New[metricdata.Metrics](sinkName, "test", pushqueue.Config{}),
+	}, fake
+}
+
+func TestExporter_Export(t *testing.T) {
+	exporter, fake := newTestExporter(func(w io.Writer) error {
+		_, err := io.WriteString(w, testMetrics)
+		return err
+	})
+
+	require.NoError(t, exporter.Export(context.Background()))
+	require.Len(t, fake.exported, 1)
+	require.Len(t, fake.exported[0].ScopeMetrics, 1)
+
+	metrics := fake.exported[0].ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 3)
+
+	assert.Equal(t, "DCGM_FI_DEV_GPU_TEMP", metrics[0].Name)
+	gauge, ok := metrics[0].Data.(metricdata.Gauge[float64])
+	require.True(t, ok)
+	require.Len(t, gauge.DataPoints, 1)
+	assert.Equal(t, 42.0, gauge.DataPoints[0].Value)
+	pod, ok := gauge.DataPoints[0].Attributes.Value(attribute.Key("pod"))
+	require.True(t, ok)
+	assert.Equal(t, "train-0", pod.AsString())
+
+	assert.Equal(t, "DCGM_FI_DEV_XID_ERRORS_TOTAL", metrics[1].Name)
+	sum, ok := metrics[1].Data.(metricdata.Sum[float64])
+	require.True(t, ok)
+	assert.True(t, sum.IsMonotonic)
+	assert.Equal(t, metricdata.CumulativeTemporality, sum.Temporality)
+	assert.Equal(t, time.Unix(100, 0), sum.DataPoints[0].StartTime)
+	assert.Equal(t, 3.0, sum.DataPoints[0].Value)
+
+	summary, ok := metrics[2].Data.(metricdata.Summary)
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), summary.DataPoints[0].Count)
+	assert.Equal(t, 0.5, summary.DataPoints[0].Sum)
+}
+
+func TestExporter_ExportReturnsSourceError(t *testing.T) {
+	exporter, fake := newTestExporter(func(io.Writer) error {
+		return errors.New("boom")
+	})
+
+	assert.EqualError(t, exporter.Export(context.Background()), "boom")
+	assert.Empty(t, fake.exported)
+}
+
+func TestExporter_RunShutsDownOnCancel(t *testing.T) {
+	exporter, fake := newTestExporter(func(io.Writer) error { return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	exporter.Run(ctx)
+
+	assert.True(t, fake.shutdown)
+}
+
+func TestExporter_RunPushesQueuedMetrics(t *testing.T) {
+	exporter, fake := newTestExporter(func(w io.Writer) error {
+		_, err := io.WriteString(w, testMetrics)
+		return err
+	})
+	exporter.interval = 10 * time.Millisecond
+	exporter.queue = pushqueue.New[metricdata.Metrics](sinkName, "test", pushqueue.Config{
+		BatchSize:     2,
+		FlushInterval: 10 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	exporter.Run(ctx)
+
+	require.NotEmpty(t, fake.exported)
+	for _, rm := range fake.exported {
+		assert.LessOrEqual(t, len(rm.ScopeMetrics[0].Metrics), 2)
+	}
+	assert.True(t, fake.shutdown)
+}
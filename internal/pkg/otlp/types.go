@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package otlp
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/pushqueue"
+)
+
+// MetricsSource writes the exported metrics in the Prometheus text format
+type MetricsSource func(w io.Writer) error
+
+// metricExporter is the part of the OTLP metric exporters used by the Exporter
+type metricExporter interface {
+	Export(ctx context.Context, rm *metricdata.ResourceMetrics) error
+	Shutdown(ctx context.Context) error
+}
+
+// Exporter periodically pushes the metrics of a MetricsSource to an OTLP collector
+type Exporter struct {
+	exporter  metricExporter
+	source    MetricsSource
+	interval  time.Duration
+	resource  *resource.Resource
+	startTime time.Time
+	queue     *pushqueue.Queue[metricdata.Metrics] // One item per metric family
+}
@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+const (
+	magic          = "PAR1"
+	fileExtension  = ".parquet"
+	tmpExtension   = ".tmp" // Suffix of the file being written, renamed once its footer is written
+	filePrefix     = "dcgm-exporter-"
+	fileTimeLayout = "20060102T150405Z"
+	createdBy      = "dcgm-exporter"
+	schemaName     = "dcgm_exporter"
+	gpuLabel       = "gpu"
+	quantileLabel  = "quantile"
+	formatVersion  = 1
+)
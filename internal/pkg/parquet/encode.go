@@ -0,0 +1,248 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Parquet enums, as defined by the parquet-format Thrift definitions
+const (
+	typeInt64     int32 = 2
+	typeDouble    int32 = 5
+	typeByteArray int32 = 6
+
+	convertedUTF8            int32 = 0
+	convertedTimestampMillis int32 = 9
+	noConvertedType          int32 = -1
+
+	repetitionRequired int32 = 0
+	encodingPlain      int32 = 0
+	encodingRLE        int32 = 3
+	codecSnappy        int32 = 1
+	pageTypeDataPage   int32 = 0
+)
+
+// Types of the Thrift compact protocol
+const (
+	compactStop   byte = 0
+	compactI32    byte = 5
+	compactI64    byte = 6
+	compactBinary byte = 8
+	compactList   byte = 9
+	compactStruct byte = 12
+)
+
+// columns are the columns of the Parquet files, all of them required
+var columns = []column{
+	{
+		name:          "timestamp",
+		physicalType:  typeInt64,
+		convertedType: convertedTimestampMillis,
+		appendValue: func(b []byte, r row) []byte {
+			return binary.LittleEndian.AppendUint64(b, uint64(r.timestamp))
+		},
+	},
+	{
+		name:          "field",
+		physicalType:  typeByteArray,
+		convertedType: convertedUTF8,
+		appendValue: func(b []byte, r row) []byte {
+			return appendByteArray(b, r.field)
+		},
+	},
+	{
+		name:          "gpu",
+		physicalType:  typeByteArray,
+		convertedType: convertedUTF8,
+		appendValue: func(b []byte, r row) []byte {
+			return appendByteArray(b, r.gpu)
+		},
+	},
+	{
+		name:          "labels",
+		physicalType:  typeByteArray,
+		convertedType: convertedUTF8,
+		appendValue: func(b []byte, r row) []byte {
+			return appendByteArray(b, r.labels)
+		},
+	},
+	{
+		name:          "value",
+		physicalType:  typeDouble,
+		convertedType: noConvertedType,
+		appendValue: func(b []byte, r row) []byte {
+			return binary.LittleEndian.AppendUint64(b, math.Float64bits(r.value))
+		},
+	},
+}
+
+// appendByteArray appends a PLAIN encoded BYTE_ARRAY value
+func appendByteArray(b []byte, s string) []byte {
+	b = binary.LittleEndian.AppendUint32(b, uint32(len(s)))
+	return append(b, s...)
+}
+
+// compactWriter encodes Thrift structs with the compact protocol, the protocol of the Parquet metadata
+type compactWriter struct {
+	b      []byte
+	lastID int16
+	stack  []int16 // Last field IDs of the enclosing structs
+}
+
+func (w *compactWriter) beginStruct() {
+	w.stack = append(w.stack, w.lastID)
+	w.lastID = 0
+}
+
+func (w *compactWriter) endStruct() {
+	w.b = append(w.b, compactStop)
+	w.lastID = w.stack[len(w.stack)-1]
+	w.stack = w.stack[:len(w.stack)-1]
+}
+
+func (w *compactWriter) fieldHeader(id int16, fieldType byte) {
+	if delta := id - w.lastID; delta > 0 && delta <= 15 {
+		w.b = append(w.b, byte(delta)<<4|fieldType)
+	} else {
+		w.b = append(w.b, fieldType)
+		w.b = binary.AppendVarint(w.b, int64(id))
+	}
+	w.lastID = id
+}
+
+func (w *compactWriter) i32Field(id int16, v int32) {
+	w.fieldHeader(id, compactI32)
+	w.i32(v)
+}
+
+func (w *compactWriter) i64Field(id int16, v int64) {
+	w.fieldHeader(id, compactI64)
+	w.b = binary.AppendVarint(w.b, v)
+}
+
+func (w *compactWriter) stringField(id int16, v string) {
+	w.fieldHeader(id, compactBinary)
+	w.string(v)
+}
+
+// structField begins a struct field, ended by endStruct
+func (w *compactWriter) structField(id int16) {
+	w.fieldHeader(id, compactStruct)
+	w.beginStruct()
+}
+
+// listField begins a list field, followed by its size elements
+func (w *compactWriter) listField(id int16, elemType byte, size int) {
+	w.fieldHeader(id, compactList)
+	if size < 15 {
+		w.b = append(w.b, byte(size)<<4|elemType)
+	} else {
+		w.b = append(w.b, 0xf0|elemType)
+		w.b = binary.AppendUvarint(w.b, uint64(size))
+	}
+}
+
+func (w *compactWriter) i32(v int32) {
+	w.b = binary.AppendVarint(w.b, int64(v))
+}
+
+func (w *compactWriter) string(v string) {
+	w.b = binary.AppendUvarint(w.b, uint64(len(v)))
+	w.b = append(w.b, v...)
+}
+
+// encodePageHeader encodes the PageHeader of a PLAIN encoded data page
+func encodePageHeader(numValues, uncompressedSize, compressedSize int) []byte {
+	var w compactWriter
+	w.beginStruct()
+	w.i32Field(1, pageTypeDataPage)
+	w.i32Field(2, int32(uncompressedSize))
+	w.i32Field(3, int32(compressedSize))
+	w.structField(5)
+	w.i32Field(1, int32(numValues))
+	w.i32Field(2, encodingPlain)
+	w.i32Field(3, encodingRLE)
+	w.i32Field(4, encodingRLE)
+	w.endStruct()
+	w.endStruct()
+	return w.b
+}
+
+// encodeFileMetaData encodes the FileMetaData of the file footer
+func encodeFileMetaData(rowGroups []rowGroup) []byte {
+	var numRows int64
+	for _, rg := range rowGroups {
+		numRows += rg.numRows
+	}
+
+	var w compactWriter
+	w.beginStruct()
+	w.i32Field(1, formatVersion)
+
+	w.listField(2, compactStruct, len(columns)+1)
+	w.beginStruct()
+	w.stringField(4, schemaName)
+	w.i32Field(5, int32(len(columns)))
+	w.endStruct()
+	for _, c := range columns {
+		w.beginStruct()
+		w.i32Field(1, c.physicalType)
+		w.i32Field(3, repetitionRequired)
+		w.stringField(4, c.name)
+		if c.convertedType != noConvertedType {
+			w.i32Field(6, c.convertedType)
+		}
+		w.endStruct()
+	}
+
+	w.i64Field(3, numRows)
+
+	w.listField(4, compactStruct, len(rowGroups))
+	for _, rg := range rowGroups {
+		var totalSize int64
+		w.beginStruct()
+		w.listField(1, compactStruct, len(rg.columns))
+		for i, chunk := range rg.columns {
+			totalSize += chunk.uncompressedSize
+
+			w.beginStruct()
+			w.i64Field(2, chunk.offset)
+			w.structField(3)
+			w.i32Field(1, columns[i].physicalType)
+			w.listField(2, compactI32, 1)
+			w.i32(encodingPlain)
+			w.listField(3, compactBinary, 1)
+			w.string(columns[i].name)
+			w.i32Field(4, codecSnappy)
+			w.i64Field(5, rg.numRows)
+			w.i64Field(6, chunk.uncompressedSize)
+			w.i64Field(7, chunk.compressedSize)
+			w.i64Field(9, chunk.offset)
+			w.endStruct()
+			w.endStruct()
+		}
+		w.i64Field(2, totalSize)
+		w.i64Field(3, rg.numRows)
+		w.endStruct()
+	}
+
+	w.stringField(6, createdBy)
+	w.endStruct()
+	return w.b
+}
@@ -0,0 +1,228 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+// NewSink creates a sink appending the metrics of source to Parquet files in the directory of the
+// configuration. The directory is created if it doesn't exist.
+func NewSink(config *appconfig.Config, source MetricsSource) (*Sink, error) {
+	if config.ParquetInterval <= 0 {
+		return nil, fmt.Errorf("invalid Parquet interval: %s", config.ParquetInterval)
+	}
+
+	if config.ParquetRotationInterval <= 0 {
+		return nil, fmt.Errorf("invalid Parquet rotation interval: %s", config.ParquetRotationInterval)
+	}
+
+	if err := os.MkdirAll(config.ParquetDirectory, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create the Parquet directory: %w", err)
+	}
+
+	return &Sink{
+		source:           source,
+		directory:        config.ParquetDirectory,
+		interval:         config.ParquetInterval,
+		rotationInterval: config.ParquetRotationInterval,
+		retention:        config.ParquetRetention,
+		now:              time.Now,
+	}, nil
+}
+
+// Run appends the metrics every interval until the context is canceled, then completes the current file.
+func (s *Sink) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if err := s.Close(); err != nil {
+				slog.Warn("Failed to complete the Parquet file", slog.String(logging.ErrorKey, err.Error()))
+			}
+			return
+		case <-ticker.C:
+			if err := s.Append(); err != nil {
+				slog.Warn("Failed to append metrics to the Parquet file", slog.String(logging.ErrorKey, err.Error()))
+			}
+		}
+	}
+}
+
+// Append appends the current metrics of the source to the current file as a row group, rotating the file
+// when it is older than the rotation interval.
+func (s *Sink) Append() error {
+	var buf bytes.Buffer
+	if err := s.source(&buf); err != nil {
+		return err
+	}
+
+	now := s.now()
+	rows, err := toRows(&buf, now)
+	if err != nil {
+		return err
+	}
+
+	if s.file != nil && now.Sub(s.opened) >= s.rotationInterval {
+		if err := s.Close(); err != nil {
+			return err
+		}
+	}
+
+	if s.file == nil {
+		path := filepath.Join(s.directory, filePrefix+now.UTC().Format(fileTimeLayout)+fileExtension)
+		if s.file, err = createFile(path); err != nil {
+			return err
+		}
+		s.opened = now
+	}
+
+	if err := s.file.writeRowGroup(rows); err != nil {
+		s.file.abort()
+		s.file = nil
+		return err
+	}
+	return nil
+}
+
+// Close completes the current file and removes the files beyond the retention
+func (s *Sink) Close() error {
+	if s.file == nil {
+		return nil
+	}
+
+	err := s.file.close()
+	s.file = nil
+	if err != nil {
+		return err
+	}
+
+	return s.removeExpiredFiles()
+}
+
+// removeExpiredFiles removes the oldest completed files, keeping the retention newest ones
+func (s *Sink) removeExpiredFiles() error {
+	if s.retention <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(s.directory)
+	if err != nil {
+		return err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.Type().IsRegular() && strings.HasPrefix(name, filePrefix) && strings.HasSuffix(name, fileExtension) {
+			files = append(files, name)
+		}
+	}
+	if len(files) <= s.retention {
+		return nil
+	}
+
+	// The names embed the creation time, so they sort from the oldest to the newest
+	slices.Sort(files)
+	for _, name := range files[:len(files)-s.retention] {
+		if err := os.Remove(filepath.Join(s.directory, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toRows converts metrics in the Prometheus text format into rows. Summaries are split into their quantile,
+// _sum and _count rows. Histograms aren't produced by the exporter and are skipped.
+func toRows(r io.Reader, now time.Time) ([]row, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+
+	timestamp := now.UnixMilli()
+
+	var rows []row
+	for _, name := range slices.Sorted(maps.Keys(families)) {
+		mf := families[name]
+		for _, pm := range mf.GetMetric() {
+			ts := timestamp
+			if pm.TimestampMs != nil {
+				ts = pm.GetTimestampMs()
+			}
+
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				rows = append(rows, newRow(name, pm, nil, pm.GetCounter().GetValue(), ts))
+			case dto.MetricType_GAUGE:
+				rows = append(rows, newRow(name, pm, nil, pm.GetGauge().GetValue(), ts))
+			case dto.MetricType_UNTYPED:
+				rows = append(rows, newRow(name, pm, nil, pm.GetUntyped().GetValue(), ts))
+			case dto.MetricType_SUMMARY:
+				for _, q := range pm.GetSummary().GetQuantile() {
+					quantile := map[string]string{quantileLabel: fmt.Sprint(q.GetQuantile())}
+					rows = append(rows, newRow(name, pm, quantile, q.GetValue(), ts))
+				}
+				rows = append(rows,
+					newRow(name+"_sum", pm, nil, pm.GetSummary().GetSampleSum(), ts),
+					newRow(name+"_count", pm, nil, float64(pm.GetSummary().GetSampleCount()), ts),
+				)
+			}
+		}
+	}
+
+	return rows, nil
+}
+
+func newRow(name string, pm *dto.Metric, extraLabels map[string]string, value float64, timestamp int64) row {
+	r := row{timestamp: timestamp, field: name, value: value}
+
+	labels := make(map[string]string, len(pm.GetLabel())+len(extraLabels))
+	for _, l := range pm.GetLabel() {
+		if l.GetName() == gpuLabel {
+			r.gpu = l.GetValue()
+			continue
+		}
+		labels[l.GetName()] = l.GetValue()
+	}
+	maps.Copy(labels, extraLabels)
+
+	// A map of strings always marshals, with its keys sorted
+	b, _ := json.Marshal(labels)
+	r.labels = string(b)
+	return r
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetrics = `# HELP DCGM_FI_DEV_GPU_TEMP GPU temperature (in C).
+# TYPE DCGM_FI_DEV_GPU_TEMP gauge
+DCGM_FI_DEV_GPU_TEMP{gpu="0",UUID="GPU-0",pod="train-0",namespace="team-a"} 42
+DCGM_FI_DEV_GPU_TEMP{gpu="1",UUID="GPU-1"} 43
+# HELP dcgm_exporter_dra_mapping_duration_seconds Time spent mapping DRA devices to pods.
+# TYPE dcgm_exporter_dra_mapping_duration_seconds summary
+dcgm_exporter_dra_mapping_duration_seconds_sum 0.5
+dcgm_exporter_dra_mapping_duration_seconds_count 2
+`
+
+func testSource(w io.Writer) error {
+	_, err := io.WriteString(w, testMetrics)
+	return err
+}
+
+func newTestSink(t *testing.T, now *time.Time) *Sink {
+	t.Helper()
+	return &Sink{
+		source:           testSource,
+		directory:        t.TempDir(),
+		interval:         time.Second,
+		rotationInterval: time.Hour,
+		retention:        2,
+		now:              func() time.Time { return *now },
+	}
+}
+
+// completedFiles returns the names of the completed files of the directory
+func completedFiles(t *testing.T, directory string) []string {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(directory, filePrefix+"*"+fileExtension))
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(matches))
+	for _, m := range matches {
+		names = append(names, filepath.Base(m))
+	}
+	return names
+}
+
+func TestToRows(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	rows, err := toRows(strings.NewReader(testMetrics), now)
+	require.NoError(t, err)
+
+	assert.Equal(t, []row{
+		{
+			timestamp: 1700000000000, field: "DCGM_FI_DEV_GPU_TEMP", gpu: "0",
+			labels: `{"UUID":"GPU-0","namespace":"team-a","pod":"train-0"}`, value: 42,
+		},
+		{timestamp: 1700000000000, field: "DCGM_FI_DEV_GPU_TEMP", gpu: "1", labels: `{"UUID":"GPU-1"}`, value: 43},
+		{timestamp: 1700000000000, field: "dcgm_exporter_dra_mapping_duration_seconds_sum", labels: `{}`, value: 0.5},
+		{timestamp: 1700000000000, field: "dcgm_exporter_dra_mapping_duration_seconds_count", labels: `{}`, value: 2},
+	}, rows)
+}
+
+func TestEncodePageHeader(t *testing.T) {
+	// PageHeader{type: DATA_PAGE, uncompressed_page_size: 10, compressed_page_size: 8,
+	// data_page_header: {num_values: 3, encoding: PLAIN, definition/repetition_level_encoding: RLE}}
+	expected := []byte{
+		0x15, 0x00, 0x15, 0x14, 0x15, 0x10,
+		0x2c, 0x15, 0x06, 0x15, 0x00, 0x15, 0x06, 0x15, 0x06, 0x00,
+		0x00,
+	}
+	assert.Equal(t, expected, encodePageHeader(3, 10, 8))
+}
+
+func TestSink_AppendRotatesFiles(t *testing.T) {
+	start := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	now := start
+	sink := newTestSink(t, &now)
+
+	require.NoError(t, sink.Append())
+	now = now.Add(30 * time.Second)
+	require.NoError(t, sink.Append())
+	assert.Empty(t, completedFiles(t, sink.directory), "expected the current file to be completed on rotation only")
+
+	now = now.Add(time.Hour)
+	require.NoError(t, sink.Append())
+	require.Equal(t, []string{"dcgm-exporter-20261016T080000Z.parquet"}, completedFiles(t, sink.directory))
+
+	b, err := os.ReadFile(filepath.Join(sink.directory, "dcgm-exporter-20261016T080000Z.parquet"))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(b, []byte(magic)))
+	require.True(t, bytes.HasSuffix(b, []byte(magic)))
+
+	metadataLength := int(binary.LittleEndian.Uint32(b[len(b)-8:]))
+	require.Less(t, metadataLength, len(b)-12)
+	metadata := b[len(b)-8-metadataLength : len(b)-8]
+	for _, c := range columns {
+		assert.Contains(t, string(metadata), c.name)
+	}
+	assert.Contains(t, string(metadata), createdBy)
+
+	// The first page holds the timestamps of the first cycle
+	var values []byte
+	for range 4 {
+		values = binary.LittleEndian.AppendUint64(values, uint64(start.UnixMilli()))
+	}
+	compressed := snappy.Encode(nil, values)
+	page := append(encodePageHeader(4, len(values), len(compressed)), compressed...)
+	assert.Equal(t, page, b[len(magic):len(magic)+len(page)])
+
+	require.NoError(t, sink.Close())
+	assert.Len(t, completedFiles(t, sink.directory), 2)
+}
+
+func TestSink_RemovesExpiredFiles(t *testing.T) {
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	sink := newTestSink(t, &now)
+
+	for range 4 {
+		require.NoError(t, sink.Append())
+		now = now.Add(time.Hour)
+	}
+	require.NoError(t, sink.Close())
+
+	assert.Equal(t, []string{
+		"dcgm-exporter-20261016T100000Z.parquet",
+		"dcgm-exporter-20261016T110000Z.parquet",
+	}, completedFiles(t, sink.directory))
+}
+
+func TestSink_EmptyFileIsRemoved(t *testing.T) {
+	now := time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC)
+	sink := newTestSink(t, &now)
+	sink.source = func(io.Writer) error { return nil }
+
+	require.NoError(t, sink.Append())
+	require.NoError(t, sink.Close())
+
+	entries, err := os.ReadDir(sink.directory)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
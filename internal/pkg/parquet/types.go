@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"io"
+	"time"
+)
+
+// MetricsSource writes the exported metrics in the Prometheus text format
+type MetricsSource func(w io.Writer) error
+
+// Sink periodically appends the metrics of a MetricsSource to Parquet files, one row group per collection
+// cycle. A file is completed, and readable, when it is rotated.
+type Sink struct {
+	source           MetricsSource
+	directory        string
+	interval         time.Duration
+	rotationInterval time.Duration
+	retention        int // Number of completed files kept, 0 keeps all of them
+	now              func() time.Time
+
+	file   *fileWriter // nil until the first cycle and after a write error
+	opened time.Time
+}
+
+// row is a row of the Parquet files, a sample of a metric
+type row struct {
+	timestamp int64 // Milliseconds since the epoch
+	field     string
+	gpu       string // Empty for the metrics without a gpu label
+	labels    string // JSON object of the labels other than gpu
+	value     float64
+}
+
+// column is a column of the Parquet files
+type column struct {
+	name          string
+	physicalType  int32
+	convertedType int32 // noConvertedType for the columns without one
+	appendValue   func(b []byte, r row) []byte
+}
+
+// columnChunk locates a column chunk of a row group in the file
+type columnChunk struct {
+	offset           int64
+	uncompressedSize int64
+	compressedSize   int64
+}
+
+// rowGroup is a row group written to the file, kept for the footer
+type rowGroup struct {
+	numRows int64
+	columns []columnChunk
+}
@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parquet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/klauspost/compress/snappy"
+)
+
+// fileWriter writes a Parquet file, one row group at a time. The file is written under a temporary name and
+// renamed when it is closed, so that readers never see a file without its footer.
+type fileWriter struct {
+	file      *os.File
+	path      string
+	offset    int64
+	rowGroups []rowGroup
+}
+
+// createFile creates the Parquet file of path and writes its header
+func createFile(path string) (*fileWriter, error) {
+	file, err := os.Create(path + tmpExtension)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &fileWriter{file: file, path: path}
+	if err := w.write([]byte(magic)); err != nil {
+		w.abort()
+		return nil, err
+	}
+	return w, nil
+}
+
+// writeRowGroup writes the rows as a row group, with a single snappy compressed page per column
+func (w *fileWriter) writeRowGroup(rows []row) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	rg := rowGroup{numRows: int64(len(rows)), columns: make([]columnChunk, 0, len(columns))}
+	for _, c := range columns {
+		var values []byte
+		for _, r := range rows {
+			values = c.appendValue(values, r)
+		}
+		compressed := snappy.Encode(nil, values)
+		header := encodePageHeader(len(rows), len(values), len(compressed))
+
+		chunk := columnChunk{
+			offset:           w.offset,
+			uncompressedSize: int64(len(header) + len(values)),
+			compressedSize:   int64(len(header) + len(compressed)),
+		}
+		if err := w.write(header); err != nil {
+			return err
+		}
+		if err := w.write(compressed); err != nil {
+			return err
+		}
+		rg.columns = append(rg.columns, chunk)
+	}
+
+	w.rowGroups = append(w.rowGroups, rg)
+	return nil
+}
+
+// close writes the footer and renames the file to its final name. A file without rows is removed.
+func (w *fileWriter) close() error {
+	if len(w.rowGroups) == 0 {
+		w.abort()
+		return nil
+	}
+
+	metadata := encodeFileMetaData(w.rowGroups)
+	footer := binary.LittleEndian.AppendUint32(metadata, uint32(len(metadata)))
+	footer = append(footer, magic...)
+
+	if err := w.write(footer); err != nil {
+		w.abort()
+		return err
+	}
+	if err := errors.Join(w.file.Sync(), w.file.Close()); err != nil {
+		_ = os.Remove(w.file.Name())
+		return err
+	}
+	if err := os.Rename(w.file.Name(), w.path); err != nil {
+		return fmt.Errorf("failed to rename %s: %w", w.file.Name(), err)
+	}
+	return nil
+}
+
+// abort closes and removes the file
+func (w *fileWriter) abort() {
+	_ = w.file.Close()
+	_ = os.Remove(w.file.Name())
+}
+
+func (w *fileWriter) write(b []byte) error {
+	n, err := w.file.Write(b)
+	w.offset += int64(n)
+	return err
+}
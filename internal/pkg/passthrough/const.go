@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package passthrough
+
+const (
+	// pciDevicesPath lists the PCI devices of the host, each a directory named after its bus ID
+	pciDevicesPath = "/sys/bus/pci/devices"
+
+	nvidiaVendorID = "0x10de"
+
+	// displayClassPrefix is the prefix of the class of the display controllers, the GPUs among NVIDIA devices
+	displayClassPrefix = "0x03"
+
+	// vfioDriver is the driver of the devices passed through to virtual machines
+	vfioDriver = "vfio-pci"
+)
@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package passthrough
+
+import (
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/identity"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+// detected are the normalized PCI bus IDs of the GPUs bound to vfio-pci found by the last Refresh
+var detected = struct {
+	sync.RWMutex
+	busIDs []string
+}{}
+
+// Refresh detects the GPUs bound to vfio-pci for VM passthrough. They are invisible to DCGM, which
+// doesn't manage them; without sysfs, e.g. outside Linux, none are detected.
+func Refresh() {
+	busIDs, err := detect(pciDevicesPath)
+	if err != nil {
+		slog.Debug("Failed to detect the passthrough GPUs", slog.String(logging.ErrorKey, err.Error()))
+	}
+
+	detected.Lock()
+	defer detected.Unlock()
+	if !slices.Equal(busIDs, detected.busIDs) && len(busIDs) > 0 {
+		slog.Info("Detected GPUs bound to vfio-pci for VM passthrough, they are not monitored",
+			slog.Any("pci_bus_ids", busIDs))
+	}
+	detected.busIDs = busIDs
+}
+
+// BusIDs returns the PCI bus IDs of the passthrough GPUs, in the sysfs format, e.g. 0000:3b:00.0
+func BusIDs() []string {
+	detected.RLock()
+	defer detected.RUnlock()
+	return slices.Clone(detected.busIDs)
+}
+
+// IsPassthrough returns whether the GPU of a PCI bus ID, in the sysfs or the DCGM format, is passed through
+func IsPassthrough(busID string) bool {
+	if busID == "" {
+		return false
+	}
+
+	detected.RLock()
+	defer detected.RUnlock()
+	return slices.Contains(detected.busIDs, identity.NormalizePCIBusID(busID))
+}
+
+// detect returns the sorted bus IDs of the NVIDIA display controllers of the devices directory bound to vfio-pci
+func detect(devicesPath string) ([]string, error) {
+	entries, err := os.ReadDir(devicesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var busIDs []string
+	for _, entry := range entries {
+		devicePath := filepath.Join(devicesPath, entry.Name())
+		if readAttribute(devicePath, "vendor") != nvidiaVendorID ||
+			!strings.HasPrefix(readAttribute(devicePath, "class"), displayClassPrefix) {
+			continue
+		}
+
+		driver, err := os.Readlink(filepath.Join(devicePath, "driver"))
+		if err != nil {
+			// Devices without driver have no link
+			if !errors.Is(err, fs.ErrNotExist) {
+				slog.Debug("Failed to read the driver of a PCI device",
+					slog.String("pci_bus_id", entry.Name()),
+					slog.String(logging.ErrorKey, err.Error()))
+			}
+			continue
+		}
+		if filepath.Base(driver) == vfioDriver {
+			busIDs = append(busIDs, identity.NormalizePCIBusID(entry.Name()))
+		}
+	}
+
+	slices.Sort(busIDs)
+	return busIDs, nil
+}
+
+func readAttribute(devicePath, name string) string {
+	value, err := os.ReadFile(filepath.Join(devicePath, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(value))
+}
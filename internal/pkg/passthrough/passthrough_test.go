@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package passthrough
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeDevice adds a PCI device to a fake sysfs devices directory, bound to driver unless empty
+func writeDevice(t *testing.T, devicesPath, busID, vendor, class, driver string) {
+	t.Helper()
+
+	devicePath := filepath.Join(devicesPath, busID)
+	require.NoError(t, os.MkdirAll(devicePath, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(devicePath, "vendor"), []byte(vendor+"\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(devicePath, "class"), []byte(class+"\n"), 0o644))
+	if driver != "" {
+		require.NoError(t, os.Symlink(filepath.Join("..", "..", "..", "bus", "pci", "drivers", driver),
+			filepath.Join(devicePath, "driver")))
+	}
+}
+
+func TestDetect(t *testing.T) {
+	devicesPath := t.TempDir()
+	writeDevice(t, devicesPath, "0000:86:00.0", nvidiaVendorID, "0x030200", vfioDriver)
+	writeDevice(t, devicesPath, "0000:3b:00.0", nvidiaVendorID, "0x030000", vfioDriver)
+	writeDevice(t, devicesPath, "0000:af:00.0", nvidiaVendorID, "0x030200", "nvidia")
+	writeDevice(t, devicesPath, "0000:d8:00.0", nvidiaVendorID, "0x030200", "")
+	// The audio function of a GPU and a NIC passed through are not GPUs
+	writeDevice(t, devicesPath, "0000:3b:00.1", nvidiaVendorID, "0x040300", vfioDriver)
+	writeDevice(t, devicesPath, "0000:5e:00.0", "0x15b3", "0x020000", vfioDriver)
+
+	busIDs, err := detect(devicesPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0000:3b:00.0", "0000:86:00.0"}, busIDs)
+
+	_, err = detect(filepath.Join(devicesPath, "missing"))
+	assert.Error(t, err)
+}
+
+func TestIsPassthrough(t *testing.T) {
+	detected.busIDs = []string{"0000:3b:00.0"}
+	t.Cleanup(func() { detected.busIDs = nil })
+
+	assert.True(t, IsPassthrough("0000:3b:00.0"))
+	assert.True(t, IsPassthrough("00000000:3B:00.0"), "The DCGM format should match")
+	assert.False(t, IsPassthrough("00000000:86:00.0"))
+	assert.False(t, IsPassthrough(""))
+	assert.Equal(t, []string{"0000:3b:00.0"}, BusIDs())
+}
@@ -0,0 +1,28 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pushqueue
+
+import "time"
+
+const (
+	// DefaultCapacity is the number of items a queue holds before it drops the oldest ones
+	DefaultCapacity = 100000
+	// DefaultBatchSize is the maximal number of items of a push
+	DefaultBatchSize = 5000
+	// DefaultFlushInterval is the interval at which the items are pushed when the batch is not full
+	DefaultFlushInterval = time.Second
+)
@@ -0,0 +1,185 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pushqueue
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+var (
+	queuesMtx sync.Mutex
+	queues    = map[statser]struct{}{}
+)
+
+// New creates a queue of the sink, e.g. otlp, pushing to endpoint. The zero values of the configuration
+// are replaced with the defaults.
+func New[T any](sink, endpoint string, config Config) *Queue[T] {
+	if config.Capacity <= 0 {
+		config.Capacity = DefaultCapacity
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = DefaultFlushInterval
+	}
+
+	return &Queue[T]{
+		sink:     sink,
+		endpoint: endpoint,
+		config:   config,
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+// Push adds items to the queue, dropping the oldest items beyond the capacity.
+func (q *Queue[T]) Push(items ...T) {
+	q.mtx.Lock()
+	q.items = append(q.items, items...)
+	q.dropOldest()
+	full := len(q.items) >= q.config.BatchSize
+	q.mtx.Unlock()
+
+	if full {
+		select {
+		case q.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Run pushes the items with send until the context is canceled: a batch as soon as it is full, and the
+// items queued so far every flush interval. A batch that fails is put back at the head of the queue and
+// retried at the next flush interval. The queue is exported in the metrics while it runs.
+func (q *Queue[T]) Run(ctx context.Context, send SendFunc[T]) {
+	queuesMtx.Lock()
+	queues[q] = struct{}{}
+	queuesMtx.Unlock()
+
+	defer func() {
+		queuesMtx.Lock()
+		delete(queues, q)
+		queuesMtx.Unlock()
+	}()
+
+	ticker := time.NewTicker(q.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.notify:
+			q.flush(ctx, send, true)
+		case <-ticker.C:
+			q.flush(ctx, send, false)
+		}
+	}
+}
+
+// flush sends the queued items in batches, only the full ones if fullOnly is set. It stops at the first
+// batch that fails.
+func (q *Queue[T]) flush(ctx context.Context, send SendFunc[T], fullOnly bool) {
+	for ctx.Err() == nil {
+		batch := q.pop(fullOnly)
+		if len(batch) == 0 {
+			return
+		}
+
+		if err := send(ctx, batch); err != nil {
+			slog.Warn("Failed to push a batch, it is retried at the next flush",
+				slog.String("sink", q.sink),
+				slog.Int("batch_size", len(batch)),
+				slog.String(logging.ErrorKey, err.Error()))
+			q.requeue(batch)
+			return
+		}
+
+		q.sent.Add(uint64(len(batch)))
+	}
+}
+
+// pop removes and returns the next batch, or nothing if fullOnly is set and the batch is not full.
+func (q *Queue[T]) pop(fullOnly bool) []T {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if len(q.items) == 0 || (fullOnly && len(q.items) < q.config.BatchSize) {
+		return nil
+	}
+
+	n := min(len(q.items), q.config.BatchSize)
+	batch := slices.Clone(q.items[:n])
+	q.items = slices.Delete(q.items, 0, n)
+	return batch
+}
+
+// requeue puts a batch that failed back at the head of the queue. It is the oldest data, so it is the
+// first to be dropped when the queue is full.
+func (q *Queue[T]) requeue(batch []T) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	q.items = slices.Insert(q.items, 0, batch...)
+	q.dropOldest()
+}
+
+// dropOldest drops the items beyond the capacity from the head of the queue. The caller must hold the lock.
+func (q *Queue[T]) dropOldest() {
+	if excess := len(q.items) - q.config.Capacity; excess > 0 {
+		q.items = slices.Delete(q.items, 0, excess)
+		q.dropped.Add(uint64(excess))
+	}
+}
+
+// Stats returns the state of the queue
+func (q *Queue[T]) Stats() Stats {
+	q.mtx.Lock()
+	depth := len(q.items)
+	q.mtx.Unlock()
+
+	return Stats{
+		Sink:     q.sink,
+		Endpoint: q.endpoint,
+		Depth:    depth,
+		Capacity: q.config.Capacity,
+		Dropped:  q.dropped.Load(),
+		Sent:     q.sent.Load(),
+	}
+}
+
+// AllStats returns the state of the running queues, sorted by sink and endpoint
+func AllStats() []Stats {
+	queuesMtx.Lock()
+	stats := make([]Stats, 0, len(queues))
+	for q := range queues {
+		stats = append(stats, q.Stats())
+	}
+	queuesMtx.Unlock()
+
+	slices.SortFunc(stats, func(a, b Stats) int {
+		return cmp.Or(cmp.Compare(a.Sink, b.Sink), cmp.Compare(a.Endpoint, b.Endpoint))
+	})
+	return stats
+}
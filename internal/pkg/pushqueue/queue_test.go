@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pushqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_Defaults(t *testing.T) {
+	q := New[int]("otlp", "", Config{})
+	assert.Equal(t, Config{
+		Capacity:      DefaultCapacity,
+		BatchSize:     DefaultBatchSize,
+		FlushInterval: DefaultFlushInterval,
+	}, q.config)
+}
+
+func TestQueue_PushDropsOldest(t *testing.T) {
+	q := New[int]("otlp", "", Config{Capacity: 3, BatchSize: 10})
+	q.Push(1, 2)
+	q.Push(3, 4, 5)
+
+	assert.Equal(t, []int{3, 4, 5}, q.items)
+	stats := q.Stats()
+	assert.Equal(t, 3, stats.Depth)
+	assert.Equal(t, 3, stats.Capacity)
+	assert.Equal(t, uint64(2), stats.Dropped)
+}
+
+func TestQueue_PopBatches(t *testing.T) {
+	q := New[int]("otlp", "", Config{Capacity: 10, BatchSize: 2})
+	q.Push(1, 2, 3)
+
+	assert.Equal(t, []int{1, 2}, q.pop(true))
+	assert.Nil(t, q.pop(true), "The batch isn't full")
+	assert.Equal(t, []int{3}, q.pop(false))
+	assert.Nil(t, q.pop(false))
+}
+
+func TestQueue_FlushRequeuesFailedBatch(t *testing.T) {
+	q := New[int]("otlp", "", Config{Capacity: 3, BatchSize: 2})
+	q.Push(1, 2, 3)
+
+	q.flush(context.Background(), func(context.Context, []int) error {
+		return errors.New("unavailable")
+	}, false)
+
+	assert.Equal(t, []int{1, 2, 3}, q.items)
+	assert.Equal(t, uint64(0), q.Stats().Sent)
+
+	q.Push(4)
+	assert.Equal(t, []int{2, 3, 4}, q.items, "The oldest item is dropped")
+
+	var sent []int
+	q.flush(context.Background(), func(_ context.Context, batch []int) error {
+		sent = append(sent, batch...)
+		return nil
+	}, false)
+
+	assert.Equal(t, []int{2, 3, 4}, sent)
+	assert.Empty(t, q.items)
+	assert.Equal(t, uint64(3), q.Stats().Sent)
+	assert.Equal(t, uint64(1), q.Stats().Dropped)
+}
+
+func TestQueue_Run(t *testing.T) {
+	q := New[int]("remote_write", "http://a", Config{Capacity: 10, BatchSize: 2, FlushInterval: time.Hour})
+
+	var mtx sync.Mutex
+	var batches [][]int
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		q.Run(ctx, func(_ context.Context, batch []int) error {
+			mtx.Lock()
+			defer mtx.Unlock()
+			batches = append(batches, batch)
+			return nil
+		})
+	}()
+
+	require.Eventually(t, func() bool { return len(AllStats()) == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "remote_write", AllStats()[0].Sink)
+	assert.Equal(t, "http://a", AllStats()[0].Endpoint)
+
+	q.Push(1, 2, 3)
+	require.Eventually(t, func() bool {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return len(batches) == 1
+	}, time.Second, 10*time.Millisecond, "A full batch is pushed without waiting for the flush interval")
+
+	mtx.Lock()
+	assert.Equal(t, [][]int{{1, 2}}, batches)
+	mtx.Unlock()
+
+	cancel()
+	<-done
+	assert.Empty(t, AllStats(), "The queue is unregistered when it stops")
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package pushqueue
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Config configures the size and the pace of a queue
+type Config struct {
+	Capacity      int
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// Queue is a bounded queue between the collection of the metrics and a push backend. A slow or unavailable
+// backend fills the queue, the oldest items are dropped then, so the memory of the exporter stays bounded.
+type Queue[T any] struct {
+	sink     string
+	endpoint string
+	config   Config
+	mtx      sync.Mutex
+	items    []T
+	notify   chan struct{} // Signaled when a batch is full
+	dropped  atomic.Uint64
+	sent     atomic.Uint64
+}
+
+// Stats is the state of a queue, exported as the dcgm_exporter_push_queue_* metrics
+type Stats struct {
+	Sink     string
+	Endpoint string
+	Depth    int
+	Capacity int
+	Dropped  uint64
+	Sent     uint64
+}
+
+// SendFunc pushes a batch of items to the backend
+type SendFunc[T any] func(ctx context.Context, batch []T) error
+
+// statser is a queue of any item type
+type statser interface {
+	Stats() Stats
+}
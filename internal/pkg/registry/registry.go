@@ -17,8 +17,11 @@
 package registry
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"log/slog"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -28,17 +31,16 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
-	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
 )
 
 // ErrRegistryShuttingDown is returned when Gather() is called on a registry that is shutting down
 var ErrRegistryShuttingDown = errors.New("registry is shutting down")
 
-// groupCounterTuple represents a composite key, that consists Group and Counter.
-// The groupCounterTuple is necessary to maintain uniqueness of Group and Counter pairs.
-type groupCounterTuple struct {
-	Group   dcgm.Field_Entity_Group
-	Counter counters.Counter
+// groupGather is the metrics of an entity type during a gather, until its last collector is done
+type groupGather struct {
+	sync.Mutex
+	pending int // collectors of the entity type still running
+	metrics collector.MetricsByCounter
 }
 
 type Registry struct {
@@ -47,14 +49,23 @@ type Registry struct {
 	mtx                 sync.RWMutex
 	activeGathers       atomic.Int32 // Tracks in-flight Gather() calls for safe cleanup
 	shuttingDown        atomic.Bool  // Signals that cleanup is imminent
+	built               atomic.Int64 // unix nano time the collectors were last built or replaced
+	limits              CollectorLimits
+	statsMtx            sync.Mutex
+	stats               map[CollectorInfo]*CollectorStats // named collector -> statistics of its collections
+	breakers            map[collector.EntityCollectorTuple]*breakerState
 }
 
 // NewRegistry creates a new registry
 func NewRegistry() *Registry {
-	return &Registry{
+	r := &Registry{
 		collectorGroups:     map[dcgm.Field_Entity_Group][]collector.Collector{},
 		collectorGroupsSeen: map[collector.EntityCollectorTuple]struct{}{},
+		stats:               map[CollectorInfo]*CollectorStats{},
+		breakers:            map[collector.EntityCollectorTuple]*breakerState{},
 	}
+	r.built.Store(time.Now().UnixNano())
+	return r
 }
 
 // Register registers a collector with the registry.
@@ -67,11 +78,92 @@ func (r *Registry) Register(entityCollectorTuples collector.EntityCollectorTuple
 	r.collectorGroupsSeen[entityCollectorTuples] = struct{}{}
 }
 
+// ReplaceCollectors replaces the collectors of the given entity types with the collectors of those types
+// in entityCollectorTuples. Collectors of other entity types are kept. Gather calls in flight complete
+// before the swap. The replaced collectors are returned and must be cleaned up by the caller.
+func (r *Registry) ReplaceCollectors(
+	entityTypes []dcgm.Field_Entity_Group, entityCollectorTuples []collector.EntityCollectorTuple,
+) []collector.Collector {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	var replaced []collector.Collector
+	for _, entityType := range entityTypes {
+		replaced = append(replaced, r.collectorGroups[entityType]...)
+		delete(r.collectorGroups, entityType)
+	}
+
+	for entityCollectorTuple := range r.collectorGroupsSeen {
+		if slices.Contains(entityTypes, entityCollectorTuple.Entity()) {
+			delete(r.collectorGroupsSeen, entityCollectorTuple)
+		}
+	}
+
+	for _, entityCollectorTuple := range entityCollectorTuples {
+		if slices.Contains(entityTypes, entityCollectorTuple.Entity()) {
+			r.Register(entityCollectorTuple)
+		}
+	}
+	r.built.Store(time.Now().UnixNano())
+
+	return replaced
+}
+
+// Collectors returns the named collectors registered with the registry, sorted by entity type and name.
+func (r *Registry) Collectors() []CollectorInfo {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	collectors := make([]CollectorInfo, 0, len(r.collectorGroupsSeen))
+	for entityCollectorTuple := range r.collectorGroupsSeen {
+		if entityCollectorTuple.Name() == "" {
+			continue
+		}
+		collectors = append(collectors, CollectorInfo{
+			Entity: entityCollectorTuple.Entity(),
+			Name:   entityCollectorTuple.Name(),
+		})
+	}
+
+	slices.SortFunc(collectors, func(a, b CollectorInfo) int {
+		return cmp.Or(cmp.Compare(a.Entity, b.Entity), cmp.Compare(a.Name, b.Name))
+	})
+
+	return collectors
+}
+
 // Gather gathers metrics from all registered collectors.
 func (r *Registry) Gather() (MetricsByCounterGroup, error) {
+	return r.GatherContext(context.Background())
+}
+
+// GatherContext gathers metrics from all registered collectors. A collector still running when ctx is done,
+// or after the collector timeout, is skipped: its metrics are missing from this gather only, it keeps running
+// in the background and delays the cleanup of the registry like a Gather call. The collectors whose circuit
+// is open are skipped without being called.
+func (r *Registry) GatherContext(ctx context.Context) (MetricsByCounterGroup, error) {
+	output := MetricsByCounterGroup{}
+	err := r.GatherStream(ctx, func(group dcgm.Field_Entity_Group, metrics collector.MetricsByCounter) error {
+		output[group] = metrics
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// GatherStream gathers metrics from all registered collectors like GatherContext, but passes the metrics of
+// an entity type to emit as soon as all its collectors are done, instead of holding the metrics of every
+// entity type until the slowest collector is done. The calls to emit are serialized; the entity types
+// without metrics are not emitted. After a collector or emit failed, the remaining entity types are not
+// emitted and the first error is returned.
+func (r *Registry) GatherStream(
+	ctx context.Context, emit func(group dcgm.Field_Entity_Group, metrics collector.MetricsByCounter) error,
+) error {
 	// Check if registry is shutting down
 	if r.shuttingDown.Load() {
-		return nil, ErrRegistryShuttingDown
+		return ErrRegistryShuttingDown
 	}
 
 	// Track this gather operation for safe cleanup
@@ -85,54 +177,104 @@ func (r *Registry) Gather() (MetricsByCounterGroup, error) {
 
 	// Double-check shutdown flag after acquiring lock
 	if r.shuttingDown.Load() {
-		return nil, ErrRegistryShuttingDown
+		return ErrRegistryShuttingDown
 	}
 
-	var wg sync.WaitGroup
+	limits := r.limits
+	if limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, limits.Timeout)
+		defer cancel()
+	}
 
-	g := new(errgroup.Group)
+	groups := map[dcgm.Field_Entity_Group]*groupGather{}
+	for entityCollectorTuple := range r.collectorGroupsSeen {
+		group := entityCollectorTuple.Entity()
+		if groups[group] == nil {
+			groups[group] = &groupGather{metrics: collector.MetricsByCounter{}}
+		}
+		groups[group].pending++
+	}
 
-	var sm sync.Map
+	var failed atomic.Bool
+	var emitMtx sync.Mutex
+	// done adds the metrics of a collector to those of its entity type, and emits them after its last collector
+	done := func(group dcgm.Field_Entity_Group, metrics collector.MetricsByCounter) error {
+		gathered := groups[group]
+		gathered.Lock()
+		for counter, metricVals := range metrics {
+			gathered.metrics[counter] = append(gathered.metrics[counter], metricVals...)
+		}
+		gathered.pending--
+		last := gathered.pending == 0
+		groupMetrics := gathered.metrics
+		if last {
+			gathered.metrics = nil
+		}
+		gathered.Unlock()
 
-	for group, collectors := range r.collectorGroups {
-		for _, c := range collectors {
-			c := c // creates new c, see https://golang.org/doc/faq#closures_and_goroutines
-			group := group
-			wg.Add(1)
-			g.Go(func() error {
-				metrics, err := c.GetMetrics()
-				if err != nil {
-					return err
-				}
-
-				for counter, metricVals := range metrics {
-					val, _ := sm.LoadOrStore(groupCounterTuple{Group: group, Counter: counter}, []collector.Metric{})
-					out := val.([]collector.Metric)
-					out = append(out, metricVals...)
-					sm.Store(groupCounterTuple{Group: group, Counter: counter}, out)
-				}
-
-				return nil
-			})
+		if !last || len(groupMetrics) == 0 {
+			return nil
 		}
-	}
 
-	if err := g.Wait(); err != nil {
-		return nil, err
+		emitMtx.Lock()
+		defer emitMtx.Unlock()
+		if failed.Load() {
+			return nil
+		}
+		if err := emit(group, groupMetrics); err != nil {
+			failed.Store(true)
+			return err
+		}
+		return nil
 	}
 
-	output := MetricsByCounterGroup{}
+	g := new(errgroup.Group)
 
-	sm.Range(func(key, value interface{}) bool {
-		tuple := key.(groupCounterTuple)
-		if _, exists := output[tuple.Group]; !exists {
-			output[tuple.Group] = map[counters.Counter][]collector.Metric{}
-		}
-		output[tuple.Group][tuple.Counter] = value.([]collector.Metric)
-		return true // continue iteration
-	})
+	for entityCollectorTuple := range r.collectorGroupsSeen {
+		group := entityCollectorTuple.Entity()
+		g.Go(func() error {
+			if r.skipCollection(entityCollectorTuple) {
+				return done(group, nil)
+			}
+
+			metrics, duration, timedOut, err := r.collect(ctx, entityCollectorTuple.Collector())
+			if timedOut && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				// The gather was canceled, e.g. by the client, the collector did not fail
+				failed.Store(true)
+				return ctx.Err()
+			}
+			r.recordCollection(entityCollectorTuple, limits, duration, timedOut, err)
+			if timedOut {
+				slog.Warn("Collector timed out, its metrics are skipped",
+					slog.String("entity", group.String()),
+					slog.String("collector", entityCollectorTuple.Name()),
+					slog.Duration("duration", duration))
+				return done(group, nil)
+			}
+			if err != nil {
+				failed.Store(true)
+				return err
+			}
+
+			return done(group, metrics)
+		})
+	}
 
-	return output, nil
+	return g.Wait()
+}
+
+// collect returns the metrics of a collector and the time it took. The collector is called in place unless
+// ctx can be done, it is then abandoned like in a traced gather once ctx is done.
+func (r *Registry) collect(
+	ctx context.Context, c collector.Collector,
+) (collector.MetricsByCounter, time.Duration, bool, error) {
+	if ctx.Done() == nil {
+		start := time.Now()
+		metrics, err := c.GetMetrics()
+		return metrics, time.Since(start), false, err
+	}
+	return r.traceCollector(ctx, c)
 }
 
 // Cleanup resources of registered collectors
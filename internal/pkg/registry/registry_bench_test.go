@@ -0,0 +1,196 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	collectorpkg "github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/rendermetrics"
+)
+
+// benchmarkGPUCounts are the topology sizes the scrape path is measured with.
+var benchmarkGPUCounts = []int{1, 8, 64}
+
+// benchmarkMIGInstancesPerGPU is the number of GPU instances on every benchmark GPU,
+// the maximum number of 1g profiles on A100/H100.
+const benchmarkMIGInstancesPerGPU = 7
+
+// Budgets enforced by TestGatherAndRenderWithinBudget. They are per rendered series,
+// so the same budget applies to every topology size and catches non-linear regressions.
+const (
+	maxNsPerSeries     = 50_000
+	maxAllocsPerSeries = 150
+)
+
+var benchmarkCounters = []counters.Counter{
+	{FieldID: dcgm.DCGM_FI_DEV_SM_CLOCK, FieldName: "DCGM_FI_DEV_SM_CLOCK", PromType: "gauge", Help: "SM clock frequency (in MHz)."},
+	{FieldID: dcgm.DCGM_FI_DEV_MEM_CLOCK, FieldName: "DCGM_FI_DEV_MEM_CLOCK", PromType: "gauge", Help: "Memory clock frequency (in MHz)."},
+	{FieldID: dcgm.DCGM_FI_DEV_GPU_TEMP, FieldName: "DCGM_FI_DEV_GPU_TEMP", PromType: "gauge", Help: "GPU temperature (in C)."},
+	{FieldID: dcgm.DCGM_FI_DEV_POWER_USAGE, FieldName: "DCGM_FI_DEV_POWER_USAGE", PromType: "gauge", Help: "Power draw (in W)."},
+	{FieldID: dcgm.DCGM_FI_DEV_GPU_UTIL, FieldName: "DCGM_FI_DEV_GPU_UTIL", PromType: "gauge", Help: "GPU utilization (in %)."},
+	{FieldID: dcgm.DCGM_FI_DEV_FB_FREE, FieldName: "DCGM_FI_DEV_FB_FREE", PromType: "gauge", Help: "Framebuffer memory free (in MiB)."},
+	{FieldID: dcgm.DCGM_FI_DEV_FB_USED, FieldName: "DCGM_FI_DEV_FB_USED", PromType: "gauge", Help: "Framebuffer memory used (in MiB)."},
+	{FieldID: dcgm.DCGM_FI_DEV_XID_ERRORS, FieldName: "DCGM_FI_DEV_XID_ERRORS", PromType: "gauge", Help: "Value of the last XID error encountered."},
+	{FieldID: dcgm.DCGM_FI_PROF_GR_ENGINE_ACTIVE, FieldName: "DCGM_FI_PROF_GR_ENGINE_ACTIVE", PromType: "gauge", Help: "Ratio of time the graphics engine is active."},
+	{FieldID: dcgm.DCGM_FI_PROF_DRAM_ACTIVE, FieldName: "DCGM_FI_PROF_DRAM_ACTIVE", PromType: "gauge", Help: "Ratio of cycles the device memory interface is active sending or receiving data."},
+}
+
+// staticCollector returns the same metrics on every call, so benchmarks measure only
+// the registry and rendering overhead.
+type staticCollector struct {
+	metrics collectorpkg.MetricsByCounter
+}
+
+func (c *staticCollector) GetMetrics() (collectorpkg.MetricsByCounter, error) {
+	return c.metrics, nil
+}
+
+func (c *staticCollector) Cleanup() {}
+
+// newBenchmarkRegistry creates a registry with one collector per GPU. Every GPU reports
+// benchmarkCounters for the GPU itself and for each of its GPU instances.
+// It returns the registry and the number of series it renders.
+func newBenchmarkRegistry(gpuCount int) (*Registry, int) {
+	r := NewRegistry()
+	series := 0
+
+	for gpu := 0; gpu < gpuCount; gpu++ {
+		metrics := collectorpkg.MetricsByCounter{}
+		gpuUUID := fmt.Sprintf("GPU-00000000-0000-0000-0000-%012d", gpu)
+
+		for _, counter := range benchmarkCounters {
+			for instance := -1; instance < benchmarkMIGInstancesPerGPU; instance++ {
+				m := collectorpkg.Metric{
+					Counter:      counter,
+					Value:        "42",
+					GPU:          fmt.Sprint(gpu),
+					GPUUUID:      gpuUUID,
+					GPUDevice:    fmt.Sprintf("nvidia%d", gpu),
+					GPUModelName: "NVIDIA H100 80GB HBM3",
+					GPUPCIBusID:  fmt.Sprintf("00000000:%02X:00.0", gpu),
+					UUID:         "UUID",
+					Hostname:     "benchmark-host",
+					Labels:       map[string]string{},
+					Attributes:   map[string]string{},
+				}
+				if instance >= 0 {
+					m.MigProfile = "1g.10gb"
+					m.GPUInstanceID = fmt.Sprint(instance)
+				}
+				metrics[counter] = append(metrics[counter], m)
+				series++
+			}
+		}
+
+		entityCollectorTuple := collectorpkg.EntityCollectorTuple{}
+		entityCollectorTuple.SetEntity(dcgm.FE_GPU)
+		entityCollectorTuple.SetCollector(&staticCollector{metrics: metrics})
+		r.Register(entityCollectorTuple)
+	}
+
+	return r, series
+}
+
+func gatherAndRender(r *Registry) error {
+	metricGroups, err := r.Gather()
+	if err != nil {
+		return err
+	}
+
+	for group, metrics := range metricGroups {
+		if err := rendermetrics.RenderGroup(io.Discard, group, metrics); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func BenchmarkGather(b *testing.B) {
+	for _, gpuCount := range benchmarkGPUCounts {
+		b.Run(fmt.Sprintf("gpus=%d", gpuCount), func(b *testing.B) {
+			r, _ := newBenchmarkRegistry(gpuCount)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := r.Gather(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGatherAndRender(b *testing.B) {
+	for _, gpuCount := range benchmarkGPUCounts {
+		b.Run(fmt.Sprintf("gpus=%d", gpuCount), func(b *testing.B) {
+			r, _ := newBenchmarkRegistry(gpuCount)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := gatherAndRender(r); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// TestGatherAndRenderWithinBudget fails when the scrape path exceeds maxNsPerSeries or
+// maxAllocsPerSeries for any of the benchmark topologies. It runs as part of `make test-bench`.
+func TestGatherAndRenderWithinBudget(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping benchmark budget test in short mode.")
+	}
+
+	for _, gpuCount := range benchmarkGPUCounts {
+		t.Run(fmt.Sprintf("gpus=%d", gpuCount), func(t *testing.T) {
+			r, series := newBenchmarkRegistry(gpuCount)
+			require.NoError(t, gatherAndRender(r))
+
+			result := testing.Benchmark(func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					_ = gatherAndRender(r)
+				}
+			})
+
+			nsPerSeries := result.NsPerOp() / int64(series)
+			allocsPerSeries := result.AllocsPerOp() / int64(series)
+
+			t.Logf("gpus=%d series=%d latency=%s allocs/op=%d (%d ns and %d allocs per series)",
+				gpuCount, series, time.Duration(result.NsPerOp()), result.AllocsPerOp(),
+				nsPerSeries, allocsPerSeries)
+
+			assert.LessOrEqual(t, nsPerSeries, int64(maxNsPerSeries),
+				"Gather+render latency per series is over budget")
+			assert.LessOrEqual(t, allocsPerSeries, int64(maxAllocsPerSeries),
+				"Gather+render allocations per series are over budget")
+		})
+	}
+}
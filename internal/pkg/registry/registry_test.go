@@ -17,8 +17,10 @@
 package registry
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -139,3 +141,316 @@ func TestRegistry_Register_Accepts_Duplicates_(t *testing.T) {
 	assert.Len(t, reg.collectorGroups, 1)
 	assert.Len(t, reg.collectorGroupsSeen, 1)
 }
+
+func TestRegistry_ReplaceCollectors(t *testing.T) {
+	newTuple := func(entity dcgm.Field_Entity_Group, c collectorpkg.Collector) collectorpkg.EntityCollectorTuple {
+		tuple := collectorpkg.EntityCollectorTuple{}
+		tuple.SetEntity(entity)
+		tuple.SetCollector(c)
+		return tuple
+	}
+
+	oldGPUCollector := new(mockCollector)
+	oldLinkCollector := new(mockCollector)
+	switchCollector := new(mockCollector)
+	newGPUCollector := new(mockCollector)
+	newSwitchCollector := new(mockCollector)
+
+	reg := NewRegistry()
+	reg.Register(newTuple(dcgm.FE_GPU, oldGPUCollector))
+	reg.Register(newTuple(dcgm.FE_LINK, oldLinkCollector))
+	reg.Register(newTuple(dcgm.FE_SWITCH, switchCollector))
+
+	replaced := reg.ReplaceCollectors(
+		[]dcgm.Field_Entity_Group{dcgm.FE_GPU, dcgm.FE_LINK},
+		[]collectorpkg.EntityCollectorTuple{
+			newTuple(dcgm.FE_GPU, newGPUCollector),
+			// Collectors of other entity types are ignored
+			newTuple(dcgm.FE_SWITCH, newSwitchCollector),
+		},
+	)
+
+	require.Len(t, replaced, 2)
+	assert.Same(t, oldGPUCollector, replaced[0])
+	assert.Same(t, oldLinkCollector, replaced[1])
+
+	require.Len(t, reg.collectorGroups[dcgm.FE_GPU], 1)
+	assert.Same(t, newGPUCollector, reg.collectorGroups[dcgm.FE_GPU][0])
+	assert.NotContains(t, reg.collectorGroups, dcgm.FE_LINK)
+	require.Len(t, reg.collectorGroups[dcgm.FE_SWITCH], 1)
+	assert.Same(t, switchCollector, reg.collectorGroups[dcgm.FE_SWITCH][0])
+	assert.Len(t, reg.collectorGroupsSeen, 2)
+
+	// The replaced collector can be registered again
+	reg.Register(newTuple(dcgm.FE_LINK, oldLinkCollector))
+	require.Len(t, reg.collectorGroups[dcgm.FE_LINK], 1)
+	assert.Same(t, oldLinkCollector, reg.collectorGroups[dcgm.FE_LINK][0])
+}
+
+func TestRegistry_Collectors(t *testing.T) {
+	newTuple := func(entity dcgm.Field_Entity_Group, name string) collectorpkg.EntityCollectorTuple {
+		tuple := collectorpkg.EntityCollectorTuple{}
+		tuple.SetEntity(entity)
+		tuple.SetCollector(new(mockCollector))
+		tuple.SetName(name)
+		return tuple
+	}
+
+	reg := NewRegistry()
+	reg.Register(newTuple(dcgm.FE_SWITCH, collectorpkg.DCGMCollectorName))
+	reg.Register(newTuple(dcgm.FE_GPU, counters.DCGMExpXIDErrorsCount))
+	reg.Register(newTuple(dcgm.FE_GPU, collectorpkg.DCGMCollectorName))
+	// Unnamed collectors are not part of the inventory
+	reg.Register(newTuple(dcgm.FE_GPU, ""))
+
+	assert.Equal(t, []CollectorInfo{
+		{Entity: dcgm.FE_GPU, Name: collectorpkg.DCGMCollectorName},
+		{Entity: dcgm.FE_GPU, Name: counters.DCGMExpXIDErrorsCount},
+		{Entity: dcgm.FE_SWITCH, Name: collectorpkg.DCGMCollectorName},
+	}, reg.Collectors())
+
+	reg.ReplaceCollectors([]dcgm.Field_Entity_Group{dcgm.FE_SWITCH}, nil)
+
+	assert.Equal(t, []CollectorInfo{
+		{Entity: dcgm.FE_GPU, Name: collectorpkg.DCGMCollectorName},
+		{Entity: dcgm.FE_GPU, Name: counters.DCGMExpXIDErrorsCount},
+	}, reg.Collectors())
+}
+
+func TestRegistry_Trace(t *testing.T) {
+	newTuple := func(entity dcgm.Field_Entity_Group, name string, c collectorpkg.Collector) collectorpkg.EntityCollectorTuple {
+		tuple := collectorpkg.EntityCollectorTuple{}
+		tuple.SetEntity(entity)
+		tuple.SetCollector(c)
+		tuple.SetName(name)
+		return tuple
+	}
+
+	counter := counters.Counter{FieldID: 155, FieldName: "DCGM_FI_DEV_POWER_USAGE", PromType: "gauge"}
+
+	dcgmCollector := new(mockCollector)
+	dcgmCollector.On("GetMetrics").Return(collectorpkg.MetricsByCounter{
+		counter: {{GPU: "0", Counter: counter, Value: "42"}},
+	}, nil)
+
+	p2pCollector := new(mockCollector)
+	p2pCollector.On("GetMetrics").Return(collectorpkg.MetricsByCounter{}, errors.New("boom"))
+
+	// The collectors are traced by entity and name, the XID collector is traced last and blocks until the
+	// trace is over
+	release := make(chan time.Time)
+	defer close(release)
+	xidCollector := new(mockCollector)
+	xidCollector.On("GetMetrics").WaitUntil(release).Return(collectorpkg.MetricsByCounter{}, nil)
+
+	reg := NewRegistry()
+	reg.Register(newTuple(dcgm.FE_GPU, counters.DCGMExpXIDErrorsCount, xidCollector))
+	reg.Register(newTuple(dcgm.FE_GPU, counters.DCGMExpP2PStatus, p2pCollector))
+	reg.Register(newTuple(dcgm.FE_GPU, collectorpkg.DCGMCollectorName, dcgmCollector))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	traces, err := reg.Trace(ctx)
+	require.NoError(t, err)
+	require.Len(t, traces, 3)
+
+	byName := map[string]CollectorTrace{}
+	for _, trace := range traces {
+		byName[trace.Name] = trace
+	}
+
+	dcgmTrace := byName[collectorpkg.DCGMCollectorName]
+	require.Len(t, dcgmTrace.Fields, 1)
+	assert.Equal(t, "DCGM_FI_DEV_POWER_USAGE", dcgmTrace.Fields[0].Field)
+	assert.Equal(t, uint16(155), dcgmTrace.Fields[0].FieldID)
+	assert.Equal(t, "42", dcgmTrace.Fields[0].Values[0].Value)
+	assert.False(t, dcgmTrace.TimedOut)
+
+	assert.Equal(t, "boom", byName[counters.DCGMExpP2PStatus].Error)
+	assert.False(t, byName[counters.DCGMExpP2PStatus].TimedOut)
+
+	assert.True(t, byName[counters.DCGMExpXIDErrorsCount].TimedOut)
+}
+
+func TestRegistry_CollectorStats(t *testing.T) {
+	newTuple := func(entity dcgm.Field_Entity_Group, name string, c collectorpkg.Collector) collectorpkg.EntityCollectorTuple {
+		tuple := collectorpkg.EntityCollectorTuple{}
+		tuple.SetEntity(entity)
+		tuple.SetCollector(c)
+		tuple.SetName(name)
+		return tuple
+	}
+
+	dcgmCollector := new(mockCollector)
+	dcgmCollector.On("GetMetrics").Return(collectorpkg.MetricsByCounter{}, nil)
+	xidCollector := new(mockCollector)
+	xidCollector.On("GetMetrics").Return(collectorpkg.MetricsByCounter{}, errors.New("boom"))
+
+	reg := NewRegistry()
+	reg.Register(newTuple(dcgm.FE_GPU, collectorpkg.DCGMCollectorName, dcgmCollector))
+	reg.Register(newTuple(dcgm.FE_GPU, counters.DCGMExpXIDErrorsCount, xidCollector))
+
+	// Not gathered yet
+	assert.Empty(t, reg.CollectorStats())
+
+	for range 2 {
+		_, err := reg.Gather()
+		require.Error(t, err)
+	}
+
+	stats := reg.CollectorStats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, collectorpkg.DCGMCollectorName, stats[0].Name)
+	assert.Equal(t, uint64(0), stats[0].Errors)
+	assert.Equal(t, counters.DCGMExpXIDErrorsCount, stats[1].Name)
+	assert.Equal(t, uint64(2), stats[1].Errors)
+
+	// The replaced collectors are not reported anymore, and the age restarts
+	time.Sleep(10 * time.Millisecond)
+	age := reg.Age()
+	reg.ReplaceCollectors([]dcgm.Field_Entity_Group{dcgm.FE_GPU}, nil)
+	assert.Empty(t, reg.CollectorStats())
+	assert.Less(t, reg.Age(), age)
+}
+
+func TestRegistry_GatherContext_CollectorTimeout(t *testing.T) {
+	counter := counters.Counter{FieldID: 155, FieldName: "DCGM_FI_DEV_POWER_USAGE", PromType: "gauge"}
+
+	dcgmCollector := new(mockCollector)
+	dcgmCollector.On("GetMetrics").Return(collectorpkg.MetricsByCounter{
+		counter: {{GPU: "0", Counter: counter, Value: "42"}},
+	}, nil)
+
+	// The P2P collector is stuck
+	release := make(chan time.Time)
+	defer close(release)
+	p2pCollector := new(mockCollector)
+	p2pCollector.On("GetMetrics").WaitUntil(release).Return(collectorpkg.MetricsByCounter{}, nil)
+
+	reg := NewRegistry()
+	reg.Register(newNamedTuple(collectorpkg.DCGMCollectorName, dcgmCollector))
+	reg.Register(newNamedTuple(counters.DCGMExpP2PStatus, p2pCollector))
+	reg.SetCollectorLimits(CollectorLimits{Timeout: 50 * time.Millisecond})
+
+	// The stuck collector doesn't block the gather
+	metrics, err := reg.GatherContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "42", metrics[dcgm.FE_GPU][counter][0].Value)
+
+	stats := reg.CollectorStats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, counters.DCGMExpP2PStatus, stats[1].Name)
+	assert.Equal(t, uint64(1), stats[1].Timeouts)
+	assert.Equal(t, uint64(0), stats[1].Errors)
+
+	// A gather canceled by the caller is not a timeout of the collector
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = reg.GatherContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, uint64(1), reg.CollectorStats()[1].Timeouts)
+}
+
+func TestRegistry_GatherContext_CircuitBreaker(t *testing.T) {
+	dcgmCollector := new(mockCollector)
+	dcgmCollector.On("GetMetrics").Return(collectorpkg.MetricsByCounter{}, nil)
+	xidCollector := new(mockCollector)
+	xidCollector.On("GetMetrics").Return(collectorpkg.MetricsByCounter{}, errors.New("boom"))
+
+	reg := NewRegistry()
+	reg.Register(newNamedTuple(collectorpkg.DCGMCollectorName, dcgmCollector))
+	reg.Register(newNamedTuple(counters.DCGMExpXIDErrorsCount, xidCollector))
+	reg.SetCollectorLimits(CollectorLimits{BreakerThreshold: 2, BreakerCycles: 2})
+
+	// The failing collector fails the gathers until its circuit opens
+	for range 2 {
+		_, err := reg.GatherContext(context.Background())
+		require.Error(t, err)
+	}
+	assert.True(t, reg.CollectorStats()[1].CircuitOpen)
+	for range 2 {
+		_, err := reg.GatherContext(context.Background())
+		require.NoError(t, err, "The collector is skipped while its circuit is open")
+	}
+	xidCollector.AssertNumberOfCalls(t, "GetMetrics", 2)
+	dcgmCollector.AssertNumberOfCalls(t, "GetMetrics", 4)
+
+	stats := reg.CollectorStats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, counters.DCGMExpXIDErrorsCount, stats[1].Name)
+	assert.Equal(t, uint64(2), stats[1].Errors)
+	assert.Equal(t, uint64(2), stats[1].Skipped)
+	assert.False(t, stats[1].CircuitOpen)
+
+	// Still failing, the circuit opens again right away
+	_, err := reg.GatherContext(context.Background())
+	require.Error(t, err)
+	assert.True(t, reg.CollectorStats()[1].CircuitOpen)
+}
+
+func TestRegistry_GatherStream(t *testing.T) {
+	gpuCounter := counters.Counter{FieldID: 155, FieldName: "DCGM_FI_DEV_POWER_USAGE", PromType: "gauge"}
+	switchCounter := counters.Counter{FieldID: 856, FieldName: "DCGM_FI_DEV_NVSWITCH_VOLTAGE_MVOLT", PromType: "gauge"}
+
+	dcgmCollector := new(mockCollector)
+	dcgmCollector.On("GetMetrics").Return(collectorpkg.MetricsByCounter{
+		gpuCounter: {{GPU: "0", Counter: gpuCounter, Value: "42"}},
+	}, nil)
+	xidCollector := new(mockCollector)
+	xidCollector.On("GetMetrics").Return(collectorpkg.MetricsByCounter{
+		gpuCounter: {{GPU: "1", Counter: gpuCounter, Value: "7"}},
+	}, nil)
+
+	// The switch collector blocks until the GPU metrics were emitted
+	release := make(chan time.Time)
+	switchCollector := new(mockCollector)
+	switchCollector.On("GetMetrics").WaitUntil(release).Return(collectorpkg.MetricsByCounter{
+		switchCounter: {{GPU: "0", Counter: switchCounter, Value: "800"}},
+	}, nil)
+
+	switchTuple := collectorpkg.EntityCollectorTuple{}
+	switchTuple.SetEntity(dcgm.FE_SWITCH)
+	switchTuple.SetCollector(switchCollector)
+
+	reg := NewRegistry()
+	reg.Register(newNamedTuple(collectorpkg.DCGMCollectorName, dcgmCollector))
+	reg.Register(newNamedTuple(counters.DCGMExpXIDErrorsCount, xidCollector))
+	reg.Register(switchTuple)
+
+	emitted := make(chan MetricsByCounterGroup, 2)
+	gathered := make(chan error, 1)
+	go func() {
+		gathered <- reg.GatherStream(context.Background(),
+			func(group dcgm.Field_Entity_Group, metrics collectorpkg.MetricsByCounter) error {
+				emitted <- MetricsByCounterGroup{group: metrics}
+				return nil
+			})
+	}()
+
+	// The GPU metrics are emitted together, before the switch collector is done
+	gpuMetrics := <-emitted
+	require.Contains(t, gpuMetrics, dcgm.FE_GPU)
+	assert.Len(t, gpuMetrics[dcgm.FE_GPU][gpuCounter], 2)
+
+	close(release)
+	require.NoError(t, <-gathered)
+	switchMetrics := <-emitted
+	require.Contains(t, switchMetrics, dcgm.FE_SWITCH)
+	assert.Equal(t, "800", switchMetrics[dcgm.FE_SWITCH][switchCounter][0].Value)
+
+	// An emit failure fails the gather
+	err := reg.GatherStream(context.Background(), func(dcgm.Field_Entity_Group, collectorpkg.MetricsByCounter) error {
+		return errors.New("boom")
+	})
+	require.EqualError(t, err, "boom")
+}
+
+// newNamedTuple returns a named GPU collector to register
+func newNamedTuple(name string, c collectorpkg.Collector) collectorpkg.EntityCollectorTuple {
+	tuple := collectorpkg.EntityCollectorTuple{}
+	tuple.SetEntity(dcgm.FE_GPU)
+	tuple.SetCollector(c)
+	tuple.SetName(name)
+	return tuple
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+)
+
+// breakerState is the circuit breaker of a collector
+type breakerState struct {
+	failures int // consecutive failed collections
+	skips    int // gathers left skipping the collector, the circuit is open while positive
+}
+
+// SetCollectorLimits sets the timeout and the circuit breaker of the collections of the next gathers.
+func (r *Registry) SetCollectorLimits(limits CollectorLimits) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.limits = limits
+}
+
+// skipCollection returns whether the circuit of a collector is open, counting the gather it skips.
+func (r *Registry) skipCollection(tuple collector.EntityCollectorTuple) bool {
+	r.statsMtx.Lock()
+	defer r.statsMtx.Unlock()
+
+	breaker, exists := r.breakers[tuple]
+	if !exists || breaker.skips == 0 {
+		return false
+	}
+
+	breaker.skips--
+	if stats := r.collectorStats(tuple); stats != nil {
+		stats.Skipped++
+		stats.CircuitOpen = breaker.skips > 0
+	}
+	return true
+}
+
+// recordCollection records the duration and the result of a collection, and opens the circuit of the
+// collector once it failed limits.BreakerThreshold times in a row. A collection failing after the circuit
+// closed again reopens it right away.
+func (r *Registry) recordCollection(
+	tuple collector.EntityCollectorTuple, limits CollectorLimits, duration time.Duration, timedOut bool, err error,
+) {
+	r.statsMtx.Lock()
+	defer r.statsMtx.Unlock()
+
+	failed := timedOut || err != nil
+	stats := r.collectorStats(tuple)
+	if stats != nil {
+		stats.Duration = duration
+		switch {
+		case timedOut:
+			stats.Timeouts++
+		case err != nil:
+			stats.Errors++
+		}
+	}
+
+	if limits.BreakerThreshold <= 0 {
+		return
+	}
+
+	breaker, exists := r.breakers[tuple]
+	if !exists {
+		breaker = &breakerState{}
+		r.breakers[tuple] = breaker
+	}
+	if !failed {
+		breaker.failures = 0
+		return
+	}
+
+	breaker.failures++
+	if breaker.failures < limits.BreakerThreshold || limits.BreakerCycles <= 0 {
+		return
+	}
+
+	breaker.skips = limits.BreakerCycles
+	if stats != nil {
+		stats.CircuitOpen = true
+	}
+	slog.Warn("Collector failed repeatedly, skipping it",
+		slog.String("entity", tuple.Entity().String()),
+		slog.String("collector", tuple.Name()),
+		slog.Int("failures", breaker.failures),
+		slog.Int("skipped_gathers", limits.BreakerCycles))
+}
+
+// collectorStats returns the statistics of a named collector, nil for an unnamed one.
+// The caller must hold the stats lock.
+func (r *Registry) collectorStats(tuple collector.EntityCollectorTuple) *CollectorStats {
+	if tuple.Name() == "" {
+		return nil
+	}
+
+	info := CollectorInfo{Entity: tuple.Entity(), Name: tuple.Name()}
+	stats, exists := r.stats[info]
+	if !exists {
+		stats = &CollectorStats{Entity: info.Entity, Name: info.Name}
+		r.stats[info] = stats
+	}
+	return stats
+}
+
+// CollectorStats returns the statistics of the collections of the named collectors registered with the
+// registry, sorted by entity type and name. The collectors not gathered yet are not returned.
+func (r *Registry) CollectorStats() []CollectorStats {
+	registered := r.Collectors()
+
+	r.statsMtx.Lock()
+	defer r.statsMtx.Unlock()
+
+	stats := make([]CollectorStats, 0, len(registered))
+	for _, info := range registered {
+		if collectorStats, exists := r.stats[info]; exists {
+			stats = append(stats, *collectorStats)
+		}
+	}
+
+	return stats
+}
+
+// Age returns the time since the collectors of the registry were built, or last replaced by a partial reload.
+func (r *Registry) Age() time.Duration {
+	return time.Since(time.Unix(0, r.built.Load()))
+}
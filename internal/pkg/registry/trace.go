@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package registry
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+)
+
+// Trace gathers the metrics of the named collectors one after the other, so that their durations are not
+// skewed by each other, and returns the duration and the values of every collector. A collector that
+// does not complete before ctx is done is reported as timed out, it keeps running in the background and
+// delays the cleanup of the registry like a Gather call.
+func (r *Registry) Trace(ctx context.Context) ([]CollectorTrace, error) {
+	r.activeGathers.Add(1)
+	defer r.activeGathers.Add(-1)
+
+	if r.shuttingDown.Load() {
+		return nil, ErrRegistryShuttingDown
+	}
+
+	r.mtx.RLock()
+	tuples := make([]collector.EntityCollectorTuple, 0, len(r.collectorGroupsSeen))
+	for entityCollectorTuple := range r.collectorGroupsSeen {
+		if entityCollectorTuple.Name() != "" {
+			tuples = append(tuples, entityCollectorTuple)
+		}
+	}
+	r.mtx.RUnlock()
+
+	slices.SortFunc(tuples, func(a, b collector.EntityCollectorTuple) int {
+		return cmp.Or(cmp.Compare(a.Entity(), b.Entity()), cmp.Compare(a.Name(), b.Name()))
+	})
+
+	traces := make([]CollectorTrace, 0, len(tuples))
+	for _, tuple := range tuples {
+		trace := CollectorTrace{
+			Entity: tuple.Entity().String(),
+			Name:   tuple.Name(),
+			Fields: []FieldTrace{},
+		}
+
+		if ctx.Err() != nil {
+			trace.TimedOut = true
+			traces = append(traces, trace)
+			continue
+		}
+
+		metrics, duration, timedOut, err := r.traceCollector(ctx, tuple.Collector())
+		trace.Duration = duration
+		trace.TimedOut = timedOut
+		if err != nil {
+			trace.Error = err.Error()
+		}
+
+		for counter, values := range metrics {
+			trace.Fields = append(trace.Fields, FieldTrace{
+				Field:   counter.FieldName,
+				FieldID: uint16(counter.FieldID),
+				Values:  values,
+			})
+		}
+		slices.SortFunc(trace.Fields, func(a, b FieldTrace) int { return cmp.Compare(a.Field, b.Field) })
+
+		traces = append(traces, trace)
+	}
+
+	return traces, nil
+}
+
+// traceCollector returns the metrics of a collector and the time it took, or whether ctx was done first.
+func (r *Registry) traceCollector(
+	ctx context.Context, c collector.Collector,
+) (collector.MetricsByCounter, time.Duration, bool, error) {
+	type result struct {
+		metrics collector.MetricsByCounter
+		err     error
+	}
+
+	r.activeGathers.Add(1)
+	start := time.Now()
+	done := make(chan result, 1)
+	go func() {
+		defer r.activeGathers.Add(-1)
+		metrics, err := c.GetMetrics()
+		done <- result{metrics: metrics, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.metrics, time.Since(start), false, res.err
+	case <-ctx.Done():
+		return nil, time.Since(start), true, nil
+	}
+}
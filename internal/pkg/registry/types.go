@@ -17,6 +17,8 @@
 package registry
 
 import (
+	"time"
+
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
 
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
@@ -24,3 +26,45 @@ import (
 
 // MetricsByCounterGroup represents a group of metrics by specific counter groups
 type MetricsByCounterGroup map[dcgm.Field_Entity_Group]collector.MetricsByCounter
+
+// CollectorInfo describes a collector registered for an entity type
+type CollectorInfo struct {
+	Entity dcgm.Field_Entity_Group
+	Name   string
+}
+
+// CollectorStats is the statistics of the collections of a collector
+type CollectorStats struct {
+	Entity      dcgm.Field_Entity_Group
+	Name        string
+	Duration    time.Duration // duration of the last collection
+	Errors      uint64        // number of failed collections
+	Timeouts    uint64        // number of collections abandoned after the collector timeout
+	Skipped     uint64        // number of gathers skipping the collector while its circuit was open
+	CircuitOpen bool          // whether the collector is skipped by the next gathers
+}
+
+// CollectorLimits bound the time a collection may take and skip the collectors failing repeatedly
+type CollectorLimits struct {
+	Timeout          time.Duration // longest collection; 0 waits for the collectors indefinitely
+	BreakerThreshold int           // consecutive failed collections opening the circuit of a collector; 0 disables it
+	BreakerCycles    int           // gathers skipping a collector once its circuit is open
+}
+
+// CollectorTrace is the result of a collector in a traced gather
+type CollectorTrace struct {
+	Entity   string        `json:"entity"`
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration_ns"`
+	TimedOut bool          `json:"timed_out,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Fields   []FieldTrace  `json:"fields"`
+}
+
+// FieldTrace is the values of a field returned by a collector in a traced gather. The fields of a
+// collector are read together, the time spent on a field is the duration of its collector.
+type FieldTrace struct {
+	Field   string             `json:"field"`
+	FieldID uint16             `json:"field_id"`
+	Values  []collector.Metric `json:"values"`
+}
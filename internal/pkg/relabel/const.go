@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package relabel
+
+// Action is what a rule does with the metrics it matches
+type Action string
+
+const (
+	ActionRename      Action = "rename"       // Renames the matching families to the expanded replacement
+	ActionDrop        Action = "drop"         // Drops the matching families, or their series whose label matches
+	ActionCopyLabel   Action = "copy_label"   // Copies the source label to the target label
+	ActionRenameLabel Action = "rename_label" // Moves the source label to the target label
+)
+
+// Labels rendered from the fields of the metrics rather than from their label maps
+const (
+	labelGPU               = "gpu"
+	labelUUID              = "UUID"
+	labelUUIDLower         = "uuid"
+	labelPCIBusID          = "pci_bus_id"
+	labelDevice            = "device"
+	labelModelName         = "modelName"
+	labelGPUInstance       = "GPU_I_ID"
+	labelGPUProfile        = "GPU_I_PROFILE"
+	labelComputeInstanceID = "compute_instance_id"
+	labelHostname          = "Hostname"
+)
+
+var builtinLabels = []string{
+	labelGPU, labelUUID, labelUUIDLower, labelPCIBusID, labelDevice, labelModelName, labelGPUInstance,
+	labelGPUProfile, labelComputeInstanceID, labelHostname,
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package relabel
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"regexp"
+	"slices"
+	"sync/atomic"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+)
+
+// current are the rules applied by Apply, nil applies none
+var current atomic.Pointer[Rules]
+
+// Load reads and compiles the rules of the file, then applies them from the next scrape on. With an invalid
+// file, the previous rules stay active.
+func Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read relabel configuration: %w", err)
+	}
+
+	rules, err := Parse(data)
+	if err != nil {
+		return fmt.Errorf("invalid relabel configuration %s: %w", path, err)
+	}
+
+	current.Store(rules)
+	slog.Info("Relabel rules loaded", slog.String("file", path), slog.Int("rules", len(rules.rules)))
+	return nil
+}
+
+// Apply applies the loaded rules to the metrics
+func Apply(metrics collector.MetricsByCounter) {
+	if rules := current.Load(); rules != nil {
+		rules.Apply(metrics)
+	}
+}
+
+// Parse parses and compiles the rules of a YAML configuration
+func Parse(data []byte) (*Rules, error) {
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, err
+	}
+
+	rules := &Rules{rules: make([]compiledRule, 0, len(config.Rules))}
+	for i, rule := range config.Rules {
+		compiled, err := compile(rule)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+		rules.rules = append(rules.rules, compiled)
+	}
+	return rules, nil
+}
+
+func compile(rule Rule) (compiledRule, error) {
+	compiled := compiledRule{Rule: rule}
+
+	var err error
+	if rule.Metric != "" {
+		if compiled.metric, err = regexp.Compile("^(?:" + rule.Metric + ")$"); err != nil {
+			return compiled, fmt.Errorf("invalid metric: %w", err)
+		}
+	}
+
+	switch rule.Action {
+	case ActionRename:
+		if rule.Metric == "" || rule.Replacement == "" {
+			return compiled, errors.New("rename requires metric and replacement")
+		}
+	case ActionDrop:
+		if rule.SourceLabel != "" {
+			if compiled.regex, err = regexp.Compile("^(?:" + rule.Regex + ")$"); err != nil {
+				return compiled, fmt.Errorf("invalid regex: %w", err)
+			}
+		} else if rule.Metric == "" {
+			return compiled, errors.New("drop requires metric or source_label")
+		}
+	case ActionCopyLabel, ActionRenameLabel:
+		if rule.SourceLabel == "" || rule.TargetLabel == "" {
+			return compiled, fmt.Errorf("%s requires source_label and target_label", rule.Action)
+		}
+		if slices.Contains(builtinLabels, rule.TargetLabel) {
+			return compiled, fmt.Errorf("target_label %s is set by the exporter", rule.TargetLabel)
+		}
+		if rule.Action == ActionRenameLabel && slices.Contains(builtinLabels, rule.SourceLabel) {
+			return compiled, fmt.Errorf("source_label %s is set by the exporter and can't be renamed, copy it",
+				rule.SourceLabel)
+		}
+	default:
+		return compiled, fmt.Errorf("unknown action '%s'", rule.Action)
+	}
+
+	return compiled, nil
+}
+
+// Apply applies the rules to the metrics, in order
+func (r *Rules) Apply(metrics collector.MetricsByCounter) {
+	for _, rule := range r.rules {
+		// The families may be renamed while iterating, they must not be processed twice
+		for _, counter := range slices.Collect(maps.Keys(metrics)) {
+			if rule.metric != nil && !rule.metric.MatchString(counter.FieldName) {
+				continue
+			}
+
+			switch rule.Action {
+			case ActionRename:
+				newCounter := counter
+				newCounter.FieldName = rule.metric.ReplaceAllString(counter.FieldName, rule.Replacement)
+				if newCounter == counter {
+					continue
+				}
+				metricList := metrics[counter]
+				for i := range metricList {
+					metricList[i].Counter = newCounter
+				}
+				delete(metrics, counter)
+				metrics[newCounter] = append(metrics[newCounter], metricList...)
+			case ActionDrop:
+				if rule.regex == nil {
+					delete(metrics, counter)
+					continue
+				}
+				kept := slices.DeleteFunc(metrics[counter], func(m collector.Metric) bool {
+					value, _ := labelValue(m, rule.SourceLabel)
+					return rule.regex.MatchString(value)
+				})
+				if len(kept) == 0 {
+					delete(metrics, counter)
+				} else {
+					metrics[counter] = kept
+				}
+			case ActionCopyLabel, ActionRenameLabel:
+				metricList := metrics[counter]
+				for i := range metricList {
+					relabelMetric(&metricList[i], rule)
+				}
+			}
+		}
+	}
+}
+
+// relabelMetric copies or moves the source label of the metric to the target label
+func relabelMetric(m *collector.Metric, rule compiledRule) {
+	value, ok := labelValue(*m, rule.SourceLabel)
+	if !ok {
+		return
+	}
+
+	// Labels may be shared between metrics of a collector
+	labels := maps.Clone(m.Labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	if rule.Action == ActionRenameLabel {
+		delete(labels, rule.SourceLabel)
+		if _, isAttribute := m.Attributes[rule.SourceLabel]; isAttribute {
+			m.Attributes = maps.Clone(m.Attributes)
+			delete(m.Attributes, rule.SourceLabel)
+		}
+	}
+	if _, isAttribute := m.Attributes[rule.TargetLabel]; isAttribute {
+		m.Attributes = maps.Clone(m.Attributes)
+		delete(m.Attributes, rule.TargetLabel)
+	}
+	labels[rule.TargetLabel] = value
+	m.Labels = labels
+}
+
+// labelValue returns the value of a label of the metric as rendered, and whether the metric has the label
+func labelValue(m collector.Metric, name string) (string, bool) {
+	switch name {
+	case labelGPU:
+		return m.GPU, true
+	case labelUUID, labelUUIDLower:
+		if m.UUID == name {
+			return m.GPUUUID, true
+		}
+	case labelPCIBusID:
+		return m.GPUPCIBusID, true
+	case labelDevice:
+		return m.GPUDevice, true
+	case labelModelName:
+		return m.GPUModelName, true
+	case labelGPUInstance:
+		return m.GPUInstanceID, m.MigProfile != ""
+	case labelGPUProfile:
+		return m.MigProfile, m.MigProfile != ""
+	case labelComputeInstanceID:
+		return m.ComputeInstanceID, m.ComputeInstanceID != ""
+	case labelHostname:
+		return m.Hostname, m.Hostname != ""
+	}
+
+	if value, ok := m.Labels[name]; ok {
+		return value, true
+	}
+	value, ok := m.Attributes[name]
+	return value, ok
+}
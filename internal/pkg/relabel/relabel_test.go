@@ -0,0 +1,133 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package relabel
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+var (
+	gpuTemp  = counters.Counter{FieldID: 150, FieldName: "DCGM_FI_DEV_GPU_TEMP", PromType: "gauge"}
+	smClock  = counters.Counter{FieldID: 100, FieldName: "DCGM_FI_DEV_SM_CLOCK", PromType: "gauge"}
+	grActive = counters.Counter{FieldID: 1001, FieldName: "DCGM_FI_PROF_GR_ENGINE_ACTIVE", PromType: "gauge"}
+)
+
+func testMetrics() collector.MetricsByCounter {
+	shared := map[string]string{"pod": "train-0", "namespace": "ml"}
+	return collector.MetricsByCounter{
+		gpuTemp: {
+			{Counter: gpuTemp, GPU: "0", UUID: "UUID", GPUUUID: "GPU-0", Hostname: "node1", Value: "40", Labels: shared},
+			{Counter: gpuTemp, GPU: "1", UUID: "UUID", GPUUUID: "GPU-1", Hostname: "node1", Value: "41"},
+		},
+		smClock: {
+			{Counter: smClock, GPU: "0", UUID: "UUID", GPUUUID: "GPU-0", Value: "1500", Labels: shared},
+		},
+		grActive: {
+			{Counter: grActive, GPU: "0", UUID: "UUID", GPUUUID: "GPU-0", Value: "0.5"},
+		},
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"Unknown action":        "rules: [{action: replace, metric: DCGM_.*}]",
+		"Unknown field":         "rules: [{action: drop, metrics: DCGM_.*}]",
+		"Invalid metric":        "rules: [{action: drop, metric: '('}]",
+		"Rename without name":   "rules: [{action: rename, metric: DCGM_.*}]",
+		"Drop everything":       "rules: [{action: drop}]",
+		"Copy without target":   "rules: [{action: copy_label, source_label: pod}]",
+		"Builtin target":        "rules: [{action: copy_label, source_label: pod, target_label: gpu}]",
+		"Rename builtin source": "rules: [{action: rename_label, source_label: Hostname, target_label: node}]",
+	}
+
+	for name, config := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(config))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRules_Apply(t *testing.T) {
+	rules, err := Parse([]byte(`
+rules:
+  - action: drop
+    metric: DCGM_FI_PROF_.*
+  - action: drop
+    metric: DCGM_FI_DEV_GPU_TEMP
+    source_label: gpu
+    regex: "1"
+  - action: rename
+    metric: DCGM_FI_DEV_(.*)
+    replacement: gpu_$1
+  - action: copy_label
+    source_label: Hostname
+    target_label: node
+  - action: rename_label
+    metric: gpu_SM_CLOCK
+    source_label: pod
+    target_label: k8s_pod
+`))
+	require.NoError(t, err)
+
+	metrics := testMetrics()
+	shared := metrics[gpuTemp][0].Labels
+	rules.Apply(metrics)
+
+	require.Len(t, metrics, 2)
+
+	renamedTemp := gpuTemp
+	renamedTemp.FieldName = "gpu_GPU_TEMP"
+	require.Len(t, metrics[renamedTemp], 1, "The series of GPU 1 is dropped")
+	assert.Equal(t, "0", metrics[renamedTemp][0].GPU)
+	assert.Equal(t, renamedTemp, metrics[renamedTemp][0].Counter)
+	assert.Equal(t, map[string]string{"pod": "train-0", "namespace": "ml", "node": "node1"},
+		metrics[renamedTemp][0].Labels)
+
+	renamedClock := smClock
+	renamedClock.FieldName = "gpu_SM_CLOCK"
+	require.Len(t, metrics[renamedClock], 1)
+	assert.Equal(t, map[string]string{"k8s_pod": "train-0", "namespace": "ml"}, metrics[renamedClock][0].Labels,
+		"The metric has no hostname to copy")
+
+	assert.Equal(t, map[string]string{"pod": "train-0", "namespace": "ml"}, shared,
+		"Labels shared between metrics must not be modified")
+}
+
+func TestLoad_KeepsPreviousRules(t *testing.T) {
+	defer current.Store(nil)
+
+	path := filepath.Join(t.TempDir(), "relabel.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("rules: [{action: drop, metric: DCGM_FI_PROF_.*}]"), 0o600))
+	require.NoError(t, Load(path))
+
+	require.NoError(t, os.WriteFile(path, []byte("rules: [{action: unknown}]"), 0o600))
+	assert.Error(t, Load(path))
+
+	metrics := testMetrics()
+	Apply(metrics)
+	assert.Len(t, metrics, 2, "The previous rules are still applied")
+	assert.NotContains(t, metrics, grActive)
+}
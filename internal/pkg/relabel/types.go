@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package relabel
+
+import "regexp"
+
+// Config is the content of the relabel configuration file
+type Config struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Rule is a relabel rule. Rules are applied in order, every rule sees the result of the previous ones.
+type Rule struct {
+	Action Action `json:"action"`
+	// Metric is a regular expression matching the whole name of the families the rule applies to; empty
+	// matches all the families
+	Metric string `json:"metric,omitempty"`
+	// Replacement is the new name of the families for rename, with the $1... groups of Metric expanded
+	Replacement string `json:"replacement,omitempty"`
+	// SourceLabel is the label copied or renamed, or the label matched against Regex for drop
+	SourceLabel string `json:"source_label,omitempty"`
+	// Regex matches the whole value of SourceLabel for drop
+	Regex       string `json:"regex,omitempty"`
+	TargetLabel string `json:"target_label,omitempty"`
+}
+
+// Rules are the compiled rules of a configuration
+type Rules struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	Rule
+	metric *regexp.Regexp // nil matches all the families
+	regex  *regexp.Regexp
+}
@@ -0,0 +1,279 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remotewrite
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/pushqueue"
+)
+
+// NewExporter creates an exporter pushing the metrics of source to the remote write endpoints of the
+// configuration. Endpoints with namespaces only receive the series of the pods in these namespaces, the
+// other endpoints receive all the series. Every endpoint has its own queue, so a slow endpoint doesn't
+// delay the other ones.
+func NewExporter(config *appconfig.Config, source MetricsSource) (*Exporter, error) {
+	if config.RemoteWriteInterval <= 0 {
+		return nil, fmt.Errorf("invalid remote write interval: %s", config.RemoteWriteInterval)
+	}
+
+	if len(config.RemoteWriteEndpoints) == 0 {
+		return nil, errors.New("no remote write endpoint is configured")
+	}
+
+	endpoints := make([]endpoint, 0, len(config.RemoteWriteEndpoints))
+	for _, e := range config.RemoteWriteEndpoints {
+		ep := endpoint{
+			url: e.URL,
+			queue: pushqueue.New[timeSeries](sinkName, appconfig.RedactURL(e.URL), pushqueue.Config{
+				Capacity:      config.PushQueueSize,
+				BatchSize:     config.PushBatchSize,
+				FlushInterval: config.PushFlushInterval,
+			}),
+		}
+		if len(e.Namespaces) > 0 {
+			ep.namespaces = make(map[string]struct{}, len(e.Namespaces))
+			for _, namespace := range e.Namespaces {
+				ep.namespaces[namespace] = struct{}{}
+			}
+		}
+		endpoints = append(endpoints, ep)
+	}
+
+	namespaceLabelName := namespaceLabel
+	if config.UseOldNamespace {
+		namespaceLabelName = oldNamespaceLabel
+	}
+
+	return &Exporter{
+		client:         &http.Client{Timeout: config.RemoteWriteInterval},
+		source:         source,
+		interval:       config.RemoteWriteInterval,
+		endpoints:      endpoints,
+		namespaceLabel: namespaceLabelName,
+	}, nil
+}
+
+// Run queues the metrics every interval until the context is canceled. The queues of the endpoints are
+// pushed in the background, in batches.
+func (e *Exporter) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for _, ep := range e.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ep.queue.Run(ctx, func(ctx context.Context, batch []timeSeries) error {
+				return e.send(ctx, ep.url, batch)
+			})
+		}()
+	}
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.enqueue(); err != nil {
+				slog.Warn("Failed to push metrics over remote write", slog.String(logging.ErrorKey, err.Error()))
+			}
+		}
+	}
+}
+
+// enqueue adds the current metrics of the source to the queue of every endpoint.
+func (e *Exporter) enqueue() error {
+	series, err := e.collect()
+	if err != nil {
+		return err
+	}
+
+	for _, ep := range e.endpoints {
+		ep.queue.Push(e.filter(series, ep.namespaces)...)
+	}
+	return nil
+}
+
+// Export pushes the current metrics of the source to every endpoint, bypassing the queues. A failing
+// endpoint doesn't prevent the push to the other ones.
+func (e *Exporter) Export(ctx context.Context) error {
+	series, err := e.collect()
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, ep := range e.endpoints {
+		epSeries := e.filter(series, ep.namespaces)
+		if len(epSeries) == 0 {
+			continue
+		}
+		if err := e.send(ctx, ep.url, epSeries); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", appconfig.RedactURL(ep.url), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// collect returns the current metrics of the source as time series
+func (e *Exporter) collect() ([]timeSeries, error) {
+	var buf bytes.Buffer
+	if err := e.source(&buf); err != nil {
+		return nil, err
+	}
+
+	return toTimeSeries(&buf, time.Now())
+}
+
+// filter returns the series with a namespace label in namespaces; nil namespaces select all the series.
+func (e *Exporter) filter(series []timeSeries, namespaces map[string]struct{}) []timeSeries {
+	if namespaces == nil {
+		return series
+	}
+
+	filtered := make([]timeSeries, 0)
+	for _, ts := range series {
+		for _, l := range ts.labels {
+			if l.name != e.namespaceLabel {
+				continue
+			}
+			if _, ok := namespaces[l.value]; ok {
+				filtered = append(filtered, ts)
+			}
+			break
+		}
+	}
+	return filtered
+}
+
+func (e *Exporter) send(ctx context.Context, url string, series []timeSeries) error {
+	body := snappy.Encode(nil, encodeWriteRequest(series))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Content-Encoding", contentEncoding)
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("X-Prometheus-Remote-Write-Version", remoteWriteVersion)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// toTimeSeries converts metrics in the Prometheus text format into remote write time series. Summaries
+// are split into their quantile, _sum and _count series. Histograms aren't produced by the exporter and
+// are skipped.
+func toTimeSeries(r io.Reader, now time.Time) ([]timeSeries, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics: %w", err)
+	}
+
+	timestamp := now.UnixMilli()
+
+	var series []timeSeries
+	for _, name := range slices.Sorted(maps.Keys(families)) {
+		mf := families[name]
+		for _, pm := range mf.GetMetric() {
+			ts := timestamp
+			if pm.TimestampMs != nil {
+				ts = pm.GetTimestampMs()
+			}
+
+			switch mf.GetType() {
+			case dto.MetricType_COUNTER:
+				series = append(series, newTimeSeries(name, pm, pm.GetCounter().GetValue(), ts))
+			case dto.MetricType_GAUGE:
+				series = append(series, newTimeSeries(name, pm, pm.GetGauge().GetValue(), ts))
+			case dto.MetricType_UNTYPED:
+				series = append(series, newTimeSeries(name, pm, pm.GetUntyped().GetValue(), ts))
+			case dto.MetricType_SUMMARY:
+				for _, q := range pm.GetSummary().GetQuantile() {
+					s := newTimeSeries(name, pm, q.GetValue(), ts)
+					s.labels = insertLabel(s.labels, label{
+						name:  quantileLabel,
+						value: fmt.Sprint(q.GetQuantile()),
+					})
+					series = append(series, s)
+				}
+				series = append(series,
+					newTimeSeries(name+"_sum", pm, pm.GetSummary().GetSampleSum(), ts),
+					newTimeSeries(name+"_count", pm, float64(pm.GetSummary().GetSampleCount()), ts),
+				)
+			}
+		}
+	}
+
+	return series, nil
+}
+
+func newTimeSeries(name string, pm *dto.Metric, value float64, timestamp int64) timeSeries {
+	labels := make([]label, 0, len(pm.GetLabel())+1)
+	labels = append(labels, label{name: metricNameLabel, value: name})
+	for _, l := range pm.GetLabel() {
+		labels = append(labels, label{name: l.GetName(), value: l.GetValue()})
+	}
+	slices.SortFunc(labels, func(a, b label) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	return timeSeries{labels: labels, value: value, timestamp: timestamp}
+}
+
+// insertLabel inserts l into labels sorted by name
+func insertLabel(labels []label, l label) []label {
+	i, _ := slices.BinarySearchFunc(labels, l.name, func(e label, name string) int {
+		return strings.Compare(e.name, name)
+	})
+	return slices.Insert(labels, i, l)
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remotewrite
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+)
+
+const testMetrics = `# HELP DCGM_FI_DEV_GPU_TEMP GPU temperature (in C).
+# TYPE DCGM_FI_DEV_GPU_TEMP gauge
+DCGM_FI_DEV_GPU_TEMP{gpu="0",UUID="GPU-0",pod="train-0",namespace="team-a"} 42
+DCGM_FI_DEV_GPU_TEMP{gpu="1",UUID="GPU-1",pod="infer-0",namespace="team-b"} 43
+DCGM_FI_DEV_GPU_TEMP{gpu="2",UUID="GPU-2"} 44
+# HELP dcgm_exporter_dra_mapping_duration_seconds Time spent mapping DRA devices to pods.
+# TYPE dcgm_exporter_dra_mapping_duration_seconds summary
+dcgm_exporter_dra_mapping_duration_seconds_sum 0.5
+dcgm_exporter_dra_mapping_duration_seconds_count 2
+`
+
+func testSource(w io.Writer) error {
+	_, err := io.WriteString(w, testMetrics)
+	return err
+}
+
+// consumeFields calls field with every field of a protobuf message, field returns the length it consumed
+func consumeFields(t *testing.T, b []byte, field func(num protowire.Number, b []byte) int) {
+	t.Helper()
+	for len(b) > 0 {
+		num, _, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		n = field(num, b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+	}
+}
+
+// decodeWriteRequest decodes a prometheus.WriteRequest into one "name=value,...,@value" string per series
+func decodeWriteRequest(t *testing.T, b []byte) []string {
+	t.Helper()
+
+	var series []string
+	consumeFields(t, b, func(_ protowire.Number, b []byte) int {
+		ts, n := protowire.ConsumeBytes(b)
+		var fields []string
+		consumeFields(t, ts, func(num protowire.Number, b []byte) int {
+			msg, n := protowire.ConsumeBytes(b)
+			switch num {
+			case timeSeriesLabels:
+				var name, value string
+				consumeFields(t, msg, func(num protowire.Number, b []byte) int {
+					s, n := protowire.ConsumeString(b)
+					if num == labelName {
+						name = s
+					} else {
+						value = s
+					}
+					return n
+				})
+				fields = append(fields, name+"="+value)
+			case timeSeriesSamples:
+				consumeFields(t, msg, func(num protowire.Number, b []byte) int {
+					if num == sampleValue {
+						v, n := protowire.ConsumeFixed64(b)
+						fields = append(fields, "@"+strconv.FormatFloat(math.Float64frombits(v), 'g', -1, 64))
+						return n
+					}
+					_, n := protowire.ConsumeVarint(b)
+					return n
+				})
+			}
+			return n
+		})
+		series = append(series, strings.Join(fields, ","))
+		return n
+	})
+	return series
+}
+
+type receiver struct {
+	mu       sync.Mutex
+	requests map[string][]string
+}
+
+func (rc *receiver) handler(t *testing.T, status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, contentEncoding, r.Header.Get("Content-Encoding"))
+		assert.Equal(t, contentType, r.Header.Get("Content-Type"))
+		assert.Equal(t, remoteWriteVersion, r.Header.Get("X-Prometheus-Remote-Write-Version"))
+
+		compressed, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		body, err := snappy.Decode(nil, compressed)
+		assert.NoError(t, err)
+
+		rc.mu.Lock()
+		rc.requests[r.URL.Path] = decodeWriteRequest(t, body)
+		rc.mu.Unlock()
+
+		w.WriteHeader(status)
+	})
+}
+
+func TestExporter_Export(t *testing.T) {
+	rc := &receiver{requests: map[string][]string{}}
+	srv := httptest.NewServer(rc.handler(t, http.StatusNoContent))
+	defer srv.Close()
+
+	exporter, err := NewExporter(&appconfig.Config{
+		RemoteWriteInterval: time.Second,
+		RemoteWriteEndpoints: []appconfig.RemoteWriteEndpoint{
+			{URL: srv.URL + "/internal"},
+			{URL: srv.URL + "/tenant-a", Namespaces: []string{"team-a"}},
+			{URL: srv.URL + "/tenant-c", Namespaces: []string{"team-c"}},
+		},
+	}, testSource)
+	require.NoError(t, err)
+
+	require.NoError(t, exporter.Export(context.Background()))
+
+	assert.Equal(t, []string{
+		"UUID=GPU-0,__name__=DCGM_FI_DEV_GPU_TEMP,gpu=0,namespace=team-a,pod=train-0,@42",
+		"UUID=GPU-1,__name__=DCGM_FI_DEV_GPU_TEMP,gpu=1,namespace=team-b,pod=infer-0,@43",
+		"UUID=GPU-2,__name__=DCGM_FI_DEV_GPU_TEMP,gpu=2,@44",
+		"__name__=dcgm_exporter_dra_mapping_duration_seconds_sum,@0.5",
+		"__name__=dcgm_exporter_dra_mapping_duration_seconds_count,@2",
+	}, rc.requests["/internal"], "The full copy should hold every series")
+
+	assert.Equal(t, []string{
+		"UUID=GPU-0,__name__=DCGM_FI_DEV_GPU_TEMP,gpu=0,namespace=team-a,pod=train-0,@42",
+	}, rc.requests["/tenant-a"], "A tenant should only get the series of its namespaces")
+
+	assert.NotContains(t, rc.requests, "/tenant-c", "An endpoint without series shouldn't be pushed to")
+}
+
+func TestExporter_filterOldNamespace(t *testing.T) {
+	exporter, err := NewExporter(&appconfig.Config{
+		UseOldNamespace:      true,
+		RemoteWriteInterval:  time.Second,
+		RemoteWriteEndpoints: []appconfig.RemoteWriteEndpoint{{URL: "http://localhost/api/v1/write"}},
+	}, testSource)
+	require.NoError(t, err)
+
+	series := []timeSeries{
+		{labels: []label{{name: "pod_namespace", value: "team-a"}}},
+		{labels: []label{{name: "namespace", value: "team-a"}}},
+	}
+	assert.Equal(t, series[:1], exporter.filter(series, map[string]struct{}{"team-a": {}}))
+}
+
+func TestExporter_ExportEndpointFailure(t *testing.T) {
+	rc := &receiver{requests: map[string][]string{}}
+	failing := httptest.NewServer(rc.handler(t, http.StatusBadRequest))
+	defer failing.Close()
+	working := httptest.NewServer(rc.handler(t, http.StatusOK))
+	defer working.Close()
+
+	exporter, err := NewExporter(&appconfig.Config{
+		RemoteWriteInterval: time.Second,
+		RemoteWriteEndpoints: []appconfig.RemoteWriteEndpoint{
+			{URL: failing.URL + "/failing"},
+			{URL: working.URL + "/working"},
+		},
+	}, testSource)
+	require.NoError(t, err)
+
+	err = exporter.Export(context.Background())
+	assert.ErrorContains(t, err, "400")
+	assert.Len(t, rc.requests["/working"], 5, "A failing endpoint shouldn't prevent the push to the others")
+}
+
+func TestNewExporter_InvalidConfig(t *testing.T) {
+	_, err := NewExporter(&appconfig.Config{
+		RemoteWriteEndpoints: []appconfig.RemoteWriteEndpoint{{URL: "http://localhost/api/v1/write"}},
+	}, testSource)
+	assert.Error(t, err)
+
+	_, err = NewExporter(&appconfig.Config{RemoteWriteInterval: time.Second}, testSource)
+	assert.Error(t, err)
+}
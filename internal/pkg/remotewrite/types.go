@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package remotewrite
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/pushqueue"
+)
+
+// MetricsSource writes the exported metrics in the Prometheus text format
+type MetricsSource func(w io.Writer) error
+
+// endpoint is a remote write endpoint and the namespaces of the series it receives
+type endpoint struct {
+	url        string
+	namespaces map[string]struct{} // nil receives all the series
+	queue      *pushqueue.Queue[timeSeries]
+}
+
+// Exporter periodically pushes the metrics of a MetricsSource to Prometheus remote write endpoints.
+// Every endpoint receives its own copy of the metrics, restricted to the namespaces of the endpoint.
+type Exporter struct {
+	client         *http.Client
+	source         MetricsSource
+	interval       time.Duration
+	endpoints      []endpoint
+	namespaceLabel string
+}
+
+// label is a label of a remote write time series
+type label struct {
+	name  string
+	value string
+}
+
+// timeSeries is a remote write time series with a single sample
+type timeSeries struct {
+	labels    []label // Sorted by name, including __name__
+	value     float64
+	timestamp int64 // Milliseconds since the epoch
+}
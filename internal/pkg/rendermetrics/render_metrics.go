@@ -19,6 +19,7 @@ package rendermetrics
 import (
 	"fmt"
 	"io"
+	"strings"
 	"sync"
 	"text/template"
 
@@ -44,13 +45,13 @@ var (
 # HELP {{ $counter.FieldName }} {{ $counter.Help }}
 # TYPE {{ $counter.FieldName }} {{ $counter.PromType }}
 {{- range $metric := $metrics }}
-{{ $counter.FieldName }}{gpu="{{ $metric.GPU }}",{{ $metric.UUID }}="{{ $metric.GPUUUID }}",pci_bus_id="{{ $metric.GPUPCIBusID }}",device="{{ $metric.GPUDevice }}",modelName="{{ $metric.GPUModelName }}"{{if $metric.MigProfile}},GPU_I_PROFILE="{{ $metric.MigProfile }}",GPU_I_ID="{{ $metric.GPUInstanceID }}"{{end}}{{if $metric.Hostname }},Hostname="{{ $metric.Hostname }}"{{end}}
+{{ $counter.FieldName }}{gpu="{{ $metric.GPU }}",{{ $metric.UUID }}="{{ $metric.GPUUUID }}",pci_bus_id="{{ $metric.GPUPCIBusID }}",device="{{ $metric.GPUDevice }}",modelName="{{ $metric.GPUModelName }}"{{if $metric.MigProfile}},GPU_I_PROFILE="{{ $metric.MigProfile }}",GPU_I_ID="{{ $metric.GPUInstanceID }}"{{end}}{{if $metric.ComputeInstanceID}},compute_instance_id="{{ $metric.ComputeInstanceID }}"{{end}}{{if $metric.Hostname }},Hostname="{{ $metric.Hostname }}"{{end}}
 
 {{- range $k, $v := $metric.Labels -}}
-	,{{ $k }}="{{ $v }}"
+	,{{ $k }}="{{ escape $v }}"
 {{- end -}}
 {{- range $k, $v := $metric.Attributes -}}
-	,{{ $k }}="{{ $v }}"
+	,{{ $k }}="{{ escape $v }}"
 {{- end -}}
 
 } {{ $metric.Value -}}
@@ -65,10 +66,10 @@ var (
 {{ $counter.FieldName }}{nvlink="{{ $metric.NvLink }}"{{if $metric.NvSwitch}},nvswitch="{{ $metric.NvSwitch }}"{{end}}{{if $metric.GPU}},gpu="{{ $metric.GPU }}"{{end}}{{if $metric.GPUUUID}},gpu_uuid="{{ $metric.GPUUUID }}"{{end}}{{if $metric.GPUPCIBusID}},pci_bus_id="{{ $metric.GPUPCIBusID }}"{{end}}{{if $metric.GPUDevice}},device="{{ $metric.GPUDevice }}"{{end}}{{if $metric.GPUModelName}},model_name="{{ $metric.GPUModelName }}"{{end}}{{if $metric.MigProfile}},GPU_I_PROFILE="{{ $metric.MigProfile }}",GPU_I_ID="{{ $metric.GPUInstanceID }}"{{end}}{{if $metric.Hostname}},hostname="{{ $metric.Hostname }}"{{end}}
 
 {{- range $k, $v := $metric.Labels -}}
-	,{{ $k }}="{{ $v }}"
+	,{{ $k }}="{{ escape $v }}"
 {{- end -}}
 {{- range $k, $v := $metric.Attributes -}}
-	,{{ $k }}="{{ $v }}"
+	,{{ $k }}="{{ escape $v }}"
 {{- end -}}
 
 } {{ $metric.Value -}}
@@ -83,7 +84,7 @@ var (
 {{ $counter.FieldName }}{nvswitch="{{ $metric.NvSwitch }}"{{if $metric.Hostname }},Hostname="{{ $metric.Hostname }}"{{end}}
 
 {{- range $k, $v := $metric.Labels -}}
-	,{{ $k }}="{{ $v }}"
+	,{{ $k }}="{{ escape $v }}"
 {{- end -}}
 } {{ $metric.Value -}}
 {{- end }}
@@ -97,7 +98,7 @@ var (
 {{ $counter.FieldName }}{cpu="{{ $metric.GPU }}"{{if $metric.Hostname }},Hostname="{{ $metric.Hostname }}"{{end}}
 
 {{- range $k, $v := $metric.Labels -}}
-	,{{ $k }}="{{ $v }}"
+	,{{ $k }}="{{ escape $v }}"
 {{- end -}}
 } {{ $metric.Value -}}
 {{- end }}
@@ -111,31 +112,55 @@ var (
 {{ $counter.FieldName }}{cpucore="{{ $metric.GPU }}",cpu="{{ $metric.GPUDevice }}"{{if $metric.Hostname }},Hostname="{{ $metric.Hostname }}"{{end}}
 
 {{- range $k, $v := $metric.Labels -}}
-	,{{ $k }}="{{ $v }}"
+	,{{ $k }}="{{ escape $v }}"
+{{- end -}}
+} {{ $metric.Value -}}
+{{- end }}
+{{ end }}`
+
+	vgpuMetricsFormat = `
+{{- range $counter, $metrics := . -}}
+# HELP {{ $counter.FieldName }} {{ $counter.Help }}
+# TYPE {{ $counter.FieldName }} {{ $counter.PromType }}
+{{- range $metric := $metrics }}
+{{ $counter.FieldName }}{vgpu="{{ $metric.GPU }}"{{if $metric.Hostname }},Hostname="{{ $metric.Hostname }}"{{end}}
+
+{{- range $k, $v := $metric.Labels -}}
+	,{{ $k }}="{{ escape $v }}"
 {{- end -}}
 } {{ $metric.Value -}}
 {{- end }}
 {{ end }}`
 )
 
+// labelValueEscaper escapes the label values as the Prometheus text format requires, the values of the labels
+// copied from the pods, e.g. their annotations, may contain any character
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+var templateFuncs = template.FuncMap{"escape": labelValueEscaper.Replace}
+
 var getGPUMetricsTemplate = sync.OnceValue(func() *template.Template {
-	return template.Must(template.New("gpuMetricsFormat").Parse(gpuMetricsFormat))
+	return template.Must(template.New("gpuMetricsFormat").Funcs(templateFuncs).Parse(gpuMetricsFormat))
 })
 
 var getSwitchMetricsTemplate = sync.OnceValue(func() *template.Template {
-	return template.Must(template.New("switchMetricsFormat").Parse(switchMetricsFormat))
+	return template.Must(template.New("switchMetricsFormat").Funcs(templateFuncs).Parse(switchMetricsFormat))
 })
 
 var getLinkMetricsTemplate = sync.OnceValue(func() *template.Template {
-	return template.Must(template.New("linkMetricsFormat").Parse(linkMetricsFormat))
+	return template.Must(template.New("linkMetricsFormat").Funcs(templateFuncs).Parse(linkMetricsFormat))
 })
 
 var getCPUMetricsTemplate = sync.OnceValue(func() *template.Template {
-	return template.Must(template.New("cpuMetricsFormat").Parse(cpuMetricsFormat))
+	return template.Must(template.New("cpuMetricsFormat").Funcs(templateFuncs).Parse(cpuMetricsFormat))
 })
 
 var getCPUCoreMetricsTemplate = sync.OnceValue(func() *template.Template {
-	return template.Must(template.New("cpuMetricsFormat").Parse(cpuCoreMetricsFormat))
+	return template.Must(template.New("cpuMetricsFormat").Funcs(templateFuncs).Parse(cpuCoreMetricsFormat))
+})
+
+var getVGPUMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("vgpuMetricsFormat").Funcs(templateFuncs).Parse(vgpuMetricsFormat))
 })
 
 func RenderGroup(w io.Writer, group dcgm.Field_Entity_Group, metrics collector.MetricsByCounter) error {
@@ -152,6 +177,8 @@ func RenderGroup(w io.Writer, group dcgm.Field_Entity_Group, metrics collector.M
 		tmpl = getCPUMetricsTemplate()
 	case dcgm.FE_CPU_CORE:
 		tmpl = getCPUCoreMetricsTemplate()
+	case dcgm.FE_VGPU:
+		tmpl = getVGPUMetricsTemplate()
 	default:
 		return fmt.Errorf("unexpected group: %s", group.String())
 	}
@@ -22,7 +22,9 @@ import (
 	"testing"
 
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
@@ -110,6 +112,15 @@ TEST_METRIC{cpu="0",Hostname="testhost"} 42
 			want: `# HELP TEST_METRIC 
 # TYPE TEST_METRIC gauge
 TEST_METRIC{cpucore="0",cpu="testdevice",Hostname="testhost"} 42
+`,
+		},
+		{
+			name:    fmt.Sprintf("Render %s", dcgm.FE_VGPU.String()),
+			group:   dcgm.FE_VGPU,
+			metrics: metrics,
+			want: `# HELP TEST_METRIC 
+# TYPE TEST_METRIC gauge
+TEST_METRIC{vgpu="0",Hostname="testhost"} 42
 `,
 		},
 		{
@@ -132,3 +143,42 @@ TEST_METRIC{cpucore="0",cpu="testdevice",Hostname="testhost"} 42
 		})
 	}
 }
+
+func Test_render_EscapesLabelValues(t *testing.T) {
+	counter := getTestMetric()
+	// The annotations of the pods are copied as they are into the labels
+	annotation := "owner: \"ml-team\"\nsee C:\\docs"
+	metrics := collector.MetricsByCounter{
+		counter: {{
+			GPU:        "0",
+			UUID:       "UUID",
+			GPUUUID:    "GPU-test-uuid-0000-0000-0000-000000000000",
+			Counter:    counter,
+			Value:      "42",
+			Labels:     map[string]string{"scheduler_example_com_note": annotation},
+			Attributes: map[string]string{"pod": "test-pod"},
+		}},
+	}
+
+	for _, group := range []dcgm.Field_Entity_Group{
+		dcgm.FE_GPU, dcgm.FE_SWITCH, dcgm.FE_LINK, dcgm.FE_CPU, dcgm.FE_CPU_CORE, dcgm.FE_VGPU,
+	} {
+		t.Run(group.String(), func(t *testing.T) {
+			w := &bytes.Buffer{}
+			require.NoError(t, RenderGroup(w, group, metrics))
+			assert.Contains(t, w.String(), `,scheduler_example_com_note="owner: \"ml-team\"\nsee C:\\docs"`)
+
+			var parser expfmt.TextParser
+			families, err := parser.TextToMetricFamilies(bytes.NewReader(w.Bytes()))
+			require.NoError(t, err)
+			require.Contains(t, families, counter.FieldName)
+			require.Len(t, families[counter.FieldName].GetMetric(), 1)
+
+			labels := map[string]string{}
+			for _, label := range families[counter.FieldName].GetMetric()[0].GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			assert.Equal(t, annotation, labels["scheduler_example_com_note"])
+		})
+	}
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/prometheus/common/expfmt"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/rendermetrics"
+)
+
+// SwapCanaryRegistry sets the registry built with the candidate counters configuration
+// and returns the previous one for cleanup.
+func (s *MetricsServer) SwapCanaryRegistry(newRegistry *registry.Registry) *registry.Registry {
+	return s.canaryRegistry.Swap(newRegistry)
+}
+
+// Canary gathers the metrics with both the current and the candidate counters configuration
+// and reports the difference, so that a counters change can be checked on a few nodes before a rollout.
+func (s *MetricsServer) Canary(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	candidateRegistry := s.canaryRegistry.Load()
+	if candidateRegistry == nil {
+		http.Error(w, "the candidate registry is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	current, err := renderRegistry(s.GetRegistry())
+	if err != nil {
+		slog.Error("Failed to gather metrics with the current counters", slog.String(logging.ErrorKey, err.Error()))
+		http.Error(w, internalServerError, http.StatusInternalServerError)
+		return
+	}
+
+	candidate, err := renderRegistry(candidateRegistry)
+	if err != nil {
+		slog.Error("Failed to gather metrics with the candidate counters", slog.String(logging.ErrorKey, err.Error()))
+		http.Error(w, internalServerError, http.StatusInternalServerError)
+		return
+	}
+
+	report, err := compareMetrics(current, candidate)
+	if err != nil {
+		slog.Error("Failed to compare the counters configurations", slog.String(logging.ErrorKey, err.Error()))
+		http.Error(w, internalServerError, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(report)
+	if err != nil {
+		slog.Error("Failed to write response.", slog.String(logging.ErrorKey, err.Error()))
+	}
+}
+
+// renderRegistry gathers the metrics of the registry and renders them without the transformations:
+// the transformations keep state between scrapes, and the difference is in the collected fields anyway.
+func renderRegistry(reg *registry.Registry) ([]byte, error) {
+	metricGroups, err := reg.Gather()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for group, metrics := range metricGroups {
+		err = rendermetrics.RenderGroup(&buf, group, metrics)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// compareMetrics compares two payloads in the Prometheus text format
+func compareMetrics(current, candidate []byte) (CanaryReport, error) {
+	currentSeries, err := parseSeries(current)
+	if err != nil {
+		return CanaryReport{}, fmt.Errorf("failed to parse the current metrics: %w", err)
+	}
+
+	candidateSeries, err := parseSeries(candidate)
+	if err != nil {
+		return CanaryReport{}, fmt.Errorf("failed to parse the candidate metrics: %w", err)
+	}
+
+	report := CanaryReport{
+		CurrentSeries:   countSeries(currentSeries),
+		CandidateSeries: countSeries(candidateSeries),
+		CurrentBytes:    len(current),
+		CandidateBytes:  len(candidate),
+		PayloadDelta:    len(candidate) - len(current),
+		AddedFamilies:   []string{},
+		RemovedFamilies: []string{},
+		AddedSeries:     []string{},
+		RemovedSeries:   []string{},
+	}
+
+	for _, family := range slices.Sorted(maps.Keys(candidateSeries)) {
+		if _, exists := currentSeries[family]; !exists {
+			report.AddedFamilies = append(report.AddedFamilies, family)
+		}
+		for _, series := range candidateSeries[family] {
+			if _, found := slices.BinarySearch(currentSeries[family], series); !found {
+				report.AddedSeries = append(report.AddedSeries, series)
+			}
+		}
+	}
+
+	for _, family := range slices.Sorted(maps.Keys(currentSeries)) {
+		if _, exists := candidateSeries[family]; !exists {
+			report.RemovedFamilies = append(report.RemovedFamilies, family)
+		}
+		for _, series := range currentSeries[family] {
+			if _, found := slices.BinarySearch(candidateSeries[family], series); !found {
+				report.RemovedSeries = append(report.RemovedSeries, series)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// parseSeries returns the sorted series identifiers, name and labels, of every metric family
+func parseSeries(payload []byte) (map[string][]string, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]string, len(families))
+	for name, family := range families {
+		series := make([]string, 0, len(family.GetMetric()))
+		for _, metric := range family.GetMetric() {
+			labels := make([]string, 0, len(metric.GetLabel()))
+			for _, label := range metric.GetLabel() {
+				labels = append(labels, fmt.Sprintf("%s=%q", label.GetName(), label.GetValue()))
+			}
+			slices.Sort(labels)
+			series = append(series, name+"{"+strings.Join(labels, ",")+"}")
+		}
+		slices.Sort(series)
+		result[name] = series
+	}
+	return result, nil
+}
+
+func countSeries(series map[string][]string) int {
+	count := 0
+	for _, s := range series {
+		count += len(s)
+	}
+	return count
+}
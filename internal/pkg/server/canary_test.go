@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+)
+
+func TestCompareMetrics(t *testing.T) {
+	current := []byte(`# HELP DCGM_FI_DEV_GPU_TEMP GPU temperature (in C).
+# TYPE DCGM_FI_DEV_GPU_TEMP gauge
+DCGM_FI_DEV_GPU_TEMP{gpu="0",UUID="GPU-0"} 40
+DCGM_FI_DEV_GPU_TEMP{gpu="1",UUID="GPU-1"} 41
+# HELP DCGM_FI_DEV_SM_CLOCK SM clock frequency (in MHz).
+# TYPE DCGM_FI_DEV_SM_CLOCK gauge
+DCGM_FI_DEV_SM_CLOCK{gpu="0",UUID="GPU-0"} 210
+`)
+	candidate := []byte(`# HELP DCGM_FI_DEV_GPU_TEMP GPU temperature (in C).
+# TYPE DCGM_FI_DEV_GPU_TEMP gauge
+DCGM_FI_DEV_GPU_TEMP{UUID="GPU-0",gpu="0"} 42
+# HELP DCGM_FI_DEV_POWER_USAGE Power draw (in W).
+# TYPE DCGM_FI_DEV_POWER_USAGE gauge
+DCGM_FI_DEV_POWER_USAGE{gpu="0",UUID="GPU-0"} 70
+`)
+
+	report, err := compareMetrics(current, candidate)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, report.CurrentSeries)
+	assert.Equal(t, 2, report.CandidateSeries)
+	assert.Equal(t, len(candidate)-len(current), report.PayloadDelta)
+	assert.Equal(t, []string{"DCGM_FI_DEV_POWER_USAGE"}, report.AddedFamilies)
+	assert.Equal(t, []string{"DCGM_FI_DEV_SM_CLOCK"}, report.RemovedFamilies)
+	// Values and the order of the labels don't make a series different
+	assert.Equal(t, []string{`DCGM_FI_DEV_POWER_USAGE{UUID="GPU-0",gpu="0"}`}, report.AddedSeries)
+	assert.Equal(t, []string{
+		`DCGM_FI_DEV_GPU_TEMP{UUID="GPU-1",gpu="1"}`,
+		`DCGM_FI_DEV_SM_CLOCK{UUID="GPU-0",gpu="0"}`,
+	}, report.RemovedSeries)
+}
+
+func TestCanaryWithoutCandidateRegistry(t *testing.T) {
+	s := &MetricsServer{config: &appconfig.Config{CanaryCollectorsFile: "/etc/dcgm-exporter/candidate.csv"}}
+
+	recorder := httptest.NewRecorder()
+	s.Canary(recorder, httptest.NewRequest(http.MethodGet, "/canary", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+}
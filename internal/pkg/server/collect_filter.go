@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+)
+
+// collectParam is the query parameter of /metrics selecting the counter families to render,
+// e.g. /metrics?collect[]=DCGM_FI_DEV_GPU_TEMP&collect[]=DCGM_FI_PROF_*
+const collectParam = "collect[]"
+
+// familyFilter selects the counter families whose name matches one of its shell patterns
+type familyFilter []string
+
+// parseFamilyFilter returns the filter of the collect[] parameters of the query, nil when there is none
+func parseFamilyFilter(query url.Values) (familyFilter, error) {
+	patterns, ok := query[collectParam]
+	if !ok {
+		return nil, nil
+	}
+
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid %s pattern '%s': %w", collectParam, pattern, err)
+		}
+	}
+	return familyFilter(patterns), nil
+}
+
+func (f familyFilter) matches(name string) bool {
+	return slices.ContainsFunc(f, func(pattern string) bool {
+		matched, _ := path.Match(pattern, name)
+		return matched
+	})
+}
+
+// apply removes the families that don't match the filter, under their name after the transformations
+func (f familyFilter) apply(metrics collector.MetricsByCounter) {
+	if f == nil {
+		return
+	}
+
+	for counter := range metrics {
+		if !f.matches(counter.FieldName) {
+			delete(metrics, counter)
+		}
+	}
+}
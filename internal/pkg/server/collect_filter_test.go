@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+func TestParseFamilyFilter(t *testing.T) {
+	filter, err := parseFamilyFilter(url.Values{})
+	require.NoError(t, err)
+	assert.Nil(t, filter, "No collect[] parameter selects all the families")
+
+	query, err := url.ParseQuery("collect[]=DCGM_FI_DEV_GPU_TEMP&collect[]=DCGM_FI_PROF_*")
+	require.NoError(t, err)
+	filter, err = parseFamilyFilter(query)
+	require.NoError(t, err)
+	assert.Equal(t, familyFilter{"DCGM_FI_DEV_GPU_TEMP", "DCGM_FI_PROF_*"}, filter)
+
+	_, err = parseFamilyFilter(url.Values{collectParam: {"DCGM_FI_[PROF"}})
+	assert.Error(t, err)
+}
+
+func TestFamilyFilterApply(t *testing.T) {
+	temp := counters.Counter{FieldName: "DCGM_FI_DEV_GPU_TEMP"}
+	power := counters.Counter{FieldName: "DCGM_FI_DEV_POWER_USAGE"}
+	grActive := counters.Counter{FieldName: "DCGM_FI_PROF_GR_ENGINE_ACTIVE"}
+	newMetrics := func() collector.MetricsByCounter {
+		return collector.MetricsByCounter{
+			temp:     {{Value: "42"}},
+			power:    {{Value: "100"}},
+			grActive: {{Value: "0.5"}},
+		}
+	}
+
+	metrics := newMetrics()
+	familyFilter(nil).apply(metrics)
+	assert.Len(t, metrics, 3)
+
+	metrics = newMetrics()
+	familyFilter{"DCGM_FI_DEV_GPU_TEMP", "DCGM_FI_PROF_*"}.apply(metrics)
+	assert.Equal(t, collector.MetricsByCounter{temp: {{Value: "42"}}, grActive: {{Value: "0.5"}}}, metrics)
+
+	metrics = newMetrics()
+	familyFilter{""}.apply(metrics)
+	assert.Empty(t, metrics)
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"sync"
+	"text/template"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
+)
+
+const collectorLimitsMetricsFormat = `# HELP dcgm_exporter_collect_timeouts_total Number of collections skipped after the collector timeout.
+# TYPE dcgm_exporter_collect_timeouts_total counter
+{{- range . }}
+dcgm_exporter_collect_timeouts_total{entity="{{ .Entity.String }}",collector="{{ .Name }}"} {{ .Timeouts }}
+{{- end }}
+# HELP dcgm_exporter_collect_skipped_total Number of gathers skipping the collector while its circuit was open.
+# TYPE dcgm_exporter_collect_skipped_total counter
+{{- range . }}
+dcgm_exporter_collect_skipped_total{entity="{{ .Entity.String }}",collector="{{ .Name }}"} {{ .Skipped }}
+{{- end }}
+# HELP dcgm_exporter_collector_circuit_open Whether the collector fails repeatedly and is skipped by the next gathers.
+# TYPE dcgm_exporter_collector_circuit_open gauge
+{{- range . }}
+dcgm_exporter_collector_circuit_open{entity="{{ .Entity.String }}",collector="{{ .Name }}"} {{ if .CircuitOpen }}1{{ else }}0{{ end }}
+{{- end }}
+`
+
+var getCollectorLimitsMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("collectorLimitsMetricsFormat").Parse(collectorLimitsMetricsFormat))
+})
+
+// renderCollectorLimitsMetrics writes the timeouts and the circuit breaker state of the collectors, when a
+// collector timeout or the circuit breaker is configured, so that a collector skipped on some nodes is seen.
+func (s *MetricsServer) renderCollectorLimitsMetrics(w io.Writer, reg *registry.Registry) error {
+	if s.config == nil || (s.config.CollectorTimeout <= 0 && s.config.CollectorBreakerThreshold <= 0) {
+		return nil
+	}
+	return getCollectorLimitsMetricsTemplate().Execute(w, reg.CollectorStats())
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockcollectorpkg "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
+)
+
+func TestRenderCollectorLimitsMetrics(t *testing.T) {
+	mockCollector := mockcollectorpkg.NewMockCollector(gomock.NewController(t))
+	mockCollector.EXPECT().GetMetrics().Return(nil, errors.New("boom")).Times(2)
+
+	reg := registry.NewRegistry()
+	tuple := collector.EntityCollectorTuple{}
+	tuple.SetEntity(dcgm.FE_GPU)
+	tuple.SetCollector(mockCollector)
+	tuple.SetName(collector.DCGMCollectorName)
+	reg.Register(tuple)
+	reg.SetCollectorLimits(registry.CollectorLimits{BreakerThreshold: 2, BreakerCycles: 5})
+	for range 2 {
+		_, err := reg.Gather()
+		require.Error(t, err)
+	}
+
+	metricServer := &MetricsServer{config: &appconfig.Config{}}
+	var buf strings.Builder
+	assert.NoError(t, metricServer.renderCollectorLimitsMetrics(&buf, reg))
+	assert.Empty(t, buf.String())
+
+	metricServer.config.CollectorBreakerThreshold = 2
+	assert.NoError(t, metricServer.renderCollectorLimitsMetrics(&buf, reg))
+	assert.Contains(t, buf.String(),
+		fmt.Sprintf("dcgm_exporter_collector_circuit_open{entity=%q,collector=\"DCGM\"} 1\n", dcgm.FE_GPU.String()))
+	assert.Contains(t, buf.String(),
+		fmt.Sprintf("dcgm_exporter_collect_timeouts_total{entity=%q,collector=\"DCGM\"} 0\n", dcgm.FE_GPU.String()))
+}
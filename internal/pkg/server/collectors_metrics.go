@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"sync"
+	"text/template"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
+)
+
+const collectorsMetricsFormat = `# HELP dcgm_exporter_collectors Collectors registered for each entity type.
+# TYPE dcgm_exporter_collectors gauge
+{{- range . }}
+dcgm_exporter_collectors{entity="{{ .Entity.String }}",collector="{{ .Name }}"} 1
+{{- end }}
+`
+
+var getCollectorsMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("collectorsMetricsFormat").Parse(collectorsMetricsFormat))
+})
+
+// renderCollectorsMetrics writes the inventory of the collectors registered with the registry,
+// so that a collector that failed to register on some nodes can be detected.
+func (s *MetricsServer) renderCollectorsMetrics(w io.Writer, reg *registry.Registry) error {
+	if s.config == nil || !s.config.CollectorInventoryMetric {
+		return nil
+	}
+	return getCollectorsMetricsTemplate().Execute(w, reg.Collectors())
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/flate"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	encodingZstd    = "zstd"
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+// supportedEncodings are the content encodings of the metrics responses, the preferred first
+var supportedEncodings = []string{encodingZstd, encodingGzip, encodingDeflate}
+
+// compressor is a writer of a content encoding, reusable for another response after Reset
+type compressor interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// compressorPools keep the compressors across responses: their state is sized for multi-MB payloads, so
+// allocating one per scrape would dominate the allocations of the server.
+var compressorPools = map[string]*sync.Pool{
+	encodingZstd: {New: func() any {
+		// A nil writer and the options of NewWriter can't fail
+		encoder, _ := zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+		return encoder
+	}},
+	encodingGzip: {New: func() any {
+		return gzip.NewWriter(nil)
+	}},
+	encodingDeflate: {New: func() any {
+		writer, _ := flate.NewWriter(nil, flate.DefaultCompression)
+		return writer
+	}},
+}
+
+// negotiateEncoding returns the content encoding of the metrics response to the request, empty when the
+// response isn't compressed. It sets the Vary header, as the response depends on Accept-Encoding.
+func (s *MetricsServer) negotiateEncoding(w http.ResponseWriter, r *http.Request) string {
+	if s.config == nil || !s.config.MetricsCompression || r == nil {
+		return ""
+	}
+
+	w.Header().Add("Vary", "Accept-Encoding")
+	return selectEncoding(r.Header.Get("Accept-Encoding"))
+}
+
+// selectEncoding returns the supported encoding with the highest quality in an Accept-Encoding header,
+// the preferred of supportedEncodings on a tie, or empty when the header accepts none.
+func selectEncoding(acceptEncoding string) string {
+	qualities := map[string]float64{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.ToLower(strings.TrimSpace(key)) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				parsed = 0
+			}
+			quality = parsed
+		}
+		qualities[name] = quality
+	}
+
+	var selected string
+	var selectedQuality float64
+	for _, encoding := range supportedEncodings {
+		quality, listed := qualities[encoding]
+		if !listed {
+			quality = qualities["*"]
+		}
+		if quality > selectedQuality {
+			selected, selectedQuality = encoding, quality
+		}
+	}
+	return selected
+}
+
+// writeEncoded writes the body compressed with a pooled compressor of the encoding
+func writeEncoded(w io.Writer, encoding string, body []byte) error {
+	c := acquireCompressor(encoding, w)
+	defer releaseCompressor(encoding, c)
+
+	if _, err := c.Write(body); err != nil {
+		return err
+	}
+	return c.Close()
+}
+
+// acquireCompressor returns a pooled compressor of the encoding writing to w, to release after Close
+func acquireCompressor(encoding string, w io.Writer) compressor {
+	c := compressorPools[encoding].Get().(compressor)
+	c.Reset(w)
+	return c
+}
+
+func releaseCompressor(encoding string, c compressor) {
+	// Drop the reference to the response before returning the compressor to the pool
+	c.Reset(io.Discard)
+	compressorPools[encoding].Put(c)
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/klauspost/compress/flate"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+)
+
+func TestSelectEncoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		expected       string
+	}{
+		{acceptEncoding: "", expected: ""},
+		{acceptEncoding: "identity", expected: ""},
+		{acceptEncoding: "gzip", expected: encodingGzip},
+		{acceptEncoding: "gzip, deflate, br, zstd", expected: encodingZstd},
+		{acceptEncoding: "GZIP;q=0.5, deflate;q=0.8", expected: encodingDeflate},
+		{acceptEncoding: "zstd;q=0, gzip", expected: encodingGzip},
+		{acceptEncoding: "*", expected: encodingZstd},
+		{acceptEncoding: "*;q=0.1, gzip;q=0.5", expected: encodingGzip},
+		{acceptEncoding: "*, zstd;q=0, gzip;q=0", expected: encodingDeflate},
+		{acceptEncoding: "gzip;q=invalid", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.acceptEncoding, func(t *testing.T) {
+			assert.Equal(t, tt.expected, selectEncoding(tt.acceptEncoding))
+		})
+	}
+}
+
+func TestWriteEncoded(t *testing.T) {
+	body := bytes.Repeat([]byte("DCGM_FI_DEV_GPU_UTIL{gpu=\"0\"} 80\n"), 1000)
+	decoders := map[string]func(r io.Reader) (io.Reader, error){
+		encodingZstd: func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) },
+		encodingGzip: func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		encodingDeflate: func(r io.Reader) (io.Reader, error) {
+			return flate.NewReader(r), nil
+		},
+	}
+
+	for encoding, decode := range decoders {
+		t.Run(encoding, func(t *testing.T) {
+			// The second response reuses the pooled compressor
+			for range 2 {
+				var compressed bytes.Buffer
+				require.NoError(t, writeEncoded(&compressed, encoding, body))
+				assert.Less(t, compressed.Len(), len(body))
+
+				reader, err := decode(&compressed)
+				require.NoError(t, err)
+				decompressed, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				assert.Equal(t, body, decompressed)
+			}
+		})
+	}
+}
+
+func TestMetricsCompression(t *testing.T) {
+	metricServer := &MetricsServer{config: &appconfig.Config{NVMLOnly: true}}
+
+	request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	request.Header.Set("Accept-Encoding", "gzip")
+	recorder := httptest.NewRecorder()
+	metricServer.Metrics(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Empty(t, recorder.Header().Get("Content-Encoding"), "The response should be compressed when enabled only")
+
+	metricServer.config.MetricsCompression = true
+	recorder = httptest.NewRecorder()
+	metricServer.Metrics(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, encodingGzip, recorder.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", recorder.Header().Get("Vary"))
+	reader, err := gzip.NewReader(recorder.Body)
+	require.NoError(t, err)
+	_, err = io.ReadAll(reader)
+	require.NoError(t, err)
+
+	request.Header.Del("Accept-Encoding")
+	recorder = httptest.NewRecorder()
+	metricServer.Metrics(recorder, request)
+	assert.Empty(t, recorder.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", recorder.Header().Get("Vary"))
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"sync"
+	"text/template"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+)
+
+const connectionMetricsFormat = `# HELP dcgm_exporter_connection_up Whether the exporter is connected to DCGM (1 = connected, 0 = reconnecting).
+# TYPE dcgm_exporter_connection_up gauge
+dcgm_exporter_connection_up {{ if . }}1{{ else }}0{{ end }}
+`
+
+var getConnectionMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("connectionMetricsFormat").Parse(connectionMetricsFormat))
+})
+
+// renderConnectionMetrics writes whether the exporter is connected to DCGM. While it reconnects, the
+// registry is empty and the connection metric is the only sign of the outage on /metrics.
+func (s *MetricsServer) renderConnectionMetrics(w io.Writer) error {
+	if s.config == nil || s.config.NVMLOnly {
+		return nil
+	}
+	return getConnectionMetricsTemplate().Execute(w, dcgmprovider.IsConnected())
+}
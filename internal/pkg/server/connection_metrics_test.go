@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mockdcgm "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+)
+
+func TestRenderConnectionMetrics(t *testing.T) {
+	setDCGMClient(t, mockdcgm.NewMockDCGM(gomock.NewController(t)))
+	defer func() {
+		// Drain the report for the other tests
+		select {
+		case <-dcgmprovider.ConnectionLost():
+		default:
+		}
+	}()
+
+	metricServer := &MetricsServer{config: &appconfig.Config{}}
+	var buf strings.Builder
+	assert.NoError(t, metricServer.renderConnectionMetrics(&buf))
+	assert.Contains(t, buf.String(), "dcgm_exporter_connection_up 1\n")
+
+	dcgmprovider.ReportConnectionLost()
+	buf.Reset()
+	assert.NoError(t, metricServer.renderConnectionMetrics(&buf))
+	assert.Contains(t, buf.String(), "dcgm_exporter_connection_up 0\n")
+
+	metricServer.config.NVMLOnly = true
+	buf.Reset()
+	assert.NoError(t, metricServer.renderConnectionMetrics(&buf))
+	assert.Empty(t, buf.String(), "DCGM is not used in the NVML-only mode")
+}
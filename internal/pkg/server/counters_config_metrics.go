@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"sync"
+	"text/template"
+)
+
+const countersConfigMetricsFormat = `# HELP dcgm_exporter_counters_config_valid Whether the last read of the counters configuration succeeded.
+# TYPE dcgm_exporter_counters_config_valid gauge
+dcgm_exporter_counters_config_valid {{ if .Invalid }}0{{ else }}1{{ end }}
+# HELP dcgm_exporter_counters_config_errors_total Number of reloads that failed to read the counters configuration.
+# TYPE dcgm_exporter_counters_config_errors_total counter
+dcgm_exporter_counters_config_errors_total {{ .Errors }}
+`
+
+var getCountersConfigMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("countersConfigMetricsFormat").Parse(countersConfigMetricsFormat))
+})
+
+// renderCountersConfigMetrics writes whether the counters configuration could be read on the last reload.
+// The metrics are written once a reload failed to read the configuration, so that the output of
+// exporters that never hit a broken configuration doesn't change.
+func (s *MetricsServer) renderCountersConfigMetrics(w io.Writer) error {
+	if s.countersConfigErrors.Load() == 0 {
+		return nil
+	}
+	return getCountersConfigMetricsTemplate().Execute(w, struct {
+		Invalid bool
+		Errors  uint64
+	}{
+		Invalid: s.countersConfigInvalid.Load(),
+		Errors:  s.countersConfigErrors.Load(),
+	})
+}
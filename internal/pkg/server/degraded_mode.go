@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"sync"
+	"text/template"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/memguard"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/transformation"
+)
+
+// processLabel is the label of the per-process metrics
+const processLabel = "pid"
+
+const degradedModeMetricsFormat = `# HELP dcgm_exporter_degraded_mode Whether the feature is shed because the RSS of the exporter exceeds the memory watermark.
+# TYPE dcgm_exporter_degraded_mode gauge
+{{- range . }}
+dcgm_exporter_degraded_mode{feature="{{ .Feature }}"} {{ if .Shed }}1{{ else }}0{{ end }}
+{{- end }}
+`
+
+var getDegradedModeMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("degradedModeMetricsFormat").Parse(degradedModeMetricsFormat))
+})
+
+type featureState struct {
+	Feature memguard.Feature
+	Shed    bool
+}
+
+func (s *MetricsServer) renderDegradedModeMetrics(w io.Writer) error {
+	if s.config == nil || s.config.MemoryWatermark == 0 {
+		return nil
+	}
+
+	states := memguard.States()
+	features := make([]featureState, 0, len(memguard.Features))
+	for _, feature := range memguard.Features {
+		features = append(features, featureState{Feature: feature, Shed: states[feature]})
+	}
+	return getDegradedModeMetricsTemplate().Execute(w, features)
+}
+
+// isTransformationShed reports whether the transformation belongs to a feature shed under memory pressure
+func isTransformationShed(t transformation.Transform) bool {
+	_, isPodMapper := t.(*transformation.PodMapper)
+	return isPodMapper && memguard.IsShed(memguard.FeaturePodLabels)
+}
+
+// dropShedMetrics removes the metrics of the features shed under memory pressure before they are transformed
+func dropShedMetrics(metrics collector.MetricsByCounter) {
+	dropDCP := memguard.IsShed(memguard.FeatureDCP)
+	dropProcesses := memguard.IsShed(memguard.FeatureProcessMetrics)
+	if !dropDCP && !dropProcesses {
+		return
+	}
+
+	for counter, metricList := range metrics {
+		if dropDCP && counter.IsProfilingMetric() {
+			delete(metrics, counter)
+			continue
+		}
+
+		if dropProcesses {
+			kept := metricList[:0]
+			for _, m := range metricList {
+				if _, isProcessMetric := m.Labels[processLabel]; !isProcessMetric {
+					kept = append(kept, m)
+				}
+			}
+			if len(kept) == 0 {
+				delete(metrics, counter)
+			} else {
+				metrics[counter] = kept
+			}
+		}
+	}
+}
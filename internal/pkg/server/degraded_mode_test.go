@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+func TestRenderDegradedModeMetrics(t *testing.T) {
+	metricServer := &MetricsServer{config: &appconfig.Config{}}
+
+	var buf strings.Builder
+	assert.NoError(t, metricServer.renderDegradedModeMetrics(&buf))
+	assert.Empty(t, buf.String(), "Nothing is rendered without a memory watermark")
+
+	metricServer.config.MemoryWatermark = 1 << 30
+	assert.NoError(t, metricServer.renderDegradedModeMetrics(&buf))
+	assert.Equal(t, `# HELP dcgm_exporter_degraded_mode Whether the feature is shed because the RSS of the exporter exceeds the memory watermark.
+# TYPE dcgm_exporter_degraded_mode gauge
+dcgm_exporter_degraded_mode{feature="process_metrics"} 0
+dcgm_exporter_degraded_mode{feature="pod_labels"} 0
+dcgm_exporter_degraded_mode{feature="dcp"} 0
+`, buf.String())
+}
+
+func TestDropShedMetrics_NothingShed(t *testing.T) {
+	dcp := counters.Counter{FieldName: "DCGM_FI_PROF_GR_ENGINE_ACTIVE"}
+	process := counters.Counter{FieldName: "DCGM_EXP_PROCESS_SM_UTIL"}
+	metrics := collector.MetricsByCounter{
+		dcp:     {{Value: "0.5"}},
+		process: {{Value: "10", Labels: map[string]string{processLabel: "42"}}},
+	}
+
+	dropShedMetrics(metrics)
+	assert.Len(t, metrics, 2)
+	assert.Len(t, metrics[process], 1)
+}
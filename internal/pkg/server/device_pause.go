@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/gorilla/mux"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/events"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+const deviceUUIDVar = "uuid"
+
+// SetDevicePauseHandler sets the function called after the collection of a GPU was paused or resumed
+func (s *MetricsServer) SetDevicePauseHandler(handler DevicePauseHandler) {
+	s.devicePauseHandler.Store(&handler)
+}
+
+// PausedDevices serves the GPUs whose collection is paused
+func (s *MetricsServer) PausedDevices(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	paused := devicemonitoring.PausedGPUs()
+	statuses := make([]DevicePauseStatus, 0, len(paused))
+	for _, uuid := range slices.Sorted(maps.Keys(paused)) {
+		statuses = append(statuses, DevicePauseStatus{UUID: uuid, Paused: true, Since: paused[uuid]})
+	}
+	s.writeDevicePauseResponse(w, statuses)
+}
+
+// PauseDevice pauses the collection of the GPU of the uuid path variable on POST. Its metrics stop on the
+// next scrape, and its watches are dropped once the registry is rebuilt.
+func (s *MetricsServer) PauseDevice(w http.ResponseWriter, r *http.Request) {
+	s.setDevicePaused(w, r, true)
+}
+
+// ResumeDevice resumes the collection of the GPU of the uuid path variable on POST
+func (s *MetricsServer) ResumeDevice(w http.ResponseWriter, r *http.Request) {
+	s.setDevicePaused(w, r, false)
+}
+
+func (s *MetricsServer) setDevicePaused(w http.ResponseWriter, r *http.Request, pause bool) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	uuid := mux.Vars(r)[deviceUUIDVar]
+	// A GPU gone while paused can still be resumed
+	if !s.isMonitoredGPU(uuid) && !devicemonitoring.IsGPUPaused(uuid) {
+		http.Error(w, "unknown GPU "+uuid, http.StatusNotFound)
+		return
+	}
+
+	var changed bool
+	if pause {
+		changed = devicemonitoring.PauseGPU(uuid)
+	} else {
+		changed = devicemonitoring.ResumeGPU(uuid)
+	}
+
+	if changed {
+		message := "Collection of the GPU resumed"
+		if pause {
+			message = "Collection of the GPU paused"
+		}
+		slog.Info(message, slog.String("gpuUUID", uuid))
+		events.Record(events.Event{
+			Type:     events.TypeDevicePause,
+			Severity: events.SeverityInfo,
+			GPUUUID:  uuid,
+			Message:  message,
+		})
+
+		if handler := s.devicePauseHandler.Load(); handler != nil {
+			go (*handler)()
+		}
+	}
+
+	status := DevicePauseStatus{UUID: uuid, Paused: pause}
+	if pause {
+		status.Since = devicemonitoring.PausedGPUs()[uuid]
+	}
+	s.writeDevicePauseResponse(w, status)
+}
+
+// isMonitoredGPU returns whether uuid is the UUID of a GPU of the current watch lists
+func (s *MetricsServer) isMonitoredGPU(uuid string) bool {
+	if uuid == "" || s.deviceWatchListManager == nil {
+		return false
+	}
+
+	watchList, exists := s.deviceWatchListManager.EntityWatchList(dcgm.FE_GPU)
+	if !exists {
+		return false
+	}
+
+	deviceInfo := watchList.DeviceInfo()
+	for i := uint(0); i < deviceInfo.GPUCount(); i++ {
+		if deviceInfo.GPU(i).DeviceInfo.UUID == uuid {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *MetricsServer) writeDevicePauseResponse(w http.ResponseWriter, response any) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		slog.Error("Failed to write response.", slog.String(logging.ErrorKey, err.Error()))
+	}
+}
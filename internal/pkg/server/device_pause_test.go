@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdeviceinfo "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/deviceinfo"
+	mockdevicewatchlistmanager "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+)
+
+func TestDevicePause(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	gpu := deviceinfo.GPUInfo{}
+	gpu.DeviceInfo.UUID = "GPU-0"
+	mockDeviceInfo := mockdeviceinfo.NewMockProvider(ctrl)
+	mockDeviceInfo.EXPECT().GPUCount().Return(uint(1)).AnyTimes()
+	mockDeviceInfo.EXPECT().GPU(uint(0)).Return(gpu).AnyTimes()
+	watchList := *devicewatchlistmanager.NewWatchList(mockDeviceInfo, []dcgm.Short{42}, nil, deviceWatcher, 1)
+	mockManager := mockdevicewatchlistmanager.NewMockManager(ctrl)
+	mockManager.EXPECT().EntityWatchList(dcgm.FE_GPU).Return(watchList, true).AnyTimes()
+
+	metricServer := &MetricsServer{deviceWatchListManager: mockManager}
+	handled := make(chan struct{}, 2)
+	metricServer.SetDevicePauseHandler(func() { handled <- struct{}{} })
+
+	router := mux.NewRouter()
+	router.HandleFunc("/api/v1/devices/paused", metricServer.PausedDevices)
+	router.HandleFunc("/api/v1/devices/{uuid}/pause", metricServer.PauseDevice)
+	router.HandleFunc("/api/v1/devices/{uuid}/resume", metricServer.ResumeDevice)
+	t.Cleanup(func() { devicemonitoring.ResumeGPU("GPU-0") })
+
+	request := func(method, path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusNotFound, request(http.MethodPost, "/api/v1/devices/GPU-1/pause").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, request(http.MethodGet, "/api/v1/devices/GPU-0/pause").Code)
+
+	recorder := request(http.MethodPost, "/api/v1/devices/GPU-0/pause")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var status DevicePauseStatus
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	assert.Equal(t, "GPU-0", status.UUID)
+	assert.True(t, status.Paused)
+	assert.False(t, status.Since.IsZero())
+	assert.True(t, devicemonitoring.IsGPUPaused("GPU-0"))
+	<-handled
+
+	recorder = request(http.MethodGet, "/api/v1/devices/paused")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var paused []DevicePauseStatus
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &paused))
+	require.Len(t, paused, 1)
+	assert.Equal(t, "GPU-0", paused[0].UUID)
+
+	// Pausing twice doesn't rebuild the watches again
+	require.Equal(t, http.StatusOK, request(http.MethodPost, "/api/v1/devices/GPU-0/pause").Code)
+	assert.Empty(t, handled)
+
+	recorder = request(http.MethodPost, "/api/v1/devices/GPU-0/resume")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	assert.False(t, status.Paused)
+	assert.False(t, devicemonitoring.IsGPUPaused("GPU-0"))
+	<-handled
+}
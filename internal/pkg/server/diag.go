@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"text/template"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/diag"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+const diagMetricsFormat = `# HELP dcgm_exporter_diag_test_passed Whether a DCGM diagnostic test passed on a GPU in the last run (1 = passed, 0 = failed).
+# TYPE dcgm_exporter_diag_test_passed gauge
+{{- range .Results }}{{ if .Ran }}
+dcgm_exporter_diag_test_passed{level="{{ $.Level }}",test="{{ .Test }}",gpu="{{ .GPU }}"} {{ if .Passed }}1{{ else }}0{{ end }}
+{{- end }}{{ end }}
+# HELP dcgm_exporter_diag_last_run_success Whether the last DCGM diagnostics run completed (1 = completed, 0 = failed to run).
+# TYPE dcgm_exporter_diag_last_run_success gauge
+dcgm_exporter_diag_last_run_success{level="{{ .Level }}"} {{ if .Error }}0{{ else }}1{{ end }}
+# HELP dcgm_exporter_diag_last_run_timestamp_seconds Time the last DCGM diagnostics run started.
+# TYPE dcgm_exporter_diag_last_run_timestamp_seconds gauge
+dcgm_exporter_diag_last_run_timestamp_seconds{level="{{ .Level }}"} {{ .Time.Unix }}
+# HELP dcgm_exporter_diag_last_run_duration_seconds Duration of the last DCGM diagnostics run.
+# TYPE dcgm_exporter_diag_last_run_duration_seconds gauge
+dcgm_exporter_diag_last_run_duration_seconds{level="{{ .Level }}"} {{ .Duration.Seconds }}
+`
+
+var getDiagMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("diagMetricsFormat").Parse(diagMetricsFormat))
+})
+
+// SetDiagRunner sets the runner of the DCGM diagnostics reported on /metrics and triggered by /diag
+func (s *MetricsServer) SetDiagRunner(runner *diag.Runner) {
+	s.diagRunner.Store(runner)
+}
+
+// Diag serves the report of the last DCGM diagnostics run on GET, and starts a run on POST.
+// The diagnostics take minutes, the run completes in the background.
+func (s *MetricsServer) Diag(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	runner := s.diagRunner.Load()
+	if runner == nil {
+		http.Error(w, "the diagnostics are not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	status := http.StatusOK
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if !runner.Start() {
+			http.Error(w, "the diagnostics are already running", http.StatusConflict)
+			return
+		}
+		status = http.StatusAccepted
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(DiagStatus{
+		InProgress: runner.InProgress(),
+		Report:     runner.LastReport(),
+	})
+	if err != nil {
+		slog.Error("Failed to write response.", slog.String(logging.ErrorKey, err.Error()))
+	}
+}
+
+// renderDiagMetrics writes the results of the last DCGM diagnostics run, once a run completed
+func (s *MetricsServer) renderDiagMetrics(w io.Writer) error {
+	runner := s.diagRunner.Load()
+	if runner == nil {
+		return nil
+	}
+
+	report := runner.LastReport()
+	if report == nil {
+		return nil
+	}
+
+	return getDiagMetricsTemplate().Execute(w, report)
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdcgm "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/diag"
+)
+
+func TestDiag(t *testing.T) {
+	metricServer := &MetricsServer{}
+
+	t.Run("Diagnostics disabled", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		metricServer.Diag(recorder, httptest.NewRequest(http.MethodPost, "/diag", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+		var buf bytes.Buffer
+		require.NoError(t, metricServer.renderDiagMetrics(&buf))
+		assert.Empty(t, buf.String())
+	})
+
+	mockDCGM := mockdcgm.NewMockDCGM(gomock.NewController(t))
+	setDCGMClient(t, mockDCGM)
+	mockDCGM.EXPECT().GroupAllGPUs().Return(dcgm.GroupHandle{})
+	mockDCGM.EXPECT().RunDiag(gomock.Any(), gomock.Any()).Return(dcgm.DiagResults{Software: []dcgm.DiagResult{
+		{TestName: "software", EntityID: 0, Status: diag.StatusPass},
+		{TestName: "pcie", EntityID: 0, Status: diag.StatusFail},
+		{TestName: "memory", EntityID: 0, Status: "skipped"},
+	}}, nil)
+
+	runner, err := diag.NewRunner(1, 0)
+	require.NoError(t, err)
+	metricServer.SetDiagRunner(runner)
+
+	t.Run("Trigger a run", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		metricServer.Diag(recorder, httptest.NewRequest(http.MethodPost, "/diag", nil))
+		assert.Equal(t, http.StatusAccepted, recorder.Code)
+
+		require.Eventually(t, func() bool { return runner.LastReport() != nil }, time.Second, 10*time.Millisecond)
+
+		recorder = httptest.NewRecorder()
+		metricServer.Diag(recorder, httptest.NewRequest(http.MethodGet, "/diag", nil))
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), `"test":"pcie"`)
+
+		recorder = httptest.NewRecorder()
+		metricServer.Diag(recorder, httptest.NewRequest(http.MethodDelete, "/diag", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+
+	t.Run("Metrics", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, metricServer.renderDiagMetrics(&buf))
+
+		var parser expfmt.TextParser
+		families, err := parser.TextToMetricFamilies(&buf)
+		require.NoError(t, err)
+
+		passed := map[string]float64{}
+		for _, m := range families["dcgm_exporter_diag_test_passed"].GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "test" {
+					passed[label.GetValue()] = m.GetGauge().GetValue()
+				}
+			}
+		}
+		assert.Equal(t, map[string]float64{"software": 1, "pcie": 0}, passed, "Skipped tests are omitted")
+		assert.Equal(t, float64(1), families["dcgm_exporter_diag_last_run_success"].GetMetric()[0].GetGauge().GetValue())
+	})
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+// SetEffectiveConfig records the configuration the exporter runs with after a startup or reload,
+// served by /api/v1/config/effective with the secrets redacted.
+func (s *MetricsServer) SetEffectiveConfig(config *appconfig.Config) {
+	redacted := config.Redacted()
+	s.effectiveConfig.Store(&redacted)
+}
+
+// EffectiveConfig serves the configuration the exporter runs with, i.e. the outcome of the flags,
+// the environment variables and the ConfigMap.
+func (s *MetricsServer) EffectiveConfig(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	config := s.effectiveConfig.Load()
+	if config == nil {
+		if s.config == nil {
+			http.Error(w, "the configuration is not available", http.StatusServiceUnavailable)
+			return
+		}
+		redacted := s.config.Redacted()
+		config = &redacted
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(config)
+	if err != nil {
+		slog.Error("Failed to write response.", slog.String(logging.ErrorKey, err.Error()))
+	}
+}
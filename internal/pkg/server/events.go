@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/events"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+// Events serves the event log, the most recent first. The type and severity query parameters restrict the
+// events to the given comma separated types and severities, and since, an RFC 3339 time, to the later events.
+func (s *MetricsServer) Events(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	match, err := eventFilter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(events.Events(match))
+	if err != nil {
+		slog.Error("Failed to write response.", slog.String(logging.ErrorKey, err.Error()))
+	}
+}
+
+// eventFilter returns the filter of the events described by the query parameters of a request
+func eventFilter(r *http.Request) (func(events.Event) bool, error) {
+	query := r.URL.Query()
+
+	var since time.Time
+	if value := query.Get("since"); value != "" {
+		var err error
+		since, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid since %q, expected an RFC 3339 time", value)
+		}
+	}
+
+	types := queryValues(query.Get("type"))
+	severities := queryValues(query.Get("severity"))
+
+	return func(event events.Event) bool {
+		return (len(types) == 0 || slices.Contains(types, string(event.Type))) &&
+			(len(severities) == 0 || slices.Contains(severities, string(event.Severity))) &&
+			event.Time.After(since)
+	}, nil
+}
+
+// queryValues splits a comma separated query parameter, nil when it is empty
+func queryValues(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// recordReloadEvent adds a reload, and the topology change it made, to the event log
+func recordReloadEvent(record ReloadRecord) {
+	attributes := map[string]string{
+		"reload_id": strconv.FormatUint(record.ID, 10),
+		"trigger":   record.Trigger,
+	}
+
+	if record.Result == ReloadResultFailed {
+		attributes["error"] = record.Error
+		events.Record(events.Event{
+			Type:       events.TypeReload,
+			Severity:   events.SeverityError,
+			Message:    fmt.Sprintf("Reload triggered by %s failed: %s", record.Trigger, record.Error),
+			Attributes: attributes,
+		})
+		return
+	}
+
+	events.Record(events.Event{
+		Type:       events.TypeReload,
+		Severity:   events.SeverityInfo,
+		Message:    fmt.Sprintf("Reload triggered by %s succeeded", record.Trigger),
+		Attributes: attributes,
+	})
+
+	if len(record.TopologyDelta) == 0 {
+		return
+	}
+
+	// The reload event keeps its own attributes
+	attributes = maps.Clone(attributes)
+	changes := make([]string, 0, len(record.TopologyDelta))
+	for _, entityType := range slices.Sorted(maps.Keys(record.TopologyDelta)) {
+		changes = append(changes, fmt.Sprintf("%s %+d", entityType, record.TopologyDelta[entityType]))
+		attributes[entityType] = strconv.Itoa(record.TopologyDelta[entityType])
+	}
+	events.Record(events.Event{
+		Type:       events.TypeTopologyChange,
+		Severity:   events.SeverityWarning,
+		Message:    "Monitored entities changed: " + strings.Join(changes, ", "),
+		Attributes: attributes,
+	})
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/events"
+)
+
+func TestEvents(t *testing.T) {
+	empty := func() {
+		events.SetCapacity(0)
+		events.SetCapacity(events.DefaultCapacity)
+	}
+	empty()
+	t.Cleanup(empty)
+
+	ctrl := gomock.NewController(t)
+	metricServer := &MetricsServer{deviceWatchListManager: cpuWatchListManager(ctrl, 2)}
+
+	getEvents := func(query string) (int, []events.Event) {
+		recorder := httptest.NewRecorder()
+		metricServer.Events(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/events"+query, nil))
+		if recorder.Code != http.StatusOK {
+			return recorder.Code, nil
+		}
+
+		var recorded []events.Event
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &recorded))
+		return recorder.Code, recorded
+	}
+
+	// No event yet
+	_, recorded := getEvents("")
+	assert.Empty(t, recorded)
+
+	events.Record(events.Event{
+		Time:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Type:     events.TypeXID,
+		Severity: events.SeverityError,
+		GPU:      "0",
+		Message:  "XID 79",
+	})
+	start := time.Now()
+	metricServer.RecordReload(1, "sighup", start, cpuWatchListManager(ctrl, 2), nil)
+	metricServer.RecordReload(2, "gpu_topology_change", start, nil, errors.New("no GPU found"))
+	metricServer.RecordReload(3, "gpu_topology_change", start, cpuWatchListManager(ctrl, 1), nil)
+
+	_, recorded = getEvents("")
+	require.Len(t, recorded, 5)
+
+	// The most recent first
+	assert.Equal(t, events.TypeTopologyChange, recorded[0].Type)
+	assert.Equal(t, events.SeverityWarning, recorded[0].Severity)
+	assert.Equal(t, "-1", recorded[0].Attributes[dcgm.FE_CPU.String()])
+	assert.Equal(t, events.TypeReload, recorded[1].Type)
+	assert.Equal(t, "3", recorded[1].Attributes["reload_id"])
+	assert.NotContains(t, recorded[1].Attributes, dcgm.FE_CPU.String())
+	assert.Equal(t, events.SeverityError, recorded[2].Severity)
+	assert.Equal(t, "no GPU found", recorded[2].Attributes["error"])
+	assert.Equal(t, events.TypeXID, recorded[4].Type)
+
+	_, recorded = getEvents("?type=reload,xid&severity=error")
+	require.Len(t, recorded, 2)
+	assert.Equal(t, events.TypeReload, recorded[0].Type)
+	assert.Equal(t, events.TypeXID, recorded[1].Type)
+
+	_, recorded = getEvents("?since=2024-06-01T00:00:00Z&type=xid")
+	assert.Empty(t, recorded)
+
+	code, _ := getEvents("?since=yesterday")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
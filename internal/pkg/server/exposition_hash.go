@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+const expositionHashMetricsFormat = `# HELP dcgm_exporter_exposition_hash Hash of the families, label names and number of series exported on the last scrape, without the values.
+# TYPE dcgm_exporter_exposition_hash gauge
+dcgm_exporter_exposition_hash {{ . }}
+`
+
+var getExpositionHashMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("expositionHashMetricsFormat").Parse(expositionHashMetricsFormat))
+})
+
+// expositionHash hashes the shape of the metrics written to it in the Prometheus text format: the family
+// names, and the label names and number of series of every family. The label and sample values are left out,
+// so that the hash changes when a family, a label or a device appears or disappears, but not with the
+// readings or the pods running on the GPUs.
+type expositionHash struct {
+	partial []byte                    // last line, until its newline is written
+	series  map[string]map[string]int // family -> sorted label names -> number of series
+}
+
+func newExpositionHash() *expositionHash {
+	return &expositionHash{series: map[string]map[string]int{}}
+}
+
+func (h *expositionHash) Write(p []byte) (int, error) {
+	data := append(h.partial, p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		h.addLine(string(data[:i]))
+		data = data[i+1:]
+	}
+	h.partial = slices.Clone(data)
+
+	return len(p), nil
+}
+
+func (h *expositionHash) addLine(line string) {
+	family, labelNames, ok := seriesSignature(line)
+	if !ok {
+		return
+	}
+	if h.series[family] == nil {
+		h.series[family] = map[string]int{}
+	}
+	h.series[family][labelNames]++
+}
+
+// Sum returns the hash of the series written so far. A 32-bit hash is exactly represented by the float64
+// value of a gauge.
+func (h *expositionHash) Sum() uint32 {
+	if len(h.partial) > 0 {
+		h.addLine(string(h.partial))
+		h.partial = nil
+	}
+
+	sum := fnv.New32a()
+	for _, family := range slices.Sorted(maps.Keys(h.series)) {
+		for _, labelNames := range slices.Sorted(maps.Keys(h.series[family])) {
+			_, _ = fmt.Fprintf(sum, "%s{%s} %d\n", family, labelNames, h.series[family][labelNames])
+		}
+	}
+	return sum.Sum32()
+}
+
+// seriesSignature returns the metric name and the sorted label names of a sample line in the Prometheus
+// text format, or false for the comments and the lines that can't be parsed.
+func seriesSignature(line string) (string, string, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || line[0] == '#' {
+		return "", "", false
+	}
+
+	i := strings.IndexAny(line, "{ ")
+	if i <= 0 {
+		return "", "", false
+	}
+	name := line[:i]
+	if line[i] == ' ' {
+		return name, "", true
+	}
+
+	var labelNames []string
+	pos := i + 1
+	for {
+		for pos < len(line) && (line[pos] == ',' || line[pos] == ' ') {
+			pos++
+		}
+		if pos >= len(line) {
+			return "", "", false
+		}
+		if line[pos] == '}' {
+			break
+		}
+
+		eq := strings.IndexByte(line[pos:], '=')
+		if eq < 0 || pos+eq+1 >= len(line) || line[pos+eq+1] != '"' {
+			return "", "", false
+		}
+		labelNames = append(labelNames, strings.TrimSpace(line[pos:pos+eq]))
+
+		// Skip the quoted value, with its escaped quotes
+		pos += eq + 2
+		for pos < len(line) && line[pos] != '"' {
+			if line[pos] == '\\' {
+				pos++
+			}
+			pos++
+		}
+		if pos >= len(line) {
+			return "", "", false
+		}
+		pos++
+	}
+
+	slices.Sort(labelNames)
+	return name, strings.Join(labelNames, ","), true
+}
+
+// renderExpositionHashMetrics writes the hash of the metrics rendered on this scrape, so that an unexpected
+// change of the exported data on some nodes can be alerted on. hash is nil when the metric is disabled.
+func (s *MetricsServer) renderExpositionHashMetrics(w io.Writer, hash *expositionHash) error {
+	if hash == nil {
+		return nil
+	}
+	return getExpositionHashMetricsTemplate().Execute(w, hash.Sum())
+}
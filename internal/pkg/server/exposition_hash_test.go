@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeriesSignature(t *testing.T) {
+	tests := []struct {
+		line       string
+		family     string
+		labelNames string
+		ok         bool
+	}{
+		{line: "# HELP DCGM_FI_DEV_GPU_TEMP GPU temperature (in C).", ok: false},
+		{line: "", ok: false},
+		{line: "up 1", family: "up", ok: true},
+		{
+			line:       `DCGM_FI_DEV_GPU_TEMP{gpu="0",UUID="GPU-1",Hostname="node"} 42`,
+			family:     "DCGM_FI_DEV_GPU_TEMP",
+			labelNames: "Hostname,UUID,gpu",
+			ok:         true,
+		},
+		{
+			line:       `DCGM_FI_DEV_XID_ERRORS{gpu="0",err_msg="a \"quoted\", message",pod="p"} 79`,
+			family:     "DCGM_FI_DEV_XID_ERRORS",
+			labelNames: "err_msg,gpu,pod",
+			ok:         true,
+		},
+		{line: `DCGM_FI_DEV_GPU_TEMP{gpu="0" 42`, ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			family, labelNames, ok := seriesSignature(tt.line)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.family, family)
+			assert.Equal(t, tt.labelNames, labelNames)
+		})
+	}
+}
+
+func TestExpositionHash(t *testing.T) {
+	sum := func(exposition string, chunk int) uint32 {
+		hash := newExpositionHash()
+		for len(exposition) > 0 {
+			n := min(chunk, len(exposition))
+			_, err := io.WriteString(hash, exposition[:n])
+			require.NoError(t, err)
+			exposition = exposition[n:]
+		}
+		return hash.Sum()
+	}
+
+	exposition := strings.Join([]string{
+		"# HELP DCGM_FI_DEV_GPU_TEMP GPU temperature (in C).",
+		"# TYPE DCGM_FI_DEV_GPU_TEMP gauge",
+		`DCGM_FI_DEV_GPU_TEMP{gpu="0",pod="a"} 42`,
+		`DCGM_FI_DEV_GPU_TEMP{gpu="1",pod="b"} 43`,
+		"",
+	}, "\n")
+	reference := sum(exposition, len(exposition))
+
+	assert.Equal(t, reference, sum(exposition, 7), "The lines split across writes should be hashed once")
+	assert.Equal(t, reference, sum(strings.NewReplacer("42", "50", `pod="a"`, `pod="c"`).Replace(exposition), 7),
+		"The values shouldn't change the hash")
+	assert.NotEqual(t, reference, sum(exposition+`DCGM_FI_DEV_GPU_TEMP{gpu="2",pod="c"} 44`, 7),
+		"A new series should change the hash")
+	assert.NotEqual(t, reference, sum(strings.ReplaceAll(exposition, ",pod=", ",namespace="), 7),
+		"A renamed label should change the hash")
+}
+
+func TestRenderExpositionHashMetrics(t *testing.T) {
+	metricServer := &MetricsServer{}
+
+	var buf strings.Builder
+	assert.NoError(t, metricServer.renderExpositionHashMetrics(&buf, nil))
+	assert.Empty(t, buf.String(), "Nothing is rendered when the metric is disabled")
+
+	assert.NoError(t, metricServer.renderExpositionHashMetrics(&buf, newExpositionHash()))
+	assert.Contains(t, buf.String(), "# TYPE dcgm_exporter_exposition_hash gauge\n")
+	assert.Contains(t, buf.String(), "dcgm_exporter_exposition_hash 2166136261\n")
+}
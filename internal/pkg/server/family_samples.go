@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"sync"
+	"text/template"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+)
+
+const familySamplesMetricsFormat = `# HELP dcgm_exporter_samples_per_family Number of samples of the family on the last gather of the collectors.
+# TYPE dcgm_exporter_samples_per_family gauge
+{{- range $family, $count := . }}
+dcgm_exporter_samples_per_family{family="{{ $family }}"} {{ $count }}
+{{- end }}
+`
+
+var getFamilySamplesMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("familySamplesMetricsFormat").Parse(familySamplesMetricsFormat))
+})
+
+// newFamilySamples returns the map the samples of a gather are counted in, nil when they are not counted:
+// the metric is disabled, or the gather is filtered and doesn't have all the families.
+func (s *MetricsServer) newFamilySamples(filter familyFilter) map[string]int {
+	if filter != nil || s.config == nil || !s.config.FamilySamplesMetric {
+		return nil
+	}
+	return map[string]int{}
+}
+
+// countFamilySamples adds the samples of the metrics to samples, unless it is nil
+func countFamilySamples(samples map[string]int, metrics collector.MetricsByCounter) {
+	if samples == nil {
+		return
+	}
+	for counter, values := range metrics {
+		samples[counter.FieldName] += len(values)
+	}
+}
+
+// setFamilySamples replaces the samples of the last gather, unless samples is nil
+func (s *MetricsServer) setFamilySamples(samples map[string]int) {
+	if samples == nil {
+		return
+	}
+	s.familySamples.Lock()
+	defer s.familySamples.Unlock()
+	s.familySamples.counts = samples
+}
+
+// renderFamilySamplesMetrics writes the number of samples of every family on the last gather, so that a family
+// losing samples, e.g. when MIG instances disappear or a collector partially fails, can be alerted on.
+func (s *MetricsServer) renderFamilySamplesMetrics(w io.Writer) error {
+	if s.config == nil || !s.config.FamilySamplesMetric {
+		return nil
+	}
+
+	s.familySamples.Lock()
+	defer s.familySamples.Unlock()
+	if s.familySamples.counts == nil {
+		return nil
+	}
+	return getFamilySamplesMetricsTemplate().Execute(w, s.familySamples.counts)
+}
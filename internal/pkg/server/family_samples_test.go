@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockcollectorpkg "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/collector"
+	mockdeviceinfo "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/deviceinfo"
+	mockdevicewatchlistmanager "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
+)
+
+func TestFamilySamplesMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	// The GPU 1 disappears after the first gather
+	first := getMetricsByCounterWithTestMetric()
+	counter := getTestMetric()
+	first[counter] = append(first[counter], collector.Metric{
+		GPU: "1", UUID: "UUID", Counter: counter, Value: "7", Attributes: map[string]string{},
+	})
+	mockCollector := mockcollectorpkg.NewMockCollector(ctrl)
+	gomock.InOrder(
+		mockCollector.EXPECT().GetMetrics().Return(first, nil),
+		mockCollector.EXPECT().GetMetrics().Return(getMetricsByCounterWithTestMetric(), nil).AnyTimes(),
+	)
+
+	reg := registry.NewRegistry()
+	entityCollectorTuple := collector.EntityCollectorTuple{}
+	entityCollectorTuple.SetEntity(dcgm.FE_GPU)
+	entityCollectorTuple.SetCollector(mockCollector)
+	reg.Register(entityCollectorTuple)
+
+	mockDeviceInfo := mockdeviceinfo.NewMockProvider(ctrl)
+	mockDeviceInfo.EXPECT().GPUCount().Return(uint(2)).AnyTimes()
+	watchList := *devicewatchlistmanager.NewWatchList(mockDeviceInfo, []dcgm.Short{42}, nil, deviceWatcher, 1)
+	mockManager := mockdevicewatchlistmanager.NewMockManager(ctrl)
+	mockManager.EXPECT().EntityWatchList(dcgm.FE_GPU).Return(watchList, true).AnyTimes()
+
+	metricServer := &MetricsServer{
+		config:                 &appconfig.Config{NVMLOnly: true},
+		deviceWatchListManager: mockManager,
+	}
+	metricServer.registry.Store(reg)
+
+	metricServer.config.FamilySamplesMetric = true
+	var buf strings.Builder
+	require.NoError(t, metricServer.renderFamilySamplesMetrics(&buf))
+	assert.Empty(t, buf.String(), "Nothing is rendered until the first gather")
+
+	buf.Reset()
+	require.NoError(t, metricServer.WriteMetrics(&buf))
+	assert.Contains(t, buf.String(), "# TYPE dcgm_exporter_samples_per_family gauge\n")
+	assert.Contains(t, buf.String(), `dcgm_exporter_samples_per_family{family="TEST_METRIC"} 2`+"\n")
+
+	buf.Reset()
+	require.NoError(t, metricServer.WriteMetrics(&buf))
+	assert.Contains(t, buf.String(), `dcgm_exporter_samples_per_family{family="TEST_METRIC"} 1`+"\n")
+
+	// A filtered scrape doesn't have all the families, it doesn't replace the samples of the last gather
+	metricServer.setFamilySamples(map[string]int{"TEST_METRIC": 5})
+	filter, err := parseFamilyFilter(map[string][]string{"collect[]": {"OTHER_METRIC"}})
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, metricServer.writeMetrics(t.Context(), &buf, filter))
+	buf.Reset()
+	require.NoError(t, metricServer.renderFamilySamplesMetrics(&buf))
+	assert.Contains(t, buf.String(), `dcgm_exporter_samples_per_family{family="TEST_METRIC"} 5`+"\n")
+
+	metricServer.config.FamilySamplesMetric = false
+	buf.Reset()
+	require.NoError(t, metricServer.WriteMetrics(&buf))
+	assert.NotContains(t, buf.String(), "dcgm_exporter_samples_per_family", "The metric is disabled")
+}
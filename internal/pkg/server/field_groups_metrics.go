@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"sync"
+	"text/template"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+)
+
+const fieldGroupsMetricsFormat = `# HELP dcgm_exporter_dcgm_field_groups Number of DCGM field groups created by the exporter.
+# TYPE dcgm_exporter_dcgm_field_groups gauge
+dcgm_exporter_dcgm_field_groups {{ .Stats.Active }}
+# HELP dcgm_exporter_dcgm_field_groups_limit Number of field groups supported by the DCGM hostengine.
+# TYPE dcgm_exporter_dcgm_field_groups_limit gauge
+dcgm_exporter_dcgm_field_groups_limit {{ .Limit }}
+# HELP dcgm_exporter_dcgm_field_groups_near_limit Whether the DCGM field groups approach the hostengine limit (1 = at least 80% in use).
+# TYPE dcgm_exporter_dcgm_field_groups_near_limit gauge
+dcgm_exporter_dcgm_field_groups_near_limit {{ if .Stats.NearLimit }}1{{ else }}0{{ end }}
+# HELP dcgm_exporter_dcgm_field_groups_created_total Number of DCGM field groups created.
+# TYPE dcgm_exporter_dcgm_field_groups_created_total counter
+dcgm_exporter_dcgm_field_groups_created_total {{ .Stats.Created }}
+# HELP dcgm_exporter_dcgm_field_groups_destroyed_total Number of DCGM field groups destroyed.
+# TYPE dcgm_exporter_dcgm_field_groups_destroyed_total counter
+dcgm_exporter_dcgm_field_groups_destroyed_total {{ .Stats.Destroyed }}
+# HELP dcgm_exporter_dcgm_field_groups_reused_total Number of field group creations served by an existing field group with the same fields.
+# TYPE dcgm_exporter_dcgm_field_groups_reused_total counter
+dcgm_exporter_dcgm_field_groups_reused_total {{ .Stats.Reused }}
+`
+
+var getFieldGroupsMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("fieldGroupsMetricsFormat").Parse(fieldGroupsMetricsFormat))
+})
+
+// renderFieldGroupsMetrics writes the usage of the DCGM field groups, once the exporter created one
+func (s *MetricsServer) renderFieldGroupsMetrics(w io.Writer) error {
+	stats := dcgmprovider.GetFieldGroupStats()
+	if stats.Created == 0 {
+		return nil
+	}
+
+	return getFieldGroupsMetricsTemplate().Execute(w, struct {
+		Stats dcgmprovider.FieldGroupStats
+		Limit int
+	}{
+		Stats: stats,
+		Limit: dcgmprovider.MaxFieldGroups,
+	})
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"maps"
+	"slices"
+	"sync"
+	"text/template"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/transformation"
+)
+
+const fieldInfoMetricsFormat = `# HELP dcgm_exporter_field_info DCGM field ID of the exported families.
+# TYPE dcgm_exporter_field_info gauge
+{{- range . }}
+dcgm_exporter_field_info{field_name="{{ .Name }}",dcgm_field_id="{{ .ID }}"} 1
+{{- end }}
+`
+
+var getFieldInfoMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("fieldInfoMetricsFormat").Parse(fieldInfoMetricsFormat))
+})
+
+// renderFieldInfoMetrics writes the dcgm_exporter_field_info gauge when the DCGM field IDs are exported by the
+// gauge rather than added to the metrics
+func (s *MetricsServer) renderFieldInfoMetrics(w io.Writer) error {
+	for _, t := range s.transformations {
+		fieldIDLabeler, ok := t.(*transformation.FieldIDLabeler)
+		if !ok {
+			continue
+		}
+
+		fields, enabled := fieldIDLabeler.FieldInfo()
+		if !enabled || len(fields) == 0 {
+			return nil
+		}
+
+		type field struct {
+			Name string
+			ID   int
+		}
+		sorted := make([]field, 0, len(fields))
+		for _, name := range slices.Sorted(maps.Keys(fields)) {
+			sorted = append(sorted, field{Name: name, ID: int(fields[name])})
+		}
+		return getFieldInfoMetricsTemplate().Execute(w, sorted)
+	}
+	return nil
+}
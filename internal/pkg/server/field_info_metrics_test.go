@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/transformation"
+)
+
+func TestRenderFieldInfoMetrics(t *testing.T) {
+	gpuTemp := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_GPU_TEMP, FieldName: "DCGM_FI_DEV_GPU_TEMP"}
+	powerUsage := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_POWER_USAGE, FieldName: "DCGM_FI_DEV_POWER_USAGE"}
+	metrics := collector.MetricsByCounter{
+		gpuTemp:    {{Counter: gpuTemp, GPU: "0", Value: "40"}},
+		powerUsage: {{Counter: powerUsage, GPU: "0", Value: "300"}},
+	}
+
+	var buf strings.Builder
+	labeler := transformation.NewFieldIDLabeler(appconfig.FieldIDModeLabel)
+	require.NoError(t, labeler.Process(metrics, nil))
+	metricServer := &MetricsServer{transformations: []transformation.Transform{labeler}}
+	assert.NoError(t, metricServer.renderFieldInfoMetrics(&buf))
+	assert.Empty(t, buf.String(), "Nothing is rendered when the field IDs are added to the metrics")
+
+	labeler = transformation.NewFieldIDLabeler(appconfig.FieldIDModeInfo)
+	metricServer = &MetricsServer{transformations: []transformation.Transform{labeler}}
+	assert.NoError(t, metricServer.renderFieldInfoMetrics(&buf))
+	assert.Empty(t, buf.String(), "Nothing is rendered before the first collection")
+
+	require.NoError(t, labeler.Process(metrics, nil))
+	assert.NoError(t, metricServer.renderFieldInfoMetrics(&buf))
+	assert.Equal(t, `# HELP dcgm_exporter_field_info DCGM field ID of the exported families.
+# TYPE dcgm_exporter_field_info gauge
+dcgm_exporter_field_info{field_name="DCGM_FI_DEV_GPU_TEMP",dcgm_field_id="150"} 1
+dcgm_exporter_field_info{field_name="DCGM_FI_DEV_POWER_USAGE",dcgm_field_id="155"} 1
+`, buf.String())
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+)
+
+// listen opens the listener for the address restricted to the IP family.
+// For appconfig.IPFamilyDual it binds an IPv4 and an IPv6 socket on the same port,
+// so a host without one of the families fails at startup instead of being silently unreachable.
+func listen(address string, family appconfig.IPFamily) (net.Listener, error) {
+	switch family {
+	case appconfig.IPFamilyIPv4:
+		return net.Listen("tcp4", address)
+	case appconfig.IPFamilyIPv6:
+		return net.Listen("tcp6", address)
+	case appconfig.IPFamilyDual:
+		return listenDualStack(address)
+	default:
+		return net.Listen("tcp", address)
+	}
+}
+
+func listenDualStack(address string) (net.Listener, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if host != "" && host != "0.0.0.0" && host != "::" {
+		return nil, fmt.Errorf("dual-stack listening requires a wildcard address, got '%s'", address)
+	}
+
+	ipv4Listener, err := net.Listen("tcp4", net.JoinHostPort("0.0.0.0", port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on IPv4; err: %w", err)
+	}
+
+	// Use the port of the IPv4 listener, in case the port was chosen by the system
+	ipv4Port := strconv.Itoa(ipv4Listener.Addr().(*net.TCPAddr).Port)
+	ipv6Listener, err := net.Listen("tcp6", net.JoinHostPort("::", ipv4Port))
+	if err != nil {
+		_ = ipv4Listener.Close()
+		return nil, fmt.Errorf("failed to listen on IPv6; err: %w", err)
+	}
+
+	return newMultiListener(ipv4Listener, ipv6Listener), nil
+}
+
+type acceptResult struct {
+	conn net.Conn
+	err  error
+}
+
+// multiListener accepts connections from several listeners.
+type multiListener struct {
+	listeners []net.Listener
+	results   chan acceptResult
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func newMultiListener(listeners ...net.Listener) *multiListener {
+	ml := &multiListener{
+		listeners: listeners,
+		results:   make(chan acceptResult),
+		done:      make(chan struct{}),
+	}
+
+	for _, l := range listeners {
+		ml.wg.Add(1)
+		go ml.acceptLoop(l)
+	}
+
+	return ml
+}
+
+func (ml *multiListener) acceptLoop(l net.Listener) {
+	defer ml.wg.Done()
+	for {
+		conn, err := l.Accept()
+		select {
+		case ml.results <- acceptResult{conn: conn, err: err}:
+		case <-ml.done:
+			if conn != nil {
+				_ = conn.Close()
+			}
+			return
+		}
+	}
+}
+
+func (ml *multiListener) Accept() (net.Conn, error) {
+	select {
+	case res := <-ml.results:
+		return res.conn, res.err
+	case <-ml.done:
+		return nil, net.ErrClosed
+	}
+}
+
+func (ml *multiListener) Close() error {
+	var err error
+	ml.closeOnce.Do(func() {
+		close(ml.done)
+		for _, l := range ml.listeners {
+			if closeErr := l.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}
+		ml.wg.Wait()
+	})
+	return err
+}
+
+// Addr returns the address of the first listener.
+func (ml *multiListener) Addr() net.Addr {
+	return ml.listeners[0].Addr()
+}
+
+// Addrs returns the addresses of all listeners.
+func (ml *multiListener) Addrs() []net.Addr {
+	addrs := make([]net.Addr, len(ml.listeners))
+	for i, l := range ml.listeners {
+		addrs[i] = l.Addr()
+	}
+	return addrs
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+)
+
+func ipv6Available(t *testing.T) bool {
+	t.Helper()
+	l, err := net.Listen("tcp6", "[::1]:0")
+	if err != nil {
+		return false
+	}
+	_ = l.Close()
+	return true
+}
+
+func TestListen(t *testing.T) {
+	t.Run("IPv4 only", func(t *testing.T) {
+		l, err := listen("127.0.0.1:0", appconfig.IPFamilyIPv4)
+		require.NoError(t, err)
+		defer l.Close()
+		assert.NotNil(t, l.Addr().(*net.TCPAddr).IP.To4())
+	})
+
+	t.Run("IPv4 only rejects an IPv6 address", func(t *testing.T) {
+		_, err := listen("[::1]:0", appconfig.IPFamilyIPv4)
+		assert.Error(t, err)
+	})
+
+	t.Run("IPv6 only", func(t *testing.T) {
+		if !ipv6Available(t) {
+			t.Skip("IPv6 is not available")
+		}
+		l, err := listen("[::1]:0", appconfig.IPFamilyIPv6)
+		require.NoError(t, err)
+		defer l.Close()
+		assert.Nil(t, l.Addr().(*net.TCPAddr).IP.To4())
+	})
+
+	t.Run("Dual-stack requires a wildcard address", func(t *testing.T) {
+		_, err := listen("127.0.0.1:0", appconfig.IPFamilyDual)
+		assert.ErrorContains(t, err, "wildcard")
+	})
+}
+
+func TestListenDualStackAcceptsBothFamilies(t *testing.T) {
+	if !ipv6Available(t) {
+		t.Skip("IPv6 is not available")
+	}
+
+	l, err := listen(":0", appconfig.IPFamilyDual)
+	require.NoError(t, err)
+
+	ml, ok := l.(*multiListener)
+	require.True(t, ok)
+	require.Len(t, ml.Addrs(), 2)
+
+	port := strconv.Itoa(ml.Addr().(*net.TCPAddr).Port)
+	assert.Equal(t, port, strconv.Itoa(ml.Addrs()[1].(*net.TCPAddr).Port), "Both families should share the port")
+
+	for _, address := range []string{net.JoinHostPort("127.0.0.1", port), net.JoinHostPort("::1", port)} {
+		client, err := net.Dial("tcp", address)
+		require.NoError(t, err, address)
+
+		conn, err := l.Accept()
+		require.NoError(t, err, address)
+
+		_ = conn.Close()
+		_ = client.Close()
+	}
+
+	require.NoError(t, l.Close())
+
+	_, err = l.Accept()
+	assert.ErrorIs(t, err, net.ErrClosed)
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/events"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/transformation"
+)
+
+// defaultMaintenanceDuration is the duration of a maintenance window started without the duration parameter
+const defaultMaintenanceDuration = time.Hour
+
+const maintenanceMetricsFormat = `# HELP dcgm_exporter_maintenance Whether a maintenance window of the node is active.
+# TYPE dcgm_exporter_maintenance gauge
+dcgm_exporter_maintenance {{ if .Active }}1{{ else }}0{{ end }}
+{{- if .Active }}
+# HELP dcgm_exporter_maintenance_end_timestamp_seconds Time the current maintenance window ends.
+# TYPE dcgm_exporter_maintenance_end_timestamp_seconds gauge
+dcgm_exporter_maintenance_end_timestamp_seconds {{ .End.Unix }}
+{{- end }}
+`
+
+var getMaintenanceMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("maintenanceMetricsFormat").Parse(maintenanceMetricsFormat))
+})
+
+// Maintenance serves the maintenance window of the node on GET, starts or extends one on POST, for the
+// duration query parameter, and ends it on DELETE. During the window, the metrics are labeled with
+// maintenance="true" and the suppressed families are dropped.
+func (s *MetricsServer) Maintenance(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	maintenance := s.maintenance()
+	if maintenance == nil {
+		http.Error(w, "maintenance mode is disabled", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		duration, err := s.parseMaintenanceDuration(r.URL.Query().Get("duration"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		_, _, wasActive := maintenance.Window()
+		_, end := maintenance.Start(duration)
+		message := "Maintenance window started"
+		if wasActive {
+			message = "Maintenance window extended"
+		}
+		slog.Info(message, slog.Time("end", end))
+		events.Record(events.Event{
+			Type:       events.TypeMaintenance,
+			Severity:   events.SeverityInfo,
+			Message:    message,
+			Attributes: map[string]string{"end": end.Format(time.RFC3339)},
+		})
+	case http.MethodDelete:
+		if maintenance.Stop() {
+			slog.Info("Maintenance window ended")
+			events.Record(events.Event{
+				Type:     events.TypeMaintenance,
+				Severity: events.SeverityInfo,
+				Message:  "Maintenance window ended",
+			})
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	start, end, active := maintenance.Window()
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(MaintenanceStatus{Active: active, Start: start, End: end})
+	if err != nil {
+		slog.Error("Failed to write response.", slog.String(logging.ErrorKey, err.Error()))
+		http.Error(w, "failed to write response", http.StatusInternalServerError)
+	}
+}
+
+// parseMaintenanceDuration returns the duration of a maintenance window, bounded by the maximum of the
+// configuration
+func (s *MetricsServer) parseMaintenanceDuration(value string) (time.Duration, error) {
+	duration := defaultMaintenanceDuration
+	if value != "" {
+		var err error
+		duration, err = time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+	}
+
+	if s.config != nil && s.config.MaintenanceMaxDuration > 0 {
+		duration = min(duration, s.config.MaintenanceMaxDuration)
+	}
+	return duration, nil
+}
+
+// maintenance returns the transformation of the maintenance windows, nil when the maintenance mode is disabled
+func (s *MetricsServer) maintenance() *transformation.Maintenance {
+	for _, t := range s.transformations {
+		if maintenance, ok := t.(*transformation.Maintenance); ok {
+			return maintenance
+		}
+	}
+	return nil
+}
+
+func (s *MetricsServer) renderMaintenanceMetrics(w io.Writer) error {
+	maintenance := s.maintenance()
+	if maintenance == nil {
+		return nil
+	}
+
+	_, end, active := maintenance.Window()
+	return getMaintenanceMetricsTemplate().Execute(w, struct {
+		Active bool
+		End    time.Time
+	}{Active: active, End: end})
+}
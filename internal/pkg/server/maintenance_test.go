@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/transformation"
+)
+
+func TestMaintenance(t *testing.T) {
+	metricServer := &MetricsServer{
+		config:          &appconfig.Config{MaintenanceMaxDuration: 2 * time.Hour},
+		transformations: []transformation.Transform{transformation.NewMaintenance(nil)},
+	}
+
+	request := func(method, target string) (*httptest.ResponseRecorder, MaintenanceStatus) {
+		recorder := httptest.NewRecorder()
+		metricServer.Maintenance(recorder, httptest.NewRequest(method, target, nil))
+		var status MaintenanceStatus
+		if recorder.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+		}
+		return recorder, status
+	}
+
+	recorder, status := request(http.MethodGet, "/-/maintenance")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.False(t, status.Active)
+
+	recorder, _ = request(http.MethodPost, "/-/maintenance?duration=-1h")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	recorder, _ = request(http.MethodPut, "/-/maintenance")
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder, status = request(http.MethodPost, "/-/maintenance")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, status.Active)
+	assert.WithinDuration(t, time.Now().Add(defaultMaintenanceDuration), status.End, time.Minute)
+
+	// The duration is bounded by the maximum of the configuration
+	recorder, status = request(http.MethodPost, "/-/maintenance?duration=24h")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), status.End, time.Minute)
+
+	var buf strings.Builder
+	require.NoError(t, metricServer.renderMaintenanceMetrics(&buf))
+	assert.Contains(t, buf.String(), "dcgm_exporter_maintenance 1\n")
+	assert.Contains(t, buf.String(), "dcgm_exporter_maintenance_end_timestamp_seconds ")
+
+	recorder, status = request(http.MethodDelete, "/-/maintenance")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.False(t, status.Active)
+
+	buf.Reset()
+	require.NoError(t, metricServer.renderMaintenanceMetrics(&buf))
+	assert.Equal(t, `# HELP dcgm_exporter_maintenance Whether a maintenance window of the node is active.
+# TYPE dcgm_exporter_maintenance gauge
+dcgm_exporter_maintenance 0
+`, buf.String())
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+// metricsCacheEnabled returns whether /metrics serves the metrics of the collectors gathered in the background
+func (s *MetricsServer) metricsCacheEnabled() bool {
+	return s.config != nil && s.config.MetricsCacheInterval > 0
+}
+
+// runMetricsCache gathers the metrics of the collectors into the cache every MetricsCacheInterval until
+// stop is closed or ctx is done. A gather in progress is abandoned when stop is closed.
+func (s *MetricsServer) runMetricsCache(ctx context.Context, stop chan interface{}) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(s.config.MetricsCacheInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.refreshMetricsCache(ctx); err != nil && ctx.Err() == nil {
+			slog.Warn("Failed to refresh the metrics cache, serving the last snapshot",
+				slog.String(logging.ErrorKey, err.Error()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refreshMetricsCache gathers and renders the metrics of the collectors into the cache. The last snapshot is
+// kept when the gather fails and during hot reloads, so that /metrics doesn't turn empty meanwhile.
+func (s *MetricsServer) refreshMetricsCache(ctx context.Context) error {
+	currentRegistry := s.registry.Load()
+	if currentRegistry == nil {
+		slog.Debug("Registry unavailable, keeping the last snapshot of the metrics cache")
+		return nil
+	}
+
+	start := time.Now()
+	metricGroups, err := currentRegistry.GatherContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	tw := &timestampWriter{w: &buf, timestamp: start.UnixMilli()}
+	err = s.render(tw, metricGroups, nil)
+	if err != nil {
+		return err
+	}
+	err = tw.Flush()
+	if err != nil {
+		return err
+	}
+
+	s.metricsCache.Lock()
+	defer s.metricsCache.Unlock()
+	s.metricsCache.body = buf.Bytes()
+	s.metricsCache.time = start
+	return nil
+}
+
+// cachedMetrics returns the last snapshot of the metrics cache and the time it was gathered, zero if none was
+func (s *MetricsServer) cachedMetrics() ([]byte, time.Time) {
+	s.metricsCache.RLock()
+	defer s.metricsCache.RUnlock()
+	return s.metricsCache.body, s.metricsCache.time
+}
+
+// metricsCacheAge returns the age of the last snapshot of the metrics cache and whether it exceeds
+// MaxStaleness. A cache that was never filled is stale.
+func (s *MetricsServer) metricsCacheAge() (time.Duration, bool) {
+	if !s.metricsCacheEnabled() || s.config.MaxStaleness <= 0 {
+		return 0, false
+	}
+	_, gathered := s.cachedMetrics()
+	if gathered.IsZero() {
+		return 0, true
+	}
+	age := time.Since(gathered)
+	return age, age > s.config.MaxStaleness
+}
+
+// timestampWriter appends an explicit timestamp, in milliseconds, to the sample lines of the metrics written
+// to it in the Prometheus text format. The comments are written unchanged.
+type timestampWriter struct {
+	w         io.Writer
+	timestamp int64
+	partial   []byte // last line, until its newline is written
+}
+
+func (t *timestampWriter) Write(p []byte) (int, error) {
+	data := append(t.partial, p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		if err := t.writeLine(data[:i]); err != nil {
+			return 0, err
+		}
+		data = data[i+1:]
+	}
+	t.partial = slices.Clone(data)
+
+	return len(p), nil
+}
+
+// Flush writes the last line, when it has no newline
+func (t *timestampWriter) Flush() error {
+	if len(t.partial) == 0 {
+		return nil
+	}
+	err := t.writeLine(t.partial)
+	t.partial = nil
+	return err
+}
+
+func (t *timestampWriter) writeLine(line []byte) error {
+	trimmed := bytes.TrimSpace(line)
+	out := slices.Clone(line)
+	if len(trimmed) > 0 && trimmed[0] != '#' {
+		out = append(bytes.TrimRight(out, " \t"), ' ')
+		out = strconv.AppendInt(out, t.timestamp, 10)
+	}
+	out = append(out, '\n')
+	_, err := t.w.Write(out)
+	return err
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockcollectorpkg "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/collector"
+	mockdeviceinfo "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/deviceinfo"
+	mockdevicewatchlistmanager "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
+)
+
+func TestTimestampWriter(t *testing.T) {
+	var buf strings.Builder
+	tw := &timestampWriter{w: &buf, timestamp: 1700000000123}
+
+	_, err := tw.Write([]byte("# HELP A help\n# TYPE A gauge\nA{gpu=\"0\"} 4"))
+	require.NoError(t, err)
+	_, err = tw.Write([]byte("2\n\nB 1"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Flush())
+
+	assert.Equal(t, "# HELP A help\n# TYPE A gauge\nA{gpu=\"0\"} 42 1700000000123\n\nB 1 1700000000123\n", buf.String())
+}
+
+func TestMetricsCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	var collectErr error
+	mockCollector := mockcollectorpkg.NewMockCollector(ctrl)
+	mockCollector.EXPECT().GetMetrics().DoAndReturn(func() (collector.MetricsByCounter, error) {
+		if collectErr != nil {
+			return nil, collectErr
+		}
+		return getMetricsByCounterWithTestMetric(), nil
+	}).AnyTimes()
+
+	reg := registry.NewRegistry()
+	tuple := collector.EntityCollectorTuple{}
+	tuple.SetEntity(dcgm.FE_GPU)
+	tuple.SetCollector(mockCollector)
+	reg.Register(tuple)
+
+	mockDeviceInfo := mockdeviceinfo.NewMockProvider(ctrl)
+	mockDeviceInfo.EXPECT().InfoType().Return(dcgm.FE_GPU).AnyTimes()
+	mockDeviceInfo.EXPECT().GOpts().Return(appconfig.DeviceOptions{}).AnyTimes()
+	mockDeviceInfo.EXPECT().GPUCount().Return(uint(1)).AnyTimes()
+	watchList := *devicewatchlistmanager.NewWatchList(mockDeviceInfo, []dcgm.Short{42}, nil, deviceWatcher, 1)
+	mockDeviceWatchListManager := mockdevicewatchlistmanager.NewMockManager(ctrl)
+	mockDeviceWatchListManager.EXPECT().EntityWatchList(dcgm.FE_GPU).Return(watchList, true).AnyTimes()
+
+	metricServer := &MetricsServer{
+		config: &appconfig.Config{
+			NVMLOnly:             true,
+			MetricsCacheInterval: time.Minute,
+			MaxStaleness:         time.Hour,
+		},
+		deviceWatchListManager: mockDeviceWatchListManager,
+	}
+	metricServer.registry.Store(reg)
+
+	// Stale until the first gather
+	recorder := httptest.NewRecorder()
+	metricServer.Metrics(recorder, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	require.NoError(t, metricServer.refreshMetricsCache(context.Background()))
+	_, gathered := metricServer.cachedMetrics()
+	require.False(t, gathered.IsZero())
+	expected := strings.Replace(expectedResponse, "} 42\n",
+		"} 42 "+strconv.FormatInt(gathered.UnixMilli(), 10)+"\n", 1)
+
+	recorder = httptest.NewRecorder()
+	metricServer.Metrics(recorder, nil)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, expected, recorder.Body.String())
+
+	// The last snapshot is served during a hot reload and when the gather fails
+	cleared := metricServer.ClearRegistry()
+	require.NoError(t, metricServer.refreshMetricsCache(context.Background()))
+	metricServer.SetRegistry(cleared)
+	collectErr = errors.New("boom")
+	require.Error(t, metricServer.refreshMetricsCache(context.Background()))
+
+	recorder = httptest.NewRecorder()
+	metricServer.Metrics(recorder, nil)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, expected, recorder.Body.String())
+
+	// Beyond the max staleness
+	metricServer.config.MaxStaleness = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	recorder = httptest.NewRecorder()
+	metricServer.Metrics(recorder, nil)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "metrics are stale")
+}
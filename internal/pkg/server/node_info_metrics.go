@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"maps"
+	"slices"
+	"sync"
+	"text/template"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/transformation"
+)
+
+const nodeInfoMetricsFormat = `# HELP dcgm_exporter_node_info Labels of the Kubernetes node the exporter runs on.
+# TYPE dcgm_exporter_node_info gauge
+dcgm_exporter_node_info{ {{- range $i, $label := . }}{{ if $i }},{{ end }}{{ $label.Name }}="{{ $label.Value }}"{{ end -}} } 1
+`
+
+var getNodeInfoMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("nodeInfoMetricsFormat").Parse(nodeInfoMetricsFormat))
+})
+
+// renderNodeInfoMetrics writes the dcgm_exporter_node_info gauge when the labels of the Kubernetes node are
+// exported by the gauge rather than added to the metrics
+func (s *MetricsServer) renderNodeInfoMetrics(w io.Writer) error {
+	for _, t := range s.transformations {
+		nodeLabeler, ok := t.(*transformation.NodeLabeler)
+		if !ok {
+			continue
+		}
+
+		labels, enabled := nodeLabeler.NodeInfo()
+		if !enabled {
+			return nil
+		}
+
+		type label struct{ Name, Value string }
+		sorted := make([]label, 0, len(labels))
+		for _, name := range slices.Sorted(maps.Keys(labels)) {
+			sorted = append(sorted, label{Name: name, Value: labels[name]})
+		}
+		return getNodeInfoMetricsTemplate().Execute(w, sorted)
+	}
+	return nil
+}
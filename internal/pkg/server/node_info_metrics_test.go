@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/transformation"
+)
+
+func TestRenderNodeInfoMetrics(t *testing.T) {
+	client := fake.NewSimpleClientset(&v1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name: "node1",
+		Labels: map[string]string{
+			"topology.kubernetes.io/zone": "us-east-1a",
+			"nvidia.com/gpu.product":      "NVIDIA-H100-80GB-HBM3",
+		},
+	}})
+	keys := []string{"topology.kubernetes.io/zone", "nvidia.com/gpu.product"}
+
+	labeler, err := transformation.NewNodeLabeler(client, "node1", keys, appconfig.NodeLabelModeLabels)
+	require.NoError(t, err)
+	t.Cleanup(labeler.Stop)
+
+	var buf strings.Builder
+	metricServer := &MetricsServer{transformations: []transformation.Transform{labeler}}
+	assert.NoError(t, metricServer.renderNodeInfoMetrics(&buf))
+	assert.Empty(t, buf.String(), "Nothing is rendered when the node labels are added to the metrics")
+
+	labeler, err = transformation.NewNodeLabeler(client, "node1", keys, appconfig.NodeLabelModeInfo)
+	require.NoError(t, err)
+	t.Cleanup(labeler.Stop)
+	require.Eventually(t, func() bool {
+		labels, _ := labeler.NodeInfo()
+		return len(labels) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	metricServer = &MetricsServer{transformations: []transformation.Transform{labeler}}
+	assert.NoError(t, metricServer.renderNodeInfoMetrics(&buf))
+	assert.Equal(t, `# HELP dcgm_exporter_node_info Labels of the Kubernetes node the exporter runs on.
+# TYPE dcgm_exporter_node_info gauge
+dcgm_exporter_node_info{node="node1",nvidia_com_gpu_product="NVIDIA-H100-80GB-HBM3",topology_kubernetes_io_zone="us-east-1a"} 1
+`, buf.String())
+}
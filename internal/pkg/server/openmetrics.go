@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"bytes"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// createdTimesRetention is how long the created time of a series no longer exported is remembered,
+	// e.g. across a hot reload
+	createdTimesRetention = time.Hour
+
+	// maxExemplarLabelsLength is the maximal length, in runes, of the names and values of the labels of
+	// an exemplar in the OpenMetrics format
+	maxExemplarLabelsLength = 128
+)
+
+// exemplarLabels are the labels of the pods copied to the exemplars, in the current and the 1.x namespaces
+var exemplarLabels = []string{"pod", "pod_name", "pod_uid"}
+
+// negotiateOpenMetrics returns the OpenMetrics format when it is enabled and preferred by the client of r
+func (s *MetricsServer) negotiateOpenMetrics(r *http.Request) (expfmt.Format, bool) {
+	if r == nil || s.config == nil || !s.config.OpenMetrics {
+		return "", false
+	}
+	format := expfmt.NegotiateIncludingOpenMetrics(r.Header)
+	return format, format.FormatType() == expfmt.TypeOpenMetrics
+}
+
+// writeOpenMetrics converts metrics in the Prometheus text format to format, an OpenMetrics format. The
+// counters are suffixed with _total, as OpenMetrics requires, and get a _created series with the time the
+// exporter first exported them, and an exemplar with the pod of the GPU when it is allocated to one.
+// OpenMetrics doesn't allow exemplars on gauges.
+func (s *MetricsServer) writeOpenMetrics(w io.Writer, text []byte, format expfmt.Format) error {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(text))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	s.createdTimes.Lock()
+	defer s.createdTimes.Unlock()
+	if s.createdTimes.series == nil {
+		s.createdTimes.series = map[string]createdTime{}
+	}
+
+	enc := expfmt.NewEncoder(w, format, expfmt.WithCreatedLines())
+	for _, name := range slices.Sorted(maps.Keys(families)) {
+		family := families[name]
+		if family.GetType() == dto.MetricType_COUNTER {
+			s.toOpenMetricsCounter(family, now)
+		}
+		err = enc.Encode(family)
+		if err != nil {
+			return err
+		}
+	}
+
+	maps.DeleteFunc(s.createdTimes.series, func(_ string, t createdTime) bool {
+		return now.Sub(t.seen) > createdTimesRetention
+	})
+
+	if closer, ok := enc.(expfmt.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// toOpenMetricsCounter adds the _total suffix, the created time and the exemplar to the series of a counter
+// family. The caller holds the lock of the created times.
+func (s *MetricsServer) toOpenMetricsCounter(family *dto.MetricFamily, now time.Time) {
+	name := family.GetName()
+	if !strings.HasSuffix(name, "_total") {
+		family.Name = proto.String(name + "_total")
+	}
+
+	for _, metric := range family.GetMetric() {
+		if metric.GetCounter() == nil {
+			continue
+		}
+
+		key := seriesKey(name, metric)
+		t, exists := s.createdTimes.series[key]
+		if !exists {
+			t.created = now
+		}
+		t.seen = now
+		s.createdTimes.series[key] = t
+
+		metric.Counter.CreatedTimestamp = timestamppb.New(t.created)
+		metric.Counter.Exemplar = podExemplar(metric)
+	}
+}
+
+// podExemplar returns an exemplar with the pod labels of metric, or nil when it has none
+func podExemplar(metric *dto.Metric) *dto.Exemplar {
+	var labels []*dto.LabelPair
+	length := 0
+	for _, label := range metric.GetLabel() {
+		if !slices.Contains(exemplarLabels, label.GetName()) || label.GetValue() == "" {
+			continue
+		}
+		labels = append(labels, &dto.LabelPair{
+			Name:  proto.String(label.GetName()),
+			Value: proto.String(label.GetValue()),
+		})
+		length += utf8.RuneCountInString(label.GetName()) + utf8.RuneCountInString(label.GetValue())
+	}
+	if len(labels) == 0 || length > maxExemplarLabelsLength {
+		return nil
+	}
+
+	exemplar := &dto.Exemplar{
+		Label: labels,
+		Value: proto.Float64(metric.GetCounter().GetValue()),
+	}
+	if metric.TimestampMs != nil {
+		exemplar.Timestamp = timestamppb.New(time.UnixMilli(metric.GetTimestampMs()))
+	}
+	return exemplar
+}
+
+// seriesKey returns an identifier of a series of the family name
+func seriesKey(name string, metric *dto.Metric) string {
+	var b strings.Builder
+	b.WriteString(name)
+	for _, label := range metric.GetLabel() {
+		b.WriteByte(0)
+		b.WriteString(label.GetName())
+		b.WriteByte(0)
+		b.WriteString(label.GetValue())
+	}
+	return b.String()
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+)
+
+const openMetricsAccept = "application/openmetrics-text;version=1.0.0,text/plain;version=0.0.4;q=0.5"
+
+func TestWriteOpenMetrics(t *testing.T) {
+	text := `# HELP DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION Total energy consumption since boot (in mJ).
+# TYPE DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION counter
+DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION{gpu="0",pod="pod-a",pod_uid="uid-a"} 42 1700000000000
+DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION{gpu="1"} 7 1700000000000
+# HELP DCGM_FI_DEV_GPU_UTIL GPU utilization (in %).
+# TYPE DCGM_FI_DEV_GPU_UTIL gauge
+DCGM_FI_DEV_GPU_UTIL{gpu="0",pod="pod-a",pod_uid="uid-a"} 80
+# HELP dcgm_exporter_hot_reloads_total Hot reloads.
+# TYPE dcgm_exporter_hot_reloads_total counter
+dcgm_exporter_hot_reloads_total 3
+`
+	format := expfmt.NewFormat(expfmt.TypeOpenMetrics)
+	metricServer := &MetricsServer{}
+
+	var first strings.Builder
+	require.NoError(t, metricServer.writeOpenMetrics(&first, []byte(text), format))
+	out := first.String()
+
+	assert.Contains(t, out, "# TYPE DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION counter\n")
+	assert.Contains(t, out,
+		"DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION_total{gpu=\"0\",pod=\"pod-a\",pod_uid=\"uid-a\"} 42.0 1.7e+09"+
+			" # {pod=\"pod-a\",pod_uid=\"uid-a\"} 42.0 1.7e+09\n")
+	assert.Contains(t, out, "DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION_total{gpu=\"1\"} 7.0 1.7e+09\n",
+		"A series without pod should have no exemplar")
+	assert.Contains(t, out, "DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION_created{gpu=\"0\",pod=\"pod-a\",pod_uid=\"uid-a\"} ")
+	assert.Contains(t, out, "DCGM_FI_DEV_GPU_UTIL{gpu=\"0\",pod=\"pod-a\",pod_uid=\"uid-a\"} 80.0\n",
+		"A gauge should have no exemplar")
+	assert.Contains(t, out, "# TYPE dcgm_exporter_hot_reloads counter\ndcgm_exporter_hot_reloads_total 3.0\n")
+	assert.True(t, strings.HasSuffix(out, "# EOF\n"))
+
+	// The created time of a series doesn't change across scrapes
+	var second strings.Builder
+	require.NoError(t, metricServer.writeOpenMetrics(&second, []byte(text), format))
+	assert.Equal(t, out, second.String())
+}
+
+func TestMetricsNegotiatesOpenMetrics(t *testing.T) {
+	metricServer := &MetricsServer{config: &appconfig.Config{NVMLOnly: true}}
+
+	request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	request.Header.Set("Accept", openMetricsAccept)
+	recorder := httptest.NewRecorder()
+	metricServer.Metrics(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "# EOF", "OpenMetrics should be served when enabled only")
+
+	metricServer.config.OpenMetrics = true
+	recorder = httptest.NewRecorder()
+	metricServer.Metrics(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, strings.HasPrefix(recorder.Header().Get("Content-Type"), "application/openmetrics-text; version=1.0.0"))
+	assert.Equal(t, "# EOF\n", recorder.Body.String())
+}
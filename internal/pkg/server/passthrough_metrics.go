@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"sync"
+	"text/template"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/passthrough"
+)
+
+const passthroughMetricsFormat = `# HELP dcgm_exp_gpu_passthrough GPU bound to vfio-pci for VM passthrough, not monitored by DCGM.
+# TYPE dcgm_exp_gpu_passthrough gauge
+{{- range . }}
+dcgm_exp_gpu_passthrough{pci_bus_id="{{ . }}"} 1
+{{- end }}
+`
+
+var getPassthroughMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("passthroughMetricsFormat").Parse(passthroughMetricsFormat))
+})
+
+// passthroughBusIDs returns the PCI bus IDs of the GPUs bound to vfio-pci
+var passthroughBusIDs = passthrough.BusIDs
+
+// renderPassthroughMetrics writes a series per GPU bound to vfio-pci, nothing when there are none
+func (s *MetricsServer) renderPassthroughMetrics(w io.Writer) error {
+	busIDs := passthroughBusIDs()
+	if len(busIDs) == 0 {
+		return nil
+	}
+	return getPassthroughMetricsTemplate().Execute(w, busIDs)
+}
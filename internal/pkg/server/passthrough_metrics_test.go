@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/passthrough"
+)
+
+func TestRenderPassthroughMetrics(t *testing.T) {
+	metricServer := &MetricsServer{}
+	busIDs := []string{}
+	passthroughBusIDs = func() []string { return busIDs }
+	t.Cleanup(func() { passthroughBusIDs = passthrough.BusIDs })
+
+	var buf strings.Builder
+	assert.NoError(t, metricServer.renderPassthroughMetrics(&buf))
+	assert.Empty(t, buf.String(), "Nothing is rendered without passthrough GPUs")
+
+	busIDs = []string{"0000:3b:00.0", "0000:86:00.0"}
+	assert.NoError(t, metricServer.renderPassthroughMetrics(&buf))
+	assert.Equal(t, `# HELP dcgm_exp_gpu_passthrough GPU bound to vfio-pci for VM passthrough, not monitored by DCGM.
+# TYPE dcgm_exp_gpu_passthrough gauge
+dcgm_exp_gpu_passthrough{pci_bus_id="0000:3b:00.0"} 1
+dcgm_exp_gpu_passthrough{pci_bus_id="0000:86:00.0"} 1
+`, buf.String())
+}
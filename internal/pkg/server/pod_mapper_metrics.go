@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"maps"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/transformation"
+)
+
+const skippedPodsMetricsFormat = `# HELP dcgm_exporter_pod_mappings_skipped_total Number of inactive or filtered out pods skipped when mapping GPUs to pods.
+# TYPE dcgm_exporter_pod_mappings_skipped_total counter
+{{- range $reason, $count := . }}
+dcgm_exporter_pod_mappings_skipped_total{reason="{{ $reason }}"} {{ $count }}
+{{- end }}
+`
+
+const duplicatesMetricsFormat = `# HELP dcgm_exporter_pod_mappings_duplicates_total Number of series mapped to the same container by both the virtual GPU and DRA mappers, resolved into a single series.
+# TYPE dcgm_exporter_pod_mappings_duplicates_total counter
+dcgm_exporter_pod_mappings_duplicates_total{policy="{{ .Policy }}"} {{ .Count }}
+`
+
+const draMetricsFormat = `# HELP dcgm_exporter_dra_resource_slice_updates_total Number of ResourceSlice add, update and delete events processed by the DRA manager.
+# TYPE dcgm_exporter_dra_resource_slice_updates_total counter
+dcgm_exporter_dra_resource_slice_updates_total {{ .SliceUpdates }}
+# HELP dcgm_exporter_dra_unresolved_lookups_total Number of DRA pool/device lookups without a known device.
+# TYPE dcgm_exporter_dra_unresolved_lookups_total counter
+dcgm_exporter_dra_unresolved_lookups_total {{ .UnresolvedLookups }}
+# HELP dcgm_exporter_dra_mapping_duration_seconds Time spent mapping DRA devices to pods.
+# TYPE dcgm_exporter_dra_mapping_duration_seconds summary
+dcgm_exporter_dra_mapping_duration_seconds_sum {{ .MappingDuration.Seconds }}
+dcgm_exporter_dra_mapping_duration_seconds_count {{ .MappingCount }}
+`
+
+const kubeletSocketMetricsFormat = `# HELP dcgm_exp_kubelet_socket_up Whether the last List of the kubelet pod-resources socket succeeded.
+# TYPE dcgm_exp_kubelet_socket_up gauge
+dcgm_exp_kubelet_socket_up {{ if .Up }}1{{ else }}0{{ end }}
+{{- if not .LastList.IsZero }}
+# HELP dcgm_exp_kubelet_pod_resources_last_list_timestamp_seconds Time of the last successful List of the kubelet pod-resources socket.
+# TYPE dcgm_exp_kubelet_pod_resources_last_list_timestamp_seconds gauge
+dcgm_exp_kubelet_pod_resources_last_list_timestamp_seconds {{ .LastList.Unix }}
+{{- end }}
+`
+
+var getKubeletSocketMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("kubeletSocketMetricsFormat").Parse(kubeletSocketMetricsFormat))
+})
+
+var getSkippedPodsMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("skippedPodsMetricsFormat").Parse(skippedPodsMetricsFormat))
+})
+
+var getDuplicatesMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("duplicatesMetricsFormat").Parse(duplicatesMetricsFormat))
+})
+
+var getDRAMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("draMetricsFormat").Parse(draMetricsFormat))
+})
+
+// skippedPodReasons returns the reasons the pods may be skipped for in the mappings with the configuration,
+// each with no pod skipped
+func skippedPodReasons(config *appconfig.Config) map[string]uint64 {
+	reasons := map[string]uint64{}
+	if config == nil {
+		return reasons
+	}
+	if config.KubernetesSkipInactivePods {
+		reasons[transformation.PodSkipReasonTerminating] = 0
+		reasons[transformation.PodSkipReasonTerminated] = 0
+		reasons[transformation.PodSkipReasonUnschedulable] = 0
+	}
+	if len(config.PodNamespaceAllowlist) > 0 || len(config.PodNamespaceDenylist) > 0 {
+		reasons[transformation.PodSkipReasonNamespace] = 0
+	}
+	return reasons
+}
+
+// renderPodMapperMetrics writes the self metrics of the pod mapper, i.e. the availability of the kubelet
+// pod-resources socket, and the pods skipped in the mappings, the duplicates of the virtual GPU and DRA mappers
+// and the DRA ResourceSlice manager counters when the respective features are enabled.
+func (s *MetricsServer) renderPodMapperMetrics(w io.Writer) error {
+	for _, t := range s.transformations {
+		pm, ok := t.(*transformation.PodMapper)
+		if !ok {
+			continue
+		}
+
+		up, lastList := pm.KubeletSocketStatus()
+		err := getKubeletSocketMetricsTemplate().Execute(w, struct {
+			Up       bool
+			LastList time.Time
+		}{Up: up, LastList: lastList})
+		if err != nil {
+			return err
+		}
+
+		if skippedPods := skippedPodReasons(pm.Config); len(skippedPods) > 0 {
+			maps.Copy(skippedPods, pm.SkippedPods())
+			if err := getSkippedPodsMetricsTemplate().Execute(w, skippedPods); err != nil {
+				return err
+			}
+		}
+
+		if pm.Config != nil && pm.Config.KubernetesVirtualGPUs && pm.Config.KubernetesEnableDRA {
+			policy := pm.Config.KubernetesDuplicatePolicy
+			if policy == "" {
+				policy = appconfig.DuplicatePolicyMerge
+			}
+			err := getDuplicatesMetricsTemplate().Execute(w, struct {
+				Policy appconfig.DuplicatePolicy
+				Count  uint64
+			}{Policy: policy, Count: pm.MergedDuplicates()})
+			if err != nil {
+				return err
+			}
+		}
+
+		if stats, enabled := pm.DRAStats(); enabled {
+			if err := getDRAMetricsTemplate().Execute(w, stats); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
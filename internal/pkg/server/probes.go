@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+// dcgmCheckTimeout is how long the probes wait for DCGM before reporting the hostengine as hung
+var dcgmCheckTimeout = 5 * time.Second
+
+// Healthz is the liveness probe. It fails when DCGM doesn't respond, but not during a reload:
+// the DCGM connection is reset on GPU topology changes, and restarting the exporter wouldn't help.
+func (s *MetricsServer) Healthz(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if s.IsReloadInProgress() {
+		w.Header().Set("X-Reload-In-Progress", "true")
+		writeProbeResponse(w, http.StatusOK, "OK - reload in progress")
+		return
+	}
+
+	err := s.checkDCGM()
+	if err != nil {
+		slog.Warn("Liveness check failed", slog.String(logging.ErrorKey, err.Error()))
+		writeProbeResponse(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	writeProbeResponse(w, http.StatusOK, "OK")
+}
+
+// Readyz is the readiness probe. It fails while a reload is in progress or no registry is available,
+// i.e. /metrics would return an empty response, and when DCGM doesn't respond. In the CPU-only mode,
+// it also fails when no CPU metrics are collected.
+func (s *MetricsServer) Readyz(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if s.IsReloadInProgress() || s.registry.Load() == nil {
+		w.Header().Set("X-Reload-In-Progress", "true")
+		writeProbeResponse(w, http.StatusServiceUnavailable, "reload in progress")
+		return
+	}
+
+	err := s.checkDCGM()
+	if err == nil && s.config != nil && s.config.CPUOnly {
+		err = s.checkCPUCollectors()
+	}
+	if err != nil {
+		slog.Warn("Readiness check failed", slog.String(logging.ErrorKey, err.Error()))
+		writeProbeResponse(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+
+	writeProbeResponse(w, http.StatusOK, "OK")
+}
+
+// checkDCGM makes a cheap DCGM call to verify the connection to the hostengine.
+// A hung call is not retried until it returns, so that the probes don't pile up goroutines.
+// There is nothing to check in the NVML-only mode, where DCGM isn't initialized.
+func (s *MetricsServer) checkDCGM() error {
+	if s.config != nil && s.config.NVMLOnly {
+		return nil
+	}
+
+	client := dcgmprovider.Client()
+	if client == nil {
+		return errors.New("DCGM is not initialized")
+	}
+
+	if !s.dcgmCheckInFlight.CompareAndSwap(false, true) {
+		return errors.New("DCGM is not responding: the previous check is still pending")
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		defer s.dcgmCheckInFlight.Store(false)
+		_, err := client.GetAllDeviceCount()
+		result <- err
+	}()
+
+	select {
+	case err := <-result:
+		if err != nil {
+			return fmt.Errorf("DCGM check failed: %w", err)
+		}
+		return nil
+	case <-time.After(dcgmCheckTimeout):
+		return fmt.Errorf("DCGM is not responding after %s", dcgmCheckTimeout)
+	}
+}
+
+// checkCPUCollectors verifies that the CPU metrics are collected, which are the only metrics in the CPU-only mode
+func (s *MetricsServer) checkCPUCollectors() error {
+	for _, c := range s.registry.Load().Collectors() {
+		if c.Entity == dcgm.FE_CPU || c.Entity == dcgm.FE_CPU_CORE {
+			return nil
+		}
+	}
+	return errors.New("no CPU collector is registered: DCGM found no CPU or no CPU field is configured")
+}
+
+func writeProbeResponse(w http.ResponseWriter, status int, message string) {
+	w.WriteHeader(status)
+	_, err := w.Write([]byte(message))
+	if err != nil {
+		slog.Error("Failed to write response.", slog.String(logging.ErrorKey, err.Error()))
+	}
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mockcollector "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/collector"
+	mockdcgm "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
+)
+
+func setDCGMClient(t *testing.T, client dcgmprovider.DCGM) {
+	t.Helper()
+	realDCGM := dcgmprovider.Client()
+	t.Cleanup(func() {
+		dcgmprovider.SetClient(realDCGM)
+	})
+	dcgmprovider.SetClient(client)
+}
+
+func TestHealthz(t *testing.T) {
+	t.Run("DCGM responds", func(t *testing.T) {
+		mockDCGM := mockdcgm.NewMockDCGM(gomock.NewController(t))
+		mockDCGM.EXPECT().GetAllDeviceCount().Return(uint(1), nil)
+		setDCGMClient(t, mockDCGM)
+
+		recorder := httptest.NewRecorder()
+		(&MetricsServer{}).Healthz(recorder, nil)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("DCGM fails", func(t *testing.T) {
+		mockDCGM := mockdcgm.NewMockDCGM(gomock.NewController(t))
+		mockDCGM.EXPECT().GetAllDeviceCount().Return(uint(0), errors.New("connection lost"))
+		setDCGMClient(t, mockDCGM)
+
+		recorder := httptest.NewRecorder()
+		(&MetricsServer{}).Healthz(recorder, nil)
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "connection lost")
+	})
+
+	t.Run("DCGM hangs", func(t *testing.T) {
+		timeout := dcgmCheckTimeout
+		dcgmCheckTimeout = 10 * time.Millisecond
+		defer func() {
+			dcgmCheckTimeout = timeout
+		}()
+
+		release := make(chan struct{})
+		defer close(release)
+		mockDCGM := mockdcgm.NewMockDCGM(gomock.NewController(t))
+		mockDCGM.EXPECT().GetAllDeviceCount().DoAndReturn(func() (uint, error) {
+			<-release
+			return 1, nil
+		})
+		setDCGMClient(t, mockDCGM)
+
+		metricServer := &MetricsServer{}
+		recorder := httptest.NewRecorder()
+		metricServer.Healthz(recorder, nil)
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+		// The hung call isn't repeated
+		recorder = httptest.NewRecorder()
+		metricServer.Healthz(recorder, nil)
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "still pending")
+	})
+
+	t.Run("NVML-only mode", func(t *testing.T) {
+		setDCGMClient(t, nil)
+
+		recorder := httptest.NewRecorder()
+		(&MetricsServer{config: &appconfig.Config{NVMLOnly: true}}).Healthz(recorder, nil)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Reload in progress", func(t *testing.T) {
+		setDCGMClient(t, nil)
+
+		metricServer := &MetricsServer{}
+		metricServer.SetReloadInProgress(true)
+		recorder := httptest.NewRecorder()
+		metricServer.Healthz(recorder, nil)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "true", recorder.Header().Get("X-Reload-In-Progress"))
+	})
+}
+
+func TestReadyz(t *testing.T) {
+	t.Run("Ready", func(t *testing.T) {
+		mockDCGM := mockdcgm.NewMockDCGM(gomock.NewController(t))
+		mockDCGM.EXPECT().GetAllDeviceCount().Return(uint(1), nil)
+		setDCGMClient(t, mockDCGM)
+
+		metricServer := &MetricsServer{}
+		metricServer.registry.Store(registry.NewRegistry())
+		recorder := httptest.NewRecorder()
+		metricServer.Readyz(recorder, nil)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+
+	t.Run("Registry is nil", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		(&MetricsServer{}).Readyz(recorder, nil)
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.Equal(t, "true", recorder.Header().Get("X-Reload-In-Progress"))
+	})
+
+	t.Run("DCGM is not initialized", func(t *testing.T) {
+		setDCGMClient(t, nil)
+
+		metricServer := &MetricsServer{}
+		metricServer.registry.Store(registry.NewRegistry())
+		recorder := httptest.NewRecorder()
+		metricServer.Readyz(recorder, nil)
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	})
+
+	t.Run("CPU-only mode", func(t *testing.T) {
+		mockDCGM := mockdcgm.NewMockDCGM(gomock.NewController(t))
+		mockDCGM.EXPECT().GetAllDeviceCount().Return(uint(0), nil).Times(2)
+		setDCGMClient(t, mockDCGM)
+
+		metricServer := &MetricsServer{config: &appconfig.Config{CPUOnly: true}}
+		reg := registry.NewRegistry()
+		metricServer.registry.Store(reg)
+
+		recorder := httptest.NewRecorder()
+		metricServer.Readyz(recorder, nil)
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+		assert.Contains(t, recorder.Body.String(), "no CPU collector")
+
+		tuple := collector.EntityCollectorTuple{}
+		tuple.SetEntity(dcgm.FE_CPU)
+		tuple.SetCollector(mockcollector.NewMockCollector(gomock.NewController(t)))
+		tuple.SetName(collector.DCGMCollectorName)
+		reg.Register(tuple)
+
+		recorder = httptest.NewRecorder()
+		metricServer.Readyz(recorder, nil)
+		assert.Equal(t, http.StatusOK, recorder.Code)
+	})
+}
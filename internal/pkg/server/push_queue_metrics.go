@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"sync"
+	"text/template"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/pushqueue"
+)
+
+const pushQueueMetricsFormat = `# HELP dcgm_exporter_push_queue_depth Number of items waiting in the push queue.
+# TYPE dcgm_exporter_push_queue_depth gauge
+{{- range . }}
+dcgm_exporter_push_queue_depth{sink="{{ .Sink }}",endpoint="{{ .Endpoint }}"} {{ .Depth }}
+{{- end }}
+# HELP dcgm_exporter_push_queue_capacity Number of items the push queue holds before it drops the oldest ones.
+# TYPE dcgm_exporter_push_queue_capacity gauge
+{{- range . }}
+dcgm_exporter_push_queue_capacity{sink="{{ .Sink }}",endpoint="{{ .Endpoint }}"} {{ .Capacity }}
+{{- end }}
+# HELP dcgm_exporter_push_queue_dropped_total Total number of items dropped because the push queue was full.
+# TYPE dcgm_exporter_push_queue_dropped_total counter
+{{- range . }}
+dcgm_exporter_push_queue_dropped_total{sink="{{ .Sink }}",endpoint="{{ .Endpoint }}"} {{ .Dropped }}
+{{- end }}
+# HELP dcgm_exporter_push_queue_sent_total Total number of items pushed successfully.
+# TYPE dcgm_exporter_push_queue_sent_total counter
+{{- range . }}
+dcgm_exporter_push_queue_sent_total{sink="{{ .Sink }}",endpoint="{{ .Endpoint }}"} {{ .Sent }}
+{{- end }}
+`
+
+var getPushQueueMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("pushQueueMetricsFormat").Parse(pushQueueMetricsFormat))
+})
+
+func (s *MetricsServer) renderPushQueueMetrics(w io.Writer) error {
+	stats := pushqueue.AllStats()
+	if len(stats) == 0 {
+		return nil
+	}
+	return getPushQueueMetricsTemplate().Execute(w, stats)
+}
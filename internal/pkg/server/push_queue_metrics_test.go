@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/pushqueue"
+)
+
+func TestRenderPushQueueMetrics(t *testing.T) {
+	metricServer := &MetricsServer{}
+
+	var buf strings.Builder
+	require.NoError(t, metricServer.renderPushQueueMetrics(&buf))
+	assert.Empty(t, buf.String(), "Nothing is rendered without a running queue")
+
+	queue := pushqueue.New[int]("otlp", "http://collector:4317", pushqueue.Config{Capacity: 2, BatchSize: 10})
+	queue.Push(1, 2, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		queue.Run(ctx, func(context.Context, []int) error { return nil })
+	}()
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	require.Eventually(t, func() bool { return len(pushqueue.AllStats()) == 1 }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, metricServer.renderPushQueueMetrics(&buf))
+	assert.Contains(t, buf.String(),
+		`dcgm_exporter_push_queue_capacity{sink="otlp",endpoint="http://collector:4317"} 2`)
+	assert.Contains(t, buf.String(),
+		`dcgm_exporter_push_queue_dropped_total{sink="otlp",endpoint="http://collector:4317"} 1`)
+	assert.Contains(t, buf.String(), "# TYPE dcgm_exporter_push_queue_sent_total counter")
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicemonitoring"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+// maxReloadRecords is the number of reloads kept in the history served by /api/v1/reloads
+const maxReloadRecords = 50
+
+// ReloadResult is the outcome of a reload recorded in the reload history.
+const (
+	ReloadResultSuccess = "success"
+	ReloadResultFailed  = "failed"
+	ReloadResultSkipped = "skipped"
+)
+
+// RecordReload adds a completed reload to the history served by /api/v1/reloads. manager is the watch list
+// manager built by the reload; its monitored entities replace the ones of the same entity types, so a partial
+// reload only changes the topology of the entity types it rebuilt. The topology delta is computed against the
+// previous successful reload, or against the startup topology for the first one.
+func (s *MetricsServer) RecordReload(
+	id uint64, trigger string, start time.Time, manager devicewatchlistmanager.Manager, err error,
+) {
+	record := ReloadRecord{
+		ID:              id,
+		Trigger:         trigger,
+		Start:           start,
+		DurationSeconds: time.Since(start).Seconds(),
+		Result:          ReloadResultSuccess,
+	}
+
+	s.reloads.Lock()
+	defer s.reloads.Unlock()
+
+	if s.reloads.topology == nil {
+		s.reloads.topology = monitoredEntities(s.deviceWatchListManager)
+	}
+
+	switch {
+	case err != nil:
+		record.Result = ReloadResultFailed
+		record.Error = err.Error()
+	case manager != nil:
+		topology := maps.Clone(s.reloads.topology)
+		maps.Copy(topology, monitoredEntities(manager))
+		record.Topology = topology
+		record.TopologyDelta = topologyDelta(s.reloads.topology, topology)
+		s.reloads.topology = topology
+	}
+
+	s.reloads.add(record)
+	recordReloadEvent(record)
+}
+
+// RecordSkippedReload adds a reload that was not performed, e.g. because it was rate limited, to the history
+// served by /api/v1/reloads.
+func (s *MetricsServer) RecordSkippedReload(id uint64, trigger, reason string) {
+	s.reloads.Lock()
+	defer s.reloads.Unlock()
+
+	s.reloads.add(ReloadRecord{
+		ID:      id,
+		Trigger: trigger,
+		Start:   time.Now(),
+		Result:  ReloadResultSkipped,
+		Reason:  reason,
+	})
+}
+
+// ReloadHistory returns the recorded reloads, the most recent first.
+func (s *MetricsServer) ReloadHistory() []ReloadRecord {
+	s.reloads.Lock()
+	defer s.reloads.Unlock()
+
+	history := slices.Clone(s.reloads.records)
+	slices.Reverse(history)
+	return history
+}
+
+// Reloads serves the history of the last reloads, which outlives the logs often rotated away before an
+// investigation begins.
+func (s *MetricsServer) Reloads(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Type", "application/json")
+
+	history := s.ReloadHistory()
+	if history == nil {
+		history = []ReloadRecord{}
+	}
+
+	err := json.NewEncoder(w).Encode(history)
+	if err != nil {
+		slog.Error("Failed to write response.", slog.String(logging.ErrorKey, err.Error()))
+	}
+}
+
+// reloadCounts returns the number of reloads per trigger and result since the start of the exporter.
+func (s *MetricsServer) reloadCounts() map[reloadCount]uint64 {
+	s.reloads.Lock()
+	defer s.reloads.Unlock()
+
+	return maps.Clone(s.reloads.counts)
+}
+
+// add appends and counts a record, dropping the oldest one once the history holds maxReloadRecords records.
+// The caller must hold the lock.
+func (h *reloadHistory) add(record ReloadRecord) {
+	if h.counts == nil {
+		h.counts = map[reloadCount]uint64{}
+	}
+	h.counts[reloadCount{Trigger: record.Trigger, Result: record.Result}]++
+
+	if len(h.records) == maxReloadRecords {
+		h.records = slices.Delete(h.records, 0, 1)
+	}
+	h.records = append(h.records, record)
+}
+
+// monitoredEntities returns the number of monitored entities per entity type of a watch list manager
+func monitoredEntities(manager devicewatchlistmanager.Manager) map[string]int {
+	topology := map[string]int{}
+	if manager == nil {
+		return topology
+	}
+
+	for _, entityType := range snapshotEntityTypes {
+		watchList, exists := manager.EntityWatchList(entityType)
+		if !exists {
+			continue
+		}
+		topology[entityType.String()] = len(devicemonitoring.GetMonitoredEntities(watchList.DeviceInfo()))
+	}
+
+	return topology
+}
+
+// topologyDelta returns the change of the number of monitored entities per entity type, nil without changes.
+// after holds all the entity types of before.
+func topologyDelta(before, after map[string]int) map[string]int {
+	var delta map[string]int
+	for entityType, count := range after {
+		if change := count - before[entityType]; change != 0 {
+			if delta == nil {
+				delta = map[string]int{}
+			}
+			delta[entityType] = change
+		}
+	}
+	return delta
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdeviceinfo "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/deviceinfo"
+	mockdevicewatchlistmanager "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+)
+
+// cpuWatchListManager returns a watch list manager monitoring the given number of CPUs only
+func cpuWatchListManager(ctrl *gomock.Controller, cpuCount uint) devicewatchlistmanager.Manager {
+	var cpus []deviceinfo.CPUInfo
+	for i := range cpuCount {
+		cpus = append(cpus, deviceinfo.CPUInfo{EntityId: i})
+	}
+
+	mockDeviceInfo := mockdeviceinfo.NewMockProvider(ctrl)
+	mockDeviceInfo.EXPECT().InfoType().Return(dcgm.FE_CPU).AnyTimes()
+	mockDeviceInfo.EXPECT().CPUs().Return(cpus).AnyTimes()
+	mockDeviceInfo.EXPECT().IsCPUWatched(gomock.Any()).Return(true).AnyTimes()
+
+	watchList := *devicewatchlistmanager.NewWatchList(mockDeviceInfo, []dcgm.Short{42}, nil, deviceWatcher, 1)
+
+	mockManager := mockdevicewatchlistmanager.NewMockManager(ctrl)
+	mockManager.EXPECT().EntityWatchList(dcgm.FE_CPU).Return(watchList, true).AnyTimes()
+	mockManager.EXPECT().EntityWatchList(gomock.Any()).Return(devicewatchlistmanager.WatchList{}, false).AnyTimes()
+	return mockManager
+}
+
+func TestReloads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	metricServer := &MetricsServer{deviceWatchListManager: cpuWatchListManager(ctrl, 2)}
+
+	getReloads := func() []ReloadRecord {
+		recorder := httptest.NewRecorder()
+		metricServer.Reloads(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/reloads", nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var reloads []ReloadRecord
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &reloads))
+		return reloads
+	}
+
+	// No reload yet
+	assert.Empty(t, getReloads())
+
+	start := time.Now()
+	metricServer.RecordReload(1, "sighup", start, cpuWatchListManager(ctrl, 2), nil)
+	metricServer.RecordSkippedReload(0, "file_change", "rate limited")
+	metricServer.RecordReload(2, "gpu_topology_change", start, nil, errors.New("no GPU found"))
+	metricServer.RecordReload(3, "gpu_topology_change", start, cpuWatchListManager(ctrl, 1), nil)
+
+	reloads := getReloads()
+	require.Len(t, reloads, 4)
+
+	// The most recent first, the topology delta is computed against the previous successful reload
+	assert.Equal(t, uint64(3), reloads[0].ID)
+	assert.Equal(t, ReloadResultSuccess, reloads[0].Result)
+	assert.Equal(t, map[string]int{dcgm.FE_CPU.String(): 1}, reloads[0].Topology)
+	assert.Equal(t, map[string]int{dcgm.FE_CPU.String(): -1}, reloads[0].TopologyDelta)
+
+	assert.Equal(t, uint64(2), reloads[1].ID)
+	assert.Equal(t, ReloadResultFailed, reloads[1].Result)
+	assert.Equal(t, "no GPU found", reloads[1].Error)
+	assert.Empty(t, reloads[1].Topology)
+
+	assert.Equal(t, "file_change", reloads[2].Trigger)
+	assert.Equal(t, ReloadResultSkipped, reloads[2].Result)
+	assert.Equal(t, "rate limited", reloads[2].Reason)
+
+	// Same topology as at startup
+	assert.Equal(t, "sighup", reloads[3].Trigger)
+	assert.Equal(t, ReloadResultSuccess, reloads[3].Result)
+	assert.Equal(t, map[string]int{dcgm.FE_CPU.String(): 2}, reloads[3].Topology)
+	assert.Empty(t, reloads[3].TopologyDelta)
+}
+
+func TestReloadsAreBounded(t *testing.T) {
+	metricServer := &MetricsServer{}
+
+	for i := range maxReloadRecords + 5 {
+		metricServer.RecordSkippedReload(uint64(i+1), "sighup", fmt.Sprintf("skipped %d", i+1))
+	}
+
+	reloads := metricServer.ReloadHistory()
+	require.Len(t, reloads, maxReloadRecords)
+	assert.Equal(t, uint64(maxReloadRecords+5), reloads[0].ID)
+	assert.Equal(t, uint64(6), reloads[maxReloadRecords-1].ID)
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+const (
+	probeTargetParam = "target"
+
+	// scrapeTimeoutHeader is the header in which Prometheus sends the scrape timeout
+	scrapeTimeoutHeader = "X-Prometheus-Scrape-Timeout-Seconds"
+
+	// maxProbeTimeout keeps the probes within the write timeout of the server
+	maxProbeTimeout = 9 * time.Second
+
+	// probeTimeoutOffset leaves time to write the response before the scrape times out
+	probeTimeoutOffset = 500 * time.Millisecond
+
+	// maxConcurrentProbes bounds the number of hostengine connections opened by the probes
+	maxConcurrentProbes = 8
+)
+
+// SetProbe sets the function reading the metrics of the remote hostengines for /probe
+func (s *MetricsServer) SetProbe(probe ProbeFunc) {
+	s.probe.Store(&probe)
+}
+
+// Probe serves the metrics of the remote hostengine of the target parameter, e.g. /probe?target=dgx-01:5555.
+// The hostengine is connected for the duration of the probe only.
+func (s *MetricsServer) Probe(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	probe := s.probe.Load()
+	if probe == nil {
+		http.Error(w, "the probes are not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	target := r.URL.Query().Get(probeTargetParam)
+	if err := validateProbeTarget(target); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if s.probesInFlight.Add(1) > maxConcurrentProbes {
+		s.probesInFlight.Add(-1)
+		http.Error(w, "too many probes in progress", http.StatusServiceUnavailable)
+		return
+	}
+	defer s.probesInFlight.Add(-1)
+
+	ctx, cancel := context.WithTimeout(r.Context(), probeTimeout(r))
+	defer cancel()
+
+	var buf bytes.Buffer
+	if err := (*probe)(ctx, target, &buf); err != nil {
+		slog.Warn("Failed to probe the remote hostengine",
+			slog.String("target", target),
+			slog.String(logging.ErrorKey, err.Error()))
+		http.Error(w, fmt.Sprintf("failed to probe %s: %s", target, err), http.StatusBadGateway)
+		return
+	}
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		slog.Error("Failed to write response.", slog.String(logging.ErrorKey, err.Error()))
+	}
+}
+
+// validateProbeTarget checks that the target is a <host>:<port> address of a hostengine
+func validateProbeTarget(target string) error {
+	if target == "" {
+		return fmt.Errorf("missing %s parameter", probeTargetParam)
+	}
+
+	host, port, err := net.SplitHostPort(target)
+	if err != nil {
+		return fmt.Errorf("invalid %s parameter value: %w", probeTargetParam, err)
+	}
+	if host == "" {
+		return fmt.Errorf("invalid %s parameter value: missing host", probeTargetParam)
+	}
+	if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return fmt.Errorf("invalid %s parameter value: invalid port '%s'", probeTargetParam, port)
+	}
+	return nil
+}
+
+// probeTimeout returns the timeout of a probe: the scrape timeout of Prometheus minus an offset,
+// bounded by maxProbeTimeout.
+func probeTimeout(r *http.Request) time.Duration {
+	seconds, err := strconv.ParseFloat(r.Header.Get(scrapeTimeoutHeader), 64)
+	if err != nil || seconds <= 0 {
+		return maxProbeTimeout
+	}
+
+	timeout := time.Duration(seconds*float64(time.Second)) - probeTimeoutOffset
+	if timeout <= 0 {
+		return time.Duration(seconds * float64(time.Second))
+	}
+	return min(timeout, maxProbeTimeout)
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbe(t *testing.T) {
+	metricServer := &MetricsServer{}
+
+	t.Run("Probes disabled", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		metricServer.Probe(recorder, httptest.NewRequest(http.MethodGet, "/probe?target=dgx-01:5555", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	})
+
+	var probed string
+	metricServer.SetProbe(func(_ context.Context, target string, w io.Writer) error {
+		if target == "unreachable:5555" {
+			return errors.New("connection refused")
+		}
+		probed = target
+		_, err := fmt.Fprintln(w, `DCGM_FI_DEV_GPU_TEMP{gpu="0"} 42`)
+		return err
+	})
+
+	tests := []struct {
+		name         string
+		target       string
+		expectedCode int
+	}{
+		{name: "Missing target", target: "", expectedCode: http.StatusBadRequest},
+		{name: "Missing port", target: "dgx-01", expectedCode: http.StatusBadRequest},
+		{name: "Invalid port", target: "dgx-01:65536", expectedCode: http.StatusBadRequest},
+		{name: "Missing host", target: ":5555", expectedCode: http.StatusBadRequest},
+		{name: "Unreachable hostengine", target: "unreachable:5555", expectedCode: http.StatusBadGateway},
+		{name: "Valid target", target: "dgx-01:5555", expectedCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			metricServer.Probe(recorder, httptest.NewRequest(http.MethodGet, "/probe?target="+tt.target, nil))
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+		})
+	}
+
+	assert.Equal(t, "dgx-01:5555", probed)
+}
+
+func TestProbeTimeout(t *testing.T) {
+	tests := []struct {
+		name          string
+		scrapeTimeout string
+		expected      time.Duration
+	}{
+		{name: "No header", scrapeTimeout: "", expected: maxProbeTimeout},
+		{name: "Invalid header", scrapeTimeout: "ten", expected: maxProbeTimeout},
+		{name: "Short scrape timeout", scrapeTimeout: "5", expected: 4500 * time.Millisecond},
+		{name: "Long scrape timeout", scrapeTimeout: "30", expected: maxProbeTimeout},
+		{name: "Scrape timeout below the offset", scrapeTimeout: "0.25", expected: 250 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/probe", nil)
+			if tt.scrapeTimeout != "" {
+				r.Header.Set(scrapeTimeoutHeader, tt.scrapeTimeout)
+			}
+			assert.Equal(t, tt.expected, probeTimeout(r))
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -28,10 +29,12 @@ import (
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/exporter-toolkit/web"
 
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/debug"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
@@ -82,6 +85,7 @@ func NewMetricsServer(
 			<h1>GPU Exporter</h1>
 			<p><a href="./metrics">Metrics</a></p>
 			<p><a href="./health">Health</a></p>
+			<p><a href="./healthz">Liveness</a> - <a href="./readyz">Readiness</a></p>
 			<h2>Profiling (pprof)</h2>
 			<ul>
 				<li><a href="./debug/pprof/">Index</a></li>
@@ -89,6 +93,7 @@ func NewMetricsServer(
 				<li><a href="./debug/pprof/goroutine">Goroutines</a> - Active goroutines</li>
 				<li><a href="./debug/pprof/allocs">Allocations</a> - All memory allocations</li>
 			</ul>
+			<p><a href="./debug/trace-scrape">Trace a scrape</a> - Duration and values of every field</p>
 			</body>
 			</html>`))
 		if err != nil {
@@ -99,10 +104,43 @@ func NewMetricsServer(
 	})
 
 	router.HandleFunc("/health", serverv1.Health)
+	router.HandleFunc("/healthz", serverv1.Healthz)
+	router.HandleFunc("/readyz", serverv1.Readyz)
+	router.HandleFunc("/api/v1/config/effective", serverv1.EffectiveConfig)
+	router.HandleFunc("/api/v1/reloads", serverv1.Reloads)
+	router.HandleFunc("/api/v1/events", serverv1.Events)
 	router.HandleFunc("/metrics", serverv1.Metrics)
 
+	if c.CanaryCollectorsFile != "" {
+		router.HandleFunc("/canary", serverv1.Canary)
+		slog.Info("Canary report enabled at /canary", slog.String("collectors", c.CanaryCollectorsFile))
+	}
+
+	if c.ProbeEnabled {
+		router.HandleFunc("/probe", serverv1.Probe)
+		slog.Info("Remote hostengine probes enabled at /probe")
+	}
+
+	if c.DevicePauseAPI {
+		router.HandleFunc("/api/v1/devices/paused", serverv1.PausedDevices)
+		router.HandleFunc("/api/v1/devices/{"+deviceUUIDVar+"}/pause", serverv1.PauseDevice)
+		router.HandleFunc("/api/v1/devices/{"+deviceUUIDVar+"}/resume", serverv1.ResumeDevice)
+		slog.Info("Pausing the collection of GPUs enabled at /api/v1/devices")
+	}
+
+	if c.MaintenanceAPI {
+		router.HandleFunc("/-/maintenance", serverv1.Maintenance)
+		slog.Info("Maintenance mode enabled at /-/maintenance")
+	}
+
+	if c.DiagLevel > 0 {
+		router.HandleFunc("/diag", serverv1.Diag)
+		slog.Info("DCGM diagnostics enabled at /diag", slog.Int("level", c.DiagLevel))
+	}
+
 	// Register pprof endpoints for profiling and debugging
 	// Access via: curl http://localhost:9400/debug/pprof/heap > heap.pprof
+	router.HandleFunc("/debug/trace-scrape", serverv1.TraceScrape)
 	router.HandleFunc("/debug/pprof/", pprof.Index)
 	router.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
 	router.HandleFunc("/debug/pprof/profile", pprof.Profile)
@@ -130,6 +168,12 @@ func NewMetricsServer(
 	}
 
 	cleanup := func() {
+		for _, t := range serverv1.transformations {
+			if nodeLabeler, ok := t.(*transformation.NodeLabeler); ok {
+				slog.Info("Stopping NodeLabeler")
+				nodeLabeler.Stop()
+			}
+		}
 		if podMapper != nil {
 			slog.Info("Stopping PodMapper")
 			podMapper.Stop()
@@ -166,6 +210,32 @@ func (s *MetricsServer) GetRegistry() *registry.Registry {
 	return reg
 }
 
+// HasRegistry returns whether a registry is set, i.e. /metrics is not returning empty responses.
+func (s *MetricsServer) HasRegistry() bool {
+	return s.registry.Load() != nil
+}
+
+// SetCountersConfigError records the result of reading the counters configuration during a reload,
+// exported as the dcgm_exporter_counters_config_* metrics.
+func (s *MetricsServer) SetCountersConfigError(err error) {
+	if err == nil {
+		s.countersConfigInvalid.Store(false)
+		return
+	}
+	s.countersConfigInvalid.Store(true)
+	s.countersConfigErrors.Add(1)
+}
+
+// RequestDRAResync makes the pod mapper resync the DRA ResourceSlices on the next scrape,
+// so that devices created by a GPU topology change are mapped to their pods.
+func (s *MetricsServer) RequestDRAResync() {
+	for _, t := range s.transformations {
+		if pm, ok := t.(*transformation.PodMapper); ok {
+			pm.RequestDRAResync()
+		}
+	}
+}
+
 // SetReloadInProgress marks whether a hot reload is currently happening
 // This can be exposed via /health endpoint
 func (s *MetricsServer) SetReloadInProgress(inProgress bool) {
@@ -195,7 +265,7 @@ func (s *MetricsServer) Run(ctx context.Context, stop chan interface{}) {
 			slog.Debug("Debug dumps disabled - use --dump-enabled flag to enable file-based debugging")
 		}
 
-		if err := web.ListenAndServe(s.server, s.webConfig, slog.Default()); err != nil && err != http.ErrServerClosed {
+		if err := s.listenAndServe(); err != nil && err != http.ErrServerClosed {
 			slog.Error("Failed to Listen and Server HTTP server.", slog.String(logging.ErrorKey, err.Error()))
 			os.Exit(1)
 		}
@@ -224,6 +294,14 @@ func (s *MetricsServer) Run(ctx context.Context, stop chan interface{}) {
 		}
 	}()
 
+	if s.metricsCacheEnabled() {
+		httpwg.Add(1)
+		go func() {
+			defer httpwg.Done()
+			s.runMetricsCache(ctx, stop)
+		}()
+	}
+
 	<-stop
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
 	defer shutdownCancel()
@@ -238,28 +316,90 @@ func (s *MetricsServer) Run(ctx context.Context, stop chan interface{}) {
 	}
 }
 
+// listenAndServe serves HTTP on the configured address. Unless a specific IP family is requested,
+// the listener is left to the exporter toolkit, which also handles systemd socket activation.
+func (s *MetricsServer) listenAndServe() error {
+	if s.config.IPFamily == "" || s.config.IPFamily == appconfig.IPFamilyAny || s.config.WebSystemdSocket {
+		return web.ListenAndServe(s.server, s.webConfig, slog.Default())
+	}
+
+	listener, err := listen(s.config.Address, s.config.IPFamily)
+	if err != nil {
+		return err
+	}
+
+	if ml, ok := listener.(*multiListener); ok {
+		for _, addr := range ml.Addrs() {
+			slog.Info("Listening on", slog.String("address", addr.String()))
+		}
+	} else {
+		slog.Info("Listening on", slog.String("address", listener.Addr().String()))
+	}
+
+	return web.Serve(listener, s.server, s.webConfig, slog.Default())
+}
+
 func (s *MetricsServer) fatal() {
 	os.Exit(1)
 }
 
-func (s *MetricsServer) Metrics(w http.ResponseWriter, _ *http.Request) {
+// Metrics serves the metrics in the Prometheus text format. With collect[] parameters, only the matching
+// counter families are served, without the self metrics of the exporter.
+func (s *MetricsServer) Metrics(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("X-Content-Type-Options", "nosniff")
 
-	currentRegistry := s.GetRegistry()
+	var filter familyFilter
+	if r != nil {
+		var err error
+		if filter, err = parseFamilyFilter(r.URL.Query()); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
 
-	metricGroups, err := currentRegistry.Gather()
-	if err != nil {
-		slog.Error("Failed to gather metrics from collectors", slog.String(logging.ErrorKey, err.Error()))
-		http.Error(w, internalServerError, http.StatusInternalServerError)
+	if filter == nil {
+		if age, stale := s.metricsCacheAge(); stale {
+			slog.Warn("Metrics cache is stale", slog.Duration("age", age))
+			http.Error(w, fmt.Sprintf("metrics are stale, gathered %s ago", age.Round(time.Second)),
+				http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	ctx := context.Background()
+	if r != nil {
+		ctx = r.Context()
+	}
+
+	if _, openMetrics := s.negotiateOpenMetrics(r); s.streamingEnabled() && !openMetrics {
+		s.streamMetrics(ctx, w, r, filter)
 		return
 	}
+
 	var buf bytes.Buffer
-	err = s.render(&buf, metricGroups)
+	err := s.writeMetrics(ctx, &buf, filter)
 	if err != nil {
 		http.Error(w, internalServerError, http.StatusInternalServerError)
 		return
 	}
-	_, err = w.Write(buf.Bytes())
+	body := buf.Bytes()
+	if format, ok := s.negotiateOpenMetrics(r); ok {
+		var om bytes.Buffer
+		err = s.writeOpenMetrics(&om, buf.Bytes(), format)
+		if err != nil {
+			slog.Error("Failed to convert metrics to OpenMetrics", slog.String(logging.ErrorKey, err.Error()))
+			http.Error(w, internalServerError, http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", string(format))
+		body = om.Bytes()
+	}
+	if encoding := s.negotiateEncoding(w, r); encoding != "" {
+		w.Header().Set("Content-Encoding", encoding)
+		err = writeEncoded(w, encoding, body)
+	} else {
+		_, err = w.Write(body)
+	}
 	if err != nil {
 		slog.Error("Failed to write response.", slog.String(logging.ErrorKey, err.Error()))
 		http.Error(w, "failed to write response", http.StatusInternalServerError)
@@ -267,71 +407,222 @@ func (s *MetricsServer) Metrics(w http.ResponseWriter, _ *http.Request) {
 	}
 }
 
-func (s *MetricsServer) render(w io.Writer, metricGroups registry.MetricsByCounterGroup) error {
+// WriteMetrics gathers the metrics of the current registry, applies the transformations and writes
+// them in the Prometheus text format, followed by the self metrics of the exporter.
+func (s *MetricsServer) WriteMetrics(w io.Writer) error {
+	return s.writeMetrics(context.Background(), w, nil)
+}
+
+// writeMetrics writes the metrics like WriteMetrics; a non-nil filter restricts them to the matching
+// counter families. The collectors still running when ctx is done are skipped. Without filter, the metrics
+// of the collectors come from the metrics cache once it is filled, when it is enabled.
+func (s *MetricsServer) writeMetrics(ctx context.Context, w io.Writer, filter familyFilter) error {
+	currentRegistry := s.GetRegistry()
+
+	// The exposition hash covers the metrics of the collectors, not the self metrics of the exporter
+	var hash *expositionHash
+	out := w
+	if filter == nil && s.config != nil && s.config.ExpositionHashMetric {
+		hash = newExpositionHash()
+		out = io.MultiWriter(w, hash)
+	}
+
+	var cached []byte
+	var gathered time.Time
+	if filter == nil && s.metricsCacheEnabled() {
+		cached, gathered = s.cachedMetrics()
+	}
+	if !gathered.IsZero() {
+		_, err := out.Write(cached)
+		if err != nil {
+			return err
+		}
+	} else {
+		err := s.gatherAndRender(ctx, currentRegistry, out, filter)
+		if err != nil {
+			return err
+		}
+	}
+	if filter != nil {
+		return nil
+	}
+	err := s.renderConnectionMetrics(w)
+	if err != nil {
+		slog.Error("Failed to render connection metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderCountersConfigMetrics(w)
+	if err != nil {
+		slog.Error("Failed to render counters configuration metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderCollectorsMetrics(w, currentRegistry)
+	if err != nil {
+		slog.Error("Failed to render collectors metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderCollectorLimitsMetrics(w, currentRegistry)
+	if err != nil {
+		slog.Error("Failed to render collector limits metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderTelemetryMetrics(w, currentRegistry)
+	if err != nil {
+		slog.Error("Failed to render telemetry metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderPodMapperMetrics(w)
+	if err != nil {
+		slog.Error("Failed to render pod mapper metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderFieldGroupsMetrics(w)
+	if err != nil {
+		slog.Error("Failed to render field groups metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderWatchConfigMetrics(w)
+	if err != nil {
+		slog.Error("Failed to render watch configuration metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderDiagMetrics(w)
+	if err != nil {
+		slog.Error("Failed to render diagnostics metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderDegradedModeMetrics(w)
+	if err != nil {
+		slog.Error("Failed to render degraded mode metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderPushQueueMetrics(w)
+	if err != nil {
+		slog.Error("Failed to render push queue metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderMaintenanceMetrics(w)
+	if err != nil {
+		slog.Error("Failed to render maintenance metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderPassthroughMetrics(w)
+	if err != nil {
+		slog.Error("Failed to render passthrough metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderNodeInfoMetrics(w)
+	if err != nil {
+		slog.Error("Failed to render node info metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderFieldInfoMetrics(w)
+	if err != nil {
+		slog.Error("Failed to render field info metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderFamilySamplesMetrics(w)
+	if err != nil {
+		slog.Error("Failed to render family samples metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	err = s.renderExpositionHashMetrics(w, hash)
+	if err != nil {
+		slog.Error("Failed to render exposition hash metrics", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	return nil
+}
+
+func (s *MetricsServer) render(w io.Writer, metricGroups registry.MetricsByCounterGroup, filter familyFilter) error {
+	samples := s.newFamilySamples(filter)
 	for group, metrics := range metricGroups {
-		deviceWatchList, exists := s.deviceWatchListManager.EntityWatchList(group)
-		if exists {
-
-			// Write debug files and log references
-			var metricsFile, deviceInfoFile string
-			var err error
-
-			if s.fileDumper != nil {
-				metricsFile, err = s.fileDumper.DumpToFile(metrics, "metrics", group.String())
-				if err != nil {
-					slog.Warn("Failed to write metrics debug file",
-						slog.String(logging.ErrorKey, err.Error()),
-						slog.String(logging.FieldEntityGroupKey, group.String()))
-				}
+		err := s.renderGroup(w, group, metrics, filter, samples)
+		if err != nil {
+			return err
+		}
+	}
+	s.setFamilySamples(samples)
+	return nil
+}
 
-				deviceInfoFile, err = s.fileDumper.DumpToFile(deviceWatchList.DeviceInfo(), "deviceinfo", group.String())
-				if err != nil {
-					slog.Warn("Failed to write device info debug file",
-						slog.String(logging.ErrorKey, err.Error()),
-						slog.String(logging.FieldEntityGroupKey, group.String()))
-				}
-			}
+// renderGroup applies the transformations to the metrics of an entity type and writes them. The samples of
+// the families written are added to samples, unless it is nil.
+func (s *MetricsServer) renderGroup(
+	w io.Writer, group dcgm.Field_Entity_Group, metrics collector.MetricsByCounter, filter familyFilter,
+	samples map[string]int,
+) error {
+	deviceWatchList, exists := s.deviceWatchListManager.EntityWatchList(group)
+	if !exists {
+		return nil
+	}
+
+	dropShedMetrics(metrics)
 
-			// Log summary information with file references
-			slog.Debug("Applying transformations",
+	// Write debug files and log references
+	var metricsFile, deviceInfoFile string
+	var err error
+
+	if s.fileDumper != nil {
+		metricsFile, err = s.fileDumper.DumpToFile(metrics, "metrics", group.String())
+		if err != nil {
+			slog.Warn("Failed to write metrics debug file",
+				slog.String(logging.ErrorKey, err.Error()),
+				slog.String(logging.FieldEntityGroupKey, group.String()))
+		}
+
+		deviceInfoFile, err = s.fileDumper.DumpToFile(deviceWatchList.DeviceInfo(), "deviceinfo", group.String())
+		if err != nil {
+			slog.Warn("Failed to write device info debug file",
+				slog.String(logging.ErrorKey, err.Error()),
+				slog.String(logging.FieldEntityGroupKey, group.String()))
+		}
+	}
+
+	// Log summary information with file references
+	slog.Debug("Applying transformations",
+		slog.String(logging.FieldEntityGroupKey, group.String()),
+		slog.Int("metrics_count", len(metrics)),
+		slog.Int("transformations_count", len(s.transformations)),
+		slog.String("metrics_debug_file", metricsFile),
+		slog.String("deviceinfo_debug_file", deviceInfoFile),
+	)
+
+	for _, transformation := range s.transformations {
+		if isTransformationShed(transformation) {
+			continue
+		}
+		transformErr := transformation.Process(metrics, deviceWatchList.DeviceInfo())
+		if transformErr != nil {
+			slog.LogAttrs(context.Background(), slog.LevelError, "Failed to apply transformations on metrics",
+				slog.String(logging.ErrorKey, transformErr.Error()),
 				slog.String(logging.FieldEntityGroupKey, group.String()),
+				slog.String("transformation", transformation.Name()),
 				slog.Int("metrics_count", len(metrics)),
-				slog.Int("transformations_count", len(s.transformations)),
 				slog.String("metrics_debug_file", metricsFile),
 				slog.String("deviceinfo_debug_file", deviceInfoFile),
 			)
-
-			for _, transformation := range s.transformations {
-				transformErr := transformation.Process(metrics, deviceWatchList.DeviceInfo())
-				if transformErr != nil {
-					slog.LogAttrs(context.Background(), slog.LevelError, "Failed to apply transformations on metrics",
-						slog.String(logging.ErrorKey, transformErr.Error()),
-						slog.String(logging.FieldEntityGroupKey, group.String()),
-						slog.String("transformation", transformation.Name()),
-						slog.Int("metrics_count", len(metrics)),
-						slog.String("metrics_debug_file", metricsFile),
-						slog.String("deviceinfo_debug_file", deviceInfoFile),
-					)
-					return transformErr
-				}
-			}
-			slog.Debug("Rendering metrics",
-				slog.String(logging.FieldEntityGroupKey, group.String()),
-				slog.Int("metrics_count", len(metrics)),
-				slog.String("metrics_debug_file", metricsFile))
-			err = rendermetrics.RenderGroup(w, group, metrics)
-			if err != nil {
-				slog.LogAttrs(context.Background(), slog.LevelError, "Failed to renderGroup metrics",
-					slog.String(logging.ErrorKey, err.Error()),
-					slog.String(logging.FieldEntityGroupKey, group.String()),
-					slog.Int("metrics_count", len(metrics)),
-					slog.String("metrics_debug_file", metricsFile),
-					slog.String("deviceinfo_debug_file", deviceInfoFile),
-				)
-				return err
-			}
+			return transformErr
 		}
 	}
+	filter.apply(metrics)
+
+	slog.Debug("Rendering metrics",
+		slog.String(logging.FieldEntityGroupKey, group.String()),
+		slog.Int("metrics_count", len(metrics)),
+		slog.String("metrics_debug_file", metricsFile))
+	err = rendermetrics.RenderGroup(w, group, metrics)
+	if err != nil {
+		slog.LogAttrs(context.Background(), slog.LevelError, "Failed to renderGroup metrics",
+			slog.String(logging.ErrorKey, err.Error()),
+			slog.String(logging.FieldEntityGroupKey, group.String()),
+			slog.Int("metrics_count", len(metrics)),
+			slog.String("metrics_debug_file", metricsFile),
+			slog.String("deviceinfo_debug_file", deviceInfoFile),
+		)
+		return err
+	}
+	countFamilySamples(samples, metrics)
 	return nil
 }
 
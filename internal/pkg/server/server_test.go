@@ -18,6 +18,7 @@ package server
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -204,6 +205,15 @@ func TestMetrics(t *testing.T) {
 	}
 }
 
+func TestMetricsReturns400OnInvalidCollectPattern(t *testing.T) {
+	metricServer := &MetricsServer{}
+
+	recorder := httptest.NewRecorder()
+	metricServer.Metrics(recorder, httptest.NewRequest(http.MethodGet, "/metrics?collect[]=DCGM_FI_%5B", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), collectParam)
+}
+
 // mockResponseWriter is a custom writer that simulates a network operation error.
 type mockResponseWriter struct {
 	httptest.ResponseRecorder
@@ -312,3 +322,114 @@ func TestHealthReturnsOKWithRegistryAvailable(t *testing.T) {
 	assert.Equal(t, "true", recorder.Header().Get("X-Registry-Available"))
 	assert.NotEqual(t, "true", recorder.Header().Get("X-Reload-In-Progress"))
 }
+
+func TestRenderPodMapperMetrics(t *testing.T) {
+	t.Run("Features disabled", func(t *testing.T) {
+		metricServer := &MetricsServer{
+			transformations: []transformation.Transform{&transformation.PodMapper{Config: &appconfig.Config{}}},
+		}
+		var buf strings.Builder
+		assert.NoError(t, metricServer.renderPodMapperMetrics(&buf))
+		// Only the kubelet socket watchdog, the pod-resources socket was not listed yet
+		assert.Contains(t, buf.String(), "dcgm_exp_kubelet_socket_up 0\n")
+		assert.NotContains(t, buf.String(), "dcgm_exp_kubelet_pod_resources_last_list_timestamp_seconds")
+		assert.NotContains(t, buf.String(), "dcgm_exporter_")
+	})
+
+	t.Run("DRA enabled", func(t *testing.T) {
+		metricServer := &MetricsServer{
+			transformations: []transformation.Transform{&transformation.PodMapper{
+				ResourceSliceManager: &transformation.DRAResourceSliceManager{},
+			}},
+		}
+		var buf strings.Builder
+		assert.NoError(t, metricServer.renderPodMapperMetrics(&buf))
+		assert.Contains(t, buf.String(), "dcgm_exporter_dra_resource_slice_updates_total 0\n")
+		assert.Contains(t, buf.String(), "dcgm_exporter_dra_unresolved_lookups_total 0\n")
+		assert.Contains(t, buf.String(), "dcgm_exporter_dra_mapping_duration_seconds_count 0\n")
+	})
+
+	t.Run("Skip inactive pods enabled", func(t *testing.T) {
+		metricServer := &MetricsServer{
+			transformations: []transformation.Transform{&transformation.PodMapper{
+				Config: &appconfig.Config{KubernetesSkipInactivePods: true},
+			}},
+		}
+		var buf strings.Builder
+		assert.NoError(t, metricServer.renderPodMapperMetrics(&buf))
+		assert.Contains(t, buf.String(), `dcgm_exporter_pod_mappings_skipped_total{reason="terminated"} 0`)
+		assert.Contains(t, buf.String(), `dcgm_exporter_pod_mappings_skipped_total{reason="unschedulable"} 0`)
+		assert.NotContains(t, buf.String(), `reason="namespace"`)
+		assert.NotContains(t, buf.String(), "dcgm_exporter_dra_")
+	})
+
+	t.Run("Namespace filtering enabled", func(t *testing.T) {
+		metricServer := &MetricsServer{
+			transformations: []transformation.Transform{&transformation.PodMapper{
+				Config: &appconfig.Config{PodNamespaceDenylist: []string{"kube-system"}},
+			}},
+		}
+		var buf strings.Builder
+		assert.NoError(t, metricServer.renderPodMapperMetrics(&buf))
+		assert.Contains(t, buf.String(), `dcgm_exporter_pod_mappings_skipped_total{reason="namespace"} 0`)
+		assert.NotContains(t, buf.String(), `reason="terminated"`)
+	})
+
+	t.Run("Virtual GPUs and DRA enabled", func(t *testing.T) {
+		metricServer := &MetricsServer{
+			transformations: []transformation.Transform{&transformation.PodMapper{
+				Config: &appconfig.Config{
+					KubernetesVirtualGPUs:     true,
+					KubernetesEnableDRA:       true,
+					KubernetesDuplicatePolicy: appconfig.DuplicatePolicyDRA,
+				},
+			}},
+		}
+		var buf strings.Builder
+		assert.NoError(t, metricServer.renderPodMapperMetrics(&buf))
+		assert.Contains(t, buf.String(), `dcgm_exporter_pod_mappings_duplicates_total{policy="dra"} 0`)
+	})
+}
+
+func TestRenderCountersConfigMetrics(t *testing.T) {
+	metricServer := &MetricsServer{}
+
+	var buf strings.Builder
+	assert.NoError(t, metricServer.renderCountersConfigMetrics(&buf))
+	assert.Empty(t, buf.String(), "Nothing is rendered until the configuration failed to be read")
+
+	metricServer.SetCountersConfigError(errors.New("invalid counter"))
+	buf.Reset()
+	assert.NoError(t, metricServer.renderCountersConfigMetrics(&buf))
+	assert.Contains(t, buf.String(), "dcgm_exporter_counters_config_valid 0\n")
+	assert.Contains(t, buf.String(), "dcgm_exporter_counters_config_errors_total 1\n")
+
+	metricServer.SetCountersConfigError(nil)
+	buf.Reset()
+	assert.NoError(t, metricServer.renderCountersConfigMetrics(&buf))
+	assert.Contains(t, buf.String(), "dcgm_exporter_counters_config_valid 1\n")
+	assert.Contains(t, buf.String(), "dcgm_exporter_counters_config_errors_total 1\n")
+}
+
+func TestRenderCollectorsMetrics(t *testing.T) {
+	reg := registry.NewRegistry()
+	for _, entity := range []dcgm.Field_Entity_Group{dcgm.FE_GPU, dcgm.FE_SWITCH} {
+		tuple := collector.EntityCollectorTuple{}
+		tuple.SetEntity(entity)
+		tuple.SetCollector(mockcollectorpkg.NewMockCollector(gomock.NewController(t)))
+		tuple.SetName(collector.DCGMCollectorName)
+		reg.Register(tuple)
+	}
+
+	metricServer := &MetricsServer{config: &appconfig.Config{}}
+	var buf strings.Builder
+	assert.NoError(t, metricServer.renderCollectorsMetrics(&buf, reg))
+	assert.Empty(t, buf.String())
+
+	metricServer.config.CollectorInventoryMetric = true
+	assert.NoError(t, metricServer.renderCollectorsMetrics(&buf, reg))
+	assert.Contains(t, buf.String(),
+		fmt.Sprintf("dcgm_exporter_collectors{entity=%q,collector=\"DCGM\"} 1\n", dcgm.FE_GPU.String()))
+	assert.Contains(t, buf.String(),
+		fmt.Sprintf("dcgm_exporter_collectors{entity=%q,collector=\"DCGM\"} 1\n", dcgm.FE_SWITCH.String()))
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"runtime/pprof"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/debug"
+)
+
+// snapshotEntityTypes are the entity types whose monitored entities are counted in a diagnostic snapshot
+// and in the reload history
+var snapshotEntityTypes = []dcgm.Field_Entity_Group{
+	dcgm.FE_GPU,
+	dcgm.FE_SWITCH,
+	dcgm.FE_LINK,
+	dcgm.FE_CPU,
+	dcgm.FE_CPU_CORE,
+	dcgm.FE_VGPU,
+}
+
+// DiagnosticSnapshot builds a snapshot of the state of the exporter. watchers is the state of the watchers
+// triggering reloads, which are owned by the caller.
+func (s *MetricsServer) DiagnosticSnapshot(watchers map[string]any) DiagnosticSnapshot {
+	snapshot := DiagnosticSnapshot{
+		Time:              time.Now(),
+		ReloadInProgress:  s.IsReloadInProgress(),
+		RegistryAvailable: s.HasRegistry(),
+		Collectors:        []string{},
+		Topology:          monitoredEntities(s.deviceWatchListManager),
+		Watchers:          watchers,
+	}
+
+	for _, info := range s.GetRegistry().Collectors() {
+		snapshot.Collectors = append(snapshot.Collectors, fmt.Sprintf("%s/%s", info.Entity.String(), info.Name))
+	}
+
+	var goroutines bytes.Buffer
+	err := pprof.Lookup("goroutine").WriteTo(&goroutines, 2)
+	if err != nil {
+		snapshot.Goroutines = fmt.Sprintf("failed to dump goroutines: %v", err)
+	} else {
+		snapshot.Goroutines = goroutines.String()
+	}
+
+	return snapshot
+}
+
+// WriteDiagnosticSnapshot writes a diagnostic snapshot to the dump directory and returns the file name.
+// The snapshot is requested explicitly, so it is written even when the debug dumps are disabled.
+func (s *MetricsServer) WriteDiagnosticSnapshot(watchers map[string]any) (string, error) {
+	var dumpConfig appconfig.DumpConfig
+	if s.config != nil {
+		dumpConfig = s.config.DumpConfig
+	}
+	dumpConfig.Enabled = true
+
+	return debug.NewFileDumper(dumpConfig).DumpToFile(s.DiagnosticSnapshot(watchers), "snapshot", "sigusr2")
+}
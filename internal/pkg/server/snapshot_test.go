@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+)
+
+func TestWriteDiagnosticSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	// Debug dumps are disabled, the snapshot is written anyway
+	metricServer := &MetricsServer{config: &appconfig.Config{DumpConfig: appconfig.DumpConfig{Directory: dir}}}
+	metricServer.SetReloadInProgress(true)
+
+	file, err := metricServer.WriteDiagnosticSnapshot(map[string]any{"reload_count": 3})
+	require.NoError(t, err)
+	assert.Equal(t, dir, filepath.Dir(file))
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	var snapshot DiagnosticSnapshot
+	require.NoError(t, json.Unmarshal(data, &snapshot))
+	assert.True(t, snapshot.ReloadInProgress)
+	assert.False(t, snapshot.RegistryAvailable)
+	assert.Empty(t, snapshot.Collectors)
+	assert.Empty(t, snapshot.Topology)
+	assert.Equal(t, float64(3), snapshot.Watchers["reload_count"])
+	assert.Contains(t, snapshot.Goroutines, "TestWriteDiagnosticSnapshot")
+}
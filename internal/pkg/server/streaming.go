@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
+)
+
+// streamBufferSize is the size of the buffer between the rendered families and a streamed response
+const streamBufferSize = 32 << 10
+
+var streamBufferPool = sync.Pool{New: func() any {
+	return bufio.NewWriterSize(nil, streamBufferSize)
+}}
+
+func (s *MetricsServer) streamingEnabled() bool {
+	return s.config != nil && s.config.StreamingRender
+}
+
+// gatherAndRender gathers the metrics of the registry and writes them. With streaming rendering, the
+// metrics of an entity type are transformed and written as soon as its collectors are done, instead of
+// holding the metrics of every entity type until the slowest collector is done.
+func (s *MetricsServer) gatherAndRender(
+	ctx context.Context, currentRegistry *registry.Registry, w io.Writer, filter familyFilter,
+) error {
+	if s.streamingEnabled() {
+		samples := s.newFamilySamples(filter)
+		err := currentRegistry.GatherStream(ctx,
+			func(group dcgm.Field_Entity_Group, metrics collector.MetricsByCounter) error {
+				return s.renderGroup(w, group, metrics, filter, samples)
+			})
+		if err != nil {
+			slog.Error("Failed to stream metrics from collectors", slog.String(logging.ErrorKey, err.Error()))
+			return err
+		}
+		s.setFamilySamples(samples)
+		return nil
+	}
+
+	metricGroups, err := currentRegistry.GatherContext(ctx)
+	if err != nil {
+		slog.Error("Failed to gather metrics from collectors", slog.String(logging.ErrorKey, err.Error()))
+		return err
+	}
+	return s.render(w, metricGroups, filter)
+}
+
+// streamMetrics writes the metrics to the response as they are rendered, compressed when negotiated, through
+// a small buffer instead of the whole body. Once the response started, a failure truncates it: the client
+// fails to parse it, as it can't get an error status anymore.
+func (s *MetricsServer) streamMetrics(
+	ctx context.Context, w http.ResponseWriter, r *http.Request, filter familyFilter,
+) {
+	response := &startedWriter{w: w}
+	var out io.Writer = response
+
+	encoding := s.negotiateEncoding(w, r)
+	var c compressor
+	if encoding != "" {
+		w.Header().Set("Content-Encoding", encoding)
+		c = acquireCompressor(encoding, response)
+		defer releaseCompressor(encoding, c)
+		out = c
+	}
+
+	buffered := streamBufferPool.Get().(*bufio.Writer)
+	buffered.Reset(out)
+	defer func() {
+		buffered.Reset(nil)
+		streamBufferPool.Put(buffered)
+	}()
+
+	err := s.writeMetrics(ctx, buffered, filter)
+	if err == nil {
+		err = buffered.Flush()
+	}
+	if err == nil && c != nil {
+		err = c.Close()
+	}
+	if err == nil {
+		return
+	}
+
+	if response.started {
+		slog.Error("Failed to stream metrics, the response is truncated", slog.String(logging.ErrorKey, err.Error()))
+		return
+	}
+	w.Header().Del("Content-Encoding")
+	http.Error(w, internalServerError, http.StatusInternalServerError)
+}
+
+// startedWriter records whether a response started, i.e. its status was sent
+type startedWriter struct {
+	w       io.Writer
+	started bool
+}
+
+func (sw *startedWriter) Write(p []byte) (int, error) {
+	sw.started = sw.started || len(p) > 0
+	return sw.w.Write(p)
+}
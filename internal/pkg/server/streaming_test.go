@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/klauspost/compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockcollectorpkg "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/collector"
+	mockdeviceinfo "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/deviceinfo"
+	mockdevicewatchlistmanager "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
+)
+
+func TestMetricsStreaming(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	newMetricServer := func(collectorErr error) *MetricsServer {
+		mockCollector := mockcollectorpkg.NewMockCollector(ctrl)
+		if collectorErr != nil {
+			mockCollector.EXPECT().GetMetrics().Return(nil, collectorErr).AnyTimes()
+		} else {
+			mockCollector.EXPECT().GetMetrics().Return(getMetricsByCounterWithTestMetric(), nil).AnyTimes()
+		}
+		reg := registry.NewRegistry()
+		entityCollectorTuple := collector.EntityCollectorTuple{}
+		entityCollectorTuple.SetEntity(dcgm.FE_GPU)
+		entityCollectorTuple.SetCollector(mockCollector)
+		reg.Register(entityCollectorTuple)
+
+		mockDeviceInfo := mockdeviceinfo.NewMockProvider(ctrl)
+		mockDeviceInfo.EXPECT().GPUCount().Return(uint(1)).AnyTimes()
+		watchList := *devicewatchlistmanager.NewWatchList(mockDeviceInfo, []dcgm.Short{42}, nil, deviceWatcher, 1)
+		mockManager := mockdevicewatchlistmanager.NewMockManager(ctrl)
+		mockManager.EXPECT().EntityWatchList(dcgm.FE_GPU).Return(watchList, true).AnyTimes()
+
+		metricServer := &MetricsServer{
+			config:                 &appconfig.Config{NVMLOnly: true, StreamingRender: true, MetricsCompression: true},
+			deviceWatchListManager: mockManager,
+		}
+		metricServer.registry.Store(reg)
+		return metricServer
+	}
+
+	t.Run("Streams the metrics", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		newMetricServer(nil).Metrics(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.True(t, strings.HasPrefix(recorder.Body.String(), expectedResponse))
+	})
+
+	t.Run("Streams the metrics compressed", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+		recorder := httptest.NewRecorder()
+		newMetricServer(nil).Metrics(recorder, request)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, encodingGzip, recorder.Header().Get("Content-Encoding"))
+
+		reader, err := gzip.NewReader(recorder.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(body), expectedResponse))
+	})
+
+	t.Run("Returns 500 when nothing was streamed", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+		recorder := httptest.NewRecorder()
+		newMetricServer(errors.New("boom")).Metrics(recorder, request)
+		assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+		assert.Empty(t, recorder.Header().Get("Content-Encoding"))
+		assert.Equal(t, internalServerError, strings.TrimSpace(recorder.Body.String()))
+	})
+}
+
+func TestStartedWriter(t *testing.T) {
+	var buf strings.Builder
+	sw := &startedWriter{w: &buf}
+
+	_, err := sw.Write(nil)
+	require.NoError(t, err)
+	assert.False(t, sw.started)
+
+	_, err = sw.Write([]byte("DCGM_FI_DEV_GPU_UTIL 1\n"))
+	require.NoError(t, err)
+	assert.True(t, sw.started)
+	assert.Equal(t, "DCGM_FI_DEV_GPU_UTIL 1\n", buf.String())
+}
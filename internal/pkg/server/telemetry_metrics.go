@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"cmp"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/transformation"
+)
+
+const telemetryMetricsFormat = `# HELP dcgm_exporter_collect_duration_seconds Duration of the last collection of each collector.
+# TYPE dcgm_exporter_collect_duration_seconds gauge
+{{- range .Collectors }}
+dcgm_exporter_collect_duration_seconds{entity="{{ .Entity.String }}",collector="{{ .Name }}"} {{ .Duration.Seconds }}
+{{- end }}
+# HELP dcgm_exporter_collect_errors_total Number of failed collections of each collector since the collectors were built.
+# TYPE dcgm_exporter_collect_errors_total counter
+{{- range .Collectors }}
+dcgm_exporter_collect_errors_total{entity="{{ .Entity.String }}",collector="{{ .Name }}"} {{ .Errors }}
+{{- end }}
+# HELP dcgm_exporter_hot_reloads_total Number of reloads by trigger and result.
+# TYPE dcgm_exporter_hot_reloads_total counter
+{{- range .Reloads }}
+dcgm_exporter_hot_reloads_total{trigger="{{ .Trigger }}",result="{{ .Result }}"} {{ .Count }}
+{{- end }}
+{{- if .HasRegistry }}
+# HELP dcgm_exporter_registry_age_seconds Time since the collectors were built by the start or the last reload.
+# TYPE dcgm_exporter_registry_age_seconds gauge
+dcgm_exporter_registry_age_seconds {{ .RegistryAge.Seconds }}
+{{- end }}
+{{- if .PodMapperCaches }}
+# HELP dcgm_exporter_pod_mapper_cache_entries Number of entries of the caches of the pod mapper.
+# TYPE dcgm_exporter_pod_mapper_cache_entries gauge
+{{- range $cache, $entries := .PodMapperCaches }}
+dcgm_exporter_pod_mapper_cache_entries{cache="{{ $cache }}"} {{ $entries }}
+{{- end }}
+{{- end }}
+`
+
+var getTelemetryMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("telemetryMetricsFormat").Parse(telemetryMetricsFormat))
+})
+
+// reloadCountValue is a number of reloads of a trigger and result
+type reloadCountValue struct {
+	Trigger string
+	Result  string
+	Count   uint64
+}
+
+// renderTelemetryMetrics writes the metrics about the exporter itself: the duration and the errors of the
+// collectors, the reloads, the age of the collectors and the sizes of the pod mapper caches.
+func (s *MetricsServer) renderTelemetryMetrics(w io.Writer, reg *registry.Registry) error {
+	if s.config == nil || !s.config.TelemetryMetrics {
+		return nil
+	}
+
+	counts := s.reloadCounts()
+	reloads := make([]reloadCountValue, 0, len(counts))
+	for count, value := range counts {
+		reloads = append(reloads, reloadCountValue{Trigger: count.Trigger, Result: count.Result, Count: value})
+	}
+	slices.SortFunc(reloads, func(a, b reloadCountValue) int {
+		return cmp.Or(cmp.Compare(a.Trigger, b.Trigger), cmp.Compare(a.Result, b.Result))
+	})
+
+	podMapperCaches := map[string]int{}
+	for _, t := range s.transformations {
+		if pm, ok := t.(*transformation.PodMapper); ok {
+			maps.Copy(podMapperCaches, pm.CacheSizes())
+		}
+	}
+
+	return getTelemetryMetricsTemplate().Execute(w, struct {
+		Collectors      []registry.CollectorStats
+		Reloads         []reloadCountValue
+		HasRegistry     bool
+		RegistryAge     time.Duration
+		PodMapperCaches map[string]int
+	}{
+		Collectors:      reg.CollectorStats(),
+		Reloads:         reloads,
+		HasRegistry:     s.HasRegistry(),
+		RegistryAge:     reg.Age(),
+		PodMapperCaches: podMapperCaches,
+	})
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockcollectorpkg "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
+)
+
+func TestRenderTelemetryMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCollector := mockcollectorpkg.NewMockCollector(ctrl)
+	mockCollector.EXPECT().GetMetrics().Return(nil, errors.New("boom"))
+
+	reg := registry.NewRegistry()
+	tuple := collector.EntityCollectorTuple{}
+	tuple.SetEntity(dcgm.FE_GPU)
+	tuple.SetCollector(mockCollector)
+	tuple.SetName(collector.DCGMCollectorName)
+	reg.Register(tuple)
+	_, err := reg.Gather()
+	require.Error(t, err)
+
+	metricServer := &MetricsServer{config: &appconfig.Config{}, deviceWatchListManager: cpuWatchListManager(ctrl, 1)}
+	var buf strings.Builder
+	assert.NoError(t, metricServer.renderTelemetryMetrics(&buf, reg))
+	assert.Empty(t, buf.String())
+
+	metricServer.config.TelemetryMetrics = true
+	metricServer.RecordReload(1, "sighup", time.Now(), nil, nil)
+	metricServer.RecordReload(2, "sighup", time.Now(), nil, nil)
+	metricServer.RecordSkippedReload(0, "file_change", "rate limited")
+	metricServer.SetRegistry(reg)
+
+	assert.NoError(t, metricServer.renderTelemetryMetrics(&buf, reg))
+	metrics := buf.String()
+	assert.Contains(t, metrics,
+		fmt.Sprintf("dcgm_exporter_collect_duration_seconds{entity=%q,collector=\"DCGM\"} ", dcgm.FE_GPU.String()))
+	assert.Contains(t, metrics,
+		fmt.Sprintf("dcgm_exporter_collect_errors_total{entity=%q,collector=\"DCGM\"} 1\n", dcgm.FE_GPU.String()))
+	assert.Contains(t, metrics, "dcgm_exporter_hot_reloads_total{trigger=\"file_change\",result=\"skipped\"} 1\n")
+	assert.Contains(t, metrics, "dcgm_exporter_hot_reloads_total{trigger=\"sighup\",result=\"success\"} 2\n")
+	assert.Contains(t, metrics, "dcgm_exporter_registry_age_seconds ")
+	assert.NotContains(t, metrics, "dcgm_exporter_pod_mapper_cache_entries", "Kubernetes is not enabled")
+
+	// No registry while reloading
+	metricServer.ClearRegistry()
+	buf.Reset()
+	assert.NoError(t, metricServer.renderTelemetryMetrics(&buf, registry.NewRegistry()))
+	assert.NotContains(t, buf.String(), "dcgm_exporter_registry_age_seconds")
+}
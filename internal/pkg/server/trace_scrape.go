@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+const (
+	// defaultTraceScrapeTimeout bounds a traced gather unless the request sets the timeout parameter
+	defaultTraceScrapeTimeout = 10 * time.Second
+	// maxTraceScrapeTimeout caps the timeout parameter, a traced gather holds the collectors like a scrape
+	maxTraceScrapeTimeout = time.Minute
+)
+
+// TraceScrape performs a single gather, collector after collector, and returns the duration and the values
+// of every field as JSON. The values are only returned to the caller, they are not written to the log.
+func (s *MetricsServer) TraceScrape(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	timeout, err := parseTraceScrapeTimeout(r.URL.Query().Get("timeout"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !s.HasRegistry() {
+		http.Error(w, "the registry is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+
+	trace := ScrapeTrace{
+		Start:   time.Now(),
+		Timeout: timeout,
+	}
+	trace.Collectors, err = s.GetRegistry().Trace(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	trace.Duration = time.Since(trace.Start)
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(trace)
+	if err != nil {
+		slog.Error("Failed to write response.", slog.String(logging.ErrorKey, err.Error()))
+	}
+}
+
+// parseTraceScrapeTimeout parses the timeout parameter of /debug/trace-scrape
+func parseTraceScrapeTimeout(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultTraceScrapeTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q", value)
+	}
+
+	return min(timeout, maxTraceScrapeTimeout), nil
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockcollector "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
+)
+
+func TestTraceScrape(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	counter := counters.Counter{FieldID: 150, FieldName: "DCGM_FI_DEV_GPU_TEMP", PromType: "gauge"}
+
+	mockCollector := mockcollector.NewMockCollector(ctrl)
+	mockCollector.EXPECT().GetMetrics().Return(collector.MetricsByCounter{
+		counter: {{GPU: "0", Counter: counter, Value: "42"}},
+	}, nil)
+
+	tuple := collector.EntityCollectorTuple{}
+	tuple.SetEntity(dcgm.FE_GPU)
+	tuple.SetCollector(mockCollector)
+	tuple.SetName(collector.DCGMCollectorName)
+
+	reg := registry.NewRegistry()
+	reg.Register(tuple)
+
+	metricServer := &MetricsServer{}
+
+	// No trace without a registry, e.g. during a reload
+	recorder := httptest.NewRecorder()
+	metricServer.TraceScrape(recorder, httptest.NewRequest(http.MethodGet, "/debug/trace-scrape", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+
+	metricServer.registry.Store(reg)
+
+	recorder = httptest.NewRecorder()
+	metricServer.TraceScrape(recorder, httptest.NewRequest(http.MethodGet, "/debug/trace-scrape?timeout=oops", nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	metricServer.TraceScrape(recorder, httptest.NewRequest(http.MethodGet, "/debug/trace-scrape?timeout=5s", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var trace ScrapeTrace
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &trace))
+	assert.Equal(t, 5*time.Second, trace.Timeout)
+	require.Len(t, trace.Collectors, 1)
+	assert.Equal(t, collector.DCGMCollectorName, trace.Collectors[0].Name)
+	require.Len(t, trace.Collectors[0].Fields, 1)
+	assert.Equal(t, "DCGM_FI_DEV_GPU_TEMP", trace.Collectors[0].Fields[0].Field)
+	assert.Equal(t, "42", trace.Collectors[0].Fields[0].Values[0].Value)
+}
+
+func Test_parseTraceScrapeTimeout(t *testing.T) {
+	timeout, err := parseTraceScrapeTimeout("")
+	require.NoError(t, err)
+	assert.Equal(t, defaultTraceScrapeTimeout, timeout)
+
+	timeout, err = parseTraceScrapeTimeout("1h")
+	require.NoError(t, err)
+	assert.Equal(t, maxTraceScrapeTimeout, timeout)
+
+	_, err = parseTraceScrapeTimeout("-1s")
+	assert.Error(t, err)
+}
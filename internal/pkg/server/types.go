@@ -17,19 +17,29 @@
 package server
 
 import (
+	"context"
+	"io"
 	"net/http"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/prometheus/exporter-toolkit/web"
 
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/debug"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/diag"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/transformation"
 )
 
+// DevicePauseHandler is called after the collection of a GPU was paused or resumed, e.g. to rebuild the watches
+type DevicePauseHandler func()
+
+// ProbeFunc writes the metrics of the remote hostengine at target in the Prometheus text format
+type ProbeFunc func(ctx context.Context, target string, w io.Writer) error
+
 type MetricsServer struct {
 	sync.RWMutex
 
@@ -37,10 +47,130 @@ type MetricsServer struct {
 	webConfig              *web.FlagConfig
 	metrics                string
 	registry               atomic.Pointer[registry.Registry]
+	canaryRegistry         atomic.Pointer[registry.Registry] // registry of the candidate counters configuration
 	config                 *appconfig.Config
+	effectiveConfig        atomic.Pointer[appconfig.Config] // redacted configuration of the last startup or reload
 	transformations        []transformation.Transform
 	deviceWatchListManager devicewatchlistmanager.Manager
 	fileDumper             *debug.FileDumper
+	diagRunner             atomic.Pointer[diag.Runner]
+	probe                  atomic.Pointer[ProbeFunc]
+	probesInFlight         atomic.Int32
+	devicePauseHandler     atomic.Pointer[DevicePauseHandler]
+
+	reloadInProgress  atomic.Bool
+	dcgmCheckInFlight atomic.Bool // whether a DCGM call of the probes didn't return yet
+
+	countersConfigInvalid atomic.Bool   // whether the last read of the counters configuration failed
+	countersConfigErrors  atomic.Uint64 // number of failed reads of the counters configuration
+
+	reloads       reloadHistory
+	metricsCache  metricsCache
+	createdTimes  createdTimes
+	familySamples familySamples
+}
+
+// familySamples are the number of samples of every family on the last gather of the collectors
+type familySamples struct {
+	sync.Mutex
+	counts map[string]int // family -> number of samples, nil until the first gather
+}
+
+// createdTimes are the times the counter series were first exported, served as their _created series
+// in the OpenMetrics format
+type createdTimes struct {
+	sync.Mutex
+	series map[string]createdTime // series identifier -> times
+}
+
+type createdTime struct {
+	created time.Time
+	seen    time.Time // last export of the series, the series unseen for a while are forgotten
+}
+
+// metricsCache is the last snapshot of the metrics of the collectors gathered in the background, served by /metrics
+type metricsCache struct {
+	sync.RWMutex
+	body []byte    // metrics of the collectors, timestamped with the start of their gather
+	time time.Time // start of the gather of body, zero until the first successful gather
+}
+
+// reloadHistory is the bounded history of the reloads served by /api/v1/reloads
+type reloadHistory struct {
+	sync.Mutex
+	records  []ReloadRecord // the oldest first
+	topology map[string]int // entity type -> number of monitored entities after the last successful reload
+	counts   map[reloadCount]uint64
+}
+
+// reloadCount identifies the reloads counted by dcgm_exporter_hot_reloads_total
+type reloadCount struct {
+	Trigger string
+	Result  string
+}
+
+// CanaryReport is the difference between the metrics gathered with the current
+// and with the candidate counters configuration.
+type CanaryReport struct {
+	CurrentSeries   int      `json:"current_series"`
+	CandidateSeries int      `json:"candidate_series"`
+	CurrentBytes    int      `json:"current_bytes"`
+	CandidateBytes  int      `json:"candidate_bytes"`
+	PayloadDelta    int      `json:"payload_delta_bytes"`
+	AddedFamilies   []string `json:"added_families"`
+	RemovedFamilies []string `json:"removed_families"`
+	AddedSeries     []string `json:"added_series"`
+	RemovedSeries   []string `json:"removed_series"`
+}
+
+// DiagnosticSnapshot is the state of the exporter dumped on request, e.g. on SIGUSR2
+type DiagnosticSnapshot struct {
+	Time              time.Time      `json:"time"`
+	ReloadInProgress  bool           `json:"reload_in_progress"`
+	RegistryAvailable bool           `json:"registry_available"`
+	Collectors        []string       `json:"collectors"` // <entity type>/<collector name>
+	Topology          map[string]int `json:"topology"`   // entity type -> number of monitored entities
+	Watchers          map[string]any `json:"watchers"`
+	Goroutines        string         `json:"goroutines"`
+}
+
+// DiagStatus is the state of the DCGM diagnostics served by /diag
+type DiagStatus struct {
+	InProgress bool         `json:"in_progress"`
+	Report     *diag.Report `json:"report"` // report of the last completed run
+}
+
+// ReloadRecord is a reload of the reload history served by /api/v1/reloads
+type ReloadRecord struct {
+	ID              uint64         `json:"id,omitempty"` // reload_id of the logs, unset if skipped before being assigned one
+	Trigger         string         `json:"trigger"`
+	Start           time.Time      `json:"start"`
+	DurationSeconds float64        `json:"duration_seconds"`
+	Result          string         `json:"result"`
+	Error           string         `json:"error,omitempty"`
+	Reason          string         `json:"reason,omitempty"`         // why the reload was skipped
+	Topology        map[string]int `json:"topology,omitempty"`       // entity type -> number of monitored entities
+	TopologyDelta   map[string]int `json:"topology_delta,omitempty"` // entity type -> change of monitored entities
+}
+
+// DevicePauseStatus is the state of the collection of a GPU served by /api/v1/devices
+type DevicePauseStatus struct {
+	UUID   string    `json:"uuid"`
+	Paused bool      `json:"paused"`
+	Since  time.Time `json:"since,omitzero"` // time the collection was paused
+}
+
+// MaintenanceStatus is the maintenance window of the node served by /-/maintenance
+type MaintenanceStatus struct {
+	Active bool      `json:"active"`
+	Start  time.Time `json:"start,omitzero"`
+	End    time.Time `json:"end,omitzero"` // time the window ends by itself
+}
 
-	reloadInProgress atomic.Bool
+// ScrapeTrace is a gather traced on request, served by /debug/trace-scrape
+type ScrapeTrace struct {
+	Start      time.Time                 `json:"start"`
+	Timeout    time.Duration             `json:"timeout_ns"`
+	Duration   time.Duration             `json:"duration_ns"`
+	Collectors []registry.CollectorTrace `json:"collectors"`
 }
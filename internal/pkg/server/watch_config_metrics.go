@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"io"
+	"sync"
+	"text/template"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatcher"
+)
+
+const watchConfigMetricsFormat = `# HELP dcgm_exporter_dcgm_watched_fields Number of distinct DCGM fields watched for each entity type.
+# TYPE dcgm_exporter_dcgm_watched_fields gauge
+{{- range . }}
+dcgm_exporter_dcgm_watched_fields{entity="{{ .Entity.String }}"} {{ .Fields }}
+{{- end }}
+# HELP dcgm_exporter_dcgm_watch_groups Number of DCGM entity groups watching fields for each entity type.
+# TYPE dcgm_exporter_dcgm_watch_groups gauge
+{{- range . }}
+dcgm_exporter_dcgm_watch_groups{entity="{{ .Entity.String }}"} {{ .Groups }}
+{{- end }}
+# HELP dcgm_exporter_dcgm_watched_entities Number of entities watched by DCGM for each entity type.
+# TYPE dcgm_exporter_dcgm_watched_entities gauge
+{{- range . }}
+dcgm_exporter_dcgm_watched_entities{entity="{{ .Entity.String }}"} {{ .Entities }}
+{{- end }}
+# HELP dcgm_exporter_dcgm_watches Number of DCGM field groups watched for each entity type, one per collector and update interval.
+# TYPE dcgm_exporter_dcgm_watches gauge
+{{- range . }}
+dcgm_exporter_dcgm_watches{entity="{{ .Entity.String }}"} {{ .Watches }}
+{{- end }}
+# HELP dcgm_exporter_dcgm_watch_update_interval_seconds Shortest update interval of the DCGM watches for each entity type.
+# TYPE dcgm_exporter_dcgm_watch_update_interval_seconds gauge
+{{- range . }}
+dcgm_exporter_dcgm_watch_update_interval_seconds{entity="{{ .Entity.String }}"} {{ .UpdateInterval.Seconds }}
+{{- end }}
+`
+
+var getWatchConfigMetricsTemplate = sync.OnceValue(func() *template.Template {
+	return template.Must(template.New("watchConfigMetricsFormat").Parse(watchConfigMetricsFormat))
+})
+
+// watchStats returns the DCGM watch configuration of the entity types
+var watchStats = devicewatcher.GetWatchStats
+
+// renderWatchConfigMetrics writes the DCGM watch configuration of the entity types, for the capacity planning
+// of the hostengine, once the collectors watch fields
+func (s *MetricsServer) renderWatchConfigMetrics(w io.Writer) error {
+	stats := watchStats()
+	if len(stats) == 0 {
+		return nil
+	}
+	return getWatchConfigMetricsTemplate().Execute(w, stats)
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatcher"
+)
+
+func TestRenderWatchConfigMetrics(t *testing.T) {
+	metricServer := &MetricsServer{}
+	var stats []devicewatcher.WatchStats
+	watchStats = func() []devicewatcher.WatchStats { return stats }
+	t.Cleanup(func() { watchStats = devicewatcher.GetWatchStats })
+
+	var buf strings.Builder
+	assert.NoError(t, metricServer.renderWatchConfigMetrics(&buf))
+	assert.Empty(t, buf.String(), "Nothing is rendered without watches")
+
+	stats = []devicewatcher.WatchStats{
+		{Entity: dcgm.FE_GPU, Fields: 3, Groups: 2, Entities: 8, Watches: 2, UpdateInterval: 500 * time.Millisecond},
+	}
+	assert.NoError(t, metricServer.renderWatchConfigMetrics(&buf))
+	assert.Equal(t, `# HELP dcgm_exporter_dcgm_watched_fields Number of distinct DCGM fields watched for each entity type.
+# TYPE dcgm_exporter_dcgm_watched_fields gauge
+dcgm_exporter_dcgm_watched_fields{entity="GPU"} 3
+# HELP dcgm_exporter_dcgm_watch_groups Number of DCGM entity groups watching fields for each entity type.
+# TYPE dcgm_exporter_dcgm_watch_groups gauge
+dcgm_exporter_dcgm_watch_groups{entity="GPU"} 2
+# HELP dcgm_exporter_dcgm_watched_entities Number of entities watched by DCGM for each entity type.
+# TYPE dcgm_exporter_dcgm_watched_entities gauge
+dcgm_exporter_dcgm_watched_entities{entity="GPU"} 8
+# HELP dcgm_exporter_dcgm_watches Number of DCGM field groups watched for each entity type, one per collector and update interval.
+# TYPE dcgm_exporter_dcgm_watches gauge
+dcgm_exporter_dcgm_watches{entity="GPU"} 2
+# HELP dcgm_exporter_dcgm_watch_update_interval_seconds Shortest update interval of the DCGM watches for each entity type.
+# TYPE dcgm_exporter_dcgm_watch_update_interval_seconds gauge
+dcgm_exporter_dcgm_watch_update_interval_seconds{entity="GPU"} 0.5
+`, buf.String())
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package soak
+
+import "time"
+
+const (
+	// DefaultDuration is the duration of a soak test.
+	DefaultDuration = time.Hour
+	// DefaultInterval is the interval between the scrapes of a soak test.
+	DefaultInterval = time.Second
+
+	// progressInterval is the interval of the progress logs of a soak test.
+	progressInterval = time.Minute
+
+	// maxSamples bounds the latencies kept to compute the quantiles, the latencies of longer soak
+	// tests are sampled uniformly.
+	maxSamples = 100000
+	// maxErrorMessages bounds the distinct error messages counted, the others are counted together.
+	maxErrorMessages = 20
+	otherErrors      = "other"
+)
+
+// latencyBuckets are the upper bounds of the latency distribution of the report
+var latencyBuckets = []time.Duration{
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package soak
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"text/tabwriter"
+	"time"
+)
+
+// WriteJSON writes the report as JSON, the durations are in nanoseconds and the memory in bytes
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteText writes the report in a human-readable form
+func (r *Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	result := "PASSED"
+	if !r.Passed {
+		result = "FAILED"
+	}
+	fmt.Fprintf(tw, "Soak test %s\n\n", result)
+	fmt.Fprintf(tw, "Start:\t%s\n", r.Start.Format(time.RFC3339))
+	fmt.Fprintf(tw, "End:\t%s\n", r.End.Format(time.RFC3339))
+	fmt.Fprintf(tw, "Interval:\t%s\n", r.Interval)
+	fmt.Fprintf(tw, "Scrapes:\t%d\n", r.Scrapes)
+	fmt.Fprintf(tw, "Failures:\t%d (%.2f%%)\n", r.Failures, r.failureRatio()*100)
+
+	fmt.Fprintf(tw, "\nLatency\tmin\tmean\tp50\tp90\tp99\tmax\n")
+	fmt.Fprintf(tw, "\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		r.Latency.Min, r.Latency.Mean, r.Latency.P50, r.Latency.P90, r.Latency.P99, r.Latency.Max)
+
+	fmt.Fprintf(tw, "\nLatency distribution\tscrapes\n")
+	for i, bucket := range r.Buckets {
+		if i < len(r.Buckets)-1 {
+			fmt.Fprintf(tw, "<= %s\t%d\n", bucket.UpperBound, bucket.Count)
+		} else if i > 0 {
+			fmt.Fprintf(tw, "> %s\t%d\n", r.Buckets[i-1].UpperBound, bucket.Count)
+		}
+	}
+
+	fmt.Fprintf(tw, "\nMemory (MiB)\tstart\tmax\tend\n")
+	if r.Memory.RSSMax > 0 {
+		fmt.Fprintf(tw, "RSS\t%s\t%s\t%s\n", mib(r.Memory.RSSStart), mib(r.Memory.RSSMax), mib(r.Memory.RSSEnd))
+	}
+	fmt.Fprintf(tw, "Heap\t%s\t%s\t%s\n", mib(r.Memory.HeapStart), mib(r.Memory.HeapMax), mib(r.Memory.HeapEnd))
+
+	fmt.Fprintf(tw, "\nResponse size (bytes)\tmin\tmean\tmax\n")
+	fmt.Fprintf(tw, "\t%d\t%d\t%d\n", r.Bytes.Min, r.Bytes.Mean, r.Bytes.Max)
+
+	if len(r.Errors) > 0 {
+		fmt.Fprintf(tw, "\nErrors\tscrapes\n")
+		for _, message := range r.sortedErrors() {
+			fmt.Fprintf(tw, "%s\t%d\n", message, r.Errors[message])
+		}
+	}
+
+	return tw.Flush()
+}
+
+func (r *Report) failureRatio() float64 {
+	if r.Scrapes == 0 {
+		return 0
+	}
+	return float64(r.Failures) / float64(r.Scrapes)
+}
+
+// sortedErrors returns the error messages, the most frequent first
+func (r *Report) sortedErrors() []string {
+	return slices.SortedFunc(maps.Keys(r.Errors), func(a, b string) int {
+		if c := cmp.Compare(r.Errors[b], r.Errors[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+}
+
+func mib(bytes uint64) string {
+	return fmt.Sprintf("%.1f", float64(bytes)/(1<<20))
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package soak
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+// Run scrapes the metrics every interval until the duration elapsed or the context is canceled, and
+// returns the report of the scrapes. The soak test passes when at least one scrape was done and the
+// ratio of failed scrapes does not exceed MaxErrorRatio.
+func Run(ctx context.Context, options Options) *Report {
+	if options.Duration <= 0 {
+		options.Duration = DefaultDuration
+	}
+	if options.Interval <= 0 {
+		options.Interval = DefaultInterval
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, options.Duration)
+	defer cancel()
+
+	slog.Info("Starting soak test",
+		slog.Duration("duration", options.Duration),
+		slog.Duration("interval", options.Interval))
+
+	r := newRecorder(time.Now(), options.Interval, options.ReadRSS)
+	ticker := time.NewTicker(options.Interval)
+	defer ticker.Stop()
+
+	lastProgress := r.report.Start
+	for {
+		r.scrape(options.Scrape)
+
+		if time.Since(lastProgress) >= progressInterval {
+			lastProgress = time.Now()
+			slog.Info("Soak test in progress",
+				slog.Int("scrapes", r.report.Scrapes),
+				slog.Int("failures", r.report.Failures),
+				slog.Duration("maxLatency", r.report.Latency.Max))
+		}
+
+		select {
+		case <-ctx.Done():
+			return r.finish(time.Now(), options.MaxErrorRatio)
+		case <-ticker.C:
+		}
+	}
+}
+
+func newRecorder(start time.Time, interval time.Duration, readRSS func() (uint64, error)) *recorder {
+	return &recorder{
+		report: Report{
+			Start:    start,
+			Interval: interval,
+			Errors:   map[string]int{},
+			Buckets:  newBuckets(),
+		},
+		rand:    rand.New(rand.NewPCG(uint64(start.UnixNano()), 0)),
+		readRSS: readRSS,
+	}
+}
+
+func newBuckets() []LatencyBucket {
+	buckets := make([]LatencyBucket, len(latencyBuckets)+1)
+	for i, upperBound := range latencyBuckets {
+		buckets[i].UpperBound = upperBound
+	}
+	return buckets
+}
+
+// scrape renders the metrics once and records the latency, the size and the error of the scrape and
+// the memory of the process after it
+func (r *recorder) scrape(scrape func(w io.Writer) error) {
+	var w countingWriter
+	start := time.Now()
+	err := scrape(&w)
+	r.record(time.Since(start), int(w), err)
+	r.sampleMemory()
+}
+
+func (r *recorder) record(latency time.Duration, size int, err error) {
+	report := &r.report
+	report.Scrapes++
+
+	if err != nil {
+		report.Failures++
+		message := err.Error()
+		if _, exists := report.Errors[message]; !exists && len(report.Errors) >= maxErrorMessages {
+			message = otherErrors
+		}
+		report.Errors[message]++
+	} else {
+		if report.Scrapes == report.Failures+1 || size < report.Bytes.Min {
+			report.Bytes.Min = size
+		}
+		report.Bytes.Max = max(report.Bytes.Max, size)
+		r.bytes += size
+	}
+
+	if report.Scrapes == 1 || latency < report.Latency.Min {
+		report.Latency.Min = latency
+	}
+	report.Latency.Max = max(report.Latency.Max, latency)
+	r.total += latency
+
+	// The first bucket whose upper bound is not below the latency, or the last bucket without bound
+	bucket, _ := slices.BinarySearch(latencyBuckets, latency)
+	report.Buckets[bucket].Count++
+
+	// Reservoir sampling keeps a uniform sample of the latencies of long soak tests
+	r.seen++
+	if len(r.samples) < maxSamples {
+		r.samples = append(r.samples, latency)
+	} else if i := r.rand.IntN(r.seen); i < maxSamples {
+		r.samples[i] = latency
+	}
+}
+
+func (r *recorder) sampleMemory() {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	memory := &r.report.Memory
+	if r.memories == 0 {
+		memory.HeapStart = stats.HeapAlloc
+	}
+	memory.HeapMax = max(memory.HeapMax, stats.HeapAlloc)
+	memory.HeapEnd = stats.HeapAlloc
+	r.memories++
+
+	if r.readRSS == nil {
+		return
+	}
+	rss, err := r.readRSS()
+	if err != nil {
+		slog.Warn("Failed to read the RSS, the soak test report will not include it",
+			slog.String(logging.ErrorKey, err.Error()))
+		r.readRSS = nil
+		return
+	}
+	if memory.RSSStart == 0 {
+		memory.RSSStart = rss
+	}
+	memory.RSSMax = max(memory.RSSMax, rss)
+	memory.RSSEnd = rss
+}
+
+func (r *recorder) finish(end time.Time, maxErrorRatio float64) *Report {
+	report := &r.report
+	report.End = end
+
+	if report.Scrapes > 0 {
+		report.Latency.Mean = r.total / time.Duration(report.Scrapes)
+	}
+	if successes := report.Scrapes - report.Failures; successes > 0 {
+		report.Bytes.Mean = r.bytes / successes
+	}
+
+	slices.Sort(r.samples)
+	report.Latency.P50 = quantile(r.samples, 0.5)
+	report.Latency.P90 = quantile(r.samples, 0.9)
+	report.Latency.P99 = quantile(r.samples, 0.99)
+
+	report.Passed = report.Scrapes > 0 && float64(report.Failures) <= maxErrorRatio*float64(report.Scrapes)
+	return report
+}
+
+// quantile returns the nearest-rank quantile q of the sorted latencies
+func quantile(sorted []time.Duration, q float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(q * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+// countingWriter discards the rendered metrics, counting their bytes
+type countingWriter int
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	*w += countingWriter(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package soak
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	scrapes := 0
+	report := Run(context.Background(), Options{
+		Duration: 100 * time.Millisecond,
+		Interval: 10 * time.Millisecond,
+		Scrape: func(w io.Writer) error {
+			scrapes++
+			if scrapes%2 == 0 {
+				return errors.New("no GPU")
+			}
+			_, err := io.WriteString(w, "DCGM_FI_DEV_GPU_TEMP 42\n")
+			return err
+		},
+		ReadRSS: func() (uint64, error) { return 64 << 20, nil },
+	})
+
+	require.Equal(t, scrapes, report.Scrapes)
+	assert.Greater(t, report.Scrapes, 1)
+	assert.Equal(t, report.Scrapes/2, report.Failures)
+	assert.Equal(t, map[string]int{"no GPU": report.Failures}, report.Errors)
+	assert.Equal(t, ResponseSizeStats{Min: 24, Mean: 24, Max: 24}, report.Bytes)
+	assert.Equal(t, uint64(64<<20), report.Memory.RSSMax)
+	assert.NotZero(t, report.Memory.HeapMax)
+	assert.False(t, report.Passed, "failures above the default ratio of 0")
+	assert.True(t, report.End.After(report.Start))
+}
+
+func TestRun_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := Run(ctx, Options{
+		Duration: time.Hour,
+		Interval: time.Hour,
+		Scrape:   func(io.Writer) error { return nil },
+	})
+
+	assert.Equal(t, 1, report.Scrapes)
+	assert.True(t, report.Passed)
+	assert.Zero(t, report.Memory.RSSMax, "no RSS without reader")
+}
+
+func TestRecorder(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := newRecorder(start, time.Second, nil)
+
+	for i := 1; i <= 100; i++ {
+		r.record(time.Duration(i)*time.Millisecond, 1000+i, nil)
+	}
+	r.record(20*time.Second, 0, errors.New("timeout"))
+
+	report := r.finish(start.Add(time.Minute), 0.01)
+
+	assert.Equal(t, 101, report.Scrapes)
+	assert.Equal(t, 1, report.Failures)
+	assert.True(t, report.Passed)
+	assert.Equal(t, LatencySummary{
+		Min:  time.Millisecond,
+		Mean: (5050*time.Millisecond + 20*time.Second) / 101,
+		P50:  51 * time.Millisecond,
+		P90:  91 * time.Millisecond,
+		P99:  100 * time.Millisecond,
+		Max:  20 * time.Second,
+	}, report.Latency)
+	assert.Equal(t, ResponseSizeStats{Min: 1001, Mean: 1050, Max: 1100}, report.Bytes)
+
+	counts := make([]int, len(report.Buckets))
+	for i, bucket := range report.Buckets {
+		counts[i] = bucket.Count
+	}
+	// <=10ms, <=25ms, <=50ms, <=100ms, ..., >10s
+	assert.Equal(t, []int{10, 15, 25, 50, 0, 0, 0, 0, 0, 0, 1}, counts)
+
+	assert.False(t, r.finish(start.Add(time.Minute), 0).Passed)
+}
+
+func TestRecorder_Errors(t *testing.T) {
+	r := newRecorder(time.Now(), time.Second, nil)
+	for i := 0; i < maxErrorMessages+5; i++ {
+		r.record(time.Millisecond, 0, fmt.Errorf("error %d", i))
+	}
+	r.record(time.Millisecond, 0, errors.New("error 0"))
+
+	assert.Len(t, r.report.Errors, maxErrorMessages+1)
+	assert.Equal(t, 2, r.report.Errors["error 0"])
+	assert.Equal(t, 5, r.report.Errors[otherErrors])
+}
+
+func TestRecorder_Reservoir(t *testing.T) {
+	r := newRecorder(time.Now(), time.Second, nil)
+	for i := 0; i < maxSamples+1000; i++ {
+		r.record(time.Millisecond, 0, nil)
+	}
+
+	assert.Len(t, r.samples, maxSamples)
+	assert.Equal(t, maxSamples+1000, r.seen)
+}
+
+func TestRecorder_sampleMemory_ReadError(t *testing.T) {
+	r := newRecorder(time.Now(), time.Second, func() (uint64, error) { return 0, errors.New("no procfs") })
+	r.sampleMemory()
+	r.sampleMemory()
+
+	assert.Nil(t, r.readRSS)
+	assert.Zero(t, r.report.Memory.RSSMax)
+	assert.NotZero(t, r.report.Memory.HeapStart)
+}
+
+func TestQuantile(t *testing.T) {
+	assert.Zero(t, quantile(nil, 0.5))
+	assert.Equal(t, time.Second, quantile([]time.Duration{time.Second}, 0.99))
+	assert.Equal(t, 2*time.Second, quantile([]time.Duration{time.Second, 2 * time.Second}, 0.99))
+	assert.Equal(t, time.Second, quantile([]time.Duration{time.Second, 2 * time.Second}, 0.5))
+}
+
+func TestReport_Write(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := newRecorder(start, time.Second, func() (uint64, error) { return 128 << 20, nil })
+	r.record(30*time.Millisecond, 2048, nil)
+	r.record(40*time.Millisecond, 0, errors.New("field not watched"))
+	r.record(40*time.Millisecond, 0, errors.New("field not watched"))
+	r.record(50*time.Millisecond, 0, errors.New("connection lost"))
+	r.sampleMemory()
+	report := r.finish(start.Add(time.Hour), 0)
+
+	var text bytes.Buffer
+	require.NoError(t, report.WriteText(&text))
+	assert.Contains(t, text.String(), "Soak test FAILED")
+	assert.Contains(t, text.String(), "Failures:  3 (75.00%)")
+	assert.Contains(t, text.String(), "<= 50ms")
+	assert.Contains(t, text.String(), "> 10s")
+	assert.Contains(t, text.String(), "RSS")
+	assert.Regexp(t, `field not watched\s+2\nconnection lost\s+1\n`, text.String())
+
+	var decoded Report
+	var encoded bytes.Buffer
+	require.NoError(t, report.WriteJSON(&encoded))
+	require.NoError(t, json.Unmarshal(encoded.Bytes(), &decoded))
+	assert.Equal(t, report.Scrapes, decoded.Scrapes)
+	assert.Equal(t, report.Errors, decoded.Errors)
+	assert.Equal(t, report.Latency, decoded.Latency)
+	assert.Equal(t, uint64(128<<20), decoded.Memory.RSSMax)
+}
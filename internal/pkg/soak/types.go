@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package soak
+
+import (
+	"io"
+	"math/rand/v2"
+	"time"
+)
+
+// Options configures a soak test
+type Options struct {
+	Duration      time.Duration
+	Interval      time.Duration
+	MaxErrorRatio float64 // Ratio of failed scrapes above which the soak test fails
+
+	Scrape  func(w io.Writer) error // Renders the metrics once
+	ReadRSS func() (uint64, error)  // Reads the RSS of the process; nil skips the RSS
+}
+
+// Report summarizes a soak test
+type Report struct {
+	Start    time.Time         `json:"start"`
+	End      time.Time         `json:"end"`
+	Interval time.Duration     `json:"interval"`
+	Scrapes  int               `json:"scrapes"`
+	Failures int               `json:"failures"`
+	Errors   map[string]int    `json:"errors,omitempty"` // error message -> number of scrapes
+	Latency  LatencySummary    `json:"latency"`
+	Buckets  []LatencyBucket   `json:"buckets"`
+	Memory   MemorySummary     `json:"memory"`
+	Bytes    ResponseSizeStats `json:"bytes"`
+	Passed   bool              `json:"passed"`
+}
+
+// LatencySummary holds the statistics of the scrape latencies
+type LatencySummary struct {
+	Min  time.Duration `json:"min"`
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P99  time.Duration `json:"p99"`
+	Max  time.Duration `json:"max"`
+}
+
+// LatencyBucket is the number of scrapes with a latency up to UpperBound and above the previous bucket,
+// the last bucket has no upper bound
+type LatencyBucket struct {
+	UpperBound time.Duration `json:"upperBound,omitempty"`
+	Count      int           `json:"count"`
+}
+
+// MemorySummary holds the memory of the process at the start, the peak and the end of the soak test
+type MemorySummary struct {
+	RSSStart  uint64 `json:"rssStart,omitempty"`
+	RSSMax    uint64 `json:"rssMax,omitempty"`
+	RSSEnd    uint64 `json:"rssEnd,omitempty"`
+	HeapStart uint64 `json:"heapStart"`
+	HeapMax   uint64 `json:"heapMax"`
+	HeapEnd   uint64 `json:"heapEnd"`
+}
+
+// ResponseSizeStats holds the sizes in bytes of the rendered metrics
+type ResponseSizeStats struct {
+	Min  int `json:"min"`
+	Mean int `json:"mean"`
+	Max  int `json:"max"`
+}
+
+// recorder accumulates the results of the scrapes of a soak test
+type recorder struct {
+	report   Report
+	samples  []time.Duration // reservoir of the latencies
+	seen     int             // scrapes offered to the reservoir
+	total    time.Duration
+	bytes    int
+	rand     *rand.Rand
+	readRSS  func() (uint64, error)
+	memories int // memory samples taken
+}
@@ -17,58 +17,60 @@
 package stdout
 
 import (
-	"bufio"
 	"context"
-	"log/slog"
+	"io"
 	"os"
 	"syscall"
+	"time"
 )
 
-// Capture go and C stdout and stderr and writes to std output
-func Capture(ctx context.Context, inner func() error) error {
-	stdout, err := syscall.Dup(syscall.Stdout)
+// drainTimeout bounds how long Capture waits on return for the captured output to be written
+const drainTimeout = time.Second
+
+// Capture go and C stdout and stderr and writes to std output.
+// The output is streamed through a pipe with bounded buffering (see logPipe), so that large log volumes
+// neither grow the memory of the process nor get lost: writers block while the buffer is full.
+func Capture(ctx context.Context, inner func() error) (err error) {
+	fd, err := syscall.Dup(syscall.Stdout)
 	if err != nil {
 		return err
 	}
+	stdout := os.NewFile(uintptr(fd), "/dev/stdout")
+	defer stdout.Close()
 
 	r, w, err := os.Pipe()
 	if err != nil {
 		return err
 	}
 
+	// Raw output is written to the original stdout: os.Stdout itself is redirected to the pipe,
+	// unless it was replaced by another file.
+	var output io.Writer = os.Stdout
+	if os.Stdout.Fd() == uintptr(syscall.Stdout) {
+		output = stdout
+	}
+
 	err = syscall.Dup3(int(w.Fd()), syscall.Stdout, 0)
 	if err != nil {
+		_ = r.Close()
+		_ = w.Close()
 		return err
 	}
 
-	defer func() {
-		ierr := syscall.Close(syscall.Stdout)
-		if ierr != nil {
-			err = ierr
-		}
+	pipe := newLogPipe(r, output)
+	pipe.start(ctx)
 
-		ierr = syscall.Dup3(stdout, syscall.Stdout, 0)
-		if ierr != nil {
+	defer func() {
+		// Restoring stdout and closing the write end of the pipe lets the pipe reach EOF
+		ierr := syscall.Dup3(fd, syscall.Stdout, 0)
+		if ierr != nil && err == nil {
 			err = ierr
 		}
-	}()
+		_ = w.Close()
 
-	scanner := bufio.NewScanner(r)
-	go func() {
-		for scanner.Scan() {
-			if ctx.Err() != nil {
-				return
-			}
-			logEntry := scanner.Text()
-			parsedLogEntry := parseOutputEntry(logEntry)
-			if parsedLogEntry.IsRawString {
-				_, err := os.Stdout.Write([]byte(parsedLogEntry.Message + "\n"))
-				if err != nil {
-					return
-				}
-				continue
-			}
-			slog.LogAttrs(ctx, slog.LevelInfo, parsedLogEntry.Message, slog.String("dcgm_level", parsedLogEntry.Level))
+		if !pipe.wait(drainTimeout) {
+			// A child process may still hold the write end of the pipe open
+			_ = r.Close()
 		}
 	}()
 
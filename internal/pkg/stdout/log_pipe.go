@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdout
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+)
+
+const (
+	// maxLineLength bounds the memory used for a single line, longer lines are split
+	maxLineLength = 64 * 1024
+	// lineBufferSize bounds the number of lines buffered between the pipe and the logger.
+	// When the buffer is full, reading from the pipe pauses and writes to stdout block until the logger catches up.
+	lineBufferSize = 1024
+)
+
+// logPipe streams the lines written to a pipe into the logger: DCGM log entries are logged with slog,
+// any other output is written to output as is.
+type logPipe struct {
+	reader io.Reader
+	output io.Writer
+	lines  chan string
+	done   chan struct{}
+}
+
+func newLogPipe(reader io.Reader, output io.Writer) *logPipe {
+	return &logPipe{
+		reader: reader,
+		output: output,
+		lines:  make(chan string, lineBufferSize),
+		done:   make(chan struct{}),
+	}
+}
+
+// start starts reading the pipe until EOF. The context is only used for logging:
+// the pipe is read after cancellation too, otherwise the writers to the pipe would block.
+func (p *logPipe) start(ctx context.Context) {
+	go p.read()
+	go p.write(ctx)
+}
+
+// wait waits until all lines were written, and returns false if it timed out.
+func (p *logPipe) wait(timeout time.Duration) bool {
+	select {
+	case <-p.done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+func (p *logPipe) read() {
+	defer close(p.lines)
+
+	reader := bufio.NewReaderSize(p.reader, maxLineLength)
+	for {
+		line, err := reader.ReadSlice('\n')
+		if len(line) > 0 {
+			p.lines <- string(trimEOL(line))
+		}
+		if err != nil && !errors.Is(err, bufio.ErrBufferFull) {
+			return
+		}
+	}
+}
+
+func (p *logPipe) write(ctx context.Context) {
+	defer close(p.done)
+
+	for line := range p.lines {
+		parsedLogEntry := parseOutputEntry(line)
+		if parsedLogEntry.IsRawString {
+			// Keep draining the pipe when the output is not writable
+			_, _ = p.output.Write([]byte(parsedLogEntry.Message + "\n"))
+			continue
+		}
+		slog.LogAttrs(ctx, slog.LevelInfo, parsedLogEntry.Message, slog.String("dcgm_level", parsedLogEntry.Level))
+	}
+}
+
+func trimEOL(line []byte) []byte {
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	return bytes.TrimSuffix(line, []byte("\r"))
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package stdout
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogPipe(t *testing.T) {
+	t.Run("lines are written in order", func(t *testing.T) {
+		var input strings.Builder
+		var want strings.Builder
+		// More lines than the buffer holds
+		for i := 0; i < 2*lineBufferSize; i++ {
+			_, _ = fmt.Fprintf(&input, "line %d\r\n", i)
+			_, _ = fmt.Fprintf(&want, "line %d\n", i)
+		}
+
+		var output bytes.Buffer
+		pipe := newLogPipe(strings.NewReader(input.String()), &output)
+		pipe.start(context.Background())
+		require.True(t, pipe.wait(time.Second))
+		assert.Equal(t, want.String(), output.String())
+	})
+
+	t.Run("long lines are split", func(t *testing.T) {
+		line := strings.Repeat("a", maxLineLength+10)
+
+		var output bytes.Buffer
+		pipe := newLogPipe(strings.NewReader(line+"\n"), &output)
+		pipe.start(context.Background())
+		require.True(t, pipe.wait(time.Second))
+		assert.Equal(t, line[:maxLineLength]+"\n"+line[maxLineLength:]+"\n", output.String())
+	})
+
+	t.Run("the pipe is drained after the context is canceled", func(t *testing.T) {
+		r, w := io.Pipe()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var output bytes.Buffer
+		pipe := newLogPipe(r, &output)
+		pipe.start(ctx)
+
+		_, err := w.Write([]byte("hello from dcgm\n"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		require.True(t, pipe.wait(time.Second))
+		assert.Equal(t, "hello from dcgm\n", output.String())
+	})
+
+	t.Run("wait times out while the pipe is open", func(t *testing.T) {
+		r, w := io.Pipe()
+		defer w.Close()
+
+		pipe := newLogPipe(r, io.Discard)
+		pipe.start(context.Background())
+		assert.False(t, pipe.wait(10*time.Millisecond))
+	})
+}
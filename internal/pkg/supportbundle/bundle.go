@@ -0,0 +1,282 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/exec"
+)
+
+// NewBuilder creates a builder gathering the sources configured by opts.
+func NewBuilder(opts Options) *Builder {
+	if opts.ExporterURL == "" {
+		opts.ExporterURL = DefaultExporterURL
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	return &Builder{
+		opts:   opts,
+		client: &http.Client{Timeout: opts.Timeout},
+		exec:   exec.RealExec{},
+		now:    time.Now,
+	}
+}
+
+// Create writes the bundle to the output file and returns its path.
+func (b *Builder) Create(ctx context.Context) (string, error) {
+	output := b.opts.Output
+	if output == "" {
+		output = fmt.Sprintf("dcgm-exporter-support-%s.tar.gz", b.now().Format("20060102-150405"))
+	}
+
+	file, err := os.Create(output)
+	if err != nil {
+		return "", fmt.Errorf("failed to create the support bundle: %w", err)
+	}
+	defer file.Close()
+
+	if err = b.Write(ctx, file); err != nil {
+		return "", err
+	}
+	return output, file.Close()
+}
+
+// Write gathers the sources and writes them as a gzipped tarball to w, followed by the manifest.
+func (b *Builder) Write(ctx context.Context, w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	hostname, _ := os.Hostname()
+	manifest := Manifest{
+		CreatedAt: b.now(),
+		Hostname:  hostname,
+		Version:   b.opts.Version,
+		Entries:   []Entry{},
+	}
+
+	add := func(name, source string, data []byte, truncated bool, err error) error {
+		entry := Entry{Name: name, Source: source, Truncated: truncated}
+		if err != nil {
+			slog.Warn("Failed to gather a support bundle source",
+				slog.String("source", source),
+				slog.String("error", err.Error()))
+			entry.Error = err.Error()
+			manifest.Entries = append(manifest.Entries, entry)
+			return nil
+		}
+		entry.Size = len(data)
+		manifest.Entries = append(manifest.Entries, entry)
+		return b.writeFile(tw, name, data)
+	}
+
+	versions, err := json.MarshalIndent(map[string]string{
+		"dcgm_exporter": b.opts.Version,
+		"go":            runtime.Version(),
+		"os":            runtime.GOOS,
+		"arch":          runtime.GOARCH,
+	}, "", "  ")
+	if err = add("versions/dcgm-exporter.json", "build", versions, false, err); err != nil {
+		return err
+	}
+
+	for _, e := range endpoints {
+		data, err := b.fetch(ctx, e.path)
+		if err = add(e.name, b.opts.ExporterURL+e.path, data, false, err); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range commands {
+		data, err := b.run(ctx, c.args)
+		if err = add(c.name, strings.Join(c.args, " "), data, false, err); err != nil {
+			return err
+		}
+	}
+
+	for _, path := range b.opts.LogFiles {
+		data, truncated, err := readTail(path)
+		if err = add("logs/"+filepath.Base(path), path, data, truncated, err); err != nil {
+			return err
+		}
+	}
+
+	if b.opts.DumpDirectory != "" {
+		dumps, err := recentFiles(b.opts.DumpDirectory, maxDumpFiles)
+		if err != nil {
+			if err = add("dumps", b.opts.DumpDirectory, nil, false, err); err != nil {
+				return err
+			}
+		}
+		for _, path := range dumps {
+			data, truncated, err := readTail(path)
+			if err = add("dumps/"+filepath.Base(path), path, data, truncated, err); err != nil {
+				return err
+			}
+		}
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode the support bundle manifest: %w", err)
+	}
+	if err = b.writeFile(tw, manifestName, data); err != nil {
+		return err
+	}
+
+	if err = tw.Close(); err != nil {
+		return fmt.Errorf("failed to close the support bundle: %w", err)
+	}
+	return gw.Close()
+}
+
+func (b *Builder) writeFile(tw *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: b.now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s to the support bundle: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to the support bundle: %w", name, err)
+	}
+	return nil
+}
+
+// fetch reads an endpoint of the running exporter.
+func (b *Builder) fetch(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.opts.ExporterURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return data, nil
+}
+
+// run returns the output of a command. The command keeps running in the background when it
+// outlasts the timeout, as the exec wrapper cannot cancel it.
+func (b *Builder) run(ctx context.Context, args []string) ([]byte, error) {
+	type result struct {
+		data []byte
+		err  error
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, b.opts.Timeout)
+	defer cancel()
+
+	done := make(chan result, 1)
+	go func() {
+		data, err := b.exec.Command(args[0], args[1:]...).Output()
+		done <- result{data: data, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.data, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// readTail reads a file, keeping only its last maxFileSize bytes.
+func readTail(path string) ([]byte, bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, false, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, false, err
+	}
+
+	truncated := info.Size() > maxFileSize
+	if truncated {
+		if _, err = file.Seek(-maxFileSize, io.SeekEnd); err != nil {
+			return nil, false, err
+		}
+	}
+
+	data, err := io.ReadAll(file)
+	return data, truncated, err
+}
+
+// recentFiles returns the paths of the most recently modified regular files of a directory.
+func recentFiles(dir string, limit int) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type file struct {
+		path    string
+		modTime time.Time
+	}
+	var files []file
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		files = append(files, file{path: filepath.Join(dir, entry.Name()), modTime: info.ModTime()})
+	}
+
+	slices.SortFunc(files, func(a, b file) int { return b.modTime.Compare(a.modTime) })
+
+	var paths []string
+	for i := 0; i < len(files) && i < limit; i++ {
+		paths = append(paths, files[i].path)
+	}
+	return paths, nil
+}
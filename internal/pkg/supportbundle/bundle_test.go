@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockexec "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/exec"
+)
+
+func readBundle(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	return files
+}
+
+func TestBuilder_Write(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/metrics":
+			_, _ = w.Write([]byte("DCGM_FI_DEV_GPU_TEMP{gpu=\"0\"} 42\n"))
+		case "/readyz":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = w.Write([]byte("ok"))
+		}
+	}))
+	defer ts.Close()
+
+	dumpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dumpDir, "snapshot-sigusr2.json"), []byte("{}"), 0o644))
+	logFile := filepath.Join(t.TempDir(), "dcgm-exporter.log")
+	require.NoError(t, os.WriteFile(logFile, []byte("level=INFO msg=started\n"), 0o644))
+
+	ctrl := gomock.NewController(t)
+	mockExec := mockexec.NewMockExec(ctrl)
+	mockCmd := mockexec.NewMockCmd(ctrl)
+	mockExec.EXPECT().Command("nvidia-smi", gomock.Any()).Return(mockCmd).Times(2)
+	mockExec.EXPECT().Command("dcgmi", gomock.Any()).Return(mockCmd).Times(2)
+	mockCmd.EXPECT().Output().Return([]byte("GPU0 X"), nil).Times(3)
+	mockCmd.EXPECT().Output().Return(nil, errors.New("executable file not found"))
+
+	b := NewBuilder(Options{
+		ExporterURL:   ts.URL,
+		DumpDirectory: dumpDir,
+		LogFiles:      []string{logFile},
+		Version:       "4.2.0",
+	})
+	b.exec = mockExec
+
+	var buf bytes.Buffer
+	require.NoError(t, b.Write(context.Background(), &buf))
+
+	files := readBundle(t, buf.Bytes())
+	assert.Contains(t, files["metrics/metrics.prom"], "DCGM_FI_DEV_GPU_TEMP")
+	assert.Equal(t, "{}", files["dumps/snapshot-sigusr2.json"])
+	assert.Equal(t, "level=INFO msg=started\n", files["logs/dcgm-exporter.log"])
+	assert.Contains(t, files["versions/dcgm-exporter.json"], "4.2.0")
+	assert.NotContains(t, files, "health/readyz.txt", "Failed sources are not written")
+
+	var manifest Manifest
+	require.NoError(t, json.Unmarshal([]byte(files[manifestName]), &manifest))
+	assert.Equal(t, "4.2.0", manifest.Version)
+
+	failed := map[string]string{}
+	for _, entry := range manifest.Entries {
+		if entry.Error != "" {
+			failed[entry.Name] = entry.Error
+		}
+	}
+	assert.Contains(t, failed["health/readyz.txt"], "503")
+	assert.Len(t, failed, 2, "The failed endpoint and the failed command are listed in the manifest")
+}
+
+func Test_recentFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.json", "b.json", "c.json"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0o755))
+
+	files, err := recentFiles(dir, 2)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	_, err = recentFiles(filepath.Join(dir, "missing"), 2)
+	assert.Error(t, err)
+}
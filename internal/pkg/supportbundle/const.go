@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package supportbundle
+
+import "time"
+
+const (
+	// DefaultExporterURL is the address of the exporter the bundle is gathered from.
+	DefaultExporterURL = "http://localhost:9400"
+	// DefaultTimeout bounds the gathering of every source of the bundle.
+	DefaultTimeout = 30 * time.Second
+
+	manifestName = "manifest.json"
+	// maxDumpFiles is the number of the most recent files of the dump directory included in the bundle.
+	maxDumpFiles = 20
+	// maxFileSize is the size above which a log or dump file is truncated to its last bytes.
+	maxFileSize = 16 << 20
+)
+
+// endpoints are the endpoints of the running exporter included in the bundle.
+var endpoints = []endpoint{
+	{name: "metrics/metrics.prom", path: "/metrics"},
+	{name: "config/effective.json", path: "/api/v1/config/effective"},
+	{name: "config/reloads.json", path: "/api/v1/reloads"},
+	{name: "health/health.txt", path: "/health"},
+	{name: "health/readyz.txt", path: "/readyz"},
+	{name: "debug/goroutines.txt", path: "/debug/pprof/goroutine?debug=2"},
+}
+
+// commands are the commands whose output is included in the bundle.
+var commands = []command{
+	{name: "topology/nvidia-smi-topo.txt", args: []string{"nvidia-smi", "topo", "-m"}},
+	{name: "topology/dcgmi-discovery.txt", args: []string{"dcgmi", "discovery", "-l"}},
+	{name: "versions/nvidia-smi.txt", args: []string{"nvidia-smi", "-q"}},
+	{name: "versions/dcgmi.txt", args: []string{"dcgmi", "--version"}},
+}
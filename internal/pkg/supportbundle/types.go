@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package supportbundle
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/exec"
+)
+
+// Options configures the content of a support bundle.
+type Options struct {
+	// Output is the path of the tarball. Defaults to dcgm-exporter-support-<timestamp>.tar.gz.
+	Output string
+	// ExporterURL is the address of the running exporter.
+	ExporterURL string
+	// DumpDirectory is the directory of the debug dumps and the diagnostic snapshots.
+	DumpDirectory string
+	// LogFiles are the log files included in the bundle.
+	LogFiles []string
+	// Version is the version of the dcgm-exporter binary building the bundle.
+	Version string
+	// Timeout bounds the gathering of every source.
+	Timeout time.Duration
+}
+
+// Manifest indexes the content of a bundle. Sources that failed are listed with their error so that
+// a partial bundle is still usable.
+type Manifest struct {
+	CreatedAt time.Time `json:"created_at"`
+	Hostname  string    `json:"hostname"`
+	Version   string    `json:"version"`
+	Entries   []Entry   `json:"entries"`
+}
+
+// Entry is a file of the bundle.
+type Entry struct {
+	Name      string `json:"name"`
+	Source    string `json:"source"`
+	Size      int    `json:"size"`
+	Truncated bool   `json:"truncated,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Builder gathers the sources of a bundle.
+type Builder struct {
+	opts   Options
+	client *http.Client
+	exec   exec.Exec
+	now    func() time.Time
+}
+
+type endpoint struct {
+	name string
+	path string
+}
+
+type command struct {
+	name string
+	args []string
+}
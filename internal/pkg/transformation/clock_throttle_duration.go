@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+)
+
+const (
+	clockThrottleReasonLabel = "reason"
+	// clockThrottleDurationTTL is how long the durations of a GPU are remembered after it was last seen
+	clockThrottleDurationTTL = 10 * time.Minute
+)
+
+var clockThrottleDurationCounter = counters.Counter{
+	FieldID:   dcgm.Short(counters.DCGMClockThrottleDuration),
+	FieldName: counters.DCGMExpClockThrottleDuration,
+	PromType:  "counter",
+	Help:      "Cumulative time the GPU clocks were throttled, per clock event reason (in seconds).",
+}
+
+// ClockThrottleDuration derives DCGM_EXP_CLOCK_THROTTLE_DURATION_SECONDS from DCGM_FI_DEV_CLOCKS_EVENT_REASONS:
+// the time between two observations of the reasons bitmask is added to the reasons set at the first one.
+// The durations are as precise as the scrape interval, the reasons are sampled, not event driven.
+type ClockThrottleDuration struct {
+	now    func() time.Time
+	mtx    sync.Mutex
+	series map[string]*clockThrottleState
+}
+
+type clockThrottleState struct {
+	reasons    []string           // Reasons set at the last observation
+	observedAt time.Time          // Time of the last observation
+	durations  map[string]float64 // Reason -> cumulative duration in seconds
+}
+
+func NewClockThrottleDuration() *ClockThrottleDuration {
+	return &ClockThrottleDuration{
+		now:    time.Now,
+		series: map[string]*clockThrottleState{},
+	}
+}
+
+func (t *ClockThrottleDuration) Name() string {
+	return "ClockThrottleDuration"
+}
+
+func (t *ClockThrottleDuration) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	now := t.now()
+	var newMetrics []collector.Metric
+
+	for counter, metricList := range metrics {
+		if counter.FieldID != dcgm.DCGM_FI_DEV_CLOCKS_EVENT_REASONS || counter.PromType == "label" {
+			continue
+		}
+
+		for _, m := range metricList {
+			bitmask, err := strconv.ParseInt(m.Value, 10, 64)
+			if err != nil {
+				continue
+			}
+
+			durations := t.observe(clockThrottleSeriesKey(m), collector.ClockEventReasons(bitmask), now)
+
+			for _, reason := range collector.ClockEventReasonNames() {
+				newMetric := m
+				newMetric.Labels = maps.Clone(m.Labels)
+				if newMetric.Labels == nil {
+					newMetric.Labels = map[string]string{}
+				}
+				newMetric.Labels[clockThrottleReasonLabel] = reason
+				newMetric.Attributes = maps.Clone(m.Attributes)
+				newMetric.Counter = clockThrottleDurationCounter
+				newMetric.Value = strconv.FormatFloat(durations[reason], 'f', -1, 64)
+
+				newMetrics = append(newMetrics, newMetric)
+			}
+		}
+	}
+
+	if len(newMetrics) > 0 {
+		metrics[clockThrottleDurationCounter] = newMetrics
+	}
+
+	for key, st := range t.series {
+		if now.Sub(st.observedAt) > clockThrottleDurationTTL {
+			delete(t.series, key)
+		}
+	}
+
+	return nil
+}
+
+// observe adds the time since the last observation to the reasons set then, records the current reasons
+// and returns the cumulative durations of the series.
+func (t *ClockThrottleDuration) observe(key string, reasons []string, now time.Time) map[string]float64 {
+	st, exists := t.series[key]
+	if !exists {
+		st = &clockThrottleState{durations: map[string]float64{}}
+		t.series[key] = st
+	} else if now.After(st.observedAt) {
+		elapsed := now.Sub(st.observedAt).Seconds()
+		for _, reason := range st.reasons {
+			st.durations[reason] += elapsed
+		}
+	}
+
+	st.reasons = reasons
+	st.observedAt = now
+
+	return st.durations
+}
+
+func clockThrottleSeriesKey(m collector.Metric) string {
+	return strings.Join([]string{m.GPU, m.GPUUUID, m.GPUInstanceID, m.ComputeInstanceID}, "|")
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+func TestClockThrottleDuration_Process(t *testing.T) {
+	reasonsCounter := counters.Counter{
+		FieldID:   dcgm.DCGM_FI_DEV_CLOCKS_EVENT_REASONS,
+		FieldName: "DCGM_FI_DEV_CLOCKS_EVENT_REASONS",
+		PromType:  "gauge",
+	}
+
+	now := time.Unix(1000, 0)
+	transform := NewClockThrottleDuration()
+	transform.now = func() time.Time { return now }
+
+	process := func(value string) map[string]string {
+		metrics := collector.MetricsByCounter{
+			reasonsCounter: {{Counter: reasonsCounter, GPU: "0", Value: value}},
+		}
+		require.NoError(t, transform.Process(metrics, nil))
+
+		durations := map[string]string{}
+		for _, m := range metrics[clockThrottleDurationCounter] {
+			durations[m.Labels[clockThrottleReasonLabel]] = m.Value
+		}
+		return durations
+	}
+
+	// Power cap and HW thermal slowdown
+	durations := process("68")
+	assert.Len(t, durations, len(collector.ClockEventReasonNames()))
+	assert.Equal(t, "0", durations["power_cap"])
+
+	now = now.Add(10 * time.Second)
+	// Only HW thermal slowdown
+	durations = process("64")
+	assert.Equal(t, "10", durations["power_cap"])
+	assert.Equal(t, "10", durations["hw_thermal"])
+
+	now = now.Add(5 * time.Second)
+	durations = process("0")
+	assert.Equal(t, "10", durations["power_cap"])
+	assert.Equal(t, "15", durations["hw_thermal"])
+	assert.Equal(t, "0", durations["sync_boost"])
+
+	// Without the clock event reasons, nothing is derived
+	metrics := collector.MetricsByCounter{}
+	require.NoError(t, transform.Process(metrics, nil))
+	assert.Empty(t, metrics)
+}
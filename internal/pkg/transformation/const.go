@@ -24,8 +24,42 @@ const (
 	uidAttribute       = "pod_uid"
 	vgpuAttribute      = "vgpu"
 
+	// Quota of the pods sharing a GPU with the HAMi or Volcano vGPU scheduler
+	vgpuMemoryAttribute = "vgpu_memory_mib"
+	vgpuCoresAttribute  = "vgpu_cores_percent"
+
+	// Workload owning the pod, e.g. the Deployment of the ReplicaSet of the pod
+	workloadKindAttribute = "workload_kind"
+	workloadNameAttribute = "workload_name"
+
 	hpcJobAttribute = "hpc_job"
 
+	instanceFQDNLabel = "instance_fqdn"
+
+	// gpuInstanceLabel is the identifier of the GPU instance of a MIG device, in the configured format
+	gpuInstanceLabel = "gpu_instance"
+
+	// fieldIDLabel is the DCGM field ID of the family of a metric
+	fieldIDLabel = "dcgm_field_id"
+
+	// nodeLabel is the name of the node in dcgm_exporter_node_info
+	nodeLabel = "node"
+
+	// maintenanceLabel marks the metrics exported during a maintenance window
+	maintenanceLabel = "maintenance"
+
+	// Labels of the per-process metrics
+	pidLabel              = "pid"
+	containerIDLabel      = "container_id"
+	containerRuntimeLabel = "container_runtime"
+	processContainerLabel = "process_container"
+	cgroupPathLabel       = "cgroup_path"
+
+	containerRuntimeDocker     = "docker"
+	containerRuntimeContainerd = "containerd"
+	containerRuntimeCRIO       = "cri-o"
+	containerRuntimePodman     = "podman"
+
 	oldPodAttribute       = "pod_name"
 	oldNamespaceAttribute = "pod_namespace"
 	oldContainerAttribute = "container_name"
@@ -40,6 +74,18 @@ const (
 
 	DRAGPUDriverName = "gpu.nvidia.com"
 
+	// Reasons for skipping pods in the mappings
+	PodSkipReasonTerminating   = "terminating"
+	PodSkipReasonTerminated    = "terminated"
+	PodSkipReasonUnschedulable = "unschedulable"
+	PodSkipReasonNamespace     = "namespace"
+
+	// Caches of the pod mapper reported by CacheSizes
+	PodMapperCachePods        = "pods"
+	PodMapperCacheLabelFilter = "label_filter"
+	PodMapperCacheAnnotations = "annotation_filter"
+	PodMapperCacheDRADevices  = "dra_devices"
+
 	metricGPUUtil = "DCGM_FI_DEV_GPU_UTIL"
 	metricFBUsed  = "DCGM_FI_DEV_FB_USED"
 )
@@ -0,0 +1,208 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+)
+
+const (
+	counterDeltaSuffix = "_DELTA"
+	counterRateSuffix  = "_RATE"
+	// counterDeltaMinTTL is the minimal time a series is remembered after it was last seen.
+	counterDeltaMinTTL = time.Minute
+)
+
+// CounterDelta derives gauges from cumulative fields: <FIELD>_DELTA, the increase of the field over the
+// last collect interval (or since the previous scrape, when scrapes are less frequent), and <FIELD>_RATE,
+// the same increase per second, e.g. PCIe bytes/s from DCGM_FI_PROF_PCIE_TX_BYTES. With deriveCounters,
+// every field exported as a counter gets a _DELTA gauge, the selected fields get the gauges of their modes
+// whatever their Prometheus type. A field going backwards, e.g. after a GPU reset, is treated as a reset,
+// and the increase is the new value of the field. A derived family that already exists is kept as is.
+type CounterDelta struct {
+	interval       time.Duration
+	now            func() time.Time
+	deriveCounters bool                                      // Derive a _DELTA gauge from every counter
+	fieldModes     map[string][]appconfig.DerivedCounterMode // Modes of the selected fields, by field name
+	mtx            sync.Mutex
+	series         map[string]*counterDeltaState
+}
+
+type counterDeltaState struct {
+	baseline   float64   // Field value at the start of the current interval
+	baselineAt time.Time // When the baseline was observed
+	delta      float64   // Increase over the last completed interval
+	elapsed    float64   // Duration of the last completed interval, in seconds
+	hasDelta   bool      // False until the first interval completes
+	lastSeen   time.Time
+}
+
+func NewCounterDelta(
+	interval time.Duration, deriveCounters bool, derivedCounters []appconfig.DerivedCounter,
+) *CounterDelta {
+	fieldModes := map[string][]appconfig.DerivedCounterMode{}
+	for _, dc := range derivedCounters {
+		fieldModes[dc.FieldName] = append(fieldModes[dc.FieldName], dc.Mode)
+	}
+
+	return &CounterDelta{
+		interval:       interval,
+		now:            time.Now,
+		deriveCounters: deriveCounters,
+		fieldModes:     fieldModes,
+		series:         map[string]*counterDeltaState{},
+	}
+}
+
+func (t *CounterDelta) Name() string {
+	return "CounterDelta"
+}
+
+func (t *CounterDelta) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	existing := map[string]struct{}{}
+	for counter := range metrics {
+		existing[counter.FieldName] = struct{}{}
+	}
+
+	now := t.now()
+	newMetrics := collector.MetricsByCounter{}
+
+	for counter, metricList := range metrics {
+		modes := t.modes(counter)
+		if len(modes) == 0 {
+			continue
+		}
+
+		for _, m := range metricList {
+			val, err := strconv.ParseFloat(m.Value, 64)
+			if err != nil {
+				continue
+			}
+
+			st, ok := t.observe(counterDeltaSeriesKey(counter, m), val, now)
+			if !ok {
+				continue
+			}
+
+			for _, mode := range modes {
+				derivedCounter, value := deriveCounter(counter, mode, st)
+				if _, exists := existing[derivedCounter.FieldName]; exists {
+					continue
+				}
+
+				newMetric := m
+				newMetric.Labels = maps.Clone(m.Labels)
+				newMetric.Attributes = maps.Clone(m.Attributes)
+				newMetric.Counter = derivedCounter
+				newMetric.Value = strconv.FormatFloat(value, 'f', -1, 64)
+
+				newMetrics[derivedCounter] = append(newMetrics[derivedCounter], newMetric)
+			}
+		}
+	}
+
+	maps.Copy(metrics, newMetrics)
+
+	t.prune(now)
+
+	return nil
+}
+
+// modes returns the gauges to derive from a field, a counter selected with a delta mode gets a single _DELTA.
+func (t *CounterDelta) modes(counter counters.Counter) []appconfig.DerivedCounterMode {
+	modes := t.fieldModes[counter.FieldName]
+	if !t.deriveCounters || counter.PromType != "counter" || strings.HasSuffix(counter.FieldName, counterDeltaSuffix) ||
+		slices.Contains(modes, appconfig.DerivedCounterModeDelta) {
+		return modes
+	}
+	return append(slices.Clone(modes), appconfig.DerivedCounterModeDelta)
+}
+
+// deriveCounter returns the counter and the value of the gauge derived from the state of a series.
+func deriveCounter(
+	counter counters.Counter, mode appconfig.DerivedCounterMode, st *counterDeltaState,
+) (counters.Counter, float64) {
+	if mode == appconfig.DerivedCounterModeRate {
+		return counters.Counter{
+			FieldID:   counter.FieldID,
+			FieldName: counter.FieldName + counterRateSuffix,
+			PromType:  "gauge",
+			Help:      fmt.Sprintf("Per-second increase of %s over the last collect interval.", counter.FieldName),
+		}, st.delta / st.elapsed
+	}
+
+	return counters.Counter{
+		FieldID:   counter.FieldID,
+		FieldName: counter.FieldName + counterDeltaSuffix,
+		PromType:  "gauge",
+		Help:      fmt.Sprintf("Increase of %s over the last collect interval.", counter.FieldName),
+	}, st.delta
+}
+
+// observe records the value of a series and returns its state once its first interval completed.
+func (t *CounterDelta) observe(key string, val float64, now time.Time) (*counterDeltaState, bool) {
+	st, exists := t.series[key]
+	if !exists {
+		t.series[key] = &counterDeltaState{baseline: val, baselineAt: now, lastSeen: now}
+		return nil, false
+	}
+
+	st.lastSeen = now
+
+	if elapsed := now.Sub(st.baselineAt); elapsed >= t.interval && elapsed > 0 {
+		st.delta = val - st.baseline
+		if st.delta < 0 {
+			st.delta = val
+		}
+		st.elapsed = elapsed.Seconds()
+		st.baseline = val
+		st.baselineAt = now
+		st.hasDelta = true
+	}
+
+	return st, st.hasDelta
+}
+
+// prune forgets series that disappeared, e.g. after a GPU was removed.
+func (t *CounterDelta) prune(now time.Time) {
+	ttl := max(10*t.interval, counterDeltaMinTTL)
+	for key, st := range t.series {
+		if now.Sub(st.lastSeen) > ttl {
+			delete(t.series, key)
+		}
+	}
+}
+
+func counterDeltaSeriesKey(counter counters.Counter, m collector.Metric) string {
+	return strings.Join([]string{
+		counter.FieldName, m.GPU, m.GPUUUID, m.GPUDevice, m.GPUInstanceID, m.ComputeInstanceID, m.NvSwitch, m.NvLink,
+	}, "|")
+}
@@ -0,0 +1,258 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+var (
+	pcieReplayCounter = counters.Counter{
+		FieldID:   202,
+		FieldName: "DCGM_FI_DEV_PCIE_REPLAY_COUNTER",
+		PromType:  "counter",
+	}
+	gpuTempGauge = counters.Counter{
+		FieldID:   dcgm.DCGM_FI_DEV_GPU_TEMP,
+		FieldName: "DCGM_FI_DEV_GPU_TEMP",
+		PromType:  "gauge",
+	}
+)
+
+func counterDeltaMetrics(replays, temp string) collector.MetricsByCounter {
+	return collector.MetricsByCounter{
+		pcieReplayCounter: {
+			{Counter: pcieReplayCounter, GPU: "0", GPUUUID: "GPU-0", Value: replays, Attributes: map[string]string{}},
+		},
+		gpuTempGauge: {
+			{Counter: gpuTempGauge, GPU: "0", GPUUUID: "GPU-0", Value: temp, Attributes: map[string]string{}},
+		},
+	}
+}
+
+func deltaValues(metrics collector.MetricsByCounter) []string {
+	var values []string
+	for counter, metricList := range metrics {
+		if counter.FieldName != pcieReplayCounter.FieldName+counterDeltaSuffix {
+			continue
+		}
+		for _, m := range metricList {
+			values = append(values, m.Value)
+		}
+	}
+	return values
+}
+
+func TestCounterDelta_Process(t *testing.T) {
+	now := time.Unix(1000, 0)
+	transform := NewCounterDelta(30*time.Second, true, nil)
+	transform.now = func() time.Time { return now }
+
+	// The first observation only records the baseline
+	metrics := counterDeltaMetrics("10", "50")
+	require.NoError(t, transform.Process(metrics, nil))
+	assert.Empty(t, deltaValues(metrics))
+	assert.Len(t, metrics, 2)
+
+	// The interval has not elapsed yet, there is still no delta
+	now = now.Add(10 * time.Second)
+	metrics = counterDeltaMetrics("12", "50")
+	require.NoError(t, transform.Process(metrics, nil))
+	assert.Empty(t, deltaValues(metrics))
+
+	// The first interval completes
+	now = now.Add(20 * time.Second)
+	metrics = counterDeltaMetrics("15", "51")
+	require.NoError(t, transform.Process(metrics, nil))
+	assert.Equal(t, []string{"5"}, deltaValues(metrics))
+
+	// A scrape within the next interval reports the delta of the completed one
+	now = now.Add(5 * time.Second)
+	metrics = counterDeltaMetrics("40", "51")
+	require.NoError(t, transform.Process(metrics, nil))
+	assert.Equal(t, []string{"5"}, deltaValues(metrics))
+
+	// The counter was reset
+	now = now.Add(30 * time.Second)
+	metrics = counterDeltaMetrics("3", "52")
+	require.NoError(t, transform.Process(metrics, nil))
+	assert.Equal(t, []string{"3"}, deltaValues(metrics))
+
+	for counter, metricList := range metrics {
+		if counter.FieldName == pcieReplayCounter.FieldName+counterDeltaSuffix {
+			assert.Equal(t, "gauge", counter.PromType)
+			assert.Equal(t, "GPU-0", metricList[0].GPUUUID)
+		}
+		assert.NotEqual(t, gpuTempGauge.FieldName+counterDeltaSuffix, counter.FieldName,
+			"gauges must not get a delta")
+	}
+}
+
+func TestCounterDelta_PrunesRemovedSeries(t *testing.T) {
+	now := time.Unix(1000, 0)
+	transform := NewCounterDelta(time.Second, true, nil)
+	transform.now = func() time.Time { return now }
+
+	require.NoError(t, transform.Process(counterDeltaMetrics("10", "50"), nil))
+	assert.Len(t, transform.series, 1)
+
+	now = now.Add(counterDeltaMinTTL + time.Second)
+	require.NoError(t, transform.Process(collector.MetricsByCounter{}, nil))
+	assert.Empty(t, transform.series)
+}
+
+var pcieTxBytes = counters.Counter{
+	FieldID:   1009,
+	FieldName: "DCGM_FI_PROF_PCIE_TX_BYTES",
+	PromType:  "gauge",
+}
+
+func pcieTxMetrics(gpu0, gpu1 string) collector.MetricsByCounter {
+	return collector.MetricsByCounter{
+		pcieTxBytes: {
+			{Counter: pcieTxBytes, GPU: "0", GPUUUID: "GPU-0", Value: gpu0, Attributes: map[string]string{}},
+			{Counter: pcieTxBytes, GPU: "1", GPUUUID: "GPU-1", Value: gpu1, Attributes: map[string]string{}},
+		},
+	}
+}
+
+func derivedValues(t *testing.T, metrics collector.MetricsByCounter, fieldName string) map[string]string {
+	t.Helper()
+	values := map[string]string{}
+	for counter, metricList := range metrics {
+		if counter.FieldName != fieldName {
+			continue
+		}
+		assert.Equal(t, "gauge", counter.PromType)
+		for _, m := range metricList {
+			values[m.GPU] = m.Value
+		}
+	}
+	return values
+}
+
+func TestCounterDelta_DerivedFields(t *testing.T) {
+	now := time.Unix(1000, 0)
+	transform := NewCounterDelta(10*time.Second, false, []appconfig.DerivedCounter{
+		{FieldName: pcieTxBytes.FieldName, Mode: appconfig.DerivedCounterModeDelta},
+		{FieldName: pcieTxBytes.FieldName, Mode: appconfig.DerivedCounterModeRate},
+	})
+	transform.now = func() time.Time { return now }
+
+	// The first observation only records the baseline
+	metrics := pcieTxMetrics("1000", "5000")
+	require.NoError(t, transform.Process(metrics, nil))
+	assert.Len(t, metrics, 1)
+
+	// The interval has not elapsed yet, there is still nothing to derive
+	now = now.Add(5 * time.Second)
+	metrics = pcieTxMetrics("1500", "5500")
+	require.NoError(t, transform.Process(metrics, nil))
+	assert.Len(t, metrics, 1)
+
+	now = now.Add(5 * time.Second)
+	metrics = pcieTxMetrics("3000", "6000")
+	require.NoError(t, transform.Process(metrics, nil))
+	assert.Equal(t, map[string]string{"0": "2000", "1": "1000"},
+		derivedValues(t, metrics, "DCGM_FI_PROF_PCIE_TX_BYTES_DELTA"))
+	assert.Equal(t, map[string]string{"0": "200", "1": "100"},
+		derivedValues(t, metrics, "DCGM_FI_PROF_PCIE_TX_BYTES_RATE"))
+
+	// A scrape within the interval repeats the values of the last completed interval
+	now = now.Add(time.Second)
+	metrics = pcieTxMetrics("3100", "6100")
+	require.NoError(t, transform.Process(metrics, nil))
+	assert.Equal(t, map[string]string{"0": "200", "1": "100"},
+		derivedValues(t, metrics, "DCGM_FI_PROF_PCIE_TX_BYTES_RATE"))
+
+	// GPU 1 went backwards, e.g. after a reset: its new value is the increase
+	now = now.Add(19 * time.Second)
+	metrics = pcieTxMetrics("7000", "400")
+	require.NoError(t, transform.Process(metrics, nil))
+	assert.Equal(t, map[string]string{"0": "4000", "1": "400"},
+		derivedValues(t, metrics, "DCGM_FI_PROF_PCIE_TX_BYTES_DELTA"))
+	assert.Equal(t, map[string]string{"0": "200", "1": "20"},
+		derivedValues(t, metrics, "DCGM_FI_PROF_PCIE_TX_BYTES_RATE"))
+}
+
+func TestCounterDelta_KeepsExistingFamily(t *testing.T) {
+	now := time.Unix(1000, 0)
+	transform := NewCounterDelta(10*time.Second, false, []appconfig.DerivedCounter{
+		{FieldName: pcieReplayCounter.FieldName, Mode: appconfig.DerivedCounterModeDelta},
+	})
+	transform.now = func() time.Time { return now }
+
+	existing := counters.Counter{FieldName: pcieReplayCounter.FieldName + counterDeltaSuffix, PromType: "gauge"}
+	newMetrics := func(replays string) collector.MetricsByCounter {
+		metrics := counterDeltaMetrics(replays, "50")
+		metrics[existing] = []collector.Metric{{Counter: existing, GPU: "0", Value: "7"}}
+		return metrics
+	}
+
+	require.NoError(t, transform.Process(newMetrics("10"), nil))
+	now = now.Add(10 * time.Second)
+	metrics := newMetrics("20")
+	require.NoError(t, transform.Process(metrics, nil))
+
+	assert.Len(t, metrics, 3, "The existing _DELTA family isn't duplicated")
+	assert.Equal(t, "7", metrics[existing][0].Value)
+}
+
+func TestCounterDelta_DerivedFieldsPrune(t *testing.T) {
+	now := time.Unix(1000, 0)
+	transform := NewCounterDelta(10*time.Second, false, []appconfig.DerivedCounter{
+		{FieldName: pcieTxBytes.FieldName, Mode: appconfig.DerivedCounterModeRate},
+	})
+	transform.now = func() time.Time { return now }
+
+	require.NoError(t, transform.Process(pcieTxMetrics("1", "2"), nil))
+	assert.Len(t, transform.series, 2)
+
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, transform.Process(collector.MetricsByCounter{}, nil))
+	assert.Empty(t, transform.series)
+}
+
+func TestCounterDelta_CountersAndDerivedFields(t *testing.T) {
+	now := time.Unix(1000, 0)
+	transform := NewCounterDelta(10*time.Second, true, []appconfig.DerivedCounter{
+		{FieldName: pcieReplayCounter.FieldName, Mode: appconfig.DerivedCounterModeDelta},
+		{FieldName: pcieReplayCounter.FieldName, Mode: appconfig.DerivedCounterModeRate},
+	})
+	transform.now = func() time.Time { return now }
+
+	require.NoError(t, transform.Process(counterDeltaMetrics("10", "50"), nil))
+	now = now.Add(10 * time.Second)
+	metrics := counterDeltaMetrics("30", "50")
+	require.NoError(t, transform.Process(metrics, nil))
+
+	// The counter selected in delta mode gets a single _DELTA family, both share the state of the series
+	assert.Len(t, metrics, 4)
+	assert.Equal(t, []string{"20"}, deltaValues(metrics))
+	assert.Equal(t, map[string]string{"0": "2"},
+		derivedValues(t, metrics, pcieReplayCounter.FieldName+counterRateSuffix))
+	assert.Len(t, transform.series, 1)
+}
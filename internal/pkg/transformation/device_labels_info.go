@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+)
+
+var deviceLabelsInfoCounter = counters.Counter{
+	FieldName: counters.DCGMExpDeviceLabelsInfo,
+	PromType:  "gauge",
+	Help:      "Values of the label counters of the device.",
+}
+
+// DeviceLabelsInfo moves the label counters, e.g. DCGM_FI_DRIVER_VERSION, from the labels of every series to
+// a single dcgm_exp_device_labels_info series per device, joinable with the other families on the UUID.
+// The label counters are the labels named after a DCGM field, the collectors name them so. Only the GPUs and
+// their MIG instances are concerned: the info family of the other entity types would be rendered once more.
+type DeviceLabelsInfo struct{}
+
+func NewDeviceLabelsInfo() *DeviceLabelsInfo {
+	return &DeviceLabelsInfo{}
+}
+
+func (t *DeviceLabelsInfo) Name() string {
+	return "DeviceLabelsInfo"
+}
+
+func (t *DeviceLabelsInfo) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	devices := map[string]collector.Metric{}
+	var order []string
+
+	for counter, metricList := range metrics {
+		if counter.FieldName == counters.DCGMExpDeviceLabelsInfo {
+			continue
+		}
+
+		for i, m := range metricList {
+			if m.GPUUUID == "" || m.NvLink != "" || !hasLabelCounter(m.Labels) {
+				continue
+			}
+
+			info, exists := devices[m.GPUUUID]
+			if !exists {
+				info = deviceLabelsInfoMetric(m)
+				order = append(order, m.GPUUUID)
+			}
+			for label, value := range m.Labels {
+				if isLabelCounter(label, value) {
+					info.Labels[label] = value
+				}
+			}
+			devices[m.GPUUUID] = info
+
+			// The labels map is shared by the series of an entity
+			labels := maps.Clone(m.Labels)
+			maps.DeleteFunc(labels, isLabelCounter)
+			metricList[i].Labels = labels
+		}
+	}
+
+	slices.Sort(order)
+	for _, uuid := range order {
+		metrics[deviceLabelsInfoCounter] = append(metrics[deviceLabelsInfoCounter], devices[uuid])
+	}
+
+	return nil
+}
+
+// isLabelCounter returns whether a label holds the value of a label counter
+func isLabelCounter(label, _ string) bool {
+	_, ok := dcgm.GetFieldID(label)
+	return ok
+}
+
+// hasLabelCounter returns whether labels hold the value of a label counter
+func hasLabelCounter(labels map[string]string) bool {
+	for label, value := range labels {
+		if isLabelCounter(label, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// deviceLabelsInfoMetric returns the info metric of the GPU of a metric, without labels yet
+func deviceLabelsInfoMetric(m collector.Metric) collector.Metric {
+	info := m
+	info.Counter = deviceLabelsInfoCounter
+	info.Value = "1"
+	info.Labels = map[string]string{}
+	info.Attributes = map[string]string{}
+	info.MigProfile = ""
+	info.GPUInstanceID = ""
+	info.ComputeInstanceID = ""
+	return info
+}
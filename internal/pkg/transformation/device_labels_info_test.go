@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+func TestDeviceLabelsInfo_Process(t *testing.T) {
+	gpuUtil := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_GPU_UTIL, FieldName: "DCGM_FI_DEV_GPU_UTIL", PromType: "gauge"}
+	memClock := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_MEM_CLOCK, FieldName: "DCGM_FI_DEV_MEM_CLOCK", PromType: "gauge"}
+
+	// The collectors share the labels map between the series of an entity
+	gpu0Labels := map[string]string{"DCGM_FI_DRIVER_VERSION": "570.86.15", "DCGM_FI_DEV_SERIAL": "1320"}
+	gpu1Labels := map[string]string{"DCGM_FI_DRIVER_VERSION": "570.86.15", "DCGM_FI_DEV_SERIAL": "1321"}
+	migLabels := map[string]string{"DCGM_FI_DRIVER_VERSION": "570.86.15", "health_watch": "THERMAL"}
+
+	metrics := collector.MetricsByCounter{
+		gpuUtil: {
+			{Counter: gpuUtil, GPU: "0", GPUUUID: "GPU-0", Value: "42", Labels: gpu0Labels},
+			{Counter: gpuUtil, GPU: "1", GPUUUID: "GPU-1", Value: "7", Labels: gpu1Labels},
+			{
+				Counter: gpuUtil, GPU: "1", GPUUUID: "GPU-1", GPUInstanceID: "3", MigProfile: "1g.10gb",
+				Value: "3", Labels: migLabels,
+			},
+		},
+		memClock: {
+			{Counter: memClock, GPU: "0", GPUUUID: "GPU-0", Value: "1593", Labels: gpu0Labels},
+		},
+	}
+
+	require.NoError(t, NewDeviceLabelsInfo().Process(metrics, nil))
+
+	for _, counter := range []counters.Counter{gpuUtil, memClock} {
+		for _, m := range metrics[counter] {
+			assert.NotContains(t, m.Labels, "DCGM_FI_DRIVER_VERSION")
+			assert.NotContains(t, m.Labels, "DCGM_FI_DEV_SERIAL")
+		}
+	}
+	assert.Equal(t, map[string]string{"health_watch": "THERMAL"}, metrics[gpuUtil][2].Labels,
+		"The other labels are kept")
+
+	infos := metrics[deviceLabelsInfoCounter]
+	require.Len(t, infos, 2)
+	assert.Equal(t, "GPU-0", infos[0].GPUUUID)
+	assert.Equal(t, "1", infos[0].Value)
+	assert.Equal(t, map[string]string{"DCGM_FI_DRIVER_VERSION": "570.86.15", "DCGM_FI_DEV_SERIAL": "1320"},
+		infos[0].Labels)
+	assert.Equal(t, "GPU-1", infos[1].GPUUUID)
+	assert.Empty(t, infos[1].GPUInstanceID, "The info is exported once per GPU")
+	assert.Equal(t, "1321", infos[1].Labels["DCGM_FI_DEV_SERIAL"])
+}
@@ -20,17 +20,26 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	stdos "os"
+	"strings"
 	"time"
 
 	resourcev1beta1 "k8s.io/api/resource/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/tools/cache"
 
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/identity"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/kubeclient"
 )
 
 const (
 	informerResyncPeriod = 10 * time.Minute
+
+	// draMinResyncInterval limits how often a lookup miss lists the ResourceSlices from the API server
+	draMinResyncInterval = 5 * time.Second
+	draResyncTimeout     = 10 * time.Second
 )
 
 func NewDRAResourceSliceManager() (*DRAResourceSliceManager, error) {
@@ -43,10 +52,12 @@ func NewDRAResourceSliceManager() (*DRAResourceSliceManager, error) {
 	informer := factory.Resource().V1beta1().ResourceSlices().Informer()
 
 	m := &DRAResourceSliceManager{
+		client:       client,
 		factory:      factory,
 		informer:     informer,
 		deviceToUUID: make(map[string]string),
 		migDevices:   make(map[string]*DRAMigDeviceInfo),
+		nodeName:     stdos.Getenv("NODE_NAME"),
 	}
 
 	_, err = informer.AddEventHandler(&cache.FilteringResourceEventHandler{
@@ -110,6 +121,173 @@ func (m *DRAResourceSliceManager) GetDeviceInfo(pool, device string) (string, *D
 	return "", nil
 }
 
+// GetCDIDeviceInfo returns the same as GetDeviceInfo for a CDI device name reported by the DRA drivers instead
+// of the pool and device names, e.g. nvidia.com/gpu=MIG-<UUID> or k8s.gpu.nvidia.com/claim=<claim UID>-gpu-0.
+// The device of the name is either a GPU or MIG UUID, or ends with the name of a device of the pool, the pool
+// of the node of the exporter when the driver doesn't report it.
+func (m *DRAResourceSliceManager) GetCDIDeviceInfo(pool, cdiDevice string) (string, *DRAMigDeviceInfo) {
+	_, device, found := strings.Cut(cdiDevice, "=")
+	if !found || device == "" {
+		return "", nil
+	}
+	if pool == "" {
+		pool = m.nodeName
+	}
+
+	switch identity.Parse(device).Kind {
+	case identity.KindGPU:
+		return device, nil
+	case identity.KindMIG:
+		return m.migDeviceInfo(device)
+	}
+
+	name := m.poolDeviceSuffix(pool, device)
+	if name == "" {
+		slog.Info(fmt.Sprintf("No device of pool %s found for CDI device %s", pool, cdiDevice))
+		return "", nil
+	}
+	return m.GetDeviceInfo(pool, name)
+}
+
+// migDeviceInfo returns the parent UUID and the info of a MIG device from its UUID
+func (m *DRAResourceSliceManager) migDeviceInfo(migUUID string) (string, *DRAMigDeviceInfo) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, migInfo := range m.migDevices {
+		if migInfo.MIGDeviceUUID == migUUID {
+			return migInfo.ParentUUID, migInfo
+		}
+	}
+	slog.Info(fmt.Sprintf("No MIG device found with UUID %s", migUUID))
+	return "", nil
+}
+
+// poolDeviceSuffix returns the longest name of a device of the pool ending the device of a CDI name,
+// gpu-0-mig-1 rather than gpu-0 for <claim UID>-gpu-0-mig-1
+func (m *DRAResourceSliceManager) poolDeviceSuffix(pool, device string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var name string
+	match := func(key string) {
+		deviceName, inPool := strings.CutPrefix(key, pool+"/")
+		if inPool && len(deviceName) > len(name) && (device == deviceName || strings.HasSuffix(device, "-"+deviceName)) {
+			name = deviceName
+		}
+	}
+	for key := range m.deviceToUUID {
+		match(key)
+	}
+	for key := range m.migDevices {
+		match(key)
+	}
+	return name
+}
+
+// LookupDeviceInfo returns the same as GetDeviceInfo, but resyncs the ResourceSlices first when a resync was
+// requested, and once more when the device is not known yet. DRA drivers that create MIG instances at claim
+// time publish the device after the claim is allocated, so the informer can lag behind the pod resources.
+func (m *DRAResourceSliceManager) LookupDeviceInfo(pool, device string) (string, *DRAMigDeviceInfo) {
+	return m.lookup(pool+"/"+device, func() (string, *DRAMigDeviceInfo) {
+		return m.GetDeviceInfo(pool, device)
+	})
+}
+
+// LookupCDIDeviceInfo returns the same as GetCDIDeviceInfo, resyncing the ResourceSlices like LookupDeviceInfo.
+func (m *DRAResourceSliceManager) LookupCDIDeviceInfo(pool, cdiDevice string) (string, *DRAMigDeviceInfo) {
+	return m.lookup(cdiDevice, func() (string, *DRAMigDeviceInfo) {
+		return m.GetCDIDeviceInfo(pool, cdiDevice)
+	})
+}
+
+func (m *DRAResourceSliceManager) lookup(device string, get func() (string, *DRAMigDeviceInfo)) (string, *DRAMigDeviceInfo) {
+	if m.resyncPending.Swap(false) {
+		m.resync()
+	}
+
+	uuid, migInfo := get()
+	if uuid != "" {
+		return uuid, migInfo
+	}
+
+	lastResync := time.Unix(0, m.lastResync.Load())
+	if m.client == nil || time.Since(lastResync) < draMinResyncInterval {
+		m.unresolvedLookups.Add(1)
+		return uuid, migInfo
+	}
+
+	slog.Debug(fmt.Sprintf("Resyncing ResourceSlices for unknown device %s", device))
+	m.resync()
+	uuid, migInfo = get()
+	if uuid == "" {
+		m.unresolvedLookups.Add(1)
+	}
+	return uuid, migInfo
+}
+
+// RequestResync makes the next lookup list the ResourceSlices from the API server,
+// e.g. after a GPU topology change or when a pod with resource claims is scheduled.
+func (m *DRAResourceSliceManager) RequestResync() {
+	m.resyncPending.Store(true)
+}
+
+func (m *DRAResourceSliceManager) resync() {
+	if err := m.Resync(); err != nil {
+		slog.Warn("Failed to resync ResourceSlices", "error", err)
+	}
+}
+
+// Resync lists the ResourceSlices of the GPU driver from the API server and updates the device mappings.
+func (m *DRAResourceSliceManager) Resync() error {
+	if m.client == nil {
+		return fmt.Errorf("kube client is not available")
+	}
+	m.lastResync.Store(time.Now().UnixNano())
+
+	ctx, cancel := context.WithTimeout(context.Background(), draResyncTimeout)
+	defer cancel()
+
+	sliceList, err := m.client.ResourceV1beta1().ResourceSlices().List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(resourcev1beta1.ResourceSliceSelectorDriver, DRAGPUDriverName).String(),
+	})
+	if err != nil {
+		return fmt.Errorf("error listing ResourceSlices: %w", err)
+	}
+
+	for i := range sliceList.Items {
+		if sliceList.Items[i].Spec.Driver != DRAGPUDriverName {
+			continue
+		}
+		m.onAddOrUpdate(&sliceList.Items[i])
+	}
+	slog.Debug(fmt.Sprintf("Resynced %d ResourceSlices", len(sliceList.Items)))
+	return nil
+}
+
+// Stats returns the counters of the manager, exported as self metrics of the exporter.
+func (m *DRAResourceSliceManager) Stats() DRAResourceSliceStats {
+	return DRAResourceSliceStats{
+		SliceUpdates:      m.sliceUpdates.Load(),
+		UnresolvedLookups: m.unresolvedLookups.Load(),
+		MappingCount:      m.mappingCount.Load(),
+		MappingDuration:   time.Duration(m.mappingDuration.Load()),
+	}
+}
+
+// Devices returns the number of GPU and MIG devices known from the ResourceSlices.
+func (m *DRAResourceSliceManager) Devices() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.deviceToUUID) + len(m.migDevices)
+}
+
+func (m *DRAResourceSliceManager) observeMapping(duration time.Duration) {
+	m.mappingCount.Add(1)
+	m.mappingDuration.Add(int64(duration))
+}
+
 func getAttrString(attrs map[resourcev1beta1.QualifiedName]resourcev1beta1.DeviceAttribute, key resourcev1beta1.QualifiedName) string {
 	if attr, ok := attrs[key]; ok && attr.StringValue != nil {
 		return *attr.StringValue
@@ -120,6 +298,7 @@ func getAttrString(attrs map[resourcev1beta1.QualifiedName]resourcev1beta1.Devic
 func (m *DRAResourceSliceManager) onAddOrUpdate(obj interface{}) {
 	slice := obj.(*resourcev1beta1.ResourceSlice)
 	pool := slice.Spec.Pool.Name
+	m.sliceUpdates.Add(1)
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -165,6 +344,7 @@ func (m *DRAResourceSliceManager) onAddOrUpdate(obj interface{}) {
 func (m *DRAResourceSliceManager) onDelete(obj interface{}) {
 	slice := obj.(*resourcev1beta1.ResourceSlice)
 	pool := slice.Spec.Pool.Name
+	m.sliceUpdates.Add(1)
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"maps"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+)
+
+// DualNamespace also exports the GPU series of the configured families in the namespace not selected by
+// --use-old-namespace, so that dashboards and alerts can move from one to the other without a gap. The 1.x
+// namespace labels the GPUs with uuid and the pods with pod_name, pod_namespace and container_name, the
+// current one with UUID, pod, namespace and container. Both series are in the same family.
+type DualNamespace struct {
+	toOld    bool            // whether the copies are in the 1.x namespace
+	families map[string]bool // nil copies every family
+}
+
+func NewDualNamespace(useOldNamespace bool, families []string) *DualNamespace {
+	t := &DualNamespace{toOld: !useOldNamespace}
+	if len(families) > 0 {
+		t.families = make(map[string]bool, len(families))
+		for _, family := range families {
+			t.families[family] = true
+		}
+	}
+	return t
+}
+
+func (t *DualNamespace) Name() string {
+	return "DualNamespace"
+}
+
+func (t *DualNamespace) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	for counter, metricList := range metrics {
+		if t.families != nil && !t.families[counter.FieldName] {
+			continue
+		}
+
+		// The other entity types have no label depending on the namespace, their copies would be duplicates
+		var copies []collector.Metric
+		for _, m := range metricList {
+			if m.GPUUUID == "" || m.NvLink != "" {
+				continue
+			}
+			copies = append(copies, t.convert(m))
+		}
+		metrics[counter] = append(metricList, copies...)
+	}
+
+	return nil
+}
+
+// convert returns a copy of m in the other namespace
+func (t *DualNamespace) convert(m collector.Metric) collector.Metric {
+	from, to := []string{podAttribute, namespaceAttribute, containerAttribute},
+		[]string{oldPodAttribute, oldNamespaceAttribute, oldContainerAttribute}
+	m.UUID = "uuid"
+	if !t.toOld {
+		from, to = to, from
+		m.UUID = "UUID"
+	}
+
+	attributes := maps.Clone(m.Attributes)
+	for i, name := range from {
+		if value, exists := attributes[name]; exists {
+			delete(attributes, name)
+			attributes[to[i]] = value
+		}
+	}
+	m.Attributes = attributes
+
+	return m
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+func TestDualNamespace_Process(t *testing.T) {
+	gpuUtil := counters.Counter{FieldName: "DCGM_FI_DEV_GPU_UTIL", PromType: "gauge"}
+	gpuTemp := counters.Counter{FieldName: "DCGM_FI_DEV_GPU_TEMP", PromType: "gauge"}
+	cpuUtil := counters.Counter{FieldName: "DCGM_FI_DEV_CPU_UTIL_TOTAL", PromType: "gauge"}
+
+	newMetrics := func() collector.MetricsByCounter {
+		return collector.MetricsByCounter{
+			gpuUtil: {{
+				Counter: gpuUtil, GPU: "0", GPUUUID: "GPU-0", UUID: "UUID", Value: "10",
+				Attributes: map[string]string{
+					podAttribute:       "pod-a",
+					namespaceAttribute: "default",
+					containerAttribute: "main",
+					uidAttribute:       "uid-a",
+				},
+			}},
+			gpuTemp: {{Counter: gpuTemp, GPU: "0", GPUUUID: "GPU-0", UUID: "UUID", Value: "40"}},
+			cpuUtil: {{Counter: cpuUtil, GPU: "0", UUID: "UUID", Value: "5"}},
+		}
+	}
+
+	t.Run("Copies the selected families in the 1.x namespace", func(t *testing.T) {
+		metrics := newMetrics()
+		require.NoError(t, NewDualNamespace(false, []string{gpuUtil.FieldName, cpuUtil.FieldName}).Process(metrics, nil))
+
+		require.Len(t, metrics[gpuUtil], 2)
+		assert.Equal(t, "UUID", metrics[gpuUtil][0].UUID)
+		assert.Equal(t, "pod-a", metrics[gpuUtil][0].Attributes[podAttribute], "The original should be unchanged")
+
+		copied := metrics[gpuUtil][1]
+		assert.Equal(t, "uuid", copied.UUID)
+		assert.Equal(t, "10", copied.Value)
+		assert.Equal(t, map[string]string{
+			oldPodAttribute:       "pod-a",
+			oldNamespaceAttribute: "default",
+			oldContainerAttribute: "main",
+			uidAttribute:          "uid-a",
+		}, copied.Attributes)
+
+		assert.Len(t, metrics[gpuTemp], 1, "The families not selected should not be copied")
+		assert.Len(t, metrics[cpuUtil], 1, "The series of other entities than GPUs should not be copied")
+	})
+
+	t.Run("Copies every family in the current namespace", func(t *testing.T) {
+		metrics := newMetrics()
+		metrics[gpuTemp][0].UUID = "uuid"
+		require.NoError(t, NewDualNamespace(true, nil).Process(metrics, nil))
+
+		require.Len(t, metrics[gpuTemp], 2)
+		assert.Equal(t, "uuid", metrics[gpuTemp][0].UUID)
+		assert.Equal(t, "UUID", metrics[gpuTemp][1].UUID)
+		require.Len(t, metrics[gpuUtil], 2)
+	})
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+)
+
+// containerKey identifies the container a metric is mapped to
+type containerKey struct {
+	namespace string
+	pod       string
+	container string
+}
+
+// metricContainer returns the container the pod mappers mapped the metric to
+func metricContainer(m collector.Metric, useOldNamespace bool) containerKey {
+	if useOldNamespace {
+		return containerKey{
+			namespace: m.Attributes[oldNamespaceAttribute],
+			pod:       m.Attributes[oldPodAttribute],
+			container: m.Attributes[oldContainerAttribute],
+		}
+	}
+	return containerKey{
+		namespace: m.Attributes[namespaceAttribute],
+		pod:       m.Attributes[podAttribute],
+		container: m.Attributes[containerAttribute],
+	}
+}
+
+// resolveDuplicates combines the clones of a sample by the virtual GPU and the DRA mappers. The clones both
+// mappers made for the same container only differ by their attributes, they are resolved into a single series
+// with the policy. It returns the combined clones and the number of duplicates resolved.
+func resolveDuplicates(
+	sharing, dra []collector.Metric, policy appconfig.DuplicatePolicy, useOldNamespace bool,
+) ([]collector.Metric, int) {
+	if len(sharing) == 0 || len(dra) == 0 {
+		return append(sharing, dra...), 0
+	}
+
+	draByContainer := make(map[containerKey]int, len(dra))
+	for i, m := range dra {
+		draByContainer[metricContainer(m, useOldNamespace)] = i
+	}
+
+	resolved := make([]collector.Metric, 0, len(sharing)+len(dra))
+	duplicated := make([]bool, len(dra))
+	duplicates := 0
+	for _, m := range sharing {
+		i, exists := draByContainer[metricContainer(m, useOldNamespace)]
+		if !exists || duplicated[i] {
+			resolved = append(resolved, m)
+			continue
+		}
+		duplicated[i] = true
+		duplicates++
+
+		switch policy {
+		case appconfig.DuplicatePolicyDRA:
+			resolved = append(resolved, dra[i])
+		case appconfig.DuplicatePolicySharing:
+			resolved = append(resolved, m)
+		default:
+			merged := dra[i]
+			for k, v := range m.Attributes {
+				if _, exists := merged.Attributes[k]; !exists {
+					merged.Attributes[k] = v
+				}
+			}
+			for k := range merged.Attributes {
+				delete(merged.Labels, k)
+			}
+			resolved = append(resolved, merged)
+		}
+	}
+
+	for i, m := range dra {
+		if !duplicated[i] {
+			resolved = append(resolved, m)
+		}
+	}
+	return resolved, duplicates
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+)
+
+func TestResolveDuplicates(t *testing.T) {
+	sharingMetrics := func() []collector.Metric {
+		return []collector.Metric{
+			{
+				Value:  "42",
+				Labels: map[string]string{},
+				Attributes: map[string]string{
+					podAttribute: "pod-a", namespaceAttribute: "default", containerAttribute: "main",
+					vgpuAttribute: "0",
+				},
+			},
+			{
+				Value:  "42",
+				Labels: map[string]string{},
+				Attributes: map[string]string{
+					podAttribute: "pod-b", namespaceAttribute: "default", containerAttribute: "main",
+					vgpuAttribute: "1",
+				},
+			},
+		}
+	}
+	draMetrics := func() []collector.Metric {
+		return []collector.Metric{
+			{
+				Value:  "42",
+				Labels: map[string]string{vgpuAttribute: "stale"},
+				Attributes: map[string]string{
+					podAttribute: "pod-a", namespaceAttribute: "default", containerAttribute: "main",
+					draClaimName: "claim-a",
+				},
+			},
+			{
+				Value:  "42",
+				Labels: map[string]string{},
+				Attributes: map[string]string{
+					podAttribute: "pod-c", namespaceAttribute: "default", containerAttribute: "main",
+					draClaimName: "claim-c",
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		policy appconfig.DuplicatePolicy
+		want   []map[string]string
+	}{
+		{
+			name:   "DRA",
+			policy: appconfig.DuplicatePolicyDRA,
+			want: []map[string]string{
+				{podAttribute: "pod-a", namespaceAttribute: "default", containerAttribute: "main", draClaimName: "claim-a"},
+				{podAttribute: "pod-b", namespaceAttribute: "default", containerAttribute: "main", vgpuAttribute: "1"},
+				{podAttribute: "pod-c", namespaceAttribute: "default", containerAttribute: "main", draClaimName: "claim-c"},
+			},
+		},
+		{
+			name:   "Sharing",
+			policy: appconfig.DuplicatePolicySharing,
+			want: []map[string]string{
+				{podAttribute: "pod-a", namespaceAttribute: "default", containerAttribute: "main", vgpuAttribute: "0"},
+				{podAttribute: "pod-b", namespaceAttribute: "default", containerAttribute: "main", vgpuAttribute: "1"},
+				{podAttribute: "pod-c", namespaceAttribute: "default", containerAttribute: "main", draClaimName: "claim-c"},
+			},
+		},
+		{
+			name:   "Merge",
+			policy: appconfig.DuplicatePolicyMerge,
+			want: []map[string]string{
+				{
+					podAttribute: "pod-a", namespaceAttribute: "default", containerAttribute: "main",
+					draClaimName: "claim-a", vgpuAttribute: "0",
+				},
+				{podAttribute: "pod-b", namespaceAttribute: "default", containerAttribute: "main", vgpuAttribute: "1"},
+				{podAttribute: "pod-c", namespaceAttribute: "default", containerAttribute: "main", draClaimName: "claim-c"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, duplicates := resolveDuplicates(sharingMetrics(), draMetrics(), tt.policy, false)
+			assert.Equal(t, 1, duplicates)
+			var got []map[string]string
+			for _, m := range resolved {
+				got = append(got, m.Attributes)
+				for k := range m.Attributes {
+					assert.NotContains(t, m.Labels, k)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("Without DRA clones", func(t *testing.T) {
+		resolved, duplicates := resolveDuplicates(sharingMetrics(), nil, appconfig.DuplicatePolicyMerge, false)
+		assert.Equal(t, 0, duplicates)
+		assert.Equal(t, sharingMetrics(), resolved)
+	})
+
+	t.Run("Old namespace", func(t *testing.T) {
+		sharing := []collector.Metric{{Attributes: map[string]string{
+			oldPodAttribute: "pod-a", oldNamespaceAttribute: "default", oldContainerAttribute: "main",
+		}}}
+		dra := []collector.Metric{{Attributes: map[string]string{
+			oldPodAttribute: "pod-a", oldNamespaceAttribute: "default", oldContainerAttribute: "main",
+		}}}
+		resolved, duplicates := resolveDuplicates(sharing, dra, appconfig.DuplicatePolicyDRA, true)
+		assert.Equal(t, 1, duplicates)
+		assert.Len(t, resolved, 1)
+	})
+}
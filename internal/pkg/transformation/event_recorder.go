@@ -0,0 +1,253 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/events"
+)
+
+// throttleReasons are the clock event reasons that slow the GPU down, the other ones, such as gpu_idle,
+// are not recorded
+var throttleReasons = []string{"power_cap", "sw_thermal", "hw_slowdown", "hw_thermal", "hw_power_brake"}
+
+// hwThrottleReasons are the throttle reasons recorded as warnings
+var hwThrottleReasons = []string{"hw_slowdown", "hw_thermal", "hw_power_brake"}
+
+// EventRecorder records the changes of the XID errors, the double-bit ECC errors, the pending resets, the clock
+// throttle reasons and the health watches of the GPUs to the event log served by /api/v1/events. The values are
+// sampled: a change is recorded at the time it is collected, and the changes between two collections are not
+// seen.
+type EventRecorder struct {
+	mtx        sync.Mutex
+	xids       map[string]string // GPU -> last XID
+	dbes       map[string]int64  // GPU -> volatile double-bit ECC errors at the last collection
+	needsReset map[string]string // GPU -> whether the GPU needed a reset at the last collection
+	throttle   map[string]string // GPU -> throttle reasons set at the last collection
+	health     map[string]string // GPU and health watch -> last health result
+}
+
+func NewEventRecorder() *EventRecorder {
+	return &EventRecorder{
+		xids:       map[string]string{},
+		dbes:       map[string]int64{},
+		needsReset: map[string]string{},
+		throttle:   map[string]string{},
+		health:     map[string]string{},
+	}
+}
+
+func (t *EventRecorder) Name() string {
+	return "EventRecorder"
+}
+
+func (t *EventRecorder) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	for counter, metricList := range metrics {
+		if counter.IsLabel() {
+			continue
+		}
+
+		for _, m := range metricList {
+			switch {
+			case counter.FieldID == dcgm.DCGM_FI_DEV_XID_ERRORS:
+				t.observeXID(m)
+			case counter.FieldID == dcgm.DCGM_FI_DEV_ECC_DBE_VOL_TOTAL:
+				t.observeDBE(m)
+			case counter.FieldName == counters.DCGMExpGPUNeedsReset:
+				t.observeNeedsReset(m)
+			case counter.FieldID == dcgm.DCGM_FI_DEV_CLOCKS_EVENT_REASONS:
+				t.observeThrottle(m)
+			case counter.FieldName == counters.DCGMExpGPUHealthStatus:
+				t.observeHealth(m)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *EventRecorder) observeXID(m collector.Metric) {
+	key := eventSeriesKey(m)
+	last, seen := t.xids[key]
+	t.xids[key] = m.Value
+	if m.Value == "0" || (seen && last == m.Value) {
+		return
+	}
+
+	attributes := eventAttributes(m)
+	attributes["err_code"] = m.Value
+	if msg, exists := m.Attributes["err_msg"]; exists {
+		attributes["err_msg"] = msg
+	}
+	events.Record(events.Event{
+		Type:       events.TypeXID,
+		Severity:   events.SeverityError,
+		GPU:        m.GPU,
+		GPUUUID:    m.GPUUUID,
+		Message:    "XID " + m.Value,
+		Attributes: attributes,
+	})
+}
+
+func (t *EventRecorder) observeDBE(m collector.Metric) {
+	count, err := strconv.ParseInt(m.Value, 10, 64)
+	if err != nil {
+		return
+	}
+
+	key := eventSeriesKey(m)
+	last, seen := t.dbes[key]
+	t.dbes[key] = count
+	// The volatile counter restarts from 0 when the driver is reloaded
+	if !seen || count <= last {
+		return
+	}
+
+	attributes := eventAttributes(m)
+	attributes["errors"] = strconv.FormatInt(count-last, 10)
+	attributes["total"] = m.Value
+	events.Record(events.Event{
+		Type:       events.TypeECC,
+		Severity:   events.SeverityError,
+		GPU:        m.GPU,
+		GPUUUID:    m.GPUUUID,
+		Message:    fmt.Sprintf("Double-bit ECC errors: %d", count-last),
+		Attributes: attributes,
+	})
+}
+
+func (t *EventRecorder) observeNeedsReset(m collector.Metric) {
+	key := eventSeriesKey(m)
+	last, seen := t.needsReset[key]
+	t.needsReset[key] = m.Value
+	if last == m.Value || (!seen && m.Value == "0") {
+		return
+	}
+
+	event := events.Event{
+		Type:       events.TypeReset,
+		Severity:   events.SeverityInfo,
+		GPU:        m.GPU,
+		GPUUUID:    m.GPUUUID,
+		Message:    "GPU no longer needs a reset",
+		Attributes: eventAttributes(m),
+	}
+	if m.Value != "0" {
+		event.Severity = events.SeverityError
+		event.Message = "GPU needs a reset to remap rows or retire pages"
+	}
+	events.Record(event)
+}
+
+func (t *EventRecorder) observeThrottle(m collector.Metric) {
+	bitmask, err := strconv.ParseInt(m.Value, 10, 64)
+	if err != nil {
+		return
+	}
+
+	var reasons []string
+	for _, reason := range collector.ClockEventReasons(bitmask) {
+		if slices.Contains(throttleReasons, reason) {
+			reasons = append(reasons, reason)
+		}
+	}
+	current := strings.Join(reasons, ",")
+
+	key := eventSeriesKey(m)
+	last := t.throttle[key]
+	t.throttle[key] = current
+	if last == current {
+		return
+	}
+
+	event := events.Event{
+		Type:       events.TypeThrottle,
+		Severity:   events.SeverityInfo,
+		GPU:        m.GPU,
+		GPUUUID:    m.GPUUUID,
+		Message:    "Clocks no longer throttled",
+		Attributes: eventAttributes(m),
+	}
+	if current != "" {
+		event.Message = "Clocks throttled: " + strings.Join(reasons, ", ")
+		event.Attributes["reasons"] = current
+		if slices.ContainsFunc(reasons, func(reason string) bool { return slices.Contains(hwThrottleReasons, reason) }) {
+			event.Severity = events.SeverityWarning
+		}
+	}
+	events.Record(event)
+}
+
+func (t *EventRecorder) observeHealth(m collector.Metric) {
+	watch := m.Labels["health_watch"]
+	key := eventSeriesKey(m) + "/" + watch
+	last, seen := t.health[key]
+	t.health[key] = m.Value
+	if last == m.Value || (!seen && m.Value == strconv.Itoa(int(dcgm.DCGM_HEALTH_RESULT_PASS))) {
+		return
+	}
+
+	attributes := eventAttributes(m)
+	attributes["health_watch"] = watch
+	attributes["health_error_code"] = m.Labels["health_error_code"]
+
+	event := events.Event{
+		Type:       events.TypeHealth,
+		GPU:        m.GPU,
+		GPUUUID:    m.GPUUUID,
+		Attributes: attributes,
+	}
+	switch m.Value {
+	case strconv.Itoa(int(dcgm.DCGM_HEALTH_RESULT_PASS)):
+		event.Severity = events.SeverityInfo
+		event.Message = fmt.Sprintf("Health watch %s passes again", watch)
+	case strconv.Itoa(int(dcgm.DCGM_HEALTH_RESULT_WARN)):
+		event.Severity = events.SeverityWarning
+		event.Message = fmt.Sprintf("Health watch %s warns: %s", watch, m.Labels["health_error_code"])
+	default:
+		event.Severity = events.SeverityError
+		event.Message = fmt.Sprintf("Health watch %s fails: %s", watch, m.Labels["health_error_code"])
+	}
+	events.Record(event)
+}
+
+// eventSeriesKey identifies the GPU, or the GPU instance, of a metric
+func eventSeriesKey(m collector.Metric) string {
+	return m.GPUUUID + "/" + m.GPU + "/" + m.GPUInstanceID
+}
+
+// eventAttributes returns the attributes identifying the GPU instance of a metric in an event
+func eventAttributes(m collector.Metric) map[string]string {
+	attributes := map[string]string{}
+	if m.GPUInstanceID != "" {
+		attributes["gpu_instance_id"] = m.GPUInstanceID
+	}
+	return attributes
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/events"
+)
+
+// clearEvents empties the event log, before and after the test
+func clearEvents(t *testing.T) {
+	t.Helper()
+	empty := func() {
+		events.SetCapacity(0)
+		events.SetCapacity(events.DefaultCapacity)
+	}
+	empty()
+	t.Cleanup(empty)
+}
+
+func TestEventRecorder_Process(t *testing.T) {
+	clearEvents(t)
+
+	xidCounter := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_XID_ERRORS, FieldName: "DCGM_FI_DEV_XID_ERRORS"}
+	reasonsCounter := counters.Counter{
+		FieldID:   dcgm.DCGM_FI_DEV_CLOCKS_EVENT_REASONS,
+		FieldName: "DCGM_FI_DEV_CLOCKS_EVENT_REASONS",
+	}
+	healthCounter := counters.Counter{FieldName: counters.DCGMExpGPUHealthStatus}
+
+	transform := NewEventRecorder()
+	process := func(xid, reasons, health string) []events.Event {
+		before := len(events.Events(nil))
+		metrics := collector.MetricsByCounter{
+			xidCounter: {{
+				Counter: xidCounter, GPU: "0", GPUUUID: "GPU-0", Value: xid,
+				Attributes: map[string]string{"err_code": xid, "err_msg": "Graphics Engine Exception"},
+			}},
+			reasonsCounter: {{Counter: reasonsCounter, GPU: "0", GPUUUID: "GPU-0", Value: reasons}},
+			healthCounter: {{
+				Counter: healthCounter, GPU: "0", GPUUUID: "GPU-0", Value: health,
+				Labels: map[string]string{"health_watch": "THERMAL", "health_error_code": "DCGM_FR_CLOCKS_EVENT_THERMAL"},
+			}},
+		}
+		require.NoError(t, transform.Process(metrics, nil))
+		recorded := events.Events(nil)
+		return recorded[:len(recorded)-before]
+	}
+
+	// A healthy GPU records nothing, gpu_idle is not a throttle reason
+	assert.Empty(t, process("0", "1", "0"))
+
+	// An XID, a HW thermal slowdown and a failed health watch
+	recorded := process("13", "64", "20")
+	require.Len(t, recorded, 3)
+	byType := map[events.Type]events.Event{}
+	for _, event := range recorded {
+		byType[event.Type] = event
+	}
+	assert.Equal(t, events.SeverityError, byType[events.TypeXID].Severity)
+	assert.Equal(t, "13", byType[events.TypeXID].Attributes["err_code"])
+	assert.Equal(t, "Graphics Engine Exception", byType[events.TypeXID].Attributes["err_msg"])
+	assert.Equal(t, events.SeverityWarning, byType[events.TypeThrottle].Severity)
+	assert.Equal(t, "hw_thermal", byType[events.TypeThrottle].Attributes["reasons"])
+	assert.Equal(t, events.SeverityError, byType[events.TypeHealth].Severity)
+	assert.Equal(t, "THERMAL", byType[events.TypeHealth].Attributes["health_watch"])
+	assert.Equal(t, "GPU-0", byType[events.TypeHealth].GPUUUID)
+
+	// Unchanged values are not recorded again
+	assert.Empty(t, process("13", "64", "20"))
+
+	// A power cap alone is informational, and the health watch recovers
+	recorded = process("13", "4", "0")
+	require.Len(t, recorded, 2)
+	for _, event := range recorded {
+		assert.Equal(t, events.SeverityInfo, event.Severity, event.Message)
+	}
+
+	// The throttling ends
+	recorded = process("13", "0", "0")
+	require.Len(t, recorded, 1)
+	assert.Equal(t, events.TypeThrottle, recorded[0].Type)
+	assert.Empty(t, recorded[0].Attributes["reasons"])
+}
+
+func TestEventRecorder_ProcessDBEAndReset(t *testing.T) {
+	clearEvents(t)
+
+	dbeCounter := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_ECC_DBE_VOL_TOTAL, FieldName: "DCGM_FI_DEV_ECC_DBE_VOL_TOTAL"}
+	resetCounter := counters.Counter{FieldName: counters.DCGMExpGPUNeedsReset}
+
+	transform := NewEventRecorder()
+	process := func(dbes, needsReset string) []events.Event {
+		before := len(events.Events(nil))
+		metrics := collector.MetricsByCounter{
+			dbeCounter:   {{Counter: dbeCounter, GPU: "0", GPUUUID: "GPU-0", Value: dbes}},
+			resetCounter: {{Counter: resetCounter, GPU: "0", GPUUUID: "GPU-0", Value: needsReset}},
+		}
+		require.NoError(t, transform.Process(metrics, nil))
+		recorded := events.Events(nil)
+		return recorded[:len(recorded)-before]
+	}
+
+	// The first collection only sets the baseline of the counter
+	assert.Empty(t, process("3", "0"))
+
+	// New double-bit errors, and the GPU needs a reset
+	recorded := process("5", "1")
+	require.Len(t, recorded, 2)
+	byType := map[events.Type]events.Event{}
+	for _, event := range recorded {
+		byType[event.Type] = event
+		assert.Equal(t, events.SeverityError, event.Severity, event.Message)
+	}
+	assert.Equal(t, "2", byType[events.TypeECC].Attributes["errors"])
+	assert.Equal(t, "5", byType[events.TypeECC].Attributes["total"])
+	assert.Equal(t, "GPU-0", byType[events.TypeReset].GPUUUID)
+
+	// The driver is reloaded, the counter restarts and the GPU was reset
+	recorded = process("0", "0")
+	require.Len(t, recorded, 1)
+	assert.Equal(t, events.TypeReset, recorded[0].Type)
+	assert.Equal(t, events.SeverityInfo, recorded[0].Severity)
+}
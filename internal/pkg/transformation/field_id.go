@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"maps"
+	"strconv"
+	"sync"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+)
+
+// FieldIDLabeler exports the DCGM field ID of the families named after a DCGM field, so that tooling can map the
+// series back to the DCGM field documentation. The ID is either the dcgm_field_id label of every metric, or the
+// dcgm_exporter_field_info gauge rendered by the server from FieldInfo. The families of the exporter, e.g.
+// DCGM_EXP_XID_ERRORS_COUNT, have no DCGM field ID.
+type FieldIDLabeler struct {
+	asLabels bool
+
+	mtx    sync.Mutex
+	fields map[string]dcgm.Short // DCGM field name -> ID of the families seen, in the info mode
+}
+
+func NewFieldIDLabeler(mode appconfig.FieldIDMode) *FieldIDLabeler {
+	return &FieldIDLabeler{
+		asLabels: mode != appconfig.FieldIDModeInfo,
+		fields:   map[string]dcgm.Short{},
+	}
+}
+
+func (t *FieldIDLabeler) Name() string {
+	return "FieldIDLabeler"
+}
+
+func (t *FieldIDLabeler) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	for counter, metricList := range metrics {
+		if counter.IsLabel() {
+			continue
+		}
+		fieldID, ok := dcgm.GetFieldID(counter.FieldName)
+		if !ok {
+			continue
+		}
+
+		if !t.asLabels {
+			t.mtx.Lock()
+			t.fields[counter.FieldName] = fieldID
+			t.mtx.Unlock()
+			continue
+		}
+
+		value := strconv.Itoa(int(fieldID))
+		for i := range metricList {
+			// Labels may be shared between metrics of a collector
+			labels := make(map[string]string, len(metricList[i].Labels)+1)
+			maps.Copy(labels, metricList[i].Labels)
+			labels[fieldIDLabel] = value
+			metricList[i].Labels = labels
+		}
+	}
+	return nil
+}
+
+// FieldInfo returns the DCGM field IDs of the families seen so far by field name, and whether they are exported
+// by the dcgm_exporter_field_info gauge rather than added to the metrics.
+func (t *FieldIDLabeler) FieldInfo() (map[string]dcgm.Short, bool) {
+	if t.asLabels {
+		return nil, false
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	return maps.Clone(t.fields), true
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+func TestFieldIDLabeler_Process(t *testing.T) {
+	gpuTemp := counters.Counter{
+		FieldID:   dcgm.DCGM_FI_DEV_GPU_TEMP,
+		FieldName: "DCGM_FI_DEV_GPU_TEMP",
+		PromType:  "gauge",
+	}
+	xidCount := counters.Counter{
+		FieldID:   dcgm.Short(counters.DCGMXIDErrorsCount),
+		FieldName: counters.DCGMExpXIDErrorsCount,
+		PromType:  "gauge",
+	}
+
+	newMetrics := func(sharedLabels map[string]string) collector.MetricsByCounter {
+		return collector.MetricsByCounter{
+			gpuTemp: {
+				{Counter: gpuTemp, GPU: "0", Value: "40"},
+				{Counter: gpuTemp, GPU: "1", Value: "41", Labels: sharedLabels},
+			},
+			xidCount: {{Counter: xidCount, GPU: "0", Value: "0"}},
+		}
+	}
+
+	t.Run("label", func(t *testing.T) {
+		sharedLabels := map[string]string{"window_size_in_ms": "60000"}
+		metrics := newMetrics(sharedLabels)
+		labeler := NewFieldIDLabeler(appconfig.FieldIDModeLabel)
+		require.NoError(t, labeler.Process(metrics, nil))
+
+		for _, m := range metrics[gpuTemp] {
+			assert.Equal(t, "150", m.Labels[fieldIDLabel])
+		}
+		assert.NotContains(t, metrics[xidCount][0].Labels, fieldIDLabel, "The exporter families have no field ID")
+		assert.NotContains(t, sharedLabels, fieldIDLabel, "Labels shared between metrics must not be modified")
+
+		_, enabled := labeler.FieldInfo()
+		assert.False(t, enabled)
+	})
+
+	t.Run("info", func(t *testing.T) {
+		metrics := newMetrics(nil)
+		labeler := NewFieldIDLabeler(appconfig.FieldIDModeInfo)
+		require.NoError(t, labeler.Process(metrics, nil))
+
+		for _, m := range metrics[gpuTemp] {
+			assert.NotContains(t, m.Labels, fieldIDLabel)
+		}
+		fields, enabled := labeler.FieldInfo()
+		assert.True(t, enabled)
+		assert.Equal(t, map[string]dcgm.Short{"DCGM_FI_DEV_GPU_TEMP": dcgm.DCGM_FI_DEV_GPU_TEMP}, fields)
+	})
+}
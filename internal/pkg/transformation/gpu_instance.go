@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"maps"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+)
+
+// GPUInstanceLabeler adds the gpu_instance label with the identifier of the GPU instance to the MIG device metrics.
+// The identifier has the same format as the one the pod mapper joins the metrics with pods on.
+type GPUInstanceLabeler struct {
+	format appconfig.GPUInstanceIDFormat
+}
+
+func NewGPUInstanceLabeler(format appconfig.GPUInstanceIDFormat) *GPUInstanceLabeler {
+	return &GPUInstanceLabeler{format: format}
+}
+
+func (t *GPUInstanceLabeler) Name() string {
+	return "GPUInstanceLabeler"
+}
+
+func (t *GPUInstanceLabeler) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	for _, metricList := range metrics {
+		for i := range metricList {
+			if metricList[i].MigProfile == "" || metricList[i].GPUInstanceID == "" {
+				continue
+			}
+
+			// Labels may be shared between metrics of a collector
+			labels := make(map[string]string, len(metricList[i].Labels)+1)
+			maps.Copy(labels, metricList[i].Labels)
+			labels[gpuInstanceLabel] = deviceinfo.FormatGPUInstanceIdentifier(t.format, metricList[i].GPU,
+				metricList[i].GPUUUID, metricList[i].GPUInstanceID)
+			metricList[i].Labels = labels
+		}
+	}
+	return nil
+}
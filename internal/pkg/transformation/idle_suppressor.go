@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+)
+
+// IdleMetricsSuppressor drops the series of the configured families for idle GPUs: GPUs without
+// running compute processes and with zero utilization. Mostly idle clusters then store the core
+// gauges only. A GPU is never considered idle when its utilization isn't collected.
+type IdleMetricsSuppressor struct {
+	families map[string]bool
+}
+
+func NewIdleMetricsSuppressor(families []string) *IdleMetricsSuppressor {
+	t := &IdleMetricsSuppressor{families: make(map[string]bool, len(families))}
+	for _, family := range families {
+		t.families[family] = true
+	}
+	return t
+}
+
+func (t *IdleMetricsSuppressor) Name() string {
+	return "IdleMetricsSuppressor"
+}
+
+func (t *IdleMetricsSuppressor) Process(metrics collector.MetricsByCounter, deviceInfo deviceinfo.Provider) error {
+	idleGPUs := t.idleGPUs(metrics, deviceInfo)
+	if len(idleGPUs) == 0 {
+		return nil
+	}
+
+	for counter, metricList := range metrics {
+		if !t.families[counter.FieldName] {
+			continue
+		}
+
+		kept := metricList[:0]
+		for _, m := range metricList {
+			if !idleGPUs[m.GPU] {
+				kept = append(kept, m)
+			}
+		}
+
+		if len(kept) == 0 {
+			delete(metrics, counter)
+		} else {
+			metrics[counter] = kept
+		}
+	}
+
+	return nil
+}
+
+// idleGPUs returns the indexes of the GPUs with zero utilization and no running compute processes
+func (t *IdleMetricsSuppressor) idleGPUs(
+	metrics collector.MetricsByCounter, deviceInfo deviceinfo.Provider,
+) map[string]bool {
+	if deviceInfo == nil {
+		return nil
+	}
+
+	// GPU index -> whether every utilization sample of the GPU is zero
+	zeroUtil := map[string]bool{}
+	for counter, metricList := range metrics {
+		if counter.FieldName != metricGPUUtil {
+			continue
+		}
+		for _, m := range metricList {
+			val, err := strconv.ParseFloat(m.Value, 64)
+			zero := err == nil && val == 0
+			if prev, exists := zeroUtil[m.GPU]; exists {
+				zero = zero && prev
+			}
+			zeroUtil[m.GPU] = zero
+		}
+	}
+
+	idle := map[string]bool{}
+	for i := uint(0); i < deviceInfo.GPUCount(); i++ {
+		gpu := deviceInfo.GPU(i)
+		gpuID := fmt.Sprint(gpu.DeviceInfo.GPU)
+		if !zeroUtil[gpuID] {
+			continue
+		}
+
+		hasProcesses, err := hasComputeProcesses(gpu)
+		if err != nil {
+			slog.Debug("Failed to get the running processes, the GPU is not considered idle",
+				slog.String("gpuUUID", gpu.DeviceInfo.UUID),
+				slog.String(logging.ErrorKey, err.Error()))
+			continue
+		}
+
+		if !hasProcesses {
+			idle[gpuID] = true
+		}
+	}
+
+	return idle
+}
+
+func hasComputeProcesses(gpu deviceinfo.GPUInfo) (bool, error) {
+	if len(gpu.GPUInstances) > 0 {
+		instanceProcesses, err := nvmlprovider.Client().GetAllMIGDevicesProcessMemory(gpu.DeviceInfo.UUID)
+		if err != nil {
+			return false, err
+		}
+		for _, processes := range instanceProcesses {
+			if len(processes) > 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	processes, err := nvmlprovider.Client().GetDeviceProcessMemory(gpu.DeviceInfo.UUID)
+	if err != nil {
+		return false, err
+	}
+	return len(processes) > 0, nil
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdeviceinfo "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/deviceinfo"
+	mocknvmlprovider "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/nvmlprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+)
+
+func TestIdleMetricsSuppressor_Process(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	gpus := []deviceinfo.GPUInfo{{}, {}, {}}
+	for i, uuid := range []string{"GPU-0", "GPU-1", "GPU-2"} {
+		gpus[i].DeviceInfo.GPU = uint(i)
+		gpus[i].DeviceInfo.UUID = uuid
+	}
+	mockDeviceInfo := mockdeviceinfo.NewMockProvider(ctrl)
+	mockDeviceInfo.EXPECT().GPUCount().Return(uint(len(gpus))).AnyTimes()
+	for i, gpu := range gpus {
+		mockDeviceInfo.EXPECT().GPU(uint(i)).Return(gpu).AnyTimes()
+	}
+
+	// GPU 0 is idle, GPU 1 has a process but zero utilization, GPU 2 is busy
+	mockNVML := mocknvmlprovider.NewMockNVML(ctrl)
+	mockNVML.EXPECT().GetDeviceProcessMemory("GPU-0").Return(map[uint32]uint64{}, nil)
+	mockNVML.EXPECT().GetDeviceProcessMemory("GPU-1").Return(map[uint32]uint64{42: 1024}, nil)
+
+	realNVML := nvmlprovider.Client()
+	defer nvmlprovider.SetClient(realNVML)
+	nvmlprovider.SetClient(mockNVML)
+
+	gpuUtil := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_GPU_UTIL, FieldName: metricGPUUtil, PromType: "gauge"}
+	grEngineActive := counters.Counter{
+		FieldID:   dcgm.DCGM_FI_PROF_GR_ENGINE_ACTIVE,
+		FieldName: "DCGM_FI_PROF_GR_ENGINE_ACTIVE",
+		PromType:  "gauge",
+	}
+	smClock := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_SM_CLOCK, FieldName: "DCGM_FI_DEV_SM_CLOCK", PromType: "gauge"}
+
+	metrics := collector.MetricsByCounter{
+		gpuUtil: {
+			{Counter: gpuUtil, GPU: "0", Value: "0"},
+			{Counter: gpuUtil, GPU: "1", Value: "0"},
+			{Counter: gpuUtil, GPU: "2", Value: "35"},
+		},
+		grEngineActive: {
+			{Counter: grEngineActive, GPU: "0", Value: "0"},
+			{Counter: grEngineActive, GPU: "1", Value: "0"},
+			{Counter: grEngineActive, GPU: "2", Value: "0.4"},
+		},
+		smClock: {
+			{Counter: smClock, GPU: "0", Value: "210"},
+		},
+	}
+
+	transform := NewIdleMetricsSuppressor([]string{"DCGM_FI_PROF_GR_ENGINE_ACTIVE", "DCGM_FI_DEV_SM_CLOCK"})
+	require.NoError(t, transform.Process(metrics, mockDeviceInfo))
+
+	require.Len(t, metrics[grEngineActive], 2)
+	assert.Equal(t, "1", metrics[grEngineActive][0].GPU)
+	assert.Equal(t, "2", metrics[grEngineActive][1].GPU)
+
+	// A family with series of idle GPUs only is dropped
+	assert.NotContains(t, metrics, smClock)
+
+	// Families that aren't listed are kept
+	assert.Len(t, metrics[gpuUtil], 3)
+}
+
+func TestIdleMetricsSuppressor_ProcessWithoutUtilization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockDeviceInfo := mockdeviceinfo.NewMockProvider(ctrl)
+	mockDeviceInfo.EXPECT().GPUCount().Return(uint(1)).AnyTimes()
+	mockDeviceInfo.EXPECT().GPU(uint(0)).Return(deviceinfo.GPUInfo{}).AnyTimes()
+
+	smClock := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_SM_CLOCK, FieldName: "DCGM_FI_DEV_SM_CLOCK", PromType: "gauge"}
+	metrics := collector.MetricsByCounter{
+		smClock: {{Counter: smClock, GPU: "0", Value: "210"}},
+	}
+
+	// The GPU isn't considered idle, the running processes aren't even checked
+	require.NoError(t, NewIdleMetricsSuppressor([]string{"DCGM_FI_DEV_SM_CLOCK"}).Process(metrics, mockDeviceInfo))
+	assert.Len(t, metrics[smClock], 1)
+}
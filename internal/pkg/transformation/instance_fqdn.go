@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"maps"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+)
+
+// InstanceFQDNLabeler adds the instance_fqdn label with the fully qualified domain name of the host.
+// Federated setups identify the host by short name, FQDN or node IP, the label lets them join series reliably.
+type InstanceFQDNLabeler struct {
+	fqdn string
+}
+
+func NewInstanceFQDNLabeler(fqdn string) *InstanceFQDNLabeler {
+	return &InstanceFQDNLabeler{fqdn: fqdn}
+}
+
+func (t *InstanceFQDNLabeler) Name() string {
+	return "InstanceFQDNLabeler"
+}
+
+func (t *InstanceFQDNLabeler) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	for _, metricList := range metrics {
+		for i := range metricList {
+			// Labels may be shared between metrics of a collector
+			labels := make(map[string]string, len(metricList[i].Labels)+1)
+			maps.Copy(labels, metricList[i].Labels)
+			labels[instanceFQDNLabel] = t.fqdn
+			metricList[i].Labels = labels
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+func TestInstanceFQDNLabeler_Process(t *testing.T) {
+	gpuTemp := counters.Counter{
+		FieldID:   dcgm.DCGM_FI_DEV_GPU_TEMP,
+		FieldName: "DCGM_FI_DEV_GPU_TEMP",
+		PromType:  "gauge",
+	}
+
+	sharedLabels := map[string]string{"window_size_in_ms": "60000"}
+	metrics := collector.MetricsByCounter{
+		gpuTemp: {
+			{Counter: gpuTemp, GPU: "0", Value: "40"},
+			{Counter: gpuTemp, GPU: "1", Value: "41", Labels: sharedLabels},
+		},
+	}
+
+	require.NoError(t, NewInstanceFQDNLabeler("node1.example.com").Process(metrics, nil))
+
+	for _, m := range metrics[gpuTemp] {
+		assert.Equal(t, "node1.example.com", m.Labels[instanceFQDNLabel])
+	}
+	assert.Equal(t, "60000", metrics[gpuTemp][1].Labels["window_size_in_ms"])
+	assert.NotContains(t, sharedLabels, instanceFQDNLabel, "Labels shared between metrics must not be modified")
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	stdos "os"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+)
+
+// kubeletCheckpoint is the checkpoint of the devices allocated by the device plugins, written by the kubelet to
+// the kubelet_internal_checkpoint file of its device-plugins directory
+type kubeletCheckpoint struct {
+	Data struct {
+		PodDeviceEntries []kubeletCheckpointEntry
+	}
+}
+
+// kubeletCheckpointEntry is the devices of a resource allocated to a container
+type kubeletCheckpointEntry struct {
+	PodUID        string
+	ContainerName string
+	ResourceName  string
+	// Device IDs by NUMA node since Kubernetes 1.20, a list of device IDs before
+	DeviceIDs json.RawMessage
+}
+
+// deviceIDs returns the device IDs of the entry, in the order of the NUMA nodes
+func (e kubeletCheckpointEntry) deviceIDs() ([]string, error) {
+	var byNUMANode map[string][]string
+	if err := json.Unmarshal(e.DeviceIDs, &byNUMANode); err == nil {
+		var ids []string
+		for _, node := range slices.Sorted(maps.Keys(byNUMANode)) {
+			ids = append(ids, byNUMANode[node]...)
+		}
+		return ids, nil
+	}
+
+	var ids []string
+	if err := json.Unmarshal(e.DeviceIDs, &ids); err != nil {
+		return nil, fmt.Errorf("invalid device IDs of pod %s: %w", e.PodUID, err)
+	}
+	return ids, nil
+}
+
+// readKubeletCheckpoint returns the entries of the kubelet device plugin checkpoint
+func readKubeletCheckpoint(path string) ([]kubeletCheckpointEntry, error) {
+	data, err := stdos.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failure reading the kubelet checkpoint: %w", err)
+	}
+
+	var checkpoint kubeletCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failure parsing the kubelet checkpoint '%s': %w", path, err)
+	}
+	return checkpoint.Data.PodDeviceEntries, nil
+}
+
+// listCheckpointPods returns the pod resources of the kubelet device plugin checkpoint, the fallback of the
+// pod-resources socket. The checkpoint only has the UIDs of the pods, whose names are looked up in the pod
+// informer: the pods it doesn't know, e.g. deleted pods the kubelet didn't clean up yet, are skipped. The
+// checkpoint has no dynamic resources.
+func (p *PodMapper) listCheckpointPods() (*podresourcesapi.ListPodResourcesResponse, error) {
+	if p.podLister == nil {
+		return nil, errors.New("the pod informer is required to map the pods of the kubelet checkpoint")
+	}
+
+	entries, err := readKubeletCheckpoint(p.Config.KubeletCheckpointFile)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := p.podLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failure listing the pods of the kubelet checkpoint: %w", err)
+	}
+	podsByUID := make(map[string]*corev1.Pod, len(pods))
+	for _, pod := range pods {
+		podsByUID[string(pod.UID)] = pod
+	}
+
+	resp := &podresourcesapi.ListPodResourcesResponse{}
+	podResources := map[string]*podresourcesapi.PodResources{}
+	for _, entry := range entries {
+		pod, exists := podsByUID[entry.PodUID]
+		if !exists {
+			continue
+		}
+		ids, err := entry.deviceIDs()
+		if err != nil {
+			return nil, err
+		}
+
+		resources, exists := podResources[entry.PodUID]
+		if !exists {
+			resources = &podresourcesapi.PodResources{Name: pod.Name, Namespace: pod.Namespace}
+			podResources[entry.PodUID] = resources
+			resp.PodResources = append(resp.PodResources, resources)
+		}
+
+		i := slices.IndexFunc(resources.Containers, func(c *podresourcesapi.ContainerResources) bool {
+			return c.Name == entry.ContainerName
+		})
+		if i < 0 {
+			resources.Containers = append(resources.Containers,
+				&podresourcesapi.ContainerResources{Name: entry.ContainerName})
+			i = len(resources.Containers) - 1
+		}
+		resources.Containers[i].Devices = append(resources.Containers[i].Devices, &podresourcesapi.ContainerDevices{
+			ResourceName: entry.ResourceName,
+			DeviceIds:    ids,
+		})
+	}
+	return resp, nil
+}
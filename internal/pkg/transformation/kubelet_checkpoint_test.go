@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	stdos "os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+)
+
+// testKubeletCheckpoint has the device IDs by NUMA node of the recent kubelets, the list of device IDs of the
+// older ones, and a pod deleted since
+const testKubeletCheckpoint = `{
+  "Data": {
+    "PodDeviceEntries": [
+      {"PodUID": "uid-1", "ContainerName": "main", "ResourceName": "nvidia.com/gpu",
+       "DeviceIDs": {"1": ["GPU-b"], "0": ["GPU-a"]}, "AllocResp": "CgQKAg=="},
+      {"PodUID": "uid-1", "ContainerName": "sidecar", "ResourceName": "nvidia.com/gpu",
+       "DeviceIDs": ["GPU-c"], "AllocResp": ""},
+      {"PodUID": "uid-deleted", "ContainerName": "main", "ResourceName": "nvidia.com/gpu",
+       "DeviceIDs": {"0": ["GPU-d"]}, "AllocResp": ""}
+    ],
+    "RegisteredDevices": {"nvidia.com/gpu": ["GPU-a", "GPU-b", "GPU-c", "GPU-d"]}
+  },
+  "Checksum": 1234
+}`
+
+func newCheckpointPodMapper(t *testing.T, checkpoint string) *PodMapper {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "kubelet_internal_checkpoint")
+	require.NoError(t, stdos.WriteFile(path, []byte(checkpoint), 0o600))
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(&v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name: "gpu-pod", Namespace: "default", UID: types.UID("uid-1"),
+	}}))
+
+	return &PodMapper{
+		Config: &appconfig.Config{
+			PodResourcesKubeletSocket: filepath.Join(t.TempDir(), "kubelet.sock"),
+			KubeletCheckpointFile:     path,
+		},
+		podLister: corev1listers.NewPodLister(indexer),
+	}
+}
+
+func TestPodMapper_listCheckpointPods(t *testing.T) {
+	pm := newCheckpointPodMapper(t, testKubeletCheckpoint)
+
+	resp, err := pm.listCheckpointPods()
+	require.NoError(t, err)
+	require.Len(t, resp.GetPodResources(), 1, "The deleted pod should be skipped")
+
+	pod := resp.GetPodResources()[0]
+	assert.Equal(t, "gpu-pod", pod.GetName())
+	assert.Equal(t, "default", pod.GetNamespace())
+	require.Len(t, pod.GetContainers(), 2)
+	assert.Equal(t, "main", pod.GetContainers()[0].GetName())
+	assert.Equal(t, []*podresourcesapi.ContainerDevices{{
+		ResourceName: "nvidia.com/gpu",
+		DeviceIds:    []string{"GPU-a", "GPU-b"},
+	}}, pod.GetContainers()[0].GetDevices())
+	assert.Equal(t, []string{"GPU-c"}, pod.GetContainers()[1].GetDevices()[0].GetDeviceIds())
+
+	pm = newCheckpointPodMapper(t, "{")
+	_, err = pm.listCheckpointPods()
+	assert.Error(t, err)
+
+	pm = newCheckpointPodMapper(t, testKubeletCheckpoint)
+	pm.podLister = nil
+	_, err = pm.listCheckpointPods()
+	assert.Error(t, err, "The pod names can't be looked up without the pod informer")
+}
+
+func TestPodMapper_listPodResources_CheckpointFallback(t *testing.T) {
+	pm := newCheckpointPodMapper(t, testKubeletCheckpoint)
+
+	resp, err := pm.listPodResources()
+	require.NoError(t, err)
+	assert.Len(t, resp.GetPodResources(), 1)
+
+	up, lastList := pm.KubeletSocketStatus()
+	assert.False(t, up, "The socket is reported down while the checkpoint is used")
+	assert.True(t, lastList.IsZero())
+
+	pm.Config.KubeletCheckpointFile = ""
+	resp, err = pm.listPodResources()
+	assert.NoError(t, err)
+	assert.Nil(t, resp, "Nothing is mapped without the socket and the fallback")
+}
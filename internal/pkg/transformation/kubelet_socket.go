@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"log/slog"
+	stdos "os"
+	"path/filepath"
+)
+
+// kubeletRootDirEnv is the kubelet root directory of the node, e.g. set from a node annotation through the
+// downward API. Its pod-resources socket is probed first.
+const kubeletRootDirEnv = "DCGM_EXPORTER_KUBELET_ROOT_DIR"
+
+// podResourcesSocketName is the path of the pod-resources socket in the kubelet root directory
+var podResourcesSocketName = filepath.Join("pod-resources", "kubelet.sock")
+
+// wellKnownKubeletRootDirs are the kubelet root directories of the common distributions
+var wellKnownKubeletRootDirs = []string{
+	"/var/lib/kubelet",                          // kubeadm, EKS, GKE, AKS, OpenShift, k3s, RKE2
+	"/var/lib/k0s/kubelet",                      // k0s
+	"/var/snap/microk8s/common/var/lib/kubelet", // MicroK8s
+	"/var/lib/rancher/k3s/agent/kubelet",        // k3s with a custom data directory
+	"/opt/rke/var/lib/kubelet",                  // RKE1
+	"/var/vcap/data/kubelet",                    // Tanzu Kubernetes Grid Integrated
+}
+
+// DiscoverPodResourcesSocket returns the first pod-resources socket found in the kubelet root directory of the
+// kubeletRootDirEnv hint, then in the well-known kubelet root directories. It returns fallback if there is none.
+func DiscoverPodResourcesSocket(fallback string) string {
+	var candidates []string
+	if hint := stdos.Getenv(kubeletRootDirEnv); hint != "" {
+		candidates = append(candidates, filepath.Join(hint, podResourcesSocketName))
+	}
+	for _, dir := range wellKnownKubeletRootDirs {
+		candidates = append(candidates, filepath.Join(dir, podResourcesSocketName))
+	}
+
+	for _, candidate := range candidates {
+		if _, err := stdos.Stat(candidate); err == nil {
+			slog.Info("Discovered the kubelet pod-resources socket", slog.String("path", candidate))
+			return candidate
+		}
+	}
+
+	slog.Warn("No kubelet pod-resources socket found in the well-known locations, using the configured path",
+		slog.String("path", fallback),
+		slog.Any("probed", candidates))
+	return fallback
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	stdos "os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverPodResourcesSocket(t *testing.T) {
+	root := t.TempDir()
+	distroDir := filepath.Join(root, "k0s")
+	hintDir := filepath.Join(root, "hint")
+
+	oldDirs := wellKnownKubeletRootDirs
+	wellKnownKubeletRootDirs = []string{filepath.Join(root, "kubelet"), distroDir}
+	defer func() { wellKnownKubeletRootDirs = oldDirs }()
+
+	fallback := "/var/lib/kubelet/pod-resources/kubelet.sock"
+	assert.Equal(t, fallback, DiscoverPodResourcesSocket(fallback), "Nothing is found")
+
+	createSocketFile := func(dir string) string {
+		path := filepath.Join(dir, podResourcesSocketName)
+		require.NoError(t, stdos.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, stdos.WriteFile(path, nil, 0o600))
+		return path
+	}
+
+	distroSocket := createSocketFile(distroDir)
+	assert.Equal(t, distroSocket, DiscoverPodResourcesSocket(fallback))
+
+	hintSocket := createSocketFile(hintDir)
+	t.Setenv(kubeletRootDirEnv, hintDir)
+	assert.Equal(t, hintSocket, DiscoverPodResourcesSocket(fallback), "The hint is probed first")
+}
@@ -19,13 +19,16 @@ package transformation
 import (
 	"container/list"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"maps"
 	"net"
 	stdos "os"
+	"path"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,6 +37,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/client-go/informers"
@@ -47,6 +51,8 @@ import (
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/identity"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/memguard"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/utils"
 )
@@ -54,9 +60,8 @@ import (
 var (
 	connectionTimeout = 10 * time.Second
 
-	// Allow for MIG devices with or without GPU sharing to match in GKE.
-	gkeMigDeviceIDRegex            = regexp.MustCompile(`^nvidia([0-9]+)/gi([0-9]+)(/vgpu[0-9]+)?$`)
-	gkeVirtualGPUDeviceIDSeparator = "/vgpu"
+	// uncachedIdentityResolver resolves the device IDs of the pod mappers not created by NewPodMapper
+	uncachedIdentityResolver = identity.NewResolver(nvmlMIGLookup, nil)
 )
 
 // DeviceProcessingFunc is a callback function type for processing devices
@@ -103,7 +108,9 @@ func NewPodMapper(c *appconfig.Config) *PodMapper {
 	podMapper := &PodMapper{
 		Config:           c,
 		labelFilterCache: newLabelFilterCache(c.KubernetesPodLabelAllowlistRegex, cacheSize),
+		annotationCache:  newLabelFilterCache(c.KubernetesPodAnnotations, cacheSize),
 		stopChan:         make(chan struct{}),
+		identityResolver: identity.NewResolver(nvmlMIGLookup, identity.NewCache()),
 	}
 
 	clusterConfig, err := rest.InClusterConfig()
@@ -139,6 +146,13 @@ func NewPodMapper(c *appconfig.Config) *PodMapper {
 	podMapper.podLister = podInformer.Lister()
 	podMapper.podInformerSynced = podInformer.Informer().HasSynced
 
+	if c.KubernetesWorkloadLabels {
+		podMapper.replicaSetFactory = newReplicaSetInformerFactory(clientset)
+		replicaSets := podMapper.replicaSetFactory.Apps().V1().ReplicaSets()
+		podMapper.replicaSetLister = replicaSets.Lister()
+		podMapper.replicaSetSynced = replicaSets.Informer().HasSynced
+	}
+
 	if c.KubernetesEnableDRA {
 		resourceSliceManager, err := NewDRAResourceSliceManager()
 		if err != nil {
@@ -147,10 +161,87 @@ func NewPodMapper(c *appconfig.Config) *PodMapper {
 		}
 		podMapper.ResourceSliceManager = resourceSliceManager
 		slog.Info("Started DRAResourceSliceManager")
+
+		// Devices created at claim time (e.g. MIG instances) are published after the claim is allocated,
+		// resync the ResourceSlices when a pod with resource claims is scheduled or started.
+		_, err = podInformer.Informer().AddEventHandler(&cache.FilteringResourceEventHandler{
+			FilterFunc: func(obj interface{}) bool {
+				pod, ok := obj.(*corev1.Pod)
+				return ok && len(pod.Spec.ResourceClaims) > 0
+			},
+			Handler: cache.ResourceEventHandlerFuncs{
+				AddFunc: func(interface{}) { resourceSliceManager.RequestResync() },
+				UpdateFunc: func(oldObj, newObj interface{}) {
+					if isClaimRelatedPodUpdate(oldObj.(*corev1.Pod), newObj.(*corev1.Pod)) {
+						resourceSliceManager.RequestResync()
+					}
+				},
+			},
+		})
+		if err != nil {
+			slog.Warn("Failed to add pod event handler, DRA devices are only resynced on lookup misses", "error", err)
+		}
 	}
 	return podMapper
 }
 
+// isClaimRelatedPodUpdate reports whether the resource claims of a pod were allocated or its containers started.
+func isClaimRelatedPodUpdate(oldPod, newPod *corev1.Pod) bool {
+	if len(oldPod.Status.ResourceClaimStatuses) != len(newPod.Status.ResourceClaimStatuses) {
+		return true
+	}
+	return oldPod.Status.Phase != newPod.Status.Phase
+}
+
+// DRAStats returns the counters of the DRA ResourceSlice manager, or false when DRA is not enabled.
+func (p *PodMapper) DRAStats() (DRAResourceSliceStats, bool) {
+	if p.ResourceSliceManager == nil {
+		return DRAResourceSliceStats{}, false
+	}
+	return p.ResourceSliceManager.Stats(), true
+}
+
+// KubeletSocketStatus returns whether the last List of the kubelet pod-resources socket succeeded, and the time
+// of the last successful one, zero if none did. The pod attribution silently disappears without the socket,
+// e.g. when it is unmounted by a kubelet upgrade.
+func (p *PodMapper) KubeletSocketStatus() (bool, time.Time) {
+	var lastList time.Time
+	if nanos := p.lastPodResourcesList.Load(); nanos != 0 {
+		lastList = time.Unix(0, nanos)
+	}
+	return p.kubeletSocketUp.Load(), lastList
+}
+
+// CacheSizes returns the number of entries of each cache of the pod mapper, without the caches of the
+// features that are not enabled.
+func (p *PodMapper) CacheSizes() map[string]int {
+	sizes := map[string]int{}
+	if p.podInformerFactory != nil {
+		sizes[PodMapperCachePods] = len(p.podInformerFactory.Core().V1().Pods().Informer().GetStore().ListKeys())
+	}
+	if cache := p.labelFilterCache; cache != nil && cache.enabled {
+		cache.mu.Lock()
+		sizes[PodMapperCacheLabelFilter] = cache.lruList.Len()
+		cache.mu.Unlock()
+	}
+	if cache := p.annotationCache; cache != nil && cache.enabled {
+		cache.mu.Lock()
+		sizes[PodMapperCacheAnnotations] = cache.lruList.Len()
+		cache.mu.Unlock()
+	}
+	if p.ResourceSliceManager != nil {
+		sizes[PodMapperCacheDRADevices] = p.ResourceSliceManager.Devices()
+	}
+	return sizes
+}
+
+// RequestDRAResync makes the next mapping resync the DRA ResourceSlices, e.g. after a GPU topology change.
+func (p *PodMapper) RequestDRAResync() {
+	if p.ResourceSliceManager != nil {
+		p.ResourceSliceManager.RequestResync()
+	}
+}
+
 // newLabelFilterCache creates a new LRU cache with pre-compiled regex patterns
 func newLabelFilterCache(patterns []string, maxSize int) *LabelFilterCache {
 	cache := &LabelFilterCache{
@@ -171,21 +262,21 @@ func newLabelFilterCache(patterns []string, maxSize int) *LabelFilterCache {
 	for _, pattern := range patterns {
 		compiled, err := regexp.Compile(pattern)
 		if err != nil {
-			slog.Warn("Failed to compile pod label allowlist regex pattern, skipping",
+			slog.Warn("Failed to compile pod metadata allowlist regex pattern, skipping",
 				"pattern", pattern,
 				"error", err)
 			continue
 		}
 		cache.compiledPatterns = append(cache.compiledPatterns, compiled)
-		slog.Info("Compiled pod label allowlist pattern", "pattern", pattern)
+		slog.Info("Compiled pod metadata allowlist pattern", "pattern", pattern)
 	}
 
 	// If all patterns failed to compile, disable filtering
 	if len(cache.compiledPatterns) == 0 {
 		cache.enabled = false
-		slog.Warn("No valid regex patterns for pod label filtering, all labels will be included")
+		slog.Warn("No valid regex patterns for pod metadata filtering", "originalPatterns", len(patterns))
 	} else {
-		slog.Info("Pod label filtering enabled",
+		slog.Info("Pod metadata filtering enabled",
 			"patterns", len(cache.compiledPatterns),
 			"originalPatterns", len(patterns),
 			"cacheSize", maxSize)
@@ -244,6 +335,8 @@ func (p *PodMapper) createPerProcessMetrics(
 		if podInfo.VGPU != "" {
 			metric.Attributes[vgpuAttribute] = podInfo.VGPU
 		}
+		setVGPUQuotaAttributes(metric.Attributes, podInfo)
+		setWorkloadAttributes(metric.Attributes, podInfo)
 
 		result = append(result, metric)
 	}
@@ -286,6 +379,14 @@ func (p *PodMapper) Run() {
 		}
 		slog.Info("Pod informer cache synced")
 	}
+	if p.replicaSetFactory != nil {
+		go p.replicaSetFactory.Start(p.stopChan)
+		if !cache.WaitForCacheSync(p.stopChan, p.replicaSetSynced) {
+			slog.Error("Failed to sync ReplicaSet informer cache")
+			return
+		}
+		slog.Info("ReplicaSet informer cache synced")
+	}
 }
 
 func (p *PodMapper) Stop() {
@@ -293,21 +394,16 @@ func (p *PodMapper) Stop() {
 }
 
 func (p *PodMapper) getMappings(deviceInfo deviceinfo.Provider) (map[string][]PodInfo, map[string]PodInfo, map[string][]PodInfo, error) {
-	socketPath := p.Config.PodResourcesKubeletSocket
-	_, err := stdos.Stat(socketPath)
-	if stdos.IsNotExist(err) {
-		return nil, nil, nil, nil
-	}
-
-	c, cleanup, err := connectToServer(socketPath)
-	if err != nil {
+	pods, err := p.listPodResources()
+	if pods == nil || err != nil {
 		return nil, nil, nil, err
 	}
-	defer cleanup()
 
-	pods, err := p.listPods(c)
-	if err != nil {
-		return nil, nil, nil, err
+	if len(p.Config.PodNamespaceAllowlist) > 0 || len(p.Config.PodNamespaceDenylist) > 0 {
+		pods = p.filterNamespaces(pods)
+	}
+	if p.Config.KubernetesSkipInactivePods {
+		pods = p.filterInactivePods(pods)
 	}
 
 	var deviceToPods map[string][]PodInfo
@@ -327,6 +423,117 @@ func (p *PodMapper) getMappings(deviceInfo deviceinfo.Provider) (map[string][]Po
 	return deviceToPods, deviceToPod, deviceToPodsDRA, nil
 }
 
+// filterNamespaces drops the pods of the namespaces that are not allowlisted or that are denylisted, before
+// their devices are mapped and their metadata looked up.
+func (p *PodMapper) filterNamespaces(
+	pods *podresourcesapi.ListPodResourcesResponse,
+) *podresourcesapi.ListPodResourcesResponse {
+	selected := make([]*podresourcesapi.PodResources, 0, len(pods.GetPodResources()))
+	for _, pod := range pods.GetPodResources() {
+		if !p.namespaceSelected(pod.GetNamespace()) {
+			p.countSkippedPod(PodSkipReasonNamespace)
+			continue
+		}
+		selected = append(selected, pod)
+	}
+
+	return &podresourcesapi.ListPodResourcesResponse{PodResources: selected}
+}
+
+// namespaceSelected reports whether the pods of the namespace are mapped, the denylist takes precedence over
+// the allowlist and an empty allowlist selects all namespaces.
+func (p *PodMapper) namespaceSelected(namespace string) bool {
+	if matchesNamespace(p.Config.PodNamespaceDenylist, namespace) {
+		return false
+	}
+	return len(p.Config.PodNamespaceAllowlist) == 0 || matchesNamespace(p.Config.PodNamespaceAllowlist, namespace)
+}
+
+// matchesNamespace reports whether the namespace matches one of the names or glob patterns
+func matchesNamespace(patterns []string, namespace string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, namespace); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// filterInactivePods drops the pods that are terminating, terminated or unschedulable according to the
+// pod informer cache. The kubelet keeps reporting the devices of such pods until their containers are removed.
+func (p *PodMapper) filterInactivePods(
+	pods *podresourcesapi.ListPodResourcesResponse,
+) *podresourcesapi.ListPodResourcesResponse {
+	if p.podLister == nil {
+		return pods
+	}
+
+	active := make([]*podresourcesapi.PodResources, 0, len(pods.GetPodResources()))
+	for _, pod := range pods.GetPodResources() {
+		podObj, err := p.podLister.Pods(pod.GetNamespace()).Get(pod.GetName())
+		if err != nil {
+			// Keep pods that are not in the informer cache yet
+			active = append(active, pod)
+			continue
+		}
+
+		if reason := inactivePodReason(podObj); reason != "" {
+			slog.Debug("Skipping inactive pod",
+				"pod", pod.GetName(),
+				"namespace", pod.GetNamespace(),
+				"reason", reason)
+			p.countSkippedPod(reason)
+			continue
+		}
+		active = append(active, pod)
+	}
+
+	return &podresourcesapi.ListPodResourcesResponse{PodResources: active}
+}
+
+// inactivePodReason returns why no GPUs should be attributed to the pod, or an empty string if the pod is active.
+func inactivePodReason(pod *corev1.Pod) string {
+	switch {
+	case pod.DeletionTimestamp != nil:
+		return PodSkipReasonTerminating
+	case pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed:
+		return PodSkipReasonTerminated
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled &&
+			condition.Status == corev1.ConditionFalse &&
+			condition.Reason == corev1.PodReasonUnschedulable {
+			return PodSkipReasonUnschedulable
+		}
+	}
+	return ""
+}
+
+func (p *PodMapper) countSkippedPod(reason string) {
+	p.skippedPodsMu.Lock()
+	defer p.skippedPodsMu.Unlock()
+
+	if p.skippedPods == nil {
+		p.skippedPods = map[string]uint64{}
+	}
+	p.skippedPods[reason]++
+}
+
+// SkippedPods returns how many pods were skipped in the mappings by skip reason.
+func (p *PodMapper) SkippedPods() map[string]uint64 {
+	p.skippedPodsMu.Lock()
+	defer p.skippedPodsMu.Unlock()
+
+	return maps.Clone(p.skippedPods)
+}
+
+// MergedDuplicates returns how many series the virtual GPU and DRA mappers both mapped to the same container, and
+// were resolved into a single series.
+func (p *PodMapper) MergedDuplicates() uint64 {
+	return p.mergedDuplicates.Load()
+}
+
 func (p *PodMapper) Process(metrics collector.MetricsByCounter, deviceInfo deviceinfo.Provider) error {
 	deviceToPods, deviceToPod, deviceToPodsDRA, err := p.getMappings(deviceInfo)
 	if err != nil {
@@ -340,22 +547,32 @@ func (p *PodMapper) Process(metrics collector.MetricsByCounter, deviceInfo devic
 		}
 		slog.Debug(fmt.Sprintf("Device to sharing pods mapping: %+v", deviceToPods))
 
-		gpuUUIDToDeviceID := getGPUUUIDToDeviceID(deviceInfo, p.Config.KubernetesGPUIdType)
-		processCollector := &perProcessCollector{
-			client:    nvmlprovider.Client(),
-			pidMapper: newPIDToPodMapper(),
+		// The per-process metrics are shed under memory pressure, the metrics are then mapped to all the pods
+		// sharing the device
+		processMetricsShed := memguard.IsShed(memguard.FeatureProcessMetrics)
+		var perProcessData *perProcessDataMap
+		if !processMetricsShed {
+			gpuUUIDToDeviceID := getGPUUUIDToDeviceID(deviceInfo, p.Config.KubernetesGPUIdType)
+			processCollector := &perProcessCollector{
+				client:    nvmlprovider.Client(),
+				pidMapper: newPIDToPodMapper(),
+				giFormat:  p.Config.GPUInstanceIDFormat,
+			}
+			perProcessData = processCollector.Collect(gpuUUIDToDeviceID, deviceToPods, deviceInfo)
 		}
-		perProcessData := processCollector.Collect(gpuUUIDToDeviceID, deviceToPods, deviceInfo)
 		for counter := range metrics {
 			var newmetrics []collector.Metric
 			for j, val := range metrics[counter] {
-				deviceID, err := val.GetIDOfType(p.Config.KubernetesGPUIdType)
+				deviceID, err := p.metricDeviceID(val, func(id string) bool {
+					_, ok := deviceToPods[id]
+					return ok
+				})
 				if err != nil {
 					return err
 				}
 
 				podInfos := deviceToPods[deviceID]
-				if isPerProcessMetric(counter.FieldName) {
+				if isPerProcessMetric(counter.FieldName) && !processMetricsShed {
 					perProcessMetrics, err := p.createPerProcessMetrics(val, counter, metrics[counter][j], perProcessData)
 					if err != nil {
 						return err
@@ -366,37 +583,45 @@ func (p *PodMapper) Process(metrics collector.MetricsByCounter, deviceInfo devic
 						continue
 					}
 				}
+				var podMetrics []collector.Metric
 				for _, pi := range podInfos {
-					metric, err := utils.DeepCopy(metrics[counter][j])
+					metric, err := p.sharingPodMetric(metrics[counter][j], pi)
 					if err != nil {
 						return err
 					}
-					if !p.Config.UseOldNamespace {
-						metric.Attributes[podAttribute] = pi.Name
-						metric.Attributes[namespaceAttribute] = pi.Namespace
-						metric.Attributes[containerAttribute] = pi.Container
-					} else {
-						metric.Attributes[oldPodAttribute] = pi.Name
-						metric.Attributes[oldNamespaceAttribute] = pi.Namespace
-						metric.Attributes[oldContainerAttribute] = pi.Container
-					}
-					if p.Config.KubernetesEnablePodUID {
-						metric.Attributes[uidAttribute] = pi.UID
-					}
-					if pi.VGPU != "" {
-						metric.Attributes[vgpuAttribute] = pi.VGPU
+					podMetrics = append(podMetrics, metric)
+				}
+
+				// The DRA claims of the device are mapped too, the containers both mappers map the device to get
+				// a single series
+				if p.Config.KubernetesEnableDRA && deviceToPodsDRA != nil {
+					draDeviceID, err := p.metricDeviceID(val, func(id string) bool {
+						_, ok := deviceToPodsDRA[id]
+						return ok
+					})
+					if err != nil {
+						return err
 					}
 
-					// Robustness: ensure no overlap between Labels and Attributes
-					for k := range metric.Attributes {
-						delete(metric.Labels, k)
+					var draMetrics []collector.Metric
+					for _, pi := range deviceToPodsDRA[draDeviceID] {
+						metric, err := p.draPodMetric(metrics[counter][j], pi)
+						if err != nil {
+							return err
+						}
+						draMetrics = append(draMetrics, metric)
 					}
 
-					newmetrics = append(newmetrics, metric)
+					var duplicates int
+					podMetrics, duplicates = resolveDuplicates(podMetrics, draMetrics, p.Config.KubernetesDuplicatePolicy,
+						p.Config.UseOldNamespace)
+					p.mergedDuplicates.Add(uint64(duplicates))
 				}
+
+				newmetrics = append(newmetrics, podMetrics...)
 				// Preserve the original device-level metric for GPUs not currently
 				// used by any pod, so they still appear in /metrics with value 0.
-				if len(podInfos) == 0 {
+				if len(podMetrics) == 0 {
 					newmetrics = append(newmetrics, metrics[counter][j])
 				}
 			}
@@ -414,7 +639,10 @@ func (p *PodMapper) Process(metrics collector.MetricsByCounter, deviceInfo devic
 
 		for counter := range metrics {
 			for j, val := range metrics[counter] {
-				deviceID, err := val.GetIDOfType(p.Config.KubernetesGPUIdType)
+				deviceID, err := p.metricDeviceID(val, func(id string) bool {
+					_, ok := deviceToPod[id]
+					return ok
+				})
 				if err != nil {
 					return err
 				}
@@ -433,6 +661,7 @@ func (p *PodMapper) Process(metrics collector.MetricsByCounter, deviceInfo devic
 					if p.Config.KubernetesEnablePodUID {
 						metrics[counter][j].Attributes[uidAttribute] = podInfo.UID
 					}
+					setWorkloadAttributes(metrics[counter][j].Attributes, podInfo)
 					for k, v := range podInfo.Labels {
 						if _, ok := metrics[counter][j].Attributes[k]; ok {
 							continue
@@ -456,7 +685,10 @@ func (p *PodMapper) Process(metrics collector.MetricsByCounter, deviceInfo devic
 			for counter := range metrics {
 				var newmetrics []collector.Metric
 				for j, val := range metrics[counter] {
-					deviceID, err := val.GetIDOfType(p.Config.KubernetesGPUIdType)
+					deviceID, err := p.metricDeviceID(val, func(id string) bool {
+						_, ok := deviceToPodsDRA[id]
+						return ok
+					})
 					if err != nil {
 						return err
 					}
@@ -464,37 +696,10 @@ func (p *PodMapper) Process(metrics collector.MetricsByCounter, deviceInfo devic
 					podInfos := deviceToPodsDRA[deviceID]
 					if podInfos != nil {
 						for _, pi := range podInfos {
-							metric, err := utils.DeepCopy(metrics[counter][j])
+							metric, err := p.draPodMetric(metrics[counter][j], pi)
 							if err != nil {
 								return err
 							}
-							if !p.Config.UseOldNamespace {
-								metric.Attributes[podAttribute] = pi.Name
-								metric.Attributes[namespaceAttribute] = pi.Namespace
-								metric.Attributes[containerAttribute] = pi.Container
-							} else {
-								metric.Attributes[oldPodAttribute] = pi.Name
-								metric.Attributes[oldNamespaceAttribute] = pi.Namespace
-								metric.Attributes[oldContainerAttribute] = pi.Container
-							}
-							if dr := pi.DynamicResources; dr != nil {
-								metric.Attributes[draClaimName] = dr.ClaimName
-								metric.Attributes[draClaimNamespace] = dr.ClaimNamespace
-								metric.Attributes[draDriverName] = dr.DriverName
-								metric.Attributes[draPoolName] = dr.PoolName
-								metric.Attributes[draDeviceName] = dr.DeviceName
-
-								if migInfo := dr.MIGInfo; migInfo != nil {
-									metric.Attributes[draMigProfile] = migInfo.Profile
-									metric.Attributes[draMigDeviceUUID] = migInfo.MIGDeviceUUID
-								}
-							}
-
-							// Robustness: ensure no overlap between Labels and Attributes
-							for k := range metric.Attributes {
-								delete(metric.Labels, k)
-							}
-
 							newmetrics = append(newmetrics, metric)
 						}
 					} else {
@@ -511,6 +716,73 @@ func (p *PodMapper) Process(metrics collector.MetricsByCounter, deviceInfo devic
 	return nil
 }
 
+// sharingPodMetric clones the metric for a pod of the virtual GPU mappings
+func (p *PodMapper) sharingPodMetric(val collector.Metric, pi PodInfo) (collector.Metric, error) {
+	metric, err := utils.DeepCopy(val)
+	if err != nil {
+		return collector.Metric{}, err
+	}
+	if !p.Config.UseOldNamespace {
+		metric.Attributes[podAttribute] = pi.Name
+		metric.Attributes[namespaceAttribute] = pi.Namespace
+		metric.Attributes[containerAttribute] = pi.Container
+	} else {
+		metric.Attributes[oldPodAttribute] = pi.Name
+		metric.Attributes[oldNamespaceAttribute] = pi.Namespace
+		metric.Attributes[oldContainerAttribute] = pi.Container
+	}
+	if p.Config.KubernetesEnablePodUID {
+		metric.Attributes[uidAttribute] = pi.UID
+	}
+	if pi.VGPU != "" {
+		metric.Attributes[vgpuAttribute] = pi.VGPU
+	}
+	setVGPUQuotaAttributes(metric.Attributes, pi)
+	setWorkloadAttributes(metric.Attributes, pi)
+
+	// Robustness: ensure no overlap between Labels and Attributes
+	for k := range metric.Attributes {
+		delete(metric.Labels, k)
+	}
+	return metric, nil
+}
+
+// draPodMetric clones the metric for a pod of the DRA mappings
+func (p *PodMapper) draPodMetric(val collector.Metric, pi PodInfo) (collector.Metric, error) {
+	metric, err := utils.DeepCopy(val)
+	if err != nil {
+		return collector.Metric{}, err
+	}
+	if !p.Config.UseOldNamespace {
+		metric.Attributes[podAttribute] = pi.Name
+		metric.Attributes[namespaceAttribute] = pi.Namespace
+		metric.Attributes[containerAttribute] = pi.Container
+	} else {
+		metric.Attributes[oldPodAttribute] = pi.Name
+		metric.Attributes[oldNamespaceAttribute] = pi.Namespace
+		metric.Attributes[oldContainerAttribute] = pi.Container
+	}
+	setWorkloadAttributes(metric.Attributes, pi)
+	if dr := pi.DynamicResources; dr != nil {
+		metric.Attributes[draClaimName] = dr.ClaimName
+		metric.Attributes[draClaimNamespace] = dr.ClaimNamespace
+		metric.Attributes[draDriverName] = dr.DriverName
+		metric.Attributes[draPoolName] = dr.PoolName
+		metric.Attributes[draDeviceName] = dr.DeviceName
+
+		if migInfo := dr.MIGInfo; migInfo != nil {
+			metric.Attributes[draMigProfile] = migInfo.Profile
+			metric.Attributes[draMigDeviceUUID] = migInfo.MIGDeviceUUID
+		}
+	}
+
+	// Robustness: ensure no overlap between Labels and Attributes
+	for k := range metric.Attributes {
+		delete(metric.Labels, k)
+	}
+	return metric, nil
+}
+
 func connectToServer(socket string) (*grpc.ClientConn, func(), error) {
 	resolver.SetDefaultScheme("passthrough")
 	conn, err := grpc.NewClient(
@@ -528,6 +800,47 @@ func connectToServer(socket string) (*grpc.ClientConn, func(), error) {
 	return conn, func() { conn.Close() }, nil
 }
 
+// listPodResources lists the pod resources from the kubelet pod-resources socket, or from the kubelet device
+// plugin checkpoint when the socket is missing or fails and the checkpoint fallback is enabled. It returns nil
+// without error when the socket is missing and there is no fallback.
+func (p *PodMapper) listPodResources() (*podresourcesapi.ListPodResourcesResponse, error) {
+	pods, err := p.listSocketPods()
+	if err == nil && pods != nil {
+		p.kubeletSocketUp.Store(true)
+		p.lastPodResourcesList.Store(time.Now().UnixNano())
+		return pods, nil
+	}
+	p.kubeletSocketUp.Store(false)
+
+	if p.Config.KubeletCheckpointFile == "" {
+		return nil, err
+	}
+	checkpointPods, checkpointErr := p.listCheckpointPods()
+	if checkpointErr != nil {
+		return nil, errors.Join(err, checkpointErr)
+	}
+	slog.Debug("Mapped the devices from the kubelet checkpoint",
+		slog.String("path", p.Config.KubeletCheckpointFile), slog.Any("socketError", err))
+	return checkpointPods, nil
+}
+
+// listSocketPods lists the pod resources from the kubelet pod-resources socket, nil when the socket is missing
+func (p *PodMapper) listSocketPods() (*podresourcesapi.ListPodResourcesResponse, error) {
+	socketPath := p.Config.PodResourcesKubeletSocket
+	_, err := stdos.Stat(socketPath)
+	if stdos.IsNotExist(err) {
+		return nil, nil
+	}
+
+	c, cleanup, err := connectToServer(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	return p.listPods(c)
+}
+
 func (p *PodMapper) listPods(conn *grpc.ClientConn) (*podresourcesapi.ListPodResourcesResponse, error) {
 	client := podresourcesapi.NewPodResourcesListerClient(conn)
 
@@ -542,35 +855,15 @@ func (p *PodMapper) listPods(conn *grpc.ClientConn) (*podresourcesapi.ListPodRes
 	return resp, nil
 }
 
-// getSharedGPU parses the provided device ID and extracts the shared
-// GPU identifier along with a boolean indicating if an identifier was
-// found.
-func getSharedGPU(deviceID string) (string, bool) {
-	// Check if we're using the GKE device plugin or NVIDIA device plugin.
-	if strings.Contains(deviceID, gkeVirtualGPUDeviceIDSeparator) {
-		return strings.Split(deviceID, gkeVirtualGPUDeviceIDSeparator)[1], true
-	} else if strings.Contains(deviceID, "::") {
-		return strings.Split(deviceID, "::")[1], true
-	}
-	return "", false
-}
-
-// stripVGPUSuffix removes the ::N suffix from device IDs.
-// AWS EKS with NVIDIA device plugin reports MIG devices as:
-//
-//	MIG-2ce7a541-c516-5dbc-a76e-26cc100d9b55::7
-//
-// This function strips the ::N suffix to get the MIG UUID for NVML lookups.
-func stripVGPUSuffix(deviceID string) string {
-	if base, _, found := strings.Cut(deviceID, "::"); found {
-		return base
-	}
-	return deviceID
-}
-
 func (p *PodMapper) toDeviceToPodsDRA(devicePods *podresourcesapi.ListPodResourcesResponse) map[string][]PodInfo {
 	deviceToPodsMap := make(map[string][]PodInfo)
 
+	if p.ResourceSliceManager != nil {
+		defer func(start time.Time) {
+			p.ResourceSliceManager.observeMapping(time.Since(start))
+		}(time.Now())
+	}
+
 	slog.Debug("Processing pod dynamic resources", "totalPods", len(devicePods.GetPodResources()))
 	// Track pod+namespace+container combinations per device
 	// UUID -> "podName/namespace/containerName" -> bool
@@ -595,7 +888,7 @@ func (p *PodMapper) toDeviceToPodsDRA(devicePods *podresourcesapi.ListPodResourc
 						draPoolName := claimResource.GetPoolName()
 						draDeviceName := claimResource.GetDeviceName()
 
-						mappingKey, migInfo := p.ResourceSliceManager.GetDeviceInfo(draPoolName, draDeviceName)
+						mappingKey, migInfo := p.lookupClaimDevice(claimResource)
 						if mappingKey == "" {
 							slog.Debug(fmt.Sprintf("No UUID for %s/%s", draPoolName, draDeviceName))
 							continue
@@ -650,6 +943,24 @@ func (p *PodMapper) toDeviceToPodsDRA(devicePods *podresourcesapi.ListPodResourc
 	return deviceToPodsMap
 }
 
+// lookupClaimDevice returns the mapping UUID and the MIG info of the device allocated to a claim, from its pool
+// and device names, or from its CDI devices for the DRA drivers that only report these.
+func (p *PodMapper) lookupClaimDevice(claimResource *podresourcesapi.ClaimResource) (string, *DRAMigDeviceInfo) {
+	pool := claimResource.GetPoolName()
+	if device := claimResource.GetDeviceName(); device != "" {
+		if uuid, migInfo := p.ResourceSliceManager.LookupDeviceInfo(pool, device); uuid != "" {
+			return uuid, migInfo
+		}
+	}
+
+	for _, cdiDevice := range claimResource.GetCDIDevices() {
+		if uuid, migInfo := p.ResourceSliceManager.LookupCDIDeviceInfo(pool, cdiDevice.GetName()); uuid != "" {
+			return uuid, migInfo
+		}
+	}
+	return "", nil
+}
+
 // toDeviceToSharingPods uses the same general logic as toDeviceToPod but
 // allows for multiple containers to be associated with a metric when sharing
 // strategies are used in Kubernetes.
@@ -659,45 +970,56 @@ func (p *PodMapper) toDeviceToPodsDRA(devicePods *podresourcesapi.ListPodResourc
 // GPU states.
 func (p *PodMapper) toDeviceToSharingPods(devicePods *podresourcesapi.ListPodResourcesResponse, deviceInfo deviceinfo.Provider) map[string][]PodInfo {
 	deviceToPodsMap := make(map[string][]PodInfo)
+	// Containers whose vGPU allocations are already mapped, as they are listed once per device resource
+	allocatedContainers := make(map[string]bool)
 
 	p.iterateGPUDevices(devicePods, func(pod *podresourcesapi.PodResources, container *podresourcesapi.ContainerResources, device *podresourcesapi.ContainerDevices) {
 		podInfo := p.createPodInfo(pod, container)
 
+		if allocations := p.sharedGPUAllocations(pod, container); len(allocations) > 0 {
+			key := pod.GetNamespace() + "/" + pod.GetName() + "/" + container.GetName()
+			if allocatedContainers[key] {
+				return
+			}
+			allocatedContainers[key] = true
+			for _, allocation := range allocations {
+				allocated := podInfo
+				allocated.VGPUMemory = allocation.Memory
+				allocated.VGPUCores = allocation.Cores
+				deviceToPodsMap[allocation.UUID] = append(deviceToPodsMap[allocation.UUID], allocated)
+			}
+			return
+		}
+
 		for _, deviceID := range device.GetDeviceIds() {
-			if vgpu, ok := getSharedGPU(deviceID); ok {
-				podInfo.VGPU = vgpu
+			id := p.deviceIdentity().Parse(deviceID)
+			if id.Replica != "" {
+				podInfo.VGPU = id.Replica
 			}
-			if strings.HasPrefix(deviceID, appconfig.MIG_UUID_PREFIX) {
-				migUUID := stripVGPUSuffix(deviceID)
-				migDevice, err := nvmlprovider.Client().GetMIGDeviceInfoByID(migUUID)
+			switch id.Kind {
+			case identity.KindMIG:
+				migDevice, err := p.deviceIdentity().ResolveMIG(id.Device)
 				if err == nil {
 					// Check for potential integer overflow before conversion
 					if migDevice.GPUInstanceID >= 0 {
-						giIdentifier := deviceinfo.GetGPUInstanceIdentifier(deviceInfo, migDevice.ParentUUID,
-							uint(migDevice.GPUInstanceID))
+						giIdentifier := deviceinfo.GetGPUInstanceIdentifier(deviceInfo, p.Config.GPUInstanceIDFormat,
+							migDevice.ParentUUID, uint(migDevice.GPUInstanceID))
 						deviceToPodsMap[giIdentifier] = append(deviceToPodsMap[giIdentifier], podInfo)
 					}
-				}
-				gpuUUID := migUUID[len(appconfig.MIG_UUID_PREFIX):]
-				deviceToPodsMap[gpuUUID] = append(deviceToPodsMap[gpuUUID], podInfo)
-			} else if gkeMigDeviceIDMatches := gkeMigDeviceIDRegex.FindStringSubmatch(deviceID); gkeMigDeviceIDMatches != nil {
-				var gpuIndex string
-				var gpuInstanceID string
-				for groupIdx, group := range gkeMigDeviceIDMatches {
-					switch groupIdx {
-					case 1:
-						gpuIndex = group
-					case 2:
-						gpuInstanceID = group
+					if ciIdentifier := p.computeInstanceIdentifier(deviceInfo, migDevice); ciIdentifier != "" {
+						deviceToPodsMap[ciIdentifier] = append(deviceToPodsMap[ciIdentifier], podInfo)
 					}
 				}
-				giIdentifier := fmt.Sprintf("%s-%s", gpuIndex, gpuInstanceID)
+				gpuUUID := strings.TrimPrefix(id.Device, identity.MIGPrefix)
+				deviceToPodsMap[gpuUUID] = append(deviceToPodsMap[gpuUUID], podInfo)
+			case identity.KindGKEMIG:
+				giIdentifier := p.gkeGPUInstanceIdentifier(deviceInfo, id.GPUIndex, id.GPUInstanceID)
 				deviceToPodsMap[giIdentifier] = append(deviceToPodsMap[giIdentifier], podInfo)
-			} else if strings.Contains(deviceID, gkeVirtualGPUDeviceIDSeparator) {
-				deviceToPodsMap[strings.Split(deviceID, gkeVirtualGPUDeviceIDSeparator)[0]] = append(deviceToPodsMap[strings.Split(deviceID, gkeVirtualGPUDeviceIDSeparator)[0]], podInfo)
-			} else if strings.Contains(deviceID, "::") {
-				gpuInstanceID := strings.Split(deviceID, "::")[0]
-				deviceToPodsMap[gpuInstanceID] = append(deviceToPodsMap[gpuInstanceID], podInfo)
+			default:
+				// The shared GPUs are mapped without their replica
+				if id.Device != deviceID {
+					deviceToPodsMap[id.Device] = append(deviceToPodsMap[id.Device], podInfo)
+				}
 			}
 			// Default mapping between deviceID and pod information
 			deviceToPodsMap[deviceID] = append(deviceToPodsMap[deviceID], podInfo)
@@ -707,6 +1029,66 @@ func (p *PodMapper) toDeviceToSharingPods(devicePods *podresourcesapi.ListPodRes
 	return deviceToPodsMap
 }
 
+// deviceIdentity returns the resolver of the device IDs of the pod resources
+func (p *PodMapper) deviceIdentity() identity.Resolver {
+	if p.identityResolver == nil {
+		return uncachedIdentityResolver
+	}
+	return p.identityResolver
+}
+
+// nvmlMIGLookup reads the GPU and compute instance of a MIG device from NVML
+func nvmlMIGLookup(migUUID string) (identity.MIGDevice, error) {
+	migDevice, err := nvmlprovider.Client().GetMIGDeviceInfoByID(migUUID)
+	if err != nil {
+		return identity.MIGDevice{}, err
+	}
+	return *migDevice, nil
+}
+
+// computeInstanceIdentifier returns the identifier of the compute instance of a MIG device, which metrics of
+// compute instances are mapped to pods with, or "" if the MIG device has no valid compute instance.
+func (p *PodMapper) computeInstanceIdentifier(
+	deviceInfo deviceinfo.Provider, migDevice identity.MIGDevice,
+) string {
+	if migDevice.GPUInstanceID < 0 || migDevice.ComputeInstanceID < 0 {
+		return ""
+	}
+	return deviceinfo.GetComputeInstanceIdentifier(deviceInfo, p.Config.GPUInstanceIDFormat, migDevice.ParentUUID,
+		uint(migDevice.GPUInstanceID), uint(migDevice.ComputeInstanceID))
+}
+
+// metricDeviceID returns the identifier of the device of a metric used to map it to pods. Metrics of a compute
+// instance fall back to their GPU instance, when the compute instance isn't mapped, e.g. because the device
+// plugin or DRA driver allocates whole GPU instances.
+func (p *PodMapper) metricDeviceID(val collector.Metric, isMapped func(deviceID string) bool) (string, error) {
+	deviceID, err := val.GetIDOfType(p.Config.KubernetesGPUIdType, p.Config.GPUInstanceIDFormat)
+	if err != nil || val.ComputeInstanceID == "" || isMapped(deviceID) {
+		return deviceID, err
+	}
+	return deviceinfo.FormatGPUInstanceIdentifier(p.Config.GPUInstanceIDFormat, val.GPU, val.GPUUUID,
+		val.GPUInstanceID), nil
+}
+
+// gkeGPUInstanceIdentifier converts the GPU index and GPU instance ID of a GKE MIG device ID
+// into the configured GPU instance identifier format.
+func (p *PodMapper) gkeGPUInstanceIdentifier(deviceInfo deviceinfo.Provider, gpuIndex, gpuInstanceID string) string {
+	if p.Config.GPUInstanceIDFormat != appconfig.GPUInstanceIDFormatUUID {
+		return deviceinfo.FormatGPUInstanceIdentifier(p.Config.GPUInstanceIDFormat, gpuIndex, "", gpuInstanceID)
+	}
+
+	index, err := strconv.ParseUint(gpuIndex, 10, 32)
+	if err != nil {
+		return ""
+	}
+	gi, err := strconv.ParseUint(gpuInstanceID, 10, 32)
+	if err != nil {
+		return ""
+	}
+
+	return deviceinfo.GetGPUInstanceIdentifierByIndex(deviceInfo, p.Config.GPUInstanceIDFormat, uint(index), uint(gi))
+}
+
 func (p *PodMapper) toDeviceToPod(
 	devicePods *podresourcesapi.ListPodResourcesResponse, deviceInfo deviceinfo.Provider,
 ) map[string]PodInfo {
@@ -797,7 +1179,9 @@ func (p *PodMapper) toDeviceToPod(
 						"deviceIds", device.GetDeviceIds(),
 					)
 
-					if strings.HasPrefix(deviceID, appconfig.MIG_UUID_PREFIX) {
+					id := p.deviceIdentity().Parse(deviceID)
+					switch id.Kind {
+					case identity.KindMIG:
 						slog.Debug("Processing MIG device", "deviceID", deviceID,
 							"podName", pod.GetName(),
 							"namespace", pod.GetNamespace(),
@@ -805,13 +1189,12 @@ func (p *PodMapper) toDeviceToPod(
 							"resourceName", resourceName,
 							"deviceIds", device.GetDeviceIds(),
 						)
-						migUUID := stripVGPUSuffix(deviceID)
-						migDevice, err := nvmlprovider.Client().GetMIGDeviceInfoByID(migUUID)
+						migDevice, err := p.deviceIdentity().ResolveMIG(id.Device)
 						if err == nil {
 							// Check for potential integer overflow before conversion
 							if migDevice.GPUInstanceID >= 0 {
-								giIdentifier := deviceinfo.GetGPUInstanceIdentifier(deviceInfo, migDevice.ParentUUID,
-									uint(migDevice.GPUInstanceID))
+								giIdentifier := deviceinfo.GetGPUInstanceIdentifier(deviceInfo, p.Config.GPUInstanceIDFormat,
+									migDevice.ParentUUID, uint(migDevice.GPUInstanceID))
 								slog.Debug("Mapped MIG device to GPU instance",
 									"deviceID", deviceID,
 									"giIdentifier", giIdentifier,
@@ -823,6 +1206,9 @@ func (p *PodMapper) toDeviceToPod(
 								)
 								deviceToPodMap[giIdentifier] = podInfo
 							}
+							if ciIdentifier := p.computeInstanceIdentifier(deviceInfo, migDevice); ciIdentifier != "" {
+								deviceToPodMap[ciIdentifier] = podInfo
+							}
 						} else {
 							slog.Debug("Failed to get MIG device info",
 								"deviceID", deviceID,
@@ -834,7 +1220,7 @@ func (p *PodMapper) toDeviceToPod(
 								"deviceIds", device.GetDeviceIds(),
 							)
 						}
-						gpuUUID := migUUID[len(appconfig.MIG_UUID_PREFIX):]
+						gpuUUID := strings.TrimPrefix(id.Device, identity.MIGPrefix)
 						slog.Debug("Mapped MIG device to GPU UUID",
 							"deviceID", deviceID,
 							"gpuUUID", gpuUUID,
@@ -845,27 +1231,8 @@ func (p *PodMapper) toDeviceToPod(
 							"deviceIds", device.GetDeviceIds(),
 						)
 						deviceToPodMap[gpuUUID] = podInfo
-					} else if gkeMigDeviceIDMatches := gkeMigDeviceIDRegex.FindStringSubmatch(deviceID); gkeMigDeviceIDMatches != nil {
-						slog.Debug("Processing GKE MIG device",
-							"deviceID", deviceID,
-							"matches", gkeMigDeviceIDMatches,
-							"podName", pod.GetName(),
-							"namespace", pod.GetNamespace(),
-							"containerName", container.GetName(),
-							"resourceName", resourceName,
-							"deviceIds", device.GetDeviceIds(),
-						)
-						var gpuIndex string
-						var gpuInstanceID string
-						for groupIdx, group := range gkeMigDeviceIDMatches {
-							switch groupIdx {
-							case 1:
-								gpuIndex = group
-							case 2:
-								gpuInstanceID = group
-							}
-						}
-						giIdentifier := fmt.Sprintf("%s-%s", gpuIndex, gpuInstanceID)
+					case identity.KindGKEMIG:
+						giIdentifier := p.gkeGPUInstanceIdentifier(deviceInfo, id.GPUIndex, id.GPUInstanceID)
 						slog.Debug("Mapped GKE MIG device",
 							"deviceID", deviceID,
 							"giIdentifier", giIdentifier,
@@ -876,30 +1243,20 @@ func (p *PodMapper) toDeviceToPod(
 							"deviceIds", device.GetDeviceIds(),
 						)
 						deviceToPodMap[giIdentifier] = podInfo
-					} else if strings.Contains(deviceID, gkeVirtualGPUDeviceIDSeparator) {
-						gpuID := strings.Split(deviceID, gkeVirtualGPUDeviceIDSeparator)[0]
-						slog.Debug("Mapped GKE virtual GPU device",
-							"deviceID", deviceID,
-							"gpuID", gpuID,
-							"podName", pod.GetName(),
-							"namespace", pod.GetNamespace(),
-							"containerName", container.GetName(),
-							"resourceName", resourceName,
-							"deviceIds", device.GetDeviceIds(),
-						)
-						deviceToPodMap[gpuID] = podInfo
-					} else if strings.Contains(deviceID, "::") {
-						gpuInstanceID := strings.Split(deviceID, "::")[0]
-						slog.Debug("Mapped GPU instance device",
-							"deviceID", deviceID,
-							"gpuInstanceID", gpuInstanceID,
-							"podName", pod.GetName(),
-							"namespace", pod.GetNamespace(),
-							"containerName", container.GetName(),
-							"resourceName", resourceName,
-							"deviceIds", device.GetDeviceIds(),
-						)
-						deviceToPodMap[gpuInstanceID] = podInfo
+					default:
+						// The shared GPUs are mapped without their replica
+						if id.Device != deviceID {
+							slog.Debug("Mapped shared GPU device",
+								"deviceID", deviceID,
+								"device", id.Device,
+								"podName", pod.GetName(),
+								"namespace", pod.GetNamespace(),
+								"containerName", container.GetName(),
+								"resourceName", resourceName,
+								"deviceIds", device.GetDeviceIds(),
+							)
+							deviceToPodMap[id.Device] = podInfo
+						}
 					}
 					// Default mapping between deviceID and pod information
 					slog.Debug("Default device mapping",
@@ -925,6 +1282,7 @@ func (p *PodMapper) toDeviceToPod(
 func (p *PodMapper) createPodInfo(pod *podresourcesapi.PodResources, container *podresourcesapi.ContainerResources) PodInfo {
 	labels := map[string]string{}
 	uid := ""
+	var workloadKind, workloadName string
 
 	// Use PodLister to get metadata
 	if p.podLister != nil {
@@ -937,6 +1295,10 @@ func (p *PodMapper) createPodInfo(pod *podresourcesapi.PodResources, container *
 		} else {
 			uid = string(podObj.UID)
 
+			if p.Config.KubernetesWorkloadLabels {
+				workloadKind, workloadName = p.resolveWorkload(podObj)
+			}
+
 			if p.Config.KubernetesEnablePodLabels {
 				for k, v := range podObj.Labels {
 					if !p.shouldIncludeLabel(k) {
@@ -945,28 +1307,54 @@ func (p *PodMapper) createPodInfo(pod *podresourcesapi.PodResources, container *
 					sanitizedKey := utils.SanitizeLabelName(k)
 					labels[sanitizedKey] = v
 				}
+
+				// Annotations are opt-in: unlike labels, an empty allowlist includes none of them.
+				// Pod labels win over annotations of the same sanitized name.
+				for k, v := range podObj.Annotations {
+					if !p.shouldIncludeAnnotation(k) {
+						continue
+					}
+					sanitizedKey := utils.SanitizeLabelName(k)
+					if _, exists := labels[sanitizedKey]; !exists {
+						labels[sanitizedKey] = v
+					}
+				}
 			}
 		}
 	}
 
 	return PodInfo{
-		Name:      pod.GetName(),
-		Namespace: pod.GetNamespace(),
-		Container: container.GetName(),
-		UID:       uid,
-		Labels:    labels,
+		Name:         pod.GetName(),
+		Namespace:    pod.GetNamespace(),
+		Container:    container.GetName(),
+		UID:          uid,
+		WorkloadKind: workloadKind,
+		WorkloadName: workloadName,
+		Labels:       labels,
 	}
 }
 
 // shouldIncludeLabel checks if a label should be included based on the allowlist regex patterns.
-// Uses an LRU cache to avoid expensive regex matching while bounding memory:
-// 1. Check cache for previously evaluated label keys
-// 2. If not cached, evaluate against pre-compiled regex patterns and cache the result
 func (p *PodMapper) shouldIncludeLabel(labelKey string) bool {
-	cache := p.labelFilterCache
+	return p.labelFilterCache.allows(labelKey, true)
+}
+
+// shouldIncludeAnnotation checks if an annotation should be included based on the annotation allowlist
+// regex patterns. Without patterns, no annotation is included.
+func (p *PodMapper) shouldIncludeAnnotation(annotationKey string) bool {
+	if p.annotationCache == nil {
+		return false
+	}
+	return p.annotationCache.allows(annotationKey, false)
+}
 
+// allows checks if a key matches the allowlist regex patterns, returning whenDisabled without patterns.
+// Uses an LRU cache to avoid expensive regex matching while bounding memory:
+// 1. Check cache for previously evaluated keys
+// 2. If not cached, evaluate against pre-compiled regex patterns and cache the result
+func (cache *LabelFilterCache) allows(labelKey string, whenDisabled bool) bool {
 	if !cache.enabled {
-		return true
+		return whenDisabled
 	}
 
 	cache.mu.Lock()
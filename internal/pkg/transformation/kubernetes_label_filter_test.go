@@ -286,6 +286,34 @@ func TestShouldIncludeLabel_Caching(t *testing.T) {
 	assert.False(t, entry2.value, "Cached exclusion value should be false")
 }
 
+func TestPodMapper_CacheSizes(t *testing.T) {
+	// Without Kubernetes client nor allowlist, no cache is reported
+	podMapper := &PodMapper{labelFilterCache: newLabelFilterCache(nil, 1000)}
+	assert.Empty(t, podMapper.CacheSizes())
+
+	podMapper = &PodMapper{labelFilterCache: newLabelFilterCache([]string{"^app$"}, 1000)}
+	podMapper.shouldIncludeLabel("app")
+	podMapper.shouldIncludeLabel("tier")
+	podMapper.shouldIncludeLabel("app")
+	assert.Equal(t, map[string]int{PodMapperCacheLabelFilter: 2}, podMapper.CacheSizes())
+
+	podMapper.annotationCache = newLabelFilterCache([]string{"^team$"}, 1000)
+	podMapper.shouldIncludeAnnotation("team")
+	assert.Equal(t, map[string]int{PodMapperCacheLabelFilter: 2, PodMapperCacheAnnotations: 1}, podMapper.CacheSizes())
+}
+
+func TestShouldIncludeAnnotation(t *testing.T) {
+	// Without allowlist, no annotation is included, unlike labels
+	podMapper := &PodMapper{annotationCache: newLabelFilterCache(nil, 1000)}
+	assert.False(t, podMapper.shouldIncludeAnnotation("team"))
+	podMapper = &PodMapper{}
+	assert.False(t, podMapper.shouldIncludeAnnotation("team"))
+
+	podMapper = &PodMapper{annotationCache: newLabelFilterCache([]string{"^scheduler\\.example\\.com/"}, 1000)}
+	assert.True(t, podMapper.shouldIncludeAnnotation("scheduler.example.com/job-id"))
+	assert.False(t, podMapper.shouldIncludeAnnotation("kubectl.kubernetes.io/last-applied-configuration"))
+}
+
 // TestGetPodMetadata_WithLabelFiltering tests the integration of label filtering with getPodMetadata
 func TestGetPodMetadata_WithLabelFiltering(t *testing.T) {
 	tests := []struct {
@@ -430,3 +458,65 @@ func TestGetPodMetadata_WithLabelFiltering(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPodMetadata_WithAnnotations(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset()
+
+	config := &appconfig.Config{
+		KubernetesEnablePodLabels:        true,
+		KubernetesPodLabelAllowlistRegex: []string{"^team$"},
+		KubernetesPodAnnotations:         []string{"^scheduler\\.example\\.com/", "^team$"},
+	}
+
+	podMapper := &PodMapper{
+		Config:           config,
+		Client:           fakeClient,
+		labelFilterCache: newLabelFilterCache(config.KubernetesPodLabelAllowlistRegex, 1000),
+		annotationCache:  newLabelFilterCache(config.KubernetesPodAnnotations, 1000),
+	}
+
+	setupMockInformer(t, podMapper, fakeClient)
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			UID:       types.UID("test-uid-123"),
+			Labels:    map[string]string{"team": "from-label", "tier": "frontend"},
+			Annotations: map[string]string{
+				"scheduler.example.com/job-id": "job-42",
+				"scheduler.example.com/note":   "owner: \"ml-team\"\nqueue: batch",
+				"team":                         "from-annotation",
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+		},
+	}
+
+	_, err := fakeClient.CoreV1().Pods("default").Create(context.Background(), pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// Wait for informer sync
+	time.Sleep(100 * time.Millisecond)
+
+	podRes := &podresourcesapi.PodResources{
+		Name:      "test-pod",
+		Namespace: "default",
+		Containers: []*podresourcesapi.ContainerResources{
+			{Name: "test-container"},
+		},
+	}
+
+	podInfo := podMapper.createPodInfo(podRes, podRes.Containers[0])
+
+	// The pod label wins over the annotation of the same name, the values are escaped when rendered
+	assert.Equal(t, map[string]string{
+		"team":                         "from-label",
+		"scheduler_example_com_job_id": "job-42",
+		"scheduler_example_com_note":   "owner: \"ml-team\"\nqueue: batch",
+	}, podInfo.Labels)
+
+	// Annotations follow the pod labels switch
+	config.KubernetesEnablePodLabels = false
+	podInfo = podMapper.createPodInfo(podRes, podRes.Containers[0])
+	assert.Empty(t, podInfo.Labels)
+}
@@ -19,6 +19,7 @@ package transformation
 import (
 	"context"
 	"fmt"
+	stdos "os"
 	"testing"
 	"time"
 
@@ -31,13 +32,16 @@ import (
 	"go.uber.org/mock/gomock"
 	"google.golang.org/grpc"
 	v1 "k8s.io/api/core/v1"
+	resourcev1beta1 "k8s.io/api/resource/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+	"k8s.io/utils/ptr"
 
 	mockdeviceinfo "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/deviceinfo"
 	mocknvmlprovider "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/nvmlprovider"
@@ -442,67 +446,6 @@ func TestProcessPodMapper_WithD_Different_Format_Of_DeviceID(t *testing.T) {
 	}
 }
 
-func TestGetSharedGPU(t *testing.T) {
-	cases := []struct {
-		desc, deviceID string
-		wantVGPU       string
-		wantOK         bool
-	}{
-		{
-			desc:     "gke device plugin, non-mig, shared",
-			deviceID: "nvidia0/vgpu0",
-			wantVGPU: "0",
-			wantOK:   true,
-		},
-		{
-			desc:     "gke device plugin, non-mig, non-shared",
-			deviceID: "nvidia0",
-		},
-		{
-			desc:     "gke device plugin, mig, shared",
-			deviceID: "nvidia0/gi0/vgpu1",
-			wantVGPU: "1",
-			wantOK:   true,
-		},
-		{
-			desc:     "gke device plugin, mig, non-shared",
-			deviceID: "nvidia0/gi0",
-		},
-		{
-			desc:     "nvidia device plugin, non-mig, shared",
-			deviceID: "GPU-5a5a7118-e550-79a1-597e-7631e126c57a::3",
-			wantVGPU: "3",
-			wantOK:   true,
-		},
-		{
-			desc:     "nvidia device plugin, non-mig, non-shared",
-			deviceID: "GPU-5a5a7118-e550-79a1-597e-7631e126c57a",
-		},
-		{
-			desc:     "nvidia device plugin, mig, shared",
-			deviceID: "MIG-42f0f413-f7b0-58cc-aced-c1d1fb54db26::0",
-			wantVGPU: "0",
-			wantOK:   true,
-		},
-		{
-			desc:     "nvidia device plugin, mig, non-shared",
-			deviceID: "MIG-42f0f413-f7b0-58cc-aced-c1d1fb54db26",
-		},
-	}
-
-	for _, tc := range cases {
-		t.Run(tc.desc, func(t *testing.T) {
-			gotVGPU, gotOK := getSharedGPU(tc.deviceID)
-			if gotVGPU != tc.wantVGPU {
-				t.Errorf("expected: %s, got: %s", tc.wantVGPU, gotVGPU)
-			}
-			if gotOK != tc.wantOK {
-				t.Errorf("expected: %t, got: %t", tc.wantOK, gotOK)
-			}
-		})
-	}
-}
-
 func TestProcessPodMapper_WithLabels(t *testing.T) {
 	testutils.RequireLinux(t)
 
@@ -729,6 +672,340 @@ func TestPodDRAInfo(t *testing.T) {
 	}
 }
 
+func TestPodDRAInfo_ResyncsDevicesCreatedAtClaimTime(t *testing.T) {
+	migSlice := &resourcev1beta1.ResourceSlice{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1-gpu.nvidia.com"},
+		Spec: resourcev1beta1.ResourceSliceSpec{
+			Driver: DRAGPUDriverName,
+			Pool:   resourcev1beta1.ResourcePool{Name: "poolA"},
+			Devices: []resourcev1beta1.Device{{
+				Name: "gpu-0-mig-1",
+				Basic: &resourcev1beta1.BasicDevice{
+					Attributes: map[resourcev1beta1.QualifiedName]resourcev1beta1.DeviceAttribute{
+						"type":       {StringValue: ptr.To("mig")},
+						"uuid":       {StringValue: ptr.To("MIG-12345")},
+						"parentUUID": {StringValue: ptr.To("GPU-parent-uuid")},
+						"profile":    {StringValue: ptr.To("1g.12gb")},
+					},
+				},
+			}},
+		},
+	}
+
+	resp := &podresourcesapi.ListPodResourcesResponse{
+		PodResources: []*podresourcesapi.PodResources{{
+			Name:      "pod1",
+			Namespace: "default",
+			Containers: []*podresourcesapi.ContainerResources{{
+				Name: "ctr1",
+				DynamicResources: []*podresourcesapi.DynamicResource{{
+					ClaimName:      "claim1",
+					ClaimNamespace: "default",
+					ClaimResources: []*podresourcesapi.ClaimResource{{
+						DriverName: DRAGPUDriverName,
+						PoolName:   "poolA",
+						DeviceName: "gpu-0-mig-1",
+					}},
+				}},
+			}},
+		}},
+	}
+
+	newPodMapper := func(client kubernetes.Interface) *PodMapper {
+		return &PodMapper{
+			Config: &appconfig.Config{NvidiaResourceNames: []string{appconfig.NvidiaResourceName}},
+			ResourceSliceManager: &DRAResourceSliceManager{
+				client:       client,
+				deviceToUUID: map[string]string{},
+				migDevices:   map[string]*DRAMigDeviceInfo{},
+			},
+		}
+	}
+
+	t.Run("lookup miss resyncs the ResourceSlices", func(t *testing.T) {
+		pm := newPodMapper(fake.NewClientset(migSlice))
+
+		got := pm.toDeviceToPodsDRA(resp)
+		require.Len(t, got["GPU-parent-uuid"], 1)
+		assert.Equal(t, "MIG-12345", got["GPU-parent-uuid"][0].DynamicResources.MIGInfo.MIGDeviceUUID)
+
+		stats, enabled := pm.DRAStats()
+		require.True(t, enabled)
+		assert.Equal(t, uint64(1), stats.SliceUpdates)
+		assert.Zero(t, stats.UnresolvedLookups)
+		assert.Equal(t, uint64(1), stats.MappingCount)
+	})
+
+	t.Run("lookup misses are rate limited", func(t *testing.T) {
+		client := fake.NewClientset()
+		pm := newPodMapper(client)
+
+		assert.Empty(t, pm.toDeviceToPodsDRA(resp))
+
+		// The device is published shortly after the first lookup
+		_, err := client.ResourceV1beta1().ResourceSlices().Create(context.Background(), migSlice, metav1.CreateOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, pm.toDeviceToPodsDRA(resp))
+
+		// A topology change or a claim related pod event requests a resync
+		pm.RequestDRAResync()
+		assert.Len(t, pm.toDeviceToPodsDRA(resp), 1)
+
+		stats, _ := pm.DRAStats()
+		assert.Equal(t, uint64(2), stats.UnresolvedLookups)
+		assert.Equal(t, uint64(3), stats.MappingCount)
+	})
+}
+
+func TestPodDRAInfo_CDIDevices(t *testing.T) {
+	draMgr := &DRAResourceSliceManager{
+		deviceToUUID: map[string]string{
+			"node1/gpu-0": "GPU-8a748984-0fe7-297f-916c-4b998ce202d1",
+			"node2/gpu-0": "GPU-other-node",
+		},
+		migDevices: map[string]*DRAMigDeviceInfo{
+			"node1/gpu-0-mig-1": {MIGDeviceUUID: "MIG-12345", Profile: "1g.12gb", ParentUUID: "GPU-parent-uuid"},
+		},
+		nodeName: "node1",
+	}
+
+	tests := []struct {
+		name       string
+		pool       string
+		cdiDevices []string
+		wantUUID   string
+		wantMIG    string
+	}{
+		{
+			name:       "claim device of the node pool",
+			cdiDevices: []string{"k8s.gpu.nvidia.com/claim=6a5f2f0c-0b4e-4c6e-9f1e-3f7c2d1e0a9b-gpu-0"},
+			wantUUID:   "GPU-8a748984-0fe7-297f-916c-4b998ce202d1",
+		},
+		{
+			name:       "claim device of the reported pool",
+			pool:       "node2",
+			cdiDevices: []string{"k8s.gpu.nvidia.com/claim=6a5f2f0c-0b4e-4c6e-9f1e-3f7c2d1e0a9b-gpu-0"},
+			wantUUID:   "GPU-other-node",
+		},
+		{
+			name:       "longest device name",
+			cdiDevices: []string{"k8s.gpu.nvidia.com/claim=6a5f2f0c-0b4e-4c6e-9f1e-3f7c2d1e0a9b-gpu-0-mig-1"},
+			wantUUID:   "GPU-parent-uuid",
+			wantMIG:    "MIG-12345",
+		},
+		{
+			name:       "gpu uuid",
+			cdiDevices: []string{"nvidia.com/gpu=GPU-5a5a7118-e550-79a1-597e-7631e126c57a"},
+			wantUUID:   "GPU-5a5a7118-e550-79a1-597e-7631e126c57a",
+		},
+		{
+			name:       "mig uuid after an unknown device",
+			cdiDevices: []string{"k8s.gpu.nvidia.com/device=common", "nvidia.com/gpu=MIG-12345"},
+			wantUUID:   "GPU-parent-uuid",
+			wantMIG:    "MIG-12345",
+		},
+		{
+			name:       "unknown devices",
+			cdiDevices: []string{"k8s.gpu.nvidia.com/claim=6a5f2f0c-gpu-7", "nvidia.com/gpu=MIG-unknown", "invalid"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			claimResource := &podresourcesapi.ClaimResource{DriverName: DRAGPUDriverName, PoolName: tc.pool}
+			for _, name := range tc.cdiDevices {
+				claimResource.CDIDevices = append(claimResource.CDIDevices, &podresourcesapi.CDIDevice{Name: name})
+			}
+
+			pm := &PodMapper{
+				Config:               &appconfig.Config{NvidiaResourceNames: []string{appconfig.NvidiaResourceName}},
+				ResourceSliceManager: draMgr,
+			}
+			resp := &podresourcesapi.ListPodResourcesResponse{
+				PodResources: []*podresourcesapi.PodResources{{
+					Name:      "pod1",
+					Namespace: "default",
+					Containers: []*podresourcesapi.ContainerResources{{
+						Name: "ctr1",
+						DynamicResources: []*podresourcesapi.DynamicResource{{
+							ClaimName:      "claim1",
+							ClaimNamespace: "default",
+							ClaimResources: []*podresourcesapi.ClaimResource{claimResource},
+						}},
+					}},
+				}},
+			}
+
+			got := pm.toDeviceToPodsDRA(resp)
+			if tc.wantUUID == "" {
+				assert.Empty(t, got)
+				return
+			}
+
+			require.Len(t, got, 1)
+			require.Len(t, got[tc.wantUUID], 1)
+			migInfo := got[tc.wantUUID][0].DynamicResources.MIGInfo
+			if tc.wantMIG == "" {
+				assert.Nil(t, migInfo)
+			} else {
+				require.NotNil(t, migInfo)
+				assert.Equal(t, tc.wantMIG, migInfo.MIGDeviceUUID)
+			}
+		})
+	}
+}
+
+func TestIsClaimRelatedPodUpdate(t *testing.T) {
+	pending := &v1.Pod{Status: v1.PodStatus{Phase: v1.PodPending}}
+	allocated := &v1.Pod{Status: v1.PodStatus{
+		Phase:                 v1.PodPending,
+		ResourceClaimStatuses: []v1.PodResourceClaimStatus{{Name: "gpu"}},
+	}}
+	running := &v1.Pod{Status: v1.PodStatus{
+		Phase:                 v1.PodRunning,
+		ResourceClaimStatuses: []v1.PodResourceClaimStatus{{Name: "gpu"}},
+	}}
+
+	assert.True(t, isClaimRelatedPodUpdate(pending, allocated))
+	assert.True(t, isClaimRelatedPodUpdate(allocated, running))
+	assert.False(t, isClaimRelatedPodUpdate(running, running))
+}
+
+func TestInactivePodReason(t *testing.T) {
+	now := metav1.Now()
+	tests := []struct {
+		name string
+		pod  *v1.Pod
+		want string
+	}{
+		{
+			name: "running",
+			pod:  &v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning}},
+			want: "",
+		},
+		{
+			name: "terminating",
+			pod: &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &now},
+				Status:     v1.PodStatus{Phase: v1.PodRunning},
+			},
+			want: PodSkipReasonTerminating,
+		},
+		{
+			name: "failed",
+			pod:  &v1.Pod{Status: v1.PodStatus{Phase: v1.PodFailed}},
+			want: PodSkipReasonTerminated,
+		},
+		{
+			name: "succeeded",
+			pod:  &v1.Pod{Status: v1.PodStatus{Phase: v1.PodSucceeded}},
+			want: PodSkipReasonTerminated,
+		},
+		{
+			name: "unschedulable",
+			pod: &v1.Pod{Status: v1.PodStatus{
+				Phase: v1.PodPending,
+				Conditions: []v1.PodCondition{{
+					Type:   v1.PodScheduled,
+					Status: v1.ConditionFalse,
+					Reason: v1.PodReasonUnschedulable,
+				}},
+			}},
+			want: PodSkipReasonUnschedulable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, inactivePodReason(tt.pod))
+		})
+	}
+}
+
+func TestPodMapper_filterInactivePods(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.NoError(t, indexer.Add(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "default"},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}))
+	require.NoError(t, indexer.Add(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "failed", Namespace: "default"},
+		Status:     v1.PodStatus{Phase: v1.PodFailed},
+	}))
+
+	mapper := &PodMapper{
+		Config:    &appconfig.Config{KubernetesSkipInactivePods: true},
+		podLister: corev1listers.NewPodLister(indexer),
+	}
+
+	got := mapper.filterInactivePods(&podresourcesapi.ListPodResourcesResponse{
+		PodResources: []*podresourcesapi.PodResources{
+			{Name: "running", Namespace: "default"},
+			{Name: "failed", Namespace: "default"},
+			// Pods that are not in the informer cache yet are kept
+			{Name: "unknown", Namespace: "default"},
+		},
+	})
+
+	var names []string
+	for _, pod := range got.GetPodResources() {
+		names = append(names, pod.GetName())
+	}
+	assert.Equal(t, []string{"running", "unknown"}, names)
+	assert.Equal(t, map[string]uint64{PodSkipReasonTerminated: 1}, mapper.SkippedPods())
+}
+
+func TestPodMapper_filterNamespaces(t *testing.T) {
+	pods := &podresourcesapi.ListPodResourcesResponse{
+		PodResources: []*podresourcesapi.PodResources{
+			{Name: "trainer", Namespace: "team-a"},
+			{Name: "notebook", Namespace: "team-b-sandbox"},
+			{Name: "inference", Namespace: "prod"},
+			{Name: "dcgm", Namespace: "kube-system"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		allowlist []string
+		denylist  []string
+		expected  []string
+	}{
+		{
+			name:      "Allowlist",
+			allowlist: []string{"team-*", "prod"},
+			expected:  []string{"trainer", "notebook", "inference"},
+		},
+		{
+			name:     "Denylist",
+			denylist: []string{"kube-*"},
+			expected: []string{"trainer", "notebook", "inference"},
+		},
+		{
+			name:      "DenylistTakesPrecedence",
+			allowlist: []string{"team-*"},
+			denylist:  []string{"*-sandbox"},
+			expected:  []string{"trainer"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapper := &PodMapper{
+				Config: &appconfig.Config{PodNamespaceAllowlist: tt.allowlist, PodNamespaceDenylist: tt.denylist},
+			}
+
+			var names []string
+			for _, pod := range mapper.filterNamespaces(pods).GetPodResources() {
+				names = append(names, pod.GetName())
+			}
+			assert.Equal(t, tt.expected, names)
+			assert.Equal(t, map[string]uint64{PodSkipReasonNamespace: uint64(len(pods.PodResources) - len(tt.expected))},
+				mapper.SkippedPods())
+		})
+	}
+}
+
 func TestProcessPodMapper_WithUID(t *testing.T) {
 	testutils.RequireLinux(t)
 
@@ -837,6 +1114,46 @@ func TestProcessPodMapper_WithUID(t *testing.T) {
 	}
 }
 
+func TestPodMapper_KubeletSocketStatus(t *testing.T) {
+	testutils.RequireLinux(t)
+
+	tmpDir, cleanup := testutils.CreateTmpDir(t)
+	defer cleanup()
+	socketPath := tmpDir + "/kubelet.sock"
+
+	mockDeviceInfo := mockdeviceinfo.NewMockProvider(gomock.NewController(t))
+
+	podMapper := &PodMapper{Config: &appconfig.Config{PodResourcesKubeletSocket: socketPath}}
+
+	// No socket yet
+	_, _, _, err := podMapper.getMappings(mockDeviceInfo)
+	require.NoError(t, err)
+	up, lastList := podMapper.KubeletSocketStatus()
+	assert.False(t, up)
+	assert.True(t, lastList.IsZero())
+
+	server := grpc.NewServer()
+	podresourcesapi.RegisterPodResourcesListerServer(server,
+		testutils.NewMockPodResourcesServer(appconfig.NvidiaResourceName, nil))
+	cleanupServer := testutils.StartMockServer(t, server, socketPath)
+
+	before := time.Now()
+	_, _, _, err = podMapper.getMappings(mockDeviceInfo)
+	require.NoError(t, err)
+	up, lastList = podMapper.KubeletSocketStatus()
+	assert.True(t, up)
+	assert.False(t, lastList.Before(before))
+
+	// The socket is unmounted, the time of the last successful List is kept
+	cleanupServer()
+	require.NoError(t, stdos.RemoveAll(socketPath))
+	_, _, _, err = podMapper.getMappings(mockDeviceInfo)
+	require.NoError(t, err)
+	up, lastListAfter := podMapper.KubeletSocketStatus()
+	assert.False(t, up)
+	assert.Equal(t, lastList, lastListAfter)
+}
+
 func TestProcessPodMapper_WithLabelsAndUID(t *testing.T) {
 	testutils.RequireLinux(t)
 
@@ -1392,54 +1709,6 @@ func TestPodMapper_CreatePerProcessMetrics(t *testing.T) {
 	}
 }
 
-func TestStripVGPUSuffix(t *testing.T) {
-	t.Parallel()
-	tests := []struct {
-		name     string
-		deviceID string
-		expected string
-	}{
-		{
-			name:     "MIG device ID with vgpu suffix",
-			deviceID: "MIG-2ce7a541-c516-5dbc-a76e-26cc100d9b55::7",
-			expected: "MIG-2ce7a541-c516-5dbc-a76e-26cc100d9b55",
-		},
-		{
-			name:     "Plain MIG UUID without suffix",
-			deviceID: "MIG-2ce7a541-c516-5dbc-a76e-26cc100d9b55",
-			expected: "MIG-2ce7a541-c516-5dbc-a76e-26cc100d9b55",
-		},
-		{
-			name:     "Regular GPU UUID",
-			deviceID: "GPU-65759866-6a45-99ff-bc37-c534ea0ae191",
-			expected: "GPU-65759866-6a45-99ff-bc37-c534ea0ae191",
-		},
-		{
-			name:     "Non-MIG device ID with vgpu suffix",
-			deviceID: "b8ea3855-276c-c9cb-b366-c6fa655957c5::2",
-			expected: "b8ea3855-276c-c9cb-b366-c6fa655957c5",
-		},
-		{
-			name:     "Empty string",
-			deviceID: "",
-			expected: "",
-		},
-		{
-			name:     "Device ID with empty suffix",
-			deviceID: "MIG-2ce7a541-c516-5dbc-a76e-26cc100d9b55::",
-			expected: "MIG-2ce7a541-c516-5dbc-a76e-26cc100d9b55",
-		},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			t.Parallel()
-			result := stripVGPUSuffix(tc.deviceID)
-			assert.Equal(t, tc.expected, result)
-		})
-	}
-}
-
 func TestKubernetesVirtualGPUs_UnusedGPUsPreserveMetrics(t *testing.T) {
 	testutils.RequireLinux(t)
 
@@ -1685,3 +1954,90 @@ func TestKubernetesVirtualGPUs_UnusedMIGInstancesPreserveMetrics(t *testing.T) {
 		})
 	}
 }
+
+func TestPodMapper_gkeGPUInstanceIdentifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gpuUUID := "GPU-00000000-0000-0000-0000-000000000000"
+
+	mockSystemInfo := mockdeviceinfo.NewMockProvider(ctrl)
+	mockSystemInfo.EXPECT().GPUCount().Return(uint(1)).AnyTimes()
+	mockSystemInfo.EXPECT().GPU(uint(0)).Return(deviceinfo.GPUInfo{
+		DeviceInfo: dcgm.Device{UUID: gpuUUID, GPU: 0},
+	}).AnyTimes()
+
+	tests := []struct {
+		name          string
+		format        appconfig.GPUInstanceIDFormat
+		gpuIndex      string
+		gpuInstanceID string
+		want          string
+	}{
+		{
+			name:          "default format",
+			gpuIndex:      "0",
+			gpuInstanceID: "3",
+			want:          "0-3",
+		},
+		{
+			name:          "index-gi format",
+			format:        appconfig.GPUInstanceIDFormatIndex,
+			gpuIndex:      "0",
+			gpuInstanceID: "3",
+			want:          "0-3",
+		},
+		{
+			name:          "uuid/gi format",
+			format:        appconfig.GPUInstanceIDFormatUUID,
+			gpuIndex:      "0",
+			gpuInstanceID: "3",
+			want:          gpuUUID + "/3",
+		},
+		{
+			name:          "uuid/gi format, unknown GPU",
+			format:        appconfig.GPUInstanceIDFormatUUID,
+			gpuIndex:      "1",
+			gpuInstanceID: "3",
+			want:          "",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			podMapper := &PodMapper{Config: &appconfig.Config{GPUInstanceIDFormat: tc.format}}
+			assert.Equal(t, tc.want, podMapper.gkeGPUInstanceIdentifier(mockSystemInfo, tc.gpuIndex, tc.gpuInstanceID))
+		})
+	}
+}
+
+func TestPodMapper_metricDeviceID(t *testing.T) {
+	podMapper := &PodMapper{Config: &appconfig.Config{KubernetesGPUIdType: appconfig.GPUUID}}
+	ciMetric := collector.Metric{
+		GPU:               "0",
+		GPUUUID:           "GPU-00000000-0000-0000-0000-000000000000",
+		GPUInstanceID:     "1",
+		ComputeInstanceID: "2",
+		MigProfile:        "1g.10gb",
+	}
+
+	t.Run("Compute instance is mapped", func(t *testing.T) {
+		deviceID, err := podMapper.metricDeviceID(ciMetric, func(string) bool { return true })
+		require.NoError(t, err)
+		assert.Equal(t, "0-1-2", deviceID)
+	})
+
+	t.Run("Falls back to the GPU instance", func(t *testing.T) {
+		deviceID, err := podMapper.metricDeviceID(ciMetric, func(string) bool { return false })
+		require.NoError(t, err)
+		assert.Equal(t, "0-1", deviceID)
+	})
+
+	t.Run("GPU instance metrics don't fall back", func(t *testing.T) {
+		giMetric := ciMetric
+		giMetric.ComputeInstanceID = ""
+		deviceID, err := podMapper.metricDeviceID(giMetric, func(string) bool { return false })
+		require.NoError(t, err)
+		assert.Equal(t, "0-1", deviceID)
+	})
+}
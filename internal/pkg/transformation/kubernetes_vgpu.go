@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+)
+
+const (
+	// Annotations set by the HAMi and Volcano vGPU schedulers on the pods, with the GPUs allocated to each
+	// container, in the order of the containers of the pod:
+	// <UUID>,<type>,<memory in MiB>,<cores in %>:<next GPU>;<next container>
+	hamiAllocatedAnnotation    = "hami.io/vgpu-devices-allocated"
+	volcanoAllocatedAnnotation = "volcano.sh/vgpu-ids-new"
+)
+
+// vgpuAllocation is a GPU allocated to a container by the HAMi or Volcano vGPU scheduler
+type vgpuAllocation struct {
+	UUID   string
+	Memory string // Device memory the container may use (in MiB)
+	Cores  string // Share of the streaming multiprocessors the container may use (in %)
+}
+
+// sharedGPUAllocations returns the GPUs the HAMi or Volcano vGPU scheduler allocated to the container, or nil
+// when the pod isn't scheduled by them. The kubelet picks any of the IDs the device plugins advertise for the
+// GPUs, so only the annotations of the pod tell which GPU the container got.
+func (p *PodMapper) sharedGPUAllocations(
+	pod *podresourcesapi.PodResources, container *podresourcesapi.ContainerResources,
+) []vgpuAllocation {
+	if p.podLister == nil {
+		return nil
+	}
+
+	podObj, err := p.podLister.Pods(pod.GetNamespace()).Get(pod.GetName())
+	if err != nil {
+		return nil
+	}
+
+	return parseVGPUAllocations(podObj, container.GetName())
+}
+
+// parseVGPUAllocations parses the GPUs allocated to a container of the pod from the annotation of the HAMi or
+// Volcano vGPU scheduler
+func parseVGPUAllocations(pod *corev1.Pod, containerName string) []vgpuAllocation {
+	value, exists := pod.Annotations[hamiAllocatedAnnotation]
+	if !exists {
+		value, exists = pod.Annotations[volcanoAllocatedAnnotation]
+	}
+	if !exists {
+		return nil
+	}
+
+	index := slices.IndexFunc(pod.Spec.Containers, func(c corev1.Container) bool {
+		return c.Name == containerName
+	})
+	containers := strings.Split(value, ";")
+	if index < 0 || index >= len(containers) {
+		return nil
+	}
+
+	var allocations []vgpuAllocation
+	for _, device := range strings.Split(containers[index], ":") {
+		fields := strings.Split(device, ",")
+		if len(fields) < 4 || fields[0] == "" {
+			continue
+		}
+		allocations = append(allocations, vgpuAllocation{UUID: fields[0], Memory: fields[2], Cores: fields[3]})
+	}
+	return allocations
+}
+
+// setVGPUQuotaAttributes adds the device memory and cores the vGPU scheduler allocated to the pod
+func setVGPUQuotaAttributes(attributes map[string]string, pi PodInfo) {
+	if pi.VGPUMemory != "" {
+		attributes[vgpuMemoryAttribute] = pi.VGPUMemory
+	}
+	if pi.VGPUCores != "" {
+		attributes[vgpuCoresAttribute] = pi.VGPUCores
+	}
+}
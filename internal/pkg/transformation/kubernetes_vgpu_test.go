@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+)
+
+const (
+	testVGPUUUID0 = "GPU-5a5a7118-e550-79a1-597e-7631e126c57a"
+	testVGPUUUID1 = "GPU-0d5e1c2b-8f3a-4b6c-9d7e-1f2a3b4c5d6e"
+)
+
+func testVGPUPod(annotations map[string]string, containers ...string) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "vgpu-pod",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+	}
+	for _, name := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: name})
+	}
+	return pod
+}
+
+func TestParseVGPUAllocations(t *testing.T) {
+	hami := testVGPUUUID0 + ",NVIDIA,4000,30:" + testVGPUUUID1 + ",NVIDIA,2000,0;" +
+		testVGPUUUID1 + ",NVIDIA,8000,50:"
+
+	cases := []struct {
+		desc        string
+		annotations map[string]string
+		container   string
+		want        []vgpuAllocation
+	}{
+		{
+			desc:        "hami, first container with two GPUs",
+			annotations: map[string]string{hamiAllocatedAnnotation: hami},
+			container:   "main",
+			want: []vgpuAllocation{
+				{UUID: testVGPUUUID0, Memory: "4000", Cores: "30"},
+				{UUID: testVGPUUUID1, Memory: "2000", Cores: "0"},
+			},
+		},
+		{
+			desc:        "hami, second container",
+			annotations: map[string]string{hamiAllocatedAnnotation: hami},
+			container:   "sidecar",
+			want:        []vgpuAllocation{{UUID: testVGPUUUID1, Memory: "8000", Cores: "50"}},
+		},
+		{
+			desc:        "volcano",
+			annotations: map[string]string{volcanoAllocatedAnnotation: testVGPUUUID0 + ",NVIDIA,1024,25"},
+			container:   "main",
+			want:        []vgpuAllocation{{UUID: testVGPUUUID0, Memory: "1024", Cores: "25"}},
+		},
+		{
+			desc:        "malformed entries are skipped",
+			annotations: map[string]string{hamiAllocatedAnnotation: ",NVIDIA,1,1:" + testVGPUUUID0 + ",NVIDIA"},
+			container:   "main",
+		},
+		{
+			desc:        "container without allocation",
+			annotations: map[string]string{hamiAllocatedAnnotation: testVGPUUUID0 + ",NVIDIA,1024,25"},
+			container:   "sidecar",
+		},
+		{
+			desc:      "no annotation",
+			container: "main",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := parseVGPUAllocations(testVGPUPod(tc.annotations, "main", "sidecar"), tc.container)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestToDeviceToSharingPods_VGPUAllocations(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(testVGPUPod(map[string]string{
+		hamiAllocatedAnnotation: testVGPUUUID0 + ",NVIDIA,4000,30:" + testVGPUUUID1 + ",NVIDIA,2000,0",
+	}, "main")))
+
+	mapper := &PodMapper{
+		Config:           &appconfig.Config{},
+		podLister:        corev1listers.NewPodLister(indexer),
+		labelFilterCache: newLabelFilterCache(nil, 1000),
+	}
+
+	devicePods := &podresourcesapi.ListPodResourcesResponse{
+		PodResources: []*podresourcesapi.PodResources{
+			{
+				Name:      "vgpu-pod",
+				Namespace: "default",
+				Containers: []*podresourcesapi.ContainerResources{
+					{
+						Name: "main",
+						Devices: []*podresourcesapi.ContainerDevices{
+							{
+								ResourceName: appconfig.NvidiaResourceName,
+								// The kubelet picked IDs of other GPUs than the scheduler allocated
+								DeviceIds: []string{testVGPUUUID1 + "-3", testVGPUUUID1 + "-4"},
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:      "unannotated-pod",
+				Namespace: "default",
+				Containers: []*podresourcesapi.ContainerResources{
+					{
+						Name: "main",
+						Devices: []*podresourcesapi.ContainerDevices{
+							{
+								ResourceName: appconfig.NvidiaResourceName,
+								DeviceIds:    []string{testVGPUUUID1 + "-5"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got := mapper.toDeviceToSharingPods(devicePods, nil)
+
+	require.Len(t, got[testVGPUUUID0], 1)
+	assert.Equal(t, "vgpu-pod", got[testVGPUUUID0][0].Name)
+	assert.Equal(t, "4000", got[testVGPUUUID0][0].VGPUMemory)
+	assert.Equal(t, "30", got[testVGPUUUID0][0].VGPUCores)
+
+	require.Len(t, got[testVGPUUUID1], 2)
+	assert.Equal(t, "vgpu-pod", got[testVGPUUUID1][0].Name)
+	assert.Equal(t, "2000", got[testVGPUUUID1][0].VGPUMemory)
+	assert.Equal(t, "unannotated-pod", got[testVGPUUUID1][1].Name)
+	assert.Equal(t, "5", got[testVGPUUUID1][1].VGPU)
+	assert.Empty(t, got[testVGPUUUID1][1].VGPUMemory)
+
+	assert.NotContains(t, got, testVGPUUUID1+"-3")
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"log/slog"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	replicaSetKind = "ReplicaSet"
+	deploymentKind = "Deployment"
+
+	// podTemplateHashLabel is set by the Deployment controller on its ReplicaSets and their pods, the name
+	// of a ReplicaSet is the name of its Deployment followed by the hash.
+	podTemplateHashLabel = "pod-template-hash"
+)
+
+// newReplicaSetInformerFactory creates the informers of the ReplicaSets of the cluster, used to resolve the
+// Deployment owning the pods. The ReplicaSets have no node, unlike the pods, so they are not watched by the
+// informers of the pods.
+func newReplicaSetInformerFactory(clientset kubernetes.Interface) informers.SharedInformerFactory {
+	return informers.NewSharedInformerFactoryWithOptions(clientset, 0, informers.WithTransform(stripReplicaSet))
+}
+
+// stripReplicaSet keeps the metadata of the ReplicaSets needed to resolve their owner, the pod templates of
+// all the ReplicaSets of a cluster would otherwise take a lot of memory.
+func stripReplicaSet(obj interface{}) (interface{}, error) {
+	rs, ok := obj.(*appsv1.ReplicaSet)
+	if !ok {
+		return obj, nil
+	}
+
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rs.Name,
+			Namespace:       rs.Namespace,
+			UID:             rs.UID,
+			ResourceVersion: rs.ResourceVersion,
+			OwnerReferences: rs.OwnerReferences,
+		},
+	}, nil
+}
+
+// resolveWorkload returns the kind and the name of the workload owning the pod: the controller of the pod,
+// or the Deployment of its ReplicaSet. Pods without controller have no workload.
+func (p *PodMapper) resolveWorkload(pod *corev1.Pod) (string, string) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return "", ""
+	}
+	if owner.Kind != replicaSetKind {
+		return owner.Kind, owner.Name
+	}
+
+	if p.replicaSetLister != nil {
+		rs, err := p.replicaSetLister.ReplicaSets(pod.Namespace).Get(owner.Name)
+		if err == nil {
+			if rsOwner := metav1.GetControllerOf(rs); rsOwner != nil {
+				return rsOwner.Kind, rsOwner.Name
+			}
+			return owner.Kind, owner.Name
+		}
+		slog.Debug("Could not find ReplicaSet in informer cache",
+			"replicaSet", owner.Name,
+			"namespace", pod.Namespace,
+			"error", err)
+	}
+
+	// Until the ReplicaSet is in the cache, its Deployment is derived from its name
+	if hash := pod.Labels[podTemplateHashLabel]; hash != "" {
+		if name, found := strings.CutSuffix(owner.Name, "-"+hash); found && name != "" {
+			return deploymentKind, name
+		}
+	}
+	return owner.Kind, owner.Name
+}
+
+// setWorkloadAttributes adds the workload owning the pod to the attributes of a metric, when it is known
+func setWorkloadAttributes(attributes map[string]string, podInfo PodInfo) {
+	if podInfo.WorkloadKind == "" {
+		return
+	}
+	attributes[workloadKindAttribute] = podInfo.WorkloadKind
+	attributes[workloadNameAttribute] = podInfo.WorkloadName
+}
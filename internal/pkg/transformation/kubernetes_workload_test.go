@@ -0,0 +1,210 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+)
+
+func controllerRef(kind, name string) []metav1.OwnerReference {
+	controller := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &controller}}
+}
+
+func TestPodMapper_resolveWorkload(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	require.NoError(t, indexer.Add(&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name: "trainer-5d8f7c9b4", Namespace: "ml", OwnerReferences: controllerRef(deploymentKind, "trainer"),
+	}}))
+	require.NoError(t, indexer.Add(&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name: "standalone", Namespace: "ml",
+	}}))
+	mapper := &PodMapper{replicaSetLister: appsv1listers.NewReplicaSetLister(indexer)}
+
+	tests := []struct {
+		name         string
+		labels       map[string]string
+		owners       []metav1.OwnerReference
+		expectedKind string
+		expectedName string
+	}{
+		{
+			name:         "Deployment",
+			owners:       controllerRef(replicaSetKind, "trainer-5d8f7c9b4"),
+			expectedKind: deploymentKind,
+			expectedName: "trainer",
+		},
+		{
+			name:         "ReplicaSetWithoutController",
+			owners:       controllerRef(replicaSetKind, "standalone"),
+			expectedKind: replicaSetKind,
+			expectedName: "standalone",
+		},
+		{
+			name:         "ReplicaSetNotCachedYet",
+			labels:       map[string]string{podTemplateHashLabel: "6b9c4d7f8"},
+			owners:       controllerRef(replicaSetKind, "inference-6b9c4d7f8"),
+			expectedKind: deploymentKind,
+			expectedName: "inference",
+		},
+		{
+			name:         "UnknownReplicaSet",
+			owners:       controllerRef(replicaSetKind, "unknown"),
+			expectedKind: replicaSetKind,
+			expectedName: "unknown",
+		},
+		{
+			name:         "StatefulSet",
+			owners:       controllerRef("StatefulSet", "etcd"),
+			expectedKind: "StatefulSet",
+			expectedName: "etcd",
+		},
+		{
+			name:         "Job",
+			owners:       controllerRef("Job", "finetune-42"),
+			expectedKind: "Job",
+			expectedName: "finetune-42",
+		},
+		{
+			name:   "NonControllerOwner",
+			owners: []metav1.OwnerReference{{Kind: "ConfigMap", Name: "settings"}},
+		},
+		{
+			name: "BarePod",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name: "pod", Namespace: "ml", Labels: tt.labels, OwnerReferences: tt.owners,
+			}}
+			kind, name := mapper.resolveWorkload(pod)
+			assert.Equal(t, tt.expectedKind, kind)
+			assert.Equal(t, tt.expectedName, name)
+		})
+	}
+}
+
+func TestPodMapper_resolveWorkload_WithoutReplicaSetLister(t *testing.T) {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:            "trainer-5d8f7c9b4-x2x7q",
+		Labels:          map[string]string{podTemplateHashLabel: "5d8f7c9b4"},
+		OwnerReferences: controllerRef(replicaSetKind, "trainer-5d8f7c9b4"),
+	}}
+
+	kind, name := (&PodMapper{}).resolveWorkload(pod)
+	assert.Equal(t, deploymentKind, kind)
+	assert.Equal(t, "trainer", name)
+}
+
+func TestStripReplicaSet(t *testing.T) {
+	replicas := int32(3)
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "trainer-5d8f7c9b4",
+			Namespace:       "ml",
+			UID:             types.UID("rs-uid"),
+			ResourceVersion: "42",
+			Labels:          map[string]string{"app": "trainer"},
+			OwnerReferences: controllerRef(deploymentKind, "trainer"),
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: &replicas,
+			Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "trainer"}}}},
+		},
+	}
+
+	stripped, err := stripReplicaSet(rs)
+	require.NoError(t, err)
+	assert.Equal(t, &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name:            "trainer-5d8f7c9b4",
+		Namespace:       "ml",
+		UID:             types.UID("rs-uid"),
+		ResourceVersion: "42",
+		OwnerReferences: controllerRef(deploymentKind, "trainer"),
+	}}, stripped)
+
+	tombstone := cache.DeletedFinalStateUnknown{Key: "ml/trainer-5d8f7c9b4"}
+	stripped, err = stripReplicaSet(tombstone)
+	require.NoError(t, err)
+	assert.Equal(t, tombstone, stripped)
+}
+
+func TestSetWorkloadAttributes(t *testing.T) {
+	attributes := map[string]string{}
+	setWorkloadAttributes(attributes, PodInfo{Name: "bare"})
+	assert.Empty(t, attributes)
+
+	setWorkloadAttributes(attributes, PodInfo{WorkloadKind: "Job", WorkloadName: "finetune-42"})
+	assert.Equal(t, map[string]string{
+		workloadKindAttribute: "Job",
+		workloadNameAttribute: "finetune-42",
+	}, attributes)
+}
+
+func TestPodMapper_createPodInfo_WithWorkload(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	config := &appconfig.Config{KubernetesWorkloadLabels: true}
+	mapper := &PodMapper{
+		Config:           config,
+		Client:           client,
+		labelFilterCache: newLabelFilterCache(nil, 1000),
+	}
+	setupMockInformer(t, mapper, client)
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:            "etcd-0",
+		Namespace:       "default",
+		OwnerReferences: controllerRef("StatefulSet", "etcd"),
+	}}
+	_, err := client.CoreV1().Pods("default").Create(context.Background(), pod, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// Wait for informer sync
+	time.Sleep(100 * time.Millisecond)
+
+	podRes := &podresourcesapi.PodResources{
+		Name:       "etcd-0",
+		Namespace:  "default",
+		Containers: []*podresourcesapi.ContainerResources{{Name: "etcd"}},
+	}
+
+	podInfo := mapper.createPodInfo(podRes, podRes.Containers[0])
+	assert.Equal(t, "StatefulSet", podInfo.WorkloadKind)
+	assert.Equal(t, "etcd", podInfo.WorkloadName)
+
+	config.KubernetesWorkloadLabels = false
+	podInfo = mapper.createPodInfo(podRes, podRes.Containers[0])
+	assert.Empty(t, podInfo.WorkloadKind)
+	assert.Empty(t, podInfo.WorkloadName)
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+)
+
+// Maintenance marks the metrics with maintenance="true" during a maintenance window of the node, so that
+// alerts can ignore planned GPU work while the data is still recorded. The configured families, e.g. those
+// alerted on, are dropped instead. A window is bounded: it ends by itself at its end time.
+type Maintenance struct {
+	families map[string]bool
+
+	mu    sync.RWMutex
+	start time.Time
+	end   time.Time
+}
+
+func NewMaintenance(suppressedFamilies []string) *Maintenance {
+	t := &Maintenance{families: make(map[string]bool, len(suppressedFamilies))}
+	for _, family := range suppressedFamilies {
+		t.families[family] = true
+	}
+	return t
+}
+
+func (t *Maintenance) Name() string {
+	return "Maintenance"
+}
+
+// Start starts a maintenance window ending after duration, or extends the current one
+func (t *Maintenance) Start(duration time.Duration) (start, end time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if !now.Before(t.end) {
+		t.start = now
+	}
+	t.end = now.Add(duration)
+	return t.start, t.end
+}
+
+// Stop ends the current maintenance window. It returns false when none was active.
+func (t *Maintenance) Stop() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	active := time.Now().Before(t.end)
+	t.start, t.end = time.Time{}, time.Time{}
+	return active
+}
+
+// Window returns the start and the end of the current maintenance window, and whether one is active
+func (t *Maintenance) Window() (start, end time.Time, active bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if !time.Now().Before(t.end) {
+		return time.Time{}, time.Time{}, false
+	}
+	return t.start, t.end, true
+}
+
+func (t *Maintenance) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	if _, _, active := t.Window(); !active {
+		return nil
+	}
+
+	for counter, metricList := range metrics {
+		if t.families[counter.FieldName] {
+			delete(metrics, counter)
+			continue
+		}
+
+		for i := range metricList {
+			// Labels may be shared between metrics of a collector
+			labels := make(map[string]string, len(metricList[i].Labels)+1)
+			maps.Copy(labels, metricList[i].Labels)
+			labels[maintenanceLabel] = "true"
+			metricList[i].Labels = labels
+		}
+	}
+	return nil
+}
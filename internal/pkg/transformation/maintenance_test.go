@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+func TestMaintenance_Process(t *testing.T) {
+	gpuUtil := counters.Counter{FieldName: "DCGM_FI_DEV_GPU_UTIL", PromType: "gauge"}
+	xidErrors := counters.Counter{FieldName: "DCGM_FI_DEV_XID_ERRORS", PromType: "gauge"}
+	shared := map[string]string{"cluster": "a"}
+
+	newMetrics := func() collector.MetricsByCounter {
+		return collector.MetricsByCounter{
+			gpuUtil: {
+				{Counter: gpuUtil, GPU: "0", Value: "10", Labels: shared},
+				{Counter: gpuUtil, GPU: "1", Value: "20", Labels: shared},
+			},
+			xidErrors: {{Counter: xidErrors, GPU: "0", Value: "79"}},
+		}
+	}
+
+	maintenance := NewMaintenance([]string{xidErrors.FieldName})
+
+	metrics := newMetrics()
+	require.NoError(t, maintenance.Process(metrics, nil))
+	assert.Equal(t, newMetrics(), metrics, "Nothing should change outside a maintenance window")
+
+	start, end := maintenance.Start(time.Hour)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), end, time.Minute)
+	windowStart, windowEnd, active := maintenance.Window()
+	assert.True(t, active)
+	assert.Equal(t, start, windowStart)
+	assert.Equal(t, end, windowEnd)
+
+	metrics = newMetrics()
+	require.NoError(t, maintenance.Process(metrics, nil))
+	assert.NotContains(t, metrics, xidErrors, "The suppressed families should be dropped")
+	require.Len(t, metrics[gpuUtil], 2)
+	for _, m := range metrics[gpuUtil] {
+		assert.Equal(t, map[string]string{"cluster": "a", maintenanceLabel: "true"}, m.Labels)
+	}
+	assert.Equal(t, map[string]string{"cluster": "a"}, shared, "The shared labels should be unchanged")
+
+	// Extending the window keeps its start
+	extendedStart, _ := maintenance.Start(2 * time.Hour)
+	assert.Equal(t, start, extendedStart)
+
+	assert.True(t, maintenance.Stop())
+	assert.False(t, maintenance.Stop(), "A stopped window should not be stopped twice")
+	_, _, active = maintenance.Window()
+	assert.False(t, active)
+
+	// A window ends by itself
+	maintenance.Start(-time.Second)
+	_, _, active = maintenance.Window()
+	assert.False(t, active)
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"strings"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+)
+
+const migFamilySuffix = "_MIG"
+
+// MIGFamilySplit moves the series of MIG instances into a separate <FIELD>_MIG family, so that
+// a family holds either full GPU or MIG instance series. Summing a mixed family counts the
+// utilization of a MIG enabled GPU twice: once for the GPU and once for its instances.
+type MIGFamilySplit struct{}
+
+func NewMIGFamilySplit() *MIGFamilySplit {
+	return &MIGFamilySplit{}
+}
+
+func (t *MIGFamilySplit) Name() string {
+	return "MIGFamilySplit"
+}
+
+func (t *MIGFamilySplit) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	migMetrics := collector.MetricsByCounter{}
+
+	for counter, metricList := range metrics {
+		if strings.HasSuffix(counter.FieldName, migFamilySuffix) {
+			continue
+		}
+
+		migCounter := counters.Counter{
+			FieldID:   counter.FieldID,
+			FieldName: counter.FieldName + migFamilySuffix,
+			PromType:  counter.PromType,
+			Help:      counter.Help,
+		}
+
+		gpuMetrics := metricList[:0]
+		for _, m := range metricList {
+			if m.GPUInstanceID == "" {
+				gpuMetrics = append(gpuMetrics, m)
+				continue
+			}
+
+			m.Counter = migCounter
+			migMetrics[migCounter] = append(migMetrics[migCounter], m)
+		}
+
+		if len(gpuMetrics) == 0 {
+			delete(metrics, counter)
+		} else {
+			metrics[counter] = gpuMetrics
+		}
+	}
+
+	for counter, metricList := range migMetrics {
+		metrics[counter] = append(metrics[counter], metricList...)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+func TestMIGFamilySplit_Process(t *testing.T) {
+	grEngineActive := counters.Counter{
+		FieldID:   dcgm.DCGM_FI_PROF_GR_ENGINE_ACTIVE,
+		FieldName: "DCGM_FI_PROF_GR_ENGINE_ACTIVE",
+		PromType:  "gauge",
+		Help:      "Ratio of time the graphics engine is active.",
+	}
+	gpuTemp := counters.Counter{
+		FieldID:   dcgm.DCGM_FI_DEV_GPU_TEMP,
+		FieldName: "DCGM_FI_DEV_GPU_TEMP",
+		PromType:  "gauge",
+	}
+	migOnly := counters.Counter{
+		FieldID:   dcgm.DCGM_FI_DEV_FB_USED,
+		FieldName: "DCGM_FI_DEV_FB_USED",
+		PromType:  "gauge",
+	}
+
+	metrics := collector.MetricsByCounter{
+		grEngineActive: {
+			{Counter: grEngineActive, GPU: "0", Value: "0.5"},
+			{Counter: grEngineActive, GPU: "1", GPUInstanceID: "1", MigProfile: "1g.10gb", Value: "0.2"},
+			{Counter: grEngineActive, GPU: "1", GPUInstanceID: "2", MigProfile: "1g.10gb", Value: "0.3"},
+		},
+		gpuTemp: {
+			{Counter: gpuTemp, GPU: "0", Value: "40"},
+		},
+		migOnly: {
+			{Counter: migOnly, GPU: "1", GPUInstanceID: "1", Value: "100"},
+		},
+	}
+
+	require.NoError(t, NewMIGFamilySplit().Process(metrics, nil))
+
+	require.Len(t, metrics[grEngineActive], 1)
+	assert.Equal(t, "0", metrics[grEngineActive][0].GPU)
+
+	migGREngineActive := counters.Counter{
+		FieldID:   grEngineActive.FieldID,
+		FieldName: "DCGM_FI_PROF_GR_ENGINE_ACTIVE_MIG",
+		PromType:  "gauge",
+		Help:      grEngineActive.Help,
+	}
+	require.Len(t, metrics[migGREngineActive], 2)
+	for _, m := range metrics[migGREngineActive] {
+		assert.Equal(t, migGREngineActive, m.Counter)
+		assert.NotEmpty(t, m.GPUInstanceID)
+	}
+
+	assert.Len(t, metrics[gpuTemp], 1)
+
+	// A family with MIG series only is moved entirely
+	assert.NotContains(t, metrics, migOnly)
+	assert.Len(t, metrics[counters.Counter{
+		FieldID:   migOnly.FieldID,
+		FieldName: "DCGM_FI_DEV_FB_USED_MIG",
+		PromType:  "gauge",
+	}], 1)
+
+	assert.Len(t, metrics, 4)
+}
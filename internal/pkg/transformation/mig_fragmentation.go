@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"strconv"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+)
+
+var (
+	migFreeSlicesCounter = counters.Counter{
+		FieldID:   dcgm.Short(counters.DCGMMIGFreeSlices),
+		FieldName: counters.DCGMExpMIGFreeSlices,
+		PromType:  "gauge",
+		Help:      "Compute slices of the MIG enabled GPU not used by a GPU instance.",
+	}
+	migLargestFreeProfileCounter = counters.Counter{
+		FieldID:   dcgm.Short(counters.DCGMMIGLargestFreeProfile),
+		FieldName: counters.DCGMExpMIGLargestFreeProfile,
+		PromType:  "gauge",
+		Help:      "Compute slices of the largest GPU instance profile that can still be created on the GPU.",
+	}
+	migFragmentationCounter = counters.Counter{
+		FieldID:   dcgm.Short(counters.DCGMMIGFragmentation),
+		FieldName: counters.DCGMExpMIGFragmentation,
+		PromType:  "gauge",
+		Help:      "Share of the free compute slices of the GPU the largest free profile can't use (0 to 1).",
+	}
+)
+
+// MIGFragmentation derives, for every MIG enabled GPU, the free compute slices, the largest GPU instance
+// profile that can still be created and the fragmentation ratio, 1 - largest / free. Autoscalers and
+// defragmentation tools repartition the GPUs whose free slices can't host a large profile. The DCGM hierarchy
+// doesn't report the placement of the GPU instances, so the capacity is read from NVML, whose remaining
+// capacity per profile accounts for the placements: free slices split by an instance don't count as a
+// large profile.
+type MIGFragmentation struct{}
+
+func NewMIGFragmentation() *MIGFragmentation {
+	return &MIGFragmentation{}
+}
+
+func (t *MIGFragmentation) Name() string {
+	return "MIGFragmentation"
+}
+
+func (t *MIGFragmentation) Process(metrics collector.MetricsByCounter, deviceInfo deviceinfo.Provider) error {
+	// The GPUs are described by every entity type, their metrics are derived with the GPU metrics only
+	if deviceInfo == nil || deviceInfo.InfoType() != dcgm.FE_GPU {
+		return nil
+	}
+
+	templates := migGPUTemplates(metrics)
+
+	var freeMetrics, largestMetrics, fragmentationMetrics []collector.Metric
+	for _, gpu := range deviceInfo.GPUs() {
+		if !gpu.MigEnabled {
+			continue
+		}
+
+		template, exists := templates[fmt.Sprint(gpu.DeviceInfo.GPU)]
+		if !exists {
+			continue
+		}
+
+		capacity, err := nvmlprovider.Client().GetMIGCapacity(gpu.DeviceInfo.UUID)
+		if err != nil {
+			// NVML may not be initialized yet, the GPU has no fragmentation metrics until it is
+			slog.Debug("Failed to get the MIG capacity of the GPU, skipping its fragmentation metrics",
+				slog.String("gpu_uuid", gpu.DeviceInfo.UUID),
+				slog.String(logging.ErrorKey, err.Error()))
+			continue
+		}
+
+		free, largest := capacity.FreeSlices, min(capacity.LargestFreeProfileSlices, capacity.FreeSlices)
+		fragmentation := 0.0
+		if free > 0 {
+			fragmentation = float64(free-largest) / float64(free)
+		}
+
+		freeMetrics = append(freeMetrics, migFragmentationMetric(template, migFreeSlicesCounter, float64(free)))
+		largestMetrics = append(largestMetrics,
+			migFragmentationMetric(template, migLargestFreeProfileCounter, float64(largest)))
+		fragmentationMetrics = append(fragmentationMetrics,
+			migFragmentationMetric(template, migFragmentationCounter, fragmentation))
+	}
+
+	if len(freeMetrics) > 0 {
+		metrics[migFreeSlicesCounter] = freeMetrics
+		metrics[migLargestFreeProfileCounter] = largestMetrics
+		metrics[migFragmentationCounter] = fragmentationMetrics
+	}
+
+	return nil
+}
+
+// migGPUTemplates returns, per GPU index, a metric of the GPU labeling the derived series, preferably
+// DCGM_FI_DEV_MIG_MAX_SLICES
+func migGPUTemplates(metrics collector.MetricsByCounter) map[string]collector.Metric {
+	templates := map[string]collector.Metric{}
+	templateFields := map[string]dcgm.Short{}
+
+	for counter, metricList := range metrics {
+		// The series derived by the other transformations carry their own labels
+		if _, derived := counters.DCGMFields[counter.FieldName]; derived || counter.PromType == "label" {
+			continue
+		}
+
+		for _, m := range metricList {
+			if m.GPUInstanceID != "" || m.NvLink != "" {
+				continue
+			}
+
+			// The template is the same whatever the iteration order of the families
+			if field, exists := templateFields[m.GPU]; exists && counter.FieldID != migMaxSlicesID &&
+				(field == migMaxSlicesID || field < counter.FieldID) {
+				continue
+			}
+
+			templates[m.GPU] = m
+			templateFields[m.GPU] = counter.FieldID
+		}
+	}
+
+	return templates
+}
+
+func migFragmentationMetric(template collector.Metric, counter counters.Counter, value float64) collector.Metric {
+	newMetric := template
+	newMetric.Labels = maps.Clone(template.Labels)
+	newMetric.Attributes = maps.Clone(template.Attributes)
+	newMetric.Counter = counter
+	newMetric.Value = strconv.FormatFloat(value, 'f', -1, 64)
+	return newMetric
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockdeviceinfo "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/deviceinfo"
+	mocknvmlprovider "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/nvmlprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+)
+
+func migGPU(index uint, migEnabled bool) deviceinfo.GPUInfo {
+	gpu := deviceinfo.GPUInfo{MigEnabled: migEnabled}
+	gpu.DeviceInfo.GPU = index
+	gpu.DeviceInfo.UUID = fmt.Sprintf("GPU-%d", index)
+	return gpu
+}
+
+func TestMIGFragmentation_Process(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	gpus := []deviceinfo.GPUInfo{
+		migGPU(0, true),  // 3 free slices, a 3g profile fits
+		migGPU(1, true),  // 5 free slices split by the placements, the largest profile is a 2g
+		migGPU(2, true),  // 0 free slices
+		migGPU(3, false), // MIG disabled
+		migGPU(4, true),  // no GPU metric
+		migGPU(5, true),  // NVML error
+	}
+	mockDeviceInfo := mockdeviceinfo.NewMockProvider(ctrl)
+	mockDeviceInfo.EXPECT().InfoType().Return(dcgm.FE_GPU).AnyTimes()
+	mockDeviceInfo.EXPECT().GPUs().Return(gpus).AnyTimes()
+
+	mockNVML := mocknvmlprovider.NewMockNVML(ctrl)
+	mockNVML.EXPECT().GetMIGCapacity("GPU-0").
+		Return(nvmlprovider.MIGCapacity{TotalSlices: 7, FreeSlices: 3, LargestFreeProfileSlices: 3}, nil)
+	mockNVML.EXPECT().GetMIGCapacity("GPU-1").
+		Return(nvmlprovider.MIGCapacity{TotalSlices: 7, FreeSlices: 5, LargestFreeProfileSlices: 2}, nil)
+	mockNVML.EXPECT().GetMIGCapacity("GPU-2").
+		Return(nvmlprovider.MIGCapacity{TotalSlices: 4}, nil)
+	mockNVML.EXPECT().GetMIGCapacity("GPU-5").
+		Return(nvmlprovider.MIGCapacity{}, errors.New("NVML not initialized"))
+
+	realNVML := nvmlprovider.Client()
+	defer nvmlprovider.SetClient(realNVML)
+	nvmlprovider.SetClient(mockNVML)
+
+	maxSlices := counters.Counter{FieldID: migMaxSlicesID, FieldName: "DCGM_FI_DEV_MIG_MAX_SLICES", PromType: "gauge"}
+	temp := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_GPU_TEMP, FieldName: "DCGM_FI_DEV_GPU_TEMP", PromType: "gauge"}
+	metrics := collector.MetricsByCounter{
+		maxSlices: {
+			{GPU: "0", GPUUUID: "GPU-0", Value: "7", Labels: map[string]string{}},
+			{GPU: "2", GPUUUID: "GPU-2", Value: "4", Labels: map[string]string{}},
+		},
+		temp: {
+			{GPU: "0", GPUUUID: "GPU-0", Value: "40", Labels: map[string]string{}},
+			{GPU: "1", GPUUUID: "GPU-1", Value: "40", Labels: map[string]string{"pod": "a"}},
+			{GPU: "1", GPUUUID: "GPU-1", GPUInstanceID: "0", Value: "40", Labels: map[string]string{}},
+			{GPU: "3", GPUUUID: "GPU-3", Value: "40", Labels: map[string]string{}},
+			{GPU: "5", GPUUUID: "GPU-5", Value: "40", Labels: map[string]string{}},
+		},
+	}
+
+	err := NewMIGFragmentation().Process(metrics, mockDeviceInfo)
+	require.NoError(t, err)
+
+	values := func(counter counters.Counter) map[string]string {
+		result := map[string]string{}
+		for _, m := range metrics[counter] {
+			assert.Equal(t, counter, m.Counter)
+			assert.Empty(t, m.GPUInstanceID, "The series are labeled like the GPU")
+			result[m.GPUUUID] = m.Value
+		}
+		return result
+	}
+
+	assert.Equal(t, map[string]string{"GPU-0": "3", "GPU-1": "5", "GPU-2": "0"}, values(migFreeSlicesCounter))
+	assert.Equal(t, map[string]string{"GPU-0": "3", "GPU-1": "2", "GPU-2": "0"}, values(migLargestFreeProfileCounter))
+	assert.Equal(t, map[string]string{"GPU-0": "0", "GPU-1": "0.6", "GPU-2": "0"}, values(migFragmentationCounter))
+
+	for _, m := range metrics[migFreeSlicesCounter] {
+		if m.GPU == "1" {
+			assert.Equal(t, map[string]string{"pod": "a"}, m.Labels)
+		}
+	}
+}
+
+func TestMIGFragmentation_ProcessOtherEntities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mockDeviceInfo := mockdeviceinfo.NewMockProvider(ctrl)
+	mockDeviceInfo.EXPECT().InfoType().Return(dcgm.FE_GPU_I).AnyTimes()
+
+	metrics := collector.MetricsByCounter{}
+	require.NoError(t, NewMIGFragmentation().Process(metrics, mockDeviceInfo))
+	assert.Empty(t, metrics)
+	require.NoError(t, NewMIGFragmentation().Process(metrics, nil))
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+)
+
+const deprecatedNameHelpPrefix = "Deprecated, renamed to "
+
+// NameMigration renames metric families and, during the transition window of every family, also exports
+// it under its old name, so that dashboards and alerts can move to the new name without a gap.
+type NameMigration struct {
+	migrations map[string]appconfig.MetricNameMigration // Old name -> migration
+	now        func() time.Time
+
+	mtx     sync.Mutex
+	expired map[string]struct{} // Old names whose transition window ended, to log it once
+}
+
+func NewNameMigration(migrations []appconfig.MetricNameMigration) *NameMigration {
+	t := &NameMigration{
+		migrations: make(map[string]appconfig.MetricNameMigration, len(migrations)),
+		now:        time.Now,
+		expired:    map[string]struct{}{},
+	}
+	for _, m := range migrations {
+		t.migrations[m.OldName] = m
+	}
+	return t
+}
+
+func (t *NameMigration) Name() string {
+	return "NameMigration"
+}
+
+func (t *NameMigration) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	now := t.now()
+
+	// The families are added while iterating, they must not be migrated again
+	for _, counter := range slices.Collect(maps.Keys(metrics)) {
+		migration, ok := t.migrations[counter.FieldName]
+		if !ok {
+			continue
+		}
+		metricList := metrics[counter]
+
+		newCounter := counter
+		newCounter.FieldName = migration.NewName
+
+		renamed := make([]collector.Metric, 0, len(metricList))
+		for _, m := range metricList {
+			m.Counter = newCounter
+			renamed = append(renamed, m)
+		}
+		metrics[newCounter] = append(metrics[newCounter], renamed...)
+
+		if migration.Until.IsZero() || now.Before(migration.Until) {
+			oldCounter := counter
+			oldCounter.Help = deprecatedNameHelpPrefix + migration.NewName + ". " + counter.Help
+			for i := range metricList {
+				metricList[i].Counter = oldCounter
+			}
+			delete(metrics, counter)
+			metrics[oldCounter] = append(metrics[oldCounter], metricList...)
+			continue
+		}
+
+		delete(metrics, counter)
+		if _, logged := t.expired[migration.OldName]; !logged {
+			t.expired[migration.OldName] = struct{}{}
+			slog.Info("Transition window of the metric name ended, the old name is not exported anymore",
+				slog.String("old_name", migration.OldName),
+				slog.String("new_name", migration.NewName),
+				slog.Time("until", migration.Until))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+func TestNameMigration_Process(t *testing.T) {
+	weightedUtil := counters.Counter{
+		FieldName: "DCGM_FI_DEV_WEIGHTED_GPU_UTIL",
+		PromType:  "gauge",
+		Help:      "Weighted GPU utilization.",
+	}
+	smUtil := counters.Counter{
+		FieldName: "DCGM_EXP_PROCESS_SM_UTIL",
+		PromType:  "gauge",
+	}
+	gpuTemp := counters.Counter{
+		FieldName: "DCGM_FI_DEV_GPU_TEMP",
+		PromType:  "gauge",
+	}
+
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	transform := NewNameMigration([]appconfig.MetricNameMigration{
+		{
+			OldName: weightedUtil.FieldName,
+			NewName: "DCGM_EXP_WEIGHTED_GPU_UTIL",
+			Until:   now.Add(24 * time.Hour),
+		},
+		{
+			OldName: smUtil.FieldName,
+			NewName: "DCGM_EXP_PROCESS_SM_UTILIZATION",
+			Until:   now.Add(-24 * time.Hour),
+		},
+	})
+	transform.now = func() time.Time { return now }
+
+	metrics := collector.MetricsByCounter{
+		weightedUtil: {{Counter: weightedUtil, GPU: "0", Value: "0.5"}},
+		smUtil:       {{Counter: smUtil, GPU: "0", Value: "80"}},
+		gpuTemp:      {{Counter: gpuTemp, GPU: "0", Value: "40"}},
+	}
+
+	require.NoError(t, transform.Process(metrics, nil))
+	assert.Len(t, metrics, 4)
+	assert.Len(t, metrics[gpuTemp], 1)
+
+	// Within the transition window, the family is exported under both names
+	newWeightedUtil := weightedUtil
+	newWeightedUtil.FieldName = "DCGM_EXP_WEIGHTED_GPU_UTIL"
+	require.Len(t, metrics[newWeightedUtil], 1)
+	assert.Equal(t, newWeightedUtil, metrics[newWeightedUtil][0].Counter)
+	assert.Equal(t, "0.5", metrics[newWeightedUtil][0].Value)
+
+	oldWeightedUtil := weightedUtil
+	oldWeightedUtil.Help = "Deprecated, renamed to DCGM_EXP_WEIGHTED_GPU_UTIL. Weighted GPU utilization."
+	assert.NotContains(t, metrics, weightedUtil)
+	require.Len(t, metrics[oldWeightedUtil], 1)
+	assert.Equal(t, oldWeightedUtil, metrics[oldWeightedUtil][0].Counter)
+
+	// After the transition window, only the new name is exported
+	newSMUtil := smUtil
+	newSMUtil.FieldName = "DCGM_EXP_PROCESS_SM_UTILIZATION"
+	assert.NotContains(t, metrics, smUtil)
+	require.Len(t, metrics[newSMUtil], 1)
+	assert.Equal(t, "80", metrics[newSMUtil][0].Value)
+}
+
+func TestNameMigration_ProcessWithoutWindow(t *testing.T) {
+	gpuUtil := counters.Counter{FieldName: "DCGM_FI_DEV_GPU_UTIL", PromType: "gauge"}
+
+	transform := NewNameMigration([]appconfig.MetricNameMigration{
+		{OldName: gpuUtil.FieldName, NewName: "DCGM_EXP_GPU_UTIL"},
+	})
+
+	metrics := collector.MetricsByCounter{
+		gpuUtil: {{Counter: gpuUtil, GPU: "0", Value: "10"}},
+	}
+
+	require.NoError(t, transform.Process(metrics, nil))
+	assert.Len(t, metrics, 2, "Without an end, both names should be exported")
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	stdos "os"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/kubeclient"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/utils"
+)
+
+// NodeLabeler exports the configured labels of the Kubernetes node the exporter runs on, e.g. its zone or its
+// GPU product, so that they don't require joins with the node labels in PromQL. They are added to every metric,
+// or exported by the dcgm_exporter_node_info gauge only. The node is watched by an informer, so the labels
+// follow its updates. A label already set on a metric, e.g. by the pod mapper, takes precedence over the node
+// label.
+type NodeLabeler struct {
+	nodeName string
+	keys     []string // Node labels exported
+	asLabels bool     // Whether the labels are added to every metric
+	labels   atomic.Pointer[map[string]string]
+	stopChan chan struct{}
+}
+
+// newInClusterNodeLabeler creates the NodeLabeler of the node named by the NODE_NAME environment variable
+func newInClusterNodeLabeler(c *appconfig.Config) (*NodeLabeler, error) {
+	nodeName := stdos.Getenv("NODE_NAME")
+	if nodeName == "" {
+		return nil, errors.New("NODE_NAME environment variable not set")
+	}
+
+	client, err := kubeclient.GetKubeClient()
+	if err != nil {
+		return nil, fmt.Errorf("error getting kube client: %w", err)
+	}
+	return NewNodeLabeler(client, nodeName, c.NodeLabels, c.NodeLabelsMode)
+}
+
+// NewNodeLabeler creates a NodeLabeler and starts watching the node. Its labels are exported once the node is
+// listed.
+func NewNodeLabeler(
+	client kubernetes.Interface, nodeName string, keys []string, mode appconfig.NodeLabelMode,
+) (*NodeLabeler, error) {
+	t := &NodeLabeler{
+		nodeName: nodeName,
+		keys:     keys,
+		asLabels: mode != appconfig.NodeLabelModeInfo,
+		stopChan: make(chan struct{}),
+	}
+	t.labels.Store(&map[string]string{})
+
+	factory := informers.NewSharedInformerFactoryWithOptions(client, informerResyncPeriod,
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", nodeName).String()
+		}))
+	_, err := factory.Core().V1().Nodes().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    t.setNode,
+		UpdateFunc: func(_, obj interface{}) { t.setNode(obj) },
+		DeleteFunc: t.deleteNode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error adding event handler: %w", err)
+	}
+	factory.Start(t.stopChan)
+
+	return t, nil
+}
+
+// setNode keeps the configured labels of the node
+func (t *NodeLabeler) setNode(obj interface{}) {
+	node, ok := obj.(*corev1.Node)
+	if !ok || node.Name != t.nodeName {
+		return
+	}
+
+	labels := map[string]string{}
+	for _, key := range t.keys {
+		if value, exists := node.Labels[key]; exists {
+			labels[utils.SanitizeLabelName(key)] = value
+		}
+	}
+	t.labels.Store(&labels)
+}
+
+// deleteNode clears the labels when the node is deleted
+func (t *NodeLabeler) deleteNode(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if node, ok := obj.(*corev1.Node); ok && node.Name == t.nodeName {
+		t.labels.Store(&map[string]string{})
+	}
+}
+
+func (t *NodeLabeler) Name() string {
+	return "NodeLabeler"
+}
+
+func (t *NodeLabeler) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	nodeLabels := *t.labels.Load()
+	if !t.asLabels || len(nodeLabels) == 0 {
+		return nil
+	}
+
+	for _, metricList := range metrics {
+		for i := range metricList {
+			// Labels may be shared between metrics of a collector
+			labels := make(map[string]string, len(metricList[i].Labels)+len(nodeLabels))
+			maps.Copy(labels, nodeLabels)
+			maps.Copy(labels, metricList[i].Labels)
+			for name := range metricList[i].Attributes {
+				delete(labels, name)
+			}
+			metricList[i].Labels = labels
+		}
+	}
+	return nil
+}
+
+// NodeInfo returns the labels of the dcgm_exporter_node_info gauge, the node name and the node labels set, or
+// false when the node labels are added to the metrics instead.
+func (t *NodeLabeler) NodeInfo() (map[string]string, bool) {
+	if t.asLabels {
+		return nil, false
+	}
+
+	labels := maps.Clone(*t.labels.Load())
+	labels[nodeLabel] = t.nodeName
+	return labels, true
+}
+
+// Stop stops watching the node
+func (t *NodeLabeler) Stop() {
+	close(t.stopChan)
+}
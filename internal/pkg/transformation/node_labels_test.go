@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+func newTestNode(name string, labels map[string]string) *v1.Node {
+	return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func TestNodeLabeler_Process(t *testing.T) {
+	gpuTemp := counters.Counter{
+		FieldID:   dcgm.DCGM_FI_DEV_GPU_TEMP,
+		FieldName: "DCGM_FI_DEV_GPU_TEMP",
+		PromType:  "gauge",
+	}
+
+	client := fake.NewSimpleClientset(
+		newTestNode("node1", map[string]string{
+			"topology.kubernetes.io/zone": "us-east-1a",
+			"nvidia.com/gpu.product":      "NVIDIA-H100-80GB-HBM3",
+			"kubernetes.io/os":            "linux",
+		}),
+		newTestNode("node2", map[string]string{"topology.kubernetes.io/zone": "us-east-1b"}),
+	)
+	labeler, err := NewNodeLabeler(client, "node1",
+		[]string{"topology.kubernetes.io/zone", "nvidia.com/gpu.product", "missing"}, appconfig.NodeLabelModeLabels)
+	require.NoError(t, err)
+	t.Cleanup(labeler.Stop)
+
+	expected := map[string]string{
+		"topology_kubernetes_io_zone": "us-east-1a",
+		"nvidia_com_gpu_product":      "NVIDIA-H100-80GB-HBM3",
+	}
+	require.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(expected, *labeler.labels.Load())
+	}, 5*time.Second, 10*time.Millisecond)
+
+	sharedLabels := map[string]string{"topology_kubernetes_io_zone": "pod-zone"}
+	metrics := collector.MetricsByCounter{
+		gpuTemp: {
+			{Counter: gpuTemp, GPU: "0", Value: "40"},
+			{Counter: gpuTemp, GPU: "1", Value: "41", Labels: sharedLabels},
+			{Counter: gpuTemp, GPU: "2", Value: "42", Attributes: map[string]string{"nvidia_com_gpu_product": "attr"}},
+		},
+	}
+	require.NoError(t, labeler.Process(metrics, nil))
+
+	assert.Equal(t, expected, metrics[gpuTemp][0].Labels)
+	assert.Equal(t, map[string]string{
+		"topology_kubernetes_io_zone": "pod-zone",
+		"nvidia_com_gpu_product":      "NVIDIA-H100-80GB-HBM3",
+	}, metrics[gpuTemp][1].Labels, "The labels of the metric take precedence")
+	assert.Equal(t, map[string]string{"topology_kubernetes_io_zone": "us-east-1a"}, metrics[gpuTemp][2].Labels,
+		"A label rendered from the attributes isn't duplicated")
+	assert.Len(t, sharedLabels, 1, "Labels shared between metrics must not be modified")
+
+	_, enabled := labeler.NodeInfo()
+	assert.False(t, enabled)
+
+	// The labels follow the updates of the node
+	_, err = client.CoreV1().Nodes().Update(context.Background(),
+		newTestNode("node1", map[string]string{"topology.kubernetes.io/zone": "us-east-1c"}), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(map[string]string{"topology_kubernetes_io_zone": "us-east-1c"},
+			*labeler.labels.Load())
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestNodeLabeler_NodeInfo(t *testing.T) {
+	client := fake.NewSimpleClientset(newTestNode("node1", map[string]string{"topology.kubernetes.io/zone": "eu-1"}))
+	labeler, err := NewNodeLabeler(client, "node1", []string{"topology.kubernetes.io/zone"}, appconfig.NodeLabelModeInfo)
+	require.NoError(t, err)
+	t.Cleanup(labeler.Stop)
+
+	require.Eventually(t, func() bool {
+		labels, _ := labeler.NodeInfo()
+		return len(labels) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	labels, enabled := labeler.NodeInfo()
+	assert.True(t, enabled)
+	assert.Equal(t, map[string]string{"node": "node1", "topology_kubernetes_io_zone": "eu-1"}, labels)
+
+	// The metrics are left untouched in the info mode
+	gpuTemp := counters.Counter{FieldID: dcgm.DCGM_FI_DEV_GPU_TEMP, FieldName: "DCGM_FI_DEV_GPU_TEMP"}
+	metrics := collector.MetricsByCounter{gpuTemp: {{Counter: gpuTemp, GPU: "0", Value: "40"}}}
+	require.NoError(t, labeler.Process(metrics, nil))
+	assert.Nil(t, metrics[gpuTemp][0].Labels)
+}
@@ -0,0 +1,244 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	stdos "os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/containerd/cgroups/v3"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+)
+
+// containerIDRegex matches the container ID at the end of a cgroup path: the systemd scopes of the
+// runtimes (docker-<id>.scope, cri-containerd-<id>.scope, crio-<id>.scope, libpod-<id>.scope) and the
+// cgroupfs directories (/docker/<id>, /kubepods/burstable/pod<uid>/<id>)
+var containerIDRegex = regexp.MustCompile(`(?:^|/)(?:(docker|cri-containerd|crio|libpod)-)?([0-9a-f]{64})(?:\.scope)?$`)
+
+// containerRuntimes maps the prefix of the systemd scope of a container to its runtime
+var containerRuntimes = map[string]string{
+	"docker":         containerRuntimeDocker,
+	"cri-containerd": containerRuntimeContainerd,
+	"crio":           containerRuntimeCRIO,
+	"libpod":         containerRuntimePodman,
+}
+
+// containerNameAnnotations are the OCI annotations the CRI runtimes store the container name in
+var containerNameAnnotations = []string{
+	"io.kubernetes.cri.container-name", // containerd
+	"io.kubernetes.container.name",     // cri-o
+}
+
+// processContainer is the container a process runs in
+type processContainer struct {
+	id         string
+	name       string
+	runtime    string
+	cgroupPath string
+}
+
+// ProcessMapper adds the container of the process to the per-process metrics, the ones with a pid label.
+// The container is derived from /proc/<pid>/cgroup, for cgroup v1 and the v2 unified hierarchy, so that the
+// GPU usage is attributed to containers that aren't mapped through the kubelet pod-resources API. The
+// container name is read from the runtime state when it is mounted in the exporter container.
+type ProcessMapper struct {
+	procRoot    string
+	bundleGlobs map[string][]string // Runtime -> globs of the OCI bundle config.json of a container, %s is the ID
+	dockerRoot  string
+
+	mtx        sync.Mutex
+	containers map[string]*processContainer // PID -> container, nil for a process outside of a container
+}
+
+func NewProcessMapper() *ProcessMapper {
+	return &ProcessMapper{
+		procRoot: "/proc",
+		bundleGlobs: map[string][]string{
+			containerRuntimeContainerd: {"/run/containerd/io.containerd.runtime.v2.task/*/%s/config.json"},
+			containerRuntimeCRIO:       {"/run/containers/storage/overlay-containers/%s/userdata/config.json"},
+		},
+		dockerRoot: "/var/lib/docker",
+		containers: map[string]*processContainer{},
+	}
+}
+
+func (t *ProcessMapper) Name() string {
+	return "ProcessMapper"
+}
+
+func (t *ProcessMapper) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	seen := map[string]*processContainer{}
+
+	for _, metricList := range metrics {
+		for i := range metricList {
+			pid, ok := metricList[i].Labels[pidLabel]
+			if !ok {
+				continue
+			}
+
+			container, resolved := seen[pid]
+			if !resolved {
+				container = t.container(pid)
+				seen[pid] = container
+			}
+			if container == nil {
+				continue
+			}
+
+			// Labels are shared between the metrics of a process
+			labels := make(map[string]string, len(metricList[i].Labels)+4)
+			maps.Copy(labels, metricList[i].Labels)
+			labels[containerIDLabel] = container.id
+			labels[cgroupPathLabel] = container.cgroupPath
+			if container.runtime != "" {
+				labels[containerRuntimeLabel] = container.runtime
+			}
+			if container.name != "" {
+				labels[processContainerLabel] = container.name
+			}
+			metricList[i].Labels = labels
+		}
+	}
+
+	// PIDs are reused, the containers of the processes that are gone are forgotten
+	t.containers = seen
+
+	return nil
+}
+
+// container returns the container of the process, using the one resolved at the previous collection
+func (t *ProcessMapper) container(pid string) *processContainer {
+	if container, ok := t.containers[pid]; ok {
+		return container
+	}
+
+	subsystems, unified, err := cgroups.ParseCgroupFileUnified(filepath.Join(t.procRoot, pid, "cgroup"))
+	if err != nil {
+		slog.Debug("Failed to read the cgroup of the process", "pid", pid, "error", err)
+		return nil
+	}
+
+	container := parseProcessContainer(subsystems, unified)
+	if container != nil {
+		container.name = t.containerName(container)
+	}
+	return container
+}
+
+// parseProcessContainer finds the container in the cgroup paths of a process. The unified hierarchy is
+// preferred, the cgroup v1 controllers are looked up in name order for stable results.
+func parseProcessContainer(subsystems map[string]string, unified string) *processContainer {
+	paths := []string{unified}
+	for _, subsystem := range slices.Sorted(maps.Keys(subsystems)) {
+		paths = append(paths, subsystems[subsystem])
+	}
+
+	for _, path := range paths {
+		matches := containerIDRegex.FindStringSubmatch(path)
+		if matches == nil {
+			continue
+		}
+		return &processContainer{
+			id:         matches[2],
+			runtime:    containerRuntimes[matches[1]],
+			cgroupPath: path,
+		}
+	}
+	return nil
+}
+
+// containerName reads the name of the container from the state of its runtime. The runtime of a cgroupfs
+// path is unknown, every runtime is tried then.
+func (t *ProcessMapper) containerName(container *processContainer) string {
+	runtimes := []string{container.runtime}
+	if container.runtime == "" {
+		runtimes = []string{containerRuntimeContainerd, containerRuntimeCRIO, containerRuntimeDocker}
+	}
+
+	for _, runtime := range runtimes {
+		var name string
+		if runtime == containerRuntimeDocker {
+			name = t.dockerContainerName(container.id)
+		} else {
+			name = t.bundleContainerName(runtime, container.id)
+		}
+		if name != "" {
+			if container.runtime == "" {
+				container.runtime = runtime
+			}
+			return name
+		}
+	}
+	return ""
+}
+
+// bundleContainerName reads the container name from the annotations of the OCI bundle of the container
+func (t *ProcessMapper) bundleContainerName(runtime, id string) string {
+	for _, pattern := range t.bundleGlobs[runtime] {
+		files, err := filepath.Glob(fmt.Sprintf(pattern, id))
+		if err != nil || len(files) == 0 {
+			continue
+		}
+
+		var spec struct {
+			Annotations map[string]string `json:"annotations"`
+		}
+		if err := readJSONFile(files[0], &spec); err != nil {
+			slog.Debug("Failed to read the OCI bundle of the container", "container", id, "error", err)
+			continue
+		}
+
+		for _, annotation := range containerNameAnnotations {
+			if name := spec.Annotations[annotation]; name != "" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// dockerContainerName reads the container name from the configuration of a Docker container
+func (t *ProcessMapper) dockerContainerName(id string) string {
+	var config struct {
+		Name string `json:"Name"`
+	}
+	if err := readJSONFile(filepath.Join(t.dockerRoot, "containers", id, "config.v2.json"), &config); err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(config.Name, "/")
+}
+
+func readJSONFile(path string, v any) error {
+	data, err := stdos.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
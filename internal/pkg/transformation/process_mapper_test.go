@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	stdos "os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+var (
+	containerdID = strings.Repeat("a1", 32)
+	crioID       = strings.Repeat("b2", 32)
+	dockerID     = strings.Repeat("c3", 32)
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, stdos.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, stdos.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestParseProcessContainer(t *testing.T) {
+	tests := []struct {
+		name       string
+		subsystems map[string]string
+		unified    string
+		want       *processContainer
+	}{
+		{
+			name: "cgroup v2 containerd systemd scope",
+			unified: "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1234.slice/" +
+				"cri-containerd-" + containerdID + ".scope",
+			want: &processContainer{id: containerdID, runtime: containerRuntimeContainerd},
+		},
+		{
+			name:    "cgroup v2 cri-o systemd scope",
+			unified: "/kubepods.slice/kubepods-pod1234.slice/crio-" + crioID + ".scope",
+			want:    &processContainer{id: crioID, runtime: containerRuntimeCRIO},
+		},
+		{
+			name: "cgroup v1 cgroupfs",
+			subsystems: map[string]string{
+				"cpu,cpuacct": "/kubepods/burstable/pod1234/" + dockerID,
+				"memory":      "/kubepods/burstable/pod1234/" + dockerID,
+			},
+			want: &processContainer{id: dockerID},
+		},
+		{
+			name:    "cri-o conmon is not a container",
+			unified: "/kubepods.slice/kubepods-pod1234.slice/crio-conmon-" + crioID + ".scope",
+		},
+		{
+			name:    "Process of the host",
+			unified: "/user.slice/user-1000.slice/session-1.scope",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseProcessContainer(tt.subsystems, tt.unified)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, tt.want.id, got.id)
+			assert.Equal(t, tt.want.runtime, got.runtime)
+			assert.Contains(t, got.cgroupPath, tt.want.id)
+		})
+	}
+}
+
+func TestProcessMapper_Process(t *testing.T) {
+	root := t.TempDir()
+
+	writeTestFile(t, filepath.Join(root, "proc", "1001", "cgroup"),
+		"0::/kubepods.slice/kubepods-pod1234.slice/cri-containerd-"+containerdID+".scope\n")
+	writeTestFile(t, filepath.Join(root, "proc", "1002", "cgroup"),
+		"4:memory:/docker/"+dockerID+"\n1:cpu,cpuacct:/docker/"+dockerID+"\n")
+	writeTestFile(t, filepath.Join(root, "proc", "1003", "cgroup"), "0::/user.slice/session-1.scope\n")
+
+	writeTestFile(t, filepath.Join(root, "containerd", "k8s.io", containerdID, "config.json"),
+		`{"annotations":{"io.kubernetes.cri.container-name":"trainer"}}`)
+	writeTestFile(t, filepath.Join(root, "docker", "containers", dockerID, "config.v2.json"),
+		`{"Name":"/inference"}`)
+
+	mapper := NewProcessMapper()
+	mapper.procRoot = filepath.Join(root, "proc")
+	mapper.bundleGlobs = map[string][]string{
+		containerRuntimeContainerd: {filepath.Join(root, "containerd", "*", "%s", "config.json")},
+	}
+	mapper.dockerRoot = filepath.Join(root, "docker")
+
+	smUtil := counters.Counter{FieldName: counters.DCGMExpProcessSMUtil, PromType: "gauge"}
+	gpuTemp := counters.Counter{FieldName: "DCGM_FI_DEV_GPU_TEMP", PromType: "gauge"}
+
+	sharedLabels := map[string]string{pidLabel: "1001"}
+	metrics := collector.MetricsByCounter{
+		smUtil: {
+			{Counter: smUtil, GPU: "0", Value: "80", Labels: sharedLabels},
+			{Counter: smUtil, GPU: "0", Value: "10", Labels: map[string]string{pidLabel: "1002"}},
+			{Counter: smUtil, GPU: "0", Value: "5", Labels: map[string]string{pidLabel: "1003"}},
+			{Counter: smUtil, GPU: "0", Value: "5", Labels: map[string]string{pidLabel: "1004"}},
+		},
+		gpuTemp: {{Counter: gpuTemp, GPU: "0", Value: "40", Labels: map[string]string{}}},
+	}
+
+	require.NoError(t, mapper.Process(metrics, nil))
+
+	assert.Equal(t, map[string]string{
+		pidLabel:              "1001",
+		containerIDLabel:      containerdID,
+		containerRuntimeLabel: containerRuntimeContainerd,
+		processContainerLabel: "trainer",
+		cgroupPathLabel:       "/kubepods.slice/kubepods-pod1234.slice/cri-containerd-" + containerdID + ".scope",
+	}, metrics[smUtil][0].Labels)
+	assert.Len(t, sharedLabels, 1, "Labels shared between metrics shouldn't be modified")
+
+	// The runtime of a cgroupfs path is found from its state
+	assert.Equal(t, map[string]string{
+		pidLabel:              "1002",
+		containerIDLabel:      dockerID,
+		containerRuntimeLabel: containerRuntimeDocker,
+		processContainerLabel: "inference",
+		cgroupPathLabel:       "/docker/" + dockerID,
+	}, metrics[smUtil][1].Labels)
+
+	// Processes outside of containers, or gone, aren't labeled
+	assert.Equal(t, map[string]string{pidLabel: "1003"}, metrics[smUtil][2].Labels)
+	assert.Equal(t, map[string]string{pidLabel: "1004"}, metrics[smUtil][3].Labels)
+	assert.Empty(t, metrics[gpuTemp][0].Labels)
+
+	assert.Len(t, mapper.containers, 4)
+	require.NoError(t, mapper.Process(collector.MetricsByCounter{}, nil))
+	assert.Empty(t, mapper.containers, "The containers of the processes that are gone should be forgotten")
+}
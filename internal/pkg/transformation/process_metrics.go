@@ -112,6 +112,7 @@ type PIDMapper interface {
 type perProcessCollector struct {
 	client    nvmlprovider.NVML
 	pidMapper PIDMapper
+	giFormat  appconfig.GPUInstanceIDFormat
 }
 
 func getMIGMetricsKey(parentUUID string, gpuInstanceID string) string {
@@ -137,7 +138,8 @@ func (c *perProcessCollector) processMIGEnabledGPU(
 
 	for _, instance := range gpu.GPUInstances {
 		gpuInstanceID := instance.Info.NvmlInstanceId
-		migDeviceID := fmt.Sprintf("%d-%d", gpuIndex, gpuInstanceID)
+		migDeviceID := deviceinfo.FormatGPUInstanceIdentifier(c.giFormat, fmt.Sprint(gpuIndex), gpuUUID,
+			fmt.Sprint(gpuInstanceID))
 		podInfos := deviceToPods[migDeviceID]
 
 		if len(podInfos) == 0 {
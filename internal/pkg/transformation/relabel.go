@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/relabel"
+)
+
+// Relabeler applies the rules of the relabel configuration file, which are reloaded when the file changes.
+type Relabeler struct{}
+
+func NewRelabeler() *Relabeler {
+	return &Relabeler{}
+}
+
+func (t *Relabeler) Name() string {
+	return "Relabeler"
+}
+
+func (t *Relabeler) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	relabel.Apply(metrics)
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	stdos "os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+)
+
+// StableGPUSlots replaces the index in the gpu label with a slot assigned to the UUID of the GPU.
+// The indices can shuffle after a reboot or a bind/unbind while the UUIDs stay the same, the slots
+// are persisted to a file so that the series of a GPU stay continuous on the dashboards.
+type StableGPUSlots struct {
+	path string
+
+	mu    sync.Mutex
+	slots map[string]int // GPU UUID -> slot
+}
+
+// gpuSlotsFile is the content of the file persisting the slots
+type gpuSlotsFile struct {
+	Slots map[string]int `json:"slots"`
+}
+
+func NewStableGPUSlots(path string) *StableGPUSlots {
+	t := &StableGPUSlots{path: path, slots: map[string]int{}}
+
+	slots, err := readGPUSlots(path)
+	if err != nil {
+		slog.Warn("Failed to read the GPU slots, the slots are assigned again",
+			slog.String("file", path),
+			slog.String(logging.ErrorKey, err.Error()))
+	} else if slots != nil {
+		t.slots = slots
+	}
+
+	return t
+}
+
+func (t *StableGPUSlots) Name() string {
+	return "StableGPUSlots"
+}
+
+func (t *StableGPUSlots) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	assigned := false
+	for _, metricList := range metrics {
+		for i := range metricList {
+			m := &metricList[i]
+			if m.GPUUUID == "" || m.GPU == "" {
+				continue
+			}
+
+			slot, exists := t.slots[m.GPUUUID]
+			if !exists {
+				slot = t.assign(m.GPUUUID, m.GPU)
+				assigned = true
+			}
+			m.GPU = strconv.Itoa(slot)
+		}
+	}
+
+	if assigned {
+		if err := writeGPUSlots(t.path, t.slots); err != nil {
+			return fmt.Errorf("failed to persist the GPU slots: %w", err)
+		}
+	}
+	return nil
+}
+
+// assign assigns a slot to a GPU seen for the first time: its index if no other GPU has it,
+// so that the slots match the indices on a new node, or the lowest free slot otherwise.
+func (t *StableGPUSlots) assign(uuid, gpu string) int {
+	used := make(map[int]bool, len(t.slots))
+	for _, slot := range t.slots {
+		used[slot] = true
+	}
+
+	slot := 0
+	if index, err := strconv.Atoi(gpu); err == nil && index >= 0 && !used[index] {
+		slot = index
+	} else {
+		for used[slot] {
+			slot++
+		}
+	}
+
+	t.slots[uuid] = slot
+	slog.Info("Assigned a stable slot to the GPU",
+		slog.String("uuid", uuid),
+		slog.String("gpu", gpu),
+		slog.Int("slot", slot))
+	return slot
+}
+
+// readGPUSlots reads the slots persisted at path, or returns nil if the file doesn't exist yet
+func readGPUSlots(path string) (map[string]int, error) {
+	data, err := stdos.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file gpuSlotsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	return file.Slots, nil
+}
+
+// writeGPUSlots persists the slots at path, through a temporary file so that a crash doesn't leave
+// a truncated file behind
+func writeGPUSlots(path string, slots map[string]int) error {
+	data, err := json.MarshalIndent(gpuSlotsFile{Slots: slots}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := stdos.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err := stdos.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return stdos.Rename(tmp, path)
+}
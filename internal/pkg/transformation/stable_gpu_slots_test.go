@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	stdos "os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+func TestStableGPUSlots_Process(t *testing.T) {
+	gpuTemp := counters.Counter{
+		FieldID:   dcgm.DCGM_FI_DEV_GPU_TEMP,
+		FieldName: "DCGM_FI_DEV_GPU_TEMP",
+		PromType:  "gauge",
+	}
+	newMetrics := func(uuids ...string) collector.MetricsByCounter {
+		metrics := collector.MetricsByCounter{}
+		for i, uuid := range uuids {
+			metrics[gpuTemp] = append(metrics[gpuTemp], collector.Metric{
+				Counter: gpuTemp, GPU: strconv.Itoa(i), GPUUUID: uuid, Value: "40",
+			})
+		}
+		return metrics
+	}
+	gpuLabels := func(metrics collector.MetricsByCounter) []string {
+		var gpus []string
+		for _, m := range metrics[gpuTemp] {
+			gpus = append(gpus, m.GPU)
+		}
+		return gpus
+	}
+
+	path := filepath.Join(t.TempDir(), "dcgm-exporter", "gpu-slots.json")
+
+	t.Run("The slots match the indices on a new node", func(t *testing.T) {
+		metrics := newMetrics("GPU-a", "GPU-b")
+		require.NoError(t, NewStableGPUSlots(path).Process(metrics, nil))
+		assert.Equal(t, []string{"0", "1"}, gpuLabels(metrics))
+		assert.FileExists(t, path)
+	})
+
+	t.Run("The slots survive a re-enumeration", func(t *testing.T) {
+		metrics := newMetrics("GPU-b", "GPU-a")
+		require.NoError(t, NewStableGPUSlots(path).Process(metrics, nil))
+		assert.Equal(t, []string{"1", "0"}, gpuLabels(metrics))
+	})
+
+	t.Run("A new GPU gets the lowest free slot when its index is taken", func(t *testing.T) {
+		metrics := newMetrics("GPU-c", "GPU-a")
+		require.NoError(t, NewStableGPUSlots(path).Process(metrics, nil))
+		assert.Equal(t, []string{"2", "0"}, gpuLabels(metrics))
+	})
+
+	t.Run("Metrics without GPU are unchanged", func(t *testing.T) {
+		metrics := collector.MetricsByCounter{
+			gpuTemp: {{Counter: gpuTemp, NvSwitch: "0", Value: "40"}},
+		}
+		require.NoError(t, NewStableGPUSlots(path).Process(metrics, nil))
+		assert.Empty(t, metrics[gpuTemp][0].GPU)
+	})
+
+	t.Run("A corrupted file is replaced", func(t *testing.T) {
+		require.NoError(t, stdos.WriteFile(path, []byte("{"), 0o644))
+
+		metrics := newMetrics("GPU-b")
+		require.NoError(t, NewStableGPUSlots(path).Process(metrics, nil))
+		assert.Equal(t, []string{"0"}, gpuLabels(metrics))
+
+		slots, err := readGPUSlots(path)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"GPU-b": 0}, slots)
+	})
+}
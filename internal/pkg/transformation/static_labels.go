@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"maps"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+)
+
+// StaticLabeler adds the extra labels of the configuration, e.g. cluster or region, to every metric, so that
+// they don't require relabeling at scrape time. A label already set on a metric, e.g. by the pod mapper,
+// takes precedence over the static one.
+type StaticLabeler struct {
+	labels map[string]string
+}
+
+func NewStaticLabeler(labels map[string]string) *StaticLabeler {
+	return &StaticLabeler{labels: labels}
+}
+
+func (t *StaticLabeler) Name() string {
+	return "StaticLabeler"
+}
+
+func (t *StaticLabeler) Process(metrics collector.MetricsByCounter, _ deviceinfo.Provider) error {
+	for _, metricList := range metrics {
+		for i := range metricList {
+			// Labels may be shared between metrics of a collector
+			labels := make(map[string]string, len(metricList[i].Labels)+len(t.labels))
+			maps.Copy(labels, t.labels)
+			maps.Copy(labels, metricList[i].Labels)
+			for name := range metricList[i].Attributes {
+				delete(labels, name)
+			}
+			metricList[i].Labels = labels
+		}
+	}
+	return nil
+}
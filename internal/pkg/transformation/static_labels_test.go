@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transformation
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+)
+
+func TestStaticLabeler_Process(t *testing.T) {
+	gpuTemp := counters.Counter{
+		FieldID:   dcgm.DCGM_FI_DEV_GPU_TEMP,
+		FieldName: "DCGM_FI_DEV_GPU_TEMP",
+		PromType:  "gauge",
+	}
+
+	sharedLabels := map[string]string{"window_size_in_ms": "60000", "region": "eu-west"}
+	metrics := collector.MetricsByCounter{
+		gpuTemp: {
+			{Counter: gpuTemp, GPU: "0", Value: "40"},
+			{Counter: gpuTemp, GPU: "1", Value: "41", Labels: sharedLabels},
+			{Counter: gpuTemp, GPU: "2", Value: "42", Attributes: map[string]string{"cluster": "attr"}},
+		},
+	}
+
+	labeler := NewStaticLabeler(map[string]string{"cluster": "prod", "region": "us-east"})
+	require.NoError(t, labeler.Process(metrics, nil))
+
+	assert.Equal(t, map[string]string{"cluster": "prod", "region": "us-east"}, metrics[gpuTemp][0].Labels)
+	assert.Equal(t, map[string]string{"cluster": "prod", "region": "eu-west", "window_size_in_ms": "60000"},
+		metrics[gpuTemp][1].Labels, "The labels of the metric take precedence")
+	assert.Equal(t, map[string]string{"region": "us-east"}, metrics[gpuTemp][2].Labels,
+		"A label rendered from the attributes isn't duplicated")
+	assert.NotContains(t, sharedLabels, "cluster", "Labels shared between metrics must not be modified")
+}
@@ -17,16 +17,48 @@
 package transformation
 
 import (
+	"log/slog"
+	"time"
+
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/hostname"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
 )
 
 // GetTransformations return list of transformation applicable for metrics
 func GetTransformations(c *appconfig.Config) []Transform {
 	var transformations []Transform
 
+	// EventRecorder runs first, so it observes the values as collected, before any transformation drops them.
+	if c.EventLogSize > 0 || c.JournalEvents {
+		transformations = append(transformations, NewEventRecorder())
+	}
+
 	// WeightedUtil derives DCGM_FI_DEV_WEIGHTED_GPU_UTIL for MIG and non-MIG devices.
 	transformations = append(transformations, NewWeightedUtil())
 
+	// MIGFragmentation derives the DCGM_EXP_MIG_* free slices and fragmentation of the MIG enabled GPUs.
+	if c.MIGFragmentationMetrics {
+		transformations = append(transformations, NewMIGFragmentation())
+	}
+
+	// ClockThrottleDuration derives DCGM_EXP_CLOCK_THROTTLE_DURATION_SECONDS from the clock event reasons.
+	transformations = append(transformations, NewClockThrottleDuration())
+
+	// ProcessMapper adds the container labels to the per-process metrics.
+	transformations = append(transformations, NewProcessMapper())
+
+	// CounterDelta runs before the mappers, so the derived gauges get the same pod and job labels.
+	if c.EnableCounterDeltas || len(c.DerivedCounters) > 0 {
+		interval := time.Duration(c.CollectInterval) * time.Millisecond
+		transformations = append(transformations, NewCounterDelta(interval, c.EnableCounterDeltas, c.DerivedCounters))
+	}
+
+	// IdleMetricsSuppressor runs before the mappers, which then don't label the dropped series.
+	if len(c.SuppressIdleMetrics) > 0 {
+		transformations = append(transformations, NewIdleMetricsSuppressor(c.SuppressIdleMetrics))
+	}
+
 	if c.Kubernetes {
 		podMapper := NewPodMapper(c)
 		transformations = append(transformations, podMapper)
@@ -37,5 +69,82 @@ func GetTransformations(c *appconfig.Config) []Transform {
 		transformations = append(transformations, hpcMapper)
 	}
 
+	if c.InstanceFQDNLabel {
+		fqdn, err := hostname.GetFQDN(c)
+		if err != nil {
+			slog.Warn("Failed to get the FQDN of the host, the instance_fqdn label will not be available",
+				slog.String(logging.ErrorKey, err.Error()))
+		} else {
+			slog.Info("Adding the instance_fqdn label", slog.String("fqdn", fqdn))
+			transformations = append(transformations, NewInstanceFQDNLabeler(fqdn))
+		}
+	}
+
+	if c.GPUInstanceIDFormat != "" {
+		transformations = append(transformations, NewGPUInstanceLabeler(c.GPUInstanceIDFormat))
+	}
+
+	// StableGPUSlots runs after the mappers, which look the GPUs up by index.
+	if c.GPUSlotsFile != "" {
+		transformations = append(transformations, NewStableGPUSlots(c.GPUSlotsFile))
+	}
+
+	// NodeLabeler runs after the mappers, whose labels take precedence over the node labels.
+	if len(c.NodeLabels) > 0 {
+		nodeLabeler, err := newInClusterNodeLabeler(c)
+		if err != nil {
+			slog.Warn("Failed to watch the Kubernetes node, the node labels will not be available",
+				slog.String(logging.ErrorKey, err.Error()))
+		} else {
+			slog.Info("Exporting the Kubernetes node labels", slog.Any("labels", c.NodeLabels),
+				slog.String("mode", string(c.NodeLabelsMode)))
+			transformations = append(transformations, nodeLabeler)
+		}
+	}
+
+	// StaticLabeler runs after the mappers, whose labels take precedence over the static ones.
+	if len(c.ExtraLabels) > 0 {
+		transformations = append(transformations, NewStaticLabeler(c.ExtraLabels))
+	}
+
+	// FieldIDLabeler runs before the Relabeler and NameMigration, which rename the families, so it sees the DCGM
+	// field names.
+	if c.FieldIDMode != "" {
+		transformations = append(transformations, NewFieldIDLabeler(c.FieldIDMode))
+	}
+
+	// Maintenance runs after the labelers, so a static label can't override its label, and before the Relabeler
+	// and NameMigration, whose rules then see the label and the suppressed families are matched by field name.
+	if c.MaintenanceAPI {
+		transformations = append(transformations, NewMaintenance(c.MaintenanceDropFamilies))
+	}
+
+	// Relabeler runs after the labelers, so its rules can copy and rename their labels.
+	if c.RelabelConfigFile != "" {
+		transformations = append(transformations, NewRelabeler())
+	}
+
+	// DeviceLabelsInfo runs after the Relabeler, whose rules may read the label counters, and before
+	// MIGFamilySplit, which then splits the lean families.
+	if c.DeviceLabelsInfo {
+		transformations = append(transformations, NewDeviceLabelsInfo())
+	}
+
+	// DualNamespace runs after the Relabeler, whose rules see the labels of the selected namespace, and before
+	// NameMigration, which then renames the families in both namespaces.
+	if c.DualNamespace {
+		transformations = append(transformations, NewDualNamespace(c.UseOldNamespace, c.DualNamespaceFamilies))
+	}
+
+	// NameMigration runs before MIGFamilySplit, which then splits the families under both names.
+	if len(c.MetricNameMigrations) > 0 {
+		transformations = append(transformations, NewNameMigration(c.MetricNameMigrations))
+	}
+
+	// MIGFamilySplit runs last, so it also splits the families derived by the other transformations.
+	if c.SplitMIGMetrics {
+		transformations = append(transformations, NewMIGFamilySplit())
+	}
+
 	return transformations
 }
@@ -35,10 +35,13 @@ func TestGetTransformations(t *testing.T) {
 			config: &appconfig.Config{
 				Kubernetes: false,
 			},
-			// WeightedUtil is always registered, so even the bare environment has one transform.
+			// WeightedUtil, ClockThrottleDuration and ProcessMapper are always registered,
+			// so even the bare environment has three transforms.
 			assert: func(t *testing.T, transforms []Transform) {
-				assert.Len(t, transforms, 1)
+				assert.Len(t, transforms, 3)
 				assert.Equal(t, "WeightedUtil", transforms[0].Name())
+				assert.Equal(t, "ClockThrottleDuration", transforms[1].Name())
+				assert.Equal(t, "ProcessMapper", transforms[2].Name())
 			},
 		},
 		{
@@ -46,9 +49,9 @@ func TestGetTransformations(t *testing.T) {
 			config: &appconfig.Config{
 				Kubernetes: true,
 			},
-			// WeightedUtil + PodMapper
+			// WeightedUtil + ClockThrottleDuration + ProcessMapper + PodMapper
 			assert: func(t *testing.T, transforms []Transform) {
-				assert.Len(t, transforms, 2)
+				assert.Len(t, transforms, 4)
 			},
 		},
 		{
@@ -56,9 +59,162 @@ func TestGetTransformations(t *testing.T) {
 			config: &appconfig.Config{
 				HPCJobMappingDir: "/var/run/nvidia/slurm",
 			},
-			// WeightedUtil + HPCMapper
+			// WeightedUtil + ClockThrottleDuration + ProcessMapper + HPCMapper
 			assert: func(t *testing.T, transforms []Transform) {
-				assert.Len(t, transforms, 2)
+				assert.Len(t, transforms, 4)
+			},
+		},
+		{
+			name: "Counter deltas are enabled",
+			config: &appconfig.Config{
+				EnableCounterDeltas: true,
+				CollectInterval:     30000,
+			},
+			// WeightedUtil + ClockThrottleDuration + ProcessMapper + CounterDelta
+			assert: func(t *testing.T, transforms []Transform) {
+				assert.Len(t, transforms, 4)
+				assert.Equal(t, "CounterDelta", transforms[3].Name())
+			},
+		},
+		{
+			name: "MIG metrics are split",
+			config: &appconfig.Config{
+				Kubernetes:      true,
+				SplitMIGMetrics: true,
+			},
+			// WeightedUtil + ClockThrottleDuration + ProcessMapper + PodMapper +
+			// MIGFamilySplit
+			assert: func(t *testing.T, transforms []Transform) {
+				assert.Len(t, transforms, 5)
+				assert.Equal(t, "MIGFamilySplit", transforms[4].Name())
+			},
+		},
+		{
+			name: "Idle GPU metrics are suppressed",
+			config: &appconfig.Config{
+				Kubernetes:          true,
+				SuppressIdleMetrics: []string{"DCGM_FI_PROF_PIPE_TENSOR_ACTIVE"},
+			},
+			// WeightedUtil + ClockThrottleDuration + ProcessMapper +
+			// IdleMetricsSuppressor + PodMapper
+			assert: func(t *testing.T, transforms []Transform) {
+				assert.Len(t, transforms, 5)
+				assert.Equal(t, "IdleMetricsSuppressor", transforms[3].Name())
+			},
+		},
+		{
+			name: "The instance_fqdn label is enabled",
+			config: &appconfig.Config{
+				InstanceFQDNLabel: true,
+			},
+			// WeightedUtil + ClockThrottleDuration + ProcessMapper +
+			// InstanceFQDNLabeler
+			assert: func(t *testing.T, transforms []Transform) {
+				assert.Len(t, transforms, 4)
+				assert.Equal(t, "InstanceFQDNLabeler", transforms[3].Name())
+			},
+		},
+		{
+			name: "Metric names are migrated",
+			config: &appconfig.Config{
+				SplitMIGMetrics: true,
+				MetricNameMigrations: []appconfig.MetricNameMigration{
+					{OldName: "DCGM_FI_DEV_WEIGHTED_GPU_UTIL", NewName: "DCGM_EXP_WEIGHTED_GPU_UTIL"},
+				},
+			},
+			// WeightedUtil + ClockThrottleDuration + ProcessMapper + NameMigration +
+			// MIGFamilySplit
+			assert: func(t *testing.T, transforms []Transform) {
+				assert.Len(t, transforms, 5)
+				assert.Equal(t, "NameMigration", transforms[3].Name())
+			},
+		},
+		{
+			name: "The label counters are exported as info metrics",
+			config: &appconfig.Config{
+				DeviceLabelsInfo: true,
+				SplitMIGMetrics:  true,
+			},
+			// WeightedUtil + ClockThrottleDuration + ProcessMapper +
+			// DeviceLabelsInfo + MIGFamilySplit
+			assert: func(t *testing.T, transforms []Transform) {
+				assert.Len(t, transforms, 5)
+				assert.Equal(t, "DeviceLabelsInfo", transforms[3].Name())
+			},
+		},
+		{
+			name: "Both namespaces are exported",
+			config: &appconfig.Config{
+				DualNamespace: true,
+				MetricNameMigrations: []appconfig.MetricNameMigration{
+					{OldName: "DCGM_FI_DEV_GPU_UTIL", NewName: "DCGM_EXP_GPU_UTIL"},
+				},
+			},
+			// WeightedUtil + ClockThrottleDuration + ProcessMapper + DualNamespace +
+			// NameMigration
+			assert: func(t *testing.T, transforms []Transform) {
+				assert.Len(t, transforms, 5)
+				assert.Equal(t, "DualNamespace", transforms[3].Name())
+			},
+		},
+		{
+			name: "The field IDs are exported",
+			config: &appconfig.Config{
+				FieldIDMode:       appconfig.FieldIDModeInfo,
+				RelabelConfigFile: "relabel.yaml",
+			},
+			// WeightedUtil + ClockThrottleDuration + ProcessMapper + FieldIDLabeler +
+			// Relabeler
+			assert: func(t *testing.T, transforms []Transform) {
+				assert.Len(t, transforms, 5)
+				assert.Equal(t, "FieldIDLabeler", transforms[3].Name())
+				assert.Equal(t, "Relabeler", transforms[4].Name())
+			},
+		},
+		{
+			name: "The event log is enabled",
+			config: &appconfig.Config{
+				EventLogSize: 100,
+			},
+			// EventRecorder + WeightedUtil + ClockThrottleDuration + ProcessMapper
+			assert: func(t *testing.T, transforms []Transform) {
+				assert.Len(t, transforms, 4)
+				assert.Equal(t, "EventRecorder", transforms[0].Name())
+			},
+		},
+		{
+			name: "The events are written to the journal without the event log",
+			config: &appconfig.Config{
+				JournalEvents: true,
+			},
+			// EventRecorder + WeightedUtil + ClockThrottleDuration + ProcessMapper
+			assert: func(t *testing.T, transforms []Transform) {
+				assert.Len(t, transforms, 4)
+				assert.Equal(t, "EventRecorder", transforms[0].Name())
+			},
+		},
+		{
+			name: "The maintenance mode is enabled",
+			config: &appconfig.Config{
+				MaintenanceAPI: true,
+				ExtraLabels:    map[string]string{"cluster": "a"},
+			},
+			// WeightedUtil + ClockThrottleDuration + ProcessMapper + StaticLabeler +
+			// Maintenance
+			assert: func(t *testing.T, transforms []Transform) {
+				assert.Len(t, transforms, 5)
+				assert.Equal(t, "Maintenance", transforms[4].Name())
+			},
+		},
+		{
+			name: "The MIG fragmentation metrics are enabled",
+			config: &appconfig.Config{
+				MIGFragmentationMetrics: true,
+			},
+			// WeightedUtil + MIGFragmentation + ClockThrottleDuration + ProcessMapper
+			assert: func(t *testing.T, transforms []Transform) {
+				assert.Len(t, transforms, 4)
+				assert.Equal(t, "MIGFragmentation", transforms[1].Name())
 			},
 		},
 	}
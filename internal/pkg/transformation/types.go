@@ -21,15 +21,19 @@ import (
 	"context"
 	"regexp"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/identity"
 )
 
 //go:generate go run -v go.uber.org/mock/mockgen  -destination=../../mocks/pkg/transformations/mock_transformer.go -package=transformation -copyright_file=../../../hack/header.txt . Transform
@@ -44,10 +48,20 @@ type PodMapper struct {
 	Client               kubernetes.Interface
 	ResourceSliceManager *DRAResourceSliceManager
 	labelFilterCache     *LabelFilterCache
+	annotationCache      *LabelFilterCache // nil or disabled when no annotation is allowlisted
 	podInformerFactory   informers.SharedInformerFactory
 	podLister            corev1listers.PodLister
 	podInformerSynced    cache.InformerSynced
+	replicaSetFactory    informers.SharedInformerFactory // nil unless the workload labels are enabled
+	replicaSetLister     appsv1listers.ReplicaSetLister
+	replicaSetSynced     cache.InformerSynced
 	stopChan             chan struct{}
+	skippedPodsMu        sync.Mutex
+	skippedPods          map[string]uint64 // skip reason -> number of pods skipped in mappings
+	kubeletSocketUp      atomic.Bool       // whether the last pod-resources List succeeded
+	lastPodResourcesList atomic.Int64      // unix nano time of the last successful pod-resources List
+	mergedDuplicates     atomic.Uint64     // series mapped to a container by both the virtual GPU and DRA mappers
+	identityResolver     identity.Resolver // nil to resolve the MIG devices without a cache, see deviceIdentity
 }
 
 // LabelFilterCache provides efficient caching for label filtering decisions
@@ -72,17 +86,38 @@ type PodInfo struct {
 	Container        string
 	UID              string
 	VGPU             string
+	VGPUMemory       string // Device memory allocated by the HAMi or Volcano vGPU scheduler (in MiB)
+	VGPUCores        string // Share of the GPU cores allocated by the HAMi or Volcano vGPU scheduler (in %)
+	WorkloadKind     string // Kind of the workload owning the pod, e.g. Deployment; empty without workload
+	WorkloadName     string
 	Labels           map[string]string
 	DynamicResources *DynamicResourceInfo
 }
 
 type DRAResourceSliceManager struct {
+	client        kubernetes.Interface
 	factory       informers.SharedInformerFactory
 	informer      cache.SharedIndexInformer
 	cancelContext context.CancelFunc
 	mu            sync.RWMutex
 	deviceToUUID  map[string]string            // pool/device -> UUID (for full GPUs)
 	migDevices    map[string]*DRAMigDeviceInfo // pool/device -> MIG info (for MIG devices)
+	nodeName      string                       // pool of the CDI devices reported without pool
+	resyncPending atomic.Bool                  // set by RequestResync, consumed by the next lookup
+	lastResync    atomic.Int64                 // unix nano time of the last resync
+
+	sliceUpdates      atomic.Uint64 // ResourceSlice add, update and delete events processed
+	unresolvedLookups atomic.Uint64 // pool/device lookups without a known device
+	mappingCount      atomic.Uint64 // device to pod mappings built
+	mappingDuration   atomic.Int64  // total duration of the device to pod mappings in nanoseconds
+}
+
+// DRAResourceSliceStats holds the counters of a DRAResourceSliceManager
+type DRAResourceSliceStats struct {
+	SliceUpdates      uint64
+	UnresolvedLookups uint64
+	MappingCount      uint64
+	MappingDuration   time.Duration
 }
 
 // PodMetadata holds pod metadata from API server
@@ -278,6 +278,11 @@ func (t *WeightedUtil) computeMIG(metrics collector.MetricsByCounter) []collecto
 			continue
 		}
 
+		// Compute instances share the slices of their GPU instance, which is already counted
+		if m.ComputeInstanceID != "" {
+			continue
+		}
+
 		// Parse Slice count from MigProfile
 		slices := t.getSlicesFromProfile(m.MigProfile)
 		if slices == 0.0 {
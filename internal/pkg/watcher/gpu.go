@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
@@ -44,10 +45,111 @@ func NewGPUBindUnbindWatcher(opts ...GPUBindUnbindWatcherOption) *GPUBindUnbindW
 	return w
 }
 
+// GPUChange describes a GPU bind/unbind event and the GPUs affected by it
+type GPUChange struct {
+	// Completed is false while DCGM is reinitializing and true once reinitialization has completed
+	Completed bool
+	// Resolved reports whether the affected GPUs could be determined
+	Resolved bool
+	// GPUs holds the DCGM IDs of the GPUs that appeared, disappeared or were replaced since the last
+	// completed reinitialization. Only valid when Resolved is true
+	GPUs []uint
+}
+
 // Watch starts monitoring GPU bind/unbind events and calls onChange when detected
 // It blocks until the context is cancelled
 // onChange is called for any GPU topology change (bind or unbind)
 func (w *GPUBindUnbindWatcher) Watch(ctx context.Context, onChange func()) error {
+	return w.watch(ctx, func(int64) {
+		onChange()
+	})
+}
+
+// WatchGPUChanges works like Watch, but also reports which GPUs changed
+// On every event the GPUs known to DCGM are compared with the GPUs known after the last completed
+// reinitialization; if they cannot be read, the change is reported as not resolved and the caller
+// must assume that any GPU may have changed
+func (w *GPUBindUnbindWatcher) WatchGPUChanges(ctx context.Context, onChange func(GPUChange)) error {
+	known, err := gpuIdentities()
+	resolvable := err == nil
+	if err != nil {
+		slog.Warn("Failed to read GPU identities - GPU changes will not be localized",
+			slog.String("error", err.Error()))
+	}
+
+	return w.watch(ctx, func(eventValue int64) {
+		change := GPUChange{
+			Completed: eventValue == int64(dcgm.DcgmBUEventStateSystemReinitializationCompleted),
+		}
+
+		current, err := gpuIdentities()
+		if err != nil {
+			slog.Warn("Failed to read GPU identities after bind/unbind event",
+				slog.String("error", err.Error()))
+			if change.Completed {
+				resolvable = false
+			}
+			onChange(change)
+			return
+		}
+
+		if resolvable {
+			change.Resolved = true
+			change.GPUs = changedGPUs(known, current)
+		}
+		if change.Completed {
+			known, resolvable = current, true
+		}
+
+		slog.Debug("Resolved GPUs affected by bind/unbind event",
+			slog.Bool("resolved", change.Resolved),
+			slog.Any("gpus", change.GPUs))
+		onChange(change)
+	})
+}
+
+// gpuIdentities returns the UUID of every GPU known to DCGM by GPU ID
+// A GPU whose information cannot be read is treated as absent
+func gpuIdentities() (map[uint]string, error) {
+	count, err := dcgmprovider.Client().GetAllDeviceCount()
+	if err != nil {
+		return nil, err
+	}
+
+	identities := make(map[uint]string, count)
+	for i := uint(0); i < count; i++ {
+		info, err := dcgmprovider.Client().GetDeviceInfo(i)
+		if err != nil {
+			slog.Debug("Failed to read GPU information - treating GPU as absent",
+				slog.Uint64("gpu", uint64(i)),
+				slog.String("error", err.Error()))
+			continue
+		}
+		identities[i] = info.UUID
+	}
+
+	return identities, nil
+}
+
+// changedGPUs returns the sorted IDs of the GPUs that are only in one of the sets or have a different UUID
+func changedGPUs(before, after map[uint]string) []uint {
+	var changed []uint
+	for id, uuid := range before {
+		if afterUUID, exists := after[id]; !exists || afterUUID != uuid {
+			changed = append(changed, id)
+		}
+	}
+	for id := range after {
+		if _, exists := before[id]; !exists {
+			changed = append(changed, id)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// watch polls DCGM_FI_BIND_UNBIND_EVENT and calls onEvent with the event state of every new event
+func (w *GPUBindUnbindWatcher) watch(ctx context.Context, onEvent func(eventValue int64)) error {
 	slog.Info("Watching for GPU bind/unbind events",
 		slog.Duration("poll_interval", w.pollInterval))
 
@@ -168,13 +270,13 @@ func (w *GPUBindUnbindWatcher) Watch(ctx context.Context, onChange func()) error
 					slog.Info("GPU unbind event detected (system reinitializing)",
 						slog.Int64("event_state", eventValue),
 						slog.Int64("timestamp", eventTS))
-					onChange()
+					onEvent(eventValue)
 					// Continue watching for more events
 				} else if eventValue == int64(dcgm.DcgmBUEventStateSystemReinitializationCompleted) {
 					slog.Info("GPU bind event detected (reinitialization completed)",
 						slog.Int64("event_state", eventValue),
 						slog.Int64("timestamp", eventTS))
-					onChange()
+					onEvent(eventValue)
 					// Continue watching for more events
 				}
 			}
@@ -598,3 +598,137 @@ func TestGPUBindUnbindWatcher_Watch_NoEventsAvailable(t *testing.T) {
 	// Should return context error (deadline exceeded or canceled)
 	require.Error(t, err)
 }
+
+func TestChangedGPUs(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   map[uint]string
+		after    map[uint]string
+		expected []uint
+	}{
+		{
+			name:     "no change",
+			before:   map[uint]string{0: "GPU-0", 1: "GPU-1"},
+			after:    map[uint]string{0: "GPU-0", 1: "GPU-1"},
+			expected: nil,
+		},
+		{
+			name:     "GPU unbound",
+			before:   map[uint]string{0: "GPU-0", 1: "GPU-1", 2: "GPU-2"},
+			after:    map[uint]string{0: "GPU-0", 2: "GPU-2"},
+			expected: []uint{1},
+		},
+		{
+			name:     "GPU bound",
+			before:   map[uint]string{0: "GPU-0"},
+			after:    map[uint]string{0: "GPU-0", 1: "GPU-1"},
+			expected: []uint{1},
+		},
+		{
+			name:     "GPU replaced",
+			before:   map[uint]string{0: "GPU-0", 1: "GPU-1"},
+			after:    map[uint]string{0: "GPU-0", 1: "GPU-9"},
+			expected: []uint{1},
+		},
+		{
+			name:     "GPUs renumbered",
+			before:   map[uint]string{0: "GPU-0", 1: "GPU-1", 2: "GPU-2"},
+			after:    map[uint]string{0: "GPU-0", 1: "GPU-2"},
+			expected: []uint{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, changedGPUs(tt.before, tt.after))
+		})
+	}
+}
+
+func TestGPUBindUnbindWatcher_WatchGPUChanges_UnbindResolved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDCGM := mockdcgm.NewMockDCGM(ctrl)
+	realDCGM := dcgmprovider.Client()
+	defer dcgmprovider.SetClient(realDCGM)
+	dcgmprovider.SetClient(mockDCGM)
+
+	mockFieldGroup := dcgm.FieldHandle{}
+	mockFieldGroup.SetHandle(uintptr(123))
+
+	mockGroupHandle := dcgm.GroupHandle{}
+	mockGroupHandle.SetHandle(uintptr(456))
+
+	// GPU 1 disappears between the initial snapshot and the event
+	mockDCGM.EXPECT().GetAllDeviceCount().Return(uint(2), nil).Times(2)
+	mockDCGM.EXPECT().GetDeviceInfo(uint(0)).Return(dcgm.Device{GPU: 0, UUID: "GPU-0"}, nil).Times(2)
+	mockDCGM.EXPECT().GetDeviceInfo(uint(1)).Return(dcgm.Device{GPU: 1, UUID: "GPU-1"}, nil)
+	mockDCGM.EXPECT().GetDeviceInfo(uint(1)).Return(dcgm.Device{}, errors.New("GPU is lost"))
+
+	mockDCGM.EXPECT().
+		FieldGroupCreate("dcgm_exporter_bind_unbind_watch", []dcgm.Short{dcgm.DCGM_FI_BIND_UNBIND_EVENT}).
+		Return(mockFieldGroup, nil)
+
+	mockDCGM.EXPECT().
+		GroupAllGPUs().
+		Return(mockGroupHandle)
+
+	mockDCGM.EXPECT().
+		WatchFieldsWithGroupEx(mockFieldGroup, mockGroupHandle, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	initialTimestamp := time.Now().UnixNano()
+
+	mockDCGM.EXPECT().
+		UpdateAllFields().
+		Return(nil)
+
+	mockDCGM.EXPECT().
+		EntityGetLatestValues(dcgm.FE_GPU, uint(0), []dcgm.Short{dcgm.DCGM_FI_BIND_UNBIND_EVENT}).
+		Return([]dcgm.FieldValue_v1{makeFieldValueInt64(0, initialTimestamp)}, nil)
+
+	mockDCGM.EXPECT().
+		UpdateAllFields().
+		Return(nil)
+
+	mockDCGM.EXPECT().
+		EntityGetLatestValues(dcgm.FE_GPU, uint(0), []dcgm.Short{dcgm.DCGM_FI_BIND_UNBIND_EVENT}).
+		Return([]dcgm.FieldValue_v1{makeFieldValueInt64(
+			int64(dcgm.DcgmBUEventStateSystemReinitializationCompleted),
+			initialTimestamp+1000000,
+		)}, nil)
+
+	mockDCGM.EXPECT().
+		UpdateAllFields().
+		Return(nil).
+		AnyTimes()
+
+	mockDCGM.EXPECT().
+		EntityGetLatestValues(dcgm.FE_GPU, uint(0), []dcgm.Short{dcgm.DCGM_FI_BIND_UNBIND_EVENT}).
+		Return([]dcgm.FieldValue_v1{}, nil).
+		AnyTimes()
+
+	mockDCGM.EXPECT().
+		UnwatchFields(mockFieldGroup, mockGroupHandle).
+		Return(nil)
+
+	mockDCGM.EXPECT().
+		FieldGroupDestroy(mockFieldGroup).
+		Return(nil)
+
+	w := NewGPUBindUnbindWatcher(WithPollInterval(10 * time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var changes []GPUChange
+	err := w.WatchGPUChanges(ctx, func(change GPUChange) {
+		changes = append(changes, change)
+	})
+
+	require.Error(t, err)
+	require.Len(t, changes, 1)
+	assert.True(t, changes[0].Completed)
+	assert.True(t, changes[0].Resolved)
+	assert.Equal(t, []uint{1}, changes[0].GPUs)
+}
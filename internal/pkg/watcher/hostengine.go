@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+)
+
+// HostengineWatcher checks the connection to the remote hostengine and reports it as lost
+// after consecutive failed checks, so that the exporter reconnects, possibly to another hostengine
+type HostengineWatcher struct {
+	interval         time.Duration
+	timeout          time.Duration
+	failureThreshold int
+	checkInFlight    atomic.Bool // whether a check didn't return yet
+}
+
+// HostengineWatcherOption configures a HostengineWatcher
+type HostengineWatcherOption func(*HostengineWatcher)
+
+// WithCheckInterval sets how often the connection is checked
+// Default is 5 seconds
+func WithCheckInterval(interval time.Duration) HostengineWatcherOption {
+	return func(w *HostengineWatcher) {
+		w.interval = interval
+	}
+}
+
+// WithFailureThreshold sets the number of consecutive failed checks after which the connection is lost
+// Default is 3
+func WithFailureThreshold(threshold int) HostengineWatcherOption {
+	return func(w *HostengineWatcher) {
+		w.failureThreshold = threshold
+	}
+}
+
+// NewHostengineWatcher creates a new watcher of the connection to the remote hostengine
+func NewHostengineWatcher(opts ...HostengineWatcherOption) *HostengineWatcher {
+	w := &HostengineWatcher{
+		interval:         5 * time.Second,
+		failureThreshold: 3,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+	w.timeout = w.interval
+
+	return w
+}
+
+// Watch checks the connection every interval and calls onChange when it is lost
+// It blocks until the context is cancelled
+func (w *HostengineWatcher) Watch(ctx context.Context, onChange func()) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			err := w.check()
+			if err == nil {
+				failures = 0
+				continue
+			}
+
+			failures++
+			slog.Warn("Remote hostengine check failed",
+				slog.Int("consecutive_failures", failures),
+				slog.String("error", err.Error()))
+			if failures < w.failureThreshold {
+				continue
+			}
+
+			slog.Error("Remote hostengine unreachable - reconnecting")
+			failures = 0
+			onChange()
+		}
+	}
+}
+
+// check makes a cheap DCGM call to verify the connection to the hostengine
+// A hung call is not retried until it returns, every check meanwhile fails
+func (w *HostengineWatcher) check() error {
+	client := dcgmprovider.Client()
+	if client == nil {
+		// DCGM is being reinitialized
+		return nil
+	}
+
+	if !w.checkInFlight.CompareAndSwap(false, true) {
+		return errors.New("the previous check is still pending")
+	}
+
+	result := make(chan error, 1)
+	go func() {
+		defer w.checkInFlight.Store(false)
+		_, err := client.GetAllDeviceCount()
+		result <- err
+	}()
+
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(w.timeout):
+		return fmt.Errorf("no response after %s", w.timeout)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package watcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mockdcgm "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+)
+
+func TestHostengineWatcher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDCGM := mockdcgm.NewMockDCGM(ctrl)
+	realDCGM := dcgmprovider.Client()
+	defer dcgmprovider.SetClient(realDCGM)
+	dcgmprovider.SetClient(mockDCGM)
+
+	// A single failure is tolerated, the connection is lost after two consecutive ones
+	gomock.InOrder(
+		mockDCGM.EXPECT().GetAllDeviceCount().Return(uint(0), errors.New("connection refused")),
+		mockDCGM.EXPECT().GetAllDeviceCount().Return(uint(1), nil),
+		mockDCGM.EXPECT().GetAllDeviceCount().Return(uint(0), errors.New("connection refused")),
+		mockDCGM.EXPECT().GetAllDeviceCount().Return(uint(0), errors.New("connection refused")),
+		mockDCGM.EXPECT().GetAllDeviceCount().Return(uint(1), nil).AnyTimes(),
+	)
+
+	w := NewHostengineWatcher(WithCheckInterval(10*time.Millisecond), WithFailureThreshold(2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan struct{}, 1)
+	done := make(chan error)
+	go func() {
+		done <- w.Watch(ctx, func() {
+			changes <- struct{}{}
+		})
+	}()
+
+	select {
+	case <-changes:
+	case <-time.After(time.Second):
+		t.Fatal("the lost connection was not reported")
+	}
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestHostengineWatcherDuringReinitialization(t *testing.T) {
+	realDCGM := dcgmprovider.Client()
+	defer dcgmprovider.SetClient(realDCGM)
+	dcgmprovider.SetClient(nil)
+
+	assert.NoError(t, NewHostengineWatcher().check())
+}
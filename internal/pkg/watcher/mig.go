@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+)
+
+// MIGWatcher polls the MIG mode and the GPU and compute instances of the GPUs through NVML and reports
+// the changes, so that the exporter rebuilds its collectors instead of being restarted
+type MIGWatcher struct {
+	interval time.Duration
+}
+
+// MIGWatcherOption configures a MIGWatcher
+type MIGWatcherOption func(*MIGWatcher)
+
+// WithMIGPollInterval sets how often the MIG layouts are read
+// Default is 10 seconds
+func WithMIGPollInterval(interval time.Duration) MIGWatcherOption {
+	return func(w *MIGWatcher) {
+		w.interval = interval
+	}
+}
+
+// NewMIGWatcher creates a new watcher of the MIG layouts of the GPUs
+func NewMIGWatcher(opts ...MIGWatcherOption) *MIGWatcher {
+	w := &MIGWatcher{
+		interval: 10 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+// Watch reads the MIG layouts every interval and calls onChange once a change is stable for an interval,
+// so that a reconfiguration in several steps (MIG mode, GPU instances, compute instances) triggers a
+// single reload. It blocks until the context is cancelled
+func (w *MIGWatcher) Watch(ctx context.Context, onChange func()) error {
+	if err := nvmlprovider.Initialize(); err != nil {
+		return fmt.Errorf("failed to initialize NVML for the MIG watcher: %w", err)
+	}
+
+	slog.Info("Watching for MIG configuration changes",
+		slog.Duration("poll_interval", w.interval))
+
+	known, err := migLayouts()
+	if err != nil {
+		slog.Warn("Failed to read the MIG layouts", slog.String("error", err.Error()))
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	pending := false
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			current, err := migLayouts()
+			if err != nil {
+				slog.Warn("Failed to read the MIG layouts", slog.String("error", err.Error()))
+				continue
+			}
+
+			if known == nil {
+				known = current
+				continue
+			}
+
+			if changed := changedMIGLayouts(known, current); len(changed) > 0 {
+				slog.Info("MIG configuration change detected - waiting for it to settle",
+					slog.Any("gpus", changed))
+				known, pending = current, true
+				continue
+			}
+
+			if pending {
+				slog.Info("MIG configuration changed - reloading")
+				pending = false
+				onChange()
+			}
+		}
+	}
+}
+
+// migLayouts returns the MIG layout of every GPU known to NVML by UUID
+func migLayouts() (map[string]nvmlprovider.MIGLayout, error) {
+	devices, err := nvmlprovider.Client().GetDevices()
+	if err != nil {
+		return nil, err
+	}
+
+	layouts := make(map[string]nvmlprovider.MIGLayout, len(devices))
+	for _, device := range devices {
+		layout, err := nvmlprovider.Client().GetMIGLayout(device.UUID)
+		if err != nil {
+			return nil, err
+		}
+		layouts[device.UUID] = layout
+	}
+
+	return layouts, nil
+}
+
+// changedMIGLayouts returns the sorted UUIDs of the GPUs whose MIG layout differs, including the GPUs that
+// are only in one of the sets
+func changedMIGLayouts(before, after map[string]nvmlprovider.MIGLayout) []string {
+	var changed []string
+	for uuid, layout := range before {
+		afterLayout, exists := after[uuid]
+		if !exists || afterLayout.Enabled != layout.Enabled || !slices.Equal(afterLayout.Instances, layout.Instances) {
+			changed = append(changed, uuid)
+		}
+	}
+	for uuid := range after {
+		if _, exists := before[uuid]; !exists {
+			changed = append(changed, uuid)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package watcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	mocknvml "github.com/NVIDIA/dcgm-exporter/internal/mocks/pkg/nvmlprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+)
+
+func TestMIGWatcher(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockNVML := mocknvml.NewMockNVML(ctrl)
+	realNVML := nvmlprovider.Client()
+	defer nvmlprovider.SetClient(realNVML)
+	nvmlprovider.SetClient(mockNVML)
+
+	disabled := nvmlprovider.MIGLayout{}
+	enabled := nvmlprovider.MIGLayout{Enabled: true}
+	partitioned := nvmlprovider.MIGLayout{
+		Enabled:   true,
+		Instances: []nvmlprovider.MIGInstance{{GPUInstanceID: 1, ComputeInstanceID: 0}},
+	}
+
+	// MIG is enabled, then partitioned: a single change is reported once the layout is stable
+	mockNVML.EXPECT().GetDevices().Return([]nvmlprovider.GPUDevice{{UUID: "GPU-0"}}, nil).AnyTimes()
+	gomock.InOrder(
+		mockNVML.EXPECT().GetMIGLayout("GPU-0").Return(disabled, nil).Times(2),
+		mockNVML.EXPECT().GetMIGLayout("GPU-0").Return(enabled, nil),
+		mockNVML.EXPECT().GetMIGLayout("GPU-0").Return(partitioned, nil).AnyTimes(),
+	)
+
+	w := NewMIGWatcher(WithMIGPollInterval(10 * time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	changes := make(chan struct{}, 10)
+	done := make(chan error)
+	go func() {
+		done <- w.Watch(ctx, func() {
+			changes <- struct{}{}
+		})
+	}()
+
+	select {
+	case <-changes:
+	case <-time.After(time.Second):
+		t.Fatal("the MIG configuration change was not reported")
+	}
+
+	// The stable layout isn't reported again
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Empty(t, changes)
+}
+
+func TestChangedMIGLayouts(t *testing.T) {
+	partitioned := nvmlprovider.MIGLayout{
+		Enabled:   true,
+		Instances: []nvmlprovider.MIGInstance{{GPUInstanceID: 1, ComputeInstanceID: 0}},
+	}
+	repartitioned := nvmlprovider.MIGLayout{
+		Enabled:   true,
+		Instances: []nvmlprovider.MIGInstance{{GPUInstanceID: 2, ComputeInstanceID: 0}},
+	}
+
+	tests := []struct {
+		name   string
+		before map[string]nvmlprovider.MIGLayout
+		after  map[string]nvmlprovider.MIGLayout
+		want   []string
+	}{
+		{
+			name:   "unchanged",
+			before: map[string]nvmlprovider.MIGLayout{"GPU-0": partitioned},
+			after:  map[string]nvmlprovider.MIGLayout{"GPU-0": partitioned},
+		},
+		{
+			name:   "MIG disabled",
+			before: map[string]nvmlprovider.MIGLayout{"GPU-0": partitioned, "GPU-1": {}},
+			after:  map[string]nvmlprovider.MIGLayout{"GPU-0": {}, "GPU-1": {}},
+			want:   []string{"GPU-0"},
+		},
+		{
+			name:   "GPU instances recreated",
+			before: map[string]nvmlprovider.MIGLayout{"GPU-0": partitioned},
+			after:  map[string]nvmlprovider.MIGLayout{"GPU-0": repartitioned},
+			want:   []string{"GPU-0"},
+		},
+		{
+			name:   "GPU replaced",
+			before: map[string]nvmlprovider.MIGLayout{"GPU-0": partitioned},
+			after:  map[string]nvmlprovider.MIGLayout{"GPU-1": partitioned},
+			want:   []string{"GPU-0", "GPU-1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, changedMIGLayouts(tt.before, tt.after))
+		})
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
 	"os/signal"
+	"path"
+	"regexp"
 	"runtime"
 	"runtime/debug"
 	"slices"
@@ -21,20 +24,32 @@ import (
 
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
 	"github.com/urfave/cli/v2"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/deviceinfo"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatcher"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/diag"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/events"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/hostname"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/logging"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/memguard"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/otlp"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/parquet"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/passthrough"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/prerequisites"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/pushqueue"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/relabel"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/remotewrite"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/server"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/stdout"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/transformation"
 	"github.com/NVIDIA/dcgm-exporter/internal/pkg/watcher"
 )
 
@@ -71,10 +86,13 @@ const (
 	CLIKubernetes                       = "kubernetes"
 	CLIKubernetesEnablePodLabels        = "kubernetes-enable-pod-labels"
 	CLIKubernetesEnablePodUID           = "kubernetes-enable-pod-uid"
+	CLIKubernetesWorkloadLabels         = "kubernetes-enable-workload-labels"
 	CLIKubernetesGPUIDType              = "kubernetes-gpu-id-type"
 	CLIKubernetesPodLabelAllowlistRegex = "kubernetes-pod-label-allowlist-regex"
+	CLIKubernetesPodAnnotations         = "kubernetes-pod-annotation-allowlist-regex"
 	CLIUseOldNamespace                  = "use-old-namespace"
 	CLIRemoteHEInfo                     = "remote-hostengine-info"
+	CLIRemoteHECheckInterval            = "remote-hostengine-check-interval"
 	CLIGPUDevices                       = "devices"
 	CLISwitchDevices                    = "switch-devices"
 	CLICPUDevices                       = "cpu-devices"
@@ -87,10 +105,12 @@ const (
 	CLIReplaceBlanksInModelName         = "replace-blanks-in-model-name"
 	CLIDebugMode                        = "debug"
 	CLIClockEventsCountWindowSize       = "clock-events-count-window-size"
+	CLINVLinkErrorsCountWindowSize      = "nvlink-errors-count-window-size"
 	CLIEnableDCGMLog                    = "enable-dcgm-log"
 	CLIDCGMLogLevel                     = "dcgm-log-level"
 	CLILogFormat                        = "log-format"
 	CLIPodResourcesKubeletSocket        = "pod-resources-kubelet-socket"
+	CLIKubeletCheckpointFile            = "kubelet-checkpoint-file"
 	CLIHPCJobMappingDir                 = "hpc-job-mapping-dir"
 	CLINvidiaResourceNames              = "nvidia-resource-names"
 	CLIKubernetesVirtualGPUs            = "kubernetes-virtual-gpus"
@@ -99,9 +119,77 @@ const (
 	CLIDumpRetention                    = "dump-retention"
 	CLIDumpCompression                  = "dump-compression"
 	CLIKubernetesEnableDRA              = "kubernetes-enable-dra"
+	CLIKubernetesDuplicatePolicy        = "kubernetes-duplicate-policy"
 	CLIDisableStartupValidate           = "disable-startup-validate"
 	CLIEnableGPUBindUnbindWatch         = "enable-gpu-bind-unbind-watch"
+	CLICPUOnly                          = "cpu-only"
+	CLINVMLOnly                         = "nvml-only"
 	CLIGPUBindUnbindPollInterval        = "gpu-bind-unbind-poll-interval"
+	CLIEnableMIGWatch                   = "enable-mig-watch"
+	CLIMIGWatchPollInterval             = "mig-watch-poll-interval"
+	CLIGPUInstanceIDFormat              = "gpu-instance-id-format"
+	CLIEnableCounterDeltas              = "enable-counter-deltas"
+	CLIIPFamily                         = "ip-family"
+	CLIDCGMModules                      = "dcgm-modules"
+	CLISplitMIGMetrics                  = "split-mig-metrics"
+	CLIMIGInstanceMemory                = "mig-instance-memory"
+	CLIKubernetesSkipInactivePods       = "kubernetes-skip-inactive-pods"
+	CLIKubernetesNamespaceAllowlist     = "kubernetes-namespace-allowlist"
+	CLIKubernetesNamespaceDenylist      = "kubernetes-namespace-denylist"
+	CLIKubernetesNodeLabels             = "kubernetes-node-labels"
+	CLIKubernetesNodeLabelsMode         = "kubernetes-node-labels-mode"
+	CLIInstanceFQDNLabel                = "instance-fqdn-label"
+	CLIFieldID                          = "field-id"
+	CLIGPUSlotsFile                     = "gpu-slots-file"
+	CLICollectorInventoryMetric         = "collector-inventory-metric"
+	CLIExpositionHashMetric             = "exposition-hash-metric"
+	CLIFamilySamplesMetric              = "family-samples-metric"
+	CLIEventLogSize                     = "event-log-size"
+	CLIJournalEvents                    = "journal-events"
+	CLITelemetryMetrics                 = "telemetry-metrics"
+	CLIDeviceLabelsInfo                 = "device-labels-info"
+	CLICollectorTimeout                 = "collector-timeout"
+	CLICollectorBreakerThreshold        = "collector-breaker-threshold"
+	CLICollectorBreakerCycles           = "collector-breaker-cycles"
+	CLIMetricsCacheInterval             = "metrics-cache-interval"
+	CLIMaxStaleness                     = "max-staleness"
+	CLIOpenMetrics                      = "openmetrics"
+	CLIDevicePauseAPI                   = "device-pause-api"
+	CLIMetricsCompression               = "metrics-compression"
+	CLIMaintenanceAPI                   = "maintenance-api"
+	CLIMaintenanceMaxDuration           = "maintenance-max-duration"
+	CLIMaintenanceDropFamilies          = "maintenance-drop-families"
+	CLIStreamingRender                  = "streaming-render"
+	CLIMIGComputeInstanceMetrics        = "mig-compute-instance-metrics"
+	CLIMIGFragmentationMetrics          = "mig-fragmentation-metrics"
+	CLIOTLPEndpoint                     = "otlp-endpoint"
+	CLIOTLPProtocol                     = "otlp-protocol"
+	CLIOTLPInterval                     = "otlp-interval"
+	CLISuppressIdleMetrics              = "suppress-idle-metrics"
+	CLICanaryCollectors                 = "canary-collectors"
+	CLICollectOnScrape                  = "collect-on-scrape"
+	CLICollectOnScrapeMinInterval       = "collect-on-scrape-min-interval"
+	CLIDiagLevel                        = "diag-level"
+	CLIDiagInterval                     = "diag-interval"
+	CLIRemoteWrite                      = "remote-write"
+	CLIRemoteWriteInterval              = "remote-write-interval"
+	CLIMetricNameMigrations             = "metric-name-migrations"
+	CLIDualNamespace                    = "dual-namespace"
+	CLIDualNamespaceFamilies            = "dual-namespace-families"
+	CLIMemoryWatermark                  = "memory-watermark"
+	CLIPushQueueSize                    = "push-queue-size"
+	CLIPushBatchSize                    = "push-batch-size"
+	CLIPushFlushInterval                = "push-flush-interval"
+	CLIDerivedCounters                  = "derived-counters"
+	CLIExtraLabels                      = "extra-labels"
+	CLIRelabelConfig                    = "relabel-config"
+	CLIConfigFile                       = "config-file"
+	CLIParquetDirectory                 = "parquet-directory"
+	CLIParquetInterval                  = "parquet-interval"
+	CLIParquetRotationInterval          = "parquet-rotation-interval"
+	CLIParquetRetention                 = "parquet-retention"
+	CLIXIDCorrelation                   = "xid-correlation"
+	CLIEnableProbe                      = "enable-probe"
 )
 
 func NewApp(buildVersion ...string) *cli.App {
@@ -130,9 +218,26 @@ func NewApp(buildVersion ...string) *cli.App {
 			Name:    CLIAddress,
 			Aliases: []string{"a"},
 			Value:   ":9400",
-			Usage:   "Address",
+			Usage:   "Address; use brackets for IPv6, e.g. '[::1]:9400'",
 			EnvVars: []string{"DCGM_EXPORTER_LISTEN"},
 		},
+		&cli.StringFlag{
+			Name:  CLIIPFamily,
+			Value: string(appconfig.IPFamilyAny),
+			Usage: fmt.Sprintf("IP family of the listen address. Possible values: '%s', '%s', '%s', '%s'. "+
+				"'%s' requires both IPv4 and IPv6 to be available",
+				appconfig.IPFamilyAny, appconfig.IPFamilyIPv4, appconfig.IPFamilyIPv6, appconfig.IPFamilyDual,
+				appconfig.IPFamilyDual),
+			EnvVars: []string{"DCGM_EXPORTER_IP_FAMILY"},
+		},
+		&cli.StringSliceFlag{
+			Name: CLIDCGMModules,
+			Value: cli.NewStringSlice(string(appconfig.DCGMModuleProfiling), string(appconfig.DCGMModuleHealth),
+				string(appconfig.DCGMModulePolicy), string(appconfig.DCGMModuleNvSwitch)),
+			Usage: "DCGM modules the exporter may load (comma-separated). Modules are loaded on first use, " +
+				"so leaving out a module also disables the metrics that depend on it",
+			EnvVars: []string{"DCGM_EXPORTER_DCGM_MODULES"},
+		},
 		&cli.IntFlag{
 			Name:    CLICollectInterval,
 			Aliases: []string{"c"},
@@ -172,9 +277,26 @@ func NewApp(buildVersion ...string) *cli.App {
 			Name:    CLIRemoteHEInfo,
 			Aliases: []string{"r"},
 			Value:   "localhost:5555",
-			Usage:   "Connect to remote hostengine at <HOST>:<PORT>",
+			Usage: "Connect to remote hostengine at <HOST>:<PORT>. A comma-separated list of hostengines sets " +
+				"failover hostengines, in their order of preference",
 			EnvVars: []string{"DCGM_REMOTE_HOSTENGINE_INFO"},
 		},
+		&cli.StringFlag{
+			Name:  CLIRemoteHECheckInterval,
+			Value: "5s",
+			Usage: "Interval between the checks of the connection to the remote hostengine. After 3 failed checks, " +
+				"the exporter reconnects to the first reachable hostengine and rebuilds its collectors. " +
+				"Effective only with several '--remote-hostengine-info' hostengines",
+			EnvVars: []string{"DCGM_EXPORTER_REMOTE_HOSTENGINE_CHECK_INTERVAL"},
+		},
+		&cli.BoolFlag{
+			Name:  CLIEnableProbe,
+			Value: false,
+			Usage: "Serve the metrics of remote hostengines on /probe?target=<HOST>:<PORT>, each probe connects " +
+				"to the hostengine for the duration of the scrape. Any client reaching the exporter can make it " +
+				"connect to any address",
+			EnvVars: []string{"DCGM_EXPORTER_ENABLE_PROBE"},
+		},
 		&cli.BoolFlag{
 			Name:    CLIKubernetesEnablePodLabels,
 			Value:   false,
@@ -187,6 +309,12 @@ func NewApp(buildVersion ...string) *cli.App {
 			Usage:   "Enable kubernetes pod UID in metrics. This parameter is effective only when the '--kubernetes' option is set to 'true'.",
 			EnvVars: []string{"DCGM_EXPORTER_KUBERNETES_ENABLE_POD_UID"},
 		},
+		&cli.BoolFlag{
+			Name:    CLIKubernetesWorkloadLabels,
+			Value:   false,
+			Usage:   "Add the workload_kind and workload_name labels of the Deployment, StatefulSet, DaemonSet or Job owning the pods. This parameter is effective only when the '--kubernetes' option is set to 'true'.",
+			EnvVars: []string{"DCGM_EXPORTER_KUBERNETES_ENABLE_WORKLOAD_LABELS"},
+		},
 		&cli.StringFlag{
 			Name:  CLIKubernetesGPUIDType,
 			Value: string(appconfig.GPUUID),
@@ -200,6 +328,12 @@ func NewApp(buildVersion ...string) *cli.App {
 			Usage:   "Regex patterns for filtering pod labels to include in metrics (comma-separated). Empty means include all labels. This parameter is effective only when '--kubernetes-enable-pod-labels' is true.",
 			EnvVars: []string{"DCGM_EXPORTER_KUBERNETES_POD_LABEL_ALLOWLIST_REGEX"},
 		},
+		&cli.StringSliceFlag{
+			Name:    CLIKubernetesPodAnnotations,
+			Value:   cli.NewStringSlice(),
+			Usage:   "Regex patterns of the pod annotations added as labels to the metrics (comma-separated). Empty means no annotation is added. This parameter is effective only when '--kubernetes-enable-pod-labels' is true.",
+			EnvVars: []string{"DCGM_EXPORTER_KUBERNETES_POD_ANNOTATION_ALLOWLIST_REGEX"},
+		},
 		&cli.StringFlag{
 			Name:    CLIGPUDevices,
 			Aliases: []string{"d"},
@@ -240,6 +374,13 @@ func NewApp(buildVersion ...string) *cli.App {
 			Usage:   "Set time window size in milliseconds (ms) for counting active XID errors in DCGM Exporter.",
 			EnvVars: []string{"DCGM_EXPORTER_XID_COUNT_WINDOW_SIZE"},
 		},
+		&cli.BoolFlag{
+			Name:  CLIXIDCorrelation,
+			Value: false,
+			Usage: "Attach the clock event reasons, temperature and power usage the closest to the last occurrence " +
+				"of every XID error to DCGM_EXP_XID_ERRORS_COUNT, as the clock_events, gpu_temp and power_usage labels",
+			EnvVars: []string{"DCGM_EXPORTER_XID_CORRELATION"},
+		},
 		&cli.BoolFlag{
 			Name:    CLIReplaceBlanksInModelName,
 			Aliases: []string{"rbmn"},
@@ -259,6 +400,12 @@ func NewApp(buildVersion ...string) *cli.App {
 			Usage:   "Set time window size in milliseconds (ms) for counting clock events in DCGM Exporter.",
 			EnvVars: []string{"DCGM_EXPORTER_CLOCK_EVENTS_COUNT_WINDOW_SIZE"},
 		},
+		&cli.IntFlag{
+			Name:    CLINVLinkErrorsCountWindowSize,
+			Value:   int((5 * time.Minute).Milliseconds()),
+			Usage:   "Set time window size in milliseconds (ms) for counting NVLink CRC, replay and recovery errors in DCGM Exporter.",
+			EnvVars: []string{"DCGM_EXPORTER_NVLINK_ERRORS_COUNT_WINDOW_SIZE"},
+		},
 		&cli.BoolFlag{
 			Name:    CLIEnableDCGMLog,
 			Value:   false,
@@ -280,9 +427,16 @@ func NewApp(buildVersion ...string) *cli.App {
 		&cli.StringFlag{
 			Name:    CLIPodResourcesKubeletSocket,
 			Value:   "/var/lib/kubelet/pod-resources/kubelet.sock",
-			Usage:   "Path to the kubelet pod-resources socket file.",
+			Usage:   "Path to the kubelet pod-resources socket file. When not set, the socket is discovered in the well-known kubelet directories, falling back to this path.",
 			EnvVars: []string{"DCGM_POD_RESOURCES_KUBELET_SOCKET"},
 		},
+		&cli.StringFlag{
+			Name:  CLIKubeletCheckpointFile,
+			Value: "",
+			Usage: "Path to the kubelet device plugin checkpoint, e.g. /var/lib/kubelet/device-plugins/kubelet_internal_checkpoint, " +
+				"from which the devices are mapped to the pods when the pod-resources socket is unavailable or fails. Empty disables the fallback.",
+			EnvVars: []string{"DCGM_EXPORTER_KUBELET_CHECKPOINT_FILE"},
+		},
 		&cli.StringFlag{
 			Name:    CLIHPCJobMappingDir,
 			Value:   "",
@@ -331,6 +485,15 @@ func NewApp(buildVersion ...string) *cli.App {
 			Usage:   "Capture metrics associated with GPUs managed by Kubernetes Dynamic Resource Allocation (DRA) API.",
 			EnvVars: []string{"KUBERNETES_ENABLE_DRA"},
 		},
+		&cli.StringFlag{
+			Name:  CLIKubernetesDuplicatePolicy,
+			Value: string(appconfig.DuplicatePolicyMerge),
+			Usage: fmt.Sprintf("How the series mapped to the same container by both the virtual GPUs and the DRA mappers are "+
+				"resolved. Possible values: '%s' keeps the DRA series, '%s' keeps the virtual GPU series, '%s' merges "+
+				"their attributes",
+				appconfig.DuplicatePolicyDRA, appconfig.DuplicatePolicySharing, appconfig.DuplicatePolicyMerge),
+			EnvVars: []string{"DCGM_EXPORTER_KUBERNETES_DUPLICATE_POLICY"},
+		},
 		&cli.BoolFlag{
 			Name:    CLIDisableStartupValidate,
 			Value:   false,
@@ -343,12 +506,443 @@ func NewApp(buildVersion ...string) *cli.App {
 			Usage:   "Enable watching for GPU bind/unbind events to trigger automatic reloads (requires DCGM 4.5+)",
 			EnvVars: []string{"DCGM_EXPORTER_ENABLE_GPU_BIND_UNBIND_WATCH"},
 		},
+		&cli.BoolFlag{
+			Name:  CLICPUOnly,
+			Value: false,
+			Usage: "Collect the CPU and CPU core metrics only, for CPU-only nodes such as Grace nodes without GPUs. " +
+				"The GPU, NvSwitch and vGPU collectors are skipped and the readiness requires a CPU collector",
+			EnvVars: []string{"DCGM_EXPORTER_CPU_ONLY"},
+		},
+		&cli.BoolFlag{
+			Name:  CLINVMLOnly,
+			Value: false,
+			Usage: "Serve the utilization, memory, temperature, power, process and XID metrics of the GPUs from NVML, " +
+				"without DCGM, for the Jetson modules and the nodes where nv-hostengine can't run",
+			EnvVars: []string{"DCGM_EXPORTER_NVML_ONLY"},
+		},
 		&cli.StringFlag{
 			Name:    CLIGPUBindUnbindPollInterval,
 			Usage:   "Interval for polling GPU bind/unbind events (DCGM recommends 1s)",
 			EnvVars: []string{"DCGM_EXPORTER_GPU_BIND_UNBIND_POLL_INTERVAL"},
 			Value:   "1s",
 		},
+		&cli.BoolFlag{
+			Name:  CLIEnableMIGWatch,
+			Value: false,
+			Usage: "Enable watching the MIG mode and the GPU and compute instances of the GPUs through NVML " +
+				"to trigger automatic reloads when they change",
+			EnvVars: []string{"DCGM_EXPORTER_ENABLE_MIG_WATCH"},
+		},
+		&cli.StringFlag{
+			Name:    CLIMIGWatchPollInterval,
+			Usage:   "Interval for polling the MIG configuration of the GPUs",
+			EnvVars: []string{"DCGM_EXPORTER_MIG_WATCH_POLL_INTERVAL"},
+			Value:   "10s",
+		},
+		&cli.StringFlag{
+			Name:  CLIGPUInstanceIDFormat,
+			Value: "",
+			Usage: fmt.Sprintf("Format of the GPU instance identifier exported as the gpu_instance label of the MIG device "+
+				"metrics and used to map MIG devices to pods. Possible values: '%s', '%s'. If unset, the label is not "+
+				"exported and MIG devices are mapped with '%s'",
+				appconfig.GPUInstanceIDFormatIndex, appconfig.GPUInstanceIDFormatUUID, appconfig.GPUInstanceIDFormatIndex),
+			EnvVars: []string{"DCGM_EXPORTER_GPU_INSTANCE_ID_FORMAT"},
+		},
+		&cli.BoolFlag{
+			Name:    CLIEnableCounterDeltas,
+			Value:   false,
+			Usage:   "Export a <FIELD>_DELTA gauge with the increase over the last collect interval for every counter field",
+			EnvVars: []string{"DCGM_EXPORTER_ENABLE_COUNTER_DELTAS"},
+		},
+		&cli.BoolFlag{
+			Name:    CLISplitMIGMetrics,
+			Value:   false,
+			Usage:   "Export metrics of MIG instances as separate <FIELD>_MIG families instead of mixing them with full GPU series",
+			EnvVars: []string{"DCGM_EXPORTER_SPLIT_MIG_METRICS"},
+		},
+		&cli.BoolFlag{
+			Name:  CLIMIGInstanceMemory,
+			Value: false,
+			Usage: "Export the framebuffer used and free and the BAR1 used of every GPU instance, even when the " +
+				"counters only list GPU fields",
+			EnvVars: []string{"DCGM_EXPORTER_MIG_INSTANCE_MEMORY"},
+		},
+		&cli.BoolFlag{
+			Name:    CLIKubernetesSkipInactivePods,
+			Value:   false,
+			Usage:   "Don't attribute GPUs to pods that are terminating, terminated or unschedulable according to the pod informer cache",
+			EnvVars: []string{"KUBERNETES_SKIP_INACTIVE_PODS"},
+		},
+		&cli.StringSliceFlag{
+			Name:    CLIKubernetesNamespaceAllowlist,
+			Value:   cli.NewStringSlice(),
+			Usage:   "Namespaces, or glob patterns such as 'team-*', whose pods GPUs are attributed to (comma-separated). Empty means all namespaces.",
+			EnvVars: []string{"DCGM_EXPORTER_KUBERNETES_NAMESPACE_ALLOWLIST"},
+		},
+		&cli.StringSliceFlag{
+			Name:    CLIKubernetesNamespaceDenylist,
+			Value:   cli.NewStringSlice(),
+			Usage:   "Namespaces, or glob patterns such as 'kube-*', whose pods GPUs are never attributed to (comma-separated). Takes precedence over the allowlist.",
+			EnvVars: []string{"DCGM_EXPORTER_KUBERNETES_NAMESPACE_DENYLIST"},
+		},
+		&cli.StringSliceFlag{
+			Name:    CLIKubernetesNodeLabels,
+			Value:   cli.NewStringSlice(),
+			Usage:   "Labels of the Kubernetes node to export, e.g. 'topology.kubernetes.io/zone,nvidia.com/gpu.product' (comma-separated). Requires the NODE_NAME environment variable.",
+			EnvVars: []string{"DCGM_EXPORTER_KUBERNETES_NODE_LABELS"},
+		},
+		&cli.StringFlag{
+			Name:  CLIKubernetesNodeLabelsMode,
+			Value: string(appconfig.NodeLabelModeLabels),
+			Usage: fmt.Sprintf("How the labels of the Kubernetes node are exported. Possible values: '%s' adds them to every metric, "+
+				"'%s' exports them by the dcgm_exporter_node_info gauge",
+				appconfig.NodeLabelModeLabels, appconfig.NodeLabelModeInfo),
+			EnvVars: []string{"DCGM_EXPORTER_KUBERNETES_NODE_LABELS_MODE"},
+		},
+		&cli.StringFlag{
+			Name:  CLIFieldID,
+			Value: "",
+			Usage: fmt.Sprintf("How the DCGM field ID of the families is exported. Possible values: '%s' adds the "+
+				"dcgm_field_id label to every metric, '%s' exports it by the dcgm_exporter_field_info gauge; empty disables it",
+				appconfig.FieldIDModeLabel, appconfig.FieldIDModeInfo),
+			EnvVars: []string{"DCGM_EXPORTER_FIELD_ID"},
+		},
+		&cli.BoolFlag{
+			Name:    CLIInstanceFQDNLabel,
+			Value:   false,
+			Usage:   "Add the instance_fqdn label with the fully qualified domain name of the host, resolved from the hostname or IP address",
+			EnvVars: []string{"DCGM_EXPORTER_INSTANCE_FQDN_LABEL"},
+		},
+		&cli.StringFlag{
+			Name:  CLIGPUSlotsFile,
+			Value: "",
+			Usage: "File persisting a stable slot per GPU UUID, e.g. /var/lib/dcgm-exporter/gpu-slots.json. When set, " +
+				"the gpu label is the slot of the GPU instead of its index, which can change after a reboot or a bind/unbind",
+			EnvVars: []string{"DCGM_EXPORTER_GPU_SLOTS_FILE"},
+		},
+		&cli.BoolFlag{
+			Name:    CLICollectorInventoryMetric,
+			Value:   false,
+			Usage:   "Export the dcgm_exporter_collectors metric with the collectors registered for each entity type",
+			EnvVars: []string{"DCGM_EXPORTER_COLLECTOR_INVENTORY_METRIC"},
+		},
+		&cli.BoolFlag{
+			Name:  CLIExpositionHashMetric,
+			Value: false,
+			Usage: "Export the dcgm_exporter_exposition_hash metric, a hash of the families, label names and " +
+				"number of series of every scrape, to alert on the nodes whose exported data changes shape",
+			EnvVars: []string{"DCGM_EXPORTER_EXPOSITION_HASH_METRIC"},
+		},
+		&cli.BoolFlag{
+			Name:  CLIFamilySamplesMetric,
+			Value: false,
+			Usage: "Export the dcgm_exporter_samples_per_family metric with the number of samples of every family on " +
+				"the last gather, to alert on the families losing samples",
+			EnvVars: []string{"DCGM_EXPORTER_FAMILY_SAMPLES_METRIC"},
+		},
+		&cli.IntFlag{
+			Name:  CLIEventLogSize,
+			Value: events.DefaultCapacity,
+			Usage: "Number of XID, throttle, health, reload and connection events kept in the log served on " +
+				"/api/v1/events; 0 disables the event log",
+			EnvVars: []string{"DCGM_EXPORTER_EVENT_LOG_SIZE"},
+		},
+		&cli.BoolFlag{
+			Name:  CLIJournalEvents,
+			Value: false,
+			Usage: "Write the XID, double-bit ECC, reset and failed health watch events to the systemd journal, " +
+				"with structured fields such as MESSAGE_ID and GPU_UUID",
+			EnvVars: []string{"DCGM_EXPORTER_JOURNAL_EVENTS"},
+		},
+		&cli.BoolFlag{
+			Name:  CLITelemetryMetrics,
+			Value: false,
+			Usage: "Export the metrics about the exporter itself: the duration and errors of the collectors, " +
+				"the reloads, the age of the collectors and the sizes of the pod mapper caches",
+			EnvVars: []string{"DCGM_EXPORTER_TELEMETRY_METRICS"},
+		},
+		&cli.BoolFlag{
+			Name:  CLIDeviceLabelsInfo,
+			Value: false,
+			Usage: "Export the label counters of the GPUs once per GPU as dcgm_exp_device_labels_info, " +
+				"instead of labeling every series with them",
+			EnvVars: []string{"DCGM_EXPORTER_DEVICE_LABELS_INFO"},
+		},
+		&cli.StringFlag{
+			Name:  CLICollectorTimeout,
+			Value: "0s",
+			Usage: "Longest time a collector may take on a scrape, its metrics are skipped from the scrape beyond it; " +
+				"0 waits for the collectors indefinitely",
+			EnvVars: []string{"DCGM_EXPORTER_COLLECTOR_TIMEOUT"},
+		},
+		&cli.IntFlag{
+			Name:  CLICollectorBreakerThreshold,
+			Value: 0,
+			Usage: "Number of consecutive failed or timed out collections after which a collector is skipped for " +
+				"--collector-breaker-cycles scrapes; 0 disables the circuit breaker",
+			EnvVars: []string{"DCGM_EXPORTER_COLLECTOR_BREAKER_THRESHOLD"},
+		},
+		&cli.IntFlag{
+			Name:    CLICollectorBreakerCycles,
+			Value:   10,
+			Usage:   "Number of scrapes skipping a collector once its circuit breaker opened",
+			EnvVars: []string{"DCGM_EXPORTER_COLLECTOR_BREAKER_CYCLES"},
+		},
+		&cli.StringFlag{
+			Name:  CLIMetricsCacheInterval,
+			Value: "0s",
+			Usage: "Interval of the background gather of the metrics served by /metrics, with the timestamp of " +
+				"their gather; 0 gathers them on every scrape",
+			EnvVars: []string{"DCGM_EXPORTER_METRICS_CACHE_INTERVAL"},
+		},
+		&cli.StringFlag{
+			Name:  CLIMaxStaleness,
+			Value: "0s",
+			Usage: "Age of the metrics gathered in the background beyond which /metrics returns 503; " +
+				"0 serves them at any age. Requires --metrics-cache-interval",
+			EnvVars: []string{"DCGM_EXPORTER_MAX_STALENESS"},
+		},
+		&cli.BoolFlag{
+			Name:  CLIOpenMetrics,
+			Value: false,
+			Usage: "Serve /metrics in the OpenMetrics format to the clients preferring it, with the _total suffix, " +
+				"a _created series and pod exemplars on the counters",
+			EnvVars: []string{"DCGM_EXPORTER_OPENMETRICS"},
+		},
+		&cli.BoolFlag{
+			Name:  CLIDevicePauseAPI,
+			Value: false,
+			Usage: "Enable the /api/v1/devices endpoints pausing and resuming the collection of a GPU, " +
+				"e.g. during a firmware update",
+			EnvVars: []string{"DCGM_EXPORTER_DEVICE_PAUSE_API"},
+		},
+		&cli.BoolFlag{
+			Name:  CLIMetricsCompression,
+			Value: false,
+			Usage: "Compress the /metrics responses with zstd, gzip or deflate, as negotiated with the " +
+				"Accept-Encoding header of the client",
+			EnvVars: []string{"DCGM_EXPORTER_METRICS_COMPRESSION"},
+		},
+		&cli.BoolFlag{
+			Name:  CLIMaintenanceAPI,
+			Value: false,
+			Usage: "Enable the /-/maintenance endpoint starting a maintenance window of the node, during which " +
+				"the metrics are labeled with maintenance=\"true\"",
+			EnvVars: []string{"DCGM_EXPORTER_MAINTENANCE_API"},
+		},
+		&cli.StringFlag{
+			Name:    CLIMaintenanceMaxDuration,
+			Value:   "24h",
+			Usage:   "Maximum duration of a maintenance window; 0 doesn't bound it",
+			EnvVars: []string{"DCGM_EXPORTER_MAINTENANCE_MAX_DURATION"},
+		},
+		&cli.StringSliceFlag{
+			Name:    CLIMaintenanceDropFamilies,
+			Value:   cli.NewStringSlice(),
+			Usage:   "Metric families dropped during a maintenance window, e.g. those alerted on (comma-separated)",
+			EnvVars: []string{"DCGM_EXPORTER_MAINTENANCE_DROP_FAMILIES"},
+		},
+		&cli.BoolFlag{
+			Name:  CLIStreamingRender,
+			Value: false,
+			Usage: "Render the metrics of each entity type as soon as its collectors are done, and stream them " +
+				"to the /metrics response, to lower the peak memory of large nodes",
+			EnvVars: []string{"DCGM_EXPORTER_STREAMING_RENDER"},
+		},
+		&cli.BoolFlag{
+			Name:    CLIMIGComputeInstanceMetrics,
+			Value:   false,
+			Usage:   "Also collect metrics of the MIG compute instances of each monitored GPU instance, labeled with compute_instance_id",
+			EnvVars: []string{"DCGM_EXPORTER_MIG_COMPUTE_INSTANCE_METRICS"},
+		},
+		&cli.BoolFlag{
+			Name:  CLIMIGFragmentationMetrics,
+			Value: false,
+			Usage: "Export the free compute slices, the largest GPU instance profile that can still be created and " +
+				"the fragmentation of the MIG enabled GPUs, as read from the GPU instance placements of NVML",
+			EnvVars: []string{"DCGM_EXPORTER_MIG_FRAGMENTATION_METRICS"},
+		},
+		&cli.StringFlag{
+			Name:    CLIOTLPEndpoint,
+			Value:   "",
+			Usage:   "URL of an OTLP collector to push metrics to, in addition to serving them for Prometheus, e.g. http://otel-collector:4317",
+			EnvVars: []string{"DCGM_EXPORTER_OTLP_ENDPOINT"},
+		},
+		&cli.StringFlag{
+			Name:  CLIOTLPProtocol,
+			Value: string(appconfig.OTLPProtocolGRPC),
+			Usage: fmt.Sprintf("Protocol of the OTLP push. Possible values: '%s', '%s'",
+				appconfig.OTLPProtocolGRPC, appconfig.OTLPProtocolHTTP),
+			EnvVars: []string{"DCGM_EXPORTER_OTLP_PROTOCOL"},
+		},
+		&cli.StringFlag{
+			Name:    CLIOTLPInterval,
+			Value:   "30s",
+			Usage:   "Interval of the OTLP push",
+			EnvVars: []string{"DCGM_EXPORTER_OTLP_INTERVAL"},
+		},
+		&cli.StringSliceFlag{
+			Name:  CLISuppressIdleMetrics,
+			Value: cli.NewStringSlice(),
+			Usage: "Metric families to omit for GPUs with no running processes and zero utilization " +
+				"(comma-separated), e.g. DCGM_FI_PROF_PIPE_TENSOR_ACTIVE. Requires DCGM_FI_DEV_GPU_UTIL to be collected",
+			EnvVars: []string{"DCGM_EXPORTER_SUPPRESS_IDLE_METRICS"},
+		},
+		&cli.StringFlag{
+			Name:  CLICanaryCollectors,
+			Value: "",
+			Usage: "Path to a candidate file with the DCGM fields to collect. The metrics are also gathered with it, " +
+				"and /canary reports the series added and removed compared to the current file",
+			EnvVars: []string{"DCGM_EXPORTER_CANARY_COLLECTORS"},
+		},
+		&cli.BoolFlag{
+			Name:    CLICollectOnScrape,
+			Value:   false,
+			Usage:   "Update the DCGM fields on every scrape instead of serving the samples of the last collect interval",
+			EnvVars: []string{"DCGM_EXPORTER_COLLECT_ON_SCRAPE"},
+		},
+		&cli.StringFlag{
+			Name:  CLICollectOnScrapeMinInterval,
+			Value: "1s",
+			Usage: "Minimal interval between the field updates triggered by scrapes, " +
+				"more frequent scrapes get the samples of the last update. Effective only with '--collect-on-scrape'",
+			EnvVars: []string{"DCGM_EXPORTER_COLLECT_ON_SCRAPE_MIN_INTERVAL"},
+		},
+		&cli.IntFlag{
+			Name:  CLIDiagLevel,
+			Value: 0,
+			Usage: "Level of the DCGM diagnostics to run in the background (1 or 2), exported as pass/fail gauges " +
+				"per test and GPU. A run can be triggered with a POST on /diag. 0 disables the diagnostics",
+			EnvVars: []string{"DCGM_EXPORTER_DIAG_LEVEL"},
+		},
+		&cli.StringFlag{
+			Name:  CLIDiagInterval,
+			Value: "24h",
+			Usage: "Interval between the DCGM diagnostics runs, 0 runs them on demand only. " +
+				"Effective only with '--diag-level'",
+			EnvVars: []string{"DCGM_EXPORTER_DIAG_INTERVAL"},
+		},
+		&cli.StringSliceFlag{
+			Name:  CLIRemoteWrite,
+			Value: cli.NewStringSlice(),
+			Usage: "Prometheus remote write endpoints to push metrics to, in addition to serving them for Prometheus, " +
+				"in the format '<url>[;namespaces=<namespace>|<namespace>...]'. An endpoint with namespaces only " +
+				"receives the series of the pods in these namespaces, e.g. " +
+				"'https://tenant-a.example.com/api/v1/write;namespaces=team-a|team-b'",
+			EnvVars: []string{"DCGM_EXPORTER_REMOTE_WRITE"},
+		},
+		&cli.StringFlag{
+			Name:    CLIRemoteWriteInterval,
+			Value:   "30s",
+			Usage:   "Interval of the Prometheus remote write push",
+			EnvVars: []string{"DCGM_EXPORTER_REMOTE_WRITE_INTERVAL"},
+		},
+		&cli.StringSliceFlag{
+			Name:  CLIMetricNameMigrations,
+			Value: cli.NewStringSlice(),
+			Usage: "Metric families to rename, in the format '<old name>=<new name>[@<YYYY-MM-DD>]'. The family " +
+				"is exported under both names until the date (UTC), or as long as the migration is configured " +
+				"without a date, e.g. 'DCGM_FI_DEV_WEIGHTED_GPU_UTIL=DCGM_EXP_WEIGHTED_GPU_UTIL@2026-12-31'",
+			EnvVars: []string{"DCGM_EXPORTER_METRIC_NAME_MIGRATIONS"},
+		},
+		&cli.BoolFlag{
+			Name:  CLIDualNamespace,
+			Value: false,
+			Usage: "Also export the GPU series in the namespace not selected by --use-old-namespace, " +
+				"to migrate from one namespace to the other",
+			EnvVars: []string{"DCGM_EXPORTER_DUAL_NAMESPACE"},
+		},
+		&cli.StringSliceFlag{
+			Name:  CLIDualNamespaceFamilies,
+			Value: cli.NewStringSlice(),
+			Usage: "Metric families exported in both namespaces with --dual-namespace (comma-separated); " +
+				"all families when empty",
+			EnvVars: []string{"DCGM_EXPORTER_DUAL_NAMESPACE_FAMILIES"},
+		},
+		&cli.StringFlag{
+			Name:  CLIMemoryWatermark,
+			Value: "",
+			Usage: "RSS of the exporter above which the optional features are shed, in order: process metrics, " +
+				"pod labels, DCP metrics. They are restored when the memory recovers, e.g. '1Gi'. " +
+				"Empty disables the watermark",
+			EnvVars: []string{"DCGM_EXPORTER_MEMORY_WATERMARK"},
+		},
+		&cli.IntFlag{
+			Name:  CLIPushQueueSize,
+			Value: pushqueue.DefaultCapacity,
+			Usage: "Number of series (remote write) or metric families (OTLP) queued per push endpoint. " +
+				"The oldest ones are dropped when a slow endpoint fills the queue",
+			EnvVars: []string{"DCGM_EXPORTER_PUSH_QUEUE_SIZE"},
+		},
+		&cli.IntFlag{
+			Name:    CLIPushBatchSize,
+			Value:   pushqueue.DefaultBatchSize,
+			Usage:   "Maximal number of series (remote write) or metric families (OTLP) of a push request",
+			EnvVars: []string{"DCGM_EXPORTER_PUSH_BATCH_SIZE"},
+		},
+		&cli.StringFlag{
+			Name:    CLIPushFlushInterval,
+			Value:   pushqueue.DefaultFlushInterval.String(),
+			Usage:   "Interval at which the push queues are flushed when the batch is not full",
+			EnvVars: []string{"DCGM_EXPORTER_PUSH_FLUSH_INTERVAL"},
+		},
+		&cli.StringSliceFlag{
+			Name:  CLIDerivedCounters,
+			Value: cli.NewStringSlice(),
+			Usage: fmt.Sprintf("Cumulative fields to derive gauges from between collect intervals, in the format "+
+				"'<field>=<mode>'. Possible modes: '%s' (<field>_DELTA), '%s' (<field>_RATE, per second), "+
+				"e.g. 'DCGM_FI_PROF_PCIE_TX_BYTES=rate'",
+				appconfig.DerivedCounterModeDelta, appconfig.DerivedCounterModeRate),
+			EnvVars: []string{"DCGM_EXPORTER_DERIVED_COUNTERS"},
+		},
+		&cli.StringSliceFlag{
+			Name:  CLIExtraLabels,
+			Value: cli.NewStringSlice(),
+			Usage: "Static labels added to every metric, in the format '<name>=<value>', " +
+				"e.g. 'cluster=prod,region=us-east'. Labels set by the exporter, e.g. pod labels, take precedence",
+			EnvVars: []string{"DCGM_EXPORTER_EXTRA_LABELS"},
+		},
+		&cli.StringFlag{
+			Name:  CLIRelabelConfig,
+			Value: "",
+			Usage: "YAML file of relabel rules renaming families, dropping metrics and copying or renaming labels " +
+				"before rendering. The rules are reloaded when the file changes",
+			EnvVars: []string{"DCGM_EXPORTER_RELABEL_CONFIG"},
+		},
+		&cli.StringFlag{
+			Name:  CLIConfigFile,
+			Value: "",
+			Usage: "YAML configuration file whose keys are the names of the flags, e.g. 'kubernetes: true'. " +
+				"Flags set on the command line or with their environment variable take precedence. " +
+				"The configuration is reloaded when the file changes",
+			EnvVars: []string{"DCGM_EXPORTER_CONFIG_FILE"},
+		},
+		&cli.StringFlag{
+			Name:  CLIParquetDirectory,
+			Value: "",
+			Usage: "Directory the metrics are appended to as Parquet files, one row group per interval, " +
+				"with the timestamp, field, gpu, labels (JSON) and value columns. A file is readable once rotated. " +
+				"Empty disables the Parquet sink",
+			EnvVars: []string{"DCGM_EXPORTER_PARQUET_DIRECTORY"},
+		},
+		&cli.StringFlag{
+			Name:    CLIParquetInterval,
+			Value:   "",
+			Usage:   "Interval at which the metrics are appended to the Parquet file, the collect interval if empty",
+			EnvVars: []string{"DCGM_EXPORTER_PARQUET_INTERVAL"},
+		},
+		&cli.StringFlag{
+			Name:    CLIParquetRotationInterval,
+			Value:   "1h",
+			Usage:   "Interval at which the current Parquet file is completed and a new one is started",
+			EnvVars: []string{"DCGM_EXPORTER_PARQUET_ROTATION_INTERVAL"},
+		},
+		&cli.IntFlag{
+			Name:    CLIParquetRetention,
+			Value:   24,
+			Usage:   "Number of completed Parquet files kept, the oldest ones are removed (0 = no cleanup)",
+			EnvVars: []string{"DCGM_EXPORTER_PARQUET_RETENTION"},
+		},
 	}
 
 	if runtime.GOOS == "linux" {
@@ -365,6 +959,12 @@ func NewApp(buildVersion ...string) *cli.App {
 		return nil
 	}
 
+	c.Commands = []*cli.Command{
+		newSupportBundleCommand(c.Version),
+		newProbeCommand(),
+		newSoakCommand(),
+	}
+
 	c.Action = func(c *cli.Context) error {
 		return action(c)
 	}
@@ -424,13 +1024,17 @@ func configureLogger(c *cli.Context) error {
 // StartDCGMExporterWithSignalSource starts the exporter with a custom signal source.
 // This variant allows dependency injection for testing.
 func StartDCGMExporterWithSignalSource(c *cli.Context, sigSource SignalSource) error {
-	if err := configureLogger(c); err != nil {
+	configCtx, err := withConfigFile(c)
+	if err != nil {
+		return err
+	}
+	if err := configureLogger(configCtx); err != nil {
 		return err
 	}
 
 	// Use OS signals if not provided (production path)
 	if sigSource == nil {
-		sigSource = NewOSSignalSource(syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGHUP)
+		sigSource = NewOSSignalSource(syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGHUP, syscall.SIGUSR2)
 	}
 	defer sigSource.Cleanup()
 
@@ -446,6 +1050,21 @@ func StartDCGMExporterWithSignalSource(c *cli.Context, sigSource SignalSource) e
 		return err
 	}
 
+	events.SetCapacity(config.EventLogSize)
+	if config.JournalEvents {
+		sink, err := events.NewJournalSink()
+		if err != nil {
+			slog.Warn("The events will not be written to the systemd journal", "error", err)
+		} else {
+			events.AddSink(sink.Write)
+		}
+	}
+
+	// The NVML-only mode has a lifecycle of its own, DCGM is never initialized
+	if config.NVMLOnly {
+		return startNVMLOnlyExporter(config, sigSource)
+	}
+
 	// Validate prerequisites once
 	if !config.DisableStartupValidate {
 		err = prerequisites.Validate()
@@ -459,9 +1078,11 @@ func StartDCGMExporterWithSignalSource(c *cli.Context, sigSource SignalSource) e
 
 	// Create cleanup function that calls the CURRENT provider's Cleanup method
 	// This is critical to avoid closure capture bugs when reinitializing DCGM
-	// during GPU bind/unbind cycles.
+	// during GPU bind/unbind cycles. There is no provider while the exporter reconnects to DCGM.
 	dcgmCleanup := func() {
-		dcgmprovider.Client().Cleanup()
+		if client := dcgmprovider.Client(); client != nil {
+			client.Cleanup()
+		}
 	}
 
 	// NOTE: dcgmCleanup is managed by GPU topology change handler if GPU watching is enabled
@@ -470,21 +1091,45 @@ func StartDCGMExporterWithSignalSource(c *cli.Context, sigSource SignalSource) e
 		defer dcgmCleanup()
 	}
 
-	// Initialize NVML Provider Instance only if Kubernetes mode is enabled
-	// NVML is only needed for MIG device UUID parsing in Kubernetes environments
-	if config.Kubernetes {
-		err = nvmlprovider.Initialize()
-		if err != nil && !config.DisableStartupValidate {
-			return err
-		}
-		defer nvmlprovider.Client().Cleanup()
-		slog.Info("NVML provider successfully initialized for Kubernetes MIG support")
+	// Initialize NVML Provider Instance only if Kubernetes mode or the MIG fragmentation metrics are enabled
+	// NVML is only needed for MIG device UUID parsing in Kubernetes environments and for the GPU instance
+	// placements, it is initialized in the background so that a slow or flaky driver doesn't delay the
+	// readiness; the MIG device parsing degrades gracefully (metrics are mapped to the pods of the parent GPU)
+	// and the MIG fragmentation metrics are skipped until it is ready
+	if (config.Kubernetes || config.MIGFragmentationMetrics) && !config.CPUOnly {
+		nvmlCtx, nvmlCancel := context.WithCancel(context.Background())
+		var nvmlWg sync.WaitGroup
+		// Set by the initialization goroutine, read once it is done
+		nvmlInitialized := false
+		nvmlWg.Add(1)
+		go func() {
+			defer nvmlWg.Done()
+			err := nvmlprovider.InitializeWithRetry(nvmlCtx, nvmlInitRetryInterval, nvmlInitMaxRetryInterval)
+			if err != nil {
+				slog.Warn("NVML provider not initialized", slog.String("error", err.Error()))
+				return
+			}
+			nvmlInitialized = true
+			slog.Info("NVML provider successfully initialized for MIG support")
+		}()
+		// NVML is only shut down if it was initialized, the initialization may have failed or been cancelled
+		defer func() {
+			nvmlCancel()
+			nvmlWg.Wait()
+			if nvmlInitialized {
+				nvmlprovider.Client().Cleanup()
+			}
+		}()
+	} else if config.CPUOnly {
+		slog.Info("NVML provider skipped (running in CPU-only mode)")
 	} else {
-		slog.Info("NVML provider skipped (not running in Kubernetes mode)")
+		slog.Info("NVML provider skipped (not running in Kubernetes mode, MIG fragmentation metrics disabled)")
 	}
 
 	slog.Info("DCGM successfully initialized!")
 
+	validateDeviceOptions(config, 0)
+
 	ctx := context.Background()
 
 	// Query DCGM profiling metrics at startup
@@ -492,12 +1137,24 @@ func StartDCGMExporterWithSignalSource(c *cli.Context, sigSource SignalSource) e
 	queryDCPMetrics(config, 0)
 
 	// Build initial registry
-	initialRegistry, deviceWatchListManager, err := buildRegistry(ctx, c, config)
+	cs, err := getCounters(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	initialRegistry, deviceWatchListManager, err := buildRegistry(cs, config)
 	if err != nil {
 		return err
 	}
 	defer initialRegistry.Cleanup()
 
+	// Load the relabel rules before the first scrape
+	if config.RelabelConfigFile != "" {
+		if err := relabel.Load(config.RelabelConfigFile); err != nil {
+			return err
+		}
+	}
+
 	// Create metrics server (will run throughout entire lifecycle)
 	metricsServer, serverCleanup, err := server.NewMetricsServer(config, deviceWatchListManager, initialRegistry)
 	if err != nil {
@@ -505,6 +1162,35 @@ func StartDCGMExporterWithSignalSource(c *cli.Context, sigSource SignalSource) e
 	}
 	defer serverCleanup()
 
+	logEffectiveConfig(ctx, metricsServer, config, 0)
+
+	reloadCanaryRegistry(ctx, metricsServer, config)
+	defer releaseCanaryRegistry(metricsServer)
+
+	otlpExporter, err := newOTLPExporter(ctx, config, metricsServer)
+	if err != nil {
+		return err
+	}
+
+	diagRunner, err := newDiagRunner(config, metricsServer)
+	if err != nil {
+		return err
+	}
+
+	remoteWriter, err := newRemoteWriter(config, metricsServer)
+	if err != nil {
+		return err
+	}
+
+	parquetSink, err := newParquetSink(config, metricsServer)
+	if err != nil {
+		return err
+	}
+
+	if err := newProbe(config, metricsServer); err != nil {
+		return err
+	}
+
 	// Start HTTP server (runs continuously until shutdown signal)
 	var serverWg sync.WaitGroup
 	stop := make(chan interface{})
@@ -525,11 +1211,101 @@ func StartDCGMExporterWithSignalSource(c *cli.Context, sigSource SignalSource) e
 	fileWatcher := watcher.NewFileWatcher(config.CollectorsFile)
 	runWatcher(watcherCtx, fileWatcher, func() {
 		slog.Info("Config file changed - triggering hot reload")
-		if err := hotReload(watcherCtx, metricsServer, c, dcgmCleanup); err != nil {
+		if err := hotReload(watcherCtx, metricsServer, c, dcgmCleanup, reloadTriggerFileChange); err != nil {
 			slog.Error("Hot reload failed", slog.String("error", err.Error()))
 		}
 	}, &watcherWg)
 
+	// Configuration file watcher (optional) - hot reload on change
+	if configFile := c.String(CLIConfigFile); configFile != "" {
+		runWatcher(watcherCtx, watcher.NewFileWatcher(configFile), func() {
+			slog.Info("Configuration file changed - triggering hot reload")
+			if err := hotReload(watcherCtx, metricsServer, c, dcgmCleanup, reloadTriggerConfigFileChange); err != nil {
+				slog.Error("Hot reload failed", slog.String("error", err.Error()))
+			}
+		}, &watcherWg)
+	}
+
+	// Pausing the collection of a GPU (optional) - the registry is rebuilt without the watches of the paused GPUs
+	if config.DevicePauseAPI {
+		metricsServer.SetDevicePauseHandler(func() {
+			if err := hotReload(watcherCtx, metricsServer, c, dcgmCleanup, reloadTriggerDevicePause); err != nil {
+				slog.Error("Hot reload failed", slog.String("error", err.Error()))
+			}
+		})
+	}
+
+	// Relabel file watcher (optional) - the rules are swapped without rebuilding the registry
+	if config.RelabelConfigFile != "" {
+		runWatcher(watcherCtx, watcher.NewFileWatcher(config.RelabelConfigFile), func() {
+			slog.Info("Relabel file changed - reloading the rules")
+			if err := relabel.Load(config.RelabelConfigFile); err != nil {
+				slog.Error("Keeping the previous relabel rules", slog.String(logging.ErrorKey, err.Error()))
+			}
+		}, &watcherWg)
+	}
+
+	// OTLP push (optional) - pushes the same metrics as served on /metrics
+	if otlpExporter != nil {
+		watcherWg.Add(1)
+		go func() {
+			defer watcherWg.Done()
+			otlpExporter.Run(watcherCtx)
+		}()
+	}
+
+	// DCGM diagnostics (optional) - periodic runs, on-demand runs are triggered by /diag
+	if diagRunner != nil {
+		watcherWg.Add(1)
+		go func() {
+			defer watcherWg.Done()
+			diagRunner.Run(watcherCtx)
+		}()
+	}
+
+	// Prometheus remote write push (optional) - fans the metrics out to the configured endpoints
+	if remoteWriter != nil {
+		watcherWg.Add(1)
+		go func() {
+			defer watcherWg.Done()
+			remoteWriter.Run(watcherCtx)
+		}()
+	}
+
+	// Parquet sink (optional) - appends the metrics to rotating files for offline analysis
+	if parquetSink != nil {
+		watcherWg.Add(1)
+		go func() {
+			defer watcherWg.Done()
+			parquetSink.Run(watcherCtx)
+		}()
+	}
+
+	// Remote hostengine failover (optional) - reconnects to the first reachable hostengine when the connection is lost
+	if config.UseRemoteHE && len(config.RemoteHEEndpoints) > 1 {
+		hostengineWatcher := watcher.NewHostengineWatcher(watcher.WithCheckInterval(config.RemoteHECheckInterval))
+		runWatcher(watcherCtx, hostengineWatcher, func() {
+			handleGPUTopologyChange(watcherCtx, metricsServer, c, dcgmCleanup, reloadTriggerHostengineFailover)
+		}, &watcherWg)
+		slog.Info("Remote hostengine failover configured",
+			slog.Any("endpoints", config.RemoteHEEndpoints),
+			slog.String("connected", config.RemoteHEInfo))
+	}
+
+	// DCGM reconnection - rebuilds the registry when the collectors report the connection as lost
+	runDCGMReconnector(watcherCtx, metricsServer, c, dcgmCleanup, &watcherWg)
+
+	// Memory watermark (optional) - sheds the optional features rather than getting OOM-killed
+	if config.MemoryWatermark > 0 {
+		guard := memguard.NewGuard(config.MemoryWatermark, memguard.DefaultCheckInterval)
+		watcherWg.Add(1)
+		go func() {
+			defer watcherWg.Done()
+			guard.Run(watcherCtx)
+		}()
+		slog.Info("Memory watermark configured", slog.Uint64("watermark", config.MemoryWatermark))
+	}
+
 	// GPU bind/unbind watcher (optional) - handles GPU topology changes
 	if config.EnableGPUBindUnbindWatch {
 		gpuWatcher := watcher.NewGPUBindUnbindWatcher(
@@ -538,23 +1314,26 @@ func StartDCGMExporterWithSignalSource(c *cli.Context, sigSource SignalSource) e
 		runGPUWatcher(watcherCtx, gpuWatcher, metricsServer, c, dcgmCleanup, &watcherWg)
 	}
 
-	// Wait for shutdown signal (SIGTERM, SIGINT) - ignore SIGHUP for compatibility
-	sigs := sigSource.Signals()
-	for {
-		sig := <-sigs
-		slog.Info("Received signal", slog.String("signal", sig.String()))
+	// MIG configuration watcher (optional) - rebuilds the collectors for the new GPU and compute instances
+	if config.EnableMIGWatch {
+		migWatcher := watcher.NewMIGWatcher(watcher.WithMIGPollInterval(config.MIGWatchPollInterval))
+		runWatcher(watcherCtx, migWatcher, func() {
+			handleGPUTopologyChange(watcherCtx, metricsServer, c, dcgmCleanup, reloadTriggerMIGChange)
+		}, &watcherWg)
+	}
 
-		if sig == syscall.SIGHUP {
-			// SIGHUP triggers hot reload instead of full restart
-			slog.Info("SIGHUP received - triggering hot reload")
-			if err := hotReload(watcherCtx, metricsServer, c, dcgmCleanup); err != nil {
-				slog.Error("Hot reload failed", slog.String("error", err.Error()))
-			}
-			continue
+	// Wait for shutdown signal (SIGTERM, SIGINT) - the other signals and control events are dispatched
+	dispatcher := &controlDispatcher{
+		ctx:         watcherCtx,
+		server:      metricsServer,
+		c:           c,
+		config:      config,
+		dcgmCleanup: dcgmCleanup,
+	}
+	for sig := range sigSource.Signals() {
+		if dispatcher.dispatch(sig) {
+			break
 		}
-
-		// SIGTERM/SIGINT/SIGQUIT - graceful shutdown
-		break
 	}
 
 	// Graceful shutdown
@@ -583,13 +1362,104 @@ func startDCGMExporter(c *cli.Context) error {
 	return StartDCGMExporterWithSignalSource(c, nil)
 }
 
+// newOTLPExporter creates the exporter pushing the metrics of the server to the OTLP collector,
+// or returns nil if no OTLP endpoint is configured.
+func newOTLPExporter(
+	ctx context.Context, config *appconfig.Config, metricsServer *server.MetricsServer,
+) (*otlp.Exporter, error) {
+	if config.OTLPEndpoint == "" {
+		return nil, nil
+	}
+
+	hostName, err := hostname.GetHostname(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hostname: %w", err)
+	}
+
+	exporter, err := otlp.NewExporter(ctx, config, hostName, metricsServer.WriteMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("OTLP exporter created",
+		slog.String("endpoint", config.OTLPEndpoint),
+		slog.String("protocol", string(config.OTLPProtocol)),
+		slog.Duration("interval", config.OTLPInterval))
+
+	return exporter, nil
+}
+
+// newRemoteWriter creates the exporter pushing the metrics of the server to the remote write endpoints,
+// or returns nil if no endpoint is configured.
+func newRemoteWriter(config *appconfig.Config, metricsServer *server.MetricsServer) (*remotewrite.Exporter, error) {
+	if len(config.RemoteWriteEndpoints) == 0 {
+		return nil, nil
+	}
+
+	exporter, err := remotewrite.NewExporter(config, metricsServer.WriteMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, endpoint := range config.RemoteWriteEndpoints {
+		slog.Info("Remote write endpoint configured",
+			slog.String("url", appconfig.RedactURL(endpoint.URL)),
+			slog.Any("namespaces", endpoint.Namespaces),
+			slog.Duration("interval", config.RemoteWriteInterval))
+	}
+
+	return exporter, nil
+}
+
+// newParquetSink creates the sink appending the metrics of the server to Parquet files,
+// or returns nil if no directory is configured.
+func newParquetSink(config *appconfig.Config, metricsServer *server.MetricsServer) (*parquet.Sink, error) {
+	if config.ParquetDirectory == "" {
+		return nil, nil
+	}
+
+	sink, err := parquet.NewSink(config, metricsServer.WriteMetrics)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Parquet sink configured",
+		slog.String("directory", config.ParquetDirectory),
+		slog.Duration("interval", config.ParquetInterval),
+		slog.Duration("rotation_interval", config.ParquetRotationInterval),
+		slog.Int("retention", config.ParquetRetention))
+
+	return sink, nil
+}
+
+// newDiagRunner creates the runner of the DCGM diagnostics and sets it on the metrics server,
+// or returns nil when the diagnostics are disabled.
+func newDiagRunner(config *appconfig.Config, metricsServer *server.MetricsServer) (*diag.Runner, error) {
+	if config.DiagLevel == 0 {
+		return nil, nil
+	}
+
+	runner, err := diag.NewRunner(config.DiagLevel, config.DiagInterval)
+	if err != nil {
+		return nil, err
+	}
+	metricsServer.SetDiagRunner(runner)
+
+	slog.Info("DCGM diagnostics runner created",
+		slog.Int("level", config.DiagLevel),
+		slog.Duration("interval", config.DiagInterval))
+
+	return runner, nil
+}
+
 // buildRegistry creates a new registry with current GPU topology.
 // Called at: startup, hot reload (SIGHUP/file change), GPU bind event.
 // Note: Does NOT query DCP metrics - caller must do this before calling.
-func buildRegistry(ctx context.Context, _ *cli.Context, config *appconfig.Config) (*registry.Registry, devicewatchlistmanager.Manager, error) {
+func buildRegistry(cs *counters.CounterSet, config *appconfig.Config) (*registry.Registry, devicewatchlistmanager.Manager, error) {
 	slog.Info("Building registry for current GPU topology")
 
-	cs := getCounters(ctx, config)
+	// GPUs bound to vfio-pci are left out of the watches of the registry
+	passthrough.Refresh()
 
 	deviceWatchListManager := startDeviceWatchListManager(cs, config)
 
@@ -601,6 +1471,7 @@ func buildRegistry(ctx context.Context, _ *cli.Context, config *appconfig.Config
 	cf := collector.InitCollectorFactory(cs, deviceWatchListManager, hostName, config)
 
 	cRegistry := registry.NewRegistry()
+	cRegistry.SetCollectorLimits(collectorLimits(config))
 	for _, entityCollector := range cf.NewCollectors() {
 		cRegistry.Register(entityCollector)
 	}
@@ -611,13 +1482,109 @@ func buildRegistry(ctx context.Context, _ *cli.Context, config *appconfig.Config
 	return cRegistry, deviceWatchListManager, nil
 }
 
+// collectorLimits returns the timeout and the circuit breaker of the collectors set by the configuration.
+func collectorLimits(config *appconfig.Config) registry.CollectorLimits {
+	return registry.CollectorLimits{
+		Timeout:          config.CollectorTimeout,
+		BreakerThreshold: config.CollectorBreakerThreshold,
+		BreakerCycles:    config.CollectorBreakerCycles,
+	}
+}
+
+// writeDiagnosticSnapshot dumps the goroutines, the topology, the registry and the state of the watchers
+// to the dump directory.
+func writeDiagnosticSnapshot(server *server.MetricsServer, config *appconfig.Config) {
+	watchers := map[string]any{
+		"collectors_file":             config.CollectorsFile,
+		"gpu_bind_unbind_watch":       config.EnableGPUBindUnbindWatch,
+		"mig_watch":                   config.EnableMIGWatch,
+		"pending_gpu_topology_change": pendingGPUTopologyChange.Load(),
+		"reload_count":                hotReloadCounter.Load(),
+	}
+
+	file, err := server.WriteDiagnosticSnapshot(watchers)
+	if err != nil {
+		slog.Error("Failed to write the diagnostic snapshot", slog.String("error", err.Error()))
+		return
+	}
+	slog.Info("Diagnostic snapshot written", slog.String("file", file))
+}
+
+// logEffectiveConfig logs the configuration the exporter runs with as a single record, with the secrets
+// redacted, and serves it at /api/v1/config/effective.
+// Called at: startup, hot reload, GPU topology change.
+func logEffectiveConfig(ctx context.Context, server *server.MetricsServer, config *appconfig.Config, reloadID uint64) {
+	slog.InfoContext(ctx, "Effective configuration",
+		slog.Uint64("reload_id", reloadID),
+		slog.Any("config", config.Redacted()))
+	server.SetEffectiveConfig(config)
+}
+
+// reloadCanaryRegistry rebuilds the registry of the candidate counters configuration served by /canary.
+// The canary never affects /metrics, a failure only makes the report unavailable.
+func reloadCanaryRegistry(ctx context.Context, server *server.MetricsServer, config *appconfig.Config) {
+	if config.CanaryCollectorsFile == "" {
+		return
+	}
+
+	releaseCanaryRegistry(server)
+
+	canaryConfig := *config
+	canaryConfig.CollectorsFile = config.CanaryCollectorsFile
+	canaryConfig.ConfigMapData = undefinedConfigMapData
+
+	cs, err := getCounters(ctx, &canaryConfig)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to read the candidate counters - the canary report is unavailable",
+			slog.String("collectors", config.CanaryCollectorsFile),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	canaryRegistry, _, err := buildRegistry(cs, &canaryConfig)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to build the candidate registry - the canary report is unavailable",
+			slog.String("collectors", config.CanaryCollectorsFile),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	server.SwapCanaryRegistry(canaryRegistry)
+}
+
+// releaseCanaryRegistry removes the registry of the candidate counters configuration and releases its watches.
+func releaseCanaryRegistry(server *server.MetricsServer) {
+	if oldRegistry := server.SwapCanaryRegistry(nil); oldRegistry != nil {
+		oldRegistry.Cleanup()
+	}
+}
+
 var (
 	hotReloadCounter  atomic.Uint64
 	lastReloadTime    atomic.Int64
 	minReloadInterval = 2 * time.Second // Prevent rapid successive reloads while allowing reasonably fast recovery
 
+	// Backoff of the NVML initialization retries in Kubernetes mode
+	nvmlInitRetryInterval    = time.Second
+	nvmlInitMaxRetryInterval = 30 * time.Second
+
 	// Pending event tracking for GPU topology changes that occur during hot reload
 	pendingGPUTopologyChange atomic.Bool
+
+	// errReloadSkipped is returned by handleGPUTopologyChange when the reset is rate limited or queued
+	errReloadSkipped = errors.New("reload skipped")
+
+	// gpuEntityTypes are the entity types whose watch lists change when a GPU is bound or unbound
+	gpuEntityTypes = []dcgm.Field_Entity_Group{dcgm.FE_GPU, dcgm.FE_LINK}
+
+	// labelNameRegex matches the valid Prometheus label names
+	labelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	// reservedLabelNames are the labels rendered by the exporter for the entities, which can't be overridden
+	reservedLabelNames = []string{
+		"gpu", "UUID", "uuid", "gpu_uuid", "pci_bus_id", "device", "modelName", "model_name", "GPU_I_PROFILE",
+		"GPU_I_ID", "compute_instance_id", "Hostname", "hostname", "nvlink", "nvswitch", "cpu", "cpucore", "vgpu",
+	}
 )
 
 // logTopologyInfo logs comprehensive information about the loaded GPU topology
@@ -654,7 +1621,7 @@ func processPendingEvents(ctx context.Context, server *server.MetricsServer, c *
 	if pendingGPUTopologyChange.Load() {
 		pendingGPUTopologyChange.Store(false)
 		slog.Info("Processing queued GPU topology change event")
-		handleGPUTopologyChange(ctx, server, c, dcgmCleanup)
+		handleGPUTopologyChange(ctx, server, c, dcgmCleanup, reloadTriggerGPUTopologyChange)
 		return true
 	}
 
@@ -664,7 +1631,19 @@ func processPendingEvents(ctx context.Context, server *server.MetricsServer, c *
 // hotReload rebuilds the registry when configuration file changes (SIGHUP or file watcher).
 // During rebuild, /metrics returns empty responses (HTTP 200, no metrics) for 2-3 seconds.
 // Note: Does NOT reset DCGM connection (unlike handleGPUTopologyChange which does full reset).
-func hotReload(ctx context.Context, server *server.MetricsServer, c *cli.Context, dcgmCleanup func()) (err error) {
+func hotReload(
+	ctx context.Context, server *server.MetricsServer, c *cli.Context, dcgmCleanup func(), trigger string,
+) (err error) {
+	// Record the reload in the reload history - deferred first to see the error set by the panic recovery
+	var reloadID uint64
+	var startTime time.Time
+	var deviceWatchListMgr devicewatchlistmanager.Manager
+	defer func() {
+		if reloadID != 0 {
+			server.RecordReload(reloadID, trigger, startTime, deviceWatchListMgr, err)
+		}
+	}()
+
 	// Panic recovery for hot reload - critical to prevent exporter crash
 	defer func() {
 		if r := recover(); r != nil {
@@ -687,6 +1666,7 @@ func hotReload(ctx context.Context, server *server.MetricsServer, c *cli.Context
 	// Safeguard 1: Check if reload is already in progress
 	if server.IsReloadInProgress() {
 		slog.Warn("Hot reload already in progress - ignoring duplicate request")
+		server.RecordSkippedReload(0, trigger, "reload already in progress")
 		return nil
 	}
 
@@ -699,12 +1679,13 @@ func hotReload(ctx context.Context, server *server.MetricsServer, c *cli.Context
 		slog.Warn("Hot reload rate limited - too soon after previous reload",
 			slog.Duration("time_since_last", timeSinceLast),
 			slog.Duration("min_interval", minReloadInterval))
+		server.RecordSkippedReload(0, trigger, "rate limited")
 		return nil
 	}
 
-	reloadID := hotReloadCounter.Add(1)
+	reloadID = hotReloadCounter.Add(1)
 	lastReloadTime.Store(now.Unix())
-	startTime := time.Now()
+	startTime = time.Now()
 
 	slog.Info("Hot reload triggered - building new registry in background",
 		slog.Uint64("reload_id", reloadID))
@@ -716,6 +1697,15 @@ func hotReload(ctx context.Context, server *server.MetricsServer, c *cli.Context
 	if err != nil {
 		return fmt.Errorf("failed to read config during hot reload: %w", err)
 	}
+	logEffectiveConfig(ctx, server, config, reloadID)
+
+	// Read the counters before clearing the registry: with a broken configuration,
+	// the previous registry stays active until the configuration is fixed
+	cs, err := getCounters(ctx, config)
+	server.SetCountersConfigError(err)
+	if err != nil {
+		return fmt.Errorf("keeping the previous registry active: %w", err)
+	}
 
 	// Step 1: Cleanup old registry (ensures only one registry exists at a time)
 	slog.Info("Clearing registry - /metrics will return empty until rebuild completes",
@@ -735,7 +1725,7 @@ func hotReload(ctx context.Context, server *server.MetricsServer, c *cli.Context
 	slog.Debug("Using DCP metrics from startup (not re-querying)",
 		slog.Uint64("reload_id", reloadID))
 
-	newRegistry, deviceWatchListMgr, err := buildRegistry(ctx, c, config)
+	newRegistry, deviceWatchListMgr, err := buildRegistry(cs, config)
 	if err != nil {
 		return fmt.Errorf("failed to build new registry during hot reload: %w", err)
 	}
@@ -744,6 +1734,7 @@ func hotReload(ctx context.Context, server *server.MetricsServer, c *cli.Context
 	slog.Info("Activating new registry - /metrics now serves updated GPU metrics",
 		slog.Uint64("reload_id", reloadID))
 	server.SetRegistry(newRegistry)
+	reloadCanaryRegistry(ctx, server, config)
 	duration := time.Since(startTime)
 
 	slog.Info("Hot reload complete",
@@ -768,7 +1759,12 @@ func hotReload(ctx context.Context, server *server.MetricsServer, c *cli.Context
 //   - GPU unbind: cleanup succeeds, reinit fails (no GPU), /metrics returns empty
 //   - GPU bind: cleanup succeeds, reinit succeeds, /metrics serves new GPU
 //   - GPU swap: cleanup succeeds, reinit succeeds with new GPU, /metrics serves new GPU
-func handleGPUTopologyChange(ctx context.Context, server *server.MetricsServer, c *cli.Context, dcgmCleanup func()) {
+//
+// It returns errReloadSkipped when the reset is rate limited or queued behind a reload in progress.
+// When DCGM can't be reinitialized, the connection is reported as lost so that the reconnection retries.
+func handleGPUTopologyChange(
+	ctx context.Context, server *server.MetricsServer, c *cli.Context, dcgmCleanup func(), trigger string,
+) error {
 	reloadID := hotReloadCounter.Add(1)
 
 	slog.InfoContext(ctx, "GPU topology change detected - full reset",
@@ -780,7 +1776,8 @@ func handleGPUTopologyChange(ctx context.Context, server *server.MetricsServer,
 		slog.WarnContext(ctx, "Ignoring topology change - too soon after last reload",
 			slog.Uint64("reload_id", reloadID),
 			slog.Duration("time_since_last", time.Since(lastReload)))
-		return
+		server.RecordSkippedReload(reloadID, trigger, "rate limited")
+		return errReloadSkipped
 	}
 	lastReloadTime.Store(time.Now().UnixNano())
 
@@ -789,11 +1786,19 @@ func handleGPUTopologyChange(ctx context.Context, server *server.MetricsServer,
 		slog.WarnContext(ctx, "Reload in progress - queuing topology change event",
 			slog.Uint64("reload_id", reloadID))
 		pendingGPUTopologyChange.Store(true)
-		return
+		server.RecordSkippedReload(reloadID, trigger, "reload in progress - queued")
+		return errReloadSkipped
 	}
 	server.SetReloadInProgress(true)
 	defer server.SetReloadInProgress(false)
 
+	var err error
+	var deviceWatchListMgr devicewatchlistmanager.Manager
+	resetStartTime := time.Now()
+	defer func() {
+		server.RecordReload(reloadID, trigger, resetStartTime, deviceWatchListMgr, err)
+	}()
+
 	// Step 1: Cleanup old registry (wait for in-flight scrapes)
 	slog.InfoContext(ctx, "Clearing registry - /metrics will return empty during reset",
 		slog.Uint64("reload_id", reloadID))
@@ -801,6 +1806,7 @@ func handleGPUTopologyChange(ctx context.Context, server *server.MetricsServer,
 	if oldRegistry != nil {
 		oldRegistry.Cleanup()
 	}
+	releaseCanaryRegistry(server)
 
 	// Step 2: Cleanup DCGM completely (release all GPU resources)
 	slog.InfoContext(ctx, "Cleaning up DCGM resources",
@@ -814,12 +1820,20 @@ func handleGPUTopologyChange(ctx context.Context, server *server.MetricsServer,
 		slog.ErrorContext(ctx, "Failed to read config",
 			slog.Uint64("reload_id", reloadID),
 			slog.String("error", err.Error()))
-		return
+		return err
 	}
+	logEffectiveConfig(ctx, server, config, reloadID)
 
 	slog.InfoContext(ctx, "Reinitializing DCGM",
 		slog.Uint64("reload_id", reloadID))
-	dcgmprovider.Initialize(config)
+	if err = dcgmprovider.TryInitialize(config); err != nil {
+		slog.ErrorContext(ctx, "Failed to reinitialize DCGM",
+			slog.Uint64("reload_id", reloadID),
+			slog.String("error", err.Error()))
+		// Keep registry as nil - /metrics will return empty until the reconnection succeeds
+		dcgmprovider.ReportConnectionLost()
+		return err
+	}
 
 	// Step 3b: Reinitialize NVML
 	if config.Kubernetes && config.KubernetesVirtualGPUs {
@@ -836,6 +1850,7 @@ func handleGPUTopologyChange(ctx context.Context, server *server.MetricsServer,
 
 	// Step 4: Query DCP metrics (safe now - GPU is stable after topology change)
 	queryDCPMetrics(config, reloadID)
+	validateDeviceOptions(config, reloadID)
 
 	// Step 5: Build new registry with current GPU topology
 	// This will create empty registry if no GPUs present
@@ -843,19 +1858,31 @@ func handleGPUTopologyChange(ctx context.Context, server *server.MetricsServer,
 		slog.Uint64("reload_id", reloadID))
 
 	startTime := time.Now()
-	newRegistry, deviceWatchListMgr, err := buildRegistry(ctx, c, config)
+	cs, err := getCounters(ctx, config)
+	server.SetCountersConfigError(err)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to read counters",
+			slog.Uint64("reload_id", reloadID),
+			slog.String("error", err.Error()))
+		// Keep registry as nil - /metrics will return empty
+		return err
+	}
+
+	newRegistry, deviceWatchListMgr, err := buildRegistry(cs, config)
 	if err != nil {
 		slog.ErrorContext(ctx, "Failed to build registry",
 			slog.Uint64("reload_id", reloadID),
 			slog.String("error", err.Error()))
 		// Keep registry as nil - /metrics will return empty
-		return
+		return err
 	}
 
 	// Step 6: Activate new registry (/metrics now serves current GPU state)
 	slog.InfoContext(ctx, "Activating new registry - /metrics now serves current GPU topology",
 		slog.Uint64("reload_id", reloadID))
 	server.SetRegistry(newRegistry)
+	server.RequestDRAResync()
+	reloadCanaryRegistry(ctx, server, config)
 	duration := time.Since(startTime)
 
 	slog.InfoContext(ctx, "GPU topology change complete",
@@ -863,10 +1890,116 @@ func handleGPUTopologyChange(ctx context.Context, server *server.MetricsServer,
 		slog.Duration("total_time", duration))
 
 	logTopologyInfo(reloadID, deviceWatchListMgr, duration)
+
+	return nil
+}
+
+// handleGPUChange handles a GPU bind/unbind event reported by the GPU watcher.
+// A change of a single GPU only rebuilds the GPU entity watch lists and collectors (see reloadGPUEntities),
+// everything else falls back to the full reset of handleGPUTopologyChange.
+func handleGPUChange(
+	ctx context.Context, server *server.MetricsServer, c *cli.Context, dcgmCleanup func(), change watcher.GPUChange,
+) {
+	switch {
+	case change.Resolved && len(change.GPUs) == 0 && !change.Completed:
+		// The GPUs are still present, the change is handled once reinitialization completes
+		slog.DebugContext(ctx, "GPU reinitialization started without GPU changes - waiting for completion")
+	case change.Resolved && len(change.GPUs) == 1 && server.HasRegistry():
+		err := reloadGPUEntities(ctx, server, c, change.GPUs[0])
+		if err != nil {
+			slog.WarnContext(ctx, "Partial reload failed - falling back to full reset",
+				slog.Uint64("gpu", uint64(change.GPUs[0])),
+				slog.String("error", err.Error()))
+			handleGPUTopologyChange(ctx, server, c, dcgmCleanup, reloadTriggerGPUTopologyChange)
+		}
+	default:
+		handleGPUTopologyChange(ctx, server, c, dcgmCleanup, reloadTriggerGPUTopologyChange)
+	}
+}
+
+// reloadGPUEntities rebuilds the watch lists and collectors of gpuEntityTypes after a single GPU was bound,
+// unbound or replaced. Unlike handleGPUTopologyChange it keeps the DCGM connection and the registry:
+// the collectors of the healthy GPUs, NvSwitches and CPUs keep serving /metrics while the new collectors
+// are built, and are swapped for them once they are ready.
+// Note: DCP metrics are NOT re-queried (use the last queried metrics).
+func reloadGPUEntities(ctx context.Context, server *server.MetricsServer, c *cli.Context, gpuID uint) (err error) {
+	reloadID := hotReloadCounter.Add(1)
+
+	slog.InfoContext(ctx, "Single GPU change detected - rebuilding GPU collectors",
+		slog.Uint64("reload_id", reloadID),
+		slog.Uint64("gpu", uint64(gpuID)))
+
+	// Safeguard: Don't start if reload already in progress - queue a full reset instead
+	if server.IsReloadInProgress() {
+		slog.WarnContext(ctx, "Reload in progress - queuing topology change event",
+			slog.Uint64("reload_id", reloadID))
+		pendingGPUTopologyChange.Store(true)
+		server.RecordSkippedReload(reloadID, reloadTriggerGPUChange, "reload in progress - queued a full reset")
+		return nil
+	}
+	server.SetReloadInProgress(true)
+	defer server.SetReloadInProgress(false)
+
+	var deviceWatchListMgr devicewatchlistmanager.Manager
+	reloadStartTime := time.Now()
+	defer func() {
+		server.RecordReload(reloadID, reloadTriggerGPUChange, reloadStartTime, deviceWatchListMgr, err)
+	}()
+
+	config, err := contextToConfig(c)
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	logEffectiveConfig(ctx, server, config, reloadID)
+
+	startTime := time.Now()
+
+	hostName, err := hostname.GetHostname(config)
+	if err != nil {
+		return fmt.Errorf("failed to get hostname: %w", err)
+	}
+
+	cs, err := getCounters(ctx, config)
+	server.SetCountersConfigError(err)
+	if err != nil {
+		return err
+	}
+
+	validateDeviceOptions(config, reloadID)
+	deviceWatchListMgr = newDeviceWatchListManager(cs, config, gpuEntityTypes)
+	cf := collector.InitCollectorFactory(cs, deviceWatchListMgr, hostName, config)
+
+	replaced := server.GetRegistry().ReplaceCollectors(gpuEntityTypes, cf.NewCollectors())
+	for _, replacedCollector := range replaced {
+		replacedCollector.Cleanup()
+	}
+	server.RequestDRAResync()
+	reloadCanaryRegistry(ctx, server, config)
+	duration := time.Since(startTime)
+
+	slog.InfoContext(ctx, "GPU collectors rebuilt",
+		slog.Uint64("reload_id", reloadID),
+		slog.Uint64("gpu", uint64(gpuID)),
+		slog.Int("replaced_collectors", len(replaced)),
+		slog.Duration("total_time", duration))
+
+	logTopologyInfo(reloadID, deviceWatchListMgr, duration)
+
+	return nil
 }
 
 func startDeviceWatchListManager(
 	cs *counters.CounterSet, config *appconfig.Config,
+) devicewatchlistmanager.Manager {
+	if config.CPUOnly {
+		return newDeviceWatchListManager(cs, config, devicewatchlistmanager.CPUDeviceTypesToWatch)
+	}
+	return newDeviceWatchListManager(cs, config, devicewatchlistmanager.DeviceTypesToWatch)
+}
+
+// newDeviceWatchListManager creates a watch list manager with watch lists for the given device types only.
+func newDeviceWatchListManager(
+	cs *counters.CounterSet, config *appconfig.Config, deviceTypes []dcgm.Field_Entity_Group,
 ) devicewatchlistmanager.Manager {
 	// Create a list containing DCGM Collector, Exp Collectors and all the label Collectors
 	var allCounters counters.CounterList
@@ -876,11 +2009,19 @@ func startDeviceWatchListManager(
 
 	allCounters = appendDCGMXIDErrorsCountDependency(allCounters, cs)
 	allCounters = appendDCGMClockEventsCountDependency(cs, allCounters)
+	allCounters = appendDCGMEnergyTotalDependency(allCounters, cs)
 
 	deviceWatchListManager = devicewatchlistmanager.NewWatchListManager(allCounters, config)
 	deviceWatcher := devicewatcher.NewDeviceWatcher()
+	deviceWatcher.MIGInstanceMemory = config.MIGInstanceMemory
+
+	for _, deviceType := range deviceTypes {
+		if (deviceType == dcgm.FE_SWITCH || deviceType == dcgm.FE_LINK) &&
+			!config.IsDCGMModuleEnabled(appconfig.DCGMModuleNvSwitch) {
+			slog.Info(fmt.Sprintf("Not collecting %s metrics; DCGM nvswitch module is disabled", deviceType.String()))
+			continue
+		}
 
-	for _, deviceType := range devicewatchlistmanager.DeviceTypesToWatch {
 		err := deviceWatchListManager.CreateEntityWatchList(deviceType, deviceWatcher, int64(config.CollectInterval))
 		if err != nil {
 			slog.Info(fmt.Sprintf("Not collecting %s metrics; %s", deviceType.String(), err))
@@ -933,11 +2074,29 @@ func appendDCGMXIDErrorsCountDependency(
 	return allCounters
 }
 
-func getCounters(ctx context.Context, config *appconfig.Config) *counters.CounterSet {
+// appendDCGMEnergyTotalDependency appends DCGM counters required for the DCGM_EXP_ENERGY_JOULES_TOTAL metric
+func appendDCGMEnergyTotalDependency(
+	allCounters []counters.Counter, cs *counters.CounterSet,
+) []counters.Counter {
+	if len(cs.ExporterCounters) > 0 && containsExporterField(cs.ExporterCounters, counters.DCGMEnergyTotal) {
+		for _, fieldID := range []dcgm.Short{dcgm.DCGM_FI_DEV_POWER_USAGE, dcgm.DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION} {
+			if !containsDCGMField(allCounters, fieldID) {
+				allCounters = append(allCounters,
+					counters.Counter{
+						FieldID: fieldID,
+					})
+			}
+		}
+	}
+	return allCounters
+}
+
+// getCounters reads the counters configuration. Errors are returned instead of exiting,
+// so that a hot reload with a broken configuration keeps the previous registry active.
+func getCounters(ctx context.Context, config *appconfig.Config) (*counters.CounterSet, error) {
 	cs, err := counters.GetCounterSet(ctx, config)
 	if err != nil {
-		slog.Error(err.Error())
-		os.Exit(1)
+		return nil, fmt.Errorf("failed to read counters configuration: %w", err)
 	}
 
 	// Copy labels from DCGM Counters to ExporterCounters
@@ -946,13 +2105,41 @@ func getCounters(ctx context.Context, config *appconfig.Config) *counters.Counte
 			cs.ExporterCounters = append(cs.ExporterCounters, cs.DCGMCounters[i])
 		}
 	}
-	return cs
+	return cs, nil
+}
+
+// validateDeviceOptions reports the devices requested by the GPU, NvSwitch and CPU device options
+// that don't exist in the current topology. Their metrics are not collected until they appear.
+// Called at: startup, GPU topology change.
+func validateDeviceOptions(config *appconfig.Config, reloadID uint64) {
+	err := deviceinfo.ValidateDeviceOptions(
+		config.GPUDeviceOptions, config.SwitchDeviceOptions, config.CPUDeviceOptions, config.UseFakeGPUs,
+	)
+	if err != nil {
+		slog.Error("Device options don't match the current topology",
+			slog.Uint64("reload_id", reloadID),
+			slog.String("error", err.Error()))
+	}
 }
 
 // queryDCPMetrics queries DCGM for supported profiling metric groups.
 // Called at: startup, GPU bind event (NOT regular hot reload - uses startup config).
 // If profiling not supported or query fails, DCP collection is disabled.
 func queryDCPMetrics(config *appconfig.Config, reloadID uint64) {
+	if config.CPUOnly {
+		slog.Info("Not collecting DCP metrics: running in CPU-only mode")
+		config.CollectDCP = false
+		config.MetricGroups = nil
+		return
+	}
+
+	if !config.IsDCGMModuleEnabled(appconfig.DCGMModuleProfiling) {
+		slog.Info("Not collecting DCP metrics: DCGM profiling module is disabled")
+		config.CollectDCP = false
+		config.MetricGroups = nil
+		return
+	}
+
 	slog.Debug("Querying DCGM profiling metric groups", slog.Uint64("reload_id", reloadID))
 
 	// Add panic recovery in case profiling API segfaults during query
@@ -991,6 +2178,224 @@ func queryDCPMetrics(config *appconfig.Config, reloadID uint64) {
 	config.CollectDCP = true
 }
 
+// parseDCGMModules parses the list of DCGM modules. An empty list allows all modules.
+func parseDCGMModules(values []string) ([]appconfig.DCGMModule, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	modules := make([]appconfig.DCGMModule, 0, len(values))
+	for _, value := range values {
+		module := appconfig.DCGMModule(strings.ToLower(strings.TrimSpace(value)))
+		if module == "" {
+			continue
+		}
+		if !slices.Contains(appconfig.DCGMModules, module) {
+			return nil, fmt.Errorf("invalid %s parameter value: %s", CLIDCGMModules, value)
+		}
+		modules = append(modules, module)
+	}
+	return modules, nil
+}
+
+// parseRemoteWriteEndpoints parses the remote write endpoints in the format
+// <url>[;namespaces=<namespace>|<namespace>...]
+func parseRemoteWriteEndpoints(values []string) ([]appconfig.RemoteWriteEndpoint, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	endpoints := make([]appconfig.RemoteWriteEndpoint, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		rawURL, options, _ := strings.Cut(value, ";")
+		u, err := url.Parse(strings.TrimSpace(rawURL))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid %s parameter value: %s", CLIRemoteWrite, appconfig.RedactURL(rawURL))
+		}
+
+		endpoint := appconfig.RemoteWriteEndpoint{URL: u.String()}
+		if options != "" {
+			key, namespaces, found := strings.Cut(options, "=")
+			if !found || strings.TrimSpace(key) != "namespaces" {
+				return nil, fmt.Errorf("invalid %s parameter value: unknown option '%s'", CLIRemoteWrite, options)
+			}
+			for _, namespace := range strings.Split(namespaces, "|") {
+				if namespace = strings.TrimSpace(namespace); namespace != "" {
+					endpoint.Namespaces = append(endpoint.Namespaces, namespace)
+				}
+			}
+			if len(endpoint.Namespaces) == 0 {
+				return nil, fmt.Errorf("invalid %s parameter value: no namespace for %s",
+					CLIRemoteWrite, appconfig.RedactURL(endpoint.URL))
+			}
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return endpoints, nil
+}
+
+// parseMetricNameMigrations parses the metric name migrations in the format <old name>=<new name>[@<YYYY-MM-DD>]
+func parseMetricNameMigrations(values []string) ([]appconfig.MetricNameMigration, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	migrations := make([]appconfig.MetricNameMigration, 0, len(values))
+	oldNames := map[string]struct{}{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		names, until, hasUntil := strings.Cut(value, "@")
+		oldName, newName, found := strings.Cut(names, "=")
+		oldName, newName = strings.TrimSpace(oldName), strings.TrimSpace(newName)
+		if !found || oldName == "" || newName == "" || oldName == newName {
+			return nil, fmt.Errorf("invalid %s parameter value: %s", CLIMetricNameMigrations, value)
+		}
+
+		if _, exists := oldNames[oldName]; exists {
+			return nil, fmt.Errorf("invalid %s parameter value: %s is migrated twice", CLIMetricNameMigrations, oldName)
+		}
+		oldNames[oldName] = struct{}{}
+
+		migration := appconfig.MetricNameMigration{OldName: oldName, NewName: newName}
+		if hasUntil {
+			t, err := time.Parse(time.DateOnly, strings.TrimSpace(until))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s parameter value: %s: %w", CLIMetricNameMigrations, value, err)
+			}
+			migration.Until = t
+		}
+		migrations = append(migrations, migration)
+	}
+	return migrations, nil
+}
+
+// parseDerivedCounters parses the derived counters in the format <field>=<mode>
+func parseDerivedCounters(values []string) ([]appconfig.DerivedCounter, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	derivedCounters := make([]appconfig.DerivedCounter, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		fieldName, mode, found := strings.Cut(value, "=")
+		derivedCounter := appconfig.DerivedCounter{
+			FieldName: strings.TrimSpace(fieldName),
+			Mode:      appconfig.DerivedCounterMode(strings.TrimSpace(mode)),
+		}
+		if !found || derivedCounter.FieldName == "" {
+			return nil, fmt.Errorf("invalid %s parameter value: %s", CLIDerivedCounters, value)
+		}
+
+		switch derivedCounter.Mode {
+		case appconfig.DerivedCounterModeDelta, appconfig.DerivedCounterModeRate:
+		default:
+			return nil, fmt.Errorf("invalid %s parameter value: %s: unknown mode '%s'",
+				CLIDerivedCounters, value, derivedCounter.Mode)
+		}
+
+		if slices.Contains(derivedCounters, derivedCounter) {
+			return nil, fmt.Errorf("invalid %s parameter value: %s is configured twice", CLIDerivedCounters, value)
+		}
+		derivedCounters = append(derivedCounters, derivedCounter)
+	}
+	return derivedCounters, nil
+}
+
+// parseExtraLabels parses the static labels in the format <name>=<value>
+func parseExtraLabels(values []string) (map[string]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	labels := make(map[string]string, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		name, labelValue, found := strings.Cut(value, "=")
+		name, labelValue = strings.TrimSpace(name), strings.TrimSpace(labelValue)
+		if !found || !labelNameRegex.MatchString(name) || strings.HasPrefix(name, "__") {
+			return nil, fmt.Errorf("invalid %s parameter value: %s", CLIExtraLabels, value)
+		}
+
+		if strings.ContainsAny(labelValue, "\"\\\n") {
+			return nil, fmt.Errorf("invalid %s parameter value: %s: quotes, backslashes and newlines aren't allowed",
+				CLIExtraLabels, value)
+		}
+
+		if slices.Contains(reservedLabelNames, name) {
+			return nil, fmt.Errorf("invalid %s parameter value: %s is set by the exporter", CLIExtraLabels, name)
+		}
+
+		if _, exists := labels[name]; exists {
+			return nil, fmt.Errorf("invalid %s parameter value: %s is set twice", CLIExtraLabels, name)
+		}
+		labels[name] = labelValue
+	}
+	return labels, nil
+}
+
+// parseNamespacePatterns parses the Kubernetes namespaces of the flag, each a name or a glob pattern
+func parseNamespacePatterns(flag string, values []string) ([]string, error) {
+	var patterns []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if _, err := path.Match(value, ""); err != nil {
+			return nil, fmt.Errorf("invalid %s parameter value: %s: %w", flag, value, err)
+		}
+		patterns = append(patterns, value)
+	}
+	return patterns, nil
+}
+
+// parseRemoteHEEndpoints parses the comma-separated list of remote hostengines, in their order of preference.
+// The list has at least one hostengine.
+func parseRemoteHEEndpoints(value string) []string {
+	var endpoints []string
+	for _, endpoint := range strings.Split(value, ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	if len(endpoints) == 0 {
+		return []string{strings.TrimSpace(value)}
+	}
+	return endpoints
+}
+
+// parseMemoryWatermark parses the memory watermark as a Kubernetes quantity, e.g. 512Mi or 1G
+func parseMemoryWatermark(value string) (uint64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil || quantity.Sign() <= 0 {
+		return 0, fmt.Errorf("invalid %s parameter value: %s", CLIMemoryWatermark, value)
+	}
+	return uint64(quantity.Value()), nil
+}
+
 func parseDeviceOptions(devices string) (appconfig.DeviceOptions, error) {
 	var dOpt appconfig.DeviceOptions
 
@@ -1055,10 +2460,16 @@ func parseDeviceOptions(devices string) (appconfig.DeviceOptions, error) {
 }
 
 func contextToConfig(c *cli.Context) (*appconfig.Config, error) {
+	c, err := withConfigFile(c)
+	if err != nil {
+		return nil, err
+	}
+
 	gOpt, err := parseDeviceOptions(c.String(CLIGPUDevices))
 	if err != nil {
 		return nil, err
 	}
+	gOpt.ComputeInstances = c.Bool(CLIMIGComputeInstanceMetrics)
 
 	sOpt, err := parseDeviceOptions(c.String(CLISwitchDevices))
 	if err != nil {
@@ -1075,6 +2486,94 @@ func contextToConfig(c *cli.Context) (*appconfig.Config, error) {
 		return nil, fmt.Errorf("invalid %s parameter value: %s", CLIDCGMLogLevel, dcgmLogLevel)
 	}
 
+	remoteHEEndpoints := parseRemoteHEEndpoints(c.String(CLIRemoteHEInfo))
+
+	ipFamily := appconfig.IPFamily(c.String(CLIIPFamily))
+	if ipFamily != "" && !slices.Contains(appconfig.IPFamilies, ipFamily) {
+		return nil, fmt.Errorf("invalid %s parameter value: %s", CLIIPFamily, ipFamily)
+	}
+
+	otlpProtocol := appconfig.OTLPProtocol(c.String(CLIOTLPProtocol))
+	if otlpProtocol != "" && !slices.Contains(appconfig.OTLPProtocols, otlpProtocol) {
+		return nil, fmt.Errorf("invalid %s parameter value: %s", CLIOTLPProtocol, otlpProtocol)
+	}
+
+	diagLevel := c.Int(CLIDiagLevel)
+	if diagLevel != 0 && !slices.Contains(diag.Levels, diagLevel) {
+		return nil, fmt.Errorf("invalid %s parameter value: %d", CLIDiagLevel, diagLevel)
+	}
+
+	dcgmModules, err := parseDCGMModules(c.StringSlice(CLIDCGMModules))
+	if err != nil {
+		return nil, err
+	}
+
+	remoteWriteEndpoints, err := parseRemoteWriteEndpoints(c.StringSlice(CLIRemoteWrite))
+	if err != nil {
+		return nil, err
+	}
+
+	metricNameMigrations, err := parseMetricNameMigrations(c.StringSlice(CLIMetricNameMigrations))
+	if err != nil {
+		return nil, err
+	}
+
+	memoryWatermark, err := parseMemoryWatermark(c.String(CLIMemoryWatermark))
+	if err != nil {
+		return nil, err
+	}
+
+	derivedCounters, err := parseDerivedCounters(c.StringSlice(CLIDerivedCounters))
+	if err != nil {
+		return nil, err
+	}
+
+	extraLabels, err := parseExtraLabels(c.StringSlice(CLIExtraLabels))
+	if err != nil {
+		return nil, err
+	}
+
+	nodeLabelsMode := appconfig.NodeLabelMode(c.String(CLIKubernetesNodeLabelsMode))
+	if nodeLabelsMode != "" && !slices.Contains(appconfig.NodeLabelModes, nodeLabelsMode) {
+		return nil, fmt.Errorf("invalid %s parameter value: %s", CLIKubernetesNodeLabelsMode, nodeLabelsMode)
+	}
+
+	duplicatePolicy := appconfig.DuplicatePolicy(c.String(CLIKubernetesDuplicatePolicy))
+	if duplicatePolicy != "" && !slices.Contains(appconfig.DuplicatePolicies, duplicatePolicy) {
+		return nil, fmt.Errorf("invalid %s parameter value: %s", CLIKubernetesDuplicatePolicy, duplicatePolicy)
+	}
+
+	fieldIDMode := appconfig.FieldIDMode(c.String(CLIFieldID))
+	if fieldIDMode != "" && !slices.Contains(appconfig.FieldIDModes, fieldIDMode) {
+		return nil, fmt.Errorf("invalid %s parameter value: %s", CLIFieldID, fieldIDMode)
+	}
+
+	namespaceAllowlist, err := parseNamespacePatterns(CLIKubernetesNamespaceAllowlist,
+		c.StringSlice(CLIKubernetesNamespaceAllowlist))
+	if err != nil {
+		return nil, err
+	}
+
+	namespaceDenylist, err := parseNamespacePatterns(CLIKubernetesNamespaceDenylist,
+		c.StringSlice(CLIKubernetesNamespaceDenylist))
+	if err != nil {
+		return nil, err
+	}
+
+	// The Parquet sink appends every collection cycle unless configured otherwise
+	collectInterval := time.Duration(c.Int(CLICollectInterval)) * time.Millisecond
+
+	// The socket is mounted at different paths depending on the distribution, it is discovered unless configured
+	podResourcesKubeletSocket := c.String(CLIPodResourcesKubeletSocket)
+	if c.Bool(CLIKubernetes) && !c.IsSet(CLIPodResourcesKubeletSocket) {
+		podResourcesKubeletSocket = transformation.DiscoverPodResourcesSocket(podResourcesKubeletSocket)
+	}
+
+	giFormat := appconfig.GPUInstanceIDFormat(c.String(CLIGPUInstanceIDFormat))
+	if giFormat != "" && !slices.Contains(appconfig.GPUInstanceIDFormats, giFormat) {
+		return nil, fmt.Errorf("invalid %s parameter value: %s", CLIGPUInstanceIDFormat, giFormat)
+	}
+
 	return &appconfig.Config{
 		CollectorsFile:                   c.String(CLIFieldsFile),
 		Address:                          c.String(CLIAddress),
@@ -1082,12 +2581,17 @@ func contextToConfig(c *cli.Context) (*appconfig.Config, error) {
 		Kubernetes:                       c.Bool(CLIKubernetes),
 		KubernetesEnablePodLabels:        c.Bool(CLIKubernetesEnablePodLabels),
 		KubernetesEnablePodUID:           c.Bool(CLIKubernetesEnablePodUID),
+		KubernetesWorkloadLabels:         c.Bool(CLIKubernetesWorkloadLabels),
 		KubernetesGPUIdType:              appconfig.KubernetesGPUIDType(c.String(CLIKubernetesGPUIDType)),
 		KubernetesPodLabelAllowlistRegex: c.StringSlice(CLIKubernetesPodLabelAllowlistRegex),
+		KubernetesPodAnnotations:         c.StringSlice(CLIKubernetesPodAnnotations),
 		CollectDCP:                       true,
 		UseOldNamespace:                  c.Bool(CLIUseOldNamespace),
 		UseRemoteHE:                      c.IsSet(CLIRemoteHEInfo),
-		RemoteHEInfo:                     c.String(CLIRemoteHEInfo),
+		RemoteHEInfo:                     remoteHEEndpoints[0],
+		RemoteHEEndpoints:                remoteHEEndpoints,
+		RemoteHECheckInterval:            parseDuration(c.String(CLIRemoteHECheckInterval), 5*time.Second),
+		ProbeEnabled:                     c.Bool(CLIEnableProbe),
 		GPUDeviceOptions:                 gOpt,
 		SwitchDeviceOptions:              sOpt,
 		CPUDeviceOptions:                 cOpt,
@@ -1097,12 +2601,15 @@ func contextToConfig(c *cli.Context) (*appconfig.Config, error) {
 		WebSystemdSocket:                 c.Bool(CLIWebSystemdSocket),
 		WebConfigFile:                    c.String(CLIWebConfigFile),
 		XIDCountWindowSize:               c.Int(CLIXIDCountWindowSize),
+		XIDCorrelation:                   c.Bool(CLIXIDCorrelation),
 		ReplaceBlanksInModelName:         c.Bool(CLIReplaceBlanksInModelName),
 		Debug:                            c.Bool(CLIDebugMode),
 		ClockEventsCountWindowSize:       c.Int(CLIClockEventsCountWindowSize),
+		NVLinkErrorsCountWindowSize:      c.Int(CLINVLinkErrorsCountWindowSize),
 		EnableDCGMLog:                    c.Bool(CLIEnableDCGMLog),
 		DCGMLogLevel:                     dcgmLogLevel,
-		PodResourcesKubeletSocket:        c.String(CLIPodResourcesKubeletSocket),
+		PodResourcesKubeletSocket:        podResourcesKubeletSocket,
+		KubeletCheckpointFile:            c.String(CLIKubeletCheckpointFile),
 		HPCJobMappingDir:                 c.String(CLIHPCJobMappingDir),
 		NvidiaResourceNames:              c.StringSlice(CLINvidiaResourceNames),
 		KubernetesVirtualGPUs:            c.Bool(CLIKubernetesVirtualGPUs),
@@ -1112,10 +2619,74 @@ func contextToConfig(c *cli.Context) (*appconfig.Config, error) {
 			Retention:   c.Int(CLIDumpRetention),
 			Compression: c.Bool(CLIDumpCompression),
 		},
-		KubernetesEnableDRA:       c.Bool(CLIKubernetesEnableDRA),
-		DisableStartupValidate:    c.Bool(CLIDisableStartupValidate),
-		EnableGPUBindUnbindWatch:  c.Bool(CLIEnableGPUBindUnbindWatch),
-		GPUBindUnbindPollInterval: parseDuration(c.String(CLIGPUBindUnbindPollInterval), 1*time.Second),
+		KubernetesEnableDRA:        c.Bool(CLIKubernetesEnableDRA),
+		KubernetesDuplicatePolicy:  duplicatePolicy,
+		DisableStartupValidate:     c.Bool(CLIDisableStartupValidate),
+		EnableGPUBindUnbindWatch:   c.Bool(CLIEnableGPUBindUnbindWatch) && !c.Bool(CLICPUOnly),
+		CPUOnly:                    c.Bool(CLICPUOnly),
+		NVMLOnly:                   c.Bool(CLINVMLOnly),
+		GPUBindUnbindPollInterval:  parseDuration(c.String(CLIGPUBindUnbindPollInterval), 1*time.Second),
+		EnableMIGWatch:             c.Bool(CLIEnableMIGWatch) && !c.Bool(CLICPUOnly),
+		MIGWatchPollInterval:       parseDuration(c.String(CLIMIGWatchPollInterval), 10*time.Second),
+		GPUInstanceIDFormat:        giFormat,
+		EnableCounterDeltas:        c.Bool(CLIEnableCounterDeltas),
+		IPFamily:                   ipFamily,
+		DCGMModules:                dcgmModules,
+		SplitMIGMetrics:            c.Bool(CLISplitMIGMetrics),
+		MIGInstanceMemory:          c.Bool(CLIMIGInstanceMemory),
+		KubernetesSkipInactivePods: c.Bool(CLIKubernetesSkipInactivePods),
+		PodNamespaceAllowlist:      namespaceAllowlist,
+		PodNamespaceDenylist:       namespaceDenylist,
+		NodeLabels:                 c.StringSlice(CLIKubernetesNodeLabels),
+		NodeLabelsMode:             nodeLabelsMode,
+		InstanceFQDNLabel:          c.Bool(CLIInstanceFQDNLabel),
+		FieldIDMode:                fieldIDMode,
+		GPUSlotsFile:               c.String(CLIGPUSlotsFile),
+		CollectorInventoryMetric:   c.Bool(CLICollectorInventoryMetric),
+		ExpositionHashMetric:       c.Bool(CLIExpositionHashMetric),
+		FamilySamplesMetric:        c.Bool(CLIFamilySamplesMetric),
+		EventLogSize:               c.Int(CLIEventLogSize),
+		JournalEvents:              c.Bool(CLIJournalEvents),
+		TelemetryMetrics:           c.Bool(CLITelemetryMetrics),
+		DeviceLabelsInfo:           c.Bool(CLIDeviceLabelsInfo),
+		CollectorTimeout:           parseDuration(c.String(CLICollectorTimeout), 0),
+		CollectorBreakerThreshold:  c.Int(CLICollectorBreakerThreshold),
+		CollectorBreakerCycles:     c.Int(CLICollectorBreakerCycles),
+		MetricsCacheInterval:       parseDuration(c.String(CLIMetricsCacheInterval), 0),
+		MaxStaleness:               parseDuration(c.String(CLIMaxStaleness), 0),
+		OpenMetrics:                c.Bool(CLIOpenMetrics),
+		DevicePauseAPI:             c.Bool(CLIDevicePauseAPI),
+		MetricsCompression:         c.Bool(CLIMetricsCompression),
+		MaintenanceAPI:             c.Bool(CLIMaintenanceAPI),
+		MaintenanceMaxDuration:     parseDuration(c.String(CLIMaintenanceMaxDuration), 24*time.Hour),
+		MaintenanceDropFamilies:    c.StringSlice(CLIMaintenanceDropFamilies),
+		StreamingRender:            c.Bool(CLIStreamingRender),
+		MIGFragmentationMetrics:    c.Bool(CLIMIGFragmentationMetrics),
+		OTLPEndpoint:               c.String(CLIOTLPEndpoint),
+		OTLPProtocol:               otlpProtocol,
+		OTLPInterval:               parseDuration(c.String(CLIOTLPInterval), 30*time.Second),
+		SuppressIdleMetrics:        c.StringSlice(CLISuppressIdleMetrics),
+		CanaryCollectorsFile:       c.String(CLICanaryCollectors),
+		CollectOnScrape:            c.Bool(CLICollectOnScrape),
+		CollectOnScrapeMinInterval: parseDuration(c.String(CLICollectOnScrapeMinInterval), time.Second),
+		DiagLevel:                  diagLevel,
+		DiagInterval:               parseDuration(c.String(CLIDiagInterval), 24*time.Hour),
+		RemoteWriteEndpoints:       remoteWriteEndpoints,
+		RemoteWriteInterval:        parseDuration(c.String(CLIRemoteWriteInterval), 30*time.Second),
+		MetricNameMigrations:       metricNameMigrations,
+		DualNamespace:              c.Bool(CLIDualNamespace),
+		DualNamespaceFamilies:      c.StringSlice(CLIDualNamespaceFamilies),
+		MemoryWatermark:            memoryWatermark,
+		PushQueueSize:              c.Int(CLIPushQueueSize),
+		PushBatchSize:              c.Int(CLIPushBatchSize),
+		PushFlushInterval:          parseDuration(c.String(CLIPushFlushInterval), pushqueue.DefaultFlushInterval),
+		DerivedCounters:            derivedCounters,
+		ExtraLabels:                extraLabels,
+		RelabelConfigFile:          c.String(CLIRelabelConfig),
+		ParquetDirectory:           c.String(CLIParquetDirectory),
+		ParquetInterval:            parseDuration(c.String(CLIParquetInterval), collectInterval),
+		ParquetRotationInterval:    parseDuration(c.String(CLIParquetRotationInterval), time.Hour),
+		ParquetRetention:           c.Int(CLIParquetRetention),
 	}, nil
 }
 
@@ -1153,14 +2724,16 @@ func runGPUWatcher(ctx context.Context, w *watcher.GPUBindUnbindWatcher, server
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		err := w.Watch(ctx, func() {
-			// Any GPU topology change (bind or unbind) triggers full reset
-			// This unified approach is simpler and handles all edge cases:
+		err := w.WatchGPUChanges(ctx, func(change watcher.GPUChange) {
+			// A change of a single GPU only rebuilds the GPU collectors, so the other GPUs keep reporting
+			// Any other GPU topology change (bind or unbind) triggers full reset, which handles all edge cases:
 			// - Multiple rapid events: only last state matters
 			// - Event during reload: queued and processed after
 			// - GPU swap: always leaves system in correct state
-			slog.DebugContext(ctx, "GPU topology change detected")
-			handleGPUTopologyChange(ctx, server, c, dcgmCleanup)
+			slog.DebugContext(ctx, "GPU topology change detected",
+				slog.Bool("resolved", change.Resolved),
+				slog.Any("gpus", change.GPUs))
+			handleGPUChange(ctx, server, c, dcgmCleanup, change)
 		})
 		if err != nil && !errors.Is(err, context.Canceled) {
 			slog.ErrorContext(ctx, "GPU watcher failed", slog.String("error", err.Error()))
@@ -17,9 +17,14 @@
 package cmd
 
 import (
+	"context"
 	"flag"
+	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
 	"github.com/stretchr/testify/assert"
@@ -116,6 +121,34 @@ func Test_getDeviceWatchListManager(t *testing.T) {
 				assert.Equal(t, dcgm.Short(230), values[0].FieldID)
 			},
 		},
+		{
+			name: "When DCGM_FI_DEV_POWER_USAGE and DCGM_EXP_ENERGY_JOULES_TOTAL enabled",
+			counterSet: &counters.CounterSet{
+				DCGMCounters: []counters.Counter{
+					{
+						FieldID:   dcgm.DCGM_FI_DEV_POWER_USAGE,
+						FieldName: "DCGM_FI_DEV_POWER_USAGE",
+						PromType:  "gauge",
+						Help:      "Power draw (in W).",
+					},
+				},
+				ExporterCounters: []counters.Counter{
+					{
+						FieldID:   dcgm.Short(counters.DCGMEnergyTotal),
+						FieldName: counters.DCGMExpEnergyTotal,
+						PromType:  "counter",
+						Help:      "Energy consumed since the exporter started (in J).",
+					},
+				},
+			},
+			assertion: func(t *testing.T, got devicewatchlistmanager.Manager) {
+				require.NotNil(t, got)
+				values := testutils.GetStructPrivateFieldValue[[]counters.Counter](t, got, "counters")
+				require.Len(t, values, 2)
+				assert.Equal(t, dcgm.DCGM_FI_DEV_POWER_USAGE, values[0].FieldID)
+				assert.Equal(t, dcgm.DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION, values[1].FieldID)
+			},
+		},
 		{
 			name:       "When no counters",
 			counterSet: &counters.CounterSet{},
@@ -367,3 +400,366 @@ func Test_contextToConfig_DumpConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseDCGMModules(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []appconfig.DCGMModule
+		wantErr bool
+	}{
+		{
+			name:   "Empty list allows all modules",
+			values: nil,
+			want:   nil,
+		},
+		{
+			name:   "Subset of modules",
+			values: []string{"health", " NvSwitch "},
+			want:   []appconfig.DCGMModule{appconfig.DCGMModuleHealth, appconfig.DCGMModuleNvSwitch},
+		},
+		{
+			name:    "Unknown module",
+			values:  []string{"profiling", "introspection"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDCGMModules(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			config := &appconfig.Config{DCGMModules: got}
+			for _, module := range appconfig.DCGMModules {
+				assert.Equal(t, tt.want == nil || slices.Contains(tt.want, module),
+					config.IsDCGMModuleEnabled(module), module)
+			}
+		})
+	}
+}
+
+func Test_parseRemoteWriteEndpoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []appconfig.RemoteWriteEndpoint
+		wantErr bool
+	}{
+		{
+			name:   "No endpoint",
+			values: nil,
+			want:   nil,
+		},
+		{
+			name: "Full copy and tenant endpoints",
+			values: []string{
+				"http://prometheus:9090/api/v1/write",
+				" https://tenant.example.com/api/v1/write;namespaces=team-a| team-b ",
+			},
+			want: []appconfig.RemoteWriteEndpoint{
+				{URL: "http://prometheus:9090/api/v1/write"},
+				{URL: "https://tenant.example.com/api/v1/write", Namespaces: []string{"team-a", "team-b"}},
+			},
+		},
+		{
+			name:    "Unsupported scheme",
+			values:  []string{"ftp://prometheus/api/v1/write"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown option",
+			values:  []string{"http://prometheus:9090/api/v1/write;tenant=a"},
+			wantErr: true,
+		},
+		{
+			name:    "Empty namespace list",
+			values:  []string{"http://prometheus:9090/api/v1/write;namespaces=|"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRemoteWriteEndpoints(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseMetricNameMigrations(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []appconfig.MetricNameMigration
+		wantErr bool
+	}{
+		{
+			name:   "No migration",
+			values: nil,
+			want:   nil,
+		},
+		{
+			name: "Migrations with and without transition window",
+			values: []string{
+				"DCGM_FI_DEV_WEIGHTED_GPU_UTIL=DCGM_EXP_WEIGHTED_GPU_UTIL@2026-12-31",
+				" DCGM_EXP_PROCESS_SM_UTIL = DCGM_EXP_PROCESS_SM_UTILIZATION ",
+			},
+			want: []appconfig.MetricNameMigration{
+				{
+					OldName: "DCGM_FI_DEV_WEIGHTED_GPU_UTIL",
+					NewName: "DCGM_EXP_WEIGHTED_GPU_UTIL",
+					Until:   time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+				},
+				{OldName: "DCGM_EXP_PROCESS_SM_UTIL", NewName: "DCGM_EXP_PROCESS_SM_UTILIZATION"},
+			},
+		},
+		{
+			name:    "Missing new name",
+			values:  []string{"DCGM_FI_DEV_WEIGHTED_GPU_UTIL="},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid date",
+			values:  []string{"DCGM_FI_DEV_WEIGHTED_GPU_UTIL=DCGM_EXP_WEIGHTED_GPU_UTIL@31/12/2026"},
+			wantErr: true,
+		},
+		{
+			name: "Family migrated twice",
+			values: []string{
+				"DCGM_FI_DEV_WEIGHTED_GPU_UTIL=DCGM_EXP_WEIGHTED_GPU_UTIL",
+				"DCGM_FI_DEV_WEIGHTED_GPU_UTIL=DCGM_EXP_GPU_UTIL_WEIGHTED",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMetricNameMigrations(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseMemoryWatermark(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    uint64
+		wantErr bool
+	}{
+		{name: "Disabled", value: "", want: 0},
+		{name: "Binary suffix", value: "512Mi", want: 512 << 20},
+		{name: "Decimal suffix", value: " 2G ", want: 2_000_000_000},
+		{name: "Bytes", value: "1048576", want: 1 << 20},
+		{name: "Invalid quantity", value: "lots", wantErr: true},
+		{name: "Negative quantity", value: "-1Gi", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMemoryWatermark(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseDerivedCounters(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []appconfig.DerivedCounter
+		wantErr bool
+	}{
+		{
+			name:   "No derived counter",
+			values: nil,
+			want:   nil,
+		},
+		{
+			name: "Delta and rate",
+			values: []string{
+				"DCGM_FI_PROF_PCIE_TX_BYTES=rate",
+				" DCGM_FI_PROF_PCIE_TX_BYTES = delta ",
+				"DCGM_FI_DEV_NVLINK_BANDWIDTH_TOTAL=rate",
+			},
+			want: []appconfig.DerivedCounter{
+				{FieldName: "DCGM_FI_PROF_PCIE_TX_BYTES", Mode: appconfig.DerivedCounterModeRate},
+				{FieldName: "DCGM_FI_PROF_PCIE_TX_BYTES", Mode: appconfig.DerivedCounterModeDelta},
+				{FieldName: "DCGM_FI_DEV_NVLINK_BANDWIDTH_TOTAL", Mode: appconfig.DerivedCounterModeRate},
+			},
+		},
+		{
+			name:    "Missing mode",
+			values:  []string{"DCGM_FI_PROF_PCIE_TX_BYTES"},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown mode",
+			values:  []string{"DCGM_FI_PROF_PCIE_TX_BYTES=average"},
+			wantErr: true,
+		},
+		{
+			name:    "Configured twice",
+			values:  []string{"DCGM_FI_PROF_PCIE_TX_BYTES=rate", "DCGM_FI_PROF_PCIE_TX_BYTES=rate"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDerivedCounters(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseExtraLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "No label",
+			values: nil,
+			want:   nil,
+		},
+		{
+			name:   "Labels",
+			values: []string{"cluster=prod", " region = us-east ", "rack=", "tenant=team-a=blue"},
+			want:   map[string]string{"cluster": "prod", "region": "us-east", "rack": "", "tenant": "team-a=blue"},
+		},
+		{
+			name:    "Missing value",
+			values:  []string{"cluster"},
+			wantErr: true,
+		},
+		{
+			name:    "Invalid name",
+			values:  []string{"cluster-name=prod"},
+			wantErr: true,
+		},
+		{
+			name:    "Reserved name",
+			values:  []string{"__name__=prod"},
+			wantErr: true,
+		},
+		{
+			name:    "Label of the exporter",
+			values:  []string{"Hostname=node1"},
+			wantErr: true,
+		},
+		{
+			name:    "Quoted value",
+			values:  []string{`cluster="prod"`},
+			wantErr: true,
+		},
+		{
+			name:    "Label set twice",
+			values:  []string{"cluster=prod", "cluster=dev"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseExtraLabels(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseNamespacePatterns(t *testing.T) {
+	got, err := parseNamespacePatterns(CLIKubernetesNamespaceAllowlist, nil)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = parseNamespacePatterns(CLIKubernetesNamespaceAllowlist, []string{" ml ", "", "team-*"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ml", "team-*"}, got)
+
+	_, err = parseNamespacePatterns(CLIKubernetesNamespaceDenylist, []string{"team-["})
+	assert.ErrorContains(t, err, CLIKubernetesNamespaceDenylist)
+}
+
+func Test_parseRemoteHEEndpoints(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{
+			name:  "Single hostengine",
+			value: "localhost:5555",
+			want:  []string{"localhost:5555"},
+		},
+		{
+			name:  "Failover hostengines",
+			value: "dcgm-a:5555, dcgm-b:5555,,dcgm-c:5555",
+			want:  []string{"dcgm-a:5555", "dcgm-b:5555", "dcgm-c:5555"},
+		},
+		{
+			name:  "Empty",
+			value: "",
+			want:  []string{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseRemoteHEEndpoints(tt.value))
+		})
+	}
+}
+
+func Test_getCounters_ReturnsError(t *testing.T) {
+	brokenFile := filepath.Join(t.TempDir(), "counters.csv")
+	require.NoError(t, os.WriteFile(brokenFile, []byte("DCGM_FI_DEV_NOT_A_FIELD, gauge, broken\n"), 0o600))
+
+	for name, collectorsFile := range map[string]string{
+		"missing file":  filepath.Join(t.TempDir(), "missing.csv"),
+		"invalid field": brokenFile,
+	} {
+		t.Run(name, func(t *testing.T) {
+			cs, err := getCounters(context.Background(), &appconfig.Config{
+				ConfigMapData:  "none",
+				CollectorsFile: collectorsFile,
+			})
+			assert.Error(t, err)
+			assert.Nil(t, cs)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// withConfigFile returns a context where the flags that are not set on the command line or with their environment
+// variable take their values from the configuration file, or c if there is no configuration file. The file is
+// read on every call, so that hot reloads pick up its changes.
+func withConfigFile(c *cli.Context) (*cli.Context, error) {
+	path := c.String(CLIConfigFile)
+	if path == "" {
+		return c, nil
+	}
+
+	values, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// A fresh flag set, so that the values of a previous version of the file don't look set on the command line
+	set := flag.NewFlagSet(c.App.Name, flag.ContinueOnError)
+	flags := map[string]cli.Flag{}
+	for _, f := range c.App.Flags {
+		if err := f.Apply(set); err != nil {
+			return nil, err
+		}
+		for _, name := range f.Names() {
+			flags[name] = f
+		}
+	}
+
+	for _, name := range c.FlagNames() {
+		if f, ok := flags[name]; ok {
+			if err := setFlag(set, f, flagValues(c, f)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	configCtx := cli.NewContext(c.App, set, nil)
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		f, ok := flags[name]
+		if !ok || name == CLIConfigFile {
+			return nil, fmt.Errorf("unknown option '%s' in the configuration file %s", name, path)
+		}
+		if configCtx.IsSet(f.Names()[0]) {
+			continue
+		}
+		if err := setFlag(set, f, values[name]); err != nil {
+			return nil, fmt.Errorf("invalid option '%s' in the configuration file %s: %w", name, path, err)
+		}
+	}
+
+	return configCtx, nil
+}
+
+// flagValues returns the values of a flag of the context, as set on the command line
+func flagValues(c *cli.Context, f cli.Flag) []string {
+	name := f.Names()[0]
+	if _, isSlice := f.(*cli.StringSliceFlag); isSlice {
+		return c.StringSlice(name)
+	}
+	return []string{fmt.Sprint(c.Value(name))}
+}
+
+func setFlag(set *flag.FlagSet, f cli.Flag, values []string) error {
+	if _, isSlice := f.(*cli.StringSliceFlag); !isSlice && len(values) != 1 {
+		return fmt.Errorf("a single value is expected, got %d", len(values))
+	}
+
+	for _, value := range values {
+		if err := set.Set(f.Names()[0], value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readConfigFile reads the YAML configuration file. Its keys are the names of the flags, the options can be
+// grouped by prefix: 'kubernetes: {gpu-id-type: uid}' is the same as 'kubernetes-gpu-id-type: uid'.
+func readConfigFile(path string) (map[string][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the configuration file: %w", err)
+	}
+
+	var config map[string]any
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+
+	values := map[string][]string{}
+	if err := flattenConfig("", config, values); err != nil {
+		return nil, fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+func flattenConfig(prefix string, config map[string]any, values map[string][]string) error {
+	for key, value := range config {
+		name := key
+		if prefix != "" {
+			name = prefix + "-" + key
+		}
+
+		switch v := value.(type) {
+		case nil:
+			continue
+		case map[string]any:
+			if err := flattenConfig(name, v, values); err != nil {
+				return err
+			}
+		case []any:
+			list := make([]string, 0, len(v))
+			for _, item := range v {
+				s, err := configScalar(item)
+				if err != nil {
+					return fmt.Errorf("option '%s': %w", name, err)
+				}
+				list = append(list, s)
+			}
+			values[name] = list
+		default:
+			s, err := configScalar(v)
+			if err != nil {
+				return fmt.Errorf("option '%s': %w", name, err)
+			}
+			values[name] = []string{s}
+		}
+	}
+	return nil
+}
+
+func configScalar(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unexpected value %v", value)
+	}
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func runWithConfigFile(t *testing.T, config string, args ...string) (*cli.Context, error) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	var configCtx *cli.Context
+	var configErr error
+	app := cli.NewApp()
+	app.Flags = []cli.Flag{
+		&cli.StringFlag{Name: CLIConfigFile},
+		&cli.StringFlag{Name: CLIAddress, Aliases: []string{"a"}, Value: ":9400"},
+		&cli.BoolFlag{Name: CLIKubernetes},
+		&cli.StringFlag{Name: CLIKubernetesGPUIDType, Value: "uid"},
+		&cli.IntFlag{Name: CLIDumpRetention, Value: 24},
+		&cli.StringFlag{Name: CLIRemoteWriteInterval, Value: "30s", EnvVars: []string{"TEST_REMOTE_WRITE_INTERVAL"}},
+		&cli.StringSliceFlag{Name: CLIExtraLabels},
+	}
+	app.Action = func(c *cli.Context) error {
+		configCtx, configErr = withConfigFile(c)
+		return nil
+	}
+
+	require.NoError(t, app.Run(append([]string{"dcgm-exporter", "--" + CLIConfigFile, path}, args...)))
+	return configCtx, configErr
+}
+
+func TestWithConfigFile(t *testing.T) {
+	t.Setenv("TEST_REMOTE_WRITE_INTERVAL", "10s")
+
+	c, err := runWithConfigFile(t, `
+address: ":9500"
+kubernetes:
+  gpu-id-type: device-name
+dump-retention: 48
+remote-write-interval: 1m
+extra-labels:
+  - cluster=prod
+  - region=us-east
+`, "-a", ":9600", "--"+CLIKubernetes)
+	require.NoError(t, err)
+
+	assert.Equal(t, ":9600", c.String(CLIAddress), "The command line takes precedence")
+	assert.True(t, c.Bool(CLIKubernetes))
+	assert.Equal(t, "device-name", c.String(CLIKubernetesGPUIDType))
+	assert.Equal(t, 48, c.Int(CLIDumpRetention))
+	assert.Equal(t, "10s", c.String(CLIRemoteWriteInterval), "The environment takes precedence")
+	assert.Equal(t, []string{"cluster=prod", "region=us-east"}, c.StringSlice(CLIExtraLabels))
+}
+
+func TestWithConfigFile_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"Unknown option":     "unknown-option: true",
+		"Nested config file": "config: {file: other.yaml}",
+		"List for a scalar":  "address: [':9400', ':9500']",
+		"Invalid value":      "dump-retention: many",
+		"Invalid YAML":       "address: [",
+	}
+
+	for name, config := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := runWithConfigFile(t, config)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWithConfigFile_NoFile(t *testing.T) {
+	app := cli.NewApp()
+	app.Flags = []cli.Flag{&cli.StringFlag{Name: CLIConfigFile}}
+	app.Action = func(c *cli.Context) error {
+		configCtx, err := withConfigFile(c)
+		require.NoError(t, err)
+		assert.Same(t, c, configCtx)
+		return nil
+	}
+	require.NoError(t, app.Run([]string{"dcgm-exporter"}))
+}
@@ -36,3 +36,18 @@ var DCGMDbgLvlValues = []string{
 	DCGMDbgLvlDebug,
 	DCGMDbgLvlVerb,
 }
+
+// reloadTrigger is what triggered a reload, as recorded in the reload history served by /api/v1/reloads.
+const (
+	reloadTriggerSIGHUP             = "sighup"
+	reloadTriggerFileChange         = "file_change"
+	reloadTriggerConfigFileChange   = "config_file_change"
+	reloadTriggerGPUTopologyChange  = "gpu_topology_change"
+	reloadTriggerGPUChange          = "gpu_change"
+	reloadTriggerReloadCounters     = "reload_counters"
+	reloadTriggerReconnectDCGM      = "reconnect_dcgm"
+	reloadTriggerHostengineFailover = "hostengine_failover"
+	reloadTriggerConnectionLost     = "connection_lost"
+	reloadTriggerDevicePause        = "device_pause"
+	reloadTriggerMIGChange          = "mig_change"
+)
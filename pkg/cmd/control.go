@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"syscall"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/server"
+)
+
+// controlDispatcher routes the signals of a SignalSource, OS signals and control signals alike,
+// to the reload, reset and dump handlers of a running exporter.
+type controlDispatcher struct {
+	ctx         context.Context
+	server      *server.MetricsServer
+	c           *cli.Context
+	config      *appconfig.Config
+	dcgmCleanup func()
+}
+
+// dispatch handles a signal and returns whether it requests the shutdown of the exporter.
+func (d *controlDispatcher) dispatch(sig os.Signal) bool {
+	slog.Info("Received signal", slog.String("signal", sig.String()))
+
+	switch sig {
+	case syscall.SIGHUP:
+		// SIGHUP triggers hot reload instead of full restart
+		d.hotReload(reloadTriggerSIGHUP)
+	case SignalReloadCounters:
+		d.hotReload(reloadTriggerReloadCounters)
+	case syscall.SIGUSR2, SignalDump:
+		// Dump a diagnostic snapshot while the exporter keeps serving
+		writeDiagnosticSnapshot(d.server, d.config)
+	case SignalReconnectDCGM:
+		slog.Info("DCGM reconnection requested - triggering full reset")
+		handleGPUTopologyChange(d.ctx, d.server, d.c, d.dcgmCleanup, reloadTriggerReconnectDCGM)
+	default:
+		if _, ok := sig.(ControlSignal); ok {
+			slog.Warn("Ignoring unknown control signal", slog.String("signal", sig.String()))
+			return false
+		}
+		// SIGTERM/SIGINT/SIGQUIT - graceful shutdown
+		return true
+	}
+
+	return false
+}
+
+func (d *controlDispatcher) hotReload(trigger string) {
+	slog.Info("Counters reload requested - triggering hot reload", slog.String("trigger", trigger))
+	if err := hotReload(d.ctx, d.server, d.c, d.dcgmCleanup, trigger); err != nil {
+		slog.Error("Hot reload failed", slog.String("error", err.Error()))
+	}
+}
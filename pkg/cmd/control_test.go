@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_controlDispatcher_dispatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		signal   os.Signal
+		shutdown bool
+	}{
+		{name: "SIGTERM shuts down", signal: syscall.SIGTERM, shutdown: true},
+		{name: "SIGINT shuts down", signal: syscall.SIGINT, shutdown: true},
+		{name: "SIGQUIT shuts down", signal: syscall.SIGQUIT, shutdown: true},
+		{name: "unknown control signal is ignored", signal: ControlSignal("unknown"), shutdown: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dispatcher := &controlDispatcher{}
+			assert.Equal(t, tt.shutdown, dispatcher.dispatch(tt.signal))
+		})
+	}
+}
+
+func TestControlSignal(t *testing.T) {
+	var sig os.Signal = SignalReloadCounters
+	assert.Equal(t, "reload-counters", sig.String())
+	assert.Equal(t, SignalReloadCounters, sig)
+	assert.NotEqual(t, SignalReconnectDCGM, sig)
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"syscall"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/collector"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/counters"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/devicewatchlistmanager"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/hostname"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/registry"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/relabel"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/server"
+)
+
+// startNVMLOnlyExporter serves the GPU metrics read from NVML, without DCGM, until a shutdown signal.
+// The lifecycle is reduced to a single registry: the GPUs are enumerated once and the configuration isn't
+// reloaded, as the NVML-only mode targets the Jetson modules and the nodes where nv-hostengine can't run.
+func startNVMLOnlyExporter(config *appconfig.Config, sigSource SignalSource) error {
+	slog.Info("Starting in NVML-only mode, DCGM is not initialized")
+
+	if err := nvmlprovider.Initialize(); err != nil {
+		return fmt.Errorf("failed to initialize NVML: %w", err)
+	}
+	defer nvmlprovider.Client().Cleanup()
+
+	ctx := context.Background()
+
+	cs, err := getCounters(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	reg, deviceWatchListManager, err := buildNVMLRegistry(cs, config)
+	if err != nil {
+		return err
+	}
+	defer reg.Cleanup()
+
+	if config.RelabelConfigFile != "" {
+		if err := relabel.Load(config.RelabelConfigFile); err != nil {
+			return err
+		}
+	}
+
+	metricsServer, serverCleanup, err := server.NewMetricsServer(config, deviceWatchListManager, reg)
+	if err != nil {
+		return err
+	}
+	defer serverCleanup()
+
+	var serverWg sync.WaitGroup
+	stop := make(chan interface{})
+
+	serverWg.Add(1)
+	go func() {
+		defer serverWg.Done()
+		metricsServer.Run(ctx, stop)
+	}()
+
+	slog.Info("HTTP server started - ready to serve metrics")
+
+	// Wait for shutdown signal (SIGTERM, SIGINT) - there is nothing to reload nor reconnect
+	for sig := range sigSource.Signals() {
+		if _, ok := sig.(ControlSignal); ok || sig == syscall.SIGHUP || sig == syscall.SIGUSR2 {
+			slog.Info("Ignoring signal in NVML-only mode", slog.String("signal", sig.String()))
+			continue
+		}
+		break
+	}
+
+	slog.Info("Shutting down gracefully...")
+
+	close(stop)
+	serverWg.Wait()
+
+	slog.Info("Shutdown complete")
+	return nil
+}
+
+// buildNVMLRegistry creates the registry of the NVML-only mode, with the GPUs enumerated by NVML.
+func buildNVMLRegistry(
+	cs *counters.CounterSet, config *appconfig.Config,
+) (*registry.Registry, devicewatchlistmanager.Manager, error) {
+	deviceWatchListManager := devicewatchlistmanager.NewWatchListManager(cs.DCGMCounters, config)
+	if err := deviceWatchListManager.CreateNVMLWatchList(); err != nil {
+		return nil, nil, fmt.Errorf("failed to enumerate the GPUs with NVML: %w", err)
+	}
+	deviceWatchList, _ := deviceWatchListManager.EntityWatchList(dcgm.FE_GPU)
+
+	hostName, err := hostname.GetHostname(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get hostname: %w", err)
+	}
+
+	entityCollectors, err := collector.NewNVMLCollectors(cs, hostName, config, deviceWatchList)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cRegistry := registry.NewRegistry()
+	cRegistry.SetCollectorLimits(collectorLimits(config))
+	for _, entityCollector := range entityCollectors {
+		cRegistry.Register(entityCollector)
+	}
+
+	slog.Info("Registry built successfully",
+		slog.Int("gpu_count", int(deviceWatchList.DeviceInfo().GPUCount())),
+		slog.Int("collector_count", len(entityCollectors)))
+
+	return cRegistry, deviceWatchListManager, nil
+}
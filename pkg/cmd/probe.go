@@ -0,0 +1,195 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/relabel"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/server"
+)
+
+const (
+	probeCommand   = "probe"
+	CLIProbeTarget = "target"
+	CLIProbeOutput = "output"
+)
+
+// newProbeCommand creates the hidden probe command, which reads the metrics of a remote hostengine
+// once and writes them to a file. go-dcgm holds a single connection per process, so every probe
+// served on /probe runs in its own process, leaving the connection of the exporter untouched.
+func newProbeCommand() *cli.Command {
+	return &cli.Command{
+		Name:   probeCommand,
+		Usage:  "Writes the metrics of a remote hostengine once",
+		Hidden: true,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     CLIProbeTarget,
+				Usage:    "Address of the remote hostengine <HOST>:<PORT>",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     CLIProbeOutput,
+				Usage:    "File to write the metrics to",
+				Required: true,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			// The flags of the exporter are set on the parent command
+			return runProbe(c.Context, c.Lineage()[1], c.String(CLIProbeTarget), c.String(CLIProbeOutput))
+		},
+	}
+}
+
+// runProbe connects to the remote hostengine at target, watches the configured counters on its
+// devices and writes one scrape of the metrics to output.
+func runProbe(ctx context.Context, c *cli.Context, target, output string) error {
+	configCtx, err := withConfigFile(c)
+	if err != nil {
+		return err
+	}
+	if err := configureLogger(configCtx); err != nil {
+		return err
+	}
+
+	config, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// The remote devices are not the devices of this node
+	config.UseRemoteHE = true
+	config.RemoteHEInfo = target
+	config.RemoteHEEndpoints = []string{target}
+	config.Kubernetes = false
+	config.NodeLabels = nil
+	config.EnableGPUBindUnbindWatch = false
+	config.DumpConfig.Enabled = false
+
+	dcgmprovider.Initialize(config)
+	defer dcgmprovider.Client().Cleanup()
+
+	queryDCPMetrics(config, 0)
+
+	cs, err := getCounters(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	reg, deviceWatchListManager, err := buildRegistry(cs, config)
+	if err != nil {
+		return err
+	}
+	defer reg.Cleanup()
+
+	if config.RelabelConfigFile != "" {
+		if err := relabel.Load(config.RelabelConfigFile); err != nil {
+			return err
+		}
+	}
+
+	metricsServer, serverCleanup, err := server.NewMetricsServer(config, deviceWatchListManager, reg)
+	if err != nil {
+		return err
+	}
+	defer serverCleanup()
+
+	var buf bytes.Buffer
+	if err := metricsServer.WriteMetrics(&buf); err != nil {
+		return err
+	}
+
+	return os.WriteFile(output, buf.Bytes(), 0o600)
+}
+
+// newProbeFunc returns the function serving /probe, which runs the probe command of this executable
+// with the flags of the exporter.
+func newProbeFunc() (server.ProbeFunc, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the executable for the probes: %w", err)
+	}
+
+	return func(ctx context.Context, target string, w io.Writer) error {
+		output, err := os.CreateTemp("", "dcgm-exporter-probe-*.prom")
+		if err != nil {
+			return err
+		}
+		outputPath := output.Name()
+		_ = output.Close()
+		defer os.Remove(outputPath)
+
+		args := append(append([]string{}, os.Args[1:]...),
+			probeCommand, "--"+CLIProbeTarget, target, "--"+CLIProbeOutput, outputPath)
+
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, executable, args...)
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			if message := strings.TrimSpace(stderr.String()); message != "" {
+				return errors.New(lastLine(message))
+			}
+			return err
+		}
+
+		metrics, err := os.Open(outputPath)
+		if err != nil {
+			return err
+		}
+		defer metrics.Close()
+
+		_, err = io.Copy(w, metrics)
+		return err
+	}, nil
+}
+
+// newProbe sets the function serving /probe on the metrics server when the probes are enabled
+func newProbe(config *appconfig.Config, metricsServer *server.MetricsServer) error {
+	if !config.ProbeEnabled {
+		return nil
+	}
+
+	probe, err := newProbeFunc()
+	if err != nil {
+		return err
+	}
+	metricsServer.SetProbe(probe)
+
+	slog.Info("Remote hostengine probes configured")
+	return nil
+}
+
+// lastLine returns the last line of the messages, which holds the reason of the failure
+func lastLine(messages string) string {
+	return messages[strings.LastIndex(messages, "\n")+1:]
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/events"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/server"
+)
+
+// Backoff of the DCGM reconnection attempts
+var (
+	dcgmReconnectRetryInterval    = time.Second
+	dcgmReconnectMaxRetryInterval = time.Minute
+)
+
+// runDCGMReconnector reconnects to DCGM whenever the connection to the hostengine is reported as lost,
+// e.g. while a remote hostengine restarts, instead of exiting. /metrics serves the self metrics only,
+// with dcgm_exporter_connection_up at 0, until the registry is rebuilt.
+func runDCGMReconnector(
+	ctx context.Context, server *server.MetricsServer, c *cli.Context, dcgmCleanup func(), wg *sync.WaitGroup,
+) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-dcgmprovider.ConnectionLost():
+				reconnectDCGM(ctx, server, c, dcgmCleanup)
+			}
+		}
+	}()
+}
+
+// reconnectDCGM resets the connection to DCGM and rebuilds the registry, retrying with an exponential
+// backoff until it succeeds or ctx is done.
+func reconnectDCGM(ctx context.Context, server *server.MetricsServer, c *cli.Context, dcgmCleanup func()) {
+	slog.WarnContext(ctx, "Connection to DCGM lost - reconnecting")
+	events.Record(events.Event{
+		Type:     events.TypeConnection,
+		Severity: events.SeverityError,
+		Message:  "Connection to DCGM lost",
+	})
+
+	retryInterval := dcgmReconnectRetryInterval
+	for attempt := 1; ; attempt++ {
+		err := handleGPUTopologyChange(ctx, server, c, dcgmCleanup, reloadTriggerConnectionLost)
+		if err == nil {
+			// The failed attempts reported the connection as lost again
+			select {
+			case <-dcgmprovider.ConnectionLost():
+			default:
+			}
+			slog.InfoContext(ctx, "Reconnected to DCGM", slog.Int("attempts", attempt))
+			events.Record(events.Event{
+				Type:       events.TypeConnection,
+				Severity:   events.SeverityInfo,
+				Message:    "Reconnected to DCGM",
+				Attributes: map[string]string{"attempts": strconv.Itoa(attempt)},
+			})
+			return
+		}
+
+		slog.WarnContext(ctx, "Failed to reconnect to DCGM",
+			slog.Int("attempt", attempt),
+			slog.Duration("retry_in", retryInterval),
+			slog.String("error", err.Error()))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+		retryInterval = min(2*retryInterval, dcgmReconnectMaxRetryInterval)
+	}
+}
@@ -18,8 +18,25 @@ package cmd
 
 import "os"
 
-// SignalSource provides signals that trigger reload or shutdown.
-// This interface allows dependency injection for testing.
+// ControlSignal is a named control event delivered through a SignalSource like an OS signal.
+// The OS never sends them; they are meant to be injected by tests and embedders, e.g. with TestSignalSource.
+type ControlSignal string
+
+func (s ControlSignal) String() string { return string(s) }
+
+func (ControlSignal) Signal() {}
+
+const (
+	// SignalReloadCounters rebuilds the registry from the counters configuration, like SIGHUP.
+	SignalReloadCounters ControlSignal = "reload-counters"
+	// SignalReconnectDCGM tears down the connection to DCGM and connects again, rebuilding the registry.
+	SignalReconnectDCGM ControlSignal = "reconnect-dcgm"
+	// SignalDump writes a diagnostic snapshot to the dump directory, like SIGUSR2.
+	SignalDump ControlSignal = "dump"
+)
+
+// SignalSource provides signals that trigger reload or shutdown, OS signals as well as ControlSignal events.
+// This interface allows dependency injection for testing and embedding.
 type SignalSource interface {
 	// Signals returns the channel that receives signals
 	Signals() <-chan os.Signal
@@ -0,0 +1,193 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/memguard"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/relabel"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/server"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/soak"
+)
+
+const (
+	CLISoakDuration      = "duration"
+	CLISoakInterval      = "interval"
+	CLISoakOutput        = "output"
+	CLISoakFormat        = "format"
+	CLISoakMaxErrorRatio = "max-error-ratio"
+
+	soakFormatText = "text"
+	soakFormatJSON = "json"
+)
+
+// newSoakCommand creates the soak command, which collects and renders the metrics at a high frequency
+// for a while and reports the scrape latencies, the memory and the errors, to validate a new driver or
+// DCGM version on a canary node before rolling it out.
+func newSoakCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "soak",
+		Usage: "Scrapes the metrics continuously and reports the latencies, the memory and the errors",
+		Flags: []cli.Flag{
+			&cli.DurationFlag{
+				Name:  CLISoakDuration,
+				Value: soak.DefaultDuration,
+				Usage: "Duration of the soak test",
+			},
+			&cli.DurationFlag{
+				Name:  CLISoakInterval,
+				Value: soak.DefaultInterval,
+				Usage: "Interval between the scrapes, also used as the collect interval",
+			},
+			&cli.StringFlag{
+				Name:    CLISoakOutput,
+				Aliases: []string{"o"},
+				Usage:   "File to write the report to (default: standard output)",
+			},
+			&cli.StringFlag{
+				Name:  CLISoakFormat,
+				Value: soakFormatText,
+				Usage: "Format of the report: text or json",
+			},
+			&cli.Float64Flag{
+				Name:  CLISoakMaxErrorRatio,
+				Usage: "Ratio of failed scrapes above which the soak test fails",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			format := c.String(CLISoakFormat)
+			if format != soakFormatText && format != soakFormatJSON {
+				return fmt.Errorf("invalid soak report format %q, expected %q or %q", format, soakFormatText,
+					soakFormatJSON)
+			}
+
+			// Interrupting the soak test still writes the report of the scrapes done
+			ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			// The flags of the exporter are set on the parent command
+			report, err := runSoak(ctx, c.Lineage()[1], soak.Options{
+				Duration:      c.Duration(CLISoakDuration),
+				Interval:      c.Duration(CLISoakInterval),
+				MaxErrorRatio: c.Float64(CLISoakMaxErrorRatio),
+				ReadRSS:       memguard.ReadRSS,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := writeSoakReport(report, c.String(CLISoakOutput), format); err != nil {
+				return err
+			}
+			if !report.Passed {
+				return fmt.Errorf("soak test failed: %d of %d scrapes failed", report.Failures, report.Scrapes)
+			}
+			return nil
+		},
+	}
+}
+
+// runSoak starts the collectors with the configuration of the exporter, collecting at the interval of
+// the soak test, and scrapes them until the soak test ends.
+func runSoak(ctx context.Context, c *cli.Context, options soak.Options) (*soak.Report, error) {
+	configCtx, err := withConfigFile(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := configureLogger(configCtx); err != nil {
+		return nil, err
+	}
+
+	config, err := contextToConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Interval <= 0 {
+		options.Interval = soak.DefaultInterval
+	}
+	config.CollectInterval = int(max(options.Interval, time.Millisecond) / time.Millisecond)
+	config.DumpConfig.Enabled = false
+
+	dcgmprovider.Initialize(config)
+	defer dcgmprovider.Client().Cleanup()
+
+	queryDCPMetrics(config, 0)
+
+	cs, err := getCounters(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	reg, deviceWatchListManager, err := buildRegistry(cs, config)
+	if err != nil {
+		return nil, err
+	}
+	defer reg.Cleanup()
+
+	if config.RelabelConfigFile != "" {
+		if err := relabel.Load(config.RelabelConfigFile); err != nil {
+			return nil, err
+		}
+	}
+
+	metricsServer, serverCleanup, err := server.NewMetricsServer(config, deviceWatchListManager, reg)
+	if err != nil {
+		return nil, err
+	}
+	defer serverCleanup()
+
+	options.Scrape = metricsServer.WriteMetrics
+	report := soak.Run(ctx, options)
+
+	slog.Info("Soak test done",
+		slog.Int("scrapes", report.Scrapes),
+		slog.Int("failures", report.Failures),
+		slog.Bool("passed", report.Passed))
+	return report, nil
+}
+
+// writeSoakReport writes the report to the output file, or to the standard output without file
+func writeSoakReport(report *soak.Report, output, format string) error {
+	var buf bytes.Buffer
+	var err error
+	if format == soakFormatJSON {
+		err = report.WriteJSON(&buf)
+	} else {
+		err = report.WriteText(&buf)
+	}
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(output, buf.Bytes(), 0o600)
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"log/slog"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/supportbundle"
+)
+
+const (
+	CLISupportBundleOutput      = "output"
+	CLISupportBundleExporterURL = "exporter-url"
+	CLISupportBundleLogFile     = "log-file"
+	CLISupportBundleTimeout     = "timeout"
+)
+
+// newSupportBundleCommand creates the support-bundle command, which gathers the state of a running
+// exporter and of the node into a tarball to attach to issues and vendor tickets.
+func newSupportBundleCommand(version string) *cli.Command {
+	return &cli.Command{
+		Name:  "support-bundle",
+		Usage: "Gathers logs, dumps, effective config, topology, versions and recent metrics into a tarball",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    CLISupportBundleOutput,
+				Aliases: []string{"o"},
+				Usage:   "Path of the tarball (default: dcgm-exporter-support-<timestamp>.tar.gz)",
+			},
+			&cli.StringFlag{
+				Name:  CLISupportBundleExporterURL,
+				Value: supportbundle.DefaultExporterURL,
+				Usage: "Address of the running exporter",
+			},
+			&cli.StringSliceFlag{
+				Name:  CLISupportBundleLogFile,
+				Usage: "Log file to include in the bundle; can be repeated",
+			},
+			&cli.DurationFlag{
+				Name:  CLISupportBundleTimeout,
+				Value: supportbundle.DefaultTimeout,
+				Usage: "Timeout of every source of the bundle",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			builder := supportbundle.NewBuilder(supportbundle.Options{
+				Output:        c.String(CLISupportBundleOutput),
+				ExporterURL:   c.String(CLISupportBundleExporterURL),
+				DumpDirectory: c.String(CLIDumpDirectory),
+				LogFiles:      c.StringSlice(CLISupportBundleLogFile),
+				Version:       version,
+				Timeout:       c.Duration(CLISupportBundleTimeout),
+			})
+
+			output, err := builder.Create(c.Context)
+			if err != nil {
+				return err
+			}
+
+			slog.Info("Support bundle written", slog.String("file", output))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,334 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package integration
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/nvmlprovider"
+	"github.com/NVIDIA/dcgm-exporter/pkg/cmd"
+)
+
+// chaosSettleTime is longer than the minimal interval between two reloads,
+// so every chaos action is guaranteed to be processed by the exporter.
+const chaosSettleTime = 3 * time.Second
+
+// errNVMLFault is returned by the NVML calls while faultyNVML fails
+var errNVMLFault = errors.New("injected fault: NVML is not available")
+
+// faultyDCGM is a DCGM client injecting faults into the calls of the exporter, the other calls go to the
+// hostengine. Once the connection is dropped, the latest values of the entities fail as when the hostengine
+// goes away, and the queued GPU bind/unbind events are served to the GPU watcher, one per poll.
+type faultyDCGM struct {
+	dcgmprovider.DCGM
+
+	connectionDropped atomic.Bool
+
+	mtx              sync.Mutex
+	bindUnbindEvents []dcgm.DcgmBindUnbindEventState
+}
+
+func (d *faultyDCGM) EntityGetLatestValues(
+	entityGroup dcgm.Field_Entity_Group, entityID uint, fields []dcgm.Short,
+) ([]dcgm.FieldValue_v1, error) {
+	if d.connectionDropped.Load() {
+		return nil, errConnectionNotValid()
+	}
+	if len(fields) == 1 && fields[0] == dcgm.DCGM_FI_BIND_UNBIND_EVENT {
+		if event, ok := d.nextBindUnbindEvent(); ok {
+			return []dcgm.FieldValue_v1{event}, nil
+		}
+	}
+	return d.DCGM.EntityGetLatestValues(entityGroup, entityID, fields)
+}
+
+func (d *faultyDCGM) LinkGetLatestValues(
+	linkID uint, parentType dcgm.Field_Entity_Group, parentID uint, fields []dcgm.Short,
+) ([]dcgm.FieldValue_v1, error) {
+	if d.connectionDropped.Load() {
+		return nil, errConnectionNotValid()
+	}
+	return d.DCGM.LinkGetLatestValues(linkID, parentType, parentID, fields)
+}
+
+func (d *faultyDCGM) queueBindUnbindEvents(events ...dcgm.DcgmBindUnbindEventState) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	d.bindUnbindEvents = append(d.bindUnbindEvents, events...)
+}
+
+func (d *faultyDCGM) nextBindUnbindEvent() (dcgm.FieldValue_v1, bool) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+
+	if len(d.bindUnbindEvents) == 0 {
+		return dcgm.FieldValue_v1{}, false
+	}
+	state := d.bindUnbindEvents[0]
+	d.bindUnbindEvents = d.bindUnbindEvents[1:]
+
+	event := dcgm.FieldValue_v1{
+		FieldID:   dcgm.DCGM_FI_BIND_UNBIND_EVENT,
+		FieldType: dcgm.DCGM_FT_INT64,
+		TS:        time.Now().UnixMicro(),
+	}
+	binary.NativeEndian.PutUint64(event.Value[:8], uint64(state))
+	return event, true
+}
+
+// errConnectionNotValid is the error of the DCGM calls once the connection to the hostengine is lost
+func errConnectionNotValid() error {
+	return fmt.Errorf("injected fault: %w", &dcgm.Error{Code: dcgm.DCGM_ST_CONNECTION_NOT_VALID})
+}
+
+// faultyNVML is an NVML client whose queries fail while failing is set, as when the driver library is
+// unloaded, e.g. during a driver upgrade.
+type faultyNVML struct {
+	nvmlprovider.NVML
+
+	failing atomic.Bool
+}
+
+func (n *faultyNVML) GetMIGDeviceInfoByID(uuid string) (*nvmlprovider.MIGDeviceInfo, error) {
+	if n.failing.Load() {
+		return nil, errNVMLFault
+	}
+	return n.NVML.GetMIGDeviceInfoByID(uuid)
+}
+
+func (n *faultyNVML) GetDeviceProcessMemory(gpuUUID string) (map[uint32]uint64, error) {
+	if n.failing.Load() {
+		return nil, errNVMLFault
+	}
+	return n.NVML.GetDeviceProcessMemory(gpuUUID)
+}
+
+func (n *faultyNVML) GetDeviceProcessUtilization(gpuUUID string) (map[uint32]uint32, error) {
+	if n.failing.Load() {
+		return nil, errNVMLFault
+	}
+	return n.NVML.GetDeviceProcessUtilization(gpuUUID)
+}
+
+func (n *faultyNVML) GetAllMIGDevicesProcessMemory(parentGPUUUID string) (map[uint]map[uint32]uint64, error) {
+	if n.failing.Load() {
+		return nil, errNVMLFault
+	}
+	return n.NVML.GetAllMIGDevicesProcessMemory(parentGPUUUID)
+}
+
+func (n *faultyNVML) GetDevices() ([]nvmlprovider.GPUDevice, error) {
+	if n.failing.Load() {
+		return nil, errNVMLFault
+	}
+	return n.NVML.GetDevices()
+}
+
+func (n *faultyNVML) GetMIGLayout(gpuUUID string) (nvmlprovider.MIGLayout, error) {
+	if n.failing.Load() {
+		return nvmlprovider.MIGLayout{}, errNVMLFault
+	}
+	return n.NVML.GetMIGLayout(gpuUUID)
+}
+
+// dcgmChaos injects DCGM and NVML faults into a running exporter through fault-injecting clients.
+type dcgmChaos struct {
+	t          *testing.T
+	metricsURL string
+}
+
+// injectDCGM wraps the current DCGM client of the exporter with a fault-injecting one. The client is replaced
+// by a new connection whenever the exporter resets DCGM, so every action injects its own.
+func (c *dcgmChaos) injectDCGM() *faultyDCGM {
+	c.t.Helper()
+	client := dcgmprovider.Client()
+	require.NotNil(c.t, client, "DCGM should be initialized")
+	faulty := &faultyDCGM{DCGM: client}
+	dcgmprovider.SetClient(faulty)
+	return faulty
+}
+
+// requireDCGMReset waits until the exporter replaced the fault-injecting client with a new connection.
+func (c *dcgmChaos) requireDCGMReset(faulty *faultyDCGM, action string) {
+	c.t.Helper()
+	require.Eventually(c.t, func() bool {
+		return dcgmprovider.Client() != dcgmprovider.DCGM(faulty)
+	}, 60*time.Second, 500*time.Millisecond, "DCGM should be reset after %s", action)
+}
+
+// dropHostengineConnection makes the DCGM calls fail as when the hostengine goes away, the collectors report
+// the connection as lost and the exporter reconnects to DCGM.
+func (c *dcgmChaos) dropHostengineConnection() {
+	c.t.Helper()
+	c.t.Log("Chaos: dropping the hostengine connection")
+	faulty := c.injectDCGM()
+	faulty.connectionDropped.Store(true)
+	c.requireDCGMReset(faulty, "a dropped hostengine connection")
+}
+
+// failNVML makes the NVML queries fail while the exporter keeps serving, then restores NVML.
+func (c *dcgmChaos) failNVML() {
+	c.t.Helper()
+	c.t.Log("Chaos: NVML failure")
+	client := nvmlprovider.Client()
+	faulty := &faultyNVML{NVML: client}
+	faulty.failing.Store(true)
+	nvmlprovider.SetClient(faulty)
+	defer nvmlprovider.SetClient(client)
+
+	time.Sleep(chaosSettleTime)
+	c.requireMetricsRecovered("an NVML failure")
+}
+
+// unbindAndBindGPU reports a GPU unbind event immediately followed by a bind event to the GPU watcher.
+func (c *dcgmChaos) unbindAndBindGPU() {
+	c.t.Helper()
+	c.t.Log("Chaos: GPU unbind/bind sequence")
+	faulty := c.injectDCGM()
+	faulty.queueBindUnbindEvents(
+		dcgm.DcgmBUEventStateSystemReinitializing,
+		dcgm.DcgmBUEventStateSystemReinitializationCompleted,
+	)
+	c.requireDCGMReset(faulty, "a GPU unbind/bind sequence")
+}
+
+// requireMetricsRecovered waits until /metrics serves parsable, non-empty metrics again.
+func (c *dcgmChaos) requireMetricsRecovered(action string) {
+	c.t.Helper()
+
+	var resp string
+	require.Eventually(c.t, func() bool {
+		r, statusCode, err := httpGet(c.t, c.metricsURL)
+		if err != nil || statusCode != 200 || len(r) == 0 {
+			return false
+		}
+		resp = r
+		return true
+	}, 60*time.Second, 500*time.Millisecond, "Metrics should not stay empty after %s", action)
+
+	var parser expfmt.TextParser
+	mf, err := parser.TextToMetricFamilies(strings.NewReader(resp))
+	require.NoError(c.t, err, "Should parse metrics after %s", action)
+	require.Greater(c.t, len(mf), 0, "Should have metrics after %s", action)
+}
+
+// TestExporterRecoversFromDCGMFlapping verifies that the exporter recovers from repeated
+// hostengine connection drops, NVML failures and GPU unbind/bind sequences without leaking goroutines.
+func TestExporterRecoversFromDCGMFlapping(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	defer goleak.VerifyNone(t,
+		goleak.IgnoreTopFunction("internal/poll.runtime_pollWait"),
+		goleak.IgnoreTopFunction("net/http.(*persistConn).writeLoop"),
+		goleak.IgnoreTopFunction("net/http.(*persistConn).readLoop"),
+	)
+
+	goroutinesBefore := runtime.NumGoroutine()
+
+	testSigs := cmd.NewTestSignalSource()
+
+	port := getRandomAvailablePort(t)
+
+	// Kubernetes virtual GPU mode makes the exporter query NVML on every scrape and reinitialize it on every
+	// reset, the GPU watcher polls the bind/unbind events
+	cliCtx := createTestCLIContext(t, "./testdata/default-counters.csv", fmt.Sprintf(":%d", port))
+	require.NoError(t, cliCtx.Set(cmd.CLIKubernetes, "true"))
+	require.NoError(t, cliCtx.Set(cmd.CLIKubernetesVirtualGPUs, "true"))
+	require.NoError(t, cliCtx.Set(cmd.CLIEnableGPUBindUnbindWatch, "true"))
+	require.NoError(t, cliCtx.Set(cmd.CLIGPUBindUnbindPollInterval, "100ms"))
+
+	appDone := make(chan error, 1)
+	go func() {
+		err := cmd.StartDCGMExporterWithSignalSource(cliCtx, testSigs)
+		appDone <- err
+	}()
+
+	var stopOnce sync.Once
+	stopExporter := func() {
+		stopOnce.Do(func() {
+			// The exporter closes the signal source when it exits on its own, e.g. without DCGM
+			select {
+			case err := <-appDone:
+				t.Logf("App already exited: %v", err)
+				return
+			default:
+			}
+			t.Log("Sending termination signal...")
+			testSigs.SendSignal(syscall.SIGTERM)
+			select {
+			case <-appDone:
+				t.Log("App shutdown completed")
+			case <-time.After(10 * time.Second):
+				t.Error("App did not shutdown within timeout")
+			}
+		})
+	}
+	defer stopExporter()
+
+	chaos := &dcgmChaos{
+		t:          t,
+		metricsURL: fmt.Sprintf("http://localhost:%d/metrics", port),
+	}
+
+	chaos.requireMetricsRecovered("startup")
+
+	actions := []struct {
+		name string
+		run  func()
+	}{
+		{name: "hostengine connection drop", run: chaos.dropHostengineConnection},
+		{name: "NVML failure", run: chaos.failNVML},
+		{name: "GPU unbind/bind sequence", run: chaos.unbindAndBindGPU},
+		{name: "second hostengine connection drop", run: chaos.dropHostengineConnection},
+	}
+
+	for _, action := range actions {
+		action.run()
+		time.Sleep(chaosSettleTime)
+		chaos.requireMetricsRecovered(action.name)
+	}
+
+	// The goroutines of the exporter, its collectors and watchers must all be gone once it is shut down
+	stopExporter()
+	http.DefaultClient.CloseIdleConnections()
+	runtime.GC()
+	time.Sleep(500 * time.Millisecond)
+
+	const maxGoroutineGrowth = 2
+	growth := runtime.NumGoroutine() - goroutinesBefore
+	assert.LessOrEqual(t, growth, maxGoroutineGrowth,
+		"Goroutines should not outlive the exporter. Growth: %d", growth)
+}
@@ -0,0 +1,290 @@
+/*
+ * Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package integration
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/go-dcgm/pkg/dcgm"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/require"
+
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/appconfig"
+	"github.com/NVIDIA/dcgm-exporter/internal/pkg/dcgmprovider"
+	"github.com/NVIDIA/dcgm-exporter/pkg/cmd"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata/golden")
+
+const (
+	goldenCountersFile = "./testdata/golden-counters.csv"
+	goldenMetricsFile  = "./testdata/golden/metrics.golden"
+	goldenFakeGPUCount = 2
+)
+
+// goldenUUIDRegex matches GPU and MIG UUIDs, which differ between machines.
+var goldenUUIDRegex = regexp.MustCompile(`(GPU|MIG)-[0-9a-fA-F]{8}(-[0-9a-fA-F]{4}){3}-[0-9a-fA-F]{12}`)
+
+// goldenVolatileLabels are labels whose values depend on the host, not on the exporter.
+// Their values are replaced by a placeholder, but the label itself must be present.
+var goldenVolatileLabels = map[string]struct{}{
+	"UUID":       {},
+	"uuid":       {},
+	"gpu_uuid":   {},
+	"pci_bus_id": {},
+	"modelName":  {},
+	"model_name": {},
+	"Hostname":   {},
+	"hostname":   {},
+}
+
+// goldenSkippedFamilyPrefixes are the families of the exporter itself, e.g. its connection and collector
+// health, and of the host, e.g. the GPUs bound for passthrough. They don't depend on the injected values.
+var goldenSkippedFamilyPrefixes = []string{
+	"dcgm_exporter_",
+	"dcgm_exp_gpu_passthrough",
+}
+
+// goldenInjectedValue is a value injected into every fake GPU before the exporter starts.
+type goldenInjectedValue struct {
+	fieldID   dcgm.Short
+	fieldType uint
+	value     any
+}
+
+var goldenInjectedValues = []goldenInjectedValue{
+	{fieldID: dcgm.DCGM_FI_DEV_GPU_TEMP, fieldType: dcgm.DCGM_FT_INT64, value: int64(62)},
+	{fieldID: dcgm.DCGM_FI_DEV_POWER_USAGE, fieldType: dcgm.DCGM_FT_DOUBLE, value: float64(150.5)},
+	{fieldID: dcgm.DCGM_FI_DEV_FB_USED, fieldType: dcgm.DCGM_FT_INT64, value: int64(1024)},
+	{fieldID: dcgm.DCGM_FI_DEV_TOTAL_ENERGY_CONSUMPTION, fieldType: dcgm.DCGM_FT_INT64, value: int64(5000000)},
+	{fieldID: dcgm.DCGM_FI_DEV_COMPUTE_MODE, fieldType: dcgm.DCGM_FT_INT64, value: int64(0)},
+}
+
+// TestMetricsMatchGoldenFile starts the exporter against fake GPUs with injected field values
+// and compares the normalized /metrics output with testdata/golden/metrics.golden.
+// Run with -update to regenerate the golden file after an intentional change of the output.
+func TestMetricsMatchGoldenFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping test in short mode.")
+	}
+
+	// DCGM must be initialized before the exporter, so we can create fake GPUs;
+	// the exporter reuses the existing client and cleans it up on shutdown.
+	dcgmprovider.Initialize(&appconfig.Config{})
+
+	gpuIDs := createFakeGPUsWithInjectedValues(t, goldenFakeGPUCount)
+
+	port := getRandomAvailablePort(t)
+
+	testSigs := cmd.NewTestSignalSource()
+
+	cliCtx := createTestCLIContext(t, goldenCountersFile, fmt.Sprintf(":%d", port))
+	require.NoError(t, cliCtx.Set(cmd.CLIUseFakeGPUs, "true"))
+	require.NoError(t, cliCtx.Set(cmd.CLINoHostname, "true"))
+	require.NoError(t, cliCtx.Set(cmd.CLIGPUDevices, cmd.MajorKey+":"+joinUints(gpuIDs)))
+
+	appDone := make(chan error, 1)
+	go func() {
+		err := cmd.StartDCGMExporterWithSignalSource(cliCtx, testSigs)
+		appDone <- err
+	}()
+
+	defer func() {
+		t.Log("Sending termination signal for cleanup...")
+		testSigs.SendSignal(syscall.SIGTERM)
+		select {
+		case <-appDone:
+			t.Log("App shutdown completed")
+		case <-time.After(10 * time.Second):
+			t.Log("Warning: App did not shutdown within timeout")
+		}
+	}()
+
+	metricsURL := fmt.Sprintf("http://localhost:%d/metrics", port)
+
+	var metricsResp string
+	require.Eventually(t, func() bool {
+		resp, _, err := httpGet(t, metricsURL)
+		if err != nil || !strings.Contains(resp, "DCGM_FI_DEV_GPU_TEMP") {
+			return false
+		}
+		metricsResp = resp
+		return true
+	}, 60*time.Second, 500*time.Millisecond, "Exporter should start and return injected metrics")
+
+	actual, err := normalizeMetrics(metricsResp, gpuIDs)
+	require.NoError(t, err)
+
+	if *updateGolden {
+		require.NoError(t, os.MkdirAll(filepath.Dir(goldenMetricsFile), 0o755))
+		require.NoError(t, os.WriteFile(goldenMetricsFile, []byte(actual), 0o644))
+		t.Logf("Golden file %s updated", goldenMetricsFile)
+		return
+	}
+
+	expected, err := os.ReadFile(goldenMetricsFile)
+	require.NoError(t, err, "Golden file is missing; run the test with -update to create it")
+	require.Equal(t, string(expected), actual,
+		"/metrics output differs from %s; if the change is intentional, run the test with -update",
+		goldenMetricsFile)
+}
+
+// createFakeGPUsWithInjectedValues creates fake GPUs and injects goldenInjectedValues into each of them.
+func createFakeGPUsWithInjectedValues(t *testing.T, count int) []uint {
+	t.Helper()
+
+	numGPUs, err := dcgmprovider.Client().GetAllDeviceCount()
+	require.NoError(t, err)
+	if numGPUs+uint(count) > dcgm.MAX_NUM_DEVICES {
+		t.Skipf("Unable to add %d fake GPUs with more than %d gpus", count, dcgm.MAX_NUM_DEVICES)
+	}
+
+	entityList := make([]dcgm.MigHierarchyInfo, count)
+	for i := range entityList {
+		entityList[i] = dcgm.MigHierarchyInfo{Entity: dcgm.GroupEntityPair{EntityGroupId: dcgm.FE_GPU}}
+	}
+
+	gpuIDs, err := dcgmprovider.Client().CreateFakeEntities(entityList)
+	require.NoError(t, err)
+	require.Len(t, gpuIDs, count)
+
+	for _, gpuID := range gpuIDs {
+		for _, v := range goldenInjectedValues {
+			err = dcgmprovider.Client().InjectFieldValue(gpuID, v.fieldID, v.fieldType, 0,
+				time.Now().UnixMicro(), v.value)
+			require.NoError(t, err)
+		}
+	}
+
+	return gpuIDs
+}
+
+// normalizeMetrics converts the /metrics response into a stable, sorted representation:
+//   - metric families and series are sorted;
+//   - the families of goldenSkippedFamilyPrefixes are dropped;
+//   - sample timestamps are dropped;
+//   - GPU and MIG UUIDs, and host specific labels are replaced with placeholders;
+//   - GPU indices of the fake GPUs are replaced with their ordinal number, so the
+//     output doesn't depend on the number of real GPUs on the machine.
+func normalizeMetrics(resp string, gpuIDs []uint) (string, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(strings.NewReader(resp))
+	if err != nil {
+		return "", err
+	}
+
+	gpuOrdinals := make(map[string]string, len(gpuIDs))
+	for i, id := range gpuIDs {
+		gpuOrdinals[strconv.FormatUint(uint64(id), 10)] = strconv.Itoa(i)
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		if isGoldenSkippedFamily(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		family := families[name]
+		sb.WriteString(fmt.Sprintf("# TYPE %s %s\n", name, strings.ToLower(family.GetType().String())))
+
+		series := make([]string, 0, len(family.GetMetric()))
+		for _, m := range family.GetMetric() {
+			series = append(series, fmt.Sprintf("%s{%s} %s", name,
+				normalizeLabels(m.GetLabel(), gpuOrdinals), normalizeValue(family.GetType(), m)))
+		}
+		slices.Sort(series)
+
+		for _, s := range series {
+			sb.WriteString(s)
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String(), nil
+}
+
+func normalizeLabels(labels []*io_prometheus_client.LabelPair, gpuOrdinals map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for _, l := range labels {
+		name, value := l.GetName(), l.GetValue()
+		switch {
+		case isGoldenVolatileLabel(name):
+			value = "<" + strings.ToUpper(name) + ">"
+		case name == "gpu":
+			if ordinal, ok := gpuOrdinals[value]; ok {
+				value = ordinal
+			}
+		case name == "device" && strings.HasPrefix(value, "nvidia"):
+			if ordinal, ok := gpuOrdinals[strings.TrimPrefix(value, "nvidia")]; ok {
+				value = "nvidia" + ordinal
+			}
+		default:
+			value = goldenUUIDRegex.ReplaceAllString(value, "<UUID>")
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%q", name, value))
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func isGoldenVolatileLabel(name string) bool {
+	_, ok := goldenVolatileLabels[name]
+	return ok
+}
+
+func isGoldenSkippedFamily(name string) bool {
+	return slices.ContainsFunc(goldenSkippedFamilyPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(name, prefix)
+	})
+}
+
+func normalizeValue(metricType io_prometheus_client.MetricType, m *io_prometheus_client.Metric) string {
+	var v float64
+	switch metricType {
+	case io_prometheus_client.MetricType_COUNTER:
+		v = m.GetCounter().GetValue()
+	case io_prometheus_client.MetricType_GAUGE:
+		v = m.GetGauge().GetValue()
+	default:
+		v = m.GetUntyped().GetValue()
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func joinUints(values []uint) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.FormatUint(uint64(v), 10)
+	}
+	return strings.Join(s, ",")
+}